@@ -15,6 +15,12 @@ type MachinePoolPlatform struct {
 	// eg. m4-large
 	InstanceType string `json:"type"`
 
+	// FallbackInstanceTypes is an ordered list of instance types to fall back to when AWS does not have sufficient
+	// capacity of the instance type in an availability zone. The MachineSet for that availability zone is switched
+	// to the next instance type in the list, and keeps using that instance type afterwards.
+	// +optional
+	FallbackInstanceTypes []string `json:"fallbackInstanceTypes,omitempty"`
+
 	// EC2RootVolume defines the storage for ec2 instance.
 	EC2RootVolume `json:"rootVolume"`
 
@@ -1,5 +1,9 @@
 package azure
 
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
 // MachinePool stores the configuration for a machine pool installed
 // on Azure.
 type MachinePool struct {
@@ -13,6 +17,21 @@ type MachinePool struct {
 
 	// OSDisk defines the storage for instance.
 	OSDisk `json:"osDisk"`
+
+	// SpotVMOptions allows users to configure instances to be run using Azure Spot VMs.
+	// +optional
+	SpotVMOptions *SpotVMOptions `json:"spotVMOptions,omitempty"`
+}
+
+// SpotVMOptions defines the options available to a user when configuring
+// Machines to run on Azure Spot VMs.
+// Most users should provide an empty struct.
+type SpotVMOptions struct {
+	// MaxPrice is the maximum price per hour the user is willing to pay for the Spot VMs.
+	// A value of -1 caps the price at the pay-as-you-go price.
+	// Default: pay-as-you-go price
+	// +optional
+	MaxPrice *resource.Quantity `json:"maxPrice,omitempty"`
 }
 
 // OSDisk defines the disk for machines on Azure.
@@ -38,4 +57,8 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.OSDisk.DiskSizeGB != 0 {
 		a.OSDisk.DiskSizeGB = required.OSDisk.DiskSizeGB
 	}
+
+	if required.SpotVMOptions != nil {
+		a.SpotVMOptions = required.SpotVMOptions
+	}
 }
@@ -24,6 +24,38 @@ type Platform struct {
 	// If empty, the value is equal to "AzurePublicCloud".
 	// +optional
 	CloudName CloudEnvironment `json:"cloudName,omitempty"`
+
+	// UserTags specifies additional tags for Azure resources created for the cluster.
+	// +optional
+	UserTags map[string]string `json:"userTags,omitempty"`
+
+	// PrivateLink allows users to enable access to the cluster's API server using Azure
+	// Private Link. Azure Private Link includes a pair of Private Link Service and Private
+	// Endpoint accross Azure subscriptions and allows clients to connect to services using
+	// Azure's internal networking instead of the Internet.
+	// +optional
+	PrivateLink *PrivateLinkAccess `json:"privateLink,omitempty"`
+}
+
+// PlatformStatus contains the observed state on Azure platform.
+type PlatformStatus struct {
+	PrivateLink *PrivateLinkAccessStatus `json:"privateLink,omitempty"`
+}
+
+// PrivateLinkAccess configures access to the cluster API using Azure Private Link
+type PrivateLinkAccess struct {
+	Enabled bool `json:"enabled"`
+}
+
+// PrivateLinkAccessStatus contains the observed state for PrivateLinkAccess resources.
+// All the resources are identified by their Azure resource IDs.
+type PrivateLinkAccessStatus struct {
+	// +optional
+	PrivateLinkService string `json:"privateLinkService,omitempty"`
+	// +optional
+	PrivateEndpoint string `json:"privateEndpoint,omitempty"`
+	// +optional
+	PrivateDNSZone string `json:"privateDNSZone,omitempty"`
 }
 
 // CloudEnvironment is the name of the Azure cloud environment
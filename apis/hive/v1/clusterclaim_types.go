@@ -32,6 +32,13 @@ type ClusterClaimSpec struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// Priority determines the order in which the cluster pool assigns clusters to pending claims. Claims with a
+	// higher priority are assigned a cluster before claims with a lower priority. Claims with the same priority
+	// are assigned a cluster in the order in which they were created.
+	// Defaults to 0. May be negative.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // ClusterClaimStatus defines the observed state of ClusterClaim.
@@ -44,6 +51,12 @@ type ClusterClaimStatus struct {
 	// when the lifetime has elapsed, the claim will be deleted by Hive.
 	// +optional
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// QueuePosition is the position of the claim in the queue of claims waiting for a cluster from the pool.
+	// A claim at position 1 is assigned the next cluster that becomes ready, so this is one more than the
+	// number of claims ahead of this claim. Cleared once a cluster has been assigned to the claim.
+	// +optional
+	QueuePosition *int32 `json:"queuePosition,omitempty"`
 }
 
 // ClusterClaimCondition contains details for the current condition of a cluster claim.
@@ -87,6 +100,8 @@ const (
 // +kubebuilder:printcolumn:name="Pending",type="string",JSONPath=".status.conditions[?(@.type=='Pending')].reason"
 // +kubebuilder:printcolumn:name="ClusterNamespace",type="string",JSONPath=".spec.namespace"
 // +kubebuilder:printcolumn:name="ClusterRunning",type="string",JSONPath=".status.conditions[?(@.type=='ClusterRunning')].reason"
+// +kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority",priority=1
+// +kubebuilder:printcolumn:name="QueuePosition",type="integer",JSONPath=".status.queuePosition",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type ClusterClaim struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -3,6 +3,7 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/openshift/hive/apis/hive/v1/agent"
 	"github.com/openshift/hive/apis/hive/v1/aws"
@@ -186,10 +187,22 @@ type ClusterDeploymentSpec struct {
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// HibernationSchedule resumes and hibernates the cluster at the times given by the schedule. At each
+	// scheduled transition the PowerState is set accordingly; between transitions the PowerState may be
+	// changed freely (for example by HibernateAfter) until the next transition occurs.
+	// Pool clusters wait until they're claimed for HibernationSchedule to have effect.
+	// +optional
+	HibernationSchedule *HibernationSchedule `json:"hibernationSchedule,omitempty"`
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
 
+	// ProvisionRetryPolicy configures the delay between install attempts and the changes of the install config
+	// for successive install attempts.
+	// +optional
+	ProvisionRetryPolicy *ProvisionRetryPolicy `json:"provisionRetryPolicy,omitempty"`
+
 	// BoundServiceAccountSignkingKeySecretRef refers to a Secret that contains a
 	// 'bound-service-account-signing-key.key' data key pointing to the private
 	// key that will be used to sign ServiceAccount objects. Primarily used to
@@ -198,6 +211,50 @@ type ClusterDeploymentSpec struct {
 	BoundServiceAccountSignkingKeySecretRef *corev1.LocalObjectReference `json:"boundServiceAccountSigningKeySecretRef,omitempty"`
 }
 
+// ProvisionRetryPolicy configures how the failed provisions of a cluster are retried.
+type ProvisionRetryPolicy struct {
+	// InitialBackoff is the delay between the failure of the first install attempt and the second install attempt.
+	// Defaults to 1m.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// MaxBackoff is the maximum delay between the failure of an install attempt and the next install attempt.
+	// Defaults to 24h.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// BackoffFactor is the factor by which the delay grows after each failed install attempt. Defaults to 2.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	BackoffFactor *int32 `json:"backoffFactor,omitempty"`
+
+	// InstallConfigOverrides is an ordered list of changes of the install config for successive install attempts.
+	// The first install attempt uses the install config as is, the second install attempt uses the first override,
+	// and so on. Once the list is exhausted, the remaining install attempts use the last override.
+	// +optional
+	InstallConfigOverrides []InstallConfigOverride `json:"installConfigOverrides,omitempty"`
+}
+
+// InstallConfigOverride is a change of the install config for an install attempt.
+type InstallConfigOverride struct {
+	// Name identifies the override in the ClusterProvisions of the install attempts that use it.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// Patch is a JSON merge patch (RFC 7386) applied to the install config, for example to use other zones,
+	// instance types or regions. When the patch changes the region, the region of the install attempt is recorded in
+	// the ClusterProvision and the region of the cluster in the platform status of the ClusterDeployment.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Patch runtime.RawExtension `json:"patch"`
+}
+
 // ClusterInstallLocalReference provides reference to an object that implements
 // the hivecontract ClusterInstall. The namespace of the object is same as the
 // ClusterDeployment.
@@ -209,6 +266,24 @@ type ClusterInstallLocalReference struct {
 	Name string `json:"name"`
 }
 
+// HibernationSchedule defines when a cluster should be running and when it should be hibernating.
+type HibernationSchedule struct {
+	// Resume is a cron expression (minute hour day-of-month month day-of-week) giving the times at
+	// which the cluster should be moved to the Running power state. For example, "0 8 * * 1-5".
+	// +required
+	Resume string `json:"resume"`
+
+	// Hibernate is a cron expression (minute hour day-of-month month day-of-week) giving the times at
+	// which the cluster should be moved to the Hibernating power state. For example, "0 19 * * 1-5".
+	// +required
+	Hibernate string `json:"hibernate"`
+
+	// TimeZone is the IANA time zone name (e.g. "Europe/Berlin") in which Resume and Hibernate are
+	// evaluated. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 // Provisioning contains settings used only for initial cluster provisioning.
 type Provisioning struct {
 	// InstallConfigSecretRef is the reference to a secret that contains an openshift-install
@@ -324,6 +399,11 @@ type ClusterDeploymentStatus struct {
 	// +optional
 	CertificateBundles []CertificateBundleStatus `json:"certificateBundles,omitempty"`
 
+	// AdminKubeconfigRotation is the status of the service account kubeconfig Hive uses to connect to the cluster
+	// in place of the admin kubeconfig generated by the installer.
+	// +optional
+	AdminKubeconfigRotation *AdminKubeconfigRotationStatus `json:"adminKubeconfigRotation,omitempty"`
+
 	// TODO: Use of *Timestamp fields here is slightly off from latest API conventions,
 	// should use InstalledTime instead if we ever get to a V2 of the API.
 
@@ -341,12 +421,31 @@ type ClusterDeploymentStatus struct {
 	// +optional
 	ProvisionRef *corev1.LocalObjectReference `json:"provisionRef,omitempty"`
 
+	// HibernationSchedule contains the observed state of the HibernationSchedule, if one is set.
+	// +optional
+	HibernationSchedule *HibernationScheduleStatus `json:"hibernationSchedule,omitempty"`
+
 	// Platform contains the observed state for the specific platform upon which to
 	// perform the installation.
 	// +optional
 	Platform *PlatformStatus `json:"platformStatus,omitempty"`
 }
 
+// HibernationScheduleStatus contains the observed state of a ClusterDeployment's HibernationSchedule.
+type HibernationScheduleStatus struct {
+	// LastTransitionTime is the time of the most recent scheduled transition that has been handled.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// NextTransitionTime is the time of the next scheduled transition.
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+
+	// NextPowerState is the power state the cluster will be moved to at NextTransitionTime.
+	// +optional
+	NextPowerState ClusterPowerState `json:"nextPowerState,omitempty"`
+}
+
 // ClusterDeploymentCondition contains details for the current condition of a cluster deployment
 type ClusterDeploymentCondition struct {
 	// Type is the type of the condition.
@@ -446,6 +545,34 @@ const (
 	// for the cluster.
 	AWSPrivateLinkFailedClusterDeploymentCondition ClusterDeploymentConditionType = "AWSPrivateLinkFailed"
 
+	// GCPPrivateServiceConnectReadyClusterDeploymentCondition is true when private service connect access has been
+	// setup for the cluster.
+	GCPPrivateServiceConnectReadyClusterDeploymentCondition ClusterDeploymentConditionType = "GCPPrivateServiceConnectReady"
+
+	// GCPPrivateServiceConnectFailedClusterDeploymentCondition is true controller fails to setup private service
+	// connect access for the cluster.
+	GCPPrivateServiceConnectFailedClusterDeploymentCondition ClusterDeploymentConditionType = "GCPPrivateServiceConnectFailed"
+
+	// AzurePrivateLinkReadyClusterDeploymentCondition is true when private link access has been
+	// setup for the cluster.
+	AzurePrivateLinkReadyClusterDeploymentCondition ClusterDeploymentConditionType = "AzurePrivateLinkReady"
+
+	// AzurePrivateLinkFailedClusterDeploymentCondition is true controller fails to setup private link access
+	// for the cluster.
+	AzurePrivateLinkFailedClusterDeploymentCondition ClusterDeploymentConditionType = "AzurePrivateLinkFailed"
+
+	// CertificateBundleIssuanceFailedClusterDeploymentCondition is true when the controller fails to issue or
+	// renew the certificate bundles that are generated for the cluster.
+	CertificateBundleIssuanceFailedClusterDeploymentCondition ClusterDeploymentConditionType = "CertificateBundleIssuanceFailed"
+
+	// CertificateExpiringClusterDeploymentCondition is true when the admin kubeconfig client certificate or the
+	// certificate of a certificate bundle of the cluster has expired or expires within the warning window.
+	CertificateExpiringClusterDeploymentCondition ClusterDeploymentConditionType = "CertificateExpiring"
+
+	// AdminKubeconfigRotationFailedClusterDeploymentCondition is true when the controller fails to create or rotate
+	// the service account kubeconfig used to connect to the cluster, or to revoke the installer admin kubeconfig.
+	AdminKubeconfigRotationFailedClusterDeploymentCondition ClusterDeploymentConditionType = "AdminKubeconfigRotationFailed"
+
 	// These are conditions that are copied from ClusterInstall on to the ClusterDeployment object.
 	ClusterInstallFailedClusterDeploymentCondition          ClusterDeploymentConditionType = "ClusterInstallFailed"
 	ClusterInstallCompletedClusterDeploymentCondition       ClusterDeploymentConditionType = "ClusterInstallCompleted"
@@ -461,6 +588,8 @@ var PositivePolarityClusterDeploymentConditions = []ClusterDeploymentConditionTy
 	ClusterHibernatingCondition,
 	ClusterReadyCondition,
 	AWSPrivateLinkReadyClusterDeploymentCondition,
+	GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+	AzurePrivateLinkReadyClusterDeploymentCondition,
 	ClusterInstallCompletedClusterDeploymentCondition,
 	ClusterInstallRequirementsMetClusterDeploymentCondition,
 	RequirementsMetCondition,
@@ -612,6 +741,17 @@ type Platform struct {
 type PlatformStatus struct {
 	// AWS is the observed state on AWS.
 	AWS *aws.PlatformStatus `json:"aws,omitempty"`
+
+	// Azure is the observed state on Azure.
+	Azure *azure.PlatformStatus `json:"azure,omitempty"`
+
+	// GCP is the observed state on GCP.
+	GCP *gcp.PlatformStatus `json:"gcp,omitempty"`
+
+	// Region is the region the cluster is installed in, if an install config override of the ProvisionRetryPolicy
+	// changed it from the region of the platform.
+	// +optional
+	Region string `json:"region,omitempty"`
 }
 
 // ClusterIngress contains the configurable pieces for any ClusterIngress objects
@@ -707,6 +847,35 @@ type CertificateBundleStatus struct {
 
 	// Generated indicates whether the certificate bundle was generated
 	Generated bool `json:"generated"`
+
+	// ACMEOrderURL is the URL of the ACME order in progress to issue or renew the certificate bundle.
+	// +optional
+	ACMEOrderURL string `json:"acmeOrderURL,omitempty"`
+
+	// NotAfter is the time at which the certificate of the certificate bundle expires.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// AdminKubeconfigRotationStatus is the status of the service account kubeconfig Hive uses to connect to the cluster.
+type AdminKubeconfigRotationStatus struct {
+	// KubeconfigSecretRef references the secret containing the service account kubeconfig.
+	KubeconfigSecretRef corev1.LocalObjectReference `json:"kubeconfigSecretRef"`
+
+	// TokenSecretName is the name of the service account token secret on the cluster used by the kubeconfig.
+	TokenSecretName string `json:"tokenSecretName"`
+
+	// LastRotationTime is the time the kubeconfig was last rotated.
+	LastRotationTime metav1.Time `json:"lastRotationTime"`
+
+	// LastRotationRequest is the value of the hive.openshift.io/rotate-admin-kubeconfig annotation when the
+	// kubeconfig was last rotated.
+	// +optional
+	LastRotationRequest string `json:"lastRotationRequest,omitempty"`
+
+	// InstallerKubeconfigRevoked is true once the admin kubeconfig generated by the installer has been revoked.
+	// +optional
+	InstallerKubeconfigRevoked bool `json:"installerKubeconfigRevoked,omitempty"`
 }
 
 // RelocateStatus is the status of a cluster relocate.
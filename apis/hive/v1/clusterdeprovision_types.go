@@ -32,6 +32,29 @@ type ClusterDeprovisionStatus struct {
 	// Conditions includes more detailed status for the cluster deprovision
 	// +optional
 	Conditions []ClusterDeprovisionCondition `json:"conditions,omitempty"`
+
+	// ResourcesRemaining is the number of cloud resources that were still to be deleted at the end of the
+	// last deprovision attempt made by the controller.
+	// +optional
+	ResourcesRemaining int `json:"resourcesRemaining,omitempty"`
+
+	// BlockingResources lists, per resource type, the cloud resources that were still to be deleted at
+	// the end of the last deprovision attempt made by the controller.
+	// +optional
+	BlockingResources []DeprovisionResourceStatus `json:"blockingResources,omitempty"`
+}
+
+// DeprovisionResourceStatus is the deprovision progress for a type of cloud resource.
+type DeprovisionResourceStatus struct {
+	// Type is the type of the cloud resource, such as "ec2:instance".
+	Type string `json:"type"`
+
+	// Remaining is the number of resources of this type still to be deleted.
+	Remaining int `json:"remaining"`
+
+	// LastError is the last error seen while deleting resources of this type.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
 }
 
 // ClusterDeprovisionPlatform contains platform-specific configuration for the
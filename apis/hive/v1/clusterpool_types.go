@@ -17,6 +17,7 @@ type ClusterPoolSpec struct {
 	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
 
 	// Size is the default number of clusters that we should keep provisioned and waiting for use.
+	// Ignored when Autoscaling is set.
 	// +kubebuilder:validation:Minimum=0
 	// +required
 	Size int32 `json:"size"`
@@ -81,6 +82,10 @@ type ClusterPoolSpec struct {
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
 
+	// ProvisionRetryPolicy configures how the failed provisions of the ClusterDeployments of the pool are retried.
+	// +optional
+	ProvisionRetryPolicy *ProvisionRetryPolicy `json:"provisionRetryPolicy,omitempty"`
+
 	// SkipMachinePools allows creating clusterpools where the machinepools are not managed by hive after cluster creation
 	// +optional
 	SkipMachinePools bool `json:"skipMachinePools,omitempty"`
@@ -92,6 +97,61 @@ type ClusterPoolSpec struct {
 	// HibernationConfig configures the hibernation/resume behavior of ClusterDeployments owned by the ClusterPool.
 	// +optional
 	HibernationConfig *HibernationConfig `json:"hibernationConfig"`
+
+	// Autoscaling configures the pool to adjust the number of clusters kept waiting for use to the demand for
+	// clusters. When set, Size is ignored in favor of the size computed by the autoscaler, which is reported in
+	// Status.Autoscaling.
+	// +optional
+	Autoscaling *ClusterPoolAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// ClusterPoolAutoscaling configures the autoscaling of a ClusterPool. The autoscaler sizes the pool to the number of
+// claims observed during the demand window plus the target headroom, within the bounds of MinSize and MaxSize.
+// Claims that are still pending do not count towards the demand, as the pool creates a cluster for each of them on
+// top of its size regardless of autoscaling.
+type ClusterPoolAutoscaling struct {
+	// MinSize is the minimum number of clusters to keep provisioned and waiting for use.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MinSize int32 `json:"minSize"`
+
+	// MaxSize is the maximum number of clusters to keep provisioned and waiting for use. Unlike Spec.MaxSize, this
+	// does not include claimed clusters.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MaxSize int32 `json:"maxSize"`
+
+	// TargetHeadroom is the number of clusters to keep waiting for use in addition to the observed demand.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TargetHeadroom int32 `json:"targetHeadroom,omitempty"`
+
+	// DemandWindow is how far back claims are counted to determine the demand for clusters. It should cover the time
+	// it takes to install a cluster, so that the pool holds enough clusters to satisfy the claims expected while
+	// replacements are installing.
+	// Defaults to 1h.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// Note: due to discrepancies in validation vs parsing, we use a Pattern instead of `Format=duration`. See
+	// https://bugzilla.redhat.com/show_bug.cgi?id=2050332
+	// https://github.com/kubernetes/apimachinery/issues/131
+	// https://github.com/kubernetes/apiextensions-apiserver/issues/56
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	DemandWindow *metav1.Duration `json:"demandWindow,omitempty"`
+
+	// ScaleDownCooldown is the minimum amount of time between the last time the autoscaler changed the size of the
+	// pool and the autoscaler reducing the size of the pool. Increases of the size of the pool are not delayed.
+	// Defaults to 30m.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// Note: due to discrepancies in validation vs parsing, we use a Pattern instead of `Format=duration`. See
+	// https://bugzilla.redhat.com/show_bug.cgi?id=2050332
+	// https://github.com/kubernetes/apimachinery/issues/131
+	// https://github.com/kubernetes/apiextensions-apiserver/issues/56
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	ScaleDownCooldown *metav1.Duration `json:"scaleDownCooldown,omitempty"`
 }
 
 type HibernationConfig struct {
@@ -108,6 +168,11 @@ type HibernationConfig struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	ResumeTimeout metav1.Duration `json:"resumeTimeout"`
+
+	// Schedule is copied to the HibernationSchedule of each ClusterDeployment created by the pool. It takes
+	// effect once the ClusterDeployment has been claimed.
+	// +optional
+	Schedule *HibernationSchedule `json:"schedule,omitempty"`
 }
 
 // ClusterPoolClaimLifetime defines the lifetimes for claims for the cluster pool.
@@ -152,6 +217,24 @@ type ClusterPoolStatus struct {
 	// Conditions includes more detailed status for the cluster pool
 	// +optional
 	Conditions []ClusterPoolCondition `json:"conditions,omitempty"`
+
+	// Autoscaling is the state of the autoscaler. Only set when Spec.Autoscaling is set.
+	// +optional
+	Autoscaling *ClusterPoolAutoscalingStatus `json:"autoscaling,omitempty"`
+}
+
+// ClusterPoolAutoscalingStatus is the state of the autoscaler of a ClusterPool.
+type ClusterPoolAutoscalingStatus struct {
+	// Size is the number of clusters the autoscaler keeps provisioned and waiting for use.
+	Size int32 `json:"size"`
+
+	// Demand is the number of claims counted by the autoscaler when it last computed the size: the claims created
+	// during the demand window that are no longer pending.
+	Demand int32 `json:"demand"`
+
+	// LastScaleTime is the last time the autoscaler changed the size of the pool.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 }
 
 // ClusterPoolCondition contains details for the current condition of a cluster pool
@@ -47,6 +47,21 @@ type ClusterProvisionSpec struct {
 
 	// PrevProvisionName is the name of the previous failed provision attempt.
 	PrevProvisionName *string `json:"prevProvisionName,omitempty"`
+
+	// PrevRegion is the region of the previous failed provision attempt, if it is not the region of the
+	// ClusterDeployment.
+	// +optional
+	PrevRegion *string `json:"prevRegion,omitempty"`
+
+	// InstallConfigOverride is the name of the install config override of the ProvisionRetryPolicy of the
+	// ClusterDeployment used by this provision attempt. Not set when the install config is used as is.
+	// +optional
+	InstallConfigOverride *string `json:"installConfigOverride,omitempty"`
+
+	// Region is the region of this provision attempt, if the install config override changes it from the region of
+	// the ClusterDeployment.
+	// +optional
+	Region *string `json:"region,omitempty"`
 }
 
 // ClusterProvisionStatus defines the observed state of ClusterProvision.
@@ -57,8 +72,44 @@ type ClusterProvisionStatus struct {
 	// Conditions includes more detailed status for the cluster provision
 	// +optional
 	Conditions []ClusterProvisionCondition `json:"conditions,omitempty"`
+
+	// FailureClassification is the classification of the install failure of a failed provision.
+	// +optional
+	FailureClassification *InstallFailureClassification `json:"failureClassification,omitempty"`
 }
 
+// InstallFailureClassification is the classification of an install failure found by scanning the install log.
+type InstallFailureClassification struct {
+	// Reason is the single word CamelCase reason of the install failure.
+	Reason string `json:"reason"`
+	// Category is the category of the install failure.
+	Category InstallFailureCategory `json:"category"`
+	// Retryable is true if the install failure may not happen again on a new provision attempt.
+	Retryable bool `json:"retryable"`
+	// Remediation is a hint to fix the cause of the install failure.
+	// +optional
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// InstallFailureCategory is the category of an install failure.
+// +kubebuilder:validation:Enum=Quota;Network;Credentials;Transient;Configuration;Unknown
+type InstallFailureCategory string
+
+const (
+	// InstallFailureCategoryQuota is for install failures caused by exhausted cloud quotas or limits.
+	InstallFailureCategoryQuota InstallFailureCategory = "Quota"
+	// InstallFailureCategoryNetwork is for install failures caused by the network of the cluster.
+	InstallFailureCategoryNetwork InstallFailureCategory = "Network"
+	// InstallFailureCategoryCredentials is for install failures caused by missing or insufficient cloud credentials.
+	InstallFailureCategoryCredentials InstallFailureCategory = "Credentials"
+	// InstallFailureCategoryTransient is for install failures caused by temporary conditions, such as throttling or timeouts.
+	InstallFailureCategoryTransient InstallFailureCategory = "Transient"
+	// InstallFailureCategoryConfiguration is for install failures caused by an invalid install config or cloud account setup.
+	InstallFailureCategoryConfiguration InstallFailureCategory = "Configuration"
+	// InstallFailureCategoryUnknown is for install failures whose cause is not known.
+	InstallFailureCategoryUnknown InstallFailureCategory = "Unknown"
+)
+
 // ClusterProvisionStage is the stage of provisioning.
 type ClusterProvisionStage string
 
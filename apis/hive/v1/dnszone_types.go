@@ -46,6 +46,10 @@ type DNSZoneSpec struct {
 	// Azure specifes Azure-specific cloud configuration
 	// +optional
 	Azure *AzureDNSZoneSpec `json:"azure,omitempty"`
+
+	// RFC2136 specifies the configuration for a zone hosted on a DNS server that accepts RFC2136 dynamic updates
+	// +optional
+	RFC2136 *RFC2136DNSZoneSpec `json:"rfc2136,omitempty"`
 }
 
 // AWSDNSZoneSpec contains AWS-specific DNSZone specifications
@@ -87,6 +91,10 @@ type GCPDNSZoneSpec struct {
 	// Secret should have a key named 'osServiceAccount.json'.
 	// The credentials must specify the project to use.
 	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// AdditionalLabels is a map of additional labels to apply to the GCP managed zone when it is created.
+	// +optional
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
 }
 
 // AzureDNSZoneSpec contains Azure-specific DNSZone specifications
@@ -105,6 +113,25 @@ type AzureDNSZoneSpec struct {
 	// If empty, the value is equal to "AzurePublicCloud".
 	// +optional
 	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
+
+	// AdditionalTags is a map of additional tags to apply to the Azure DNS zone when it is created.
+	// +optional
+	AdditionalTags map[string]string `json:"additionalTags,omitempty"`
+}
+
+// RFC2136DNSZoneSpec contains the specifications for a DNSZone hosted on a DNS server that accepts
+// RFC2136 dynamic updates. Zones cannot be created with dynamic updates, so the zone must already
+// be configured on the DNS server.
+type RFC2136DNSZoneSpec struct {
+	// Nameserver is the address of the DNS server hosting the zone, as host or host:port.
+	// The port defaults to 53.
+	Nameserver string `json:"nameserver"`
+
+	// TSIGSecretRef references a secret containing the TSIG key used to authenticate dynamic updates
+	// and zone transfers with the DNS server.
+	// Secret should have keys named 'tsig_key_name' and 'tsig_secret', and may have a key named
+	// 'tsig_algorithm'. The algorithm defaults to hmac-sha256.
+	TSIGSecretRef corev1.LocalObjectReference `json:"tsigSecretRef"`
 }
 
 // DNSZoneStatus defines the observed state of DNSZone
@@ -13,6 +13,11 @@ type MachinePool struct {
 	//
 	// +optional
 	OSDisk OSDisk `json:"osDisk"`
+
+	// Preemptible allows users to configure instances to be run using GCP preemptible VMs. Preemptible VMs are
+	// cheaper, but GCP may stop them at any time and always stops them after they have run for 24 hours.
+	// +optional
+	Preemptible bool `json:"preemptible,omitempty"`
 }
 
 // OSDisk defines the disk for machines on GCP.
@@ -13,4 +13,61 @@ type Platform struct {
 
 	// Region specifies the GCP region where the cluster will be created.
 	Region string `json:"region"`
+
+	// UserLabels specifies additional labels for GCP resources created for the cluster.
+	// +optional
+	UserLabels map[string]string `json:"userLabels,omitempty"`
+
+	// PrivateServiceConnect allows users to enable access to the cluster's API server using GCP
+	// Private Service Connect. GCP Private Service Connect includes a pair of service attachment and
+	// endpoint across GCP projects and allows clients to connect to services using GCP's internal
+	// networking instead of the Internet.
+	// +optional
+	PrivateServiceConnect *PrivateServiceConnectAccess `json:"privateServiceConnect,omitempty"`
+}
+
+// PlatformStatus contains the observed state on GCP platform.
+type PlatformStatus struct {
+	PrivateServiceConnect *PrivateServiceConnectAccessStatus `json:"privateServiceConnect,omitempty"`
+}
+
+// PrivateServiceConnectAccess configures access to the cluster API using GCP Private Service Connect
+type PrivateServiceConnectAccess struct {
+	Enabled bool `json:"enabled"`
+
+	// ServiceAttachmentSubnet configures the subnet of the cluster's network that the service attachment
+	// uses to translate the source addresses of the connections from the endpoint.
+	// When not set, a subnet with the default CIDR is created for the service attachment.
+	// +optional
+	ServiceAttachmentSubnet *ServiceAttachmentSubnet `json:"serviceAttachmentSubnet,omitempty"`
+}
+
+// ServiceAttachmentSubnet configures the subnet used by the service attachment of the cluster.
+type ServiceAttachmentSubnet struct {
+	// CIDR is the IP range of the subnet created for the service attachment. It must not overlap
+	// with any other subnet in the cluster's network.
+	// Defaults to 192.168.0.0/29.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+
+	// Existing is the name of an existing subnet with the PRIVATE_SERVICE_CONNECT purpose in the
+	// cluster's network and region to use instead of creating one.
+	// +optional
+	Existing string `json:"existing,omitempty"`
+}
+
+// PrivateServiceConnectAccessStatus contains the observed state for PrivateServiceConnectAccess resources.
+type PrivateServiceConnectAccessStatus struct {
+	// +optional
+	ServiceAttachmentSubnet string `json:"serviceAttachmentSubnet,omitempty"`
+	// +optional
+	ServiceAttachmentFirewall string `json:"serviceAttachmentFirewall,omitempty"`
+	// +optional
+	ServiceAttachment string `json:"serviceAttachment,omitempty"`
+	// +optional
+	EndpointAddress string `json:"endpointAddress,omitempty"`
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// +optional
+	DNSZone string `json:"dnsZone,omitempty"`
 }
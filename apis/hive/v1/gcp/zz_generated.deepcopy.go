@@ -105,6 +105,18 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.UserLabels != nil {
+		in, out := &in.UserLabels, &out.UserLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PrivateServiceConnect != nil {
+		in, out := &in.PrivateServiceConnect, &out.PrivateServiceConnect
+		*out = new(PrivateServiceConnectAccess)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -117,3 +129,77 @@ func (in *Platform) DeepCopy() *Platform {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+	*out = *in
+	if in.PrivateServiceConnect != nil {
+		in, out := &in.PrivateServiceConnect, &out.PrivateServiceConnect
+		*out = new(PrivateServiceConnectAccessStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateServiceConnectAccess) DeepCopyInto(out *PrivateServiceConnectAccess) {
+	*out = *in
+	if in.ServiceAttachmentSubnet != nil {
+		in, out := &in.ServiceAttachmentSubnet, &out.ServiceAttachmentSubnet
+		*out = new(ServiceAttachmentSubnet)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateServiceConnectAccess.
+func (in *PrivateServiceConnectAccess) DeepCopy() *PrivateServiceConnectAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateServiceConnectAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateServiceConnectAccessStatus) DeepCopyInto(out *PrivateServiceConnectAccessStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateServiceConnectAccessStatus.
+func (in *PrivateServiceConnectAccessStatus) DeepCopy() *PrivateServiceConnectAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateServiceConnectAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAttachmentSubnet) DeepCopyInto(out *ServiceAttachmentSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAttachmentSubnet.
+func (in *ServiceAttachmentSubnet) DeepCopy() *ServiceAttachmentSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAttachmentSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
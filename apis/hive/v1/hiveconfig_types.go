@@ -73,6 +73,12 @@ type HiveConfigSpec struct {
 	// DeprovisionsDisabled can be set to true to block deprovision jobs from running.
 	DeprovisionsDisabled *bool `json:"deprovisionsDisabled,omitempty"`
 
+	// DeprovisionInController can be set to true to delete the cloud resources of clusters from within the
+	// clusterdeprovision controller rather than from an uninstall job. Only AWS, GCP and Azure support this. Clusters
+	// on other platforms are deprovisioned by an uninstall job regardless.
+	// +optional
+	DeprovisionInController *bool `json:"deprovisionInController,omitempty"`
+
 	// DeleteProtection can be set to "enabled" to turn on automatic delete protection for ClusterDeployments. When
 	// enabled, Hive will add the "hive.openshift.io/protected-delete" annotation to new ClusterDeployments. Once a
 	// ClusterDeployment has been installed, a user must remove the annotation from a ClusterDeployment prior to
@@ -99,6 +105,46 @@ type HiveConfigSpec struct {
 	// 3. A list of VPCs that should be able to resolve the DNS addresses setup for Private Link.
 	AWSPrivateLink *AWSPrivateLinkConfig `json:"awsPrivateLink,omitempty"`
 
+	// GCPPrivateServiceConnect defines the configuration for the gcp-private-service-connect controller.
+	// For each ClusterDeployment with Private Service Connect enabled, the controller publishes the
+	// internal API load balancer of the cluster through a service attachment in the project of the
+	// cluster, and consumes it from an endpoint in a subnet of the inventory in the region of the
+	// cluster, using the hub project credentials. A private DNS zone for the API domain of the cluster,
+	// visible from the network of the endpoint and the associated networks, resolves to the endpoint.
+	// +optional
+	GCPPrivateServiceConnect *GCPPrivateServiceConnectConfig `json:"gcpPrivateServiceConnect,omitempty"`
+
+	// AzurePrivateLink defines the configuration for the azure-private-link controller.
+	// For each ClusterDeployment with Private Link enabled, the controller creates a Private Link
+	// Service for the internal API load balancer of the cluster in the subscription of the cluster,
+	// and a Private Endpoint for it in a VNet of the inventory in the region of the cluster, using the
+	// hub subscription credentials. The Private Link Service only accepts connections from the
+	// subscriptions of the inventory VNets. A private DNS zone for the API domain of the cluster, linked
+	// to the VNet of the Private Endpoint and the associated VNets, resolves to the Private Endpoint.
+	// +optional
+	AzurePrivateLink *AzurePrivateLinkConfig `json:"azurePrivateLink,omitempty"`
+
+	// ACMEIssuer defines the configuration for the acme-issuer controller, which issues and renews the
+	// certificates of the certificate bundles of ClusterDeployments that set generate to true, using an ACME
+	// server such as Let's Encrypt. The DNS-01 challenges are answered using the managed DNSZone of the
+	// cluster, so only ClusterDeployments with manageDNS enabled are supported.
+	// +optional
+	ACMEIssuer *ACMEIssuerConfig `json:"acmeIssuer,omitempty"`
+
+	// CertificateExpiryWarningWindow is how long before the admin kubeconfig client certificate or the
+	// certificate of a certificate bundle of a ClusterDeployment expires that the CertificateExpiring
+	// condition is raised on the ClusterDeployment. Defaults to 14 days.
+	// +optional
+	CertificateExpiryWarningWindow *metav1.Duration `json:"certificateExpiryWarningWindow,omitempty"`
+
+	// AdminKubeconfigRotation defines the configuration for the admin-kubeconfig-rotation controller. When set, Hive
+	// creates a service account with cluster-admin access on each installed cluster and connects to the cluster with
+	// a kubeconfig for it instead of the admin kubeconfig generated by the installer. The token of the service account
+	// is rotated periodically, and when the hive.openshift.io/rotate-admin-kubeconfig annotation of the
+	// ClusterDeployment changes.
+	// +optional
+	AdminKubeconfigRotation *AdminKubeconfigRotationConfig `json:"adminKubeconfigRotation,omitempty"`
+
 	// ReleaseImageVerificationConfigMapRef is a reference to the ConfigMap that
 	// will be used to verify release images.
 	//
@@ -232,6 +278,128 @@ type AWSPrivateLinkSubnet struct {
 	AvailabilityZone string `json:"availabilityZone"`
 }
 
+// GCPPrivateServiceConnectConfig defines the configuration for the gcp-private-service-connect controller.
+type GCPPrivateServiceConnectConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// GCP for creating the resources for GCP Private Service Connect.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// EndpointVPCInventory is a list of networks and the corresponding subnets in various GCP regions.
+	// The controller uses this list to choose a subnet for creating GCP Private Service Connect endpoints.
+	// Since the endpoints must be in the same region as the ClusterDeployment, we must have subnets in that
+	// region to be able to setup Private Service Connect.
+	EndpointVPCInventory []GCPPrivateServiceConnectInventory `json:"endpointVPCInventory,omitempty"`
+
+	// AssociatedNetworks is the list of URLs of networks that should be able to resolve the DNS addresses
+	// setup for Private Service Connect, in the form
+	// https://www.googleapis.com/compute/v1/projects/{project}/global/networks/{network}.
+	// The network of the chosen endpoint is always able to resolve them.
+	//
+	// This list should at minimum include the network where the current Hive controller is running.
+	// +optional
+	AssociatedNetworks []string `json:"associatedNetworks,omitempty"`
+}
+
+// GCPPrivateServiceConnectInventory is a network and its corresponding subnets in the project of the
+// GCPPrivateServiceConnectConfig credentials.
+// This network will be used to create a GCP Private Service Connect endpoint whenever there is a
+// service attachment created for a ClusterDeployment.
+type GCPPrivateServiceConnectInventory struct {
+	Network string                           `json:"network"`
+	Subnets []GCPPrivateServiceConnectSubnet `json:"subnets"`
+}
+
+// GCPPrivateServiceConnectSubnet defines a subnet of a GCP network in a region.
+type GCPPrivateServiceConnectSubnet struct {
+	Subnet string `json:"subnet"`
+	Region string `json:"region"`
+}
+
+// AzurePrivateLinkConfig defines the configuration for the azure-private-link controller.
+type AzurePrivateLinkConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Azure for creating the resources for Azure Private Link.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// CloudName is the name of the Azure cloud environment of the CredentialsSecretRef credentials.
+	// If empty, the value is equal to "AzurePublicCloud".
+	// +optional
+	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
+
+	// EndpointVNetInventory is a list of VNets and the corresponding subnets in various Azure regions.
+	// The controller uses this list to choose a VNet for creating Azure Private Endpoints. Since the
+	// Private Endpoints must be in the same region as the ClusterDeployment, we must have VNets in that
+	// region to be able to setup Private Link.
+	EndpointVNetInventory []AzurePrivateLinkInventory `json:"endpointVNetInventory,omitempty"`
+
+	// AssociatedVNets is the list of resource IDs of VNets that should be able to resolve the DNS
+	// addresses setup for Private Link, in the form
+	// /subscriptions/{subscription}/resourceGroups/{resourceGroup}/providers/Microsoft.Network/virtualNetworks/{vnet}.
+	// The VNet of the chosen Private Endpoint is always able to resolve them.
+	//
+	// This list should at minimum include the VNet where the current Hive controller is running.
+	// +optional
+	AssociatedVNets []string `json:"associatedVNets,omitempty"`
+}
+
+// AzurePrivateLinkInventory is a VNet and its corresponding subnets in an Azure region.
+// This VNet will be used to create an Azure Private Endpoint whenever there is a Private Link
+// Service created for a ClusterDeployment.
+type AzurePrivateLinkInventory struct {
+	AzurePrivateLinkVNet `json:",inline"`
+	Subnets              []AzurePrivateLinkSubnet `json:"subnets"`
+}
+
+// AzurePrivateLinkVNet defines an Azure VNet in a region.
+type AzurePrivateLinkVNet struct {
+	// VNetID is the resource ID of the VNet, in the form
+	// /subscriptions/{subscription}/resourceGroups/{resourceGroup}/providers/Microsoft.Network/virtualNetworks/{vnet}.
+	VNetID string `json:"vnetID"`
+	Region string `json:"region"`
+}
+
+// AzurePrivateLinkSubnet defines a subnet in an Azure VNet.
+type AzurePrivateLinkSubnet struct {
+	Name string `json:"name"`
+}
+
+// ACMEIssuerConfig defines the configuration for the acme-issuer controller.
+type ACMEIssuerConfig struct {
+	// DirectoryURL is the URL of the directory of the ACME server.
+	// If empty, the Let's Encrypt production server is used.
+	// +optional
+	DirectoryURL string `json:"directoryURL,omitempty"`
+
+	// Email is the contact email of the ACME account, used by the ACME server to send notices such as
+	// expiration warnings.
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// AccountKeySecretRef references a secret in the TargetNamespace with the PEM-encoded ECDSA P-256 private
+	// key of the ACME account in the "tls.key" key. The controller creates the secret with a new key if it
+	// does not exist.
+	AccountKeySecretRef corev1.LocalObjectReference `json:"accountKeySecretRef"`
+
+	// RenewBefore is how long before the expiry of a certificate the controller renews it.
+	// If unset, certificates are renewed 30 days before they expire.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// AdminKubeconfigRotationConfig defines the configuration for the admin-kubeconfig-rotation controller.
+type AdminKubeconfigRotationConfig struct {
+	// RotationInterval is how often the token of the service account Hive uses to connect to clusters is rotated.
+	// If unset, the token is rotated every 30 days.
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+
+	// RevokeInstallerKubeconfig revokes the client certificate of the admin kubeconfig generated by the installer
+	// once Hive connects to the cluster with the service account, by replacing the CA the cluster trusts for it.
+	// The admin kubeconfig secret of the ClusterDeployment can no longer be used to access the cluster afterwards.
+	// +optional
+	RevokeInstallerKubeconfig bool `json:"revokeInstallerKubeconfig,omitempty"`
+}
+
 // ServiceProviderCredentials is used to configure credentials related to being a service provider on
 // various cloud platforms.
 type ServiceProviderCredentials struct {
@@ -390,10 +558,24 @@ type FailedProvisionConfig struct {
 	// DEPRECATED: This flag is no longer respected and will be removed in the future.
 	SkipGatherLogs bool                      `json:"skipGatherLogs,omitempty"`
 	AWS            *FailedProvisionAWSConfig `json:"aws,omitempty"`
+	// GCP contains settings to upload the logs of failed installations to Google Cloud Storage.
+	// Only one of AWS, GCP, Azure and S3Compatible may be set.
+	// +optional
+	GCP *FailedProvisionGCPConfig `json:"gcp,omitempty"`
+	// Azure contains settings to upload the logs of failed installations to Azure Blob Storage.
+	// Only one of AWS, GCP, Azure and S3Compatible may be set.
+	// +optional
+	Azure *FailedProvisionAzureConfig `json:"azure,omitempty"`
+	// S3Compatible contains settings to upload the logs of failed installations to an S3-compatible object store
+	// such as MinIO.
+	// Only one of AWS, GCP, Azure and S3Compatible may be set.
+	// +optional
+	S3Compatible *FailedProvisionS3CompatibleConfig `json:"s3Compatible,omitempty"`
 	// RetryReasons is a list of installFailingReason strings from the [additional-]install-log-regexes ConfigMaps.
-	// If specified, Hive will only retry a failed installation if it results in one of the listed reasons. If
-	// omitted (not the same thing as empty!), Hive will retry regardless of the failure reason. (The total number
-	// of install attempts is still constrained by ClusterDeployment.Spec.InstallAttemptsLimit.)
+	// If specified, Hive will only retry a failed installation if it results in one of the listed reasons, whatever
+	// the classification of its failure. If omitted (not the same thing as empty!), Hive will retry a failed
+	// installation only if the classification of its failure is retryable. (The total number of install attempts
+	// is still constrained by ClusterDeployment.Spec.InstallAttemptsLimit.)
 	RetryReasons *[]string `json:"retryReasons,omitempty"`
 }
 
@@ -416,6 +598,10 @@ type ManageDNSConfig struct {
 	// +optional
 	Azure *ManageDNSAzureConfig `json:"azure,omitempty"`
 
+	// RFC2136 contains settings for external DNS hosted on a DNS server that accepts RFC2136 dynamic updates
+	// +optional
+	RFC2136 *ManageDNSRFC2136Config `json:"rfc2136,omitempty"`
+
 	// As other cloud providers are supported, additional fields will be
 	// added for each of those cloud providers. Only a single cloud provider
 	// may be configured at a time.
@@ -445,6 +631,62 @@ type FailedProvisionAWSConfig struct {
 	Bucket string `json:"bucket,omitempty"`
 }
 
+// FailedProvisionGCPConfig contains GCP-specific info to upload log files.
+type FailedProvisionGCPConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Google Cloud Storage. It will need permission to create objects in the bucket.
+	// Secret should have a key named 'osServiceAccount.json'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Bucket is the Google Cloud Storage bucket to store the logs in.
+	Bucket string `json:"bucket"`
+}
+
+// FailedProvisionAzureConfig contains Azure-specific info to upload log files.
+type FailedProvisionAzureConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Azure Blob Storage. The service principal will need permission to write blobs in the container, for example
+	// with the Storage Blob Data Contributor role.
+	// Secret should have a key named 'osServicePrincipal.json'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// StorageAccount is the name of the Azure storage account to store the logs in.
+	StorageAccount string `json:"storageAccount"`
+
+	// Container is the name of the blob container in the storage account to store the logs in.
+	Container string `json:"container"`
+
+	// CloudName is the name of the Azure cloud environment which can be used to configure the Azure SDK
+	// with the appropriate Azure API endpoints.
+	// If empty, the value is equal to "AzurePublicCloud".
+	// +optional
+	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
+}
+
+// FailedProvisionS3CompatibleConfig contains info to upload log files to an S3-compatible object store.
+type FailedProvisionS3CompatibleConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// the object store. It will need permission to upload objects to the bucket.
+	// Secret should have keys named aws_access_key_id and aws_secret_access_key that contain the credentials.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// ServiceEndpoint is the url of the S3 API of the object store, for example https://minio.example.com:9000.
+	ServiceEndpoint string `json:"serviceEndpoint"`
+
+	// Region is the region to use for S3 operations.
+	// This defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Bucket is the bucket to store the logs in.
+	Bucket string `json:"bucket"`
+
+	// ForcePathStyle makes the bucket part of the path of the object URLs (http://endpoint/bucket/key) instead of
+	// the host name (http://bucket.endpoint/key). Most S3-compatible object stores require path-style addressing.
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+}
+
 // ManageDNSAWSConfig contains AWS-specific info to manage a given domain.
 type ManageDNSAWSConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -496,6 +738,21 @@ type ManageDNSAzureConfig struct {
 	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
 }
 
+// ManageDNSRFC2136Config contains the info to manage a given domain on a DNS server that accepts
+// RFC2136 dynamic updates. The zones of the managed domains must be configured on the DNS server.
+type ManageDNSRFC2136Config struct {
+	// Nameserver is the address of the DNS server hosting the zones of the managed domains, as host
+	// or host:port. The port defaults to 53.
+	Nameserver string `json:"nameserver"`
+
+	// TSIGSecretRef references a secret in the TargetNamespace containing the TSIG key used to authenticate
+	// dynamic updates and zone transfers with the DNS server. It will need to be allowed to update the zones of
+	// the managed domains listed in the parent ManageDNSConfig object.
+	// Secret should have keys named 'tsig_key_name' and 'tsig_secret', and may have a key named
+	// 'tsig_algorithm'. The algorithm defaults to hmac-sha256.
+	TSIGSecretRef corev1.LocalObjectReference `json:"tsigSecretRef"`
+}
+
 // ControllerConfig contains the configuration for a controller
 type ControllerConfig struct {
 	// ConcurrentReconciles specifies number of concurrent reconciles for a controller
@@ -519,7 +776,7 @@ type ControllerConfig struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync;selectorsyncsetrollout;gcpprivateserviceconnect;azureprivatelink;acmeissuer;certificateexpiry;adminkubeconfigrotation
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -542,33 +799,39 @@ func (c ControllerNames) Contains(controllerName ControllerName) bool {
 
 // WARNING: All the controller names below should also be added to the kubebuilder validation of the type ControllerName
 const (
-	ClusterClaimControllerName         ControllerName = "clusterclaim"
-	ClusterDeploymentControllerName    ControllerName = "clusterDeployment"
-	ClusterDeprovisionControllerName   ControllerName = "clusterDeprovision"
-	ClusterpoolControllerName          ControllerName = "clusterpool"
-	ClusterpoolNamespaceControllerName ControllerName = "clusterpoolnamespace"
-	ClusterProvisionControllerName     ControllerName = "clusterProvision"
-	ClusterRelocateControllerName      ControllerName = "clusterRelocate"
-	ClusterStateControllerName         ControllerName = "clusterState"
-	ClusterVersionControllerName       ControllerName = "clusterversion"
-	ControlPlaneCertsControllerName    ControllerName = "controlPlaneCerts"
-	DNSEndpointControllerName          ControllerName = "dnsendpoint"
-	DNSZoneControllerName              ControllerName = "dnszone"
-	FakeClusterInstallControllerName   ControllerName = "fakeclusterinstall"
-	HibernationControllerName          ControllerName = "hibernation"
-	RemoteIngressControllerName        ControllerName = "remoteingress"
-	SyncIdentityProviderControllerName ControllerName = "syncidentityprovider"
-	UnreachableControllerName          ControllerName = "unreachable"
-	VeleroBackupControllerName         ControllerName = "velerobackup"
-	MetricsControllerName              ControllerName = "metrics"
-	ClustersyncControllerName          ControllerName = "clustersync"
-	AWSPrivateLinkControllerName       ControllerName = "awsprivatelink"
-	HiveControllerName                 ControllerName = "hive"
+	ClusterClaimControllerName             ControllerName = "clusterclaim"
+	ClusterDeploymentControllerName        ControllerName = "clusterDeployment"
+	ClusterDeprovisionControllerName       ControllerName = "clusterDeprovision"
+	ClusterpoolControllerName              ControllerName = "clusterpool"
+	ClusterpoolNamespaceControllerName     ControllerName = "clusterpoolnamespace"
+	ClusterProvisionControllerName         ControllerName = "clusterProvision"
+	ClusterRelocateControllerName          ControllerName = "clusterRelocate"
+	ClusterStateControllerName             ControllerName = "clusterState"
+	ClusterVersionControllerName           ControllerName = "clusterversion"
+	ControlPlaneCertsControllerName        ControllerName = "controlPlaneCerts"
+	DNSEndpointControllerName              ControllerName = "dnsendpoint"
+	DNSZoneControllerName                  ControllerName = "dnszone"
+	FakeClusterInstallControllerName       ControllerName = "fakeclusterinstall"
+	HibernationControllerName              ControllerName = "hibernation"
+	RemoteIngressControllerName            ControllerName = "remoteingress"
+	SyncIdentityProviderControllerName     ControllerName = "syncidentityprovider"
+	UnreachableControllerName              ControllerName = "unreachable"
+	VeleroBackupControllerName             ControllerName = "velerobackup"
+	MetricsControllerName                  ControllerName = "metrics"
+	ClustersyncControllerName              ControllerName = "clustersync"
+	AWSPrivateLinkControllerName           ControllerName = "awsprivatelink"
+	GCPPrivateServiceConnectControllerName ControllerName = "gcpprivateserviceconnect"
+	AzurePrivateLinkControllerName         ControllerName = "azureprivatelink"
+	ACMEIssuerControllerName               ControllerName = "acmeissuer"
+	CertificateExpiryControllerName        ControllerName = "certificateexpiry"
+	AdminKubeconfigRotationControllerName  ControllerName = "adminkubeconfigrotation"
+	HiveControllerName                     ControllerName = "hive"
 
 	// DeprecatedRemoteMachinesetControllerName was deprecated but can be used to disable the
 	// MachinePool controller which supercedes it for compatability.
 	DeprecatedRemoteMachinesetControllerName ControllerName = "remotemachineset"
 	MachinePoolControllerName                ControllerName = "machinepool"
+	SelectorSyncSetRolloutControllerName     ControllerName = "selectorsyncsetrollout"
 )
 
 // SpecificControllerConfig contains the configuration for a specific controller
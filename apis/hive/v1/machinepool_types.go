@@ -3,6 +3,7 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/azure"
@@ -55,6 +56,29 @@ type MachinePoolSpec struct {
 	// This list will overwrite any modifications made to Node taints on an ongoing basis.
 	// +optional
 	Taints []corev1.Taint `json:"taints,omitempty"`
+
+	// RollingUpdate is the strategy for replacing the existing Machines when the platform of the machine pool
+	// changes. The platform of the machine pool can only be changed when RollingUpdate is set.
+	// +optional
+	RollingUpdate *MachinePoolRollingUpdate `json:"rollingUpdate,omitempty"`
+}
+
+// MachinePoolRollingUpdate details how the outdated Machines of a machine pool are replaced. The outdated Machines of
+// each MachineSet are cordoned and deleted a few at a time, and the MachineSet creates their replacements.
+type MachinePoolRollingUpdate struct {
+	// MaxSurge is the maximum number of Machines that can be created above the replicas of a MachineSet while its
+	// outdated Machines are replaced. It can be an absolute number or a percentage of the replicas of the MachineSet,
+	// which is rounded up. MaxSurge is not used when the machine pool is auto-scaled.
+	// Defaults to 1.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the maximum number of Machines of a MachineSet that can be unavailable while its outdated
+	// Machines are replaced. It can be an absolute number or a percentage of the replicas of the MachineSet, which is
+	// rounded down. MaxUnavailable must not be 0 when MaxSurge is 0 or the machine pool is auto-scaled.
+	// Defaults to 0.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // MachinePoolAutoscaling details how the machine pool is to be auto-scaled.
@@ -91,6 +115,11 @@ type MachinePoolStatus struct {
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
 
+	// UpdatedReplicas is the number of Machines of the machine pool that have the current platform configuration.
+	// It is only set when the machine pool has a rolling update strategy.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
 	// MachineSets is the status of the machine sets for the machine pool on the remote cluster.
 	MachineSets []MachineSetStatus `json:"machineSets,omitempty"`
 
@@ -119,6 +148,16 @@ type MachineSetStatus struct {
 	// MaxReplicas is the maximum number of replicas for the machine set.
 	MaxReplicas int32 `json:"maxReplicas"`
 
+	// UpdatedReplicas is the number of Machines of the machine set that have the current platform configuration. It
+	// is only set when the machine pool has a rolling update strategy.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// InstanceType is the instance type chosen for the machine set. It is only set for platforms that support
+	// falling back to other instance types.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
 	// In the event that there is a terminal problem reconciling the
 	// replicas, both ErrorReason and ErrorMessage will be set. ErrorReason
 	// will be populated with a succinct value suitable for machine
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProvisioningQuotaSpec defines the desired state of ProvisioningQuota
+type ProvisioningQuotaSpec struct {
+	// CredentialsSecretRef refers to a cloud credentials secret. The quota applies to the ClusterDeployments whose
+	// credentials secret has the same data as the referenced secret, which includes the copies of the secret made
+	// for the clusters of ClusterPools. When not set, the quota applies regardless of the credentials.
+	// +optional
+	CredentialsSecretRef *corev1.SecretReference `json:"credentialsSecretRef,omitempty"`
+
+	// Region limits the quota to the ClusterDeployments in the region. When not set, the quota applies regardless of
+	// the region.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// MaxConcurrent is the maximum number of ClusterDeployments matching the quota that can be provisioning at the
+	// same time. ClusterDeployments that would exceed the quota wait for a provision to finish before starting their
+	// own.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrent int32 `json:"maxConcurrent"`
+}
+
+// ProvisioningQuotaStatus defines the observed state of ProvisioningQuota
+type ProvisioningQuotaStatus struct {
+	// Reservations are the ClusterDeployments recently admitted by the quota. A reservation counts against the quota
+	// until the provision of its ClusterDeployment is observed, so that ClusterDeployments admitted at the same time
+	// cannot exceed the quota.
+	// +optional
+	Reservations []ProvisioningQuotaReservation `json:"reservations,omitempty"`
+}
+
+// ProvisioningQuotaReservation is a ClusterDeployment admitted by a ProvisioningQuota to start provisioning.
+type ProvisioningQuotaReservation struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// ReservationTime is when the ClusterDeployment was admitted.
+	ReservationTime metav1.Time `json:"reservationTime"`
+}
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProvisioningQuota limits the number of ClusterDeployments that provision at the same time with the same cloud
+// credentials and/or in the same region.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.region"
+// +kubebuilder:printcolumn:name="MaxConcurrent",type="integer",JSONPath=".spec.maxConcurrent"
+// +kubebuilder:resource:path=provisioningquotas,scope=Cluster
+type ProvisioningQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProvisioningQuotaSpec   `json:"spec,omitempty"`
+	Status ProvisioningQuotaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProvisioningQuotaList contains a list of ProvisioningQuota
+type ProvisioningQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProvisioningQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProvisioningQuota{}, &ProvisioningQuotaList{})
+}
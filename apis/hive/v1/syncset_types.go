@@ -23,7 +23,7 @@ const (
 
 // SyncSetApplyBehavior is a string representing the behavior to use when
 // aplying a syncset to target cluster.
-// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate
+// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate;ServerSideApply
 type SyncSetApplyBehavior string
 
 const (
@@ -42,6 +42,13 @@ const (
 	// is not added to the target resource with the "lastApplied" value. It allows
 	// for syncing larger resources, but loses the ability to sync map entry deletes.
 	CreateOrUpdateSyncSetApplyBehavior SyncSetApplyBehavior = "CreateOrUpdate"
+
+	// ServerSideApplySyncSetApplyBehavior results in resources getting applied to
+	// the target cluster using server-side apply, with hive as the field manager.
+	// Ownership of fields is tracked by the API server rather than by a "lastApplied"
+	// annotation, so large resources can be synced, and fields owned by other
+	// field managers, such as operators in the target cluster, are left alone.
+	ServerSideApplySyncSetApplyBehavior SyncSetApplyBehavior = "ServerSideApply"
 )
 
 // SyncSetPatchApplyMode is a string representing the mode with which to apply
@@ -231,8 +238,31 @@ type SyncSetCommonSpec struct {
 	// the use of the 'oc apply' command, allowing larger resources to be synced, but losing
 	// some functionality of the 'oc apply' command such as the ability to remove annotations,
 	// labels, and other map entries in general.
+	// A value of "ServerSideApply" indicates that the resource will be applied using
+	// server-side apply with hive as the field manager.
 	// +optional
 	ApplyBehavior SyncSetApplyBehavior `json:"applyBehavior,omitempty"`
+
+	// ForceConflicts, when true, makes hive take ownership of fields that are owned by
+	// other field managers in the target cluster when applying resources, rather than
+	// failing the apply with a conflict. This may only be set when the ApplyBehavior
+	// is "ServerSideApply".
+	// +optional
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
+
+	// DryRun, when true, prevents the resources and secrets in this syncset from being applied to the target
+	// cluster. Instead, a server-side dry run is performed against the target cluster, and the change that would
+	// have been made to each resource is recorded in the status of the ClusterSync for the cluster.
+	// Patches are not applied, nor evaluated, in dry-run mode.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// EnableTemplates, when true, evaluates the string values in the resources, the patches, and the secret
+	// mappings of this syncset as Go templates before they are applied to the target cluster. The templates are
+	// evaluated with variables from the ClusterDeployment of the target cluster, such as {{ .ClusterName }},
+	// {{ .InfraID }}, {{ .Region }} and {{ index .Labels "key" }}.
+	// +optional
+	EnableTemplates bool `json:"enableTemplates,omitempty"`
 }
 
 // SelectorSyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along
@@ -245,6 +275,42 @@ type SelectorSyncSetSpec struct {
 	// applies to in any namespace.
 	// +optional
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector,omitempty"`
+
+	// Rollout is the strategy for rolling out changes to the SelectorSyncSet to the clusters
+	// that it applies to. When not set, changes are applied to all of the clusters at once.
+	// +optional
+	Rollout *SelectorSyncSetRollout `json:"rollout,omitempty"`
+}
+
+// SelectorSyncSetRollout is a strategy for rolling out each generation of a SelectorSyncSet
+// progressively. The generation is first applied to the canary clusters, and then to batches
+// of the remaining clusters. Clusters that the generation has not been rolled out to yet keep
+// the resources from the generation that was last applied to them.
+type SelectorSyncSetRollout struct {
+	// CanarySelector is a LabelSelector indicating which clusters the generation is applied
+	// to first. The rollout proceeds to the batches once the generation has been applied to
+	// all of the canary clusters.
+	// +optional
+	CanarySelector *metav1.LabelSelector `json:"canarySelector,omitempty"`
+
+	// BatchPercent is the percentage of the clusters that the generation is rolled out to in
+	// each batch.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	BatchPercent int32 `json:"batchPercent"`
+
+	// PauseBetweenBatches is how long to wait, once the generation has been applied to all of
+	// the clusters in the rollout so far, before rolling out the next batch.
+	// +optional
+	PauseBetweenBatches *metav1.Duration `json:"pauseBetweenBatches,omitempty"`
+
+	// MaxFailurePercent is the percentage of the clusters in the rollout so far that may fail
+	// to apply the generation before the rollout is halted. Defaults to 0, which halts the
+	// rollout on any failure. A halted rollout resumes with the next generation.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxFailurePercent int32 `json:"maxFailurePercent,omitempty"`
 }
 
 // SyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along with
@@ -265,6 +331,65 @@ type SyncSetStatus struct {
 
 // SelectorSyncSetStatus defines the observed state of a SelectorSyncSet
 type SelectorSyncSetStatus struct {
+	// Rollout is the progress of rolling out the current generation of the SelectorSyncSet. This
+	// is only set when the SelectorSyncSet has a rollout strategy.
+	// +optional
+	Rollout *SelectorSyncSetRolloutStatus `json:"rollout,omitempty"`
+}
+
+// SelectorSyncSetRolloutPhase is the phase of the rollout of a SelectorSyncSet.
+// +kubebuilder:validation:Enum=Canary;Progressing;Halted;Complete
+type SelectorSyncSetRolloutPhase string
+
+const (
+	// CanarySelectorSyncSetRolloutPhase is the phase when the generation is being applied to the
+	// canary clusters.
+	CanarySelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Canary"
+
+	// ProgressingSelectorSyncSetRolloutPhase is the phase when the generation is being applied
+	// to batches of clusters.
+	ProgressingSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Progressing"
+
+	// HaltedSelectorSyncSetRolloutPhase is the phase when the rollout has been stopped because
+	// too many clusters failed to apply the generation.
+	HaltedSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Halted"
+
+	// CompleteSelectorSyncSetRolloutPhase is the phase when the generation has been rolled out
+	// to all of the clusters.
+	CompleteSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Complete"
+)
+
+// SelectorSyncSetRolloutStatus is the progress of rolling out a generation of a SelectorSyncSet.
+type SelectorSyncSetRolloutStatus struct {
+	// ObservedGeneration is the generation of the SelectorSyncSet that is being rolled out.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// Phase is the phase of the rollout.
+	Phase SelectorSyncSetRolloutPhase `json:"phase"`
+
+	// Percent is the percentage of the clusters, in addition to the canary clusters, that the
+	// generation may be applied to.
+	Percent int32 `json:"percent"`
+
+	// Clusters is the number of clusters that the SelectorSyncSet applies to.
+	Clusters int32 `json:"clusters"`
+
+	// RolledOutClusters is the number of clusters that the generation may be applied to.
+	RolledOutClusters int32 `json:"rolledOutClusters"`
+
+	// UpdatedClusters is the number of clusters that have successfully applied the generation.
+	UpdatedClusters int32 `json:"updatedClusters"`
+
+	// FailedClusters is the number of clusters that have failed to apply the generation.
+	FailedClusters int32 `json:"failedClusters"`
+
+	// LastBatchTime is the time when the generation was last rolled out to more clusters.
+	// +optional
+	LastBatchTime *metav1.Time `json:"lastBatchTime,omitempty"`
+
+	// Message is a human-readable message describing the progress of the rollout.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
@@ -21,8 +21,31 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerConfig) DeepCopyInto(out *ACMEIssuerConfig) {
+	*out = *in
+	out.AccountKeySecretRef = in.AccountKeySecretRef
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerConfig.
+func (in *ACMEIssuerConfig) DeepCopy() *ACMEIssuerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSAssociatedVPC) DeepCopyInto(out *AWSAssociatedVPC) {
 	*out = *in
@@ -237,6 +260,45 @@ func (in *AWSServiceProviderCredentials) DeepCopy() *AWSServiceProviderCredentia
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminKubeconfigRotationConfig) DeepCopyInto(out *AdminKubeconfigRotationConfig) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminKubeconfigRotationConfig.
+func (in *AdminKubeconfigRotationConfig) DeepCopy() *AdminKubeconfigRotationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminKubeconfigRotationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminKubeconfigRotationStatus) DeepCopyInto(out *AdminKubeconfigRotationStatus) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	in.LastRotationTime.DeepCopyInto(&out.LastRotationTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminKubeconfigRotationStatus.
+func (in *AdminKubeconfigRotationStatus) DeepCopy() *AdminKubeconfigRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminKubeconfigRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDConfig) DeepCopyInto(out *ArgoCDConfig) {
 	*out = *in
@@ -283,6 +345,13 @@ func (in *AzureClusterDeprovision) DeepCopy() *AzureClusterDeprovision {
 func (in *AzureDNSZoneSpec) DeepCopyInto(out *AzureDNSZoneSpec) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -312,6 +381,89 @@ func (in *AzureDNSZoneStatus) DeepCopy() *AzureDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateLinkConfig) DeepCopyInto(out *AzurePrivateLinkConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.EndpointVNetInventory != nil {
+		in, out := &in.EndpointVNetInventory, &out.EndpointVNetInventory
+		*out = make([]AzurePrivateLinkInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AssociatedVNets != nil {
+		in, out := &in.AssociatedVNets, &out.AssociatedVNets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkConfig.
+func (in *AzurePrivateLinkConfig) DeepCopy() *AzurePrivateLinkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateLinkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateLinkInventory) DeepCopyInto(out *AzurePrivateLinkInventory) {
+	*out = *in
+	out.AzurePrivateLinkVNet = in.AzurePrivateLinkVNet
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]AzurePrivateLinkSubnet, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkInventory.
+func (in *AzurePrivateLinkInventory) DeepCopy() *AzurePrivateLinkInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateLinkInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateLinkSubnet) DeepCopyInto(out *AzurePrivateLinkSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkSubnet.
+func (in *AzurePrivateLinkSubnet) DeepCopy() *AzurePrivateLinkSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateLinkSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateLinkVNet) DeepCopyInto(out *AzurePrivateLinkVNet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkVNet.
+func (in *AzurePrivateLinkVNet) DeepCopy() *AzurePrivateLinkVNet {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateLinkVNet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
 	*out = *in
@@ -370,6 +522,10 @@ func (in *CertificateBundleSpec) DeepCopy() *CertificateBundleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateBundleStatus) DeepCopyInto(out *CertificateBundleStatus) {
 	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -598,6 +754,11 @@ func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.QueuePosition != nil {
+		in, out := &in.QueuePosition, &out.QueuePosition
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -737,11 +898,21 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationSchedule)
+		**out = **in
+	}
 	if in.InstallAttemptsLimit != nil {
 		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ProvisionRetryPolicy != nil {
+		in, out := &in.ProvisionRetryPolicy, &out.ProvisionRetryPolicy
+		*out = new(ProvisionRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BoundServiceAccountSignkingKeySecretRef != nil {
 		in, out := &in.BoundServiceAccountSignkingKeySecretRef, &out.BoundServiceAccountSignkingKeySecretRef
 		*out = new(corev1.LocalObjectReference)
@@ -788,7 +959,14 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 	if in.CertificateBundles != nil {
 		in, out := &in.CertificateBundles, &out.CertificateBundles
 		*out = make([]CertificateBundleStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdminKubeconfigRotation != nil {
+		in, out := &in.AdminKubeconfigRotation, &out.AdminKubeconfigRotation
+		*out = new(AdminKubeconfigRotationStatus)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.InstallStartedTimestamp != nil {
 		in, out := &in.InstallStartedTimestamp, &out.InstallStartedTimestamp
@@ -803,6 +981,11 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Platform != nil {
 		in, out := &in.Platform, &out.Platform
 		*out = new(PlatformStatus)
@@ -978,6 +1161,11 @@ func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BlockingResources != nil {
+		in, out := &in.BlockingResources, &out.BlockingResources
+		*out = make([]DeprovisionResourceStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1233,6 +1421,52 @@ func (in *ClusterPool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscaling) DeepCopyInto(out *ClusterPoolAutoscaling) {
+	*out = *in
+	if in.DemandWindow != nil {
+		in, out := &in.DemandWindow, &out.DemandWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownCooldown != nil {
+		in, out := &in.ScaleDownCooldown, &out.ScaleDownCooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscaling.
+func (in *ClusterPoolAutoscaling) DeepCopy() *ClusterPoolAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscalingStatus) DeepCopyInto(out *ClusterPoolAutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscalingStatus.
+func (in *ClusterPoolAutoscalingStatus) DeepCopy() *ClusterPoolAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
 	*out = *in
@@ -1379,6 +1613,11 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ProvisionRetryPolicy != nil {
+		in, out := &in.ProvisionRetryPolicy, &out.ProvisionRetryPolicy
+		*out = new(ProvisionRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ClaimLifetime != nil {
 		in, out := &in.ClaimLifetime, &out.ClaimLifetime
 		*out = new(ClusterPoolClaimLifetime)
@@ -1387,7 +1626,12 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 	if in.HibernationConfig != nil {
 		in, out := &in.HibernationConfig, &out.HibernationConfig
 		*out = new(HibernationConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscaling)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -1412,6 +1656,11 @@ func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1554,6 +1803,21 @@ func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PrevRegion != nil {
+		in, out := &in.PrevRegion, &out.PrevRegion
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstallConfigOverride != nil {
+		in, out := &in.InstallConfigOverride, &out.InstallConfigOverride
+		*out = new(string)
+		**out = **in
+	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -1582,6 +1846,11 @@ func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FailureClassification != nil {
+		in, out := &in.FailureClassification, &out.FailureClassification
+		*out = new(InstallFailureClassification)
+		**out = **in
+	}
 	return
 }
 
@@ -2032,11 +2301,16 @@ func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
 		*out = new(GCPDNSZoneSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(AzureDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(RFC2136DNSZoneSpec)
 		**out = **in
 	}
 	return
@@ -2099,6 +2373,22 @@ func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprovisionResourceStatus) DeepCopyInto(out *DeprovisionResourceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprovisionResourceStatus.
+func (in *DeprovisionResourceStatus) DeepCopy() *DeprovisionResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprovisionResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
 	*out = *in
@@ -2116,6 +2406,23 @@ func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionAzureConfig) DeepCopyInto(out *FailedProvisionAzureConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAzureConfig.
+func (in *FailedProvisionAzureConfig) DeepCopy() *FailedProvisionAzureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionAzureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 	*out = *in
@@ -2124,6 +2431,21 @@ func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 		*out = new(FailedProvisionAWSConfig)
 		**out = **in
 	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(FailedProvisionGCPConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(FailedProvisionAzureConfig)
+		**out = **in
+	}
+	if in.S3Compatible != nil {
+		in, out := &in.S3Compatible, &out.S3Compatible
+		*out = new(FailedProvisionS3CompatibleConfig)
+		**out = **in
+	}
 	if in.RetryReasons != nil {
 		in, out := &in.RetryReasons, &out.RetryReasons
 		*out = new([]string)
@@ -2146,6 +2468,40 @@ func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionGCPConfig) DeepCopyInto(out *FailedProvisionGCPConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionGCPConfig.
+func (in *FailedProvisionGCPConfig) DeepCopy() *FailedProvisionGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionS3CompatibleConfig) DeepCopyInto(out *FailedProvisionS3CompatibleConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionS3CompatibleConfig.
+func (in *FailedProvisionS3CompatibleConfig) DeepCopy() *FailedProvisionS3CompatibleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionS3CompatibleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
 	*out = *in
@@ -2213,6 +2569,13 @@ func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
 func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -2248,9 +2611,80 @@ func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HibernationConfig) DeepCopyInto(out *HibernationConfig) {
+func (in *GCPPrivateServiceConnectConfig) DeepCopyInto(out *GCPPrivateServiceConnectConfig) {
 	*out = *in
-	out.ResumeTimeout = in.ResumeTimeout
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.EndpointVPCInventory != nil {
+		in, out := &in.EndpointVPCInventory, &out.EndpointVPCInventory
+		*out = make([]GCPPrivateServiceConnectInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AssociatedNetworks != nil {
+		in, out := &in.AssociatedNetworks, &out.AssociatedNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectConfig.
+func (in *GCPPrivateServiceConnectConfig) DeepCopy() *GCPPrivateServiceConnectConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectInventory) DeepCopyInto(out *GCPPrivateServiceConnectInventory) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]GCPPrivateServiceConnectSubnet, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectInventory.
+func (in *GCPPrivateServiceConnectInventory) DeepCopy() *GCPPrivateServiceConnectInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectSubnet) DeepCopyInto(out *GCPPrivateServiceConnectSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectSubnet.
+func (in *GCPPrivateServiceConnectSubnet) DeepCopy() *GCPPrivateServiceConnectSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationConfig) DeepCopyInto(out *HibernationConfig) {
+	*out = *in
+	out.ResumeTimeout = in.ResumeTimeout
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(HibernationSchedule)
+		**out = **in
+	}
 	return
 }
 
@@ -2264,6 +2698,46 @@ func (in *HibernationConfig) DeepCopy() *HibernationConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationSchedule) DeepCopyInto(out *HibernationSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationSchedule.
+func (in *HibernationSchedule) DeepCopy() *HibernationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationScheduleStatus) DeepCopyInto(out *HibernationScheduleStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextTransitionTime != nil {
+		in, out := &in.NextTransitionTime, &out.NextTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationScheduleStatus.
+func (in *HibernationScheduleStatus) DeepCopy() *HibernationScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
 	*out = *in
@@ -2376,6 +2850,11 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DeprovisionInController != nil {
+		in, out := &in.DeprovisionInController, &out.DeprovisionInController
+		*out = new(bool)
+		**out = **in
+	}
 	if in.DisabledControllers != nil {
 		in, out := &in.DisabledControllers, &out.DisabledControllers
 		*out = make([]string, len(*in))
@@ -2391,6 +2870,31 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 		*out = new(AWSPrivateLinkConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCPPrivateServiceConnect != nil {
+		in, out := &in.GCPPrivateServiceConnect, &out.GCPPrivateServiceConnect
+		*out = new(GCPPrivateServiceConnectConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzurePrivateLink != nil {
+		in, out := &in.AzurePrivateLink, &out.AzurePrivateLink
+		*out = new(AzurePrivateLinkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ACMEIssuer != nil {
+		in, out := &in.ACMEIssuer, &out.ACMEIssuer
+		*out = new(ACMEIssuerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateExpiryWarningWindow != nil {
+		in, out := &in.CertificateExpiryWarningWindow, &out.CertificateExpiryWarningWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AdminKubeconfigRotation != nil {
+		in, out := &in.AdminKubeconfigRotation, &out.AdminKubeconfigRotation
+		*out = new(AdminKubeconfigRotationConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ReleaseImageVerificationConfigMapRef != nil {
 		in, out := &in.ReleaseImageVerificationConfigMapRef, &out.ReleaseImageVerificationConfigMapRef
 		*out = new(ReleaseImageVerificationConfigMapReference)
@@ -2471,6 +2975,39 @@ func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallConfigOverride) DeepCopyInto(out *InstallConfigOverride) {
+	*out = *in
+	in.Patch.DeepCopyInto(&out.Patch)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallConfigOverride.
+func (in *InstallConfigOverride) DeepCopy() *InstallConfigOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallConfigOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallFailureClassification) DeepCopyInto(out *InstallFailureClassification) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallFailureClassification.
+func (in *InstallFailureClassification) DeepCopy() *InstallFailureClassification {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallFailureClassification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
 	*out = *in
@@ -2726,6 +3263,32 @@ func (in *MachinePoolPlatform) DeepCopy() *MachinePoolPlatform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolRollingUpdate) DeepCopyInto(out *MachinePoolRollingUpdate) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolRollingUpdate.
+func (in *MachinePoolRollingUpdate) DeepCopy() *MachinePoolRollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolRollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 	*out = *in
@@ -2755,6 +3318,11 @@ func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(MachinePoolRollingUpdate)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2881,6 +3449,11 @@ func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
 		*out = new(ManageDNSAzureConfig)
 		**out = **in
 	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(ManageDNSRFC2136Config)
+		**out = **in
+	}
 	return
 }
 
@@ -2911,6 +3484,23 @@ func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSRFC2136Config) DeepCopyInto(out *ManageDNSRFC2136Config) {
+	*out = *in
+	out.TSIGSecretRef = in.TSIGSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSRFC2136Config.
+func (in *ManageDNSRFC2136Config) DeepCopy() *ManageDNSRFC2136Config {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSRFC2136Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackClusterDeprovision) DeepCopyInto(out *OpenStackClusterDeprovision) {
 	*out = *in
@@ -2966,7 +3556,7 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(azure.Platform)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.BareMetal != nil {
 		in, out := &in.BareMetal, &out.BareMetal
@@ -2976,7 +3566,7 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
 		*out = new(gcp.Platform)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.OpenStack != nil {
 		in, out := &in.OpenStack, &out.OpenStack
@@ -3029,6 +3619,16 @@ func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 		*out = new(aws.PlatformStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(gcp.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3042,6 +3642,44 @@ func (in *PlatformStatus) DeepCopy() *PlatformStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionRetryPolicy) DeepCopyInto(out *ProvisionRetryPolicy) {
+	*out = *in
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BackoffFactor != nil {
+		in, out := &in.BackoffFactor, &out.BackoffFactor
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InstallConfigOverrides != nil {
+		in, out := &in.InstallConfigOverrides, &out.InstallConfigOverrides
+		*out = make([]InstallConfigOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionRetryPolicy.
+func (in *ProvisionRetryPolicy) DeepCopy() *ProvisionRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provisioning) DeepCopyInto(out *Provisioning) {
 	*out = *in
@@ -3090,6 +3728,145 @@ func (in *Provisioning) DeepCopy() *Provisioning {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisioningQuota) DeepCopyInto(out *ProvisioningQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningQuota.
+func (in *ProvisioningQuota) DeepCopy() *ProvisioningQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisioningQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProvisioningQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisioningQuotaList) DeepCopyInto(out *ProvisioningQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProvisioningQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningQuotaList.
+func (in *ProvisioningQuotaList) DeepCopy() *ProvisioningQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisioningQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProvisioningQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisioningQuotaReservation) DeepCopyInto(out *ProvisioningQuotaReservation) {
+	*out = *in
+	in.ReservationTime.DeepCopyInto(&out.ReservationTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningQuotaReservation.
+func (in *ProvisioningQuotaReservation) DeepCopy() *ProvisioningQuotaReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisioningQuotaReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisioningQuotaSpec) DeepCopyInto(out *ProvisioningQuotaSpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningQuotaSpec.
+func (in *ProvisioningQuotaSpec) DeepCopy() *ProvisioningQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisioningQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisioningQuotaStatus) DeepCopyInto(out *ProvisioningQuotaStatus) {
+	*out = *in
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = make([]ProvisioningQuotaReservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningQuotaStatus.
+func (in *ProvisioningQuotaStatus) DeepCopy() *ProvisioningQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisioningQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RFC2136DNSZoneSpec) DeepCopyInto(out *RFC2136DNSZoneSpec) {
+	*out = *in
+	out.TSIGSecretRef = in.TSIGSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RFC2136DNSZoneSpec.
+func (in *RFC2136DNSZoneSpec) DeepCopy() *RFC2136DNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RFC2136DNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReleaseImageVerificationConfigMapReference) DeepCopyInto(out *ReleaseImageVerificationConfigMapReference) {
 	*out = *in
@@ -3225,7 +4002,7 @@ func (in *SelectorSyncSet) DeepCopyInto(out *SelectorSyncSet) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -3280,11 +4057,62 @@ func (in *SelectorSyncSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRollout) DeepCopyInto(out *SelectorSyncSetRollout) {
+	*out = *in
+	if in.CanarySelector != nil {
+		in, out := &in.CanarySelector, &out.CanarySelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PauseBetweenBatches != nil {
+		in, out := &in.PauseBetweenBatches, &out.PauseBetweenBatches
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRollout.
+func (in *SelectorSyncSetRollout) DeepCopy() *SelectorSyncSetRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRolloutStatus) DeepCopyInto(out *SelectorSyncSetRolloutStatus) {
+	*out = *in
+	if in.LastBatchTime != nil {
+		in, out := &in.LastBatchTime, &out.LastBatchTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRolloutStatus.
+func (in *SelectorSyncSetRolloutStatus) DeepCopy() *SelectorSyncSetRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetSpec) DeepCopyInto(out *SelectorSyncSetSpec) {
 	*out = *in
 	in.SyncSetCommonSpec.DeepCopyInto(&out.SyncSetCommonSpec)
 	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(SelectorSyncSetRollout)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3301,6 +4129,11 @@ func (in *SelectorSyncSetSpec) DeepCopy() *SelectorSyncSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetStatus) DeepCopyInto(out *SelectorSyncSetStatus) {
 	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(SelectorSyncSetRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -71,8 +71,51 @@ type SyncStatus struct {
 	// FirstSuccessTime is the time when the SyncSet or SelectorSyncSet was first successfully applied to the cluster.
 	// +optional
 	FirstSuccessTime *metav1.Time `json:"firstSuccessTime,omitempty"`
+
+	// RenderedHash is a hash of the SyncSet or SelectorSyncSet after its templates were evaluated for the cluster. It is
+	// only set when templates are enabled. The SyncSet or SelectorSyncSet is applied again when the hash changes.
+	// +optional
+	RenderedHash string `json:"renderedHash,omitempty"`
+
+	// DryRunResults is the change that applying the SyncSet or SelectorSyncSet would make to each of its resources in
+	// the cluster. This is only set when the SyncSet or SelectorSyncSet is in dry-run mode.
+	// +optional
+	DryRunResults []DryRunResult `json:"dryRunResults,omitempty"`
+}
+
+// DryRunResult is the change that applying a SyncSet or SelectorSyncSet would make to a resource in the cluster.
+type DryRunResult struct {
+	SyncResourceReference `json:",inline"`
+
+	// Action is the change that would be made to the resource.
+	Action DryRunAction `json:"action"`
+
+	// Diff is a JSON merge patch from the current state of the resource to the state that the resource would have
+	// after being applied. This is only set when Action is Update. For secrets, the values of the changed keys are
+	// redacted.
+	// +optional
+	Diff string `json:"diff,omitempty"`
 }
 
+// DryRunAction is the change that would be made to a resource by applying a SyncSet or SelectorSyncSet.
+// +kubebuilder:validation:Enum=Create;Update;Delete;None
+type DryRunAction string
+
+const (
+	// CreateDryRunAction is the action when the resource does not exist in the cluster and would be created.
+	CreateDryRunAction DryRunAction = "Create"
+
+	// UpdateDryRunAction is the action when the resource exists in the cluster and would be changed.
+	UpdateDryRunAction DryRunAction = "Update"
+
+	// DeleteDryRunAction is the action when the resource is no longer in the SyncSet or SelectorSyncSet and would be
+	// deleted from the cluster.
+	DeleteDryRunAction DryRunAction = "Delete"
+
+	// NoneDryRunAction is the action when the resource exists in the cluster and would be left unchanged.
+	NoneDryRunAction DryRunAction = "None"
+)
+
 // SyncResourceReference is a reference to a resource that is synced to a cluster via a SyncSet or SelectorSyncSet.
 type SyncResourceReference struct {
 	// APIVersion is the Group and Version of the resource.
@@ -31,8 +31,12 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	cmdutil "github.com/openshift/hive/cmd/util"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/acmeissuer"
+	"github.com/openshift/hive/pkg/controller/adminkubeconfigrotation"
 	"github.com/openshift/hive/pkg/controller/argocdregister"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/azureprivatelink"
+	"github.com/openshift/hive/pkg/controller/certificateexpiry"
 	"github.com/openshift/hive/pkg/controller/clusterclaim"
 	"github.com/openshift/hive/pkg/controller/clusterdeployment"
 	"github.com/openshift/hive/pkg/controller/clusterdeprovision"
@@ -47,10 +51,12 @@ import (
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
 	"github.com/openshift/hive/pkg/controller/dnszone"
 	"github.com/openshift/hive/pkg/controller/fakeclusterinstall"
+	"github.com/openshift/hive/pkg/controller/gcpprivateserviceconnect"
 	"github.com/openshift/hive/pkg/controller/hibernation"
 	"github.com/openshift/hive/pkg/controller/machinepool"
 	"github.com/openshift/hive/pkg/controller/metrics"
 	"github.com/openshift/hive/pkg/controller/remoteingress"
+	"github.com/openshift/hive/pkg/controller/selectorsyncsetrollout"
 	"github.com/openshift/hive/pkg/controller/syncidentityprovider"
 	"github.com/openshift/hive/pkg/controller/unreachable"
 	"github.com/openshift/hive/pkg/controller/utils"
@@ -67,29 +73,35 @@ const (
 type controllerSetupFunc func(manager.Manager) error
 
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
-	clusterclaim.ControllerName:         clusterclaim.Add,
-	clusterdeployment.ControllerName:    clusterdeployment.Add,
-	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
-	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
-	clusterprovision.ControllerName:     clusterprovision.Add,
-	clusterrelocate.ControllerName:      clusterrelocate.Add,
-	clusterstate.ControllerName:         clusterstate.Add,
-	clustersync.ControllerName:          clustersync.Add,
-	clusterversion.ControllerName:       clusterversion.Add,
-	controlplanecerts.ControllerName:    controlplanecerts.Add,
-	dnsendpoint.ControllerName:          dnsendpoint.Add,
-	dnszone.ControllerName:              dnszone.Add,
-	fakeclusterinstall.ControllerName:   fakeclusterinstall.Add,
-	metrics.ControllerName:              metrics.Add,
-	remoteingress.ControllerName:        remoteingress.Add,
-	machinepool.ControllerName:          machinepool.Add,
-	syncidentityprovider.ControllerName: syncidentityprovider.Add,
-	unreachable.ControllerName:          unreachable.Add,
-	velerobackup.ControllerName:         velerobackup.Add,
-	clusterpool.ControllerName:          clusterpool.Add,
-	hibernation.ControllerName:          hibernation.Add,
-	awsprivatelink.ControllerName:       awsprivatelink.Add,
-	argocdregister.ControllerName:       argocdregister.Add,
+	clusterclaim.ControllerName:             clusterclaim.Add,
+	clusterdeployment.ControllerName:        clusterdeployment.Add,
+	clusterdeprovision.ControllerName:       clusterdeprovision.Add,
+	clusterpoolnamespace.ControllerName:     clusterpoolnamespace.Add,
+	clusterprovision.ControllerName:         clusterprovision.Add,
+	clusterrelocate.ControllerName:          clusterrelocate.Add,
+	clusterstate.ControllerName:             clusterstate.Add,
+	clustersync.ControllerName:              clustersync.Add,
+	clusterversion.ControllerName:           clusterversion.Add,
+	controlplanecerts.ControllerName:        controlplanecerts.Add,
+	dnsendpoint.ControllerName:              dnsendpoint.Add,
+	dnszone.ControllerName:                  dnszone.Add,
+	fakeclusterinstall.ControllerName:       fakeclusterinstall.Add,
+	metrics.ControllerName:                  metrics.Add,
+	remoteingress.ControllerName:            remoteingress.Add,
+	machinepool.ControllerName:              machinepool.Add,
+	syncidentityprovider.ControllerName:     syncidentityprovider.Add,
+	unreachable.ControllerName:              unreachable.Add,
+	velerobackup.ControllerName:             velerobackup.Add,
+	clusterpool.ControllerName:              clusterpool.Add,
+	hibernation.ControllerName:              hibernation.Add,
+	awsprivatelink.ControllerName:           awsprivatelink.Add,
+	gcpprivateserviceconnect.ControllerName: gcpprivateserviceconnect.Add,
+	azureprivatelink.ControllerName:         azureprivatelink.Add,
+	acmeissuer.ControllerName:               acmeissuer.Add,
+	certificateexpiry.ControllerName:        certificateexpiry.Add,
+	adminkubeconfigrotation.ControllerName:  adminkubeconfigrotation.Add,
+	argocdregister.ControllerName:           argocdregister.Add,
+	selectorsyncsetrollout.ControllerName:   selectorsyncsetrollout.Add,
 }
 
 // disabledControllerEquivalents contains a mapping of old controller names to their new equivalent so that CLI parameters like --controllers and --disabled-controllers continue to work
@@ -20,6 +20,7 @@ type ClusterClaimOptions struct {
 	Name            string
 	Namespace       string
 	Lifetime        time.Duration
+	Priority        int32
 	ClusterPoolName string
 
 	log log.FieldLogger
@@ -47,6 +48,7 @@ func NewClaimClusterPoolCommand() *cobra.Command {
 	flags.StringVarP(&opt.Namespace, "namespace", "n", "",
 		"Namespace to create cluster claim in. Has to be the namespace in which the cluster pool is deployed")
 	flags.DurationVar(&opt.Lifetime, "lifetime", 0, "Lifetime of the cluster claim")
+	flags.Int32Var(&opt.Priority, "priority", 0, "Priority of the cluster claim. Claims with a higher priority are assigned a cluster first")
 
 	return cmd
 }
@@ -87,6 +89,7 @@ func (o ClusterClaimOptions) generateClaim() *hivev1.ClusterClaim {
 		},
 		Spec: hivev1.ClusterClaimSpec{
 			ClusterPoolName: o.ClusterPoolName,
+			Priority:        o.Priority,
 		},
 	}
 	if o.Lifetime != 0 {
@@ -172,6 +172,10 @@ type Options struct {
 	// Azure
 	AzureBaseDomainResourceGroupName string
 	AzureCloudName                   string
+	AzureUserTags                    []string
+
+	// GCP
+	GCPUserLabels []string
 
 	// OpenStack
 	OpenStackCloud             string
@@ -315,6 +319,10 @@ OpenShift Installer publishes all the services of the cluster like API server an
 	// Azure flags
 	flags.StringVar(&opt.AzureBaseDomainResourceGroupName, "azure-base-domain-resource-group-name", "os4-common", "Resource group where the azure DNS zone for the base domain is found")
 	flags.StringVar(&opt.AzureCloudName, "azure-cloud-name", "AzurePublicCloud", "Azure Cloud in which cluster will be created")
+	flags.StringSliceVar(&opt.AzureUserTags, "azure-user-tags", nil, "Additional tags to add to resources. Must be in the form \"key=value\"")
+
+	// GCP flags
+	flags.StringSliceVar(&opt.GCPUserLabels, "gcp-user-labels", nil, "Additional labels to add to resources. Must be in the form \"key=value\"")
 
 	// OpenStack flags
 	flags.StringVar(&opt.OpenStackCloud, "openstack-cloud", "openstack", "Section of clouds.yaml to use for API/auth")
@@ -610,21 +618,10 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 		if err != nil {
 			return nil, err
 		}
-		userTags := make(map[string]string, len(o.AWSUserTags))
-		for _, t := range o.AWSUserTags {
-			tagParts := strings.SplitN(t, "=", 2)
-			switch len(tagParts) {
-			case 0:
-			case 1:
-				userTags[tagParts[0]] = ""
-			case 2:
-				userTags[tagParts[0]] = tagParts[1]
-			}
-		}
 		awsProvider := &clusterresource.AWSCloudBuilder{
 			AccessKeyID:     accessKeyID,
 			SecretAccessKey: secretAccessKey,
-			UserTags:        userTags,
+			UserTags:        parseKeyValues(o.AWSUserTags),
 			Region:          o.Region,
 			PrivateLink:     o.AWSPrivateLink,
 		}
@@ -641,6 +638,7 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 			BaseDomainResourceGroupName: o.AzureBaseDomainResourceGroupName,
 			Region:                      o.Region,
 			CloudName:                   hivev1azure.CloudEnvironment(o.AzureCloudName),
+			UserTags:                    parseKeyValues(o.AzureUserTags),
 		}
 		builder.CloudBuilder = azureProvider
 	case cloudGCP:
@@ -657,6 +655,7 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 			ProjectID:      projectID,
 			ServiceAccount: creds,
 			Region:         o.Region,
+			UserLabels:     parseKeyValues(o.GCPUserLabels),
 		}
 		builder.CloudBuilder = gcpProvider
 	case cloudOpenStack:
@@ -1037,3 +1036,22 @@ func printObjects(objects []runtime.Object, scheme *runtime.Scheme, printer prin
 		typeSetterPrinter.PrintObj(list, os.Stdout)
 	}
 }
+
+// parseKeyValues parses a list of "key=value" strings into a map. A string without a "=" is parsed as a key with an
+// empty value.
+func parseKeyValues(keyValues []string) map[string]string {
+	if len(keyValues) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(keyValues))
+	for _, kv := range keyValues {
+		parts := strings.SplitN(kv, "=", 2)
+		switch len(parts) {
+		case 1:
+			result[parts[0]] = ""
+		case 2:
+			result[parts[0]] = parts[1]
+		}
+	}
+	return result
+}
@@ -7,6 +7,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	azuresession "github.com/openshift/installer/pkg/asset/installconfig/azure"
 	"github.com/openshift/installer/pkg/destroy/azure"
 	"github.com/openshift/installer/pkg/destroy/providers"
 	"github.com/openshift/installer/pkg/types"
@@ -77,14 +78,41 @@ func completeAzureUninstaller(logLevel, cloudName string, args []string) (provid
 		Level: level,
 	})
 
+	return NewAzureDestroyer(logger, args[0], installertypesazure.CloudEnvironment(cloudName), nil)
+}
+
+// NewAzureDestroyer returns the installer's destroyer for the Azure cluster with the given infra ID. The
+// credentials are read from the file system when none are given.
+func NewAzureDestroyer(logger log.FieldLogger, infraID string, cloudName installertypesazure.CloudEnvironment, credentials *azuresession.Credentials) (providers.Destroyer, error) {
 	metadata := &types.ClusterMetadata{
-		InfraID: args[0],
+		InfraID: infraID,
 		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
 			Azure: &installertypesazure.Metadata{
-				CloudName: installertypesazure.CloudEnvironment(cloudName),
+				CloudName: cloudName,
 			},
 		},
 	}
+	if credentials == nil {
+		return azure.New(logger, metadata)
+	}
 
-	return azure.New(logger, metadata)
+	// Same as azure.New, which only reads the credentials from the file system.
+	if cloudName == "" {
+		cloudName = installertypesazure.PublicCloud
+	}
+	session, err := azuresession.GetSessionWithCredentials(cloudName, "", credentials)
+	if err != nil {
+		return nil, err
+	}
+	return &azure.ClusterUninstaller{
+		SubscriptionID:    session.Credentials.SubscriptionID,
+		TenantID:          session.Credentials.TenantID,
+		GraphAuthorizer:   session.GraphAuthorizer,
+		Authorizer:        session.Authorizer,
+		Environment:       session.Environment,
+		InfraID:           infraID,
+		ResourceGroupName: infraID + "-rg",
+		Logger:            logger,
+		CloudName:         cloudName,
+	}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/installer/pkg/destroy/gcp"
+	"github.com/openshift/installer/pkg/destroy/providers"
 	"github.com/openshift/installer/pkg/types"
 	typesgcp "github.com/openshift/installer/pkg/types/gcp"
 
@@ -93,17 +94,7 @@ func (o *gcpOptions) Run() error {
 		Level: level,
 	})
 
-	metadata := &types.ClusterMetadata{
-		InfraID: o.infraID,
-		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
-			GCP: &typesgcp.Metadata{
-				Region:    o.region,
-				ProjectID: o.projectID,
-			},
-		},
-	}
-
-	destroyer, err := gcp.New(logger, metadata)
+	destroyer, err := NewGCPDestroyer(logger, o.infraID, o.region, o.projectID)
 	if err != nil {
 		return err
 	}
@@ -112,3 +103,18 @@ func (o *gcpOptions) Run() error {
 	_, err = destroyer.Run()
 	return err
 }
+
+// NewGCPDestroyer returns the installer's destroyer for the GCP cluster with the given infra ID. The destroyer
+// reads its credentials from the environment when it runs.
+func NewGCPDestroyer(logger log.FieldLogger, infraID, region, projectID string) (providers.Destroyer, error) {
+	metadata := &types.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+			GCP: &typesgcp.Metadata{
+				Region:    region,
+				ProjectID: projectID,
+			},
+		},
+	}
+	return gcp.New(logger, metadata)
+}
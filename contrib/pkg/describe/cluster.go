@@ -0,0 +1,499 @@
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// DescribeClusterOptions is the set of options for describing a cluster.
+type DescribeClusterOptions struct {
+	Name            string
+	Namespace       string
+	Output          string
+	InstallLogLines int
+
+	log log.FieldLogger
+}
+
+// ClusterReport is the troubleshooting report of a ClusterDeployment and the resources of the cluster.
+type ClusterReport struct {
+	Name         string              `json:"name"`
+	Namespace    string              `json:"namespace"`
+	Platform     string              `json:"platform"`
+	Region       string              `json:"region,omitempty"`
+	Installed    bool                `json:"installed"`
+	Deleting     bool                `json:"deleting,omitempty"`
+	PowerState   PowerStateReport    `json:"powerState"`
+	Conditions   []ConditionReport   `json:"conditions,omitempty"`
+	Provision    *ProvisionReport    `json:"provision,omitempty"`
+	DNSZone      *DNSZoneReport      `json:"dnsZone,omitempty"`
+	SyncFailures []SyncFailureReport `json:"syncFailures,omitempty"`
+	MachinePools []MachinePoolReport `json:"machinePools,omitempty"`
+}
+
+// PowerStateReport is the desired and the observed power state of a cluster.
+type PowerStateReport struct {
+	Desired  hivev1.ClusterPowerState `json:"desired,omitempty"`
+	Observed hivev1.ClusterPowerState `json:"observed,omitempty"`
+}
+
+// ConditionReport is a condition of a ClusterDeployment, DNSZone or MachinePool.
+type ConditionReport struct {
+	Type               string                 `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+	// Problem is true when the condition is not in its desired state.
+	Problem bool `json:"problem"`
+}
+
+// ProvisionReport is the current or last ClusterProvision of a cluster.
+type ProvisionReport struct {
+	Name                  string                               `json:"name"`
+	Attempt               int                                  `json:"attempt"`
+	Stage                 hivev1.ClusterProvisionStage         `json:"stage"`
+	InfraID               string                               `json:"infraID,omitempty"`
+	FailureClassification *hivev1.InstallFailureClassification `json:"failureClassification,omitempty"`
+	InstallLogTail        []string                             `json:"installLogTail,omitempty"`
+}
+
+// DNSZoneReport is the DNSZone of a cluster with managed DNS.
+type DNSZoneReport struct {
+	Name              string            `json:"name"`
+	Zone              string            `json:"zone"`
+	NameServers       []string          `json:"nameServers,omitempty"`
+	LastSyncTimestamp *metav1.Time      `json:"lastSyncTimestamp,omitempty"`
+	Conditions        []ConditionReport `json:"conditions,omitempty"`
+}
+
+// SyncFailureReport is a SyncSet or SelectorSyncSet that failed to apply to a cluster.
+type SyncFailureReport struct {
+	Kind               string      `json:"kind"`
+	Name               string      `json:"name"`
+	FailureMessage     string      `json:"failureMessage"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// MachinePoolReport is a MachinePool of a cluster.
+type MachinePoolReport struct {
+	Name            string                         `json:"name"`
+	DesiredReplicas *int64                         `json:"desiredReplicas,omitempty"`
+	Autoscaling     *hivev1.MachinePoolAutoscaling `json:"autoscaling,omitempty"`
+	Replicas        int32                          `json:"replicas"`
+	UpdatedReplicas int32                          `json:"updatedReplicas"`
+	MachineSets     []hivev1.MachineSetStatus      `json:"machineSets,omitempty"`
+	Conditions      []ConditionReport              `json:"conditions,omitempty"`
+}
+
+// NewDescribeClusterCommand creates a command that prints the troubleshooting report of a cluster.
+func NewDescribeClusterCommand() *cobra.Command {
+	opt := &DescribeClusterOptions{log: log.WithField("command", "describe cluster")}
+
+	cmd := &cobra.Command{
+		Use:   "cluster CLUSTER_DEPLOYMENT_NAME",
+		Short: "Prints a troubleshooting report of a cluster",
+		Long: "Prints the conditions of the ClusterDeployment, the stage and install log of the current ClusterProvision, " +
+			"the status of the DNSZone, the SyncSets failing to apply, the status of the MachinePools and the power state of the cluster",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			if err := opt.Validate(cmd); err != nil {
+				opt.log.WithError(err).Fatal("Invalid options")
+			}
+			if err := opt.Run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the ClusterDeployment. Defaults to the namespace of the current context")
+	flags.StringVarP(&opt.Output, "output", "o", outputText, "Output format, one of: text, json")
+	flags.IntVar(&opt.InstallLogLines, "install-log-lines", 20, "Number of lines of the end of the install log to print")
+	return cmd
+}
+
+// Validate ensures that option values make sense
+func (o *DescribeClusterOptions) Validate(cmd *cobra.Command) error {
+	if o.Output != outputText && o.Output != outputJSON {
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+	if o.InstallLogLines < 0 {
+		return fmt.Errorf("install log lines must not be negative")
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *DescribeClusterOptions) Run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not create kube client")
+	}
+	if o.Namespace == "" {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+
+	report, err := o.ClusterReport(c)
+	if err != nil {
+		return err
+	}
+	if o.Output == outputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return printClusterReport(os.Stdout, report)
+}
+
+// ClusterReport gathers the troubleshooting report of the cluster.
+func (o *DescribeClusterOptions) ClusterReport(c client.Client) (*ClusterReport, error) {
+	cd := &hivev1.ClusterDeployment{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, cd); err != nil {
+		return nil, errors.Wrap(err, "could not get ClusterDeployment")
+	}
+
+	report := &ClusterReport{
+		Name:      cd.Name,
+		Namespace: cd.Namespace,
+		Installed: cd.Spec.Installed,
+		Deleting:  cd.DeletionTimestamp != nil,
+		PowerState: PowerStateReport{
+			Desired:  cd.Spec.PowerState,
+			Observed: cd.Status.PowerState,
+		},
+	}
+	report.Platform = clusterPlatform(cd)
+	report.Region = controllerutils.GetClusterRegion(cd)
+	for _, cond := range cd.Status.Conditions {
+		report.Conditions = append(report.Conditions, ConditionReport{
+			Type:               string(cond.Type),
+			Status:             cond.Status,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime,
+			Problem:            cond.Status != corev1.ConditionUnknown && !controllerutils.IsConditionInDesiredState(cond),
+		})
+	}
+
+	var err error
+	if report.Provision, err = o.provisionReport(c, cd); err != nil {
+		return nil, err
+	}
+	if report.DNSZone, err = dnsZoneReport(c, cd); err != nil {
+		return nil, err
+	}
+	if report.SyncFailures, err = syncFailures(c, cd); err != nil {
+		return nil, err
+	}
+	if report.MachinePools, err = machinePoolReports(c, cd); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// provisionReport returns the report of the current ClusterProvision of the cluster, or of its last one when no
+// provision is in progress.
+func (o *DescribeClusterOptions) provisionReport(c client.Client, cd *hivev1.ClusterDeployment) (*ProvisionReport, error) {
+	provision := &hivev1.ClusterProvision{}
+	if ref := cd.Status.ProvisionRef; ref != nil {
+		switch err := c.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: ref.Name}, provision); {
+		case apierrors.IsNotFound(err):
+			provision = nil
+		case err != nil:
+			return nil, errors.Wrap(err, "could not get ClusterProvision")
+		}
+	} else {
+		provisionList := &hivev1.ClusterProvisionList{}
+		if err := c.List(context.TODO(), provisionList,
+			client.InNamespace(cd.Namespace),
+			client.MatchingLabels{constants.ClusterDeploymentNameLabel: cd.Name}); err != nil {
+			return nil, errors.Wrap(err, "could not list ClusterProvisions")
+		}
+		provision = nil
+		for i, p := range provisionList.Items {
+			if provision == nil || p.Spec.Attempt > provision.Spec.Attempt {
+				provision = &provisionList.Items[i]
+			}
+		}
+	}
+	if provision == nil {
+		return nil, nil
+	}
+
+	report := &ProvisionReport{
+		Name:                  provision.Name,
+		Attempt:               provision.Spec.Attempt,
+		Stage:                 provision.Spec.Stage,
+		FailureClassification: provision.Status.FailureClassification,
+	}
+	if provision.Spec.InfraID != nil {
+		report.InfraID = *provision.Spec.InfraID
+	}
+	if provision.Spec.InstallLog != nil && o.InstallLogLines > 0 {
+		lines := strings.Split(strings.TrimRight(*provision.Spec.InstallLog, "\n"), "\n")
+		if len(lines) > o.InstallLogLines {
+			lines = lines[len(lines)-o.InstallLogLines:]
+		}
+		report.InstallLogTail = lines
+	}
+	return report, nil
+}
+
+func dnsZoneReport(c client.Client, cd *hivev1.ClusterDeployment) (*DNSZoneReport, error) {
+	if !cd.Spec.ManageDNS {
+		return nil, nil
+	}
+	dnsZone := &hivev1.DNSZone{}
+	switch err := c.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)}, dnsZone); {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get DNSZone")
+	}
+	report := &DNSZoneReport{
+		Name:              dnsZone.Name,
+		Zone:              dnsZone.Spec.Zone,
+		NameServers:       dnsZone.Status.NameServers,
+		LastSyncTimestamp: dnsZone.Status.LastSyncTimestamp,
+	}
+	for _, cond := range dnsZone.Status.Conditions {
+		problemStatus := corev1.ConditionTrue
+		if cond.Type == hivev1.ZoneAvailableDNSZoneCondition || cond.Type == hivev1.ParentLinkCreatedCondition {
+			problemStatus = corev1.ConditionFalse
+		}
+		report.Conditions = append(report.Conditions, ConditionReport{
+			Type:               string(cond.Type),
+			Status:             cond.Status,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime,
+			Problem:            cond.Status == problemStatus,
+		})
+	}
+	return report, nil
+}
+
+func syncFailures(c client.Client, cd *hivev1.ClusterDeployment) ([]SyncFailureReport, error) {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	switch err := c.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get ClusterSync")
+	}
+	var failures []SyncFailureReport
+	appendFailures := func(kind string, statuses []hiveintv1alpha1.SyncStatus) {
+		for _, status := range statuses {
+			if status.Result == hiveintv1alpha1.FailureSyncSetResult {
+				failures = append(failures, SyncFailureReport{
+					Kind:               kind,
+					Name:               status.Name,
+					FailureMessage:     status.FailureMessage,
+					LastTransitionTime: status.LastTransitionTime,
+				})
+			}
+		}
+	}
+	appendFailures("SyncSet", clusterSync.Status.SyncSets)
+	appendFailures("SelectorSyncSet", clusterSync.Status.SelectorSyncSets)
+	return failures, nil
+}
+
+func machinePoolReports(c client.Client, cd *hivev1.ClusterDeployment) ([]MachinePoolReport, error) {
+	poolList := &hivev1.MachinePoolList{}
+	if err := c.List(context.TODO(), poolList, client.InNamespace(cd.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "could not list MachinePools")
+	}
+	var reports []MachinePoolReport
+	for _, pool := range poolList.Items {
+		if pool.Spec.ClusterDeploymentRef.Name != cd.Name {
+			continue
+		}
+		report := MachinePoolReport{
+			Name:            pool.Spec.Name,
+			DesiredReplicas: pool.Spec.Replicas,
+			Autoscaling:     pool.Spec.Autoscaling,
+			Replicas:        pool.Status.Replicas,
+			UpdatedReplicas: pool.Status.UpdatedReplicas,
+			MachineSets:     pool.Status.MachineSets,
+		}
+		for _, cond := range pool.Status.Conditions {
+			report.Conditions = append(report.Conditions, ConditionReport{
+				Type:               string(cond.Type),
+				Status:             cond.Status,
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime,
+				// All the MachinePool conditions report problems when true.
+				Problem: cond.Status == corev1.ConditionTrue,
+			})
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+	return reports, nil
+}
+
+func clusterPlatform(cd *hivev1.ClusterDeployment) string {
+	switch p := cd.Spec.Platform; {
+	case p.AWS != nil:
+		return constants.PlatformAWS
+	case p.Azure != nil:
+		return constants.PlatformAzure
+	case p.GCP != nil:
+		return constants.PlatformGCP
+	case p.IBMCloud != nil:
+		return constants.PlatformIBMCloud
+	case p.OpenStack != nil:
+		return constants.PlatformOpenStack
+	case p.VSphere != nil:
+		return constants.PlatformVSphere
+	case p.Ovirt != nil:
+		return constants.PlatformOvirt
+	case p.BareMetal != nil:
+		return constants.PlatformBaremetal
+	case p.AgentBareMetal != nil:
+		return constants.PlatformAgentBaremetal
+	case p.None != nil:
+		return constants.PlatformNone
+	}
+	return constants.PlatformUnknown
+}
+
+// printClusterReport prints the report in a human readable form. Only the conditions reporting problems are printed.
+func printClusterReport(out io.Writer, report *ClusterReport) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Cluster:\t%s/%s\n", report.Namespace, report.Name)
+	fmt.Fprintf(w, "Platform:\t%s\n", report.Platform)
+	if report.Region != "" {
+		fmt.Fprintf(w, "Region:\t%s\n", report.Region)
+	}
+	fmt.Fprintf(w, "Installed:\t%t\n", report.Installed)
+	if report.Deleting {
+		fmt.Fprintf(w, "Deleting:\t%t\n", report.Deleting)
+	}
+	fmt.Fprintf(w, "Power state:\t%s (desired %s)\n", valueOrNone(string(report.PowerState.Observed)), valueOrNone(string(report.PowerState.Desired)))
+
+	fmt.Fprintln(w, "\nConditions:")
+	printConditions(w, report.Conditions)
+
+	fmt.Fprintln(w, "\nProvision:")
+	if p := report.Provision; p == nil {
+		fmt.Fprintln(w, "  <none>")
+	} else {
+		fmt.Fprintf(w, "  Name:\t%s\n", p.Name)
+		fmt.Fprintf(w, "  Attempt:\t%d\n", p.Attempt)
+		fmt.Fprintf(w, "  Stage:\t%s\n", p.Stage)
+		if p.InfraID != "" {
+			fmt.Fprintf(w, "  Infra ID:\t%s\n", p.InfraID)
+		}
+		if f := p.FailureClassification; f != nil {
+			fmt.Fprintf(w, "  Failure:\t%s (%s, retryable: %t)\n", f.Reason, f.Category, f.Retryable)
+			if f.Remediation != "" {
+				fmt.Fprintf(w, "  Remediation:\t%s\n", f.Remediation)
+			}
+		}
+	}
+
+	fmt.Fprintln(w, "\nDNS zone:")
+	if z := report.DNSZone; z == nil {
+		fmt.Fprintln(w, "  <none>")
+	} else {
+		fmt.Fprintf(w, "  Name:\t%s\n", z.Name)
+		fmt.Fprintf(w, "  Zone:\t%s\n", z.Zone)
+		fmt.Fprintf(w, "  Name servers:\t%s\n", valueOrNone(strings.Join(z.NameServers, ", ")))
+		if z.LastSyncTimestamp != nil {
+			fmt.Fprintf(w, "  Last sync:\t%s\n", z.LastSyncTimestamp.Time)
+		}
+		printConditions(w, z.Conditions)
+	}
+
+	fmt.Fprintln(w, "\nSync failures:")
+	if len(report.SyncFailures) == 0 {
+		fmt.Fprintln(w, "  <none>")
+	}
+	for _, f := range report.SyncFailures {
+		fmt.Fprintf(w, "  %s %s:\t%s (since %s)\n", f.Kind, f.Name, f.FailureMessage, f.LastTransitionTime.Time)
+	}
+
+	fmt.Fprintln(w, "\nMachine pools:")
+	if len(report.MachinePools) == 0 {
+		fmt.Fprintln(w, "  <none>")
+	}
+	for _, pool := range report.MachinePools {
+		desired := "<none>"
+		switch {
+		case pool.Autoscaling != nil:
+			desired = fmt.Sprintf("%d-%d", pool.Autoscaling.MinReplicas, pool.Autoscaling.MaxReplicas)
+		case pool.DesiredReplicas != nil:
+			desired = fmt.Sprintf("%d", *pool.DesiredReplicas)
+		}
+		fmt.Fprintf(w, "  %s:\t%d replicas, %d updated (desired %s)\n", pool.Name, pool.Replicas, pool.UpdatedReplicas, desired)
+		printConditions(w, pool.Conditions)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if report.Provision != nil && len(report.Provision.InstallLogTail) > 0 {
+		fmt.Fprintln(out, "\nInstall log:")
+		for _, line := range report.Provision.InstallLogTail {
+			fmt.Fprintf(out, "  %s\n", line)
+		}
+	}
+	return nil
+}
+
+func printConditions(w io.Writer, conditions []ConditionReport) {
+	var printed bool
+	for _, cond := range conditions {
+		if !cond.Problem {
+			continue
+		}
+		printed = true
+		fmt.Fprintf(w, "  %s=%s\t%s: %s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+	}
+	if !printed {
+		fmt.Fprintln(w, "  <no problems>")
+	}
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
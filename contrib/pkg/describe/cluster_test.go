@@ -0,0 +1,192 @@
+package describe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterprovision"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testdnszone "github.com/openshift/hive/pkg/test/dnszone"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testCDName    = "test-cluster"
+)
+
+func TestClusterReport(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(testNamespace, testCDName, scheme)
+	provisionBuilder := testcp.FullBuilder(testNamespace, testCDName).Options(testcp.WithClusterDeploymentRef(testCDName))
+
+	cases := []struct {
+		name                 string
+		existing             []runtime.Object
+		expectedPlatform     string
+		expectedRegion       string
+		expectedInstalled    bool
+		expectedPowerState   PowerStateReport
+		expectedProblems     []string
+		expectedProvision    *ProvisionReport
+		expectedDNSZone      *DNSZoneReport
+		expectedSyncFailures []SyncFailureReport
+		expectedMachinePools []string
+	}{
+		{
+			name: "installed",
+			existing: []runtime.Object{
+				cdBuilder.Build(
+					testcd.Running(),
+					testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ProvisionFailedCondition,
+						Status: corev1.ConditionFalse,
+					}),
+					func(cd *hivev1.ClusterDeployment) {
+						cd.Spec.ManageDNS = true
+					},
+				),
+				testdnszone.FullBuilder(testNamespace, controllerutils.DNSZoneName(testCDName), scheme).Build(
+					testdnszone.WithZone("test-cluster.example.com"),
+				),
+				testcs.FullBuilder(testNamespace, testCDName, scheme).Build(
+					testcs.WithSyncSetStatus(hiveintv1alpha1.SyncStatus{
+						Name:           "failing-syncset",
+						Result:         hiveintv1alpha1.FailureSyncSetResult,
+						FailureMessage: "apply failed",
+					}),
+					testcs.WithSyncSetStatus(hiveintv1alpha1.SyncStatus{
+						Name:   "applied-syncset",
+						Result: hiveintv1alpha1.SuccessSyncSetResult,
+					}),
+				),
+				testmp.BuildFull(testNamespace, "worker", testCDName, func(pool *hivev1.MachinePool) {
+					pool.Spec.Replicas = pointer.Int64Ptr(3)
+					pool.Status.Replicas = 3
+				}),
+				testmp.BuildFull(testNamespace, "infra", testCDName),
+				testmp.BuildFull(testNamespace, "worker", "other-cluster"),
+			},
+			expectedPlatform:   constants.PlatformAWS,
+			expectedRegion:     "us-east-1",
+			expectedInstalled:  true,
+			expectedPowerState: PowerStateReport{Desired: hivev1.ClusterPowerStateRunning, Observed: hivev1.ClusterPowerStateRunning},
+			expectedDNSZone: &DNSZoneReport{
+				Name: controllerutils.DNSZoneName(testCDName),
+				Zone: "test-cluster.example.com",
+			},
+			expectedSyncFailures: []SyncFailureReport{{
+				Kind:           "SyncSet",
+				Name:           "failing-syncset",
+				FailureMessage: "apply failed",
+			}},
+			expectedMachinePools: []string{"infra", "worker"},
+		},
+		{
+			name: "hibernating",
+			existing: []runtime.Object{
+				cdBuilder.Build(
+					testcd.Installed(),
+					testcd.WithGCPPlatform(&hivev1gcp.Platform{Region: "us-central1"}),
+					testcd.WithPowerState(hivev1.ClusterPowerStateHibernating),
+					testcd.WithStatusPowerState(hivev1.ClusterPowerStateHibernating),
+				),
+			},
+			expectedPlatform:   constants.PlatformGCP,
+			expectedRegion:     "us-central1",
+			expectedInstalled:  true,
+			expectedPowerState: PowerStateReport{Desired: hivev1.ClusterPowerStateHibernating, Observed: hivev1.ClusterPowerStateHibernating},
+		},
+		{
+			name: "failed",
+			existing: []runtime.Object{
+				cdBuilder.Build(
+					testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ProvisionFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "AWSInsufficientCapacity",
+					}),
+				),
+				provisionBuilder.Build(testcp.Attempt(0), testcp.Failed()),
+				provisionBuilder.Build(
+					testcp.Attempt(1),
+					testcp.WithFailureClassification("AWSInsufficientCapacity", hivev1.InstallFailureCategoryQuota, true, "Retry later"),
+					func(provision *hivev1.ClusterProvision) {
+						provision.Spec.InfraID = pointer.StringPtr("test-cluster-abcde")
+						provision.Spec.InstallLog = pointer.StringPtr("line 1\nline 2\nline 3\n")
+					},
+				),
+			},
+			expectedPlatform:   constants.PlatformAWS,
+			expectedRegion:     "us-east-1",
+			expectedProblems:   []string{string(hivev1.ProvisionFailedCondition)},
+			expectedPowerState: PowerStateReport{},
+			expectedProvision: &ProvisionReport{
+				Name:    testCDName + "-01",
+				Attempt: 1,
+				Stage:   hivev1.ClusterProvisionStageFailed,
+				InfraID: "test-cluster-abcde",
+				FailureClassification: &hivev1.InstallFailureClassification{
+					Reason:      "AWSInsufficientCapacity",
+					Category:    hivev1.InstallFailureCategoryQuota,
+					Retryable:   true,
+					Remediation: "Retry later",
+				},
+				InstallLogTail: []string{"line 2", "line 3"},
+			},
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			opt := &DescribeClusterOptions{
+				Name:            testCDName,
+				Namespace:       testNamespace,
+				InstallLogLines: 2,
+			}
+
+			report, err := opt.ClusterReport(c)
+			require.NoError(t, err, "unexpected error gathering the report")
+
+			assert.Equal(t, testCDName, report.Name, "unexpected name")
+			assert.Equal(t, test.expectedPlatform, report.Platform, "unexpected platform")
+			assert.Equal(t, test.expectedRegion, report.Region, "unexpected region")
+			assert.Equal(t, test.expectedInstalled, report.Installed, "unexpected installed")
+			assert.Equal(t, test.expectedPowerState, report.PowerState, "unexpected power state")
+			var problems []string
+			for _, cond := range report.Conditions {
+				if cond.Problem {
+					problems = append(problems, cond.Type)
+				}
+			}
+			assert.Equal(t, test.expectedProblems, problems, "unexpected problem conditions")
+			assert.Equal(t, test.expectedProvision, report.Provision, "unexpected provision")
+			assert.Equal(t, test.expectedDNSZone, report.DNSZone, "unexpected DNSZone")
+			assert.Equal(t, test.expectedSyncFailures, report.SyncFailures, "unexpected sync failures")
+			var pools []string
+			for _, pool := range report.MachinePools {
+				pools = append(pools, pool.Name)
+			}
+			assert.Equal(t, test.expectedMachinePools, pools, "unexpected machine pools")
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package describe
+
+import "github.com/spf13/cobra"
+
+// NewDescribeCommand is the entrypoint to create the 'describe' subcommand
+func NewDescribeCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Utility to troubleshoot hive resources",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewDescribeClusterCommand())
+	return cmd
+
+}
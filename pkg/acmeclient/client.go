@@ -0,0 +1,433 @@
+package acmeclient
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+const (
+	// LetsEncryptDirectoryURL is the directory URL of the Let's Encrypt production ACME server.
+	LetsEncryptDirectoryURL = "https://acme-v02.api.letsencrypt.org/directory"
+
+	// Statuses of the ACME orders, authorizations and challenges.
+	StatusPending    = "pending"
+	StatusReady      = "ready"
+	StatusProcessing = "processing"
+	StatusValid      = "valid"
+	StatusInvalid    = "invalid"
+
+	// ChallengeTypeDNS01 is the type of the DNS-01 challenges, answered with a TXT record.
+	ChallengeTypeDNS01 = "dns-01"
+
+	defaultTimeout = 30 * time.Second
+
+	badNonceProblem = "urn:ietf:params:acme:error:badNonce"
+	joseContentType = "application/jose+json"
+)
+
+// Client is a wrapper object for talking to an ACME (RFC 8555) server to issue certificates. The account key is
+// an ECDSA P-256 key, and Register must be called before any other function.
+type Client interface {
+	// Register creates the account of the account key with the given contact email, or finds the existing one.
+	// It returns the URL of the account.
+	Register(email string) (string, error)
+
+	// CreateOrder creates an order for a certificate for the given DNS names.
+	CreateOrder(domains []string) (*Order, error)
+
+	// GetOrder returns the order with the given URL.
+	GetOrder(url string) (*Order, error)
+
+	// GetAuthorization returns the authorization with the given URL.
+	GetAuthorization(url string) (*Authorization, error)
+
+	// AcceptChallenge tells the server that the challenge with the given URL is ready to be validated.
+	AcceptChallenge(url string) (*Challenge, error)
+
+	// FinalizeOrder requests the certificate of a ready order, using the given DER-encoded CSR.
+	FinalizeOrder(finalizeURL string, csr []byte) (*Order, error)
+
+	// GetCertificate returns the PEM-encoded certificate chain with the given URL.
+	GetCertificate(url string) ([]byte, error)
+
+	// DNS01ChallengeRecord returns the value of the TXT record answering the DNS-01 challenge with the given token.
+	DNS01ChallengeRecord(token string) (string, error)
+}
+
+// Order is an ACME order of a certificate.
+type Order struct {
+	// URL is the URL of the order, which is not part of the order object returned by the server.
+	URL string `json:"-"`
+
+	Status         string       `json:"status"`
+	Identifiers    []Identifier `json:"identifiers"`
+	Authorizations []string     `json:"authorizations"`
+	Finalize       string       `json:"finalize"`
+	Certificate    string       `json:"certificate,omitempty"`
+	Error          *Problem     `json:"error,omitempty"`
+}
+
+// Identifier is an identifier, such as a DNS name, of an ACME order or authorization.
+type Identifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Authorization is the ACME authorization of the account for an identifier of an order.
+type Authorization struct {
+	Identifier Identifier  `json:"identifier"`
+	Status     string      `json:"status"`
+	Wildcard   bool        `json:"wildcard,omitempty"`
+	Challenges []Challenge `json:"challenges"`
+}
+
+// Challenge is an ACME challenge to prove the control of an identifier.
+type Challenge struct {
+	Type   string   `json:"type"`
+	URL    string   `json:"url"`
+	Token  string   `json:"token"`
+	Status string   `json:"status"`
+	Error  *Problem `json:"error,omitempty"`
+}
+
+// Problem is an error returned by the ACME server (RFC 7807).
+type Problem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	Status int    `json:"status,omitempty"`
+}
+
+func (p *Problem) Error() string {
+	return fmt.Sprintf("acme: %s: %s", p.Type, p.Detail)
+}
+
+// IsNotFound returns true if the error is an ACME problem for an object that does not exist, for example an
+// expired order.
+func IsNotFound(err error) bool {
+	var p *Problem
+	return errors.As(err, &p) && p.Status == http.StatusNotFound
+}
+
+type directory struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type acmeClient struct {
+	httpClient   *http.Client
+	directoryURL string
+	directory    *directory
+	key          *ecdsa.PrivateKey
+	accountURL   string
+	nonce        string
+}
+
+// NewClient creates our client wrapper object for the ACME server with the given directory URL, using the given
+// account key.
+func NewClient(directoryURL string, accountKey crypto.Signer) (Client, error) {
+	key, ok := accountKey.(*ecdsa.PrivateKey)
+	if !ok || key.Curve != elliptic.P256() {
+		return nil, errors.New("the account key must be an ECDSA P-256 key")
+	}
+	if directoryURL == "" {
+		directoryURL = LetsEncryptDirectoryURL
+	}
+	return &acmeClient{
+		httpClient:   &http.Client{Timeout: defaultTimeout},
+		directoryURL: directoryURL,
+		key:          key,
+	}, nil
+}
+
+// Register implements Client.Register.
+func (c *acmeClient) Register(email string) (string, error) {
+	dir, err := c.getDirectory()
+	if err != nil {
+		return "", err
+	}
+	req := map[string]interface{}{
+		"termsOfServiceAgreed": true,
+	}
+	if email != "" {
+		req["contact"] = []string{"mailto:" + email}
+	}
+	resp, err := c.post(dir.NewAccount, req, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to register the ACME account")
+	}
+	c.accountURL = resp.Header.Get("Location")
+	if c.accountURL == "" {
+		return "", errors.New("the ACME server did not return the URL of the account")
+	}
+	return c.accountURL, nil
+}
+
+// CreateOrder implements Client.CreateOrder.
+func (c *acmeClient) CreateOrder(domains []string) (*Order, error) {
+	dir, err := c.getDirectory()
+	if err != nil {
+		return nil, err
+	}
+	req := struct {
+		Identifiers []Identifier `json:"identifiers"`
+	}{}
+	for _, d := range domains {
+		req.Identifiers = append(req.Identifiers, Identifier{Type: "dns", Value: d})
+	}
+	order := &Order{}
+	resp, err := c.post(dir.NewOrder, req, order)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the ACME order")
+	}
+	order.URL = resp.Header.Get("Location")
+	return order, nil
+}
+
+// GetOrder implements Client.GetOrder.
+func (c *acmeClient) GetOrder(url string) (*Order, error) {
+	order := &Order{}
+	if _, err := c.post(url, nil, order); err != nil {
+		return nil, errors.Wrap(err, "failed to get the ACME order")
+	}
+	order.URL = url
+	return order, nil
+}
+
+// GetAuthorization implements Client.GetAuthorization.
+func (c *acmeClient) GetAuthorization(url string) (*Authorization, error) {
+	authz := &Authorization{}
+	if _, err := c.post(url, nil, authz); err != nil {
+		return nil, errors.Wrap(err, "failed to get the ACME authorization")
+	}
+	return authz, nil
+}
+
+// AcceptChallenge implements Client.AcceptChallenge.
+func (c *acmeClient) AcceptChallenge(url string) (*Challenge, error) {
+	chal := &Challenge{}
+	if _, err := c.post(url, struct{}{}, chal); err != nil {
+		return nil, errors.Wrap(err, "failed to accept the ACME challenge")
+	}
+	return chal, nil
+}
+
+// FinalizeOrder implements Client.FinalizeOrder.
+func (c *acmeClient) FinalizeOrder(finalizeURL string, csr []byte) (*Order, error) {
+	req := struct {
+		CSR string `json:"csr"`
+	}{
+		CSR: base64.RawURLEncoding.EncodeToString(csr),
+	}
+	order := &Order{}
+	resp, err := c.post(finalizeURL, req, order)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to finalize the ACME order")
+	}
+	order.URL = resp.Header.Get("Location")
+	return order, nil
+}
+
+// GetCertificate implements Client.GetCertificate.
+func (c *acmeClient) GetCertificate(url string) ([]byte, error) {
+	resp, err := c.post(url, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download the certificate")
+	}
+	return resp.body, nil
+}
+
+// DNS01ChallengeRecord implements Client.DNS01ChallengeRecord.
+func (c *acmeClient) DNS01ChallengeRecord(token string) (string, error) {
+	thumbprint, err := JWKThumbprint(&c.key.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256([]byte(token + "." + thumbprint))
+	return base64.RawURLEncoding.EncodeToString(digest[:]), nil
+}
+
+// JWKThumbprint returns the RFC 7638 thumbprint of the JWK of the ECDSA P-256 public key.
+func JWKThumbprint(pub *ecdsa.PublicKey) (string, error) {
+	jwk, err := jwkJSON(pub)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(jwk)
+	return base64.RawURLEncoding.EncodeToString(digest[:]), nil
+}
+
+// jwkJSON returns the JWK of the public key with its members in the lexicographic order required by the
+// thumbprint.
+func jwkJSON(pub *ecdsa.PublicKey) ([]byte, error) {
+	if pub.Curve != elliptic.P256() {
+		return nil, errors.New("only ECDSA P-256 keys are supported")
+	}
+	return []byte(fmt.Sprintf(`{"crv":"P-256","kty":"EC","x":"%s","y":"%s"}`,
+		base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, 32))),
+		base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, 32))))), nil
+}
+
+func (c *acmeClient) getDirectory() (*directory, error) {
+	if c.directory != nil {
+		return c.directory, nil
+	}
+	resp, err := c.httpClient.Get(c.directoryURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the ACME directory")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get the ACME directory: %s", resp.Status)
+	}
+	dir := &directory{}
+	if err := json.NewDecoder(resp.Body).Decode(dir); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the ACME directory")
+	}
+	c.directory = dir
+	return dir, nil
+}
+
+func (c *acmeClient) getNonce() (string, error) {
+	if c.nonce != "" {
+		nonce := c.nonce
+		c.nonce = ""
+		return nonce, nil
+	}
+	dir, err := c.getDirectory()
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient.Head(dir.NewNonce)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get a new nonce")
+	}
+	resp.Body.Close()
+	nonce := resp.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", errors.New("the ACME server did not return a nonce")
+	}
+	return nonce, nil
+}
+
+type response struct {
+	*http.Response
+	body []byte
+}
+
+// post sends the payload to the URL in a JWS signed with the account key, and decodes the JSON response into out
+// when it is not nil. A nil payload sends a POST-as-GET request. The request is retried once when the server
+// rejects the nonce.
+func (c *acmeClient) post(url string, payload interface{}, out interface{}) (*response, error) {
+	resp, err := c.postOnce(url, payload)
+	var p *Problem
+	if errors.As(err, &p) && p.Type == badNonceProblem {
+		resp, err = c.postOnce(url, payload)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if out != nil {
+		if err := json.Unmarshal(resp.body, out); err != nil {
+			return nil, errors.Wrap(err, "failed to decode the response of the ACME server")
+		}
+	}
+	return resp, nil
+}
+
+func (c *acmeClient) postOnce(url string, payload interface{}) (*response, error) {
+	nonce, err := c.getNonce()
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.signJWS(url, nonce, payload)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := c.httpClient.Post(url, joseContentType, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if nonce := httpResp.Header.Get("Replay-Nonce"); nonce != "" {
+		c.nonce = nonce
+	}
+	respBody, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode >= http.StatusBadRequest {
+		p := &Problem{}
+		if err := json.Unmarshal(respBody, p); err != nil || p.Type == "" {
+			return nil, fmt.Errorf("unexpected response from the ACME server: %s", httpResp.Status)
+		}
+		if p.Status == 0 {
+			p.Status = httpResp.StatusCode
+		}
+		return nil, p
+	}
+	return &response{Response: httpResp, body: respBody}, nil
+}
+
+// signJWS returns the flattened JSON serialization of the JWS of the payload, signed with ES256. The account URL
+// identifies the key once the account is registered, and the JWK of the key is used before.
+func (c *acmeClient) signJWS(url, nonce string, payload interface{}) ([]byte, error) {
+	protected := map[string]interface{}{
+		"alg":   "ES256",
+		"nonce": nonce,
+		"url":   url,
+	}
+	if c.accountURL != "" {
+		protected["kid"] = c.accountURL
+	} else {
+		jwk, err := jwkJSON(&c.key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		protected["jwk"] = json.RawMessage(jwk)
+	}
+	protectedJSON, err := json.Marshal(protected)
+	if err != nil {
+		return nil, err
+	}
+	var payloadJSON []byte
+	if payload != nil {
+		if payloadJSON, err = json.Marshal(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	protected64 := base64.RawURLEncoding.EncodeToString(protectedJSON)
+	payload64 := base64.RawURLEncoding.EncodeToString(payloadJSON)
+	digest := sha256.Sum256([]byte(protected64 + "." + payload64))
+	r, s, err := ecdsa.Sign(rand.Reader, c.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+
+	return json.Marshal(struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}{
+		Protected: protected64,
+		Payload:   payload64,
+		Signature: base64.RawURLEncoding.EncodeToString(signature),
+	})
+}
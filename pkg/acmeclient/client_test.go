@@ -0,0 +1,188 @@
+package acmeclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer is a minimal ACME server that verifies the signatures of the requests.
+type fakeServer struct {
+	*httptest.Server
+	t          *testing.T
+	key        *ecdsa.PublicKey
+	nonce      int
+	rejectNext bool
+	payloads   map[string][]byte
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	s := &fakeServer{t: t, payloads: map[string][]byte{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(directory{
+			NewNonce:   s.URL + "/new-nonce",
+			NewAccount: s.URL + "/new-account",
+			NewOrder:   s.URL + "/new-order",
+		})
+	})
+	mux.HandleFunc("/new-nonce", func(w http.ResponseWriter, r *http.Request) {
+		s.setNonce(w)
+	})
+	mux.HandleFunc("/new-account", s.handle(func(w http.ResponseWriter, payload []byte) {
+		w.Header().Set("Location", s.URL+"/account/1")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"status":"valid"}`)
+	}))
+	mux.HandleFunc("/new-order", s.handle(func(w http.ResponseWriter, payload []byte) {
+		w.Header().Set("Location", s.URL+"/order/1")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"status":"pending","authorizations":["%s/authz/1"],"finalize":"%s/order/1/finalize"}`, s.URL, s.URL)
+	}))
+	mux.HandleFunc("/authz/1", s.handle(func(w http.ResponseWriter, payload []byte) {
+		fmt.Fprintf(w, `{"status":"pending","identifier":{"type":"dns","value":"example.com"},"wildcard":true,`+
+			`"challenges":[{"type":"dns-01","url":"%s/chal/1","token":"token1","status":"pending"}]}`, s.URL)
+	}))
+	mux.HandleFunc("/order/2", s.handle(func(w http.ResponseWriter, payload []byte) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"type":"urn:ietf:params:acme:error:malformed","detail":"No order for ID 2"}`)
+	}))
+	mux.HandleFunc("/cert/1", s.handle(func(w http.ResponseWriter, payload []byte) {
+		fmt.Fprint(w, "-----BEGIN CERTIFICATE-----\n")
+	}))
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *fakeServer) setNonce(w http.ResponseWriter) {
+	s.nonce++
+	w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce%d", s.nonce))
+}
+
+func (s *fakeServer) handle(fn func(w http.ResponseWriter, payload []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(s.t, err)
+		jws := struct {
+			Protected string `json:"protected"`
+			Payload   string `json:"payload"`
+			Signature string `json:"signature"`
+		}{}
+		require.NoError(s.t, json.Unmarshal(body, &jws), "request is not a JWS")
+		protectedJSON, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+		require.NoError(s.t, err)
+		protected := struct {
+			Alg   string          `json:"alg"`
+			Nonce string          `json:"nonce"`
+			URL   string          `json:"url"`
+			KID   string          `json:"kid"`
+			JWK   json.RawMessage `json:"jwk"`
+		}{}
+		require.NoError(s.t, json.Unmarshal(protectedJSON, &protected))
+		assert.Equal(s.t, "ES256", protected.Alg, "unexpected algorithm")
+		assert.Equal(s.t, s.URL+r.URL.Path, protected.URL, "unexpected url in protected header")
+		assert.Equal(s.t, fmt.Sprintf("nonce%d", s.nonce), protected.Nonce, "unexpected nonce")
+		if r.URL.Path == "/new-account" {
+			assert.NotEmpty(s.t, protected.JWK, "expected jwk for new account")
+		} else {
+			assert.Equal(s.t, s.URL+"/account/1", protected.KID, "unexpected kid")
+		}
+
+		signature, err := base64.RawURLEncoding.DecodeString(jws.Signature)
+		require.NoError(s.t, err)
+		require.Len(s.t, signature, 64, "unexpected signature length")
+		digest := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+		assert.True(s.t, ecdsa.Verify(s.key, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])),
+			"invalid signature")
+
+		payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+		require.NoError(s.t, err)
+		s.payloads[r.URL.Path] = payload
+
+		s.setNonce(w)
+		if s.rejectNext {
+			s.rejectNext = false
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"type":"urn:ietf:params:acme:error:badNonce","detail":"bad nonce"}`)
+			return
+		}
+		fn(w, payload)
+	}
+}
+
+func TestClient(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	server := newFakeServer(t)
+	defer server.Close()
+	server.key = &key.PublicKey
+
+	client, err := NewClient(server.URL+"/directory", key)
+	require.NoError(t, err, "unexpected error creating client")
+
+	accountURL, err := client.Register("admin@example.com")
+	require.NoError(t, err, "unexpected error registering account")
+	assert.Equal(t, server.URL+"/account/1", accountURL, "unexpected account URL")
+	assert.JSONEq(t, `{"termsOfServiceAgreed":true,"contact":["mailto:admin@example.com"]}`, string(server.payloads["/new-account"]))
+
+	server.rejectNext = true
+	order, err := client.CreateOrder([]string{"*.example.com"})
+	require.NoError(t, err, "unexpected error creating order after bad nonce")
+	assert.Equal(t, server.URL+"/order/1", order.URL, "unexpected order URL")
+	assert.Equal(t, StatusPending, order.Status, "unexpected order status")
+	assert.Equal(t, []string{server.URL + "/authz/1"}, order.Authorizations, "unexpected authorizations")
+	assert.JSONEq(t, `{"identifiers":[{"type":"dns","value":"*.example.com"}]}`, string(server.payloads["/new-order"]))
+
+	authz, err := client.GetAuthorization(order.Authorizations[0])
+	require.NoError(t, err, "unexpected error getting authorization")
+	assert.True(t, authz.Wildcard, "expected wildcard authorization")
+	require.Len(t, authz.Challenges, 1, "unexpected challenges")
+	assert.Equal(t, ChallengeTypeDNS01, authz.Challenges[0].Type, "unexpected challenge type")
+	assert.Empty(t, server.payloads["/authz/1"], "expected POST-as-GET request")
+
+	_, err = client.GetOrder(server.URL + "/order/2")
+	assert.True(t, IsNotFound(err), "expected not found error, got %v", err)
+
+	cert, err := client.GetCertificate(server.URL + "/cert/1")
+	require.NoError(t, err, "unexpected error getting certificate")
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----\n", string(cert), "unexpected certificate")
+
+	record, err := client.DNS01ChallengeRecord("token1")
+	require.NoError(t, err, "unexpected error computing challenge record")
+	thumbprint, err := JWKThumbprint(&key.PublicKey)
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("token1." + thumbprint))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(digest[:]), record, "unexpected challenge record")
+}
+
+func TestNewClientRejectsUnsupportedKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, err = NewClient("", key)
+	assert.Error(t, err, "expected error for P-384 key")
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	// The JWK of this key is the P-256 example of RFC 7517 appendix A.1.
+	x, _ := base64.RawURLEncoding.DecodeString("MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4")
+	y, _ := base64.RawURLEncoding.DecodeString("4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM")
+	pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	jwk := fmt.Sprintf(`{"crv":"P-256","kty":"EC","x":"%s","y":"%s"}`,
+		"MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4", "4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM")
+	digest := sha256.Sum256([]byte(jwk))
+	thumbprint, err := JWKThumbprint(pub)
+	require.NoError(t, err)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(digest[:]), thumbprint, "unexpected thumbprint")
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./client.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	acmeclient "github.com/openshift/hive/pkg/acmeclient"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// AcceptChallenge mocks base method.
+func (m *MockClient) AcceptChallenge(url string) (*acmeclient.Challenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptChallenge", url)
+	ret0, _ := ret[0].(*acmeclient.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptChallenge indicates an expected call of AcceptChallenge.
+func (mr *MockClientMockRecorder) AcceptChallenge(url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptChallenge", reflect.TypeOf((*MockClient)(nil).AcceptChallenge), url)
+}
+
+// CreateOrder mocks base method.
+func (m *MockClient) CreateOrder(domains []string) (*acmeclient.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", domains)
+	ret0, _ := ret[0].(*acmeclient.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockClientMockRecorder) CreateOrder(domains interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockClient)(nil).CreateOrder), domains)
+}
+
+// DNS01ChallengeRecord mocks base method.
+func (m *MockClient) DNS01ChallengeRecord(token string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DNS01ChallengeRecord", token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DNS01ChallengeRecord indicates an expected call of DNS01ChallengeRecord.
+func (mr *MockClientMockRecorder) DNS01ChallengeRecord(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DNS01ChallengeRecord", reflect.TypeOf((*MockClient)(nil).DNS01ChallengeRecord), token)
+}
+
+// FinalizeOrder mocks base method.
+func (m *MockClient) FinalizeOrder(finalizeURL string, csr []byte) (*acmeclient.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinalizeOrder", finalizeURL, csr)
+	ret0, _ := ret[0].(*acmeclient.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinalizeOrder indicates an expected call of FinalizeOrder.
+func (mr *MockClientMockRecorder) FinalizeOrder(finalizeURL, csr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizeOrder", reflect.TypeOf((*MockClient)(nil).FinalizeOrder), finalizeURL, csr)
+}
+
+// GetAuthorization mocks base method.
+func (m *MockClient) GetAuthorization(url string) (*acmeclient.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorization", url)
+	ret0, _ := ret[0].(*acmeclient.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorization indicates an expected call of GetAuthorization.
+func (mr *MockClientMockRecorder) GetAuthorization(url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorization", reflect.TypeOf((*MockClient)(nil).GetAuthorization), url)
+}
+
+// GetCertificate mocks base method.
+func (m *MockClient) GetCertificate(url string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificate", url)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificate indicates an expected call of GetCertificate.
+func (mr *MockClientMockRecorder) GetCertificate(url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificate", reflect.TypeOf((*MockClient)(nil).GetCertificate), url)
+}
+
+// GetOrder mocks base method.
+func (m *MockClient) GetOrder(url string) (*acmeclient.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", url)
+	ret0, _ := ret[0].(*acmeclient.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockClientMockRecorder) GetOrder(url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockClient)(nil).GetOrder), url)
+}
+
+// Register mocks base method.
+func (m *MockClient) Register(email string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", email)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockClientMockRecorder) Register(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockClient)(nil).Register), email)
+}
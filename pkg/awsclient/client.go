@@ -33,6 +33,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
@@ -78,10 +80,18 @@ type Client interface {
 	DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error)
 	CreateVpcEndpoint(*ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error)
 	DeleteVpcEndpoints(*ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
+	DescribeInstanceTypes(*ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error)
+	DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
+	DescribeAddresses(*ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error)
+	DescribeNatGateways(*ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error)
 
 	// ELBV2
 	DescribeLoadBalancers(*elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
 
+	// Service Quotas
+	GetServiceQuota(*servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error)
+	GetAWSDefaultServiceQuota(*servicequotas.GetAWSDefaultServiceQuotaInput) (*servicequotas.GetAWSDefaultServiceQuotaOutput, error)
+
 	// S3 Manager
 	Upload(*s3manager.UploadInput) (*s3manager.UploadOutput, error)
 
@@ -118,6 +128,7 @@ type awsClient struct {
 	s3Uploader    *s3manager.Uploader
 	stsClient     stsiface.STSAPI
 	tagClient     *resourcegroupstaggingapi.ResourceGroupsTaggingAPI
+	quotasClient  servicequotasiface.ServiceQuotasAPI
 }
 
 func (c *awsClient) DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
@@ -210,6 +221,36 @@ func (c *awsClient) DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2
 	return c.ec2Client.DeleteVpcEndpoints(input)
 }
 
+func (c *awsClient) DescribeInstanceTypes(input *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeInstanceTypes").Inc()
+	return c.ec2Client.DescribeInstanceTypes(input)
+}
+
+func (c *awsClient) DescribeVpcs(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeVpcs").Inc()
+	return c.ec2Client.DescribeVpcs(input)
+}
+
+func (c *awsClient) DescribeAddresses(input *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeAddresses").Inc()
+	return c.ec2Client.DescribeAddresses(input)
+}
+
+func (c *awsClient) DescribeNatGateways(input *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeNatGateways").Inc()
+	return c.ec2Client.DescribeNatGateways(input)
+}
+
+func (c *awsClient) GetServiceQuota(input *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetServiceQuota").Inc()
+	return c.quotasClient.GetServiceQuota(input)
+}
+
+func (c *awsClient) GetAWSDefaultServiceQuota(input *servicequotas.GetAWSDefaultServiceQuotaInput) (*servicequotas.GetAWSDefaultServiceQuotaOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetAWSDefaultServiceQuota").Inc()
+	return c.quotasClient.GetAWSDefaultServiceQuota(input)
+}
+
 func (c *awsClient) DescribeLoadBalancers(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeLoadBalancers").Inc()
 	return c.elbv2Client.DescribeLoadBalancers(input)
@@ -407,11 +448,51 @@ func New(kubeClient client.Client, options Options) (Client, error) {
 	return NewClientFromSecret(nil, options.Region)
 }
 
+// NewSession creates an AWS session using the provided options. It loads credentials in the
+// same way as New, for callers that need to build their own AWS service clients.
+func NewSession(kubeClient client.Client, options Options) (*session.Session, error) {
+	source := options.CredentialsSource
+	switch {
+	case source.Secret != nil && source.Secret.Ref != nil && source.Secret.Ref.Name != "":
+		secret := &corev1.Secret{}
+		err := kubeClient.Get(context.TODO(),
+			types.NamespacedName{
+				Name:      source.Secret.Ref.Name,
+				Namespace: source.Secret.Namespace,
+			},
+			secret)
+		if err != nil {
+			return nil, err
+		}
+		return NewSessionFromSecret(secret, options.Region)
+	case source.AssumeRole != nil && source.AssumeRole.Role != nil && source.AssumeRole.Role.RoleARN != "":
+		return newSessionAssumeRole(kubeClient,
+			source.AssumeRole.SecretRef.Name, source.AssumeRole.SecretRef.Namespace,
+			source.AssumeRole.Role,
+			options.Region,
+		)
+	}
+
+	return NewSessionFromSecret(nil, options.Region)
+}
+
 func newClientAssumeRole(kubeClient client.Client,
 	serviceProviderSecretName, serviceProviderSecretNamespace string,
 	role *hivev1aws.AssumeRole,
 	region string,
 ) (Client, error) {
+	sess, err := newSessionAssumeRole(kubeClient, serviceProviderSecretName, serviceProviderSecretNamespace, role, region)
+	if err != nil {
+		return nil, err
+	}
+	return newClientFromSession(sess)
+}
+
+func newSessionAssumeRole(kubeClient client.Client,
+	serviceProviderSecretName, serviceProviderSecretNamespace string,
+	role *hivev1aws.AssumeRole,
+	region string,
+) (*session.Session, error) {
 	var secret *corev1.Secret
 	if serviceProviderSecretName != "" {
 		secret = &corev1.Secret{}
@@ -439,7 +520,7 @@ func newClientAssumeRole(kubeClient client.Client,
 		}
 	})
 
-	return newClientFromSession(sess)
+	return sess, nil
 }
 
 // NewClient creates our client wrapper object for the actual AWS clients we use.
@@ -484,6 +565,11 @@ func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
 	return newClientFromSession(s)
 }
 
+// NewClientFromSession creates our client wrapper object for the actual AWS clients we use from an existing session.
+func NewClientFromSession(s *session.Session) (Client, error) {
+	return newClientFromSession(s)
+}
+
 func newClientFromSession(s *session.Session, cfgs ...*aws.Config) (Client, error) {
 	return &awsClient{
 		ec2Client:     ec2.New(s, cfgs...),
@@ -494,6 +580,7 @@ func newClientFromSession(s *session.Session, cfgs ...*aws.Config) (Client, erro
 		route53Client: route53.New(s, cfgs...),
 		stsClient:     sts.New(s, cfgs...),
 		tagClient:     resourcegroupstaggingapi.New(s, cfgs...),
+		quotasClient:  servicequotas.New(s, cfgs...),
 	}, nil
 }
 
@@ -3,10 +3,16 @@ package azureclient
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2017-10-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
@@ -24,7 +30,7 @@ type Client interface {
 	ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error)
 
 	// Zones
-	CreateOrUpdateZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error)
+	CreateOrUpdateZone(ctx context.Context, resourceGroupName string, zone string, tags map[string]string) (dns.Zone, error)
 	DeleteZone(ctx context.Context, resourceGroupName string, zone string) error
 	GetZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error)
 
@@ -37,8 +43,42 @@ type Client interface {
 	ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error)
 	DeallocateVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesDeallocateFuture, error)
 	StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error)
+
+	// Blobs
+	UploadBlob(ctx context.Context, storageAccount, container, name string, content []byte) error
+
+	// Usages
+	ListComputeUsages(ctx context.Context, location string) (ComputeUsagesPage, error)
+	ListNetworkUsages(ctx context.Context, location string) (NetworkUsagesPage, error)
+
+	// Networking
+	GetLoadBalancer(ctx context.Context, resourceGroupName, loadBalancerName string) (network.LoadBalancer, error)
+	GetNetworkInterface(ctx context.Context, resourceGroupName, networkInterfaceName string) (network.Interface, error)
+	GetSubnet(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) (*Subnet, error)
+	DisableSubnetPrivateLinkServiceNetworkPolicies(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) error
+
+	// Private Link
+	GetPrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) (*PrivateLinkService, error)
+	CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName string, service *PrivateLinkService) (*PrivateLinkService, error)
+	DeletePrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) error
+	GetPrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) (*PrivateEndpoint, error)
+	CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName string, endpoint *PrivateEndpoint) (*PrivateEndpoint, error)
+	DeletePrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) error
+
+	// Private DNS
+	GetPrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error)
+	CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName, zone string, tags map[string]string) (privatedns.PrivateZone, error)
+	DeletePrivateZone(ctx context.Context, resourceGroupName, zone string) error
+	CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error)
+	ListVirtualNetworkLinks(ctx context.Context, resourceGroupName, zone string) ([]privatedns.VirtualNetworkLink, error)
+	CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName, virtualNetworkID string) error
+	DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName string) error
 }
 
+// blobServiceVersion is the version of the Azure Blob Storage REST API used to upload blobs. Authorizing with an
+// Azure AD token requires version 2017-11-09 or later.
+const blobServiceVersion = "2019-12-12"
+
 // ResourceSKUsPage is a page of results from listing resource SKUs.
 type ResourceSKUsPage interface {
 	NextWithContext(ctx context.Context) error
@@ -53,11 +93,129 @@ type RecordSetPage interface {
 	Values() []dns.RecordSet
 }
 
+// ComputeUsagesPage is a page of results from listing the compute resource usages of a location.
+type ComputeUsagesPage interface {
+	NextWithContext(ctx context.Context) error
+	NotDone() bool
+	Values() []compute.Usage
+}
+
+// NetworkUsagesPage is a page of results from listing the network resource usages of a location.
+type NetworkUsagesPage interface {
+	NextWithContext(ctx context.Context) error
+	NotDone() bool
+	Values() []network.Usage
+}
+
+// SubResource is a reference to another Azure resource.
+type SubResource struct {
+	ID string `json:"id,omitempty"`
+}
+
+// Subnet is an Azure subnet, with only the properties used for Private Link.
+type Subnet struct {
+	ID         string           `json:"id,omitempty"`
+	Name       string           `json:"name,omitempty"`
+	Properties SubnetProperties `json:"properties"`
+}
+
+// SubnetProperties are the properties of a Subnet.
+type SubnetProperties struct {
+	AddressPrefix                     string        `json:"addressPrefix,omitempty"`
+	PrivateEndpoints                  []SubResource `json:"privateEndpoints,omitempty"`
+	PrivateLinkServiceNetworkPolicies string        `json:"privateLinkServiceNetworkPolicies,omitempty"`
+}
+
+// PrivateLinkService is an Azure Private Link Service.
+type PrivateLinkService struct {
+	ID         string                       `json:"id,omitempty"`
+	Name       string                       `json:"name,omitempty"`
+	Location   string                       `json:"location,omitempty"`
+	Tags       map[string]string            `json:"tags,omitempty"`
+	Properties PrivateLinkServiceProperties `json:"properties"`
+}
+
+// PrivateLinkServiceProperties are the properties of a PrivateLinkService.
+type PrivateLinkServiceProperties struct {
+	LoadBalancerFrontendIPConfigurations []SubResource                       `json:"loadBalancerFrontendIpConfigurations,omitempty"`
+	IPConfigurations                     []PrivateLinkServiceIPConfiguration `json:"ipConfigurations,omitempty"`
+	Visibility                           *PrivateLinkServiceSubscriptions    `json:"visibility,omitempty"`
+	AutoApproval                         *PrivateLinkServiceSubscriptions    `json:"autoApproval,omitempty"`
+	Alias                                string                              `json:"alias,omitempty"`
+	ProvisioningState                    string                              `json:"provisioningState,omitempty"`
+}
+
+// PrivateLinkServiceIPConfiguration is a NAT IP configuration of a PrivateLinkService.
+type PrivateLinkServiceIPConfiguration struct {
+	Name       string                                      `json:"name"`
+	Properties PrivateLinkServiceIPConfigurationProperties `json:"properties"`
+}
+
+// PrivateLinkServiceIPConfigurationProperties are the properties of a PrivateLinkServiceIPConfiguration.
+type PrivateLinkServiceIPConfigurationProperties struct {
+	PrivateIPAllocationMethod string       `json:"privateIPAllocationMethod,omitempty"`
+	Subnet                    *SubResource `json:"subnet,omitempty"`
+	Primary                   bool         `json:"primary,omitempty"`
+}
+
+// PrivateLinkServiceSubscriptions is a list of subscriptions a PrivateLinkService is visible to or automatically
+// approves the connections from.
+type PrivateLinkServiceSubscriptions struct {
+	Subscriptions []string `json:"subscriptions"`
+}
+
+// PrivateEndpoint is an Azure Private Endpoint.
+type PrivateEndpoint struct {
+	ID         string                    `json:"id,omitempty"`
+	Name       string                    `json:"name,omitempty"`
+	Location   string                    `json:"location,omitempty"`
+	Tags       map[string]string         `json:"tags,omitempty"`
+	Properties PrivateEndpointProperties `json:"properties"`
+}
+
+// PrivateEndpointProperties are the properties of a PrivateEndpoint.
+type PrivateEndpointProperties struct {
+	Subnet                        *SubResource                   `json:"subnet,omitempty"`
+	PrivateLinkServiceConnections []PrivateLinkServiceConnection `json:"privateLinkServiceConnections,omitempty"`
+	NetworkInterfaces             []SubResource                  `json:"networkInterfaces,omitempty"`
+	ProvisioningState             string                         `json:"provisioningState,omitempty"`
+}
+
+// PrivateLinkServiceConnection is the connection of a PrivateEndpoint to a PrivateLinkService.
+type PrivateLinkServiceConnection struct {
+	Name       string                                 `json:"name"`
+	Properties PrivateLinkServiceConnectionProperties `json:"properties"`
+}
+
+// PrivateLinkServiceConnectionProperties are the properties of a PrivateLinkServiceConnection.
+type PrivateLinkServiceConnectionProperties struct {
+	PrivateLinkServiceID              string                             `json:"privateLinkServiceId"`
+	PrivateLinkServiceConnectionState *PrivateLinkServiceConnectionState `json:"privateLinkServiceConnectionState,omitempty"`
+}
+
+// PrivateLinkServiceConnectionState is the state of a PrivateLinkServiceConnection.
+type PrivateLinkServiceConnectionState struct {
+	Status      string `json:"status,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
 type azureClient struct {
-	resourceSKUsClient    *compute.ResourceSkusClient
-	recordSetsClient      *dns.RecordSetsClient
-	zonesClient           *dns.ZonesClient
-	virtualMachinesClient *compute.VirtualMachinesClient
+	resourceSKUsClient        *compute.ResourceSkusClient
+	recordSetsClient          *dns.RecordSetsClient
+	zonesClient               *dns.ZonesClient
+	virtualMachinesClient     *compute.VirtualMachinesClient
+	computeUsageClient        *compute.UsageClient
+	networkUsagesClient       *network.UsagesClient
+	loadBalancersClient       *network.LoadBalancersClient
+	interfacesClient          *network.InterfacesClient
+	privateZonesClient        *privatedns.PrivateZonesClient
+	privateRecordSetsClient   *privatedns.RecordSetsClient
+	virtualNetworkLinksClient *privatedns.VirtualNetworkLinksClient
+	resourceManagerClient     *autorest.Client
+	resourceManagerEndpoint   string
+	subscriptionID            string
+	blobClient                *autorest.Client
+	storageEndpointSuffix     string
 }
 
 func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error) {
@@ -65,9 +223,14 @@ func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (Reso
 	return &page, err
 }
 
-func (c *azureClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error) {
+func (c *azureClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName string, zone string, tags map[string]string) (dns.Zone, error) {
+	var zoneTags map[string]*string
+	if len(tags) > 0 {
+		zoneTags = *to.StringMapPtr(tags)
+	}
 	return c.zonesClient.CreateOrUpdate(ctx, resourceGroupName, zone, dns.Zone{
 		Location: to.StringPtr("global"),
+		Tags:     zoneTags,
 		ZoneProperties: &dns.ZoneProperties{
 			ZoneType: dns.Public,
 		},
@@ -113,6 +276,256 @@ func (c *azureClient) StartVirtualMachine(ctx context.Context, resourceGroup, na
 	return c.virtualMachinesClient.Start(ctx, resourceGroup, name)
 }
 
+func (c *azureClient) ListComputeUsages(ctx context.Context, location string) (ComputeUsagesPage, error) {
+	page, err := c.computeUsageClient.List(ctx, location)
+	return &page, err
+}
+
+func (c *azureClient) ListNetworkUsages(ctx context.Context, location string) (NetworkUsagesPage, error) {
+	page, err := c.networkUsagesClient.List(ctx, location)
+	return &page, err
+}
+
+func (c *azureClient) GetLoadBalancer(ctx context.Context, resourceGroupName, loadBalancerName string) (network.LoadBalancer, error) {
+	return c.loadBalancersClient.Get(ctx, resourceGroupName, loadBalancerName, "")
+}
+
+func (c *azureClient) GetNetworkInterface(ctx context.Context, resourceGroupName, networkInterfaceName string) (network.Interface, error) {
+	return c.interfacesClient.Get(ctx, resourceGroupName, networkInterfaceName, "")
+}
+
+func (c *azureClient) GetSubnet(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) (*Subnet, error) {
+	subnet := &Subnet{}
+	if err := c.resourceManagerRequest(ctx, http.MethodGet,
+		subnetPath(c.subscriptionID, resourceGroupName, virtualNetworkName, subnetName), nil, subnet); err != nil {
+		return nil, err
+	}
+	return subnet, nil
+}
+
+func (c *azureClient) DisableSubnetPrivateLinkServiceNetworkPolicies(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) error {
+	resourcePath := subnetPath(c.subscriptionID, resourceGroupName, virtualNetworkName, subnetName)
+	// The subnet is read and written back as is so that none of its properties unknown to Subnet are lost.
+	var subnet map[string]interface{}
+	if err := c.resourceManagerRequest(ctx, http.MethodGet, resourcePath, nil, &subnet); err != nil {
+		return err
+	}
+	properties, _ := subnet["properties"].(map[string]interface{})
+	if properties == nil {
+		properties = map[string]interface{}{}
+		subnet["properties"] = properties
+	}
+	if properties["privateLinkServiceNetworkPolicies"] == "Disabled" {
+		return nil
+	}
+	properties["privateLinkServiceNetworkPolicies"] = "Disabled"
+	return c.resourceManagerRequest(ctx, http.MethodPut, resourcePath, subnet, nil)
+}
+
+func (c *azureClient) GetPrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) (*PrivateLinkService, error) {
+	service := &PrivateLinkService{}
+	if err := c.resourceManagerRequest(ctx, http.MethodGet,
+		networkResourcePath(c.subscriptionID, resourceGroupName, "privateLinkServices", serviceName), nil, service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+func (c *azureClient) CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName string, service *PrivateLinkService) (*PrivateLinkService, error) {
+	if err := c.resourceManagerRequest(ctx, http.MethodPut,
+		networkResourcePath(c.subscriptionID, resourceGroupName, "privateLinkServices", service.Name), service, nil); err != nil {
+		return nil, err
+	}
+	return c.GetPrivateLinkService(ctx, resourceGroupName, service.Name)
+}
+
+func (c *azureClient) DeletePrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) error {
+	return c.resourceManagerRequest(ctx, http.MethodDelete,
+		networkResourcePath(c.subscriptionID, resourceGroupName, "privateLinkServices", serviceName), nil, nil)
+}
+
+func (c *azureClient) GetPrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) (*PrivateEndpoint, error) {
+	endpoint := &PrivateEndpoint{}
+	if err := c.resourceManagerRequest(ctx, http.MethodGet,
+		networkResourcePath(c.subscriptionID, resourceGroupName, "privateEndpoints", endpointName), nil, endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (c *azureClient) CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName string, endpoint *PrivateEndpoint) (*PrivateEndpoint, error) {
+	if err := c.resourceManagerRequest(ctx, http.MethodPut,
+		networkResourcePath(c.subscriptionID, resourceGroupName, "privateEndpoints", endpoint.Name), endpoint, nil); err != nil {
+		return nil, err
+	}
+	return c.GetPrivateEndpoint(ctx, resourceGroupName, endpoint.Name)
+}
+
+func (c *azureClient) DeletePrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) error {
+	return c.resourceManagerRequest(ctx, http.MethodDelete,
+		networkResourcePath(c.subscriptionID, resourceGroupName, "privateEndpoints", endpointName), nil, nil)
+}
+
+func (c *azureClient) GetPrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error) {
+	return c.privateZonesClient.Get(ctx, resourceGroupName, zone)
+}
+
+func (c *azureClient) CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName, zone string, tags map[string]string) (privatedns.PrivateZone, error) {
+	var zoneTags map[string]*string
+	if len(tags) > 0 {
+		zoneTags = *to.StringMapPtr(tags)
+	}
+	future, err := c.privateZonesClient.CreateOrUpdate(ctx, resourceGroupName, zone, privatedns.PrivateZone{
+		Location: to.StringPtr("global"),
+		Tags:     zoneTags,
+	}, "", "")
+	if err != nil {
+		return privatedns.PrivateZone{}, err
+	}
+	if err := future.WaitForCompletionRef(ctx, c.privateZonesClient.Client); err != nil {
+		return privatedns.PrivateZone{}, err
+	}
+	return future.Result(*c.privateZonesClient)
+}
+
+func (c *azureClient) DeletePrivateZone(ctx context.Context, resourceGroupName, zone string) error {
+	future, err := c.privateZonesClient.Delete(ctx, resourceGroupName, zone, "")
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(ctx, c.privateZonesClient.Client)
+}
+
+func (c *azureClient) CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error) {
+	return c.privateRecordSetsClient.CreateOrUpdate(ctx, resourceGroupName, zone, recordType, recordSetName, recordSet, "", "")
+}
+
+func (c *azureClient) ListVirtualNetworkLinks(ctx context.Context, resourceGroupName, zone string) ([]privatedns.VirtualNetworkLink, error) {
+	var links []privatedns.VirtualNetworkLink
+	iter, err := c.virtualNetworkLinksClient.ListComplete(ctx, resourceGroupName, zone, nil)
+	if err != nil {
+		return nil, err
+	}
+	for ; iter.NotDone(); err = iter.NextWithContext(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, iter.Value())
+	}
+	return links, nil
+}
+
+func (c *azureClient) CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName, virtualNetworkID string) error {
+	future, err := c.virtualNetworkLinksClient.CreateOrUpdate(ctx, resourceGroupName, zone, linkName, privatedns.VirtualNetworkLink{
+		Location: to.StringPtr("global"),
+		VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+			VirtualNetwork:      &privatedns.SubResource{ID: to.StringPtr(virtualNetworkID)},
+			RegistrationEnabled: to.BoolPtr(false),
+		},
+	}, "", "")
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(ctx, c.virtualNetworkLinksClient.Client)
+}
+
+func (c *azureClient) DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName string) error {
+	future, err := c.virtualNetworkLinksClient.Delete(ctx, resourceGroupName, zone, linkName, "")
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(ctx, c.virtualNetworkLinksClient.Client)
+}
+
+// resourceManagerRequest sends a request for the resource at resourcePath to the resource manager, waiting for
+// the completion of the long running operations started by PUT and DELETE requests. The body of the response is
+// decoded into out when out is not nil.
+func (c *azureClient) resourceManagerRequest(ctx context.Context, method, resourcePath string, in, out interface{}) error {
+	decorators := []autorest.PrepareDecorator{
+		autorest.WithMethod(method),
+		autorest.WithBaseURL(c.resourceManagerEndpoint),
+		autorest.WithPath(resourcePath),
+		autorest.WithQueryParameters(map[string]interface{}{"api-version": privateLinkAPIVersion}),
+	}
+	if in != nil {
+		decorators = append(decorators, autorest.AsContentType("application/json; charset=utf-8"), autorest.WithJSON(in))
+	}
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), decorators...)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "azureclient", resourcePath, nil, "Failure preparing request")
+	}
+	resp, err := c.resourceManagerClient.Send(req, azure.DoRetryWithRegistration(*c.resourceManagerClient))
+	if err != nil {
+		return autorest.NewErrorWithError(err, "azureclient", resourcePath, resp, "Failure sending request")
+	}
+
+	if method == http.MethodGet {
+		if err := autorest.Respond(resp,
+			azure.WithErrorUnlessStatusCode(http.StatusOK),
+			autorest.ByUnmarshallingJSON(out),
+			autorest.ByClosing()); err != nil {
+			return autorest.NewErrorWithError(err, "azureclient", resourcePath, resp, "Failure responding to request")
+		}
+		return nil
+	}
+
+	future, err := azure.NewFutureFromResponse(resp)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "azureclient", resourcePath, resp, "Failure responding to request")
+	}
+	if err := future.WaitForCompletionRef(ctx, *c.resourceManagerClient); err != nil {
+		return err
+	}
+	return nil
+}
+
+// privateLinkAPIVersion is the version of the network resource manager API used for the resources that are not
+// available in the vendored network API version.
+const privateLinkAPIVersion = "2020-11-01"
+
+func networkResourcePath(subscriptionID, resourceGroupName, resourceType, name string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/%s/%s",
+		url.PathEscape(subscriptionID), url.PathEscape(resourceGroupName), resourceType, url.PathEscape(name))
+}
+
+func subnetPath(subscriptionID, resourceGroupName, virtualNetworkName, subnetName string) string {
+	return networkResourcePath(subscriptionID, resourceGroupName, "virtualNetworks", virtualNetworkName) +
+		"/subnets/" + url.PathEscape(subnetName)
+}
+
+// IsNotFound returns true when the error is a response from Azure for a resource that does not exist.
+func IsNotFound(err error) bool {
+	var detailedErr autorest.DetailedError
+	if errors.As(err, &detailedErr) && detailedErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	var requestErr *azure.RequestError
+	return errors.As(err, &requestErr) && requestErr.StatusCode == http.StatusNotFound
+}
+
+func (c *azureClient) UploadBlob(ctx context.Context, storageAccount, container, name string, content []byte) error {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	blobURL := fmt.Sprintf("https://%s.blob.%s/%s/%s",
+		storageAccount, c.storageEndpointSuffix, url.PathEscape(container), strings.Join(segments, "/"))
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+		autorest.AsPut(),
+		autorest.WithBaseURL(blobURL),
+		autorest.WithHeader("x-ms-blob-type", "BlockBlob"),
+		autorest.WithHeader("x-ms-version", blobServiceVersion),
+		autorest.WithBytes(&content))
+	if err != nil {
+		return err
+	}
+	resp, err := c.blobClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload blob %s to container %s", name, container)
+	}
+	return autorest.Respond(resp, azure.WithErrorUnlessStatusCode(http.StatusCreated), autorest.ByClosing())
+}
+
 // NewClientFromSecret creates our client wrapper object for interacting with Azure. The Azure creds are read from the
 // specified secret.
 func NewClientFromSecret(secret *corev1.Secret, environmentName string) (Client, error) {
@@ -182,11 +595,57 @@ func newClient(authJSONSource func() ([]byte, error), environmentName string) (*
 	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
 	virtualMachinesClient.Authorizer = authorizer
 
+	computeUsageClient := compute.NewUsageClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	computeUsageClient.Authorizer = authorizer
+
+	networkUsagesClient := network.NewUsagesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	networkUsagesClient.Authorizer = authorizer
+
+	loadBalancersClient := network.NewLoadBalancersClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	loadBalancersClient.Authorizer = authorizer
+
+	interfacesClient := network.NewInterfacesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	interfacesClient.Authorizer = authorizer
+
+	privateZonesClient := privatedns.NewPrivateZonesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	privateZonesClient.Authorizer = authorizer
+
+	privateRecordSetsClient := privatedns.NewRecordSetsClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	privateRecordSetsClient.Authorizer = authorizer
+
+	virtualNetworkLinksClient := privatedns.NewVirtualNetworkLinksClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	virtualNetworkLinksClient.Authorizer = authorizer
+
+	// The resources that are not available in the vendored network API version are managed with
+	// plain resource manager requests.
+	resourceManagerClient := autorest.NewClientWithUserAgent("openshift.io hive/v1")
+	resourceManagerClient.Authorizer = authorizer
+
+	// Blob storage takes tokens for the storage resource rather than the resource manager.
+	storageAuthorizer, err := getAuthorizerForResource(clientID, clientSecret, tenantID, env.ResourceIdentifiers.Storage, env)
+	if err != nil {
+		return nil, err
+	}
+	blobClient := autorest.NewClientWithUserAgent("openshift.io hive/v1")
+	blobClient.Authorizer = storageAuthorizer
+
 	return &azureClient{
-		resourceSKUsClient:    &resourceSKUsClient,
-		recordSetsClient:      &recordSetsClient,
-		zonesClient:           &zonesClient,
-		virtualMachinesClient: &virtualMachinesClient,
+		resourceSKUsClient:        &resourceSKUsClient,
+		recordSetsClient:          &recordSetsClient,
+		zonesClient:               &zonesClient,
+		virtualMachinesClient:     &virtualMachinesClient,
+		computeUsageClient:        &computeUsageClient,
+		networkUsagesClient:       &networkUsagesClient,
+		loadBalancersClient:       &loadBalancersClient,
+		interfacesClient:          &interfacesClient,
+		privateZonesClient:        &privateZonesClient,
+		privateRecordSetsClient:   &privateRecordSetsClient,
+		virtualNetworkLinksClient: &virtualNetworkLinksClient,
+		resourceManagerClient:     &resourceManagerClient,
+		resourceManagerEndpoint:   env.ResourceManagerEndpoint,
+		subscriptionID:            subscriptionID,
+		blobClient:                &blobClient,
+		storageEndpointSuffix:     env.StorageEndpointSuffix,
 	}, nil
 }
 
@@ -213,8 +672,12 @@ func authJSONFromFileSource(filename string) func() ([]byte, error) {
 }
 
 func getAuthorizer(clientID, clientSecret, tenantID string, env azure.Environment) (autorest.Authorizer, error) {
+	return getAuthorizerForResource(clientID, clientSecret, tenantID, env.ResourceManagerEndpoint, env)
+}
+
+func getAuthorizerForResource(clientID, clientSecret, tenantID, resource string, env azure.Environment) (autorest.Authorizer, error) {
 	config := auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
-	config.Resource = env.ResourceManagerEndpoint
+	config.Resource = resource
 	config.AADEndpoint = env.ActiveDirectoryEndpoint
 	return config.Authorizer()
 }
@@ -10,6 +10,8 @@ import (
 
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	dns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	network "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2017-10-01/network"
+	privatedns "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	gomock "github.com/golang/mock/gomock"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 )
@@ -37,6 +39,66 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CreateOrUpdatePrivateEndpoint mocks base method.
+func (m *MockClient) CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName string, endpoint *azureclient.PrivateEndpoint) (*azureclient.PrivateEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateEndpoint", ctx, resourceGroupName, endpoint)
+	ret0, _ := ret[0].(*azureclient.PrivateEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateEndpoint indicates an expected call of CreateOrUpdatePrivateEndpoint.
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateEndpoint(ctx, resourceGroupName, endpoint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateEndpoint", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateEndpoint), ctx, resourceGroupName, endpoint)
+}
+
+// CreateOrUpdatePrivateLinkService mocks base method.
+func (m *MockClient) CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName string, service *azureclient.PrivateLinkService) (*azureclient.PrivateLinkService, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateLinkService", ctx, resourceGroupName, service)
+	ret0, _ := ret[0].(*azureclient.PrivateLinkService)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateLinkService indicates an expected call of CreateOrUpdatePrivateLinkService.
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateLinkService(ctx, resourceGroupName, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateLinkService", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateLinkService), ctx, resourceGroupName, service)
+}
+
+// CreateOrUpdatePrivateRecordSet mocks base method.
+func (m *MockClient) CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateRecordSet", ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
+	ret0, _ := ret[0].(privatedns.RecordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateRecordSet indicates an expected call of CreateOrUpdatePrivateRecordSet.
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateRecordSet(ctx, resourceGroupName, zone, recordSetName, recordType, recordSet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateRecordSet", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
+}
+
+// CreateOrUpdatePrivateZone mocks base method.
+func (m *MockClient) CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName, zone string, tags map[string]string) (privatedns.PrivateZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateZone", ctx, resourceGroupName, zone, tags)
+	ret0, _ := ret[0].(privatedns.PrivateZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateZone indicates an expected call of CreateOrUpdatePrivateZone.
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateZone(ctx, resourceGroupName, zone, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateZone", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateZone), ctx, resourceGroupName, zone, tags)
+}
+
 // CreateOrUpdateRecordSet mocks base method.
 func (m *MockClient) CreateOrUpdateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType dns.RecordType, recordSet dns.RecordSet) (dns.RecordSet, error) {
 	m.ctrl.T.Helper()
@@ -52,19 +114,33 @@ func (mr *MockClientMockRecorder) CreateOrUpdateRecordSet(ctx, resourceGroupName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateRecordSet", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
 }
 
+// CreateOrUpdateVirtualNetworkLink mocks base method.
+func (m *MockClient) CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName, virtualNetworkID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateVirtualNetworkLink", ctx, resourceGroupName, zone, linkName, virtualNetworkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdateVirtualNetworkLink indicates an expected call of CreateOrUpdateVirtualNetworkLink.
+func (mr *MockClientMockRecorder) CreateOrUpdateVirtualNetworkLink(ctx, resourceGroupName, zone, linkName, virtualNetworkID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateVirtualNetworkLink", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateVirtualNetworkLink), ctx, resourceGroupName, zone, linkName, virtualNetworkID)
+}
+
 // CreateOrUpdateZone mocks base method.
-func (m *MockClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName, zone string) (dns.Zone, error) {
+func (m *MockClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName, zone string, tags map[string]string) (dns.Zone, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdateZone", ctx, resourceGroupName, zone)
+	ret := m.ctrl.Call(m, "CreateOrUpdateZone", ctx, resourceGroupName, zone, tags)
 	ret0, _ := ret[0].(dns.Zone)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateOrUpdateZone indicates an expected call of CreateOrUpdateZone.
-func (mr *MockClientMockRecorder) CreateOrUpdateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateOrUpdateZone(ctx, resourceGroupName, zone, tags interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateZone", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateZone), ctx, resourceGroupName, zone)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateZone", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateZone), ctx, resourceGroupName, zone, tags)
 }
 
 // DeallocateVirtualMachine mocks base method.
@@ -82,6 +158,48 @@ func (mr *MockClientMockRecorder) DeallocateVirtualMachine(ctx, resourceGroup, n
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeallocateVirtualMachine", reflect.TypeOf((*MockClient)(nil).DeallocateVirtualMachine), ctx, resourceGroup, name)
 }
 
+// DeletePrivateEndpoint mocks base method.
+func (m *MockClient) DeletePrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateEndpoint", ctx, resourceGroupName, endpointName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateEndpoint indicates an expected call of DeletePrivateEndpoint.
+func (mr *MockClientMockRecorder) DeletePrivateEndpoint(ctx, resourceGroupName, endpointName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateEndpoint", reflect.TypeOf((*MockClient)(nil).DeletePrivateEndpoint), ctx, resourceGroupName, endpointName)
+}
+
+// DeletePrivateLinkService mocks base method.
+func (m *MockClient) DeletePrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateLinkService", ctx, resourceGroupName, serviceName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateLinkService indicates an expected call of DeletePrivateLinkService.
+func (mr *MockClientMockRecorder) DeletePrivateLinkService(ctx, resourceGroupName, serviceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateLinkService", reflect.TypeOf((*MockClient)(nil).DeletePrivateLinkService), ctx, resourceGroupName, serviceName)
+}
+
+// DeletePrivateZone mocks base method.
+func (m *MockClient) DeletePrivateZone(ctx context.Context, resourceGroupName, zone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateZone indicates an expected call of DeletePrivateZone.
+func (mr *MockClientMockRecorder) DeletePrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateZone", reflect.TypeOf((*MockClient)(nil).DeletePrivateZone), ctx, resourceGroupName, zone)
+}
+
 // DeleteRecordSet mocks base method.
 func (m *MockClient) DeleteRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType dns.RecordType) error {
 	m.ctrl.T.Helper()
@@ -96,6 +214,20 @@ func (mr *MockClientMockRecorder) DeleteRecordSet(ctx, resourceGroupName, zone,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecordSet", reflect.TypeOf((*MockClient)(nil).DeleteRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType)
 }
 
+// DeleteVirtualNetworkLink mocks base method.
+func (m *MockClient) DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVirtualNetworkLink", ctx, resourceGroupName, zone, linkName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVirtualNetworkLink indicates an expected call of DeleteVirtualNetworkLink.
+func (mr *MockClientMockRecorder) DeleteVirtualNetworkLink(ctx, resourceGroupName, zone, linkName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVirtualNetworkLink", reflect.TypeOf((*MockClient)(nil).DeleteVirtualNetworkLink), ctx, resourceGroupName, zone, linkName)
+}
+
 // DeleteZone mocks base method.
 func (m *MockClient) DeleteZone(ctx context.Context, resourceGroupName, zone string) error {
 	m.ctrl.T.Helper()
@@ -110,6 +242,110 @@ func (mr *MockClientMockRecorder) DeleteZone(ctx, resourceGroupName, zone interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockClient)(nil).DeleteZone), ctx, resourceGroupName, zone)
 }
 
+// DisableSubnetPrivateLinkServiceNetworkPolicies mocks base method.
+func (m *MockClient) DisableSubnetPrivateLinkServiceNetworkPolicies(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableSubnetPrivateLinkServiceNetworkPolicies", ctx, resourceGroupName, virtualNetworkName, subnetName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableSubnetPrivateLinkServiceNetworkPolicies indicates an expected call of DisableSubnetPrivateLinkServiceNetworkPolicies.
+func (mr *MockClientMockRecorder) DisableSubnetPrivateLinkServiceNetworkPolicies(ctx, resourceGroupName, virtualNetworkName, subnetName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableSubnetPrivateLinkServiceNetworkPolicies", reflect.TypeOf((*MockClient)(nil).DisableSubnetPrivateLinkServiceNetworkPolicies), ctx, resourceGroupName, virtualNetworkName, subnetName)
+}
+
+// GetLoadBalancer mocks base method.
+func (m *MockClient) GetLoadBalancer(ctx context.Context, resourceGroupName, loadBalancerName string) (network.LoadBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancer", ctx, resourceGroupName, loadBalancerName)
+	ret0, _ := ret[0].(network.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancer indicates an expected call of GetLoadBalancer.
+func (mr *MockClientMockRecorder) GetLoadBalancer(ctx, resourceGroupName, loadBalancerName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancer", reflect.TypeOf((*MockClient)(nil).GetLoadBalancer), ctx, resourceGroupName, loadBalancerName)
+}
+
+// GetNetworkInterface mocks base method.
+func (m *MockClient) GetNetworkInterface(ctx context.Context, resourceGroupName, networkInterfaceName string) (network.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkInterface", ctx, resourceGroupName, networkInterfaceName)
+	ret0, _ := ret[0].(network.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkInterface indicates an expected call of GetNetworkInterface.
+func (mr *MockClientMockRecorder) GetNetworkInterface(ctx, resourceGroupName, networkInterfaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkInterface", reflect.TypeOf((*MockClient)(nil).GetNetworkInterface), ctx, resourceGroupName, networkInterfaceName)
+}
+
+// GetPrivateEndpoint mocks base method.
+func (m *MockClient) GetPrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) (*azureclient.PrivateEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateEndpoint", ctx, resourceGroupName, endpointName)
+	ret0, _ := ret[0].(*azureclient.PrivateEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateEndpoint indicates an expected call of GetPrivateEndpoint.
+func (mr *MockClientMockRecorder) GetPrivateEndpoint(ctx, resourceGroupName, endpointName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateEndpoint", reflect.TypeOf((*MockClient)(nil).GetPrivateEndpoint), ctx, resourceGroupName, endpointName)
+}
+
+// GetPrivateLinkService mocks base method.
+func (m *MockClient) GetPrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) (*azureclient.PrivateLinkService, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateLinkService", ctx, resourceGroupName, serviceName)
+	ret0, _ := ret[0].(*azureclient.PrivateLinkService)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateLinkService indicates an expected call of GetPrivateLinkService.
+func (mr *MockClientMockRecorder) GetPrivateLinkService(ctx, resourceGroupName, serviceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateLinkService", reflect.TypeOf((*MockClient)(nil).GetPrivateLinkService), ctx, resourceGroupName, serviceName)
+}
+
+// GetPrivateZone mocks base method.
+func (m *MockClient) GetPrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(privatedns.PrivateZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateZone indicates an expected call of GetPrivateZone.
+func (mr *MockClientMockRecorder) GetPrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateZone", reflect.TypeOf((*MockClient)(nil).GetPrivateZone), ctx, resourceGroupName, zone)
+}
+
+// GetSubnet mocks base method.
+func (m *MockClient) GetSubnet(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) (*azureclient.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnet", ctx, resourceGroupName, virtualNetworkName, subnetName)
+	ret0, _ := ret[0].(*azureclient.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnet indicates an expected call of GetSubnet.
+func (mr *MockClientMockRecorder) GetSubnet(ctx, resourceGroupName, virtualNetworkName, subnetName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnet", reflect.TypeOf((*MockClient)(nil).GetSubnet), ctx, resourceGroupName, virtualNetworkName, subnetName)
+}
+
 // GetZone mocks base method.
 func (m *MockClient) GetZone(ctx context.Context, resourceGroupName, zone string) (dns.Zone, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +376,36 @@ func (mr *MockClientMockRecorder) ListAllVirtualMachines(ctx, statusOnly interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllVirtualMachines", reflect.TypeOf((*MockClient)(nil).ListAllVirtualMachines), ctx, statusOnly)
 }
 
+// ListComputeUsages mocks base method.
+func (m *MockClient) ListComputeUsages(ctx context.Context, location string) (azureclient.ComputeUsagesPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeUsages", ctx, location)
+	ret0, _ := ret[0].(azureclient.ComputeUsagesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListComputeUsages indicates an expected call of ListComputeUsages.
+func (mr *MockClientMockRecorder) ListComputeUsages(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeUsages", reflect.TypeOf((*MockClient)(nil).ListComputeUsages), ctx, location)
+}
+
+// ListNetworkUsages mocks base method.
+func (m *MockClient) ListNetworkUsages(ctx context.Context, location string) (azureclient.NetworkUsagesPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkUsages", ctx, location)
+	ret0, _ := ret[0].(azureclient.NetworkUsagesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkUsages indicates an expected call of ListNetworkUsages.
+func (mr *MockClientMockRecorder) ListNetworkUsages(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkUsages", reflect.TypeOf((*MockClient)(nil).ListNetworkUsages), ctx, location)
+}
+
 // ListRecordSetsByZone mocks base method.
 func (m *MockClient) ListRecordSetsByZone(ctx context.Context, resourceGroupName, zone, suffix string) (azureclient.RecordSetPage, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +436,21 @@ func (mr *MockClientMockRecorder) ListResourceSKUs(ctx, filter interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceSKUs", reflect.TypeOf((*MockClient)(nil).ListResourceSKUs), ctx, filter)
 }
 
+// ListVirtualNetworkLinks mocks base method.
+func (m *MockClient) ListVirtualNetworkLinks(ctx context.Context, resourceGroupName, zone string) ([]privatedns.VirtualNetworkLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVirtualNetworkLinks", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].([]privatedns.VirtualNetworkLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVirtualNetworkLinks indicates an expected call of ListVirtualNetworkLinks.
+func (mr *MockClientMockRecorder) ListVirtualNetworkLinks(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVirtualNetworkLinks", reflect.TypeOf((*MockClient)(nil).ListVirtualNetworkLinks), ctx, resourceGroupName, zone)
+}
+
 // StartVirtualMachine mocks base method.
 func (m *MockClient) StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error) {
 	m.ctrl.T.Helper()
@@ -185,6 +466,20 @@ func (mr *MockClientMockRecorder) StartVirtualMachine(ctx, resourceGroup, name i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVirtualMachine", reflect.TypeOf((*MockClient)(nil).StartVirtualMachine), ctx, resourceGroup, name)
 }
 
+// UploadBlob mocks base method.
+func (m *MockClient) UploadBlob(ctx context.Context, storageAccount, container, name string, content []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBlob", ctx, storageAccount, container, name, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadBlob indicates an expected call of UploadBlob.
+func (mr *MockClientMockRecorder) UploadBlob(ctx, storageAccount, container, name, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBlob", reflect.TypeOf((*MockClient)(nil).UploadBlob), ctx, storageAccount, container, name, content)
+}
+
 // MockResourceSKUsPage is a mock of ResourceSKUsPage interface.
 type MockResourceSKUsPage struct {
 	ctrl     *gomock.Controller
@@ -314,3 +609,133 @@ func (mr *MockRecordSetPageMockRecorder) Values() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockRecordSetPage)(nil).Values))
 }
+
+// MockComputeUsagesPage is a mock of ComputeUsagesPage interface.
+type MockComputeUsagesPage struct {
+	ctrl     *gomock.Controller
+	recorder *MockComputeUsagesPageMockRecorder
+}
+
+// MockComputeUsagesPageMockRecorder is the mock recorder for MockComputeUsagesPage.
+type MockComputeUsagesPageMockRecorder struct {
+	mock *MockComputeUsagesPage
+}
+
+// NewMockComputeUsagesPage creates a new mock instance.
+func NewMockComputeUsagesPage(ctrl *gomock.Controller) *MockComputeUsagesPage {
+	mock := &MockComputeUsagesPage{ctrl: ctrl}
+	mock.recorder = &MockComputeUsagesPageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComputeUsagesPage) EXPECT() *MockComputeUsagesPageMockRecorder {
+	return m.recorder
+}
+
+// NextWithContext mocks base method.
+func (m *MockComputeUsagesPage) NextWithContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWithContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NextWithContext indicates an expected call of NextWithContext.
+func (mr *MockComputeUsagesPageMockRecorder) NextWithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWithContext", reflect.TypeOf((*MockComputeUsagesPage)(nil).NextWithContext), ctx)
+}
+
+// NotDone mocks base method.
+func (m *MockComputeUsagesPage) NotDone() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotDone")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NotDone indicates an expected call of NotDone.
+func (mr *MockComputeUsagesPageMockRecorder) NotDone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotDone", reflect.TypeOf((*MockComputeUsagesPage)(nil).NotDone))
+}
+
+// Values mocks base method.
+func (m *MockComputeUsagesPage) Values() []compute.Usage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Values")
+	ret0, _ := ret[0].([]compute.Usage)
+	return ret0
+}
+
+// Values indicates an expected call of Values.
+func (mr *MockComputeUsagesPageMockRecorder) Values() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockComputeUsagesPage)(nil).Values))
+}
+
+// MockNetworkUsagesPage is a mock of NetworkUsagesPage interface.
+type MockNetworkUsagesPage struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkUsagesPageMockRecorder
+}
+
+// MockNetworkUsagesPageMockRecorder is the mock recorder for MockNetworkUsagesPage.
+type MockNetworkUsagesPageMockRecorder struct {
+	mock *MockNetworkUsagesPage
+}
+
+// NewMockNetworkUsagesPage creates a new mock instance.
+func NewMockNetworkUsagesPage(ctrl *gomock.Controller) *MockNetworkUsagesPage {
+	mock := &MockNetworkUsagesPage{ctrl: ctrl}
+	mock.recorder = &MockNetworkUsagesPageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkUsagesPage) EXPECT() *MockNetworkUsagesPageMockRecorder {
+	return m.recorder
+}
+
+// NextWithContext mocks base method.
+func (m *MockNetworkUsagesPage) NextWithContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWithContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NextWithContext indicates an expected call of NextWithContext.
+func (mr *MockNetworkUsagesPageMockRecorder) NextWithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWithContext", reflect.TypeOf((*MockNetworkUsagesPage)(nil).NextWithContext), ctx)
+}
+
+// NotDone mocks base method.
+func (m *MockNetworkUsagesPage) NotDone() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotDone")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NotDone indicates an expected call of NotDone.
+func (mr *MockNetworkUsagesPageMockRecorder) NotDone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotDone", reflect.TypeOf((*MockNetworkUsagesPage)(nil).NotDone))
+}
+
+// Values mocks base method.
+func (m *MockNetworkUsagesPage) Values() []network.Usage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Values")
+	ret0, _ := ret[0].([]network.Usage)
+	return ret0
+}
+
+// Values indicates an expected call of Values.
+func (mr *MockNetworkUsagesPageMockRecorder) Values() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockNetworkUsagesPage)(nil).Values))
+}
@@ -64,6 +64,10 @@ func (c *FakeHiveV1) MachinePoolNameLeases(namespace string) v1.MachinePoolNameL
 	return &FakeMachinePoolNameLeases{c, namespace}
 }
 
+func (c *FakeHiveV1) ProvisioningQuotas() v1.ProvisioningQuotaInterface {
+	return &FakeProvisioningQuotas{c}
+}
+
 func (c *FakeHiveV1) SelectorSyncIdentityProviders() v1.SelectorSyncIdentityProviderInterface {
 	return &FakeSelectorSyncIdentityProviders{c}
 }
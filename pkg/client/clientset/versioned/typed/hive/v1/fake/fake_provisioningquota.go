@@ -0,0 +1,117 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeProvisioningQuotas implements ProvisioningQuotaInterface
+type FakeProvisioningQuotas struct {
+	Fake *FakeHiveV1
+}
+
+var provisioningquotasResource = schema.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "provisioningquotas"}
+
+var provisioningquotasKind = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ProvisioningQuota"}
+
+// Get takes name of the provisioningQuota, and returns the corresponding provisioningQuota object, and an error if there is any.
+func (c *FakeProvisioningQuotas) Get(ctx context.Context, name string, options v1.GetOptions) (result *hivev1.ProvisioningQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(provisioningquotasResource, name), &hivev1.ProvisioningQuota{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ProvisioningQuota), err
+}
+
+// List takes label and field selectors, and returns the list of ProvisioningQuotas that match those selectors.
+func (c *FakeProvisioningQuotas) List(ctx context.Context, opts v1.ListOptions) (result *hivev1.ProvisioningQuotaList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(provisioningquotasResource, provisioningquotasKind, opts), &hivev1.ProvisioningQuotaList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &hivev1.ProvisioningQuotaList{ListMeta: obj.(*hivev1.ProvisioningQuotaList).ListMeta}
+	for _, item := range obj.(*hivev1.ProvisioningQuotaList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested provisioningQuotas.
+func (c *FakeProvisioningQuotas) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(provisioningquotasResource, opts))
+}
+
+// Create takes the representation of a provisioningQuota and creates it.  Returns the server's representation of the provisioningQuota, and an error, if there is any.
+func (c *FakeProvisioningQuotas) Create(ctx context.Context, provisioningQuota *hivev1.ProvisioningQuota, opts v1.CreateOptions) (result *hivev1.ProvisioningQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(provisioningquotasResource, provisioningQuota), &hivev1.ProvisioningQuota{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ProvisioningQuota), err
+}
+
+// Update takes the representation of a provisioningQuota and updates it. Returns the server's representation of the provisioningQuota, and an error, if there is any.
+func (c *FakeProvisioningQuotas) Update(ctx context.Context, provisioningQuota *hivev1.ProvisioningQuota, opts v1.UpdateOptions) (result *hivev1.ProvisioningQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(provisioningquotasResource, provisioningQuota), &hivev1.ProvisioningQuota{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ProvisioningQuota), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeProvisioningQuotas) UpdateStatus(ctx context.Context, provisioningQuota *hivev1.ProvisioningQuota, opts v1.UpdateOptions) (*hivev1.ProvisioningQuota, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(provisioningquotasResource, "status", provisioningQuota), &hivev1.ProvisioningQuota{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ProvisioningQuota), err
+}
+
+// Delete takes name of the provisioningQuota and deletes it. Returns an error if one occurs.
+func (c *FakeProvisioningQuotas) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(provisioningquotasResource, name, opts), &hivev1.ProvisioningQuota{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeProvisioningQuotas) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(provisioningquotasResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &hivev1.ProvisioningQuotaList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched provisioningQuota.
+func (c *FakeProvisioningQuotas) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *hivev1.ProvisioningQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(provisioningquotasResource, name, pt, data, subresources...), &hivev1.ProvisioningQuota{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ProvisioningQuota), err
+}
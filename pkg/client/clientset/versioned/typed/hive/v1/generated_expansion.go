@@ -28,6 +28,8 @@ type MachinePoolExpansion interface{}
 
 type MachinePoolNameLeaseExpansion interface{}
 
+type ProvisioningQuotaExpansion interface{}
+
 type SelectorSyncIdentityProviderExpansion interface{}
 
 type SelectorSyncSetExpansion interface{}
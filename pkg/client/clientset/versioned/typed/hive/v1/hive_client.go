@@ -25,6 +25,7 @@ type HiveV1Interface interface {
 	HiveConfigsGetter
 	MachinePoolsGetter
 	MachinePoolNameLeasesGetter
+	ProvisioningQuotasGetter
 	SelectorSyncIdentityProvidersGetter
 	SelectorSyncSetsGetter
 	SyncIdentityProvidersGetter
@@ -88,6 +89,10 @@ func (c *HiveV1Client) MachinePoolNameLeases(namespace string) MachinePoolNameLe
 	return newMachinePoolNameLeases(c, namespace)
 }
 
+func (c *HiveV1Client) ProvisioningQuotas() ProvisioningQuotaInterface {
+	return newProvisioningQuotas(c)
+}
+
 func (c *HiveV1Client) SelectorSyncIdentityProviders() SelectorSyncIdentityProviderInterface {
 	return newSelectorSyncIdentityProviders(c)
 }
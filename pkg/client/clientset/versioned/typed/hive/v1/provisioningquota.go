@@ -0,0 +1,168 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	scheme "github.com/openshift/hive/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ProvisioningQuotasGetter has a method to return a ProvisioningQuotaInterface.
+// A group's client should implement this interface.
+type ProvisioningQuotasGetter interface {
+	ProvisioningQuotas() ProvisioningQuotaInterface
+}
+
+// ProvisioningQuotaInterface has methods to work with ProvisioningQuota resources.
+type ProvisioningQuotaInterface interface {
+	Create(ctx context.Context, provisioningQuota *v1.ProvisioningQuota, opts metav1.CreateOptions) (*v1.ProvisioningQuota, error)
+	Update(ctx context.Context, provisioningQuota *v1.ProvisioningQuota, opts metav1.UpdateOptions) (*v1.ProvisioningQuota, error)
+	UpdateStatus(ctx context.Context, provisioningQuota *v1.ProvisioningQuota, opts metav1.UpdateOptions) (*v1.ProvisioningQuota, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ProvisioningQuota, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ProvisioningQuotaList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ProvisioningQuota, err error)
+	ProvisioningQuotaExpansion
+}
+
+// provisioningQuotas implements ProvisioningQuotaInterface
+type provisioningQuotas struct {
+	client rest.Interface
+}
+
+// newProvisioningQuotas returns a ProvisioningQuotas
+func newProvisioningQuotas(c *HiveV1Client) *provisioningQuotas {
+	return &provisioningQuotas{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the provisioningQuota, and returns the corresponding provisioningQuota object, and an error if there is any.
+func (c *provisioningQuotas) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ProvisioningQuota, err error) {
+	result = &v1.ProvisioningQuota{}
+	err = c.client.Get().
+		Resource("provisioningquotas").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ProvisioningQuotas that match those selectors.
+func (c *provisioningQuotas) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ProvisioningQuotaList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ProvisioningQuotaList{}
+	err = c.client.Get().
+		Resource("provisioningquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested provisioningQuotas.
+func (c *provisioningQuotas) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("provisioningquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a provisioningQuota and creates it.  Returns the server's representation of the provisioningQuota, and an error, if there is any.
+func (c *provisioningQuotas) Create(ctx context.Context, provisioningQuota *v1.ProvisioningQuota, opts metav1.CreateOptions) (result *v1.ProvisioningQuota, err error) {
+	result = &v1.ProvisioningQuota{}
+	err = c.client.Post().
+		Resource("provisioningquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(provisioningQuota).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a provisioningQuota and updates it. Returns the server's representation of the provisioningQuota, and an error, if there is any.
+func (c *provisioningQuotas) Update(ctx context.Context, provisioningQuota *v1.ProvisioningQuota, opts metav1.UpdateOptions) (result *v1.ProvisioningQuota, err error) {
+	result = &v1.ProvisioningQuota{}
+	err = c.client.Put().
+		Resource("provisioningquotas").
+		Name(provisioningQuota.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(provisioningQuota).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *provisioningQuotas) UpdateStatus(ctx context.Context, provisioningQuota *v1.ProvisioningQuota, opts metav1.UpdateOptions) (result *v1.ProvisioningQuota, err error) {
+	result = &v1.ProvisioningQuota{}
+	err = c.client.Put().
+		Resource("provisioningquotas").
+		Name(provisioningQuota.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(provisioningQuota).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the provisioningQuota and deletes it. Returns an error if one occurs.
+func (c *provisioningQuotas) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("provisioningquotas").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *provisioningQuotas) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("provisioningquotas").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched provisioningQuota.
+func (c *provisioningQuotas) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ProvisioningQuota, err error) {
+	result = &v1.ProvisioningQuota{}
+	err = c.client.Patch(pt).
+		Resource("provisioningquotas").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -64,6 +64,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().MachinePools().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("machinepoolnameleases"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().MachinePoolNameLeases().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("provisioningquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ProvisioningQuotas().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("selectorsyncidentityproviders"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().SelectorSyncIdentityProviders().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("selectorsyncsets"):
@@ -34,6 +34,8 @@ type Interface interface {
 	MachinePools() MachinePoolInformer
 	// MachinePoolNameLeases returns a MachinePoolNameLeaseInformer.
 	MachinePoolNameLeases() MachinePoolNameLeaseInformer
+	// ProvisioningQuotas returns a ProvisioningQuotaInformer.
+	ProvisioningQuotas() ProvisioningQuotaInformer
 	// SelectorSyncIdentityProviders returns a SelectorSyncIdentityProviderInformer.
 	SelectorSyncIdentityProviders() SelectorSyncIdentityProviderInformer
 	// SelectorSyncSets returns a SelectorSyncSetInformer.
@@ -120,6 +122,11 @@ func (v *version) MachinePoolNameLeases() MachinePoolNameLeaseInformer {
 	return &machinePoolNameLeaseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ProvisioningQuotas returns a ProvisioningQuotaInformer.
+func (v *version) ProvisioningQuotas() ProvisioningQuotaInformer {
+	return &provisioningQuotaInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // SelectorSyncIdentityProviders returns a SelectorSyncIdentityProviderInformer.
 func (v *version) SelectorSyncIdentityProviders() SelectorSyncIdentityProviderInformer {
 	return &selectorSyncIdentityProviderInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	versioned "github.com/openshift/hive/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/openshift/hive/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/openshift/hive/pkg/client/listers/hive/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ProvisioningQuotaInformer provides access to a shared informer and lister for
+// ProvisioningQuotas.
+type ProvisioningQuotaInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.ProvisioningQuotaLister
+}
+
+type provisioningQuotaInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewProvisioningQuotaInformer constructs a new informer for ProvisioningQuota type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewProvisioningQuotaInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredProvisioningQuotaInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredProvisioningQuotaInformer constructs a new informer for ProvisioningQuota type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredProvisioningQuotaInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.HiveV1().ProvisioningQuotas().List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.HiveV1().ProvisioningQuotas().Watch(context.TODO(), options)
+			},
+		},
+		&hivev1.ProvisioningQuota{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *provisioningQuotaInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredProvisioningQuotaInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *provisioningQuotaInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&hivev1.ProvisioningQuota{}, f.defaultInformer)
+}
+
+func (f *provisioningQuotaInformer) Lister() v1.ProvisioningQuotaLister {
+	return v1.NewProvisioningQuotaLister(f.Informer().GetIndexer())
+}
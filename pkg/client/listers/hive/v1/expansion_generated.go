@@ -94,6 +94,10 @@ type MachinePoolNameLeaseListerExpansion interface{}
 // MachinePoolNameLeaseNamespaceLister.
 type MachinePoolNameLeaseNamespaceListerExpansion interface{}
 
+// ProvisioningQuotaListerExpansion allows custom methods to be added to
+// ProvisioningQuotaLister.
+type ProvisioningQuotaListerExpansion interface{}
+
 // SelectorSyncIdentityProviderListerExpansion allows custom methods to be added to
 // SelectorSyncIdentityProviderLister.
 type SelectorSyncIdentityProviderListerExpansion interface{}
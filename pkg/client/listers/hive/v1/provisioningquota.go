@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ProvisioningQuotaLister helps list ProvisioningQuotas.
+// All objects returned here must be treated as read-only.
+type ProvisioningQuotaLister interface {
+	// List lists all ProvisioningQuotas in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ProvisioningQuota, err error)
+	// Get retrieves the ProvisioningQuota from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ProvisioningQuota, error)
+	ProvisioningQuotaListerExpansion
+}
+
+// provisioningQuotaLister implements the ProvisioningQuotaLister interface.
+type provisioningQuotaLister struct {
+	indexer cache.Indexer
+}
+
+// NewProvisioningQuotaLister returns a new ProvisioningQuotaLister.
+func NewProvisioningQuotaLister(indexer cache.Indexer) ProvisioningQuotaLister {
+	return &provisioningQuotaLister{indexer: indexer}
+}
+
+// List lists all ProvisioningQuotas in the indexer.
+func (s *provisioningQuotaLister) List(selector labels.Selector) (ret []*v1.ProvisioningQuota, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ProvisioningQuota))
+	})
+	return ret, err
+}
+
+// Get retrieves the ProvisioningQuota from the index for a given name.
+func (s *provisioningQuotaLister) Get(name string) (*v1.ProvisioningQuota, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("provisioningquota"), name)
+	}
+	return obj.(*v1.ProvisioningQuota), nil
+}
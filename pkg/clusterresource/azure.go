@@ -21,6 +21,7 @@ const (
 )
 
 var _ CloudBuilder = (*AzureCloudBuilder)(nil)
+var _ installConfigPlatformFieldsAdder = (*AzureCloudBuilder)(nil)
 
 // AzureCloudBuilder encapsulates cluster artifact generation logic specific to Azure.
 type AzureCloudBuilder struct {
@@ -35,6 +36,9 @@ type AzureCloudBuilder struct {
 
 	// CloudName is the name of the Azure cloud environment which will be used for the cluster.
 	CloudName hivev1azure.CloudEnvironment
+
+	// UserTags are user-provided tags to add to resources.
+	UserTags map[string]string
 }
 
 func NewAzureCloudBuilderFromSecret(credsSecret *corev1.Secret) *AzureCloudBuilder {
@@ -70,6 +74,7 @@ func (p *AzureCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 			Region:                      p.Region,
 			BaseDomainResourceGroupName: p.BaseDomainResourceGroupName,
 			CloudName:                   p.CloudName,
+			UserTags:                    p.UserTags,
 		},
 	}
 }
@@ -100,6 +105,17 @@ func (p *AzureCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installerty
 	ic.Compute[0].Platform.Azure = mpp
 }
 
+func (p *AzureCloudBuilder) addInstallConfigPlatformFields(o *Builder) (string, map[string]interface{}) {
+	if len(p.UserTags) == 0 {
+		return "", nil
+	}
+	userTags := make(map[string]interface{}, len(p.UserTags))
+	for k, v := range p.UserTags {
+		userTags[k] = v
+	}
+	return "azure", map[string]interface{}{"userTags": userTags}
+}
+
 func (p *AzureCloudBuilder) CredsSecretName(o *Builder) string {
 	return fmt.Sprintf("%s-azure-creds", o.Name)
 }
@@ -72,9 +72,15 @@ type Builder struct {
 	// HibernateAfter is the duration after which a running cluster should be automatically hibernated.
 	HibernateAfter *time.Duration
 
+	// HibernationSchedule is the schedule on which the cluster should be resumed and hibernated.
+	HibernationSchedule *hivev1.HibernationSchedule
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	InstallAttemptsLimit *int32
 
+	// ProvisionRetryPolicy configures how the failed provisions of the cluster are retried.
+	ProvisionRetryPolicy *hivev1.ProvisionRetryPolicy
+
 	// ServingCert is the contents of a serving certificate to be used for the cluster.
 	ServingCert string
 
@@ -320,8 +326,16 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 		cd.Spec.HibernateAfter = &metav1.Duration{Duration: *o.HibernateAfter}
 	}
 
+	if o.HibernationSchedule != nil {
+		cd.Spec.HibernationSchedule = o.HibernationSchedule.DeepCopy()
+	}
+
 	cd.Spec.InstallAttemptsLimit = o.InstallAttemptsLimit
 
+	if o.ProvisionRetryPolicy != nil {
+		cd.Spec.ProvisionRetryPolicy = o.ProvisionRetryPolicy.DeepCopy()
+	}
+
 	if o.Adopt {
 		cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
 			ClusterID:                o.AdoptClusterID,
@@ -405,6 +419,11 @@ func (o *Builder) generateInstallConfigSecret() (*corev1.Secret, error) {
 	if err != nil {
 		return nil, err
 	}
+	if p, ok := o.CloudBuilder.(installConfigPlatformFieldsAdder); ok {
+		if d, err = addInstallConfigPlatformFields(d, p, o); err != nil {
+			return nil, err
+		}
+	}
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Secret",
@@ -421,6 +440,33 @@ func (o *Builder) generateInstallConfigSecret() (*corev1.Secret, error) {
 	}, nil
 }
 
+// addInstallConfigPlatformFields adds the fields from the cloud builder to the platform section of the marshalled
+// install-config.
+func addInstallConfigPlatformFields(installConfig []byte, p installConfigPlatformFieldsAdder, o *Builder) ([]byte, error) {
+	platformName, fields := p.addInstallConfigPlatformFields(o)
+	if len(fields) == 0 {
+		return installConfig, nil
+	}
+	ic := map[string]interface{}{}
+	if err := yaml.Unmarshal(installConfig, &ic); err != nil {
+		return nil, err
+	}
+	platforms, _ := ic["platform"].(map[string]interface{})
+	if platforms == nil {
+		platforms = map[string]interface{}{}
+		ic["platform"] = platforms
+	}
+	platform, _ := platforms[platformName].(map[string]interface{})
+	if platform == nil {
+		platform = map[string]interface{}{}
+		platforms[platformName] = platform
+	}
+	for k, v := range fields {
+		platform[k] = v
+	}
+	return yaml.Marshal(ic)
+}
+
 func (o *Builder) mergeInstallConfigTemplate() (*corev1.Secret, error) {
 	ic := new(InstallConfigTemplate)
 	err := yaml.Unmarshal([]byte(o.InstallConfigTemplate), ic)
@@ -628,3 +674,9 @@ type CloudBuilder interface {
 	// GenerateCloudObjects returns any additional resources needed for a particular cloud provider.
 	GenerateCloudObjects(o *Builder) []runtime.Object
 }
+
+// installConfigPlatformFieldsAdder is implemented by cloud builders that need to set install-config platform fields
+// which the vendored installer types do not have yet. It returns the name of the platform and the fields to set.
+type installConfigPlatformFieldsAdder interface {
+	addInstallConfigPlatformFields(o *Builder) (string, map[string]interface{})
+}
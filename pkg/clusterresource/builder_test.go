@@ -231,6 +231,47 @@ func TestBuildClusterResources(t *testing.T) {
 				assert.Equal(t, gcpInstanceType, workerPool.Spec.Platform.GCP.InstanceType)
 			},
 		},
+		{
+			name: "GCP cluster with user labels",
+			builder: func() *Builder {
+				b := createGCPClusterBuilder()
+				b.CloudBuilder.(*GCPCloudBuilder).UserLabels = map[string]string{"team": "hive", "cost-center": "123"}
+				return b
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				cd := findClusterDeployment(allObjects, clusterName)
+				assert.Equal(t, map[string]string{"team": "hive", "cost-center": "123"}, cd.Spec.Platform.GCP.UserLabels)
+
+				installConfigSecret := findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName))
+				ic := map[string]interface{}{}
+				require.NoError(t, yaml.Unmarshal([]byte(installConfigSecret.StringData["install-config.yaml"]), &ic))
+				platform := ic["platform"].(map[string]interface{})["gcp"].(map[string]interface{})
+				assert.Equal(t, fakeGCPProjectID, platform["projectID"])
+				assert.Equal(t, []interface{}{
+					map[string]interface{}{"key": "cost-center", "value": "123"},
+					map[string]interface{}{"key": "team", "value": "hive"},
+				}, platform["userLabels"])
+			},
+		},
+		{
+			name: "Azure cluster with user tags",
+			builder: func() *Builder {
+				b := createAzureClusterBuilder()
+				b.CloudBuilder.(*AzureCloudBuilder).UserTags = map[string]string{"team": "hive"}
+				return b
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				cd := findClusterDeployment(allObjects, clusterName)
+				assert.Equal(t, map[string]string{"team": "hive"}, cd.Spec.Platform.Azure.UserTags)
+
+				installConfigSecret := findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName))
+				ic := map[string]interface{}{}
+				require.NoError(t, yaml.Unmarshal([]byte(installConfigSecret.StringData["install-config.yaml"]), &ic))
+				platform := ic["platform"].(map[string]interface{})["azure"].(map[string]interface{})
+				assert.Equal(t, fakeAzureBaseDomainResourceGroup, platform["baseDomainResourceGroupName"])
+				assert.Equal(t, map[string]interface{}{"team": "hive"}, platform["userTags"])
+			},
+		},
 		{
 			name:    "OpenStack cluster",
 			builder: createOpenStackClusterBuilder(),
@@ -2,6 +2,7 @@ package clusterresource
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -22,6 +23,7 @@ const (
 )
 
 var _ CloudBuilder = (*GCPCloudBuilder)(nil)
+var _ installConfigPlatformFieldsAdder = (*GCPCloudBuilder)(nil)
 
 // GCPCloudBuilder encapsulates cluster artifact generation logic specific to GCP.
 type GCPCloudBuilder struct {
@@ -33,6 +35,9 @@ type GCPCloudBuilder struct {
 
 	// Region is the GCP region to which to install the cluster.
 	Region string
+
+	// UserLabels are user-provided labels to add to resources.
+	UserLabels map[string]string
 }
 
 func NewGCPCloudBuilderFromSecret(credsSecret *corev1.Secret) (*GCPCloudBuilder, error) {
@@ -70,7 +75,8 @@ func (p *GCPCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 			CredentialsSecretRef: corev1.LocalObjectReference{
 				Name: p.CredsSecretName(o),
 			},
-			Region: p.Region,
+			Region:     p.Region,
+			UserLabels: p.UserLabels,
 		},
 	}
 }
@@ -98,6 +104,26 @@ func (p *GCPCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installertype
 	ic.Compute[0].Platform.GCP = mpp
 }
 
+func (p *GCPCloudBuilder) addInstallConfigPlatformFields(o *Builder) (string, map[string]interface{}) {
+	if len(p.UserLabels) == 0 {
+		return "", nil
+	}
+	// The installer expects the user labels as a list of key/value pairs.
+	keys := make([]string, 0, len(p.UserLabels))
+	for k := range p.UserLabels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	userLabels := make([]interface{}, len(keys))
+	for i, k := range keys {
+		userLabels[i] = map[string]interface{}{
+			"key":   k,
+			"value": p.UserLabels[k],
+		}
+	}
+	return "gcp", map[string]interface{}{"userLabels": userLabels}
+}
+
 func (p *GCPCloudBuilder) CredsSecretName(o *Builder) string {
 	return fmt.Sprintf("%s-gcp-creds", o.Name)
 }
@@ -30,9 +30,17 @@ const (
 	// processing of any ClusterDeprovisions.
 	DeprovisionsDisabledEnvVar = "DEPROVISIONS_DISABLED"
 
+	// DeprovisionInControllerEnvVar is the name of the environment variable used to tell the controller manager to
+	// delete the cloud resources of clusters from within the clusterdeprovision controller where supported.
+	DeprovisionInControllerEnvVar = "DEPROVISION_IN_CONTROLLER"
+
 	// MinBackupPeriodSecondsEnvVar is the name of the environment variable used to tell the controller manager the minimum period of time between backups.
 	MinBackupPeriodSecondsEnvVar = "HIVE_MIN_BACKUP_PERIOD_SECONDS"
 
+	// CertificateExpiryWarningWindowEnvVar is the name of the environment variable used to tell the controller manager
+	// how long before a certificate expires the CertificateExpiring condition is raised on the ClusterDeployment.
+	CertificateExpiryWarningWindowEnvVar = "HIVE_CERTIFICATE_EXPIRY_WARNING_WINDOW"
+
 	// InstallJobLabel is the label used for artifacts specific to Hive cluster installations.
 	InstallJobLabel = "hive.openshift.io/install"
 
@@ -131,6 +139,10 @@ const (
 	// managed by Hive, and any manual changes may be undone the next time the resource is reconciled.
 	HiveManagedLabel = "hive.openshift.io/managed"
 
+	// HiveFieldManager is the field manager used when hive applies resources to the remote cluster with server-side
+	// apply.
+	HiveFieldManager = "hive"
+
 	// DisableInstallLogPasswordRedactionAnnotation is an annotation used on ClusterDeployments to disable the installmanager
 	// functionality which refuses to print output if it appears to contain a password or sensitive info. This can be
 	// useful in scenarios where debugging is needed and important info is being redacted. Set to "true".
@@ -163,6 +175,16 @@ const (
 	// An incoming status indicates that the resource is on the destination side of an in-progress relocate.
 	RelocateAnnotation = "hive.openshift.io/relocate"
 
+	// HibernationScheduleOverrideUntilAnnotation is an annotation used on ClusterDeployments to temporarily
+	// suspend their HibernationSchedule. The value is an RFC3339 timestamp. Scheduled transitions occurring
+	// before that time are skipped, so a manually set PowerState is left alone until then.
+	HibernationScheduleOverrideUntilAnnotation = "hive.openshift.io/hibernation-schedule-override-until"
+
+	// RotateAdminKubeconfigAnnotation is an annotation used on ClusterDeployments to rotate the service account
+	// kubeconfig Hive uses to connect to the cluster on demand. The kubeconfig is rotated whenever the value of the
+	// annotation changes, so setting it to the current time is a convenient way to request a rotation.
+	RotateAdminKubeconfigAnnotation = "hive.openshift.io/rotate-admin-kubeconfig"
+
 	// ManagedDomainsFileEnvVar if present, points to a simple text
 	// file that includes a valid managed domain per line. Cluster deployments
 	// requesting that their domains be managed must have a base domain
@@ -256,6 +278,15 @@ const (
 	// AWSCredsMount is the location where the AWS credentials secret is mounted for uninstall pods.
 	AWSCredsMount = "/etc/aws-creds"
 
+	// RFC2136TSIGKeyNameSecretKey is the key we use in a Kubernetes Secret containing a TSIG key for the key name.
+	RFC2136TSIGKeyNameSecretKey = "tsig_key_name"
+
+	// RFC2136TSIGSecretSecretKey is the key we use in a Kubernetes Secret containing a TSIG key for the base64 encoded secret.
+	RFC2136TSIGSecretSecretKey = "tsig_secret"
+
+	// RFC2136TSIGAlgorithmSecretKey is the key we use in a Kubernetes Secret containing a TSIG key for the algorithm.
+	RFC2136TSIGAlgorithmSecretKey = "tsig_algorithm"
+
 	// TLSCrtSecretKey is the key we use in a Kubernetes Secret containing a TLS certificate.
 	TLSCrtSecretKey = "tls.crt"
 
@@ -307,6 +338,15 @@ const (
 	// InstallLogsUploadProviderAWS is used to specify that AWS is the cloud provider to upload logs to.
 	InstallLogsUploadProviderAWS = "aws"
 
+	// InstallLogsUploadProviderGCP is used to specify that logs are uploaded to Google Cloud Storage.
+	InstallLogsUploadProviderGCP = "gcp"
+
+	// InstallLogsUploadProviderAzure is used to specify that logs are uploaded to Azure Blob Storage.
+	InstallLogsUploadProviderAzure = "azure"
+
+	// InstallLogsUploadProviderS3Compatible is used to specify that logs are uploaded to an S3-compatible object store.
+	InstallLogsUploadProviderS3Compatible = "s3compatible"
+
 	// InstallLogsCredentialsSecretRefEnvVar is the environment variable specifying what secret to use for storing logs.
 	InstallLogsCredentialsSecretRefEnvVar = "HIVE_INSTALL_LOGS_CREDENTIALS_SECRET"
 
@@ -319,6 +359,22 @@ const (
 	// InstallLogsAWSS3BucketEnvVar is the environment variable specifying the S3 bucket to use.
 	InstallLogsAWSS3BucketEnvVar = "HIVE_INSTALL_LOGS_AWS_S3_BUCKET"
 
+	// InstallLogsS3ForcePathStyleEnvVar is the environment variable specifying whether to use path-style addressing
+	// with an S3-compatible object store.
+	InstallLogsS3ForcePathStyleEnvVar = "HIVE_INSTALL_LOGS_S3_FORCE_PATH_STYLE"
+
+	// InstallLogsGCPBucketEnvVar is the environment variable specifying the Google Cloud Storage bucket to use.
+	InstallLogsGCPBucketEnvVar = "HIVE_INSTALL_LOGS_GCP_BUCKET"
+
+	// InstallLogsAzureStorageAccountEnvVar is the environment variable specifying the Azure storage account to use.
+	InstallLogsAzureStorageAccountEnvVar = "HIVE_INSTALL_LOGS_AZURE_STORAGE_ACCOUNT"
+
+	// InstallLogsAzureContainerEnvVar is the environment variable specifying the Azure blob container to use.
+	InstallLogsAzureContainerEnvVar = "HIVE_INSTALL_LOGS_AZURE_CONTAINER"
+
+	// InstallLogsAzureCloudNameEnvVar is the environment variable specifying the Azure cloud environment to use.
+	InstallLogsAzureCloudNameEnvVar = "HIVE_INSTALL_LOGS_AZURE_CLOUD_NAME"
+
 	// HiveFakeClusterAnnotation can be set to true on a cluster deployment to create a fake cluster that never
 	// provisions resources, and all communication with the cluster will be faked.
 	HiveFakeClusterAnnotation = "hive.openshift.io/fake-cluster"
@@ -357,6 +413,22 @@ const (
 	// file that includes configuration for aws-private-link-controller
 	AWSPrivateLinkControllerConfigFileEnvVar = "AWS_PRIVATELINK_CONTROLLER_CONFIG_FILE"
 
+	// GCPPrivateServiceConnectControllerConfigFileEnvVar if present, points to a simple text
+	// file that includes configuration for gcp-private-service-connect-controller
+	GCPPrivateServiceConnectControllerConfigFileEnvVar = "GCP_PRIVATESERVICECONNECT_CONTROLLER_CONFIG_FILE"
+
+	// AzurePrivateLinkControllerConfigFileEnvVar if present, points to a simple text
+	// file that includes configuration for azure-private-link-controller
+	AzurePrivateLinkControllerConfigFileEnvVar = "AZURE_PRIVATELINK_CONTROLLER_CONFIG_FILE"
+
+	// ACMEIssuerControllerConfigFileEnvVar if present, points to a simple text
+	// file that includes configuration for acme-issuer-controller
+	ACMEIssuerControllerConfigFileEnvVar = "ACME_ISSUER_CONTROLLER_CONFIG_FILE"
+
+	// AdminKubeconfigRotationControllerConfigFileEnvVar if present, points to a simple text
+	// file that includes configuration for admin-kubeconfig-rotation-controller
+	AdminKubeconfigRotationControllerConfigFileEnvVar = "ADMIN_KUBECONFIG_ROTATION_CONTROLLER_CONFIG_FILE"
+
 	// FailedProvisionConfigFileEnvVar points to a text file containing configuration for
 	// desired behavior when provisions fail. See HiveConfig.Spec.FailedProvisionConfig.
 	FailedProvisionConfigFileEnvVar = "FAILED_PROVISION_CONFIG_FILE"
@@ -0,0 +1,381 @@
+package acmeissuer
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/acmeclient"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/dnszone"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ACMEIssuerControllerName
+
+	defaultRenewBefore  = 30 * 24 * time.Hour
+	defaultRequeueLater = 1 * time.Minute
+
+	// orderPollInterval is how often the order is checked while the challenges are validated.
+	orderPollInterval = 15 * time.Second
+)
+
+// clusterDeploymentACMEIssuerConditions are the cluster deployment conditions controlled by
+// the ACME issuer controller
+var clusterDeploymentACMEIssuerConditions = []hivev1.ClusterDeploymentConditionType{
+	hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+}
+
+// Add creates a new ACMEIssuer Controller and adds it to the Manager with default RBAC.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	reconciler, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("could not create reconciler")
+		return err
+	}
+	return AddToManager(mgr, reconciler, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileACMEIssuer
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileACMEIssuer, error) {
+	logger := log.WithField("controller", ControllerName)
+	reconciler := &ReconcileACMEIssuer{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+	}
+
+	config, err := ReadACMEIssuerControllerConfigFile()
+	if err != nil {
+		logger.WithError(err).Error("could not get load configuration")
+		return reconciler, err
+	}
+	reconciler.controllerconfig = config
+	reconciler.acmeClientFn = acmeclient.NewClient
+	reconciler.txtRecordActuatorFn = newTXTRecordActuator
+	reconciler.txtRecordPropagatedFn = txtRecordPropagated
+	return reconciler, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileACMEIssuer, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controller.New("acmeissuer-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}},
+		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, controllerutils.IsClusterDeploymentErrorUpdateEvent))
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		return err
+	}
+
+	// Watch for changes to the DNSZone of the ClusterDeployment, which must be available to answer the challenges
+	if err := c.Watch(&source.Kind{Type: &hivev1.DNSZone{}},
+		&handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &hivev1.ClusterDeployment{},
+		}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching dnszone")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileACMEIssuer{}
+
+// ReconcileACMEIssuer issues and renews the generated certificate bundles of ClusterDeployments using an
+// ACME server.
+type ReconcileACMEIssuer struct {
+	client.Client
+
+	controllerconfig *hivev1.ACMEIssuerConfig
+
+	// testing purpose
+	acmeClientFn          acmeClientFn
+	txtRecordActuatorFn   txtRecordActuatorFn
+	txtRecordPropagatedFn txtRecordPropagatedFn
+}
+
+type acmeClientFn func(directoryURL string, accountKey crypto.Signer) (acmeclient.Client, error)
+
+type txtRecordActuatorFn func(c client.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) (dnszone.TXTRecordActuator, error)
+
+type txtRecordPropagatedFn func(nameServers []string, name string, values []string) (bool, error)
+
+// Reconcile issues and renews the generated certificate bundles of a ClusterDeployment.
+func (r *ReconcileACMEIssuer) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		// Error reading the object - requeue the request.
+		logger.WithError(err).Error("error getting ClusterDeployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.DeletionTimestamp != nil {
+		// the generated secrets are owned by the ClusterDeployment and the challenge records are in its DNSZone,
+		// so both are removed with it.
+		logger.Debug("cluster deployment is being deleted, so skipping")
+		return reconcile.Result{}, nil
+	}
+
+	bundles := generatedBundles(cd)
+	if len(bundles) == 0 {
+		logger.Debug("cluster deployment has no generated certificate bundles, so skipping")
+		return reconcile.Result{}, nil
+	}
+	if r.controllerconfig == nil || r.controllerconfig.AccountKeySecretRef.Name == "" {
+		logger.Debug("ACME issuer is not configured, so skipping")
+		return reconcile.Result{}, nil
+	}
+
+	// Initialize cluster deployment conditions if not present
+	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, clusterDeploymentACMEIssuerConditions)
+	if len(newConditions) > len(cd.Status.Conditions) {
+		cd.Status.Conditions = newConditions
+		logger.Info("initializing ACME issuer controller conditions")
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if !cd.Spec.ManageDNS {
+		err := errors.New("generated certificate bundles require manageDNS to answer the ACME challenges")
+		logger.WithError(err).Error("cannot issue certificate bundles")
+		return reconcile.Result{}, r.setFailedCondition(cd, "ManagedDNSRequired", err, logger)
+	}
+
+	dnsZone := &hivev1.DNSZone{}
+	err = r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)}, dnsZone)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("DNSZone not found yet, waiting")
+		return reconcile.Result{RequeueAfter: defaultRequeueLater}, nil
+	}
+	if err != nil {
+		logger.WithError(err).Error("error getting DNSZone")
+		return reconcile.Result{}, err
+	}
+	if cond := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition); cond == nil ||
+		cond.Status != corev1.ConditionTrue {
+		logger.Debug("DNSZone is not available yet, waiting")
+		return reconcile.Result{RequeueAfter: defaultRequeueLater}, nil
+	}
+
+	origStatus := cd.Status.DeepCopy()
+	issuer := &issuerContext{reconciler: r, cd: cd, dnsZone: dnsZone, logger: logger}
+	var requeueAfter time.Duration
+	var errs []error
+	for _, bundle := range bundles {
+		bundleLog := logger.WithField("certificateBundle", bundle.Name)
+		after, err := issuer.reconcileBundle(bundle, bundleLog)
+		if err != nil {
+			bundleLog.WithError(err).Error("failed to issue certificate bundle")
+			errs = append(errs, errors.Wrapf(err, "certificate bundle %s", bundle.Name))
+			continue
+		}
+		if requeueAfter == 0 || (after > 0 && after < requeueAfter) {
+			requeueAfter = after
+		}
+	}
+
+	err = utilerrors.NewAggregate(errs)
+	if err != nil {
+		cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+			corev1.ConditionTrue,
+			"IssuanceFailed",
+			controllerutils.ErrorScrub(err),
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+	} else {
+		cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+			corev1.ConditionFalse,
+			"NoFailures",
+			"No failures issuing the certificate bundles",
+			controllerutils.UpdateConditionNever)
+	}
+	if !reflect.DeepEqual(origStatus, &cd.Status) {
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *ReconcileACMEIssuer) setFailedCondition(cd *hivev1.ClusterDeployment, reason string, err error, logger log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+		corev1.ConditionTrue,
+		reason,
+		controllerutils.ErrorScrub(err),
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	logger.Debug("setting CertificateBundleIssuanceFailedClusterDeploymentCondition to true")
+	return r.Status().Update(context.TODO(), cd)
+}
+
+// generatedBundle is a certificate bundle to generate with the domains it must be valid for.
+type generatedBundle struct {
+	hivev1.CertificateBundleSpec
+	domains []string
+}
+
+// generatedBundles returns the certificate bundles of the ClusterDeployment that must be generated. The domains of
+// a bundle are those of the control plane and ingress serving certificates that reference it, and bundles that
+// are not referenced are not generated.
+func generatedBundles(cd *hivev1.ClusterDeployment) []generatedBundle {
+	var bundles []generatedBundle
+	for _, bundle := range cd.Spec.CertificateBundles {
+		if !bundle.Generate {
+			continue
+		}
+		var domains []string
+		addDomain := func(domain string) {
+			for _, d := range domains {
+				if d == domain {
+					return
+				}
+			}
+			domains = append(domains, domain)
+		}
+		if cd.Spec.ControlPlaneConfig.ServingCertificates.Default == bundle.Name {
+			addDomain(fmt.Sprintf("api.%s.%s", cd.Spec.ClusterName, cd.Spec.BaseDomain))
+		}
+		for _, additional := range cd.Spec.ControlPlaneConfig.ServingCertificates.Additional {
+			if additional.Name == bundle.Name {
+				addDomain(additional.Domain)
+			}
+		}
+		for _, ingress := range cd.Spec.Ingress {
+			if ingress.ServingCertificate == bundle.Name {
+				addDomain("*." + ingress.Domain)
+			}
+		}
+		if len(domains) > 0 {
+			bundles = append(bundles, generatedBundle{CertificateBundleSpec: bundle, domains: domains})
+		}
+	}
+	return bundles
+}
+
+// bundleStatus returns the status of the certificate bundle with the given name, adding it when it is missing.
+func bundleStatus(cd *hivev1.ClusterDeployment, name string) *hivev1.CertificateBundleStatus {
+	for i := range cd.Status.CertificateBundles {
+		if cd.Status.CertificateBundles[i].Name == name {
+			return &cd.Status.CertificateBundles[i]
+		}
+	}
+	cd.Status.CertificateBundles = append(cd.Status.CertificateBundles, hivev1.CertificateBundleStatus{Name: name})
+	return &cd.Status.CertificateBundles[len(cd.Status.CertificateBundles)-1]
+}
+
+func (r *ReconcileACMEIssuer) renewBefore() time.Duration {
+	if r.controllerconfig.RenewBefore != nil {
+		return r.controllerconfig.RenewBefore.Duration
+	}
+	return defaultRenewBefore
+}
+
+func newTXTRecordActuator(c client.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) (dnszone.TXTRecordActuator, error) {
+	actuator, err := dnszone.NewActuator(c, dnsZone, logger)
+	if err != nil {
+		return nil, err
+	}
+	txtActuator, ok := actuator.(dnszone.TXTRecordActuator)
+	if !ok {
+		return nil, errors.New("the DNS provider of the DNSZone does not support TXT records")
+	}
+	return txtActuator, nil
+}
+
+// inZone returns true if the domain, which may be a wildcard, is in the zone.
+func inZone(domain, zone string) bool {
+	domain = strings.TrimPrefix(domain, "*.")
+	return domain == zone || strings.HasSuffix(domain, "."+zone)
+}
+
+// ReadACMEIssuerControllerConfigFile reads the configuration from the env
+// and unmarshals. If the env is set to a file but that file doesn't exist it returns
+// a zero value configuration.
+func ReadACMEIssuerControllerConfigFile() (*hivev1.ACMEIssuerConfig, error) {
+	fPath := os.Getenv(constants.ACMEIssuerControllerConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+
+	config := &hivev1.ACMEIssuerConfig{}
+
+	fileBytes, err := ioutil.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, errors.Wrap(err, "failed to read the acme issuer controller config file")
+	}
+	if err := json.Unmarshal(fileBytes, &config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
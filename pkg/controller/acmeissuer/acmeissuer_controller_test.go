@@ -0,0 +1,495 @@
+package acmeissuer
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/acmeclient"
+	"github.com/openshift/hive/pkg/acmeclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/dnszone"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testdnszone "github.com/openshift/hive/pkg/test/dnszone"
+)
+
+const (
+	testNS           = "test-namespace"
+	testOrderURL     = "https://acme.example.com/order/1"
+	testFinalizeURL  = "https://acme.example.com/order/1/finalize"
+	testCertURL      = "https://acme.example.com/cert/1"
+	accountKeySecret = "acme-account-key"
+	bundleSecret     = "test-cd-certs"
+
+	apiDomain  = "api.test-cd.example.com"
+	appsDomain = "*.apps.test-cd.example.com"
+)
+
+// fakeTXTRecords is a TXTRecordActuator that keeps the records in memory.
+type fakeTXTRecords map[string][]string
+
+func (f fakeTXTRecords) SetTXTRecord(name string, values []string) error {
+	f[name] = values
+	return nil
+}
+
+func (f fakeTXTRecords) DeleteTXTRecord(name string, values []string) error {
+	delete(f, name)
+	return nil
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	key := client.ObjectKey{Name: "test-cd", Namespace: testNS}
+	cdBuilder := testcd.FullBuilder(testNS, "test-cd", scheme).Options(
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterName = "test-cd"
+			cd.Spec.BaseDomain = "example.com"
+			cd.Spec.ManageDNS = true
+			cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{{
+				Name:                 "default",
+				Generate:             true,
+				CertificateSecretRef: corev1.LocalObjectReference{Name: bundleSecret},
+			}}
+			cd.Spec.ControlPlaneConfig.ServingCertificates.Default = "default"
+			cd.Spec.Ingress = []hivev1.ClusterIngress{{
+				Name:               "default",
+				Domain:             "apps.test-cd.example.com",
+				ServingCertificate: "default",
+			}}
+		},
+	)
+	withConditions := testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+		Status: corev1.ConditionUnknown,
+		Type:   hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+	})
+	withOrder := func(cd *hivev1.ClusterDeployment) {
+		cd.Status.CertificateBundles = []hivev1.CertificateBundleStatus{{Name: "default", ACMEOrderURL: testOrderURL}}
+	}
+	zoneBuilder := testdnszone.FullBuilder(testNS, controllerutils.DNSZoneName("test-cd"), scheme)
+	availableZone := zoneBuilder.Build(func(z *hivev1.DNSZone) {
+		z.Spec.Zone = "test-cd.example.com"
+		z.Status.NameServers = []string{"ns1.example.com"}
+		z.Status.Conditions = []hivev1.DNSZoneCondition{{
+			Type:   hivev1.ZoneAvailableDNSZoneCondition,
+			Status: corev1.ConditionTrue,
+		}}
+	})
+
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	accountKeyDER, err := x509.MarshalECPrivateKey(accountKey)
+	require.NoError(t, err)
+	accountKeySecretObj := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: accountKeySecret},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: accountKeyDER}),
+		},
+	}
+	certSecret := func(notAfter time.Time, domains ...string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: bundleSecret},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       testCertificate(t, notAfter, domains...),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		}
+	}
+
+	pendingOrder := &acmeclient.Order{
+		URL:            testOrderURL,
+		Status:         acmeclient.StatusPending,
+		Authorizations: []string{"authz-api", "authz-apps"},
+		Finalize:       testFinalizeURL,
+	}
+	orderWithStatus := func(status string) *acmeclient.Order {
+		o := *pendingOrder
+		o.Status = status
+		return &o
+	}
+	authorizations := func(expect *mock.MockClientMockRecorder, status string) {
+		expect.GetAuthorization("authz-api").Return(&acmeclient.Authorization{
+			Identifier: acmeclient.Identifier{Type: "dns", Value: apiDomain},
+			Status:     status,
+			Challenges: []acmeclient.Challenge{
+				{Type: "http-01", URL: "chal-api-http", Token: "token-api-http", Status: status},
+				{Type: acmeclient.ChallengeTypeDNS01, URL: "chal-api", Token: "token-api", Status: status},
+			},
+		}, nil)
+		expect.GetAuthorization("authz-apps").Return(&acmeclient.Authorization{
+			Identifier: acmeclient.Identifier{Type: "dns", Value: "apps.test-cd.example.com"},
+			Status:     status,
+			Wildcard:   true,
+			Challenges: []acmeclient.Challenge{
+				{Type: acmeclient.ChallengeTypeDNS01, URL: "chal-apps", Token: "token-apps", Status: status},
+			},
+		}, nil)
+	}
+	presentedRecords := func() fakeTXTRecords {
+		return fakeTXTRecords{
+			"_acme-challenge." + apiDomain:             {"record-token-api"},
+			"_acme-challenge.apps.test-cd.example.com": {"record-token-apps"},
+		}
+	}
+
+	cases := []struct {
+		name              string
+		existing          []runtime.Object
+		config            *hivev1.ACMEIssuerConfig
+		records           fakeTXTRecords
+		propagated        bool
+		configureACME     func(*mock.MockClientMockRecorder)
+		expectedErr       string
+		expectedResult    reconcile.Result
+		renewal           bool
+		expectedStatus    []hivev1.CertificateBundleStatus
+		expectedRecords   fakeTXTRecords
+		expectedCondition *hivev1.ClusterDeploymentCondition
+		validate          func(t *testing.T, c client.Client)
+	}{{
+		name: "no generated bundles",
+		existing: []runtime.Object{cdBuilder.Build(withConditions, func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.CertificateBundles[0].Generate = false
+		})},
+	}, {
+		name:     "not configured",
+		existing: []runtime.Object{cdBuilder.Build(withConditions)},
+		config:   &hivev1.ACMEIssuerConfig{},
+	}, {
+		name:     "initialize conditions",
+		existing: []runtime.Object{cdBuilder.Build()},
+		expectedCondition: &hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+			Status: corev1.ConditionUnknown,
+			Reason: "Initialized",
+		},
+	}, {
+		name: "managed DNS required",
+		existing: []runtime.Object{cdBuilder.Build(withConditions, func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ManageDNS = false
+		})},
+		expectedCondition: &hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+			Status: corev1.ConditionTrue,
+			Reason: "ManagedDNSRequired",
+		},
+	}, {
+		name: "dnszone not available",
+		existing: []runtime.Object{
+			cdBuilder.Build(withConditions),
+			zoneBuilder.Build(func(z *hivev1.DNSZone) { z.Spec.Zone = "test-cd.example.com" }),
+		},
+		expectedResult: reconcile.Result{RequeueAfter: defaultRequeueLater},
+	}, {
+		name:     "create order and account key",
+		existing: []runtime.Object{cdBuilder.Build(withConditions), availableZone},
+		configureACME: func(expect *mock.MockClientMockRecorder) {
+			expect.CreateOrder([]string{apiDomain, appsDomain}).Return(pendingOrder, nil)
+			authorizations(expect, acmeclient.StatusPending)
+		},
+		expectedResult:  reconcile.Result{RequeueAfter: orderPollInterval},
+		expectedStatus:  []hivev1.CertificateBundleStatus{{Name: "default", ACMEOrderURL: testOrderURL}},
+		expectedRecords: presentedRecords(),
+		expectedCondition: &hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+			Status: corev1.ConditionFalse,
+			Reason: "NoFailures",
+		},
+		validate: func(t *testing.T, c client.Client) {
+			secret := &corev1.Secret{}
+			err := c.Get(context.TODO(), types.NamespacedName{Namespace: constants.DefaultHiveNamespace, Name: accountKeySecret}, secret)
+			require.NoError(t, err, "expected account key secret to be created")
+			assert.NotEmpty(t, secret.Data[corev1.TLSPrivateKeyKey], "expected account key in secret")
+		},
+	}, {
+		name:       "accept propagated challenges",
+		existing:   []runtime.Object{cdBuilder.Build(withConditions, withOrder), availableZone, accountKeySecretObj},
+		records:    presentedRecords(),
+		propagated: true,
+		configureACME: func(expect *mock.MockClientMockRecorder) {
+			expect.GetOrder(testOrderURL).Return(pendingOrder, nil)
+			authorizations(expect, acmeclient.StatusPending)
+			expect.AcceptChallenge("chal-api").Return(&acmeclient.Challenge{}, nil)
+			expect.AcceptChallenge("chal-apps").Return(&acmeclient.Challenge{}, nil)
+		},
+		expectedResult:  reconcile.Result{RequeueAfter: orderPollInterval},
+		expectedStatus:  []hivev1.CertificateBundleStatus{{Name: "default", ACMEOrderURL: testOrderURL}},
+		expectedRecords: presentedRecords(),
+	}, {
+		name:     "wait for challenge records to propagate",
+		existing: []runtime.Object{cdBuilder.Build(withConditions, withOrder), availableZone, accountKeySecretObj},
+		records:  fakeTXTRecords{},
+		configureACME: func(expect *mock.MockClientMockRecorder) {
+			expect.GetOrder(testOrderURL).Return(pendingOrder, nil)
+			authorizations(expect, acmeclient.StatusPending)
+		},
+		expectedResult:  reconcile.Result{RequeueAfter: orderPollInterval},
+		expectedStatus:  []hivev1.CertificateBundleStatus{{Name: "default", ACMEOrderURL: testOrderURL}},
+		expectedRecords: presentedRecords(),
+	}, {
+		name:     "finalize ready order",
+		existing: []runtime.Object{cdBuilder.Build(withConditions, withOrder), availableZone, accountKeySecretObj},
+		records:  presentedRecords(),
+		configureACME: func(expect *mock.MockClientMockRecorder) {
+			expect.GetOrder(testOrderURL).Return(orderWithStatus(acmeclient.StatusReady), nil)
+			authorizations(expect, acmeclient.StatusValid)
+			validOrder := orderWithStatus(acmeclient.StatusValid)
+			validOrder.Certificate = testCertURL
+			expect.FinalizeOrder(testFinalizeURL, gomock.Any()).DoAndReturn(func(_ string, der []byte) (*acmeclient.Order, error) {
+				csr, err := x509.ParseCertificateRequest(der)
+				require.NoError(t, err, "invalid CSR")
+				assert.Equal(t, []string{apiDomain, appsDomain}, csr.DNSNames, "unexpected CSR DNS names")
+				return validOrder, nil
+			})
+			expect.GetCertificate(testCertURL).Return(testCertificate(t, time.Now().Add(90*24*time.Hour), apiDomain, appsDomain), nil)
+		},
+		renewal:         true,
+		expectedStatus:  []hivev1.CertificateBundleStatus{{Name: "default", Generated: true}},
+		expectedRecords: fakeTXTRecords{},
+		validate: func(t *testing.T, c client.Client) {
+			secret := &corev1.Secret{}
+			err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNS, Name: bundleSecret}, secret)
+			require.NoError(t, err, "expected certificate secret to be created")
+			assert.Equal(t, corev1.SecretTypeTLS, secret.Type, "unexpected secret type")
+			assert.NotEmpty(t, secret.Data[corev1.TLSCertKey], "expected certificate in secret")
+			assert.NotEmpty(t, secret.Data[corev1.TLSPrivateKeyKey], "expected private key in secret")
+			if assert.Len(t, secret.OwnerReferences, 1, "expected owner reference") {
+				assert.Equal(t, "test-cd", secret.OwnerReferences[0].Name, "unexpected owner")
+			}
+		},
+	}, {
+		name: "certificate up to date",
+		existing: []runtime.Object{
+			cdBuilder.Build(withConditions),
+			availableZone,
+			certSecret(time.Now().Add(60*24*time.Hour), apiDomain, appsDomain),
+		},
+		renewal:        true,
+		expectedStatus: []hivev1.CertificateBundleStatus{{Name: "default", Generated: true}},
+	}, {
+		name: "renew expiring certificate",
+		existing: []runtime.Object{
+			cdBuilder.Build(withConditions, func(cd *hivev1.ClusterDeployment) {
+				cd.Status.CertificateBundles = []hivev1.CertificateBundleStatus{{Name: "default", Generated: true}}
+			}),
+			availableZone,
+			accountKeySecretObj,
+			certSecret(time.Now().Add(10*24*time.Hour), apiDomain, appsDomain),
+		},
+		configureACME: func(expect *mock.MockClientMockRecorder) {
+			expect.CreateOrder([]string{apiDomain, appsDomain}).Return(pendingOrder, nil)
+			authorizations(expect, acmeclient.StatusPending)
+		},
+		expectedResult:  reconcile.Result{RequeueAfter: orderPollInterval},
+		expectedStatus:  []hivev1.CertificateBundleStatus{{Name: "default", Generated: true, ACMEOrderURL: testOrderURL}},
+		expectedRecords: presentedRecords(),
+	}, {
+		name: "reissue certificate missing domains",
+		existing: []runtime.Object{
+			cdBuilder.Build(withConditions),
+			availableZone,
+			accountKeySecretObj,
+			certSecret(time.Now().Add(60*24*time.Hour), apiDomain),
+		},
+		configureACME: func(expect *mock.MockClientMockRecorder) {
+			expect.CreateOrder([]string{apiDomain, appsDomain}).Return(pendingOrder, nil)
+			authorizations(expect, acmeclient.StatusPending)
+		},
+		expectedResult:  reconcile.Result{RequeueAfter: orderPollInterval},
+		expectedStatus:  []hivev1.CertificateBundleStatus{{Name: "default", ACMEOrderURL: testOrderURL}},
+		expectedRecords: presentedRecords(),
+	}, {
+		name:     "invalid order",
+		existing: []runtime.Object{cdBuilder.Build(withConditions, withOrder), availableZone, accountKeySecretObj},
+		records:  presentedRecords(),
+		configureACME: func(expect *mock.MockClientMockRecorder) {
+			order := orderWithStatus(acmeclient.StatusInvalid)
+			order.Error = &acmeclient.Problem{Detail: "authorization failed"}
+			expect.GetOrder(testOrderURL).Return(order, nil)
+			authorizations(expect, acmeclient.StatusInvalid)
+		},
+		expectedErr:     "certificate bundle default: ACME order is invalid: [authorization failed]",
+		expectedStatus:  []hivev1.CertificateBundleStatus{{Name: "default"}},
+		expectedRecords: fakeTXTRecords{},
+		expectedCondition: &hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+			Status: corev1.ConditionTrue,
+			Reason: "IssuanceFailed",
+		},
+	}, {
+		name:     "expired order",
+		existing: []runtime.Object{cdBuilder.Build(withConditions, withOrder), availableZone, accountKeySecretObj},
+		configureACME: func(expect *mock.MockClientMockRecorder) {
+			expect.GetOrder(testOrderURL).Return(nil, &acmeclient.Problem{Status: 404, Detail: "not found"})
+		},
+		expectedResult: reconcile.Result{RequeueAfter: orderPollInterval},
+		expectedStatus: []hivev1.CertificateBundleStatus{{Name: "default"}},
+	}, {
+		name: "domain outside of the managed zone",
+		existing: []runtime.Object{
+			cdBuilder.Build(withConditions, func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.ControlPlaneConfig.ServingCertificates.Additional = []hivev1.ControlPlaneAdditionalCertificate{{
+					Name:   "default",
+					Domain: "api.other.com",
+				}}
+			}),
+			availableZone,
+		},
+		expectedErr:    "certificate bundle default: domain api.other.com is not in the managed DNS zone test-cd.example.com",
+		expectedStatus: []hivev1.CertificateBundleStatus{},
+		expectedCondition: &hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.CertificateBundleIssuanceFailedClusterDeploymentCondition,
+			Status: corev1.ConditionTrue,
+			Reason: "IssuanceFailed",
+		},
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockedACMEClient := mock.NewMockClient(mockCtrl)
+			if test.configureACME != nil {
+				mockedACMEClient.EXPECT().Register("admin@example.com").Return("https://acme.example.com/account/1", nil)
+				mockedACMEClient.EXPECT().DNS01ChallengeRecord(gomock.Any()).DoAndReturn(func(token string) (string, error) {
+					return "record-" + token, nil
+				}).AnyTimes()
+				test.configureACME(mockedACMEClient.EXPECT())
+			}
+			records := test.records
+			if records == nil {
+				records = fakeTXTRecords{}
+			}
+			config := test.config
+			if config == nil {
+				config = &hivev1.ACMEIssuerConfig{
+					Email:               "admin@example.com",
+					AccountKeySecretRef: corev1.LocalObjectReference{Name: accountKeySecret},
+				}
+			}
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			log.SetLevel(log.DebugLevel)
+			reconciler := &ReconcileACMEIssuer{
+				Client:           fakeClient,
+				controllerconfig: config,
+				acmeClientFn: func(directoryURL string, accountKey crypto.Signer) (acmeclient.Client, error) {
+					return mockedACMEClient, nil
+				},
+				txtRecordActuatorFn: func(client.Client, *hivev1.DNSZone, log.FieldLogger) (dnszone.TXTRecordActuator, error) {
+					return records, nil
+				},
+				txtRecordPropagatedFn: func(nameServers []string, name string, values []string) (bool, error) {
+					if len(nameServers) == 0 {
+						return false, errors.New("no name servers")
+					}
+					return test.propagated, nil
+				},
+			}
+
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+			if test.expectedErr == "" {
+				assert.NoError(t, err, "unexpected error from Reconcile")
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+			if test.renewal {
+				assert.Greater(t, result.RequeueAfter, 24*time.Hour, "expected requeue for the renewal of the certificate")
+			} else {
+				assert.Equal(t, test.expectedResult, result, "unexpected reconcile result")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), key, cd))
+			if test.expectedStatus != nil {
+				assert.ElementsMatch(t, test.expectedStatus, cd.Status.CertificateBundles, "unexpected certificate bundle status")
+			}
+			if test.expectedRecords != nil {
+				assert.Equal(t, test.expectedRecords, records, "unexpected TXT records")
+			}
+			if test.expectedCondition != nil {
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, test.expectedCondition.Type)
+				if assert.NotNil(t, cond, "expected condition") {
+					assert.Equal(t, test.expectedCondition.Status, cond.Status, "unexpected condition status")
+					assert.Equal(t, test.expectedCondition.Reason, cond.Reason, "unexpected condition reason")
+				}
+			}
+			if test.validate != nil {
+				test.validate(t, fakeClient)
+			}
+		})
+	}
+}
+
+func TestGeneratedBundles(t *testing.T) {
+	cd := testcd.Build(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.ClusterName = "test-cd"
+		cd.Spec.BaseDomain = "example.com"
+		cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+			{Name: "api", Generate: true},
+			{Name: "ingress", Generate: true},
+			{Name: "provided"},
+			{Name: "unused", Generate: true},
+		}
+		cd.Spec.ControlPlaneConfig.ServingCertificates.Default = "api"
+		cd.Spec.ControlPlaneConfig.ServingCertificates.Additional = []hivev1.ControlPlaneAdditionalCertificate{
+			{Name: "api", Domain: "api.test-cd.example.com"},
+			{Name: "api", Domain: "api-int.test-cd.example.com"},
+			{Name: "provided", Domain: "api.example.org"},
+		}
+		cd.Spec.Ingress = []hivev1.ClusterIngress{
+			{Name: "default", Domain: "apps.test-cd.example.com", ServingCertificate: "ingress"},
+			{Name: "other", Domain: "other.test-cd.example.com", ServingCertificate: "ingress"},
+		}
+	})
+
+	bundles := generatedBundles(cd)
+	require.Len(t, bundles, 2, "unexpected number of generated bundles")
+	assert.Equal(t, "api", bundles[0].Name)
+	assert.Equal(t, []string{"api.test-cd.example.com", "api-int.test-cd.example.com"}, bundles[0].domains)
+	assert.Equal(t, "ingress", bundles[1].Name)
+	assert.Equal(t, []string{"*.apps.test-cd.example.com", "*.other.test-cd.example.com"}, bundles[1].domains)
+}
+
+// testCertificate returns a PEM-encoded self-signed certificate for the domains.
+func testCertificate(t *testing.T, notAfter time.Time, domains ...string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domains[0]},
+		DNSNames:     domains,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
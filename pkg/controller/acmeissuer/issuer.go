@@ -0,0 +1,478 @@
+package acmeissuer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/acmeclient"
+	"github.com/openshift/hive/pkg/controller/dnszone"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	finalizePollInterval = 2 * time.Second
+	finalizeTimeout      = 1 * time.Minute
+)
+
+// issuerContext holds the state of a reconcile of the certificate bundles of a ClusterDeployment. The ACME client
+// and the TXT record actuator are only created when a bundle must be issued.
+type issuerContext struct {
+	reconciler *ReconcileACMEIssuer
+	cd         *hivev1.ClusterDeployment
+	dnsZone    *hivev1.DNSZone
+	logger     log.FieldLogger
+
+	acmeClient  acmeclient.Client
+	txtActuator dnszone.TXTRecordActuator
+}
+
+// dnsChallenge is the DNS-01 challenge of an authorization of an order.
+type dnsChallenge struct {
+	// recordName is the fully qualified name of the TXT record answering the challenge.
+	recordName  string
+	recordValue string
+	authzStatus string
+	challenge   acmeclient.Challenge
+}
+
+// reconcileBundle issues or renews the certificate bundle when needed, and returns when the bundle must be
+// reconciled again.
+func (ic *issuerContext) reconcileBundle(bundle generatedBundle, logger log.FieldLogger) (time.Duration, error) {
+	for _, domain := range bundle.domains {
+		if !inZone(domain, ic.dnsZone.Spec.Zone) {
+			return 0, fmt.Errorf("domain %s is not in the managed DNS zone %s", domain, ic.dnsZone.Spec.Zone)
+		}
+	}
+
+	status := bundleStatus(ic.cd, bundle.Name)
+	if status.ACMEOrderURL == "" {
+		renewAt, err := ic.renewalTime(bundle)
+		if err != nil {
+			return 0, err
+		}
+		if now := time.Now(); renewAt.After(now) {
+			logger.WithField("renewAt", renewAt).Debug("certificate bundle is up to date")
+			status.Generated = true
+			return renewAt.Sub(now), nil
+		}
+		return orderPollInterval, ic.createOrder(bundle, status, logger)
+	}
+
+	if err := ic.setupClients(); err != nil {
+		return 0, err
+	}
+	order, err := ic.acmeClient.GetOrder(status.ACMEOrderURL)
+	if acmeclient.IsNotFound(err) {
+		logger.Info("ACME order not found, starting over")
+		status.ACMEOrderURL = ""
+		return orderPollInterval, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	orderLog := logger.WithField("order", order.URL).WithField("status", order.Status)
+	challenges, err := ic.dnsChallenges(order)
+	if err != nil {
+		return 0, err
+	}
+
+	switch order.Status {
+	case acmeclient.StatusPending:
+		return orderPollInterval, ic.acceptChallenges(challenges, orderLog)
+	case acmeclient.StatusReady:
+		renewAt, err := ic.finalizeOrder(bundle, order, orderLog)
+		if err != nil {
+			// the private key of the certificate is lost, so a new order is needed.
+			if err := ic.cleanupChallenges(challenges); err != nil {
+				orderLog.WithError(err).Warn("failed to clean up the TXT records of the ACME challenges")
+			}
+			status.ACMEOrderURL = ""
+			return 0, err
+		}
+		if err := ic.cleanupChallenges(challenges); err != nil {
+			return 0, err
+		}
+		status.ACMEOrderURL = ""
+		status.Generated = true
+		return time.Until(renewAt), nil
+	case acmeclient.StatusInvalid:
+		if err := ic.cleanupChallenges(challenges); err != nil {
+			return 0, err
+		}
+		status.ACMEOrderURL = ""
+		return 0, orderError(order, challenges)
+	default:
+		// the order was finalized by a previous reconcile that did not complete, and the private key of the
+		// certificate is lost, so a new order is needed.
+		orderLog.Info("ACME order was finalized without storing the certificate, starting over")
+		if err := ic.cleanupChallenges(challenges); err != nil {
+			return 0, err
+		}
+		status.ACMEOrderURL = ""
+		return orderPollInterval, nil
+	}
+}
+
+// renewalTime returns when the certificate in the secret of the bundle must be renewed, which is the zero time when
+// the secret does not exist or does not hold a valid certificate for the domains of the bundle.
+func (ic *issuerContext) renewalTime(bundle generatedBundle) (time.Time, error) {
+	secret := &corev1.Secret{}
+	err := ic.reconciler.Get(context.TODO(), types.NamespacedName{Namespace: ic.cd.Namespace, Name: bundle.CertificateSecretRef.Name}, secret)
+	if apierrors.IsNotFound(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return time.Time{}, nil
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return time.Time{}, nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil || !sets.NewString(cert.DNSNames...).HasAll(bundle.domains...) {
+		return time.Time{}, nil
+	}
+	return cert.NotAfter.Add(-ic.reconciler.renewBefore()), nil
+}
+
+// createOrder creates an order for the domains of the bundle and sets the TXT records answering its challenges.
+func (ic *issuerContext) createOrder(bundle generatedBundle, status *hivev1.CertificateBundleStatus, logger log.FieldLogger) error {
+	if err := ic.setupClients(); err != nil {
+		return err
+	}
+	order, err := ic.acmeClient.CreateOrder(bundle.domains)
+	if err != nil {
+		return err
+	}
+	if order.URL == "" {
+		return errors.New("the ACME server did not return the URL of the order")
+	}
+	challenges, err := ic.dnsChallenges(order)
+	if err != nil {
+		return err
+	}
+	for name, values := range txtRecords(challenges, acmeclient.StatusPending) {
+		if err := ic.txtActuator.SetTXTRecord(name, values); err != nil {
+			return errors.Wrapf(err, "failed to set TXT record %s", name)
+		}
+	}
+	logger.WithField("order", order.URL).Info("created ACME order")
+	status.ACMEOrderURL = order.URL
+	return nil
+}
+
+// acceptChallenges tells the ACME server to validate the pending challenges once their TXT records are visible on
+// all the name servers of the zone. The TXT records that are not visible are set again in case they were changed
+// since the order was created.
+func (ic *issuerContext) acceptChallenges(challenges []dnsChallenge, logger log.FieldLogger) error {
+	records := txtRecords(challenges, acmeclient.StatusPending)
+	for _, c := range challenges {
+		if c.authzStatus != acmeclient.StatusPending || c.challenge.Status != acmeclient.StatusPending {
+			continue
+		}
+		propagated, err := ic.reconciler.txtRecordPropagatedFn(ic.dnsZone.Status.NameServers, c.recordName, records[c.recordName])
+		if err != nil {
+			return err
+		}
+		if !propagated {
+			logger.WithField("record", c.recordName).Debug("TXT record is not visible on the name servers yet")
+			if err := ic.txtActuator.SetTXTRecord(c.recordName, records[c.recordName]); err != nil {
+				return errors.Wrapf(err, "failed to set TXT record %s", c.recordName)
+			}
+			continue
+		}
+		logger.WithField("record", c.recordName).Info("accepting ACME challenge")
+		if _, err := ic.acmeClient.AcceptChallenge(c.challenge.URL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finalizeOrder requests the certificate of the ready order with a new private key and stores both in the secret
+// of the bundle. It returns when the certificate must be renewed.
+func (ic *issuerContext) finalizeOrder(bundle generatedBundle, order *acmeclient.Order, logger log.FieldLogger) (time.Time, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return time.Time{}, err
+	}
+	template := &x509.CertificateRequest{DNSNames: bundle.domains}
+	if len(bundle.domains[0]) <= 64 {
+		template.Subject = pkix.Name{CommonName: bundle.domains[0]}
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	logger.Info("finalizing ACME order")
+	orderURL := order.URL
+	order, err = ic.acmeClient.FinalizeOrder(order.Finalize, csr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	err = wait.PollImmediate(finalizePollInterval, finalizeTimeout, func() (bool, error) {
+		switch order.Status {
+		case acmeclient.StatusValid:
+			return true, nil
+		case acmeclient.StatusInvalid:
+			return false, orderError(order, nil)
+		}
+		var err error
+		order, err = ic.acmeClient.GetOrder(orderURL)
+		return false, err
+	})
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed waiting for the certificate to be issued")
+	}
+
+	chain, err := ic.acmeClient.GetCertificate(order.Certificate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	block, _ := pem.Decode(chain)
+	if block == nil {
+		return time.Time{}, errors.New("the ACME server returned an invalid certificate chain")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "the ACME server returned an invalid certificate")
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := ic.writeSecret(bundle, chain, keyPEM); err != nil {
+		return time.Time{}, err
+	}
+	logger.WithField("notAfter", cert.NotAfter).Info("issued certificate bundle")
+	return cert.NotAfter.Add(-ic.reconciler.renewBefore()), nil
+}
+
+// writeSecret creates or updates the TLS secret of the bundle, owned by the ClusterDeployment.
+func (ic *issuerContext) writeSecret(bundle generatedBundle, chain, key []byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ic.cd.Namespace,
+			Name:      bundle.CertificateSecretRef.Name,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(context.TODO(), ic.reconciler.Client, secret, func() error {
+		if secret.CreationTimestamp.IsZero() {
+			secret.Type = corev1.SecretTypeTLS
+		}
+		secret.Data = map[string][]byte{
+			corev1.TLSCertKey:       chain,
+			corev1.TLSPrivateKeyKey: key,
+		}
+		return controllerutil.SetOwnerReference(ic.cd, secret, ic.reconciler.Scheme())
+	})
+	return errors.Wrapf(err, "failed to write secret %s", bundle.CertificateSecretRef.Name)
+}
+
+// cleanupChallenges removes the TXT records of the challenges of an order.
+func (ic *issuerContext) cleanupChallenges(challenges []dnsChallenge) error {
+	for name, values := range txtRecords(challenges, "") {
+		if err := ic.txtActuator.DeleteTXTRecord(name, values); err != nil {
+			return errors.Wrapf(err, "failed to delete TXT record %s", name)
+		}
+	}
+	return nil
+}
+
+// dnsChallenges returns the DNS-01 challenges of the authorizations of the order.
+func (ic *issuerContext) dnsChallenges(order *acmeclient.Order) ([]dnsChallenge, error) {
+	var challenges []dnsChallenge
+	for _, authzURL := range order.Authorizations {
+		authz, err := ic.acmeClient.GetAuthorization(authzURL)
+		if err != nil {
+			return nil, err
+		}
+		var challenge *acmeclient.Challenge
+		for i := range authz.Challenges {
+			if authz.Challenges[i].Type == acmeclient.ChallengeTypeDNS01 {
+				challenge = &authz.Challenges[i]
+			}
+		}
+		if challenge == nil {
+			return nil, fmt.Errorf("the ACME server did not offer a %s challenge for %s", acmeclient.ChallengeTypeDNS01, authz.Identifier.Value)
+		}
+		value, err := ic.acmeClient.DNS01ChallengeRecord(challenge.Token)
+		if err != nil {
+			return nil, err
+		}
+		challenges = append(challenges, dnsChallenge{
+			recordName:  "_acme-challenge." + authz.Identifier.Value,
+			recordValue: value,
+			authzStatus: authz.Status,
+			challenge:   *challenge,
+		})
+	}
+	return challenges, nil
+}
+
+// txtRecords returns the values of the TXT records answering the challenges, by record name. A name and its
+// wildcard share a record. When authzStatus is not empty, only the challenges of authorizations in that status
+// are included.
+func txtRecords(challenges []dnsChallenge, authzStatus string) map[string][]string {
+	records := map[string]sets.String{}
+	for _, c := range challenges {
+		if authzStatus != "" && c.authzStatus != authzStatus {
+			continue
+		}
+		if records[c.recordName] == nil {
+			records[c.recordName] = sets.NewString()
+		}
+		records[c.recordName].Insert(c.recordValue)
+	}
+	result := make(map[string][]string, len(records))
+	for name, values := range records {
+		result[name] = values.List()
+	}
+	return result
+}
+
+// orderError returns the error of an invalid order, including the errors of its failed challenges.
+func orderError(order *acmeclient.Order, challenges []dnsChallenge) error {
+	var msgs []string
+	if order.Error != nil {
+		msgs = append(msgs, order.Error.Detail)
+	}
+	for _, c := range challenges {
+		if c.challenge.Error != nil {
+			msgs = append(msgs, c.challenge.Error.Detail)
+		}
+	}
+	sort.Strings(msgs)
+	return fmt.Errorf("ACME order is invalid: %v", msgs)
+}
+
+// setupClients creates the ACME client, registering the account, and the actuator for the TXT records of the zone.
+func (ic *issuerContext) setupClients() error {
+	if ic.acmeClient != nil {
+		return nil
+	}
+	r := ic.reconciler
+	accountKey, err := r.getAccountKey(ic.logger)
+	if err != nil {
+		return err
+	}
+	acmeClient, err := r.acmeClientFn(r.controllerconfig.DirectoryURL, accountKey)
+	if err != nil {
+		return err
+	}
+	if _, err := acmeClient.Register(r.controllerconfig.Email); err != nil {
+		return err
+	}
+	txtActuator, err := r.txtRecordActuatorFn(r.Client, ic.dnsZone, ic.logger)
+	if err != nil {
+		return err
+	}
+	ic.acmeClient = acmeClient
+	ic.txtActuator = txtActuator
+	return nil
+}
+
+// getAccountKey returns the key of the ACME account from the secret in the hive namespace, creating the secret
+// with a new key when it does not exist.
+func (r *ReconcileACMEIssuer) getAccountKey(logger log.FieldLogger) (*ecdsa.PrivateKey, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: r.controllerconfig.AccountKeySecretRef.Name}
+	err := r.Get(context.TODO(), key, secret)
+	if err == nil {
+		block, _ := pem.Decode(secret.Data[corev1.TLSPrivateKeyKey])
+		if block == nil {
+			return nil, fmt.Errorf("secret %s does not contain a PEM-encoded %q key", key.Name, corev1.TLSPrivateKeyKey)
+		}
+		if accountKey, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+			return accountKey, nil
+		}
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse the ACME account key")
+		}
+		accountKey, ok := parsed.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("the ACME account key is not an ECDSA key")
+		}
+		return accountKey, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	logger.WithField("secret", key.Name).Info("generating ACME account key")
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(accountKey)
+	if err != nil {
+		return nil, err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}),
+		},
+	}
+	if err := r.Create(context.TODO(), secret); err != nil {
+		return nil, errors.Wrap(err, "failed to create the ACME account key secret")
+	}
+	return accountKey, nil
+}
+
+// txtRecordPropagated returns true if the TXT record with the given name has all the values on all the name
+// servers.
+func txtRecordPropagated(nameServers []string, name string, values []string) (bool, error) {
+	if len(nameServers) == 0 {
+		return false, errors.New("the name servers of the DNSZone are not known")
+	}
+	client := &dns.Client{Timeout: 10 * time.Second}
+	for _, ns := range nameServers {
+		m := &dns.Msg{}
+		m.SetQuestion(dns.Fqdn(name), dns.TypeTXT)
+		m.RecursionDesired = false
+		resp, _, err := client.Exchange(m, net.JoinHostPort(controllerutils.Undotted(ns), "53"))
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to query name server %s", ns)
+		}
+		found := sets.NewString()
+		for _, rr := range resp.Answer {
+			if txt, ok := rr.(*dns.TXT); ok {
+				found.Insert(txt.Txt...)
+			}
+		}
+		if !found.HasAll(values...) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
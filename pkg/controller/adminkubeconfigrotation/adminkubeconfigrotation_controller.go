@@ -0,0 +1,428 @@
+package adminkubeconfigrotation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.AdminKubeconfigRotationControllerName
+
+	defaultRotationInterval = 30 * 24 * time.Hour
+	defaultRequeueLater     = 1 * time.Minute
+
+	// tokenPollInterval is how often the token secret is checked until the token controller of the cluster
+	// populates it.
+	tokenPollInterval = 5 * time.Second
+
+	// kubeconfigSecretSuffix is the suffix of the name of the secret with the service account kubeconfig.
+	kubeconfigSecretSuffix = "hive-admin-kubeconfig"
+
+	rotationFailedReason    = "RotationFailed"
+	revocationFailedReason  = "RevocationFailed"
+	rotationSucceededReason = "RotationSucceeded"
+)
+
+// clusterDeploymentAdminKubeconfigRotationConditions are the cluster deployment conditions controlled by
+// the admin kubeconfig rotation controller
+var clusterDeploymentAdminKubeconfigRotationConditions = []hivev1.ClusterDeploymentConditionType{
+	hivev1.AdminKubeconfigRotationFailedClusterDeploymentCondition,
+}
+
+// Add creates a new AdminKubeconfigRotation Controller and adds it to the Manager with default RBAC.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	reconciler, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("could not create reconciler")
+		return err
+	}
+	return AddToManager(mgr, reconciler, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileAdminKubeconfigRotation
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileAdminKubeconfigRotation, error) {
+	logger := log.WithField("controller", ControllerName)
+	reconciler := &ReconcileAdminKubeconfigRotation{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		nowFn:  time.Now,
+	}
+
+	config, err := ReadAdminKubeconfigRotationControllerConfigFile()
+	if err != nil {
+		logger.WithError(err).Error("could not get load configuration")
+		return reconciler, err
+	}
+	reconciler.controllerconfig = config
+	reconciler.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(reconciler.Client, cd, ControllerName)
+	}
+	reconciler.kubeconfigClientFn = func(c client.Client, secret *corev1.Secret) (client.Client, error) {
+		return remoteclient.NewBuilderFromKubeconfig(c, secret).Build()
+	}
+	return reconciler, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileAdminKubeconfigRotation, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controller.New("adminkubeconfigrotation-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}},
+		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, controllerutils.IsClusterDeploymentErrorUpdateEvent))
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileAdminKubeconfigRotation{}
+
+// ReconcileAdminKubeconfigRotation creates and rotates the service account kubeconfig Hive uses to connect to
+// installed clusters in place of the admin kubeconfig generated by the installer.
+type ReconcileAdminKubeconfigRotation struct {
+	client.Client
+
+	controllerconfig *hivev1.AdminKubeconfigRotationConfig
+
+	// remoteClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// testing purpose
+	kubeconfigClientFn func(c client.Client, secret *corev1.Secret) (client.Client, error)
+	nowFn              func() time.Time
+}
+
+// Reconcile creates the service account kubeconfig of an installed ClusterDeployment, rotates it when it is due or
+// requested, and revokes the installer admin kubeconfig when configured to.
+func (r *ReconcileAdminKubeconfigRotation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		// Error reading the object - requeue the request.
+		logger.WithError(err).Error("error getting ClusterDeployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.DeletionTimestamp != nil {
+		// the kubeconfig secret is owned by the ClusterDeployment, and the service account is deleted with the cluster.
+		logger.Debug("cluster deployment is being deleted, so skipping")
+		return reconcile.Result{}, nil
+	}
+	if r.controllerconfig == nil {
+		logger.Debug("admin kubeconfig rotation is not configured, so skipping")
+		return reconcile.Result{}, nil
+	}
+	if !cd.Spec.Installed || cd.Spec.ClusterMetadata == nil {
+		logger.Debug("cluster deployment is not installed, so skipping")
+		return reconcile.Result{}, nil
+	}
+	if controllerutils.IsFakeCluster(cd) {
+		logger.Debug("fake cluster, so skipping")
+		return reconcile.Result{}, nil
+	}
+
+	// Initialize cluster deployment conditions if not present
+	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, clusterDeploymentAdminKubeconfigRotationConditions)
+	if len(newConditions) > len(cd.Status.Conditions) {
+		cd.Status.Conditions = newConditions
+		logger.Info("initializing admin kubeconfig rotation controller conditions")
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		logger.Debug("cluster is unreachable, waiting")
+		return reconcile.Result{RequeueAfter: defaultRequeueLater}, nil
+	}
+
+	rotationReason := r.rotationReason(cd)
+	revoke := r.controllerconfig.RevokeInstallerKubeconfig &&
+		(cd.Status.AdminKubeconfigRotation == nil || !cd.Status.AdminKubeconfigRotation.InstallerKubeconfigRevoked)
+	// A failed reconcile is retried even if nothing is due, so that the token secrets left over by an interrupted
+	// rotation are deleted.
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.AdminKubeconfigRotationFailedClusterDeploymentCondition)
+	retry := cond != nil && cond.Status == corev1.ConditionTrue
+	if rotationReason == "" && !revoke && !retry {
+		logger.Debug("service account kubeconfig is up to date")
+		return reconcile.Result{RequeueAfter: r.requeueAfter(cd)}, nil
+	}
+
+	if rotationReason != "" {
+		logger.WithField("reason", rotationReason).Info("rotating service account kubeconfig")
+		rotated, err := r.rotate(cd, logger)
+		if err != nil {
+			logger.WithError(err).Error("failed to rotate service account kubeconfig")
+			return reconcile.Result{}, r.setFailedCondition(cd, rotationFailedReason, err, logger)
+		}
+		if !rotated {
+			logger.Debug("waiting for the service account token")
+			return reconcile.Result{RequeueAfter: tokenPollInterval}, nil
+		}
+	}
+
+	// The remote client uses the service account kubeconfig from now on.
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err != nil {
+		logger.WithError(err).Error("failed to connect to the cluster with the service account kubeconfig")
+		return reconcile.Result{}, r.setFailedCondition(cd, rotationFailedReason, err, logger)
+	}
+	if err := deleteStaleTokens(remoteClient, cd.Status.AdminKubeconfigRotation.TokenSecretName, logger); err != nil {
+		logger.WithError(err).Error("failed to delete the previous service account tokens")
+		return reconcile.Result{}, r.setFailedCondition(cd, rotationFailedReason, err, logger)
+	}
+
+	if revoke {
+		logger.Info("revoking the installer admin kubeconfig")
+		if err := revokeInstallerKubeconfig(remoteClient, logger); err != nil {
+			logger.WithError(err).Error("failed to revoke the installer admin kubeconfig")
+			return reconcile.Result{}, r.setFailedCondition(cd, revocationFailedReason, err, logger)
+		}
+		cd.Status.AdminKubeconfigRotation.InstallerKubeconfigRevoked = true
+	}
+
+	cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.AdminKubeconfigRotationFailedClusterDeploymentCondition,
+		corev1.ConditionFalse,
+		rotationSucceededReason,
+		"The service account kubeconfig is up to date",
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: r.requeueAfter(cd)}, nil
+}
+
+// rotationReason returns why the service account kubeconfig must be rotated, or an empty string if it is up to date.
+func (r *ReconcileAdminKubeconfigRotation) rotationReason(cd *hivev1.ClusterDeployment) string {
+	rotation := cd.Status.AdminKubeconfigRotation
+	switch {
+	case rotation == nil:
+		return "no service account kubeconfig"
+	case cd.Annotations[constants.RotateAdminKubeconfigAnnotation] != rotation.LastRotationRequest:
+		return "rotation requested"
+	case !r.nowFn().Before(rotation.LastRotationTime.Add(r.rotationInterval())):
+		return "rotation interval elapsed"
+	}
+	return ""
+}
+
+func (r *ReconcileAdminKubeconfigRotation) requeueAfter(cd *hivev1.ClusterDeployment) time.Duration {
+	rotation := cd.Status.AdminKubeconfigRotation
+	if rotation == nil {
+		return defaultRequeueLater
+	}
+	after := rotation.LastRotationTime.Add(r.rotationInterval()).Sub(r.nowFn())
+	if after <= 0 {
+		return defaultRequeueLater
+	}
+	return after
+}
+
+func (r *ReconcileAdminKubeconfigRotation) rotationInterval() time.Duration {
+	if r.controllerconfig.RotationInterval != nil {
+		return r.controllerconfig.RotationInterval.Duration
+	}
+	return defaultRotationInterval
+}
+
+// rotate mints a new token for the service account and switches the service account kubeconfig to it. It returns
+// false while the token controller of the cluster has not populated the token yet.
+func (r *ReconcileAdminKubeconfigRotation) rotate(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to connect to the cluster")
+	}
+	if err := ensureServiceAccount(remoteClient, logger); err != nil {
+		return false, err
+	}
+	currentToken := ""
+	if cd.Status.AdminKubeconfigRotation != nil {
+		currentToken = cd.Status.AdminKubeconfigRotation.TokenSecretName
+	}
+	tokenSecret, err := pendingToken(remoteClient, currentToken, logger)
+	if err != nil || tokenSecret == nil {
+		return false, err
+	}
+
+	kubeconfig, err := r.serviceAccountKubeconfig(cd, tokenSecret.Data[corev1.ServiceAccountTokenKey])
+	if err != nil {
+		return false, err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cd.Namespace,
+			Name:      apihelpers.GetResourceName(cd.Name, kubeconfigSecretSuffix),
+		},
+	}
+	// Make sure the new kubeconfig works before switching to it.
+	verifySecret := secret.DeepCopy()
+	verifySecret.Data = map[string][]byte{constants.KubeconfigSecretKey: kubeconfig}
+	verifyClient, err := r.kubeconfigClientFn(r.Client, verifySecret)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to connect to the cluster with the new service account token")
+	}
+	if err := verifyClient.Get(context.TODO(), types.NamespacedName{Namespace: serviceAccountNamespace, Name: serviceAccountName}, &corev1.ServiceAccount{}); err != nil {
+		return false, errors.Wrap(err, "failed to verify the new service account token")
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[constants.ClusterDeploymentNameLabel] = cd.Name
+		secret.Data = verifySecret.Data
+		return controllerutil.SetControllerReference(cd, secret, r.Scheme())
+	}); err != nil {
+		return false, errors.Wrapf(err, "failed to write secret %s", secret.Name)
+	}
+
+	revoked := cd.Status.AdminKubeconfigRotation != nil && cd.Status.AdminKubeconfigRotation.InstallerKubeconfigRevoked
+	cd.Status.AdminKubeconfigRotation = &hivev1.AdminKubeconfigRotationStatus{
+		KubeconfigSecretRef:        corev1.LocalObjectReference{Name: secret.Name},
+		TokenSecretName:            tokenSecret.Name,
+		LastRotationTime:           metav1.NewTime(r.nowFn()),
+		LastRotationRequest:        cd.Annotations[constants.RotateAdminKubeconfigAnnotation],
+		InstallerKubeconfigRevoked: revoked,
+	}
+	// Record the new token before the previous ones are deleted, so that the cluster stays reachable if the
+	// deletion fails.
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		return false, errors.Wrap(err, "failed to update cluster deployment status")
+	}
+	logger.WithField("tokenSecret", tokenSecret.Name).Info("rotated service account kubeconfig")
+	return true, nil
+}
+
+// serviceAccountKubeconfig returns a kubeconfig for the service account token with the cluster of the installer
+// admin kubeconfig, so that it uses the same API URL and trusts the same CAs.
+func (r *ReconcileAdminKubeconfigRotation) serviceAccountKubeconfig(cd *hivev1.ClusterDeployment, token []byte) ([]byte, error) {
+	adminSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, adminSecret); err != nil {
+		return nil, errors.Wrap(err, "failed to get admin kubeconfig secret")
+	}
+	adminConfig, err := clientcmd.Load(adminSecret.Data[constants.KubeconfigSecretKey])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse admin kubeconfig")
+	}
+	adminContext, ok := adminConfig.Contexts[adminConfig.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("admin kubeconfig has no context %q", adminConfig.CurrentContext)
+	}
+	cluster, ok := adminConfig.Clusters[adminContext.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("admin kubeconfig has no cluster %q", adminContext.Cluster)
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[adminContext.Cluster] = cluster
+	config.AuthInfos[serviceAccountName] = &clientcmdapi.AuthInfo{Token: string(token)}
+	config.Contexts[serviceAccountName] = &clientcmdapi.Context{Cluster: adminContext.Cluster, AuthInfo: serviceAccountName}
+	config.CurrentContext = serviceAccountName
+	return clientcmd.Write(*config)
+}
+
+func (r *ReconcileAdminKubeconfigRotation) setFailedCondition(cd *hivev1.ClusterDeployment, reason string, err error, logger log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.AdminKubeconfigRotationFailedClusterDeploymentCondition,
+		corev1.ConditionTrue,
+		reason,
+		controllerutils.ErrorScrub(err),
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if changed {
+		cd.Status.Conditions = conditions
+		logger.Debug("setting AdminKubeconfigRotationFailedClusterDeploymentCondition to true")
+		if updateErr := r.Status().Update(context.TODO(), cd); updateErr != nil {
+			logger.WithError(updateErr).Log(controllerutils.LogLevel(updateErr), "failed to update cluster deployment status")
+		}
+	}
+	return err
+}
+
+// ReadAdminKubeconfigRotationControllerConfigFile reads the configuration from the env
+// and unmarshals. If the env is set to a file but that file doesn't exist it returns
+// a zero value configuration.
+func ReadAdminKubeconfigRotationControllerConfigFile() (*hivev1.AdminKubeconfigRotationConfig, error) {
+	fPath := os.Getenv(constants.AdminKubeconfigRotationControllerConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+
+	config := &hivev1.AdminKubeconfigRotationConfig{}
+
+	fileBytes, err := ioutil.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, errors.Wrap(err, "failed to read the admin kubeconfig rotation controller config file")
+	}
+	if err := json.Unmarshal(fileBytes, &config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
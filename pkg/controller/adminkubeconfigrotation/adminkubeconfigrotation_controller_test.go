@@ -0,0 +1,400 @@
+package adminkubeconfigrotation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+const (
+	testNS           = "test-namespace"
+	kubeconfigSecret = "test-cd-admin-kubeconfig"
+	rotatedSecret    = "test-cd-hive-admin-kubeconfig"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+	remoteScheme := runtime.NewScheme()
+	corev1.AddToScheme(remoteScheme)
+	rbacv1.AddToScheme(remoteScheme)
+
+	now := time.Now().Truncate(time.Second)
+	key := client.ObjectKey{Name: "test-cd", Namespace: testNS}
+	cdBuilder := testcd.FullBuilder(testNS, "test-cd", scheme).Options(
+		testcd.Installed(),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: kubeconfigSecret},
+			}
+		},
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{Type: hivev1.UnreachableCondition, Status: corev1.ConditionFalse}),
+	)
+	withCondition := func(status corev1.ConditionStatus) testcd.Option {
+		return testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Status: status,
+			Type:   hivev1.AdminKubeconfigRotationFailedClusterDeploymentCondition,
+		})
+	}
+	withRotation := func(token string, lastRotation time.Time) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.AdminKubeconfigRotation = &hivev1.AdminKubeconfigRotationStatus{
+				KubeconfigSecretRef: corev1.LocalObjectReference{Name: rotatedSecret},
+				TokenSecretName:     token,
+				LastRotationTime:    metav1.NewTime(lastRotation),
+			}
+		}
+	}
+	adminKubeconfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: kubeconfigSecret},
+		Data:       map[string][]byte{constants.KubeconfigSecretKey: testKubeconfig(t)},
+	}
+	tokenSecret := func(name, token string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   serviceAccountNamespace,
+				Name:        name,
+				Labels:      map[string]string{tokenSecretLabel: "true"},
+				Annotations: map[string]string{corev1.ServiceAccountNameKey: serviceAccountName},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+		}
+		if token != "" {
+			secret.Data = map[string][]byte{corev1.ServiceAccountTokenKey: []byte(token)}
+		}
+		return secret
+	}
+	clientCA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: adminKubeconfigClientCANamespace, Name: adminKubeconfigClientCAName},
+		Data:       map[string]string{adminKubeconfigClientCAKey: "installer-ca"},
+	}
+	rotationInterval := 24 * time.Hour
+
+	cases := []struct {
+		name             string
+		notConfigured    bool
+		config           *hivev1.AdminKubeconfigRotationConfig
+		existing         []runtime.Object
+		remoteExisting   []runtime.Object
+		expectRemote     bool
+		verifyErr        error
+		expectErr        bool
+		expectConditions bool
+		expectStatus     corev1.ConditionStatus
+		expectReason     string
+		expectToken      string
+		expectTokens     []string
+		expectNewToken   bool
+		expectRotatedAt  *time.Time
+		expectRevoked    bool
+		expectRequeue    time.Duration
+	}{
+		{
+			name:          "not configured",
+			notConfigured: true,
+			existing:      []runtime.Object{cdBuilder.Build(withCondition(corev1.ConditionUnknown))},
+		},
+		{
+			name:     "not installed",
+			existing: []runtime.Object{cdBuilder.Build(withCondition(corev1.ConditionUnknown), func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false })},
+		},
+		{
+			name:             "initialize conditions",
+			existing:         []runtime.Object{cdBuilder.Build()},
+			expectConditions: true,
+		},
+		{
+			name: "unreachable",
+			existing: []runtime.Object{cdBuilder.Build(
+				withCondition(corev1.ConditionUnknown),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{Type: hivev1.UnreachableCondition, Status: corev1.ConditionTrue}),
+			)},
+			expectRequeue: defaultRequeueLater,
+		},
+		{
+			name:           "create token",
+			existing:       []runtime.Object{cdBuilder.Build(withCondition(corev1.ConditionUnknown)), adminKubeconfigSecret},
+			expectRemote:   true,
+			expectNewToken: true,
+			expectRequeue:  tokenPollInterval,
+		},
+		{
+			name:           "wait for token",
+			existing:       []runtime.Object{cdBuilder.Build(withCondition(corev1.ConditionUnknown)), adminKubeconfigSecret},
+			remoteExisting: []runtime.Object{tokenSecret("hive-admin-token-new", "")},
+			expectRemote:   true,
+			expectTokens:   []string{"hive-admin-token-new"},
+			expectRequeue:  tokenPollInterval,
+		},
+		{
+			name:            "first rotation",
+			existing:        []runtime.Object{cdBuilder.Build(withCondition(corev1.ConditionUnknown)), adminKubeconfigSecret},
+			remoteExisting:  []runtime.Object{tokenSecret("hive-admin-token-new", "new-token")},
+			expectRemote:    true,
+			expectStatus:    corev1.ConditionFalse,
+			expectReason:    rotationSucceededReason,
+			expectToken:     "new-token",
+			expectTokens:    []string{"hive-admin-token-new"},
+			expectRotatedAt: &now,
+			expectRequeue:   rotationInterval,
+		},
+		{
+			name: "up to date",
+			existing: []runtime.Object{
+				cdBuilder.Build(withCondition(corev1.ConditionFalse), withRotation("hive-admin-token-old", now.Add(-time.Hour))),
+				adminKubeconfigSecret,
+			},
+			expectRequeue: rotationInterval - time.Hour,
+		},
+		{
+			name: "rotation interval elapsed",
+			existing: []runtime.Object{
+				cdBuilder.Build(withCondition(corev1.ConditionFalse), withRotation("hive-admin-token-old", now.Add(-rotationInterval))),
+				adminKubeconfigSecret,
+			},
+			remoteExisting: []runtime.Object{tokenSecret("hive-admin-token-old", "old-token")},
+			expectRemote:   true,
+			expectTokens:   []string{"hive-admin-token-old"},
+			expectNewToken: true,
+			expectRequeue:  tokenPollInterval,
+		},
+		{
+			name: "rotation interval elapsed with new token",
+			existing: []runtime.Object{
+				cdBuilder.Build(withCondition(corev1.ConditionFalse), withRotation("hive-admin-token-old", now.Add(-rotationInterval))),
+				adminKubeconfigSecret,
+			},
+			remoteExisting: []runtime.Object{
+				tokenSecret("hive-admin-token-old", "old-token"),
+				tokenSecret("hive-admin-token-new", "new-token"),
+			},
+			expectRemote:    true,
+			expectStatus:    corev1.ConditionFalse,
+			expectReason:    rotationSucceededReason,
+			expectToken:     "new-token",
+			expectTokens:    []string{"hive-admin-token-new"},
+			expectRotatedAt: &now,
+			expectRequeue:   rotationInterval,
+		},
+		{
+			name: "rotation requested",
+			existing: []runtime.Object{
+				cdBuilder.Build(
+					withCondition(corev1.ConditionFalse),
+					withRotation("hive-admin-token-old", now.Add(-time.Hour)),
+					testcd.WithAnnotation(constants.RotateAdminKubeconfigAnnotation, "1"),
+				),
+				adminKubeconfigSecret,
+			},
+			remoteExisting:  []runtime.Object{tokenSecret("hive-admin-token-old", "old-token"), tokenSecret("hive-admin-token-new", "new-token")},
+			expectRemote:    true,
+			expectStatus:    corev1.ConditionFalse,
+			expectReason:    rotationSucceededReason,
+			expectToken:     "new-token",
+			expectTokens:    []string{"hive-admin-token-new"},
+			expectRotatedAt: &now,
+			expectRequeue:   rotationInterval,
+		},
+		{
+			name: "verification failure",
+			existing: []runtime.Object{
+				cdBuilder.Build(withCondition(corev1.ConditionUnknown)),
+				adminKubeconfigSecret,
+			},
+			remoteExisting: []runtime.Object{tokenSecret("hive-admin-token-new", "new-token")},
+			expectRemote:   true,
+			verifyErr:      errors.New("unauthorized"),
+			expectErr:      true,
+			expectStatus:   corev1.ConditionTrue,
+			expectReason:   rotationFailedReason,
+			expectTokens:   []string{"hive-admin-token-new"},
+		},
+		{
+			name: "retry cleanup of previous tokens",
+			existing: []runtime.Object{
+				cdBuilder.Build(withCondition(corev1.ConditionTrue), withRotation("hive-admin-token-new", now.Add(-time.Hour))),
+				adminKubeconfigSecret,
+			},
+			remoteExisting: []runtime.Object{tokenSecret("hive-admin-token-old", "old-token"), tokenSecret("hive-admin-token-new", "new-token")},
+			expectRemote:   true,
+			expectStatus:   corev1.ConditionFalse,
+			expectReason:   rotationSucceededReason,
+			expectTokens:   []string{"hive-admin-token-new"},
+			expectRequeue:  rotationInterval - time.Hour,
+		},
+		{
+			name: "revoke installer kubeconfig",
+			config: &hivev1.AdminKubeconfigRotationConfig{
+				RotationInterval:          &metav1.Duration{Duration: rotationInterval},
+				RevokeInstallerKubeconfig: true,
+			},
+			existing: []runtime.Object{
+				cdBuilder.Build(withCondition(corev1.ConditionFalse), withRotation("hive-admin-token-new", now.Add(-time.Hour))),
+				adminKubeconfigSecret,
+			},
+			remoteExisting: []runtime.Object{tokenSecret("hive-admin-token-new", "new-token"), clientCA},
+			expectRemote:   true,
+			expectStatus:   corev1.ConditionFalse,
+			expectReason:   rotationSucceededReason,
+			expectTokens:   []string{"hive-admin-token-new"},
+			expectRevoked:  true,
+			expectRequeue:  rotationInterval - time.Hour,
+		},
+		{
+			name: "revocation failure",
+			config: &hivev1.AdminKubeconfigRotationConfig{
+				RotationInterval:          &metav1.Duration{Duration: rotationInterval},
+				RevokeInstallerKubeconfig: true,
+			},
+			existing: []runtime.Object{
+				cdBuilder.Build(withCondition(corev1.ConditionFalse), withRotation("hive-admin-token-new", now.Add(-time.Hour))),
+				adminKubeconfigSecret,
+			},
+			remoteExisting: []runtime.Object{tokenSecret("hive-admin-token-new", "new-token")},
+			expectRemote:   true,
+			expectErr:      true,
+			expectStatus:   corev1.ConditionTrue,
+			expectReason:   revocationFailedReason,
+			expectTokens:   []string{"hive-admin-token-new"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := tc.config
+			if config == nil && !tc.notConfigured {
+				config = &hivev1.AdminKubeconfigRotationConfig{RotationInterval: &metav1.Duration{Duration: rotationInterval}}
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			remoteClient := fake.NewClientBuilder().WithScheme(remoteScheme).WithRuntimeObjects(tc.remoteExisting...).Build()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if tc.expectRemote {
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil).MinTimes(1)
+			}
+			var verifiedToken string
+			r := &ReconcileAdminKubeconfigRotation{
+				Client:              fakeClient,
+				controllerconfig:    config,
+				remoteClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				kubeconfigClientFn: func(c client.Client, secret *corev1.Secret) (client.Client, error) {
+					kubeconfig, err := clientcmd.Load(secret.Data[constants.KubeconfigSecretKey])
+					require.NoError(t, err)
+					verifiedToken = kubeconfig.AuthInfos[kubeconfig.Contexts[kubeconfig.CurrentContext].AuthInfo].Token
+					return remoteClient, tc.verifyErr
+				},
+				nowFn: func() time.Time { return now },
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+			if tc.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				assert.NoError(t, err, "unexpected error from reconcile")
+			}
+			assert.Equal(t, tc.expectRequeue, result.RequeueAfter, "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), key, cd))
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.AdminKubeconfigRotationFailedClusterDeploymentCondition)
+			if tc.expectConditions {
+				require.NotNil(t, cond, "expected condition to be initialized")
+				assert.Equal(t, corev1.ConditionUnknown, cond.Status, "unexpected condition status")
+				return
+			}
+			if tc.expectStatus != "" {
+				require.NotNil(t, cond, "missing condition")
+				assert.Equal(t, tc.expectStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectReason, cond.Reason, "unexpected condition reason")
+			}
+
+			if tc.expectToken != "" {
+				assert.Equal(t, tc.expectToken, verifiedToken, "unexpected verified token")
+				secret := &corev1.Secret{}
+				require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNS, Name: rotatedSecret}, secret))
+				kubeconfig, err := clientcmd.Load(secret.Data[constants.KubeconfigSecretKey])
+				require.NoError(t, err)
+				authInfo := kubeconfig.AuthInfos[kubeconfig.Contexts[kubeconfig.CurrentContext].AuthInfo]
+				assert.Equal(t, tc.expectToken, authInfo.Token, "unexpected token in kubeconfig")
+				assert.Equal(t, "https://api.test-cd.example.com:6443", kubeconfig.Clusters[kubeconfig.Contexts[kubeconfig.CurrentContext].Cluster].Server, "unexpected server in kubeconfig")
+				assert.Equal(t, "test-cd", secret.Labels[constants.ClusterDeploymentNameLabel], "unexpected cluster deployment label")
+				require.NotNil(t, cd.Status.AdminKubeconfigRotation, "missing rotation status")
+				assert.Equal(t, rotatedSecret, cd.Status.AdminKubeconfigRotation.KubeconfigSecretRef.Name, "unexpected kubeconfig secret")
+				assert.Equal(t, rotatedSecret, remoteclient.AdminKubeconfigSecretName(cd), "remote client not using the rotated kubeconfig")
+			}
+			if tc.expectRotatedAt != nil {
+				require.NotNil(t, cd.Status.AdminKubeconfigRotation, "missing rotation status")
+				assert.True(t, tc.expectRotatedAt.Equal(cd.Status.AdminKubeconfigRotation.LastRotationTime.Time), "unexpected last rotation time")
+				assert.Equal(t, cd.Annotations[constants.RotateAdminKubeconfigAnnotation], cd.Status.AdminKubeconfigRotation.LastRotationRequest, "unexpected last rotation request")
+			}
+
+			if !tc.expectRemote {
+				return
+			}
+			if tc.expectNewToken || tc.expectToken != "" {
+				sa := &corev1.ServiceAccount{}
+				assert.NoError(t, remoteClient.Get(context.TODO(), client.ObjectKey{Namespace: serviceAccountNamespace, Name: serviceAccountName}, sa), "missing service account")
+				crb := &rbacv1.ClusterRoleBinding{}
+				if assert.NoError(t, remoteClient.Get(context.TODO(), client.ObjectKey{Name: serviceAccountName}, crb), "missing cluster role binding") {
+					assert.Equal(t, "cluster-admin", crb.RoleRef.Name, "unexpected cluster role")
+				}
+			}
+			tokens, err := listTokens(remoteClient)
+			require.NoError(t, err)
+			var names []string
+			for _, token := range tokens {
+				if token.GenerateName != "" {
+					assert.Equal(t, "hive-admin-token-", token.GenerateName, "unexpected token secret name")
+					continue
+				}
+				names = append(names, token.Name)
+			}
+			assert.ElementsMatch(t, tc.expectTokens, names, "unexpected token secrets")
+			if tc.expectNewToken {
+				assert.Len(t, tokens, len(tc.expectTokens)+1, "expected new token secret")
+			} else {
+				assert.Len(t, tokens, len(tc.expectTokens), "unexpected new token secret")
+			}
+
+			if tc.expectRevoked {
+				assert.True(t, cd.Status.AdminKubeconfigRotation.InstallerKubeconfigRevoked, "expected installer kubeconfig to be revoked")
+				cm := &corev1.ConfigMap{}
+				require.NoError(t, remoteClient.Get(context.TODO(), client.ObjectKey{Namespace: adminKubeconfigClientCANamespace, Name: adminKubeconfigClientCAName}, cm))
+				assert.Contains(t, cm.Data[adminKubeconfigClientCAKey], "BEGIN CERTIFICATE", "expected new client CA")
+			}
+		})
+	}
+}
+
+func testKubeconfig(t *testing.T) []byte {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["cluster"] = &clientcmdapi.Cluster{Server: "https://api.test-cd.example.com:6443", CertificateAuthorityData: []byte("ca")}
+	config.AuthInfos["admin"] = &clientcmdapi.AuthInfo{ClientCertificateData: []byte("cert"), ClientKeyData: []byte("key")}
+	config.Contexts["admin"] = &clientcmdapi.Context{Cluster: "cluster", AuthInfo: "admin"}
+	config.CurrentContext = "admin"
+	data, err := clientcmd.Write(*config)
+	require.NoError(t, err)
+	return data
+}
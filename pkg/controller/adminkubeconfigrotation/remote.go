@@ -0,0 +1,195 @@
+package adminkubeconfigrotation
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// serviceAccountName is the name of the service account, and of its cluster role binding, that Hive uses to
+	// connect to the cluster.
+	serviceAccountName      = "hive-admin"
+	serviceAccountNamespace = "kube-system"
+
+	// tokenSecretLabel labels the token secrets of the service account created by Hive.
+	tokenSecretLabel = "hive.openshift.io/admin-kubeconfig-token"
+
+	// adminKubeconfigClientCANamespace and adminKubeconfigClientCAName are the configmap with the CA bundle that
+	// the API server trusts for the client certificate of the installer admin kubeconfig.
+	adminKubeconfigClientCANamespace = "openshift-config"
+	adminKubeconfigClientCAName      = "admin-kubeconfig-client-ca"
+	adminKubeconfigClientCAKey       = "ca-bundle.crt"
+)
+
+// ensureServiceAccount creates the service account and binds it to the cluster-admin role if they do not exist.
+func ensureServiceAccount(c client.Client, logger log.FieldLogger) error {
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: serviceAccountNamespace,
+			Name:      serviceAccountName,
+		},
+	}
+	if err := c.Create(context.TODO(), sa); err == nil {
+		logger.WithField("serviceAccount", serviceAccountName).Info("created service account")
+	} else if !apierrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, "failed to create service account")
+	}
+
+	crb := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: serviceAccountName,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "cluster-admin",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: serviceAccountNamespace,
+			Name:      serviceAccountName,
+		}},
+	}
+	if err := c.Create(context.TODO(), crb); err == nil {
+		logger.WithField("clusterRoleBinding", serviceAccountName).Info("created cluster role binding")
+	} else if !apierrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, "failed to create cluster role binding")
+	}
+	return nil
+}
+
+// pendingToken returns the token secret to rotate to, creating it if there is none. It returns nil while the
+// token controller of the cluster has not populated the token yet.
+func pendingToken(c client.Client, currentToken string, logger log.FieldLogger) (*corev1.Secret, error) {
+	secrets, err := listTokens(c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range secrets {
+		secret := &secrets[i]
+		if secret.Name == currentToken || secret.DeletionTimestamp != nil {
+			continue
+		}
+		if len(secret.Data[corev1.ServiceAccountTokenKey]) == 0 {
+			return nil, nil
+		}
+		return secret, nil
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    serviceAccountNamespace,
+			GenerateName: serviceAccountName + "-token-",
+			Labels:       map[string]string{tokenSecretLabel: "true"},
+			Annotations:  map[string]string{corev1.ServiceAccountNameKey: serviceAccountName},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+	if err := c.Create(context.TODO(), secret); err != nil {
+		return nil, errors.Wrap(err, "failed to create service account token secret")
+	}
+	logger.WithField("tokenSecret", secret.Name).Info("created service account token secret")
+	return nil, nil
+}
+
+// deleteStaleTokens deletes the token secrets of the service account other than the current one, which
+// invalidates their tokens.
+func deleteStaleTokens(c client.Client, currentToken string, logger log.FieldLogger) error {
+	secrets, err := listTokens(c)
+	if err != nil {
+		return err
+	}
+	for i := range secrets {
+		secret := &secrets[i]
+		if secret.Name == currentToken {
+			continue
+		}
+		if err := c.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete token secret %s", secret.Name)
+		}
+		logger.WithField("tokenSecret", secret.Name).Info("deleted previous service account token secret")
+	}
+	return nil
+}
+
+func listTokens(c client.Client) ([]corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.List(context.TODO(), secrets,
+		client.InNamespace(serviceAccountNamespace),
+		client.MatchingLabels{tokenSecretLabel: "true"}); err != nil {
+		return nil, errors.Wrap(err, "failed to list service account token secrets")
+	}
+	return secrets.Items, nil
+}
+
+// revokeInstallerKubeconfig makes the API server stop trusting the client certificate of the installer admin
+// kubeconfig, by replacing the CA that signed it with a new CA whose key is discarded.
+func revokeInstallerKubeconfig(c client.Client, logger log.FieldLogger) error {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: adminKubeconfigClientCANamespace, Name: adminKubeconfigClientCAName}, cm); err != nil {
+		return errors.Wrapf(err, "failed to get configmap %s/%s", adminKubeconfigClientCANamespace, adminKubeconfigClientCAName)
+	}
+	ca, err := unusableCA()
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[adminKubeconfigClientCAKey] = string(ca)
+	if err := c.Update(context.TODO(), cm); err != nil {
+		return errors.Wrapf(err, "failed to update configmap %s/%s", adminKubeconfigClientCANamespace, adminKubeconfigClientCAName)
+	}
+	logger.Info("replaced the admin kubeconfig client CA")
+	return nil
+}
+
+// unusableCA returns a new PEM-encoded self-signed CA certificate. Its private key is never stored, so no client
+// certificate can be signed by it.
+func unusableCA() ([]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate CA key")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate CA serial number")
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{OrganizationalUnit: []string{"openshift"}, CommonName: "admin-kubeconfig-signer-revoked"},
+		NotBefore:             now,
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA certificate")
+	}
+	buf := &bytes.Buffer{}
+	if err := pem.Encode(buf, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+		return nil, errors.Wrap(err, "failed to encode CA certificate")
+	}
+	return buf.Bytes(), nil
+}
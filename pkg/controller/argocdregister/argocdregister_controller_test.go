@@ -2,6 +2,7 @@ package argocdregister
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"testing"
 	"time"
@@ -49,6 +50,25 @@ contexts:
     cluster: bar
   name: admin
 current-context: admin
+`
+	// rotatedKubeconfig is the service account kubeconfig of the admin kubeconfig rotation controller, which
+	// replaces the installer admin kubeconfig once its client certificate is revoked.
+	rotatedKubeconfigSecret = "foo-lqmsh-rotated-kubeconfig"
+	rotatedKubeconfig       = `clusters:
+- cluster:
+    certificate-authority-data: Uk9UQVRFRC1DQQ==
+    server: https://bar-api.clusters.example.com:6443
+  name: bar
+contexts:
+- context:
+    cluster: bar
+    user: admin
+  name: admin
+current-context: admin
+users:
+- name: admin
+  user:
+    token: rotated-token
 `
 	adminPasswordSecret = "foo-lqmsh-admin-password"
 	adminPassword       = "foo"
@@ -144,6 +164,43 @@ func TestArgoCDRegisterReconcile(t *testing.T) {
 				assert.Equal(t, secret.Data["server"], []byte(cd.Status.APIURL))
 			},
 		},
+		{
+			name: "Create ArgoCD cluster secret after the installer kubeconfig is revoked",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.AdminKubeconfigRotation = &hivev1.AdminKubeconfigRotationStatus{
+						KubeconfigSecretRef: corev1.LocalObjectReference{Name: rotatedKubeconfigSecret},
+					}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, credsSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeOpaque, rotatedKubeconfigSecret, "kubeconfig", rotatedKubeconfig),
+				testServiceAccount("argocd-server", argoCDDefaultNamespace,
+					corev1.ObjectReference{Kind: "Secret",
+						Name:      "argocd-token",
+						Namespace: argoCDDefaultNamespace}),
+				testSecretWithNamespace(corev1.SecretTypeDockerConfigJson, "argocd-token", argoCDDefaultNamespace, "token", "{}"),
+			},
+			argoCDEnabled: true,
+			reconcilerSetup: func(r *ArgoCDRegisterController) {
+				r.tlsClientConfigBuilder = tlsClientConfigBuilderFunc
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				secretName, _ := getPredictableSecretName(cd.Status.APIURL)
+				secret := getSecret(c, secretName, argoCDDefaultNamespace)
+				if assert.NotNil(t, secret, "ArgoCD cluster secret not found") {
+					config := &ClusterConfig{}
+					if assert.NoError(t, json.Unmarshal(secret.Data["config"], config), "unexpected error parsing ArgoCD cluster config") {
+						assert.Equal(t, []byte("ROTATED-CA"), config.CAData, "expected the CA of the rotated kubeconfig")
+					}
+				}
+			},
+		},
 		{
 			name: "Delete ArgoCD cluster secret",
 			existing: []runtime.Object{
@@ -215,14 +215,14 @@ func (r *ReconcileAWSPrivateLink) Reconcile(ctx context.Context, request reconci
 
 	supportedRegion := false
 	for _, item := range r.controllerconfig.EndpointVPCInventory {
-		if strings.EqualFold(item.Region, cd.Spec.Platform.AWS.Region) {
+		if strings.EqualFold(item.Region, controllerutils.GetClusterRegion(cd)) {
 			supportedRegion = true
 			break
 		}
 	}
 	if !supportedRegion {
 		err := errors.Errorf("cluster deployment region %q is not supported as there is no inventory to create necessary resources",
-			cd.Spec.Platform.AWS.Region)
+			controllerutils.GetClusterRegion(cd))
 		logger.WithError(err).Error("cluster deployment region is not supported, so skipping")
 
 		if err := r.setErrCondition(cd, "UnsupportedRegion", err, logger); err != nil {
@@ -959,7 +959,7 @@ func (r *ReconcileAWSPrivateLink) ensureHostedZone(awsClient awsclient.Client,
 	endpoint *ec2.VpcEndpoint, apiDomain string,
 	logger log.FieldLogger) (bool, string, error) {
 	modified := false
-	hzID, err := findHostedZone(awsClient, *endpoint.VpcId, cd.Spec.Platform.AWS.Region, apiDomain, logger)
+	hzID, err := findHostedZone(awsClient, *endpoint.VpcId, controllerutils.GetClusterRegion(cd), apiDomain, logger)
 	if err != nil && errors.Is(err, errNoHostedZoneFoundForVPC) {
 		modified = true
 		hzID, err = r.createHostedZone(awsClient, cd, endpoint, apiDomain, logger)
@@ -1027,7 +1027,7 @@ func (r *ReconcileAWSPrivateLink) createHostedZone(awsClient awsclient.Client,
 		},
 		VPC: &route53.VPC{
 			VPCId:     endpoint.VpcId,
-			VPCRegion: aws.String(cd.Spec.Platform.AWS.Region),
+			VPCRegion: aws.String(controllerutils.GetClusterRegion(cd)),
 		},
 	})
 	if err != nil {
@@ -1205,7 +1205,7 @@ type awsClient struct {
 
 func newAWSClient(r *ReconcileAWSPrivateLink, cd *hivev1.ClusterDeployment) (*awsClient, error) {
 	uClient, err := r.awsClientFn(r.Client, awsclient.Options{
-		Region: cd.Spec.Platform.AWS.Region,
+		Region: controllerutils.GetClusterRegion(cd),
 		CredentialsSource: awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
 				Namespace: cd.Namespace,
@@ -1224,7 +1224,7 @@ func newAWSClient(r *ReconcileAWSPrivateLink, cd *hivev1.ClusterDeployment) (*aw
 		return nil, err
 	}
 	hClient, err := r.awsClientFn(r.Client, awsclient.Options{
-		Region: cd.Spec.Platform.AWS.Region,
+		Region: controllerutils.GetClusterRegion(cd),
 		CredentialsSource: awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
 				Namespace: controllerutils.GetHiveNamespace(),
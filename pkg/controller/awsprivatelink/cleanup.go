@@ -153,7 +153,7 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 		}
 
 		vpcEndpoint := endpointResp.VpcEndpoints[0]
-		hzID, err = findHostedZone(awsClient, *vpcEndpoint.VpcId, cd.Spec.Platform.AWS.Region, apiDomain, logger)
+		hzID, err = findHostedZone(awsClient, *vpcEndpoint.VpcId, controllerutils.GetClusterRegion(cd), apiDomain, logger)
 		if err != nil && errors.Is(err, errNoHostedZoneFoundForVPC) {
 			return nil // no work
 		}
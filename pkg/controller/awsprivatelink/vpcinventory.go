@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 var (
@@ -24,9 +25,9 @@ func (r *ReconcileAWSPrivateLink) chooseVPCForVPCEndpoint(awsClient awsclient.Cl
 	logger log.FieldLogger) (*hivev1.AWSPrivateLinkInventory, error) {
 	serviceLog := logger.WithField("serviceName", vpcEndpointServiceName)
 	// Filter out the VPCs in cluster region.
-	candidates := filterVPCInventory(r.controllerconfig.DeepCopy().EndpointVPCInventory, toSupportedRegion(cd.Spec.Platform.AWS.Region))
+	candidates := filterVPCInventory(r.controllerconfig.DeepCopy().EndpointVPCInventory, toSupportedRegion(controllerutils.GetClusterRegion(cd)))
 	if len(candidates) == 0 {
-		serviceLog.WithField("region", cd.Spec.Platform.AWS.Region).Error("no supported VPC in inventory")
+		serviceLog.WithField("region", controllerutils.GetClusterRegion(cd)).Error("no supported VPC in inventory")
 		return nil, errors.New("no supported VPC in inventory for the cluster")
 	}
 
@@ -43,7 +44,7 @@ func (r *ReconcileAWSPrivateLink) chooseVPCForVPCEndpoint(awsClient awsclient.Cl
 	supportedAZSet := sets.NewString(aws.StringValueSlice(servicesResp.ServiceDetails[0].AvailabilityZones)...)
 	candidates = filterVPCInventory(candidates, toSupportedSubnets(supportedAZSet))
 	if len(candidates) == 0 {
-		logger.WithField("region", cd.Spec.Platform.AWS.Region).
+		logger.WithField("region", controllerutils.GetClusterRegion(cd)).
 			WithField("requiredAZs", supportedAZSet.List()).
 			Error(errNoSupportedAZsInInventory.Error())
 		return nil, errNoSupportedAZsInInventory
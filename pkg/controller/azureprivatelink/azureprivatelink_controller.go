@@ -0,0 +1,665 @@
+package azureprivatelink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2017-10-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/privatelink"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.AzurePrivateLinkControllerName
+	finalizer      = "hive.openshift.io/azure-private-link"
+
+	lastCleanupAnnotationKey = "azure-private-link-controller.hive.openshift.io/last-cleanup-for"
+)
+
+// Add creates a new AzurePrivateLink Controller and adds it to the Manager with default RBAC.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	reconciler, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("could not create reconciler")
+		return err
+	}
+	return AddToManager(mgr, reconciler, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileAzurePrivateLink
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileAzurePrivateLink, error) {
+	logger := log.WithField("controller", ControllerName)
+	reconciler := &ReconcileAzurePrivateLink{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+	}
+
+	config, err := ReadAzurePrivateLinkControllerConfigFile()
+	if err != nil {
+		logger.WithError(err).Error("could not get load configuration")
+		return reconciler, err
+	}
+	reconciler.controllerconfig = config
+	reconciler.azureClientFn = azureclient.NewClientFromSecret
+	return reconciler, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileAzurePrivateLink, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controller.New("azureprivatelink-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}},
+		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, controllerutils.IsClusterDeploymentErrorUpdateEvent))
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		return err
+	}
+
+	// Watch for changes to ClusterProvision
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterProvision{}},
+		&handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &hivev1.ClusterDeployment{},
+		}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster provision")
+		return err
+	}
+
+	// Watch for changes to ClusterDeprovision
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeprovision{}},
+		&handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &hivev1.ClusterDeployment{},
+		}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deprovision")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileAzurePrivateLink{}
+
+// ReconcileAzurePrivateLink reconciles a PrivateLink for clusterdeployment object
+type ReconcileAzurePrivateLink struct {
+	client.Client
+
+	controllerconfig *hivev1.AzurePrivateLinkConfig
+
+	// testing purpose
+	azureClientFn azureClientFn
+}
+
+type azureClientFn func(secret *corev1.Secret, environmentName string) (azureclient.Client, error)
+
+// Reconcile reconciles PrivateLink for ClusterDeployment.
+func (r *ReconcileAzurePrivateLink) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	return r.privateLinkReconciler().Reconcile(ctx, request)
+}
+
+func (r *ReconcileAzurePrivateLink) privateLinkReconciler() *privatelink.Reconciler {
+	return &privatelink.Reconciler{
+		Client:                   r.Client,
+		Platform:                 r,
+		ControllerName:           ControllerName,
+		Finalizer:                finalizer,
+		LastCleanupAnnotationKey: lastCleanupAnnotationKey,
+		FailedCondition:          hivev1.AzurePrivateLinkFailedClusterDeploymentCondition,
+		ReadyCondition:           hivev1.AzurePrivateLinkReadyClusterDeploymentCondition,
+		AccessName:               "PrivateLink",
+		AccessDescription:        "private link",
+	}
+}
+
+// Access returns whether the cluster deployment configures Azure Private Link, and whether it is enabled.
+func (r *ReconcileAzurePrivateLink) Access(cd *hivev1.ClusterDeployment) (bool, bool) {
+	if cd.Spec.Platform.Azure == nil || cd.Spec.Platform.Azure.PrivateLink == nil {
+		return false, false
+	}
+	return true, cd.Spec.Platform.Azure.PrivateLink.Enabled
+}
+
+// SupportsRegion returns true when the inventory has a VNet in the region.
+func (r *ReconcileAzurePrivateLink) SupportsRegion(region string) bool {
+	for _, item := range r.controllerconfig.EndpointVNetInventory {
+		if strings.EqualFold(item.Region, region) {
+			return true
+		}
+	}
+	return false
+}
+
+// AssociatedNetworks returns the VNets linked to the private DNS zones of all the clusters.
+func (r *ReconcileAzurePrivateLink) AssociatedNetworks() []string {
+	return r.controllerconfig.AssociatedVNets
+}
+
+// CleanupRequired returns true when the status of the cluster deployment records Azure resources.
+func (r *ReconcileAzurePrivateLink) CleanupRequired(cd *hivev1.ClusterDeployment) bool {
+	return cleanupRequired(cd)
+}
+
+// NewActuator returns the actuator for the Azure resources of the cluster deployment.
+func (r *ReconcileAzurePrivateLink) NewActuator(cd *hivev1.ClusterDeployment) (privatelink.Actuator, error) {
+	return newActuator(r, cd)
+}
+
+// ReconcileService discovers the internal API load balancer of the cluster, and makes sure a private link service
+// exposes it to the HUB subscriptions.
+func (a *actuator) ReconcileService(cd *hivev1.ClusterDeployment, clusterMetadata *hivev1.ClusterMetadata, logger log.FieldLogger) (bool, *privatelink.Service, error) {
+	frontend, err := discoverInternalFrontend(a.user, clusterMetadata)
+	if err != nil {
+		if azureclient.IsNotFound(err) {
+			return false, nil, privatelink.NewWaitingError("DiscoveringLoadBalancerNotYetFound",
+				"discovering internal API load balancer for the cluster, but it does not exist yet")
+		}
+
+		logger.WithField("infraID", clusterMetadata.InfraID).WithError(err).Error("error discovering internal API load balancer for the cluster")
+		return false, nil, privatelink.NewConditionError("DiscoveringLoadBalancerFailed", err)
+	}
+
+	modified, service, err := a.reconcilePrivateLinkService(cd, clusterMetadata, frontend, logger)
+	if err != nil {
+		return false, nil, privatelink.NewConditionError("PrivateLinkServiceReconcileFailed",
+			errors.Wrap(err, "failed to reconcile the private link service"))
+	}
+	return modified, &privatelink.Service{Name: service.Name, ID: service.ID}, nil
+}
+
+// discoverInternalFrontend returns the frontend IP configuration of the cluster's internal API load balancer.
+func discoverInternalFrontend(azureClient azureclient.Client, metadata *hivev1.ClusterMetadata) (*network.FrontendIPConfiguration, error) {
+	lb, err := azureClient.GetLoadBalancer(context.TODO(), resourceGroupName(metadata), metadata.InfraID+"-internal")
+	if err != nil {
+		return nil, err
+	}
+	if lb.LoadBalancerPropertiesFormat != nil && lb.FrontendIPConfigurations != nil {
+		for _, frontend := range *lb.FrontendIPConfigurations {
+			if frontend.ID != nil && frontend.FrontendIPConfigurationPropertiesFormat != nil &&
+				frontend.Subnet != nil && frontend.Subnet.ID != nil {
+				return &frontend, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("no frontend IP configuration with a subnet on load balancer %s", to.String(lb.ID))
+}
+
+// reconcilePrivateLinkService ensures that a private link service is created for the frontend of the cluster's
+// internal API load balancer. It continuously makes sure that only the subscriptions of the HUB VNets in the
+// region of the cluster can see the service, and that the connections from them are approved automatically.
+func (a *actuator) reconcilePrivateLinkService(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	frontend *network.FrontendIPConfiguration,
+	logger log.FieldLogger) (bool, *azureclient.PrivateLinkService, error) {
+	modified := false
+	rg := resourceGroupName(metadata)
+	name := metadata.InfraID + "-pls"
+	serviceLog := logger.WithField("privateLinkService", name)
+
+	subnetID := *frontend.Subnet.ID
+	subnetRG, vnet, subnet, err := parseSubnetID(subnetID)
+	if err != nil {
+		return modified, nil, err
+	}
+
+	desiredSubscriptions, err := a.r.hubSubscriptions(controllerutils.GetClusterRegion(cd))
+	if err != nil {
+		serviceLog.WithError(err).Error("error getting the subscriptions that will create the private endpoint")
+		return modified, nil, err
+	}
+
+	service, err := a.user.GetPrivateLinkService(context.TODO(), rg, name)
+	switch {
+	case azureclient.IsNotFound(err):
+		modified = true
+		// the NAT IP configuration of a private link service can only use a subnet with the network policies for
+		// private link services disabled.
+		if err := a.user.DisableSubnetPrivateLinkServiceNetworkPolicies(context.TODO(), subnetRG, vnet, subnet); err != nil {
+			serviceLog.WithField("subnet", subnetID).WithError(err).Error("failed to disable the private link service network policies of the subnet")
+			return modified, nil, err
+		}
+		service, err = a.user.CreateOrUpdatePrivateLinkService(context.TODO(), rg, &azureclient.PrivateLinkService{
+			Name:     name,
+			Location: controllerutils.GetClusterRegion(cd),
+			Tags:     resourceTags(metadata),
+			Properties: azureclient.PrivateLinkServiceProperties{
+				LoadBalancerFrontendIPConfigurations: []azureclient.SubResource{{ID: *frontend.ID}},
+				IPConfigurations: []azureclient.PrivateLinkServiceIPConfiguration{{
+					Name: name + "-nat",
+					Properties: azureclient.PrivateLinkServiceIPConfigurationProperties{
+						PrivateIPAllocationMethod: string(network.Dynamic),
+						Subnet:                    &azureclient.SubResource{ID: subnetID},
+						Primary:                   true,
+					},
+				}},
+				Visibility:   &azureclient.PrivateLinkServiceSubscriptions{Subscriptions: desiredSubscriptions.List()},
+				AutoApproval: &azureclient.PrivateLinkServiceSubscriptions{Subscriptions: desiredSubscriptions.List()},
+			},
+		})
+		if err != nil {
+			serviceLog.WithError(err).Error("failed to create the private link service")
+			return modified, nil, err
+		}
+	case err != nil:
+		serviceLog.WithError(err).Error("failed to get the private link service")
+		return modified, nil, err
+	}
+
+	if !subscriptionsEqual(service.Properties.Visibility, desiredSubscriptions) ||
+		!subscriptionsEqual(service.Properties.AutoApproval, desiredSubscriptions) {
+		modified = true
+		service.Properties.Visibility = &azureclient.PrivateLinkServiceSubscriptions{Subscriptions: desiredSubscriptions.List()}
+		service.Properties.AutoApproval = &azureclient.PrivateLinkServiceSubscriptions{Subscriptions: desiredSubscriptions.List()}
+		service, err = a.user.CreateOrUpdatePrivateLinkService(context.TODO(), rg, service)
+		if err != nil {
+			serviceLog.WithError(err).Error("error updating the private link service to match the desired state")
+			return modified, nil, err
+		}
+	}
+
+	initPrivateLinkStatus(cd)
+	cd.Status.Platform.Azure.PrivateLink.PrivateLinkService = service.ID
+	if err := a.r.updatePrivateLinkStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment status with privateLinkService")
+		return modified, nil, err
+	}
+
+	return modified, service, nil
+}
+
+// hubSubscriptions returns the subscriptions of the VNets in the inventory for the region.
+func (r *ReconcileAzurePrivateLink) hubSubscriptions(region string) (sets.String, error) {
+	subscriptions := sets.NewString()
+	for _, inv := range r.controllerconfig.EndpointVNetInventory {
+		if !strings.EqualFold(inv.Region, region) {
+			continue
+		}
+		resource, err := azure.ParseResourceID(inv.VNetID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid VNet in inventory")
+		}
+		subscriptions.Insert(resource.SubscriptionID)
+	}
+	return subscriptions, nil
+}
+
+func subscriptionsEqual(current *azureclient.PrivateLinkServiceSubscriptions, desired sets.String) bool {
+	if current == nil {
+		return desired.Len() == 0
+	}
+	return sets.NewString(current.Subscriptions...).Equal(desired)
+}
+
+// ReconcileEndpoint makes sure a private endpoint for the private link service exists in a VNet from the inventory.
+// The failures to find a VNet in the inventory are reported with their own reasons.
+func (a *actuator) ReconcileEndpoint(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	service *privatelink.Service,
+	logger log.FieldLogger) (bool, *privatelink.Endpoint, error) {
+	modified, endpoint, err := a.reconcilePrivateEndpoint(cd, metadata, service, logger)
+	switch {
+	case err == nil:
+		return modified, endpoint, nil
+	case errors.Is(err, errNoSupportedVNetInInventory):
+		return modified, nil, privatelink.NewConditionError("NoSupportedVNetInInventory", err)
+	case errors.Is(err, errNoVNetWithQuotaInInventory):
+		return modified, nil, privatelink.NewConditionError("NoVNetWithQuotaInInventory", err)
+	}
+	return modified, nil, privatelink.NewConditionError("PrivateEndpointReconcileFailed", err)
+}
+
+// reconcilePrivateEndpoint ensures that a private endpoint is created for the private link service in the HUB
+// subscription, in a VNet chosen from the inventory given to the controller.
+// It currently doesn't manage any properties of the private endpoint once it is created.
+func (a *actuator) reconcilePrivateEndpoint(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	service *privatelink.Service,
+	logger log.FieldLogger) (bool, *privatelink.Endpoint, error) {
+	modified := false
+	name := metadata.InfraID + "-pe"
+	endpointLog := logger.WithField("privateEndpoint", name)
+
+	var endpoint *azureclient.PrivateEndpoint
+	// the private endpoint is looked up from the status as the VNet it was created in can no longer be chosen
+	// from the inventory once it has been used.
+	if id := privateLinkStatus(cd).PrivateEndpoint; id != "" {
+		resource, err := azure.ParseResourceID(id)
+		if err != nil {
+			return modified, nil, err
+		}
+		endpoint, err = a.hub.GetPrivateEndpoint(context.TODO(), resource.ResourceGroup, resource.ResourceName)
+		if err != nil && !azureclient.IsNotFound(err) {
+			endpointLog.WithError(err).Error("error getting the private endpoint")
+			return modified, nil, err
+		}
+	}
+	if endpoint == nil {
+		modified = true
+		chosen, err := a.r.chooseVNetForPrivateEndpoint(a.hub, cd, logger)
+		if err != nil {
+			endpointLog.WithError(err).Error("failed to choose VNet for the private endpoint from the inventory")
+			return modified, nil, err
+		}
+		resource, err := azure.ParseResourceID(chosen.VNetID)
+		if err != nil {
+			return modified, nil, err
+		}
+		endpoint, err = a.hub.CreateOrUpdatePrivateEndpoint(context.TODO(), resource.ResourceGroup, &azureclient.PrivateEndpoint{
+			Name:     name,
+			Location: controllerutils.GetClusterRegion(cd),
+			Tags:     resourceTags(metadata),
+			Properties: azureclient.PrivateEndpointProperties{
+				Subnet: &azureclient.SubResource{ID: chosen.VNetID + "/subnets/" + chosen.Subnets[0].Name},
+				PrivateLinkServiceConnections: []azureclient.PrivateLinkServiceConnection{{
+					Name: service.Name,
+					Properties: azureclient.PrivateLinkServiceConnectionProperties{
+						PrivateLinkServiceID: service.ID,
+					},
+				}},
+			},
+		})
+		if err != nil {
+			endpointLog.WithError(err).Error("error creating the private endpoint")
+			return modified, nil, err
+		}
+	}
+
+	initPrivateLinkStatus(cd)
+	cd.Status.Platform.Azure.PrivateLink.PrivateEndpoint = endpoint.ID
+	if err := a.r.updatePrivateLinkStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment status with privateEndpoint")
+		return modified, nil, err
+	}
+
+	if endpoint.Properties.Subnet == nil || len(endpoint.Properties.NetworkInterfaces) == 0 {
+		return modified, nil, errors.Errorf("private endpoint %s does not have a network interface yet", endpoint.ID)
+	}
+	ip, err := privateEndpointIP(a.hub, endpoint)
+	if err != nil {
+		endpointLog.WithError(err).Error("error getting the IP address of the private endpoint")
+		return modified, nil, err
+	}
+	vnetID := endpoint.Properties.Subnet.ID[:strings.LastIndex(strings.ToLower(endpoint.Properties.Subnet.ID), "/subnets/")]
+
+	return modified, &privatelink.Endpoint{Network: vnetID, IP: ip}, nil
+}
+
+// privateEndpointIP returns the private IP address of the network interface of the private endpoint.
+func privateEndpointIP(azureClient azureclient.Client, endpoint *azureclient.PrivateEndpoint) (string, error) {
+	resource, err := azure.ParseResourceID(endpoint.Properties.NetworkInterfaces[0].ID)
+	if err != nil {
+		return "", err
+	}
+	nic, err := azureClient.GetNetworkInterface(context.TODO(), resource.ResourceGroup, resource.ResourceName)
+	if err != nil {
+		return "", err
+	}
+	if nic.InterfacePropertiesFormat != nil && nic.IPConfigurations != nil {
+		for _, config := range *nic.IPConfigurations {
+			if config.InterfaceIPConfigurationPropertiesFormat != nil && config.PrivateIPAddress != nil {
+				return *config.PrivateIPAddress, nil
+			}
+		}
+	}
+	return "", errors.Errorf("network interface %s does not have a private IP address", to.String(nic.ID))
+}
+
+// ReconcileDNSZone ensures that a private DNS zone for apiDomain exists in the resource group of the private
+// endpoint and is linked to exactly the given VNets. It also makes sure the zone has an A record pointing to the
+// private endpoint.
+func (a *actuator) ReconcileDNSZone(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	endpoint *privatelink.Endpoint, apiDomain string, vnets []string,
+	logger log.FieldLogger) (bool, error) {
+	modified := false
+	resource, err := azure.ParseResourceID(privateLinkStatus(cd).PrivateEndpoint)
+	if err != nil {
+		return modified, err
+	}
+	rg := resource.ResourceGroup
+	zoneLog := logger.WithField("privateDNSZone", apiDomain)
+
+	zone, err := a.hub.GetPrivateZone(context.TODO(), rg, apiDomain)
+	switch {
+	case azureclient.IsNotFound(err):
+		modified = true
+		zone, err = a.hub.CreateOrUpdatePrivateZone(context.TODO(), rg, apiDomain, resourceTags(metadata))
+		if err != nil {
+			zoneLog.WithError(err).Error("could not create the private DNS zone")
+			return modified, err
+		}
+	case err != nil:
+		zoneLog.WithError(err).Error("failed to get the private DNS zone")
+		return modified, err
+	}
+
+	initPrivateLinkStatus(cd)
+	cd.Status.Platform.Azure.PrivateLink.PrivateDNSZone = to.String(zone.ID)
+	if err := a.r.updatePrivateLinkStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("failed to update the private DNS zone for cluster deployment")
+		return modified, err
+	}
+
+	if _, err := a.hub.CreateOrUpdatePrivateRecordSet(context.TODO(), rg, apiDomain, "@", privatedns.A, privatedns.RecordSet{
+		RecordSetProperties: &privatedns.RecordSetProperties{
+			TTL:      to.Int64Ptr(10),
+			ARecords: &[]privatedns.ARecord{{Ipv4Address: to.StringPtr(endpoint.IP)}},
+		},
+	}); err != nil {
+		zoneLog.WithError(err).Error("error adding record to the private DNS zone for the private endpoint")
+		return modified, err
+	}
+
+	// resource IDs are case insensitive, so the VNets are compared by their lower case IDs.
+	desiredVNets := map[string]string{}
+	for _, vnet := range vnets {
+		desiredVNets[strings.ToLower(vnet)] = vnet
+	}
+
+	links, err := a.hub.ListVirtualNetworkLinks(context.TODO(), rg, apiDomain)
+	if err != nil {
+		zoneLog.WithError(err).Error("failed to list the VNet links of the private DNS zone")
+		return modified, err
+	}
+	oldVNets := sets.NewString()
+	for _, link := range links {
+		if link.VirtualNetworkLinkProperties == nil || link.VirtualNetwork == nil {
+			continue
+		}
+		vnet := to.String(link.VirtualNetwork.ID)
+		if _, ok := desiredVNets[strings.ToLower(vnet)]; ok {
+			oldVNets.Insert(strings.ToLower(vnet))
+			continue
+		}
+		modified = true
+		zoneLog.WithField("vnet", vnet).Debug("removing the VNet link from the private DNS zone")
+		if err := a.hub.DeleteVirtualNetworkLink(context.TODO(), rg, apiDomain, to.String(link.Name)); err != nil {
+			zoneLog.WithField("vnet", vnet).WithError(err).Error("failed to remove the VNet link from the private DNS zone")
+			return modified, err
+		}
+	}
+	for _, key := range sets.StringKeySet(desiredVNets).Difference(oldVNets).List() {
+		vnet := desiredVNets[key]
+		modified = true
+		zoneLog.WithField("vnet", vnet).Debug("adding a VNet link to the private DNS zone")
+		if err := a.hub.CreateOrUpdateVirtualNetworkLink(context.TODO(), rg, apiDomain, vnetLinkName(vnet), vnet); err != nil {
+			zoneLog.WithField("vnet", vnet).WithError(err).Error("failed to add the VNet link to the private DNS zone")
+			return modified, err
+		}
+	}
+
+	return modified, nil
+}
+
+// vnetLinkName returns the name of the link of the private DNS zone to the VNet. The name of a VNet is only unique
+// in its resource group, so its subscription and resource group are part of the name.
+func vnetLinkName(vnetID string) string {
+	resource, err := azure.ParseResourceID(vnetID)
+	if err != nil {
+		return vnetID
+	}
+	name := fmt.Sprintf("%s-%s-%s", resource.SubscriptionID, resource.ResourceGroup, resource.ResourceName)
+	// names of VNet links are limited to 80 characters.
+	if len(name) > 80 {
+		name = name[len(name)-80:]
+	}
+	return name
+}
+
+// resourceGroupName is the resource group the installer creates for the cluster.
+func resourceGroupName(metadata *hivev1.ClusterMetadata) string {
+	return metadata.InfraID + "-rg"
+}
+
+// resourceTags are the tags set on the Azure resources created for the cluster.
+func resourceTags(metadata *hivev1.ClusterMetadata) map[string]string {
+	return map[string]string{
+		"kubernetes.io_cluster." + metadata.InfraID: "owned",
+	}
+}
+
+// parseSubnetID returns the resource group, VNet and name of the subnet with the given resource ID.
+func parseSubnetID(subnetID string) (string, string, string, error) {
+	i := strings.LastIndex(strings.ToLower(subnetID), "/subnets/")
+	if i < 0 {
+		return "", "", "", errors.Errorf("invalid subnet ID %s", subnetID)
+	}
+	vnet, err := azure.ParseResourceID(subnetID[:i])
+	if err != nil {
+		return "", "", "", err
+	}
+	return vnet.ResourceGroup, vnet.ResourceName, subnetID[i+len("/subnets/"):], nil
+}
+
+// actuator creates and deletes the Azure resources of the private link of a cluster. The private link service is
+// managed with the credentials of the cluster, and the private endpoint and private DNS zone with the credentials
+// of the HUB subscription.
+type actuator struct {
+	r    *ReconcileAzurePrivateLink
+	hub  azureclient.Client
+	user azureclient.Client
+}
+
+func newActuator(r *ReconcileAzurePrivateLink, cd *hivev1.ClusterDeployment) (*actuator, error) {
+	userSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{
+		Namespace: cd.Namespace,
+		Name:      cd.Spec.Platform.Azure.CredentialsSecretRef.Name,
+	}, userSecret); err != nil {
+		return nil, errors.Wrap(err, "failed to get Azure credentials secret of the cluster")
+	}
+	uClient, err := r.azureClientFn(userSecret, cd.Spec.Platform.Azure.CloudName.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	hubSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{
+		Namespace: controllerutils.GetHiveNamespace(),
+		Name:      r.controllerconfig.CredentialsSecretRef.Name,
+	}, hubSecret); err != nil {
+		return nil, errors.Wrap(err, "failed to get Azure credentials secret for private link")
+	}
+	hClient, err := r.azureClientFn(hubSecret, r.controllerconfig.CloudName.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &actuator{r: r, hub: hClient, user: uClient}, nil
+}
+
+// ReadAzurePrivateLinkControllerConfigFile reads the configuration from the env
+// and unmarshals. If the env is set to a file but that file doesn't exist it returns
+// a zero value configuration.
+func ReadAzurePrivateLinkControllerConfigFile() (*hivev1.AzurePrivateLinkConfig, error) {
+	fPath := os.Getenv(constants.AzurePrivateLinkControllerConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+
+	config := &hivev1.AzurePrivateLinkConfig{}
+
+	fileBytes, err := ioutil.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, errors.Wrap(err, "failed to read the azure private link controller config file")
+	}
+	if err := json.Unmarshal(fileBytes, &config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+func (r *ReconcileAzurePrivateLink) updatePrivateLinkStatus(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	return retry.RetryOnConflict(privatelink.RetryBackoff, func() error {
+		curr := &hivev1.ClusterDeployment{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+		if err != nil {
+			return err
+		}
+
+		initPrivateLinkStatus(curr)
+		curr.Status.Platform.Azure.PrivateLink = cd.Status.Platform.Azure.PrivateLink
+		return r.Client.Status().Update(context.TODO(), curr)
+	})
+}
+
+func initPrivateLinkStatus(cd *hivev1.ClusterDeployment) {
+	if cd.Status.Platform == nil {
+		cd.Status.Platform = &hivev1.PlatformStatus{}
+	}
+	if cd.Status.Platform.Azure == nil {
+		cd.Status.Platform.Azure = &hivev1azure.PlatformStatus{}
+	}
+	if cd.Status.Platform.Azure.PrivateLink == nil {
+		cd.Status.Platform.Azure.PrivateLink = &hivev1azure.PrivateLinkAccessStatus{}
+	}
+}
+
+// privateLinkStatus returns the private link status of the cluster deployment, or an empty status when it is
+// not set.
+func privateLinkStatus(cd *hivev1.ClusterDeployment) hivev1azure.PrivateLinkAccessStatus {
+	if cd.Status.Platform != nil && cd.Status.Platform.Azure != nil && cd.Status.Platform.Azure.PrivateLink != nil {
+		return *cd.Status.Platform.Azure.PrivateLink
+	}
+	return hivev1azure.PrivateLinkAccessStatus{}
+}
@@ -0,0 +1,703 @@
+package azureprivatelink
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2017-10-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testassert "github.com/openshift/hive/pkg/test/assert"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	testNS = "test-namespace"
+
+	testRegion           = "eastus"
+	userCredsSecretName  = "user-azure-creds"
+	hubCredsSecretName   = "hub-azure-creds"
+	testKubeconfigSecret = "test-cd-provision-0-kubeconfig"
+
+	clusterSubnet = "/subscriptions/user-sub/resourceGroups/test-cd-1234-rg/providers/Microsoft.Network/virtualNetworks/test-cd-1234-vnet/subnets/test-cd-1234-master-subnet"
+	hubVNet       = "/subscriptions/hub-sub/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet"
+)
+
+var notFoundErr = autorest.DetailedError{StatusCode: http.StatusNotFound, Message: "not found"}
+
+// clusterDeploymentAzurePrivateLinkConditions are the cluster deployment conditions controlled by
+// Azure private link controller
+var clusterDeploymentAzurePrivateLinkConditions = []hivev1.ClusterDeploymentConditionType{
+	hivev1.AzurePrivateLinkFailedClusterDeploymentCondition,
+	hivev1.AzurePrivateLinkReadyClusterDeploymentCondition,
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	key := client.ObjectKey{Name: "test-cd", Namespace: testNS}
+	cdBuilder := testcd.FullBuilder(testNS, "test-cd", scheme)
+	azurePlatform := func(pl *hivev1azure.PrivateLinkAccess) testcd.Option {
+		return testcd.WithAzurePlatform(&hivev1azure.Platform{
+			Region:               testRegion,
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: userCredsSecretName},
+			PrivateLink:          pl,
+		})
+	}
+	enabledPLBuilder := cdBuilder.
+		Options(azurePlatform(&hivev1azure.PrivateLinkAccess{Enabled: true}),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionUnknown,
+				Type:   hivev1.AzurePrivateLinkFailedClusterDeploymentCondition,
+			}),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionUnknown,
+				Type:   hivev1.AzurePrivateLinkReadyClusterDeploymentCondition,
+			}),
+		)
+	validInventory := []hivev1.AzurePrivateLinkInventory{{
+		AzurePrivateLinkVNet: hivev1.AzurePrivateLinkVNet{
+			VNetID: "/subscriptions/hub-sub/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet-west",
+			Region: "westus",
+		},
+		Subnets: []hivev1.AzurePrivateLinkSubnet{{Name: "hub-subnet"}},
+	}, {
+		AzurePrivateLinkVNet: hivev1.AzurePrivateLinkVNet{
+			VNetID: hubVNet,
+			Region: testRegion,
+		},
+		Subnets: []hivev1.AzurePrivateLinkSubnet{{Name: "hub-subnet"}},
+	}}
+	kubeConfigSecret := map[string]string{
+		"kubeconfig": `apiVersion: v1
+clusters:
+- cluster:
+    server: https://api.test-cluster:6443
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: admin
+  name: admin
+current-context: admin
+kind: Config
+users:
+- name: admin
+`,
+	}
+	credsSecrets := []runtime.Object{
+		testSecret(testNS, userCredsSecretName, map[string]string{constants.AzureCredentialsName: "{}"}),
+		testSecret(constants.DefaultHiveNamespace, hubCredsSecretName, map[string]string{constants.AzureCredentialsName: "{}"}),
+	}
+
+	frontendID := "/subscriptions/user-sub/resourceGroups/test-cd-1234-rg/providers/Microsoft.Network/loadBalancers/test-cd-1234-internal/frontendIPConfigurations/internal-lb-ip-v4"
+	internalLB := network.LoadBalancer{
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]network.FrontendIPConfiguration{{
+				ID: to.StringPtr(frontendID),
+				FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+					Subnet: &network.Subnet{ID: to.StringPtr(clusterSubnet)},
+				},
+			}},
+		},
+	}
+	hubSubscriptions := &azureclient.PrivateLinkServiceSubscriptions{Subscriptions: []string{"hub-sub"}}
+	service := &azureclient.PrivateLinkService{
+		ID:   "/subscriptions/user-sub/resourceGroups/test-cd-1234-rg/providers/Microsoft.Network/privateLinkServices/test-cd-1234-pls",
+		Name: "test-cd-1234-pls",
+		Properties: azureclient.PrivateLinkServiceProperties{
+			Visibility:   hubSubscriptions,
+			AutoApproval: hubSubscriptions,
+		},
+	}
+	endpoint := &azureclient.PrivateEndpoint{
+		ID:   "/subscriptions/hub-sub/resourceGroups/hub-rg/providers/Microsoft.Network/privateEndpoints/test-cd-1234-pe",
+		Name: "test-cd-1234-pe",
+		Properties: azureclient.PrivateEndpointProperties{
+			Subnet: &azureclient.SubResource{ID: hubVNet + "/subnets/hub-subnet"},
+			NetworkInterfaces: []azureclient.SubResource{{
+				ID: "/subscriptions/hub-sub/resourceGroups/hub-rg/providers/Microsoft.Network/networkInterfaces/test-cd-1234-pe.nic",
+			}},
+		},
+	}
+	nic := network.Interface{
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			IPConfigurations: &[]network.InterfaceIPConfiguration{{
+				InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+					PrivateIPAddress: to.StringPtr("10.0.0.5"),
+				},
+			}},
+		},
+	}
+	zoneID := "/subscriptions/hub-sub/resourceGroups/hub-rg/providers/Microsoft.Network/privateDnsZones/api.test-cluster"
+	readyStatus := &hivev1azure.PrivateLinkAccessStatus{
+		PrivateLinkService: service.ID,
+		PrivateEndpoint:    endpoint.ID,
+		PrivateDNSZone:     zoneID,
+	}
+	mockRecord := func(m *mock.MockClient) {
+		m.EXPECT().CreateOrUpdatePrivateRecordSet(gomock.Any(), "hub-rg", "api.test-cluster", "@", privatedns.A, privatedns.RecordSet{
+			RecordSetProperties: &privatedns.RecordSetProperties{
+				TTL:      to.Int64Ptr(10),
+				ARecords: &[]privatedns.ARecord{{Ipv4Address: to.StringPtr("10.0.0.5")}},
+			},
+		}).Return(privatedns.RecordSet{}, nil)
+	}
+	mockExistingHubResources := func(m *mock.MockClient) {
+		m.EXPECT().GetPrivateEndpoint(gomock.Any(), "hub-rg", "test-cd-1234-pe").Return(endpoint, nil)
+		m.EXPECT().GetNetworkInterface(gomock.Any(), "hub-rg", "test-cd-1234-pe.nic").Return(nic, nil)
+		m.EXPECT().GetPrivateZone(gomock.Any(), "hub-rg", "api.test-cluster").
+			Return(privatedns.PrivateZone{ID: to.StringPtr(zoneID)}, nil)
+		mockRecord(m)
+	}
+
+	cases := []struct {
+		name string
+
+		existing      []runtime.Object
+		inventory     []hivev1.AzurePrivateLinkInventory
+		associate     []string
+		configureUser func(*mock.MockClient)
+		configureHub  func(*mock.MockClient)
+
+		hasFinalizer        bool
+		expectedAnnotations map[string]string
+		expectedStatus      *hivev1azure.PrivateLinkAccessStatus
+		expectedConditions  []hivev1.ClusterDeploymentCondition
+		err                 string
+	}{{
+		name: "cd without initialized conditions",
+
+		existing: []runtime.Object{
+			cdBuilder.Build(azurePlatform(nil)),
+		},
+	}, {
+		name: "cd with aws platform",
+
+		existing: []runtime.Object{
+			cdBuilder.Build(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"})),
+		},
+	}, {
+		name: "cd with private link disabled",
+
+		existing: []runtime.Object{
+			cdBuilder.Build(azurePlatform(&hivev1azure.PrivateLinkAccess{Enabled: false})),
+		},
+	}, {
+		name: "cd with private link enabled, no inventory in given region",
+
+		existing: []runtime.Object{
+			enabledPLBuilder.Build(),
+		},
+		inventory: validInventory[:1],
+
+		hasFinalizer: true,
+		expectedConditions: getExpectedConditions(true, "UnsupportedRegion",
+			"cluster deployment region \"eastus\" is not supported as there is no inventory to create necessary resources"),
+	}, {
+		name: "cd with private link enabled, no provision started",
+
+		existing: []runtime.Object{
+			enabledPLBuilder.Build(),
+		},
+		inventory: validInventory,
+
+		hasFinalizer: true,
+	}, {
+		name: "cd with private link enabled, provision started, load balancer not found",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			enabledPLBuilder.Build(withClusterProvision("test-cd-provision-0")),
+		}, credsSecrets...),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-internal").
+				Return(network.LoadBalancer{}, notFoundErr)
+		},
+
+		hasFinalizer: true,
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.AzurePrivateLinkFailedClusterDeploymentCondition,
+		}, {
+			Status:  corev1.ConditionFalse,
+			Type:    hivev1.AzurePrivateLinkReadyClusterDeploymentCondition,
+			Reason:  "DiscoveringLoadBalancerNotYetFound",
+			Message: "discovering internal API load balancer for the cluster, but it does not exist yet",
+		}},
+	}, {
+		name: "cd with private link enabled, provision started, no previous resources",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			testSecret(testNS, testKubeconfigSecret, kubeConfigSecret),
+			enabledPLBuilder.Build(withClusterProvision("test-cd-provision-0")),
+		}, credsSecrets...),
+		inventory: validInventory,
+		associate: []string{"/subscriptions/hub-sub/resourceGroups/hive-rg/providers/Microsoft.Network/virtualNetworks/hive-vnet"},
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-internal").Return(internalLB, nil)
+			m.EXPECT().GetPrivateLinkService(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-pls").Return(nil, notFoundErr)
+			m.EXPECT().DisableSubnetPrivateLinkServiceNetworkPolicies(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-vnet", "test-cd-1234-master-subnet").
+				Return(nil)
+			m.EXPECT().CreateOrUpdatePrivateLinkService(gomock.Any(), "test-cd-1234-rg", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, pls *azureclient.PrivateLinkService) (*azureclient.PrivateLinkService, error) {
+					assert.Equal(t, testRegion, pls.Location)
+					assert.Equal(t, []azureclient.SubResource{{ID: frontendID}}, pls.Properties.LoadBalancerFrontendIPConfigurations)
+					assert.Equal(t, clusterSubnet, pls.Properties.IPConfigurations[0].Properties.Subnet.ID)
+					assert.Equal(t, hubSubscriptions, pls.Properties.Visibility)
+					assert.Equal(t, hubSubscriptions, pls.Properties.AutoApproval)
+					return service, nil
+				})
+		},
+		configureHub: func(m *mock.MockClient) {
+			m.EXPECT().GetSubnet(gomock.Any(), "hub-rg", "hub-vnet", "hub-subnet").Return(&azureclient.Subnet{}, nil)
+			m.EXPECT().CreateOrUpdatePrivateEndpoint(gomock.Any(), "hub-rg", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, pe *azureclient.PrivateEndpoint) (*azureclient.PrivateEndpoint, error) {
+					assert.Equal(t, "test-cd-1234-pe", pe.Name)
+					assert.Equal(t, hubVNet+"/subnets/hub-subnet", pe.Properties.Subnet.ID)
+					assert.Equal(t, service.ID, pe.Properties.PrivateLinkServiceConnections[0].Properties.PrivateLinkServiceID)
+					return endpoint, nil
+				})
+			m.EXPECT().GetNetworkInterface(gomock.Any(), "hub-rg", "test-cd-1234-pe.nic").Return(nic, nil)
+			m.EXPECT().GetPrivateZone(gomock.Any(), "hub-rg", "api.test-cluster").Return(privatedns.PrivateZone{}, notFoundErr)
+			m.EXPECT().CreateOrUpdatePrivateZone(gomock.Any(), "hub-rg", "api.test-cluster", gomock.Any()).
+				Return(privatedns.PrivateZone{ID: to.StringPtr(zoneID)}, nil)
+			mockRecord(m)
+			m.EXPECT().ListVirtualNetworkLinks(gomock.Any(), "hub-rg", "api.test-cluster").Return(nil, nil)
+			m.EXPECT().CreateOrUpdateVirtualNetworkLink(gomock.Any(), "hub-rg", "api.test-cluster",
+				"hub-sub-hive-rg-hive-vnet", "/subscriptions/hub-sub/resourceGroups/hive-rg/providers/Microsoft.Network/virtualNetworks/hive-vnet").
+				Return(nil)
+			m.EXPECT().CreateOrUpdateVirtualNetworkLink(gomock.Any(), "hub-rg", "api.test-cluster",
+				"hub-sub-hub-rg-hub-vnet", hubVNet).Return(nil)
+		},
+
+		hasFinalizer:   true,
+		expectedStatus: readyStatus,
+		expectedConditions: getExpectedConditions(false, "PrivateLinkAccessReady",
+			"private link access is ready for use"),
+	}, {
+		name: "cd with private link enabled, no VNet with quota",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			enabledPLBuilder.Build(withClusterProvision("test-cd-provision-0")),
+		}, credsSecrets...),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-internal").Return(internalLB, nil)
+			m.EXPECT().GetPrivateLinkService(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-pls").Return(service, nil)
+		},
+		configureHub: func(m *mock.MockClient) {
+			m.EXPECT().GetSubnet(gomock.Any(), "hub-rg", "hub-vnet", "hub-subnet").Return(&azureclient.Subnet{
+				Properties: azureclient.SubnetProperties{
+					PrivateEndpoints: make([]azureclient.SubResource, privateEndpointsPerVNetLimit),
+				},
+			}, nil)
+		},
+
+		hasFinalizer:   true,
+		expectedStatus: &hivev1azure.PrivateLinkAccessStatus{PrivateLinkService: service.ID},
+		expectedConditions: getExpectedConditions(true, "NoVNetWithQuotaInInventory",
+			errNoVNetWithQuotaInInventory.Error()),
+		err: "failed to reconcile the endpoint: " + errNoVNetWithQuotaInInventory.Error(),
+	}, {
+		name: "cd with private link enabled, previous resources, service visible to other subscription",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			testSecret(testNS, testKubeconfigSecret, kubeConfigSecret),
+			enabledPLBuilder.Build(
+				withClusterProvision("test-cd-provision-0"),
+				withPrivateLink(readyStatus),
+			),
+		}, credsSecrets...),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-internal").Return(internalLB, nil)
+			m.EXPECT().GetPrivateLinkService(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-pls").Return(&azureclient.PrivateLinkService{
+				ID:   service.ID,
+				Name: service.Name,
+				Properties: azureclient.PrivateLinkServiceProperties{
+					Visibility:   &azureclient.PrivateLinkServiceSubscriptions{Subscriptions: []string{"other-sub"}},
+					AutoApproval: hubSubscriptions,
+				},
+			}, nil)
+			m.EXPECT().CreateOrUpdatePrivateLinkService(gomock.Any(), "test-cd-1234-rg", &azureclient.PrivateLinkService{
+				ID:   service.ID,
+				Name: service.Name,
+				Properties: azureclient.PrivateLinkServiceProperties{
+					Visibility:   hubSubscriptions,
+					AutoApproval: hubSubscriptions,
+				},
+			}).Return(service, nil)
+		},
+		configureHub: func(m *mock.MockClient) {
+			mockExistingHubResources(m)
+			m.EXPECT().ListVirtualNetworkLinks(gomock.Any(), "hub-rg", "api.test-cluster").Return([]privatedns.VirtualNetworkLink{{
+				Name: to.StringPtr("hub-sub-hub-rg-hub-vnet"),
+				VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+					VirtualNetwork: &privatedns.SubResource{ID: to.StringPtr(hubVNet)},
+				},
+			}}, nil)
+		},
+
+		hasFinalizer:   true,
+		expectedStatus: readyStatus,
+		expectedConditions: getExpectedConditions(false, "PrivateLinkAccessReady",
+			"private link access is ready for use"),
+	}, {
+		name: "cd with private link enabled, previous resources, remove stale VNet link",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			testSecret(testNS, testKubeconfigSecret, kubeConfigSecret),
+			enabledPLBuilder.Build(
+				withClusterProvision("test-cd-provision-0"),
+				withPrivateLink(readyStatus),
+			),
+		}, credsSecrets...),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-internal").Return(internalLB, nil)
+			m.EXPECT().GetPrivateLinkService(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-pls").Return(service, nil)
+		},
+		configureHub: func(m *mock.MockClient) {
+			mockExistingHubResources(m)
+			m.EXPECT().ListVirtualNetworkLinks(gomock.Any(), "hub-rg", "api.test-cluster").Return([]privatedns.VirtualNetworkLink{{
+				Name: to.StringPtr("hub-sub-hub-rg-hub-vnet"),
+				VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+					// resource IDs are case insensitive
+					VirtualNetwork: &privatedns.SubResource{ID: to.StringPtr("/subscriptions/hub-sub/resourcegroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet")},
+				},
+			}, {
+				Name: to.StringPtr("hub-sub-old-rg-old-vnet"),
+				VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+					VirtualNetwork: &privatedns.SubResource{ID: to.StringPtr("/subscriptions/hub-sub/resourceGroups/old-rg/providers/Microsoft.Network/virtualNetworks/old-vnet")},
+				},
+			}}, nil)
+			m.EXPECT().DeleteVirtualNetworkLink(gomock.Any(), "hub-rg", "api.test-cluster", "hub-sub-old-rg-old-vnet").Return(nil)
+		},
+
+		hasFinalizer:   true,
+		expectedStatus: readyStatus,
+		expectedConditions: getExpectedConditions(false, "PrivateLinkAccessReady",
+			"private link access is ready for use"),
+	}, {
+		name: "cd with private link enabled, previous provision failed, new started",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret),
+				provisionWithFailed()),
+			testProvision("test-cd-provision-1",
+				provisionWithPrevInfraID("test-cd-1234")),
+			enabledPLBuilder.Build(
+				withClusterProvision("test-cd-provision-1"),
+				withPrivateLink(readyStatus),
+			),
+		}, credsSecrets...),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().DeletePrivateLinkService(gomock.Any(), "test-cd-1234-rg", "test-cd-1234-pls").Return(nil)
+		},
+		configureHub: func(m *mock.MockClient) {
+			m.EXPECT().ListVirtualNetworkLinks(gomock.Any(), "hub-rg", "api.test-cluster").Return([]privatedns.VirtualNetworkLink{{
+				Name: to.StringPtr("hub-sub-hub-rg-hub-vnet"),
+			}}, nil)
+			m.EXPECT().DeleteVirtualNetworkLink(gomock.Any(), "hub-rg", "api.test-cluster", "hub-sub-hub-rg-hub-vnet").Return(nil)
+			m.EXPECT().DeletePrivateZone(gomock.Any(), "hub-rg", "api.test-cluster").Return(nil)
+			m.EXPECT().DeletePrivateEndpoint(gomock.Any(), "hub-rg", "test-cd-1234-pe").Return(notFoundErr)
+		},
+
+		hasFinalizer: true,
+		expectedAnnotations: map[string]string{
+			lastCleanupAnnotationKey: "test-cd-1234",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.AzurePrivateLinkFailedClusterDeploymentCondition,
+		}, {
+			Status:  corev1.ConditionFalse,
+			Type:    hivev1.AzurePrivateLinkReadyClusterDeploymentCondition,
+			Reason:  "PreviousAttemptCleanupComplete",
+			Message: "successfully cleaned up resources from previous provision attempt so that next attempt can start",
+		}},
+	}, {
+		name: "cd with private link enabled, previous provision failed, new started, cleanup already done",
+
+		existing: []runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithFailed()),
+			testProvision("test-cd-provision-1",
+				provisionWithPrevInfraID("test-cd-1234")),
+			enabledPLBuilder.GenericOptions(
+				generic.WithAnnotation(lastCleanupAnnotationKey, "test-cd-1234"),
+			).Build(
+				withClusterProvision("test-cd-provision-1"),
+				withPrivateLink(readyStatus),
+			),
+		},
+		inventory: validInventory,
+
+		hasFinalizer: true,
+		expectedAnnotations: map[string]string{
+			lastCleanupAnnotationKey: "test-cd-1234",
+		},
+		expectedStatus: readyStatus,
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockedUserClient := mock.NewMockClient(mockCtrl)
+			mockedHubClient := mock.NewMockClient(mockCtrl)
+
+			if test.configureUser != nil {
+				test.configureUser(mockedUserClient)
+			}
+			if test.configureHub != nil {
+				test.configureHub(mockedHubClient)
+			}
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			log.SetLevel(log.DebugLevel)
+			reconciler := &ReconcileAzurePrivateLink{
+				Client: fakeClient,
+				controllerconfig: &hivev1.AzurePrivateLinkConfig{
+					CredentialsSecretRef:  corev1.LocalObjectReference{Name: hubCredsSecretName},
+					EndpointVNetInventory: test.inventory,
+					AssociatedVNets:       test.associate,
+				},
+
+				azureClientFn: func(secret *corev1.Secret, _ string) (azureclient.Client, error) {
+					if secret.Name == hubCredsSecretName {
+						return mockedHubClient, nil
+					}
+					return mockedUserClient, nil
+				},
+			}
+
+			reconcileRequest := reconcile.Request{
+				NamespacedName: key,
+			}
+
+			_, err := reconciler.Reconcile(context.TODO(), reconcileRequest)
+			if test.err == "" {
+				assert.NoError(t, err, "unexpected error from Reconcile")
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+			cd := &hivev1.ClusterDeployment{}
+			err = fakeClient.Get(context.TODO(), key, cd)
+			require.NoError(t, err)
+
+			if test.hasFinalizer {
+				assert.Contains(t, cd.ObjectMeta.Finalizers, finalizer)
+			}
+
+			if len(test.expectedAnnotations) > 0 {
+				assert.Equal(t, test.expectedAnnotations, cd.Annotations)
+			}
+
+			for _, cond := range clusterDeploymentAzurePrivateLinkConditions {
+				if present := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions,
+					cond); present == nil {
+					test.expectedConditions = append(test.expectedConditions, hivev1.ClusterDeploymentCondition{
+						Status:  corev1.ConditionUnknown,
+						Type:    cond,
+						Reason:  "Initialized",
+						Message: "Condition Initialized",
+					})
+				}
+			}
+			testassert.AssertConditions(t, cd, test.expectedConditions)
+
+			if cd.Status.Platform == nil {
+				cd.Status.Platform = &hivev1.PlatformStatus{}
+			}
+			if cd.Status.Platform.Azure == nil {
+				cd.Status.Platform.Azure = &hivev1azure.PlatformStatus{}
+			}
+			assert.Equal(t, test.expectedStatus, cd.Status.Platform.Azure.PrivateLink)
+		})
+	}
+}
+
+func Test_parseSubnetID(t *testing.T) {
+	rg, vnet, subnet, err := parseSubnetID(clusterSubnet)
+	require.NoError(t, err)
+	assert.Equal(t, "test-cd-1234-rg", rg)
+	assert.Equal(t, "test-cd-1234-vnet", vnet)
+	assert.Equal(t, "test-cd-1234-master-subnet", subnet)
+
+	_, _, _, err = parseSubnetID(hubVNet)
+	assert.Error(t, err)
+}
+
+func Test_toSupportedVNets(t *testing.T) {
+	inv := []hivev1.AzurePrivateLinkInventory{{
+		AzurePrivateLinkVNet: hivev1.AzurePrivateLinkVNet{VNetID: "vnet-1", Region: "eastus"},
+		Subnets:              []hivev1.AzurePrivateLinkSubnet{{Name: "subnet-1"}},
+	}, {
+		AzurePrivateLinkVNet: hivev1.AzurePrivateLinkVNet{VNetID: "vnet-2", Region: "westus"},
+		Subnets:              []hivev1.AzurePrivateLinkSubnet{{Name: "subnet-2"}},
+	}, {
+		AzurePrivateLinkVNet: hivev1.AzurePrivateLinkVNet{VNetID: "vnet-3", Region: "EastUS"},
+	}}
+
+	inv = filterInventory(inv, toSupportedVNets("eastus"))
+	assert.Equal(t, []hivev1.AzurePrivateLinkInventory{{
+		AzurePrivateLinkVNet: hivev1.AzurePrivateLinkVNet{VNetID: "vnet-1", Region: "eastus"},
+		Subnets:              []hivev1.AzurePrivateLinkSubnet{{Name: "subnet-1"}},
+	}}, inv)
+}
+
+func testSecret(namespace, name string, data map[string]string) *corev1.Secret {
+	s := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Data: map[string][]byte{},
+	}
+	for k, v := range data {
+		s.Data[k] = []byte(v)
+	}
+	return s
+}
+
+func withClusterProvision(provisionName string) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.ProvisionRef = &corev1.LocalObjectReference{Name: provisionName}
+	}
+}
+
+func withPrivateLink(p *hivev1azure.PrivateLinkAccessStatus) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		if cd.Status.Platform == nil {
+			cd.Status.Platform = &hivev1.PlatformStatus{Azure: &hivev1azure.PlatformStatus{}}
+		}
+		cd.Status.Platform.Azure.PrivateLink = p.DeepCopy()
+	}
+}
+
+type provisionOption func(*hivev1.ClusterProvision)
+
+func testProvision(name string, opts ...provisionOption) *hivev1.ClusterProvision {
+	provision := &hivev1.ClusterProvision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNS,
+			Labels: map[string]string{
+				constants.ClusterDeploymentNameLabel: "test-cd",
+			},
+		},
+		Spec: hivev1.ClusterProvisionSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{
+				Name: "test-cd",
+			},
+			Stage: hivev1.ClusterProvisionStageInitializing,
+		},
+	}
+
+	for _, o := range opts {
+		o(provision)
+	}
+
+	return provision
+}
+
+func provisionWithInfraID(id string) provisionOption {
+	return func(cp *hivev1.ClusterProvision) {
+		cp.Spec.InfraID = &id
+	}
+}
+
+func provisionWithFailed() provisionOption {
+	return func(cp *hivev1.ClusterProvision) {
+		cp.Spec.Stage = hivev1.ClusterProvisionStageFailed
+	}
+}
+
+func provisionWithPrevInfraID(id string) provisionOption {
+	return func(cp *hivev1.ClusterProvision) {
+		cp.Spec.PrevInfraID = &id
+	}
+}
+
+func provisionWithAdminKubeconfig(name string) provisionOption {
+	return func(cp *hivev1.ClusterProvision) {
+		cp.Spec.AdminKubeconfigSecretRef = &corev1.LocalObjectReference{Name: name}
+	}
+}
+
+// getExpectedConditions should be called when only one of Ready and Failed conditions is true,
+// and both have the same reason and message
+func getExpectedConditions(failed bool, reason string, message string) []hivev1.ClusterDeploymentCondition {
+	if failed {
+		return []hivev1.ClusterDeploymentCondition{{
+			Status:  corev1.ConditionTrue,
+			Type:    hivev1.AzurePrivateLinkFailedClusterDeploymentCondition,
+			Reason:  reason,
+			Message: message,
+		}, {
+			Status:  corev1.ConditionFalse,
+			Type:    hivev1.AzurePrivateLinkReadyClusterDeploymentCondition,
+			Reason:  reason,
+			Message: message,
+		}}
+	}
+	return []hivev1.ClusterDeploymentCondition{{
+		Status:  corev1.ConditionFalse,
+		Type:    hivev1.AzurePrivateLinkFailedClusterDeploymentCondition,
+		Reason:  reason,
+		Message: message,
+	}, {
+		Status:  corev1.ConditionTrue,
+		Type:    hivev1.AzurePrivateLinkReadyClusterDeploymentCondition,
+		Reason:  reason,
+		Message: message,
+	}}
+}
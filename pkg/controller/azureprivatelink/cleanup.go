@@ -0,0 +1,115 @@
+package azureprivatelink
+
+import (
+	"context"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+)
+
+func cleanupRequired(cd *hivev1.ClusterDeployment) bool {
+	plStatus := privateLinkStatus(cd)
+	return plStatus.PrivateLinkService != "" ||
+		plStatus.PrivateEndpoint != "" ||
+		plStatus.PrivateDNSZone != ""
+}
+
+// Cleanup deletes the Azure resources recorded in the status of the cluster deployment, so it does not need the
+// metadata to find them.
+func (a *actuator) Cleanup(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error {
+	plStatus := privateLinkStatus(cd)
+	if err := cleanupPrivateDNSZone(a.hub, plStatus.PrivateDNSZone, logger); err != nil {
+		logger.WithError(err).Error("error cleaning up private DNS zone")
+		return err
+	}
+	if err := cleanupPrivateEndpoint(a.hub, plStatus.PrivateEndpoint, logger); err != nil {
+		logger.WithError(err).Error("error cleaning up private endpoint")
+		return err
+	}
+	if err := cleanupPrivateLinkService(a.user, plStatus.PrivateLinkService, logger); err != nil {
+		logger.WithError(err).Error("error cleaning up private link service")
+		return err
+	}
+
+	initPrivateLinkStatus(cd)
+	cd.Status.Platform.Azure.PrivateLink = nil
+	if err := a.r.updatePrivateLinkStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment after cleanup of private link")
+		return err
+	}
+
+	return nil
+}
+
+func cleanupPrivateDNSZone(azureClient azureclient.Client, id string, logger log.FieldLogger) error {
+	if id == "" {
+		return nil
+	}
+	zoneLog := logger.WithField("privateDNSZone", id)
+	resource, err := azure.ParseResourceID(id)
+	if err != nil {
+		return err
+	}
+
+	// a private DNS zone cannot be deleted while it is linked to VNets.
+	links, err := azureClient.ListVirtualNetworkLinks(context.TODO(), resource.ResourceGroup, resource.ResourceName)
+	if azureclient.IsNotFound(err) {
+		return nil // no more work
+	}
+	if err != nil {
+		zoneLog.WithError(err).Error("failed to list the VNet links of the private DNS zone")
+		return err
+	}
+	for _, link := range links {
+		if err := azureClient.DeleteVirtualNetworkLink(context.TODO(), resource.ResourceGroup, resource.ResourceName, to.String(link.Name)); err != nil &&
+			!azureclient.IsNotFound(err) {
+			zoneLog.WithField("link", to.String(link.Name)).WithError(err).Error("failed to delete the VNet link of the private DNS zone")
+			return err
+		}
+	}
+
+	if err := azureClient.DeletePrivateZone(context.TODO(), resource.ResourceGroup, resource.ResourceName); err != nil && !azureclient.IsNotFound(err) {
+		zoneLog.WithError(err).Error("error deleting the private DNS zone")
+		return err
+	}
+
+	return nil
+}
+
+func cleanupPrivateEndpoint(azureClient azureclient.Client, id string, logger log.FieldLogger) error {
+	if id == "" {
+		return nil
+	}
+	resource, err := azure.ParseResourceID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := azureClient.DeletePrivateEndpoint(context.TODO(), resource.ResourceGroup, resource.ResourceName); err != nil && !azureclient.IsNotFound(err) {
+		logger.WithField("privateEndpoint", id).WithError(err).Error("error deleting the private endpoint")
+		return err
+	}
+
+	return nil
+}
+
+func cleanupPrivateLinkService(azureClient azureclient.Client, id string, logger log.FieldLogger) error {
+	if id == "" {
+		return nil
+	}
+	resource, err := azure.ParseResourceID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := azureClient.DeletePrivateLinkService(context.TODO(), resource.ResourceGroup, resource.ResourceName); err != nil && !azureclient.IsNotFound(err) {
+		logger.WithField("privateLinkService", id).WithError(err).Error("error deleting the private link service")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package azureprivatelink
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// privateEndpointsPerVNetLimit is the number of private endpoints the controller creates in a VNet, kept below
+// the limit of 1000 private endpoints per VNet of Azure to leave room for other users of the VNet.
+const privateEndpointsPerVNetLimit = 950
+
+var (
+	errNoSupportedVNetInInventory = errors.New("no supported VNet in inventory in the region of the cluster")
+	errNoVNetWithQuotaInInventory = errors.New("no VNet in inventory with quota for private endpoints in the region of the cluster")
+)
+
+// chooseVNetForPrivateEndpoint chooses a VNet from the inventory given to the controller in the region of the
+// cluster that has room for another private endpoint. The VNet with the fewest private endpoints is chosen to
+// spread them evenly across the inventory.
+func (r *ReconcileAzurePrivateLink) chooseVNetForPrivateEndpoint(azureClient azureclient.Client,
+	cd *hivev1.ClusterDeployment,
+	logger log.FieldLogger) (*hivev1.AzurePrivateLinkInventory, error) {
+	candidates := filterInventory(r.controllerconfig.DeepCopy().EndpointVNetInventory, toSupportedVNets(controllerutils.GetClusterRegion(cd)))
+	if len(candidates) == 0 {
+		logger.WithField("region", controllerutils.GetClusterRegion(cd)).Error(errNoSupportedVNetInInventory.Error())
+		return nil, errNoSupportedVNetInInventory
+	}
+
+	var chosen *hivev1.AzurePrivateLinkInventory
+	chosenCount := privateEndpointsPerVNetLimit
+	for i, cand := range candidates {
+		count, err := countPrivateEndpoints(azureClient, &cand)
+		if err != nil {
+			logger.WithField("vnet", cand.VNetID).WithError(err).Error("failed to count the private endpoints in the VNet")
+			return nil, err
+		}
+		if count < chosenCount {
+			chosen = &candidates[i]
+			chosenCount = count
+		}
+	}
+	if chosen == nil {
+		logger.WithField("region", controllerutils.GetClusterRegion(cd)).Error(errNoVNetWithQuotaInInventory.Error())
+		return nil, errNoVNetWithQuotaInInventory
+	}
+
+	return chosen, nil
+}
+
+// countPrivateEndpoints returns the number of private endpoints in the subnets of the inventory VNet.
+func countPrivateEndpoints(azureClient azureclient.Client, inv *hivev1.AzurePrivateLinkInventory) (int, error) {
+	count := 0
+	for _, subnet := range inv.Subnets {
+		rg, vnet, name, err := parseSubnetID(inv.VNetID + "/subnets/" + subnet.Name)
+		if err != nil {
+			return 0, err
+		}
+		s, err := azureClient.GetSubnet(context.TODO(), rg, vnet, name)
+		if err != nil {
+			return 0, err
+		}
+		count += len(s.Properties.PrivateEndpoints)
+	}
+	return count, nil
+}
+
+type filterInventoryFn func(*hivev1.AzurePrivateLinkInventory) bool
+
+func filterInventory(input []hivev1.AzurePrivateLinkInventory, fn filterInventoryFn) []hivev1.AzurePrivateLinkInventory {
+	n := 0
+	for _, cand := range input {
+		if fn(&cand) {
+			input[n] = cand
+			n++
+		}
+	}
+	input = input[:n]
+	return input
+}
+
+func toSupportedVNets(region string) filterInventoryFn {
+	return func(inv *hivev1.AzurePrivateLinkInventory) bool {
+		return strings.EqualFold(region, inv.Region) && len(inv.Subnets) > 0
+	}
+}
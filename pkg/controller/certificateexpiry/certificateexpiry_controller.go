@@ -0,0 +1,379 @@
+package certificateexpiry
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.CertificateExpiryControllerName
+
+	defaultWarningWindow = 14 * 24 * time.Hour
+
+	// expiryCheckInterval is how often the certificates are checked again, since changes to the secrets do not
+	// trigger a reconcile.
+	expiryCheckInterval = 1 * time.Hour
+
+	certificatesExpiredReason  = "CertificatesExpired"
+	certificatesExpiringReason = "CertificatesExpiring"
+	certificatesValidReason    = "CertificatesValid"
+)
+
+// clusterDeploymentCertificateExpiryConditions are the cluster deployment conditions controlled by
+// the certificate expiry controller
+var clusterDeploymentCertificateExpiryConditions = []hivev1.ClusterDeploymentConditionType{
+	hivev1.CertificateExpiringClusterDeploymentCondition,
+}
+
+// certificateKind is the kind of credential a certificate belongs to.
+type certificateKind string
+
+const (
+	adminKubeconfigCertificate   certificateKind = "admin-kubeconfig"
+	certificateBundleCertificate certificateKind = "certificate-bundle"
+)
+
+// certificate is a certificate of a ClusterDeployment and when it expires.
+type certificate struct {
+	kind     certificateKind
+	bundle   string
+	notAfter time.Time
+}
+
+func (c certificate) String() string {
+	if c.kind == adminKubeconfigCertificate {
+		return "admin kubeconfig"
+	}
+	return fmt.Sprintf("certificate bundle %s", c.bundle)
+}
+
+// Add creates a new CertificateExpiry Controller and adds it to the Manager with default RBAC.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	reconciler, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("could not create reconciler")
+		return err
+	}
+	return AddToManager(mgr, reconciler, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileCertificateExpiry
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileCertificateExpiry, error) {
+	logger := log.WithField("controller", ControllerName)
+	warningWindow := defaultWarningWindow
+	if windowStr := os.Getenv(constants.CertificateExpiryWarningWindowEnvVar); windowStr != "" {
+		window, err := time.ParseDuration(windowStr)
+		if err != nil {
+			logger.WithError(err).Errorf("Couldn't parse environment variable %v: %v", constants.CertificateExpiryWarningWindowEnvVar, windowStr)
+			return nil, err
+		}
+		warningWindow = window
+	}
+	return &ReconcileCertificateExpiry{
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		warningWindow: warningWindow,
+		metrics:       newCertificateMetrics(),
+		nowFn:         time.Now,
+	}, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileCertificateExpiry, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controller.New("certificateexpiry-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}},
+		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, controllerutils.IsClusterDeploymentErrorUpdateEvent))
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileCertificateExpiry{}
+
+// ReconcileCertificateExpiry tracks when the admin kubeconfig client certificate and the certificates of the
+// certificate bundles of ClusterDeployments expire.
+type ReconcileCertificateExpiry struct {
+	client.Client
+
+	// warningWindow is how long before a certificate expires the CertificateExpiring condition is raised.
+	warningWindow time.Duration
+
+	metrics *certificateMetrics
+
+	// testing purpose
+	nowFn func() time.Time
+}
+
+// Reconcile records when the certificates of a ClusterDeployment expire and raises the CertificateExpiring
+// condition when one of them expires within the warning window.
+func (r *ReconcileCertificateExpiry) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("cluster deployment not found")
+		r.metrics.clear(request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		// Error reading the object - requeue the request.
+		logger.WithError(err).Error("error getting ClusterDeployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.DeletionTimestamp != nil {
+		logger.Debug("cluster deployment is being deleted, so skipping")
+		r.metrics.clear(request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	// Initialize cluster deployment conditions if not present
+	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, clusterDeploymentCertificateExpiryConditions)
+	if len(newConditions) > len(cd.Status.Conditions) {
+		cd.Status.Conditions = newConditions
+		logger.Info("initializing certificate expiry controller conditions")
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	origStatus := cd.Status.DeepCopy()
+	var certs []certificate
+
+	if cd.Spec.Installed && cd.Spec.ClusterMetadata != nil {
+		notAfter, err := r.adminKubeconfigNotAfter(cd, logger)
+		if err != nil {
+			logger.WithError(err).Error("error getting admin kubeconfig")
+			return reconcile.Result{}, err
+		}
+		if notAfter != nil {
+			certs = append(certs, certificate{kind: adminKubeconfigCertificate, notAfter: *notAfter})
+		}
+	}
+
+	bundles := sets.NewString()
+	for _, bundle := range cd.Spec.CertificateBundles {
+		bundles.Insert(bundle.Name)
+		notAfter, err := r.certificateBundleNotAfter(cd, bundle, logger.WithField("certificateBundle", bundle.Name))
+		if err != nil {
+			logger.WithError(err).WithField("certificateBundle", bundle.Name).Error("error getting certificate bundle secret")
+			return reconcile.Result{}, err
+		}
+		setBundleNotAfter(cd, bundle.Name, notAfter)
+		if notAfter != nil {
+			certs = append(certs, certificate{kind: certificateBundleCertificate, bundle: bundle.Name, notAfter: *notAfter})
+		}
+	}
+	// Bundles removed from the spec no longer have a certificate to track.
+	for i := range cd.Status.CertificateBundles {
+		if !bundles.Has(cd.Status.CertificateBundles[i].Name) {
+			cd.Status.CertificateBundles[i].NotAfter = nil
+		}
+	}
+
+	r.metrics.report(request.NamespacedName, certs)
+	r.setExpiringCondition(cd, certs)
+
+	if !reflect.DeepEqual(origStatus, &cd.Status) {
+		logger.Debug("updating certificate expiry status")
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: r.requeueAfter(certs)}, nil
+}
+
+// adminKubeconfigNotAfter returns when the client certificate of the admin kubeconfig expires, or nil if the
+// admin kubeconfig does not exist or does not authenticate with a client certificate.
+func (r *ReconcileCertificateExpiry) adminKubeconfigNotAfter(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*time.Time, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, secret)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("admin kubeconfig secret not found")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.Load(secret.Data[constants.KubeconfigSecretKey])
+	if err != nil {
+		logger.WithError(err).Warn("could not parse admin kubeconfig")
+		return nil, nil
+	}
+	var earliest *time.Time
+	for name, authInfo := range config.AuthInfos {
+		if len(authInfo.ClientCertificateData) == 0 {
+			continue
+		}
+		notAfter, err := parseNotAfter(authInfo.ClientCertificateData)
+		if err != nil {
+			logger.WithError(err).WithField("user", name).Warn("could not parse admin kubeconfig client certificate")
+			continue
+		}
+		if earliest == nil || notAfter.Before(*earliest) {
+			earliest = &notAfter
+		}
+	}
+	return earliest, nil
+}
+
+// certificateBundleNotAfter returns when the certificate of the certificate bundle expires, or nil if its secret
+// does not exist or does not hold a certificate.
+func (r *ReconcileCertificateExpiry) certificateBundleNotAfter(cd *hivev1.ClusterDeployment, bundle hivev1.CertificateBundleSpec, logger log.FieldLogger) (*time.Time, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: bundle.CertificateSecretRef.Name}, secret)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("certificate bundle secret not found")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	notAfter, err := parseNotAfter(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		logger.WithError(err).Warn("could not parse certificate bundle certificate")
+		return nil, nil
+	}
+	return &notAfter, nil
+}
+
+// parseNotAfter returns when the first certificate of the PEM-encoded certificate chain expires.
+func parseNotAfter(data []byte) (time.Time, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return time.Time{}, errors.New("no PEM-encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
+
+// setBundleNotAfter records when the certificate of the certificate bundle expires in its status.
+func setBundleNotAfter(cd *hivev1.ClusterDeployment, name string, notAfter *time.Time) {
+	var t *metav1.Time
+	if notAfter != nil {
+		t = &metav1.Time{Time: *notAfter}
+	}
+	for i := range cd.Status.CertificateBundles {
+		if cd.Status.CertificateBundles[i].Name == name {
+			cd.Status.CertificateBundles[i].NotAfter = t
+			return
+		}
+	}
+	if t != nil {
+		cd.Status.CertificateBundles = append(cd.Status.CertificateBundles, hivev1.CertificateBundleStatus{Name: name, NotAfter: t})
+	}
+}
+
+func (r *ReconcileCertificateExpiry) setExpiringCondition(cd *hivev1.ClusterDeployment, certs []certificate) {
+	now := r.nowFn()
+	var expired, expiring []string
+	for _, cert := range certs {
+		switch {
+		case !now.Before(cert.notAfter):
+			expired = append(expired, fmt.Sprintf("%s expired at %s", cert, cert.notAfter.UTC().Format(time.RFC3339)))
+		case cert.notAfter.Sub(now) <= r.warningWindow:
+			expiring = append(expiring, fmt.Sprintf("%s expires at %s", cert, cert.notAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+	status := corev1.ConditionTrue
+	reason := certificatesExpiringReason
+	message := ""
+	updateCheck := controllerutils.UpdateConditionIfReasonOrMessageChange
+	switch {
+	case len(expired) > 0:
+		reason = certificatesExpiredReason
+		message = strings.Join(append(expired, expiring...), "; ")
+	case len(expiring) > 0:
+		message = strings.Join(expiring, "; ")
+	default:
+		status = corev1.ConditionFalse
+		reason = certificatesValidReason
+		message = fmt.Sprintf("No certificates expire within %s", r.warningWindow)
+		updateCheck = controllerutils.UpdateConditionNever
+	}
+	cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.CertificateExpiringClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		updateCheck)
+}
+
+// requeueAfter returns when the certificates must be checked again, which is at the latest after
+// expiryCheckInterval, or earlier if a certificate enters the warning window or expires before that.
+func (r *ReconcileCertificateExpiry) requeueAfter(certs []certificate) time.Duration {
+	now := r.nowFn()
+	var deadlines []time.Duration
+	for _, cert := range certs {
+		deadlines = append(deadlines, cert.notAfter.Add(-r.warningWindow).Sub(now), cert.notAfter.Sub(now))
+	}
+	sort.Slice(deadlines, func(i, j int) bool { return deadlines[i] < deadlines[j] })
+	for _, d := range deadlines {
+		if d > 0 && d < expiryCheckInterval {
+			return d
+		}
+	}
+	return expiryCheckInterval
+}
@@ -0,0 +1,299 @@
+package certificateexpiry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	testNS           = "test-namespace"
+	kubeconfigSecret = "test-cd-admin-kubeconfig"
+	bundleSecret     = "test-cd-certs"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	now := time.Now().Truncate(time.Second)
+	key := client.ObjectKey{Name: "test-cd", Namespace: testNS}
+	cdBuilder := testcd.FullBuilder(testNS, "test-cd", scheme).Options(
+		testcd.Installed(),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: kubeconfigSecret},
+			}
+			cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{{
+				Name:                 "default",
+				CertificateSecretRef: corev1.LocalObjectReference{Name: bundleSecret},
+			}}
+		},
+	)
+	withConditions := testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+		Status: corev1.ConditionUnknown,
+		Type:   hivev1.CertificateExpiringClusterDeploymentCondition,
+	})
+	withBundleStatus := func(status hivev1.CertificateBundleStatus) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.CertificateBundles = append(cd.Status.CertificateBundles, status)
+		}
+	}
+	kubeconfigSecretObj := func(notAfter time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: kubeconfigSecret},
+			Data: map[string][]byte{
+				constants.KubeconfigSecretKey: testKubeconfig(t, testCertificate(t, notAfter)),
+			},
+		}
+	}
+	bundleSecretObj := func(notAfter time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: bundleSecret},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       testCertificate(t, notAfter),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		}
+	}
+	validUntil := now.Add(90 * 24 * time.Hour)
+
+	cases := []struct {
+		name              string
+		existing          []runtime.Object
+		expectNoCD        bool
+		expectConditions  bool
+		expectStatus      corev1.ConditionStatus
+		expectReason      string
+		expectBundles     map[string]*time.Time
+		expectGenerated   bool
+		expectMetrics     int
+		expectRequeue     time.Duration
+		expectMetricValue *time.Time
+	}{
+		{
+			name:       "no cluster deployment",
+			expectNoCD: true,
+		},
+		{
+			name:             "initialize conditions",
+			existing:         []runtime.Object{cdBuilder.Build()},
+			expectConditions: true,
+			// the reported series are left as is until the next reconcile
+			expectMetrics: 1,
+		},
+		{
+			name:     "deleted cluster deployment",
+			existing: []runtime.Object{cdBuilder.GenericOptions(generic.Deleted(), generic.WithFinalizer("test-finalizer")).Build(withConditions)},
+		},
+		{
+			name: "valid certificates",
+			existing: []runtime.Object{
+				cdBuilder.Build(withConditions),
+				kubeconfigSecretObj(validUntil),
+				bundleSecretObj(validUntil),
+			},
+			expectStatus:      corev1.ConditionFalse,
+			expectReason:      certificatesValidReason,
+			expectBundles:     map[string]*time.Time{"default": &validUntil},
+			expectMetrics:     2,
+			expectRequeue:     expiryCheckInterval,
+			expectMetricValue: &validUntil,
+		},
+		{
+			name: "bundle expiring",
+			existing: []runtime.Object{
+				cdBuilder.Build(withConditions, withBundleStatus(hivev1.CertificateBundleStatus{Name: "default", Generated: true})),
+				kubeconfigSecretObj(validUntil),
+				bundleSecretObj(now.Add(24 * time.Hour)),
+			},
+			expectStatus:    corev1.ConditionTrue,
+			expectReason:    certificatesExpiringReason,
+			expectBundles:   map[string]*time.Time{"default": timePtr(now.Add(24 * time.Hour))},
+			expectGenerated: true,
+			expectMetrics:   2,
+			expectRequeue:   expiryCheckInterval,
+		},
+		{
+			name: "admin kubeconfig expired",
+			existing: []runtime.Object{
+				cdBuilder.Build(withConditions),
+				kubeconfigSecretObj(now.Add(-time.Hour)),
+				bundleSecretObj(validUntil),
+			},
+			expectStatus:  corev1.ConditionTrue,
+			expectReason:  certificatesExpiredReason,
+			expectBundles: map[string]*time.Time{"default": &validUntil},
+			expectMetrics: 2,
+			expectRequeue: expiryCheckInterval,
+		},
+		{
+			name: "requeue when entering warning window",
+			existing: []runtime.Object{
+				cdBuilder.Build(withConditions),
+				bundleSecretObj(now.Add(defaultWarningWindow + 10*time.Minute)),
+			},
+			expectStatus:  corev1.ConditionFalse,
+			expectReason:  certificatesValidReason,
+			expectBundles: map[string]*time.Time{"default": timePtr(now.Add(defaultWarningWindow + 10*time.Minute))},
+			expectMetrics: 1,
+			expectRequeue: 10 * time.Minute,
+		},
+		{
+			name: "bundle secret missing",
+			existing: []runtime.Object{
+				cdBuilder.Build(withConditions,
+					withBundleStatus(hivev1.CertificateBundleStatus{Name: "default", Generated: true, NotAfter: &metav1.Time{Time: validUntil}})),
+				kubeconfigSecretObj(validUntil),
+			},
+			expectStatus:    corev1.ConditionFalse,
+			expectReason:    certificatesValidReason,
+			expectBundles:   map[string]*time.Time{"default": nil},
+			expectGenerated: true,
+			expectMetrics:   1,
+			expectRequeue:   expiryCheckInterval,
+		},
+		{
+			name: "bundle removed from spec",
+			existing: []runtime.Object{
+				cdBuilder.Build(withConditions,
+					withBundleStatus(hivev1.CertificateBundleStatus{Name: "removed", NotAfter: &metav1.Time{Time: validUntil}})),
+				bundleSecretObj(validUntil),
+			},
+			expectStatus:  corev1.ConditionFalse,
+			expectReason:  certificatesValidReason,
+			expectBundles: map[string]*time.Time{"default": &validUntil, "removed": nil},
+			expectMetrics: 1,
+			expectRequeue: expiryCheckInterval,
+		},
+		{
+			name: "not installed",
+			existing: []runtime.Object{
+				cdBuilder.Build(withConditions, func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false }),
+				kubeconfigSecretObj(now.Add(-time.Hour)),
+			},
+			expectStatus:  corev1.ConditionFalse,
+			expectReason:  certificatesValidReason,
+			expectBundles: map[string]*time.Time{},
+			expectRequeue: expiryCheckInterval,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			metricCertificateExpirySeconds.Reset()
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			r := &ReconcileCertificateExpiry{
+				Client:        fakeClient,
+				warningWindow: defaultWarningWindow,
+				metrics:       newCertificateMetrics(),
+				nowFn:         func() time.Time { return now },
+			}
+			// Report a stale series, which must be removed by the reconcile.
+			r.metrics.report(key, []certificate{{kind: certificateBundleCertificate, bundle: "stale", notAfter: now}})
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.Equal(t, tc.expectRequeue, result.RequeueAfter, "unexpected requeue")
+			assert.Equal(t, tc.expectMetrics, testutil.CollectAndCount(metricCertificateExpirySeconds), "unexpected number of metrics")
+			if tc.expectMetricValue != nil {
+				assert.Equal(t, float64(tc.expectMetricValue.Unix()),
+					testutil.ToFloat64(metricCertificateExpirySeconds.WithLabelValues("test-cd", testNS, string(certificateBundleCertificate), "default")),
+					"unexpected metric value")
+			}
+
+			if tc.expectNoCD {
+				return
+			}
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), key, cd))
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.CertificateExpiringClusterDeploymentCondition)
+			if tc.expectConditions {
+				require.NotNil(t, cond, "expected condition to be initialized")
+				assert.Equal(t, corev1.ConditionUnknown, cond.Status, "unexpected condition status")
+				return
+			}
+			if tc.expectStatus != "" {
+				require.NotNil(t, cond, "missing condition")
+				assert.Equal(t, tc.expectStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectReason, cond.Reason, "unexpected condition reason")
+			}
+			for name, notAfter := range tc.expectBundles {
+				var status *hivev1.CertificateBundleStatus
+				for i := range cd.Status.CertificateBundles {
+					if cd.Status.CertificateBundles[i].Name == name {
+						status = &cd.Status.CertificateBundles[i]
+					}
+				}
+				if notAfter == nil {
+					if status != nil {
+						assert.Nil(t, status.NotAfter, "unexpected notAfter for bundle %s", name)
+					}
+					continue
+				}
+				require.NotNil(t, status, "missing status for bundle %s", name)
+				require.NotNil(t, status.NotAfter, "missing notAfter for bundle %s", name)
+				assert.True(t, notAfter.Equal(status.NotAfter.Time), "unexpected notAfter for bundle %s", name)
+				assert.Equal(t, tc.expectGenerated, status.Generated, "unexpected generated for bundle %s", name)
+			}
+			if len(tc.expectBundles) == 0 {
+				assert.Empty(t, cd.Status.CertificateBundles, "unexpected bundle statuses")
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func testCertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func testKubeconfig(t *testing.T, cert []byte) []byte {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["cluster"] = &clientcmdapi.Cluster{Server: "https://api.test-cd.example.com:6443"}
+	config.AuthInfos["admin"] = &clientcmdapi.AuthInfo{ClientCertificateData: cert, ClientKeyData: []byte("key")}
+	config.Contexts["admin"] = &clientcmdapi.Context{Cluster: "cluster", AuthInfo: "admin"}
+	config.CurrentContext = "admin"
+	data, err := clientcmd.Write(*config)
+	require.NoError(t, err)
+	return data
+}
@@ -0,0 +1,65 @@
+package certificateexpiry
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// metricCertificateExpirySeconds reports when the certificates of a ClusterDeployment expire. The bundle label
+	// is empty for the admin kubeconfig client certificate.
+	metricCertificateExpirySeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_certificate_expiry_seconds",
+		Help: "The time at which the certificate expires, in seconds since the Unix epoch.",
+	}, []string{"cluster_deployment", "namespace", "type", "bundle"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricCertificateExpirySeconds)
+}
+
+// certificateMetrics keeps track of the series reported for each ClusterDeployment, so that the series of
+// certificates that are gone, or of ClusterDeployments that are deleted, can be removed.
+type certificateMetrics struct {
+	mutex    sync.Mutex
+	reported map[types.NamespacedName][]prometheus.Labels
+}
+
+func newCertificateMetrics() *certificateMetrics {
+	return &certificateMetrics{reported: map[types.NamespacedName][]prometheus.Labels{}}
+}
+
+// report replaces the series of the ClusterDeployment with the given certificates.
+func (m *certificateMetrics) report(cd types.NamespacedName, certs []certificate) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.deleteLocked(cd)
+	for _, cert := range certs {
+		labels := prometheus.Labels{
+			"cluster_deployment": cd.Name,
+			"namespace":          cd.Namespace,
+			"type":               string(cert.kind),
+			"bundle":             cert.bundle,
+		}
+		metricCertificateExpirySeconds.With(labels).Set(float64(cert.notAfter.Unix()))
+		m.reported[cd] = append(m.reported[cd], labels)
+	}
+}
+
+// clear removes the series of the ClusterDeployment.
+func (m *certificateMetrics) clear(cd types.NamespacedName) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.deleteLocked(cd)
+}
+
+func (m *certificateMetrics) deleteLocked(cd types.NamespacedName) {
+	for _, labels := range m.reported[cd] {
+		metricCertificateExpirySeconds.Delete(labels)
+	}
+	delete(m.reported, cd)
+}
@@ -102,6 +102,7 @@ func TestReconcileClusterClaim(t *testing.T) {
 		expectNoFinalizer                      bool
 		expectAssignedClusterDeploymentDeleted bool
 		expectRBAC                             bool
+		expectedKubeconfigSecretName           string
 		expectHibernating                      bool
 		expectDeleted                          bool
 		expectedRequeueAfter                   *time.Duration
@@ -381,6 +382,40 @@ func TestReconcileClusterClaim(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "role grants the rotated kubeconfig after the installer kubeconfig is revoked",
+			claim: initializedClaimBuilder.Build(testclaim.WithCluster(clusterName)),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Status.AdminKubeconfigRotation = &hivev1.AdminKubeconfigRotationStatus{
+						KubeconfigSecretRef: corev1.LocalObjectReference{Name: "rotated-kubeconfig-secret"},
+					}
+				},
+			),
+			existing: []runtime.Object{
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim:         true,
+			expectRBAC:                   true,
+			expectedKubeconfigSecretName: "rotated-kubeconfig-secret",
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimPendingCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ClusterClaimed",
+					Message: "Cluster claimed",
+				},
+				{
+					Type:    hivev1.ClusterRunningCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
+			},
+		},
 		{
 			name:  "update existing rolebinding",
 			claim: initializedClaimBuilder.Build(testclaim.WithCluster(clusterName)),
@@ -707,6 +742,10 @@ func TestReconcileClusterClaim(t *testing.T) {
 			if test.expectRBAC {
 				assert.NoError(t, getRoleError, "unexpected error getting role")
 				assert.NoError(t, getRoleBindingError, "unexpected error getting role binding")
+				expectedKubeconfigSecretName := test.expectedKubeconfigSecretName
+				if expectedKubeconfigSecretName == "" {
+					expectedKubeconfigSecretName = kubeconfigSecretName
+				}
 				expectedRules := []rbacv1.PolicyRule{
 					{
 						APIGroups: []string{"hive.openshift.io"},
@@ -716,7 +755,7 @@ func TestReconcileClusterClaim(t *testing.T) {
 					{
 						APIGroups:     []string{""},
 						Resources:     []string{"secrets"},
-						ResourceNames: []string{kubeconfigSecretName, passwordSecretName},
+						ResourceNames: []string{expectedKubeconfigSecretName, passwordSecretName},
 						Verbs:         []string{"get"},
 					},
 				}
@@ -0,0 +1,251 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+const (
+	// cloudQuotaPreflightAnnotation is an annotation used on ClusterDeployments to check that the cloud quotas have
+	// room for the resources of the cluster before each install attempt.
+	cloudQuotaPreflightAnnotation = "hive.openshift.io/cloud-quota-preflight"
+
+	cloudQuotaExceededReason = "CloudQuotaExceeded"
+
+	// cloudQuotaPreflightInterval is how often the cloud quotas are checked again for a ClusterDeployment that is
+	// waiting for room in the cloud quotas.
+	cloudQuotaPreflightInterval = 10 * time.Minute
+
+	// defaultControlPlaneReplicas and defaultComputeReplicas are the replicas of the install config machine pools
+	// that do not set any.
+	defaultControlPlaneReplicas = 3
+	defaultComputeReplicas      = 3
+)
+
+// cloudQuotaShortage is a cloud quota that does not have room for the resources that an install would create.
+type cloudQuotaShortage struct {
+	// resource describes the resources limited by the quota.
+	resource string
+	// required is the number of resources that the install would create.
+	required int64
+	// usage is the number of resources already in use.
+	usage int64
+	// limit is the value of the quota.
+	limit int64
+}
+
+func (s cloudQuotaShortage) String() string {
+	return fmt.Sprintf("%s: %d required, %d of %d in use", s.resource, s.required, s.usage, s.limit)
+}
+
+// appendQuotaShortage appends a shortage to the shortages when the quota does not have room for the required
+// resources.
+func appendQuotaShortage(shortages []cloudQuotaShortage, resource string, required, usage, limit int64) []cloudQuotaShortage {
+	if required <= 0 || usage+required <= limit {
+		return shortages
+	}
+	return append(shortages, cloudQuotaShortage{resource: resource, required: required, usage: usage, limit: limit})
+}
+
+// installMachines are machines of the same instance type that an install creates.
+type installMachines struct {
+	instanceType string
+	replicas     int64
+	zones        []string
+}
+
+// installConfigMachines returns the machines that an install creates, counting the bootstrap machine as a control
+// plane machine. instanceType returns the instance type and zones of the platform machine pool of a machine pool, or
+// of the default machine platform when the machine pool is nil.
+func installConfigMachines(
+	ic *installertypes.InstallConfig,
+	instanceType func(*installertypes.MachinePool) (string, []string),
+	defaultControlPlaneType string,
+	defaultComputeType string,
+) []installMachines {
+	defaultType, defaultZones := instanceType(nil)
+	poolMachines := func(pool *installertypes.MachinePool, defaultReplicas int64, defaultPoolType string) installMachines {
+		machines := installMachines{instanceType: defaultType, replicas: defaultReplicas, zones: defaultZones}
+		if machines.instanceType == "" {
+			machines.instanceType = defaultPoolType
+		}
+		if pool == nil {
+			return machines
+		}
+		if pool.Replicas != nil {
+			machines.replicas = *pool.Replicas
+		}
+		poolType, zones := instanceType(pool)
+		if poolType != "" {
+			machines.instanceType = poolType
+		}
+		if len(zones) > 0 {
+			machines.zones = zones
+		}
+		return machines
+	}
+	controlPlane := poolMachines(ic.ControlPlane, defaultControlPlaneReplicas, defaultControlPlaneType)
+	controlPlane.replicas++
+	machines := []installMachines{controlPlane}
+	for i := range ic.Compute {
+		machines = append(machines, poolMachines(&ic.Compute[i], defaultComputeReplicas, defaultComputeType))
+	}
+	if len(ic.Compute) == 0 {
+		// The installer defaults to a single pool of compute machines.
+		machines = append(machines, poolMachines(nil, defaultComputeReplicas, defaultComputeType))
+	}
+	return machines
+}
+
+// installMachinesZones returns the zones of the machines, or nil if any of the machines uses the default zones of
+// the region.
+func installMachinesZones(machines []installMachines) []string {
+	var zones []string
+	seen := map[string]bool{}
+	for _, m := range machines {
+		if m.replicas == 0 {
+			continue
+		}
+		if len(m.zones) == 0 {
+			return nil
+		}
+		for _, zone := range m.zones {
+			if !seen[zone] {
+				seen[zone] = true
+				zones = append(zones, zone)
+			}
+		}
+	}
+	return zones
+}
+
+// checkCloudQuotas returns the cloud quotas that do not have room for the resources that installing the cluster
+// with the install config would create.
+func checkCloudQuotas(c client.Client, cd *hivev1.ClusterDeployment, installConfig []byte, logger log.FieldLogger) ([]cloudQuotaShortage, error) {
+	ic := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(installConfig, ic); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal install config")
+	}
+	switch platform := cd.Spec.Platform; {
+	case platform.AWS != nil && ic.Platform.AWS != nil:
+		awsClient, err := awsclient.New(c, awsclient.Options{
+			// The install config, with the override of the attempt applied, has the region to install the cluster in
+			Region: ic.Platform.AWS.Region,
+			CredentialsSource: awsclient.CredentialsSource{
+				Secret: &awsclient.SecretCredentialsSource{
+					Namespace: cd.Namespace,
+					Ref:       &platform.AWS.CredentialsSecretRef,
+				},
+				AssumeRole: &awsclient.AssumeRoleCredentialsSource{
+					SecretRef: corev1.SecretReference{
+						Name:      os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar),
+						Namespace: controllerutils.GetHiveNamespace(),
+					},
+					Role: platform.AWS.CredentialsAssumeRole,
+				},
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create AWS client")
+		}
+		return awsCloudQuotaShortages(awsClient, ic, logger)
+	case platform.GCP != nil && ic.Platform.GCP != nil:
+		secret := &corev1.Secret{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: platform.GCP.CredentialsSecretRef.Name}, secret); err != nil {
+			return nil, errors.Wrap(err, "could not get GCP credentials secret")
+		}
+		gcpClient, err := gcpclient.NewClientFromSecret(secret)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create GCP client")
+		}
+		return gcpCloudQuotaShortages(gcpClient, ic, logger)
+	case platform.Azure != nil && ic.Platform.Azure != nil:
+		secret := &corev1.Secret{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: platform.Azure.CredentialsSecretRef.Name}, secret); err != nil {
+			return nil, errors.Wrap(err, "could not get Azure credentials secret")
+		}
+		azureClient, err := azureclient.NewClientFromSecret(secret, platform.Azure.CloudName.Name())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create Azure client")
+		}
+		return azureCloudQuotaShortages(azureClient, ic, logger)
+	}
+	logger.Debug("cloud quota preflight is not supported for the platform")
+	return nil, nil
+}
+
+// reconcileCloudQuotaPreflight checks the cloud quotas before starting a new provision of a ClusterDeployment with
+// the cloud quota preflight annotation. It returns a result when the ClusterDeployment has to wait for room in the
+// cloud quotas. Failures to check the quotas do not hold back the provision.
+func (r *ReconcileClusterDeployment) reconcileCloudQuotaPreflight(cd *hivev1.ClusterDeployment, installConfig []byte, cdLog log.FieldLogger) (*reconcile.Result, error) {
+	if cd.Annotations[cloudQuotaPreflightAnnotation] != "true" {
+		return nil, nil
+	}
+	if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.RequirementsMetCondition); cond != nil &&
+		cond.Status == corev1.ConditionFalse && cond.Reason == cloudQuotaExceededReason {
+		if wait := cloudQuotaPreflightInterval - time.Since(cond.LastProbeTime.Time); wait > 0 {
+			cdLog.Debug("waiting to check the cloud quotas again")
+			return &reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	if override := installConfigOverrideForAttempt(cd.Spec.ProvisionRetryPolicy, cd.Status.InstallRestarts); override != nil {
+		var err error
+		if installConfig, err = applyInstallConfigOverride(installConfig, override); err != nil {
+			cdLog.WithError(err).Warn("could not apply install config override, skipping cloud quota preflight")
+			return nil, nil
+		}
+	}
+
+	shortages, err := r.checkCloudQuotas(r.Client, cd, installConfig, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Warn("could not check cloud quotas, provisioning anyway")
+		return nil, nil
+	}
+	if len(shortages) == 0 {
+		cdLog.Debug("cloud quotas have room for the cluster")
+		return nil, nil
+	}
+
+	messages := make([]string, len(shortages))
+	for i, shortage := range shortages {
+		messages[i] = shortage.String()
+	}
+	message := fmt.Sprintf("Cloud quotas do not have room for the cluster: %s", strings.Join(messages, "; "))
+	cdLog.WithField("shortages", messages).Info("waiting for room in the cloud quotas")
+	// Always update the condition to record when the quotas were checked.
+	conditions, _ := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.RequirementsMetCondition,
+		corev1.ConditionFalse,
+		cloudQuotaExceededReason,
+		message,
+		controllerutils.UpdateConditionAlways)
+	cd.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+		return nil, err
+	}
+	return &reconcile.Result{RequeueAfter: cloudQuotaPreflightInterval}, nil
+}
@@ -0,0 +1,328 @@
+package clusterdeployment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	"github.com/openshift/hive/pkg/awsclient"
+)
+
+const (
+	// defaultAWSInstanceType is the instance type assumed for the machine pools of install configs that do not set
+	// any.
+	defaultAWSInstanceType = "m5.xlarge"
+
+	// awsStandardInstanceFamilies are the first letters of the instance families limited by the quota of running
+	// On-Demand Standard instances.
+	awsStandardInstanceFamilies = "acdhimrtz"
+)
+
+// awsServiceQuota identifies an AWS service quota.
+type awsServiceQuota struct {
+	serviceCode string
+	quotaCode   string
+	resource    string
+}
+
+var (
+	awsStandardVCPUsQuota        = awsServiceQuota{serviceCode: "ec2", quotaCode: "L-1216C47A", resource: "vCPUs of running On-Demand Standard instances"}
+	awsElasticIPsQuota           = awsServiceQuota{serviceCode: "ec2", quotaCode: "L-0263D0A3", resource: "Elastic IPs"}
+	awsVPCsQuota                 = awsServiceQuota{serviceCode: "vpc", quotaCode: "L-F678F1CE", resource: "VPCs"}
+	awsNATGatewaysQuota          = awsServiceQuota{serviceCode: "vpc", quotaCode: "L-FE5A380F", resource: "NAT gateways in"}
+	awsNetworkLoadBalancersQuota = awsServiceQuota{serviceCode: "elasticloadbalancing", quotaCode: "L-69A177A2", resource: "Network Load Balancers"}
+)
+
+// awsCloudQuotaShortages returns the AWS service quotas of the region that do not have room for the instances, VPC,
+// Elastic IPs, NAT gateways and load balancers that installing the cluster would create. Quotas that cannot be read
+// are skipped.
+func awsCloudQuotaShortages(awsClient awsclient.Client, ic *installertypes.InstallConfig, logger log.FieldLogger) ([]cloudQuotaShortage, error) {
+	machines := installConfigMachines(ic, func(pool *installertypes.MachinePool) (string, []string) {
+		platform := ic.Platform.AWS.DefaultMachinePlatform
+		if pool != nil {
+			platform = pool.Platform.AWS
+		}
+		if platform == nil {
+			return "", nil
+		}
+		return platform.InstanceType, platform.Zones
+	}, defaultAWSInstanceType, defaultAWSInstanceType)
+
+	var shortages []cloudQuotaShortage
+	check := func(quota awsServiceQuota, required int64, usage func() (int64, error)) error {
+		if required <= 0 {
+			return nil
+		}
+		limit, ok, err := awsServiceQuotaValue(awsClient, quota, logger)
+		if err != nil || !ok {
+			return err
+		}
+		used, err := usage()
+		if err != nil {
+			return err
+		}
+		shortages = appendQuotaShortage(shortages, quota.resource, required, used, limit)
+		return nil
+	}
+
+	requiredVCPUs, err := awsRequiredStandardVCPUs(awsClient, machines)
+	if err != nil {
+		return nil, err
+	}
+	if err := check(awsStandardVCPUsQuota, requiredVCPUs, func() (int64, error) { return awsStandardVCPUsUsage(awsClient) }); err != nil {
+		return nil, err
+	}
+
+	// The installer only creates the VPC and its NAT gateways when the install does not use existing subnets.
+	if len(ic.Platform.AWS.Subnets) == 0 {
+		zones := installMachinesZones(machines)
+		if zones == nil {
+			if zones, err = awsAvailabilityZones(awsClient); err != nil {
+				return nil, err
+			}
+		}
+		if err := check(awsVPCsQuota, 1, func() (int64, error) { return awsVPCsUsage(awsClient) }); err != nil {
+			return nil, err
+		}
+		// Each NAT gateway has an Elastic IP.
+		if err := check(awsElasticIPsQuota, int64(len(zones)), func() (int64, error) { return awsElasticIPsUsage(awsClient) }); err != nil {
+			return nil, err
+		}
+		limit, ok, err := awsServiceQuotaValue(awsClient, awsNATGatewaysQuota, logger)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			usage, err := awsNATGatewaysUsage(awsClient)
+			if err != nil {
+				return nil, err
+			}
+			for _, zone := range zones {
+				shortages = appendQuotaShortage(shortages, fmt.Sprintf("%s %s", awsNATGatewaysQuota.resource, zone), 1, usage[zone], limit)
+			}
+		}
+	}
+
+	// The installer creates an external and an internal load balancer for the API, or only the internal one for
+	// private clusters.
+	requiredLoadBalancers := int64(2)
+	if ic.Publish == installertypes.InternalPublishingStrategy {
+		requiredLoadBalancers = 1
+	}
+	if err := check(awsNetworkLoadBalancersQuota, requiredLoadBalancers, func() (int64, error) { return awsNetworkLoadBalancersUsage(awsClient) }); err != nil {
+		return nil, err
+	}
+	return shortages, nil
+}
+
+// awsServiceQuotaValue returns the value of the service quota, falling back to the default value of quotas that
+// have not been changed for the account. It returns false when the quota cannot be read.
+func awsServiceQuotaValue(awsClient awsclient.Client, quota awsServiceQuota, logger log.FieldLogger) (int64, bool, error) {
+	quotaLog := logger.WithField("quotaCode", quota.quotaCode)
+	fail := func(err error) (int64, bool, error) {
+		if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == servicequotas.ErrCodeAccessDeniedException ||
+			awsErr.Code() == servicequotas.ErrCodeNoSuchResourceException) {
+			quotaLog.WithError(err).Warn("could not read service quota, skipping it")
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrapf(err, "could not get service quota %s", quota.quotaCode)
+	}
+
+	var value *servicequotas.ServiceQuota
+	output, err := awsClient.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(quota.serviceCode),
+		QuotaCode:   aws.String(quota.quotaCode),
+	})
+	switch awsErr, ok := err.(awserr.Error); {
+	case ok && awsErr.Code() == servicequotas.ErrCodeNoSuchResourceException:
+		// Quotas that were never changed for the account only have their default value.
+		defaultOutput, err := awsClient.GetAWSDefaultServiceQuota(&servicequotas.GetAWSDefaultServiceQuotaInput{
+			ServiceCode: aws.String(quota.serviceCode),
+			QuotaCode:   aws.String(quota.quotaCode),
+		})
+		if err != nil {
+			return fail(err)
+		}
+		value = defaultOutput.Quota
+	case err != nil:
+		return fail(err)
+	default:
+		value = output.Quota
+	}
+	if value == nil || value.Value == nil {
+		quotaLog.Warn("service quota has no value, skipping it")
+		return 0, false, nil
+	}
+	return int64(*value.Value), true, nil
+}
+
+func awsIsStandardInstanceType(instanceType string) bool {
+	return instanceType != "" && strings.ContainsRune(awsStandardInstanceFamilies, rune(instanceType[0]))
+}
+
+// awsRequiredStandardVCPUs returns the number of vCPUs of the machines of standard instance types.
+func awsRequiredStandardVCPUs(awsClient awsclient.Client, machines []installMachines) (int64, error) {
+	var instanceTypes []string
+	for _, m := range machines {
+		if m.replicas > 0 && awsIsStandardInstanceType(m.instanceType) {
+			instanceTypes = append(instanceTypes, m.instanceType)
+		}
+	}
+	if len(instanceTypes) == 0 {
+		return 0, nil
+	}
+	output, err := awsClient.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: aws.StringSlice(instanceTypes),
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "could not describe instance types")
+	}
+	vCPUs := map[string]int64{}
+	for _, info := range output.InstanceTypes {
+		if info.VCpuInfo != nil {
+			vCPUs[aws.StringValue(info.InstanceType)] = aws.Int64Value(info.VCpuInfo.DefaultVCpus)
+		}
+	}
+	var required int64
+	for _, m := range machines {
+		if awsIsStandardInstanceType(m.instanceType) {
+			required += m.replicas * vCPUs[m.instanceType]
+		}
+	}
+	return required, nil
+}
+
+// awsStandardVCPUsUsage returns the number of vCPUs of the pending and running On-Demand instances of standard
+// instance types.
+func awsStandardVCPUsUsage(awsClient awsclient.Client) (int64, error) {
+	var usage int64
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{"pending", "running"})}},
+	}
+	for {
+		output, err := awsClient.DescribeInstances(input)
+		if err != nil {
+			return 0, errors.Wrap(err, "could not describe instances")
+		}
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				if aws.StringValue(instance.InstanceLifecycle) == "spot" ||
+					!awsIsStandardInstanceType(aws.StringValue(instance.InstanceType)) ||
+					instance.CpuOptions == nil {
+					continue
+				}
+				usage += aws.Int64Value(instance.CpuOptions.CoreCount) * aws.Int64Value(instance.CpuOptions.ThreadsPerCore)
+			}
+		}
+		if aws.StringValue(output.NextToken) == "" {
+			return usage, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+func awsAvailabilityZones(awsClient awsclient.Client) ([]string, error) {
+	output, err := awsClient.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("zone-type"), Values: aws.StringSlice([]string{"availability-zone"})}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not describe availability zones")
+	}
+	zones := make([]string, len(output.AvailabilityZones))
+	for i, zone := range output.AvailabilityZones {
+		zones[i] = aws.StringValue(zone.ZoneName)
+	}
+	return zones, nil
+}
+
+func awsVPCsUsage(awsClient awsclient.Client) (int64, error) {
+	var usage int64
+	input := &ec2.DescribeVpcsInput{}
+	for {
+		output, err := awsClient.DescribeVpcs(input)
+		if err != nil {
+			return 0, errors.Wrap(err, "could not describe VPCs")
+		}
+		usage += int64(len(output.Vpcs))
+		if aws.StringValue(output.NextToken) == "" {
+			return usage, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+func awsElasticIPsUsage(awsClient awsclient.Client) (int64, error) {
+	output, err := awsClient.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("domain"), Values: aws.StringSlice([]string{"vpc"})}},
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "could not describe Elastic IPs")
+	}
+	return int64(len(output.Addresses)), nil
+}
+
+// awsNATGatewaysUsage returns the number of pending and available NAT gateways per availability zone.
+func awsNATGatewaysUsage(awsClient awsclient.Client) (map[string]int64, error) {
+	var subnetIDs []string
+	input := &ec2.DescribeNatGatewaysInput{
+		Filter: []*ec2.Filter{{Name: aws.String("state"), Values: aws.StringSlice([]string{"pending", "available"})}},
+	}
+	for {
+		output, err := awsClient.DescribeNatGateways(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not describe NAT gateways")
+		}
+		for _, natGateway := range output.NatGateways {
+			subnetIDs = append(subnetIDs, aws.StringValue(natGateway.SubnetId))
+		}
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	usage := map[string]int64{}
+	if len(subnetIDs) == 0 {
+		return usage, nil
+	}
+	output, err := awsClient.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice(subnetIDs)})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not describe subnets of NAT gateways")
+	}
+	subnetZones := map[string]string{}
+	for _, subnet := range output.Subnets {
+		subnetZones[aws.StringValue(subnet.SubnetId)] = aws.StringValue(subnet.AvailabilityZone)
+	}
+	for _, subnetID := range subnetIDs {
+		usage[subnetZones[subnetID]]++
+	}
+	return usage, nil
+}
+
+func awsNetworkLoadBalancersUsage(awsClient awsclient.Client) (int64, error) {
+	var usage int64
+	input := &elbv2.DescribeLoadBalancersInput{}
+	for {
+		output, err := awsClient.DescribeLoadBalancers(input)
+		if err != nil {
+			return 0, errors.Wrap(err, "could not describe load balancers")
+		}
+		for _, lb := range output.LoadBalancers {
+			if aws.StringValue(lb.Type) == elbv2.LoadBalancerTypeEnumNetwork {
+				usage++
+			}
+		}
+		if aws.StringValue(output.NextMarker) == "" {
+			return usage, nil
+		}
+		input.Marker = output.NextMarker
+	}
+}
@@ -0,0 +1,145 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	"github.com/openshift/hive/pkg/azureclient"
+)
+
+const (
+	// defaultAzureControlPlaneInstanceType and defaultAzureComputeInstanceType are the VM sizes assumed for the
+	// machine pools of install configs that do not set any.
+	defaultAzureControlPlaneInstanceType = "Standard_D8s_v3"
+	defaultAzureComputeInstanceType      = "Standard_D4s_v3"
+
+	// azureTotalCoresUsage is the name of the compute usage of the total regional vCPUs.
+	azureTotalCoresUsage = "cores"
+)
+
+// azureVMSize is the vCPUs and family of a VM size.
+type azureVMSize struct {
+	vCPUs  int64
+	family string
+}
+
+// azureCloudQuotaShortages returns the Azure usage limits of the region that do not have room for the virtual
+// machines, virtual network, public IP addresses and load balancers that installing the cluster would create.
+func azureCloudQuotaShortages(azureClient azureclient.Client, ic *installertypes.InstallConfig, logger log.FieldLogger) ([]cloudQuotaShortage, error) {
+	machines := installConfigMachines(ic, func(pool *installertypes.MachinePool) (string, []string) {
+		platform := ic.Platform.Azure.DefaultMachinePlatform
+		if pool != nil {
+			platform = pool.Platform.Azure
+		}
+		if platform == nil {
+			return "", nil
+		}
+		return platform.InstanceType, platform.Zones
+	}, defaultAzureControlPlaneInstanceType, defaultAzureComputeInstanceType)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	location := ic.Platform.Azure.Region
+
+	sizes, err := azureVMSizes(ctx, azureClient, location)
+	if err != nil {
+		return nil, err
+	}
+	requiredCores := map[string]int64{}
+	for _, m := range machines {
+		if m.replicas == 0 {
+			continue
+		}
+		size, ok := sizes[strings.ToLower(m.instanceType)]
+		if !ok {
+			return nil, errors.Errorf("VM size %s is not available in %s", m.instanceType, location)
+		}
+		requiredCores[azureTotalCoresUsage] += m.replicas * size.vCPUs
+		requiredCores[size.family] += m.replicas * size.vCPUs
+	}
+
+	var shortages []cloudQuotaShortage
+	var page azureclient.ComputeUsagesPage
+	for page, err = azureClient.ListComputeUsages(ctx, location); err == nil && page.NotDone(); err = page.NextWithContext(ctx) {
+		for _, usage := range page.Values() {
+			if usage.Name == nil {
+				continue
+			}
+			shortages = appendQuotaShortage(shortages, azureUsageResource(usage.Name.Value, usage.Name.LocalizedValue),
+				requiredCores[to.String(usage.Name.Value)], int64(to.Int32(usage.CurrentValue)), to.Int64(usage.Limit))
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list compute usages")
+	}
+
+	// The installer creates an external and an internal load balancer, with a public IP address for outbound
+	// traffic and another one for the API of clusters that are not private. It only creates the virtual network
+	// when the install does not use an existing one.
+	requiredNetwork := map[string]int64{
+		"LoadBalancers":     2,
+		"PublicIPAddresses": 2,
+	}
+	if ic.Publish == installertypes.InternalPublishingStrategy {
+		requiredNetwork["PublicIPAddresses"] = 1
+	}
+	if ic.Platform.Azure.VirtualNetwork == "" {
+		requiredNetwork["VirtualNetworks"] = 1
+	}
+	var networkPage azureclient.NetworkUsagesPage
+	for networkPage, err = azureClient.ListNetworkUsages(ctx, location); err == nil && networkPage.NotDone(); err = networkPage.NextWithContext(ctx) {
+		for _, usage := range networkPage.Values() {
+			if usage.Name == nil {
+				continue
+			}
+			shortages = appendQuotaShortage(shortages, azureUsageResource(usage.Name.Value, usage.Name.LocalizedValue),
+				requiredNetwork[to.String(usage.Name.Value)], to.Int64(usage.CurrentValue), to.Int64(usage.Limit))
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list network usages")
+	}
+	return shortages, nil
+}
+
+// azureVMSizes returns the VM sizes available in the location, keyed by their lowercase names.
+func azureVMSizes(ctx context.Context, azureClient azureclient.Client, location string) (map[string]azureVMSize, error) {
+	sizes := map[string]azureVMSize{}
+	var page azureclient.ResourceSKUsPage
+	var err error
+	for page, err = azureClient.ListResourceSKUs(ctx, fmt.Sprintf("location eq '%s'", location)); err == nil && page.NotDone(); err = page.NextWithContext(ctx) {
+		for _, sku := range page.Values() {
+			if to.String(sku.ResourceType) != "virtualMachines" || sku.Capabilities == nil {
+				continue
+			}
+			size := azureVMSize{family: to.String(sku.Family)}
+			for _, capability := range *sku.Capabilities {
+				if to.String(capability.Name) == "vCPUs" {
+					size.vCPUs, _ = strconv.ParseInt(to.String(capability.Value), 10, 64)
+				}
+			}
+			sizes[strings.ToLower(to.String(sku.Name))] = size
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list resource SKUs")
+	}
+	return sizes, nil
+}
+
+// azureUsageResource returns the localized name of a usage, falling back to its name.
+func azureUsageResource(name, localizedName *string) string {
+	if localizedName != nil && *localizedName != "" {
+		return *localizedName
+	}
+	return to.String(name)
+}
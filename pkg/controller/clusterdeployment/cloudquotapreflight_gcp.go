@@ -0,0 +1,104 @@
+package clusterdeployment
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+// defaultGCPInstanceType is the machine type assumed for the machine pools of install configs that do not set any.
+const defaultGCPInstanceType = "n1-standard-4"
+
+// gcpCloudQuotaShortages returns the GCP quotas of the region and project that do not have room for the instances,
+// network, router, addresses and forwarding rules that installing the cluster would create.
+func gcpCloudQuotaShortages(gcpClient gcpclient.Client, ic *installertypes.InstallConfig, logger log.FieldLogger) ([]cloudQuotaShortage, error) {
+	machines := installConfigMachines(ic, func(pool *installertypes.MachinePool) (string, []string) {
+		platform := ic.Platform.GCP.DefaultMachinePlatform
+		if pool != nil {
+			platform = pool.Platform.GCP
+		}
+		if platform == nil {
+			return "", nil
+		}
+		return platform.InstanceType, platform.Zones
+	}, defaultGCPInstanceType, defaultGCPInstanceType)
+
+	region, err := gcpClient.GetComputeRegion(ic.Platform.GCP.Region)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get compute region")
+	}
+	project, err := gcpClient.GetComputeProject()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get compute project")
+	}
+
+	requiredCPUs, err := gcpRequiredCPUs(gcpClient, machines, region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The installer creates an external and an internal forwarding rule for the API, or only the internal one for
+	// private clusters. The external one uses a static address.
+	requiredForwardingRules, requiredAddresses := int64(2), int64(1)
+	if ic.Publish == installertypes.InternalPublishingStrategy {
+		requiredForwardingRules, requiredAddresses = 1, 0
+	}
+	// The installer only creates the network and its router when the install does not use an existing network.
+	var requiredNetworks int64
+	if ic.Platform.GCP.Network == "" {
+		requiredNetworks = 1
+	}
+
+	var shortages []cloudQuotaShortage
+	check := func(quotas []*compute.Quota, metric, resource string, required int64) {
+		for _, quota := range quotas {
+			if quota.Metric == metric {
+				shortages = appendQuotaShortage(shortages, resource, required, int64(quota.Usage), int64(quota.Limit))
+				return
+			}
+		}
+		logger.WithField("metric", metric).Warn("quota not found, skipping it")
+	}
+	check(region.Quotas, "CPUS", "vCPUs", requiredCPUs)
+	check(region.Quotas, "STATIC_ADDRESSES", "static addresses", requiredAddresses)
+	check(project.Quotas, "NETWORKS", "networks", requiredNetworks)
+	check(project.Quotas, "ROUTERS", "routers", requiredNetworks)
+	check(project.Quotas, "FORWARDING_RULES", "forwarding rules", requiredForwardingRules)
+	return shortages, nil
+}
+
+// gcpRequiredCPUs returns the number of vCPUs of the machines.
+func gcpRequiredCPUs(gcpClient gcpclient.Client, machines []installMachines, region *compute.Region) (int64, error) {
+	cpus := map[string]int64{}
+	var required int64
+	for _, m := range machines {
+		if m.replicas == 0 {
+			continue
+		}
+		if _, ok := cpus[m.instanceType]; !ok {
+			// Machine types are zonal resources, any zone where the machines can run has them.
+			var zone string
+			switch {
+			case len(m.zones) > 0:
+				zone = m.zones[0]
+			case len(region.Zones) > 0:
+				zone = path.Base(region.Zones[0])
+			default:
+				return 0, errors.Errorf("region %s has no zones", region.Name)
+			}
+			machineType, err := gcpClient.GetComputeMachineType(zone, m.instanceType)
+			if err != nil {
+				return 0, errors.Wrapf(err, "could not get machine type %s", m.instanceType)
+			}
+			cpus[m.instanceType] = machineType.GuestCpus
+		}
+		required += m.replicas * cpus[m.instanceType]
+	}
+	return required, nil
+}
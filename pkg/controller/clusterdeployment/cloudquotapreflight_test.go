@@ -0,0 +1,275 @@
+package clusterdeployment
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+
+	"k8s.io/utils/pointer"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+	installeraws "github.com/openshift/installer/pkg/types/aws"
+	installergcp "github.com/openshift/installer/pkg/types/gcp"
+
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+)
+
+func TestAWSCloudQuotaShortages(t *testing.T) {
+	tests := []struct {
+		name              string
+		installConfig     func(*installertypes.InstallConfig)
+		quotas            map[string]float64
+		defaultQuotas     map[string]float64
+		vpcs              int
+		elasticIPs        int
+		natGateways       map[string]int
+		loadBalancers     int
+		expectedShortages []cloudQuotaShortage
+	}{
+		{
+			name:          "quotas have room",
+			quotas:        map[string]float64{"L-1216C47A": 64, "L-0263D0A3": 5, "L-F678F1CE": 5, "L-FE5A380F": 5, "L-69A177A2": 50},
+			vpcs:          1,
+			elasticIPs:    1,
+			natGateways:   map[string]int{"us-east-1a": 1},
+			loadBalancers: 2,
+		},
+		{
+			name:          "quotas exceeded",
+			quotas:        map[string]float64{"L-1216C47A": 32, "L-0263D0A3": 5, "L-F678F1CE": 5, "L-FE5A380F": 2, "L-69A177A2": 50},
+			vpcs:          5,
+			elasticIPs:    4,
+			natGateways:   map[string]int{"us-east-1a": 1, "us-east-1b": 2},
+			loadBalancers: 2,
+			expectedShortages: []cloudQuotaShortage{
+				{resource: "vCPUs of running On-Demand Standard instances", required: 22, usage: 12, limit: 32},
+				{resource: "VPCs", required: 1, usage: 5, limit: 5},
+				{resource: "Elastic IPs", required: 2, usage: 4, limit: 5},
+				{resource: "NAT gateways in us-east-1b", required: 1, usage: 2, limit: 2},
+			},
+		},
+		{
+			name: "existing subnets",
+			installConfig: func(ic *installertypes.InstallConfig) {
+				ic.Platform.AWS.Subnets = []string{"subnet-a", "subnet-b"}
+				ic.Publish = installertypes.InternalPublishingStrategy
+			},
+			quotas:        map[string]float64{"L-1216C47A": 64},
+			defaultQuotas: map[string]float64{"L-69A177A2": 2},
+			loadBalancers: 2,
+			expectedShortages: []cloudQuotaShortage{
+				{resource: "Network Load Balancers", required: 1, usage: 2, limit: 2},
+			},
+		},
+		{
+			name:          "unreadable quotas are skipped",
+			quotas:        map[string]float64{},
+			defaultQuotas: map[string]float64{},
+			natGateways:   map[string]int{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			awsClient := mockaws.NewMockClient(mockCtrl)
+
+			awsClient.EXPECT().GetServiceQuota(gomock.Any()).DoAndReturn(
+				func(input *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error) {
+					value, ok := test.quotas[aws.StringValue(input.QuotaCode)]
+					if !ok {
+						return nil, awserr.New(servicequotas.ErrCodeNoSuchResourceException, "no such quota", nil)
+					}
+					return &servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(value)}}, nil
+				}).AnyTimes()
+			awsClient.EXPECT().GetAWSDefaultServiceQuota(gomock.Any()).DoAndReturn(
+				func(input *servicequotas.GetAWSDefaultServiceQuotaInput) (*servicequotas.GetAWSDefaultServiceQuotaOutput, error) {
+					value, ok := test.defaultQuotas[aws.StringValue(input.QuotaCode)]
+					if !ok {
+						return nil, awserr.New(servicequotas.ErrCodeAccessDeniedException, "access denied", nil)
+					}
+					return &servicequotas.GetAWSDefaultServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(value)}}, nil
+				}).AnyTimes()
+			awsClient.EXPECT().DescribeInstanceTypes(gomock.Any()).Return(&ec2.DescribeInstanceTypesOutput{
+				InstanceTypes: []*ec2.InstanceTypeInfo{
+					{InstanceType: aws.String("m5.xlarge"), VCpuInfo: &ec2.VCpuInfo{DefaultVCpus: aws.Int64(4)}},
+					{InstanceType: aws.String("m5.large"), VCpuInfo: &ec2.VCpuInfo{DefaultVCpus: aws.Int64(2)}},
+				},
+			}, nil)
+			awsClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{{
+					Instances: []*ec2.Instance{
+						testAWSInstance("m5.xlarge", 2, 2, ""),
+						testAWSInstance("m5.2xlarge", 4, 2, ""),
+						testAWSInstance("m5.2xlarge", 4, 2, "spot"),
+						testAWSInstance("p3.2xlarge", 4, 2, ""),
+					},
+				}},
+			}, nil).AnyTimes()
+			awsClient.EXPECT().DescribeVpcs(gomock.Any()).Return(&ec2.DescribeVpcsOutput{Vpcs: make([]*ec2.Vpc, test.vpcs)}, nil).AnyTimes()
+			awsClient.EXPECT().DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{Addresses: make([]*ec2.Address, test.elasticIPs)}, nil).AnyTimes()
+			if test.natGateways != nil {
+				natGatewaysOutput := &ec2.DescribeNatGatewaysOutput{}
+				subnetsOutput := &ec2.DescribeSubnetsOutput{}
+				for zone, count := range test.natGateways {
+					subnetID := aws.String("subnet-" + zone)
+					for i := 0; i < count; i++ {
+						natGatewaysOutput.NatGateways = append(natGatewaysOutput.NatGateways, &ec2.NatGateway{SubnetId: subnetID})
+					}
+					subnetsOutput.Subnets = append(subnetsOutput.Subnets, &ec2.Subnet{SubnetId: subnetID, AvailabilityZone: aws.String(zone)})
+				}
+				awsClient.EXPECT().DescribeNatGateways(gomock.Any()).Return(natGatewaysOutput, nil).AnyTimes()
+				awsClient.EXPECT().DescribeSubnets(gomock.Any()).Return(subnetsOutput, nil).AnyTimes()
+			}
+			loadBalancersOutput := &elbv2.DescribeLoadBalancersOutput{
+				LoadBalancers: []*elbv2.LoadBalancer{{Type: aws.String(elbv2.LoadBalancerTypeEnumApplication)}},
+			}
+			for i := 0; i < test.loadBalancers; i++ {
+				loadBalancersOutput.LoadBalancers = append(loadBalancersOutput.LoadBalancers,
+					&elbv2.LoadBalancer{Type: aws.String(elbv2.LoadBalancerTypeEnumNetwork)})
+			}
+			awsClient.EXPECT().DescribeLoadBalancers(gomock.Any()).Return(loadBalancersOutput, nil).AnyTimes()
+
+			ic := testAWSQuotaInstallConfig()
+			if test.installConfig != nil {
+				test.installConfig(ic)
+			}
+			shortages, err := awsCloudQuotaShortages(awsClient, ic, log.WithField("test", test.name))
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedShortages, shortages, "unexpected shortages")
+		})
+	}
+}
+
+func TestGCPCloudQuotaShortages(t *testing.T) {
+	tests := []struct {
+		name              string
+		installConfig     func(*installertypes.InstallConfig)
+		regionQuotas      []*compute.Quota
+		projectQuotas     []*compute.Quota
+		expectedShortages []cloudQuotaShortage
+	}{
+		{
+			name: "quotas have room",
+			regionQuotas: []*compute.Quota{
+				{Metric: "CPUS", Usage: 8, Limit: 72},
+				{Metric: "STATIC_ADDRESSES", Usage: 1, Limit: 8},
+			},
+			projectQuotas: []*compute.Quota{
+				{Metric: "NETWORKS", Usage: 1, Limit: 5},
+				{Metric: "ROUTERS", Usage: 1, Limit: 10},
+				{Metric: "FORWARDING_RULES", Usage: 1, Limit: 15},
+			},
+		},
+		{
+			name: "quotas exceeded",
+			regionQuotas: []*compute.Quota{
+				{Metric: "CPUS", Usage: 48, Limit: 72},
+				{Metric: "STATIC_ADDRESSES", Usage: 1, Limit: 8},
+			},
+			projectQuotas: []*compute.Quota{
+				{Metric: "NETWORKS", Usage: 5, Limit: 5},
+				{Metric: "ROUTERS", Usage: 5, Limit: 10},
+				{Metric: "FORWARDING_RULES", Usage: 14, Limit: 15},
+			},
+			expectedShortages: []cloudQuotaShortage{
+				{resource: "vCPUs", required: 28, usage: 48, limit: 72},
+				{resource: "networks", required: 1, usage: 5, limit: 5},
+				{resource: "forwarding rules", required: 2, usage: 14, limit: 15},
+			},
+		},
+		{
+			name: "private cluster in existing network",
+			installConfig: func(ic *installertypes.InstallConfig) {
+				ic.Platform.GCP.Network = "existing-network"
+				ic.Publish = installertypes.InternalPublishingStrategy
+			},
+			regionQuotas: []*compute.Quota{
+				{Metric: "CPUS", Usage: 8, Limit: 72},
+				{Metric: "STATIC_ADDRESSES", Usage: 8, Limit: 8},
+			},
+			projectQuotas: []*compute.Quota{
+				{Metric: "NETWORKS", Usage: 5, Limit: 5},
+				{Metric: "ROUTERS", Usage: 10, Limit: 10},
+				{Metric: "FORWARDING_RULES", Usage: 14, Limit: 15},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			gcpClient := mockgcp.NewMockClient(mockCtrl)
+
+			gcpClient.EXPECT().GetComputeRegion("us-east1").Return(&compute.Region{
+				Name:   "us-east1",
+				Zones:  []string{"https://www.googleapis.com/compute/v1/projects/test/zones/us-east1-b"},
+				Quotas: test.regionQuotas,
+			}, nil)
+			gcpClient.EXPECT().GetComputeProject().Return(&compute.Project{Quotas: test.projectQuotas}, nil)
+			gcpClient.EXPECT().GetComputeMachineType("us-east1-b", "n1-standard-4").Return(&compute.MachineType{GuestCpus: 4}, nil)
+
+			ic := &installertypes.InstallConfig{
+				Platform: installertypes.Platform{
+					GCP: &installergcp.Platform{Region: "us-east1"},
+				},
+				Publish: installertypes.ExternalPublishingStrategy,
+			}
+			if test.installConfig != nil {
+				test.installConfig(ic)
+			}
+			shortages, err := gcpCloudQuotaShortages(gcpClient, ic, log.WithField("test", test.name))
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedShortages, shortages, "unexpected shortages")
+		})
+	}
+}
+
+func testAWSQuotaInstallConfig() *installertypes.InstallConfig {
+	return &installertypes.InstallConfig{
+		Platform: installertypes.Platform{
+			AWS: &installeraws.Platform{
+				Region: "us-east-1",
+				DefaultMachinePlatform: &installeraws.MachinePool{
+					Zones: []string{"us-east-1a", "us-east-1b"},
+				},
+			},
+		},
+		Publish: installertypes.ExternalPublishingStrategy,
+		ControlPlane: &installertypes.MachinePool{
+			Name:     "master",
+			Replicas: pointer.Int64Ptr(3),
+		},
+		Compute: []installertypes.MachinePool{{
+			Name:     "worker",
+			Replicas: pointer.Int64Ptr(3),
+			Platform: installertypes.MachinePoolPlatform{
+				AWS: &installeraws.MachinePool{InstanceType: "m5.large"},
+			},
+		}},
+	}
+}
+
+func testAWSInstance(instanceType string, coreCount, threadsPerCore int64, lifecycle string) *ec2.Instance {
+	instance := &ec2.Instance{
+		InstanceType: aws.String(instanceType),
+		CpuOptions: &ec2.CpuOptions{
+			CoreCount:      aws.Int64(coreCount),
+			ThreadsPerCore: aws.Int64(threadsPerCore),
+		},
+	}
+	if lifecycle != "" {
+		instance.InstanceLifecycle = aws.String(lifecycle)
+	}
+	return instance
+}
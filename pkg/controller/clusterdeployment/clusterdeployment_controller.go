@@ -47,6 +47,7 @@ import (
 	"github.com/openshift/hive/pkg/controller/utils"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/imageset"
+	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/remoteclient"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
@@ -130,6 +131,7 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		expectations:                            controllerutils.NewExpectations(logger),
 		watchingClusterInstall:                  map[string]struct{}{},
 		validateCredentialsForClusterDeployment: controllerutils.ValidateCredentialsForClusterDeployment,
+		checkCloudQuotas:                        checkCloudQuotas,
 	}
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
@@ -141,6 +143,12 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		r.protectedDelete = true
 	}
 
+	managedDomains, err := manageddns.ReadManagedDomainsFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read managed domains file")
+	}
+	r.managedDomains = managedDomains
+
 	verifier, err := LoadReleaseImageVerifier(mgr.GetConfig())
 	if err == nil {
 		logger.Info("Release Image verification enabled")
@@ -179,6 +187,12 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &hivev1.ClusterProvision{},
+		clusterProvisionInProgressIndex, indexClusterProvisionInProgress); err != nil {
+		logger.WithError(err).Error("Error indexing cluster provisions in progress")
+		return err
+	}
+
 	// Watch for changes to ClusterDeployment
 	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}},
 		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, controllerutils.IsClusterDeploymentErrorUpdateEvent))
@@ -268,11 +282,18 @@ type ReconcileClusterDeployment struct {
 	// that the platform creds are good (used for testing)
 	validateCredentialsForClusterDeployment func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
 
+	// checkCloudQuotas is what this controller will call to find the cloud quotas that do not have room
+	// for the cluster (used for testing)
+	checkCloudQuotas func(client.Client, *hivev1.ClusterDeployment, []byte, log.FieldLogger) ([]cloudQuotaShortage, error)
+
 	// releaseImageVerifier, if provided, will be used to check an release image before it is executed.
 	// Any error will prevent a release image from being accessed.
 	releaseImageVerifier verify.Interface
 
 	protectedDelete bool
+
+	// managedDomains are the domains Hive manages DNS for, as configured in HiveConfig.
+	managedDomains []hivev1.ManageDNSConfig
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -769,7 +790,7 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 			return reconcile.Result{}, err
 		}
 
-		err = ValidateInstallConfig(cd, icSecret.Data["install-config.yaml"])
+		err = ValidateInstallConfig(cd, icSecret.Data[installConfigSecretKey])
 		if err != nil {
 			cdLog.WithError(err).Info("install config validation failed")
 			conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
@@ -786,6 +807,40 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 			return reconcile.Result{}, nil
 		}
 
+		// Wait for the provisioning quotas applying to the cluster before starting a new provision.
+		if cd.Status.ProvisionRef == nil {
+			quota, provisioning, err := r.exceededProvisioningQuota(cd, cdLog)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if quota != nil {
+				cdLog.WithField("provisioningQuota", quota.Name).Info("waiting for provisioning quota")
+				conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+					cd.Status.Conditions,
+					hivev1.RequirementsMetCondition,
+					corev1.ConditionFalse,
+					provisioningQuotaExceededReason,
+					fmt.Sprintf("Waiting for ProvisioningQuota %s: %d of %d concurrent provisions in progress",
+						quota.Name, provisioning, quota.Spec.MaxConcurrent),
+					controllerutils.UpdateConditionIfReasonOrMessageChange)
+				if changed {
+					cd.Status.Conditions = conditions
+					if err := r.Status().Update(context.TODO(), cd); err != nil {
+						return reconcile.Result{}, err
+					}
+				}
+				return reconcile.Result{RequeueAfter: provisioningQuotaRequeueInterval}, nil
+			}
+
+			// Wait for room in the cloud quotas before starting a new provision.
+			switch result, err := r.reconcileCloudQuotaPreflight(cd, icSecret.Data[installConfigSecretKey], cdLog); {
+			case err != nil:
+				return reconcile.Result{}, err
+			case result != nil:
+				return *result, nil
+			}
+		}
+
 		// If we made it this far, RequirementsMet condition should be True:
 		//
 		// TODO: when https://github.com/openshift/hive/pull/1413 is implemented
@@ -1458,17 +1513,26 @@ func (r *ReconcileClusterDeployment) ensureDNSZonePreserveOnDelete(cd *hivev1.Cl
 }
 
 func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*hivev1.DNSZone, error) {
+	var rfc2136 *hivev1.ManageDNSRFC2136Config
 	switch p := cd.Spec.Platform; {
 	case p.AWS != nil:
 	case p.GCP != nil:
 	case p.Azure != nil:
 	default:
-		cdLog.Error("cluster deployment platform does not support managed DNS")
-		if err := r.updateCondition(cd, hivev1.DNSNotReadyCondition, corev1.ConditionTrue, dnsUnsupportedPlatformReason, "Managed DNS is not supported on specified platform", cdLog); err != nil {
-			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update DNSNotReadyCondition for DNSUnsupportedPlatform reason")
+		// Clusters on other platforms can use a zone on the RFC2136 DNS server hosting their managed domain.
+		rfc2136 = manageddns.RFC2136ConfigForDomain(r.managedDomains, cd.Spec.BaseDomain)
+		if rfc2136 == nil {
+			cdLog.Error("cluster deployment platform does not support managed DNS")
+			if err := r.updateCondition(cd, hivev1.DNSNotReadyCondition, corev1.ConditionTrue, dnsUnsupportedPlatformReason, "Managed DNS is not supported on specified platform", cdLog); err != nil {
+				cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update DNSNotReadyCondition for DNSUnsupportedPlatform reason")
+				return nil, err
+			}
+			return nil, errors.New("managed DNS not supported on platform")
+		}
+		if err := r.copyRFC2136TSIGSecret(cd, rfc2136); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not copy RFC2136 TSIG secret")
 			return nil, err
 		}
-		return nil, errors.New("managed DNS not supported on platform")
 	}
 
 	dnsZone := &hivev1.DNSZone{}
@@ -1478,7 +1542,7 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 	switch err := r.Get(context.TODO(), dnsZoneNamespacedName, dnsZone); {
 	case apierrors.IsNotFound(err):
 		logger.Info("creating new DNSZone for cluster deployment")
-		return nil, r.createManagedDNSZone(cd, logger)
+		return nil, r.createManagedDNSZone(cd, rfc2136, logger)
 	case err != nil:
 		logger.WithError(err).Error("failed to fetch DNS zone")
 		return nil, err
@@ -1551,7 +1615,7 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 	return dnsZone, nil
 }
 
-func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDeployment, rfc2136 *hivev1.ManageDNSRFC2136Config, logger log.FieldLogger) error {
 	dnsZone := &hivev1.DNSZone{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      controllerutils.DNSZoneName(cd.Name),
@@ -1571,7 +1635,7 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 			additionalTags = append(additionalTags, hivev1.AWSResourceTag{Key: k, Value: v})
 		}
 		region := ""
-		if strings.HasPrefix(cd.Spec.Platform.AWS.Region, constants.AWSChinaRegionPrefix) {
+		if strings.HasPrefix(controllerutils.GetClusterRegion(cd), constants.AWSChinaRegionPrefix) {
 			region = constants.AWSChinaRoute53Region
 		}
 		dnsZone.Spec.AWS = &hivev1.AWSDNSZoneSpec{
@@ -1583,12 +1647,19 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 	case cd.Spec.Platform.GCP != nil:
 		dnsZone.Spec.GCP = &hivev1.GCPDNSZoneSpec{
 			CredentialsSecretRef: cd.Spec.Platform.GCP.CredentialsSecretRef,
+			AdditionalLabels:     cd.Spec.Platform.GCP.UserLabels,
 		}
 	case cd.Spec.Platform.Azure != nil:
 		dnsZone.Spec.Azure = &hivev1.AzureDNSZoneSpec{
 			CredentialsSecretRef: cd.Spec.Platform.Azure.CredentialsSecretRef,
 			ResourceGroupName:    cd.Spec.Platform.Azure.BaseDomainResourceGroupName,
 			CloudName:            cd.Spec.Platform.Azure.CloudName,
+			AdditionalTags:       cd.Spec.Platform.Azure.UserTags,
+		}
+	case rfc2136 != nil:
+		dnsZone.Spec.RFC2136 = &hivev1.RFC2136DNSZoneSpec{
+			Nameserver:    rfc2136.Nameserver,
+			TSIGSecretRef: corev1.LocalObjectReference{Name: rfc2136TSIGSecretName(cd)},
 		}
 	}
 
@@ -1609,6 +1680,18 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 	return nil
 }
 
+// copyRFC2136TSIGSecret copies the TSIG secret of the RFC2136 managed domain from the hive namespace to the
+// namespace of the ClusterDeployment, where the DNSZone reads it.
+func (r *ReconcileClusterDeployment) copyRFC2136TSIGSecret(cd *hivev1.ClusterDeployment, rfc2136 *hivev1.ManageDNSRFC2136Config) error {
+	src := types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: rfc2136.TSIGSecretRef.Name}
+	dest := types.NamespacedName{Namespace: cd.Namespace, Name: rfc2136TSIGSecretName(cd)}
+	return controllerutils.CopySecret(r.Client, src, dest, cd, r.scheme)
+}
+
+func rfc2136TSIGSecretName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, "rfc2136-tsig")
+}
+
 func selectorPodWatchHandler(a client.Object) []reconcile.Request {
 	retval := []reconcile.Request{}
 
@@ -1700,7 +1783,7 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 	switch {
 	case cd.Spec.Platform.AWS != nil:
 		req.Spec.Platform.AWS = &hivev1.AWSClusterDeprovision{
-			Region:                cd.Spec.Platform.AWS.Region,
+			Region:                getClusterRegion(cd),
 			CredentialsSecretRef:  &cd.Spec.Platform.AWS.CredentialsSecretRef,
 			CredentialsAssumeRole: cd.Spec.Platform.AWS.CredentialsAssumeRole,
 		}
@@ -1711,7 +1794,7 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 		}
 	case cd.Spec.Platform.GCP != nil:
 		req.Spec.Platform.GCP = &hivev1.GCPClusterDeprovision{
-			Region:               cd.Spec.Platform.GCP.Region,
+			Region:               getClusterRegion(cd),
 			CredentialsSecretRef: &cd.Spec.Platform.GCP.CredentialsSecretRef,
 		}
 	case cd.Spec.Platform.OpenStack != nil:
@@ -1895,15 +1978,9 @@ func (r *ReconcileClusterDeployment) updatePullSecretInfo(pullSecret string, cd
 	return true, nil
 }
 
-func calculateNextProvisionTime(failureTime time.Time, retries int, cdLog log.FieldLogger) time.Time {
-	// (2^currentRetries) * 60 seconds up to a max of 24 hours.
-	const sleepCap = 24 * time.Hour
-	const retryCap = 11 // log_2_(24*60)
-
-	if retries >= retryCap {
-		return failureTime.Add(sleepCap)
-	}
-	return failureTime.Add((1 << uint(retries)) * time.Minute)
+func calculateNextProvisionTime(failureTime time.Time, retries int, policy *hivev1.ProvisionRetryPolicy, cdLog log.FieldLogger) time.Time {
+	// By default, (2^currentRetries) * 60 seconds up to a max of 24 hours.
+	return failureTime.Add(provisionBackoff(policy, retries))
 }
 
 // existingProvisions returns the list of ClusterProvisions associated with the specified
@@ -2125,15 +2202,8 @@ func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
 
 // getClusterRegion returns the region of a given ClusterDeployment
 func getClusterRegion(cd *hivev1.ClusterDeployment) string {
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		return cd.Spec.Platform.AWS.Region
-	case cd.Spec.Platform.Azure != nil:
-		return cd.Spec.Platform.Azure.Region
-	case cd.Spec.Platform.GCP != nil:
-		return cd.Spec.Platform.GCP.Region
-	case cd.Spec.Platform.IBMCloud != nil:
-		return cd.Spec.Platform.IBMCloud.Region
+	if region := controllerutils.GetClusterRegion(cd); region != "" {
+		return region
 	}
 	return regionUnknown
 }
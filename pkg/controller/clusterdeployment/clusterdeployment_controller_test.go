@@ -19,6 +19,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -43,6 +44,7 @@ import (
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/apis/hive/v1/baremetal"
+	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
@@ -818,6 +820,40 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.True(t, zone.Spec.PreserveOnDelete, "PreserveOnDelete did not transfer to DNSZone")
 			},
 		},
+		{
+			name: "Create RFC2136 DNSZone when manageDNS is true on OpenStack",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					baseCD := testClusterDeployment()
+					baseCD.Labels[hivev1.HiveClusterPlatformLabel] = "openstack"
+					baseCD.Labels[hivev1.HiveClusterRegionLabel] = regionUnknown
+					baseCD.Spec.Platform.AWS = nil
+					baseCD.Spec.Platform.OpenStack = &hivev1openstack.Platform{
+						CredentialsSecretRef: corev1.LocalObjectReference{Name: "openstack-credentials"},
+						Cloud:                "openstack",
+					}
+					baseCD.Spec.BaseDomain = "bar.rfc2136.example.com"
+					baseCD.Spec.ManageDNS = true
+					return testClusterDeploymentWithInitializedConditions(baseCD)
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testRFC2136TSIGSecret(),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.managedDomains = testRFC2136ManagedDomains()
+			},
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				require.NotNil(t, zone, "dns zone should exist")
+				require.NotNil(t, zone.Spec.RFC2136, "dns zone should be hosted on the RFC2136 server")
+				assert.Equal(t, "ns1.rfc2136.example.com:53", zone.Spec.RFC2136.Nameserver, "unexpected nameserver")
+				assert.Equal(t, testName+"-rfc2136-tsig", zone.Spec.RFC2136.TSIGSecretRef.Name, "unexpected TSIG secret")
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName + "-rfc2136-tsig"}, secret), "TSIG secret should be copied")
+				assert.Equal(t, "hive-key", string(secret.Data[constants.RFC2136TSIGKeyNameSecretKey]), "unexpected TSIG key name")
+			},
+		},
 		{
 			name: "Create DNSZone with Azure CloudName",
 			existing: []runtime.Object{
@@ -1245,6 +1281,25 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.NotNil(t, deprovision, "expected deprovision request to be created")
 			},
 		},
+		{
+			name: "Deprovision in the region of an install config override",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testDeletedClusterDeployment())
+					cd.Status.Platform = &hivev1.PlatformStatus{Region: "us-west-2"}
+					cd.Labels[hivev1.HiveClusterRegionLabel] = "us-west-2"
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				deprovision := getDeprovision(c)
+				if assert.NotNil(t, deprovision, "expected deprovision request to be created") {
+					assert.Equal(t, "us-west-2", deprovision.Spec.Platform.AWS.Region, "unexpected deprovision region")
+				}
+			},
+		},
 		{
 			name: "Delete old provisions",
 			existing: []runtime.Object{
@@ -2043,6 +2098,352 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}})
 			},
 		},
+		{
+			name: "install config override of retry policy",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment()))
+					cd.Status.InstallRestarts = 3
+					cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{
+						InstallConfigOverrides: []hivev1.InstallConfigOverride{
+							{Name: "zones", Patch: runtime.RawExtension{Raw: []byte(`{"controlPlane":{"platform":{"aws":{"zones":["us-east-1b"]}}}}`)}},
+							{Name: "instance-type", Patch: runtime.RawExtension{Raw: []byte(`{"controlPlane":{"platform":{"aws":{"type":"m5.xlarge"}}}}`)}},
+						},
+					}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				require.Len(t, provisions, 1, "expected 1 ClusterProvision to exist")
+				// The last override is used once the overrides are exhausted
+				if assert.NotNil(t, provisions[0].Spec.InstallConfigOverride, "expected install config override on provision") {
+					assert.Equal(t, "instance-type", *provisions[0].Spec.InstallConfigOverride, "unexpected install config override")
+				}
+				secretName := testName + "-install-config-instance-type"
+				secret := &corev1.Secret{}
+				if assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: secretName}, secret), "could not get install config override secret") {
+					assert.Contains(t, string(secret.Data["install-config.yaml"]), "type: m5.xlarge", "expected override in install config")
+					assert.Contains(t, string(secret.Data["install-config.yaml"]), "region: us-east-1", "expected region in install config")
+				}
+				for _, volume := range provisions[0].Spec.PodSpec.Volumes {
+					if volume.Name == "installconfig" {
+						assert.Equal(t, secretName, volume.Secret.SecretName, "expected installer pod to use install config override secret")
+					}
+				}
+			},
+		},
+		{
+			name: "install config override of retry policy changes region",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment()))
+					cd.Status.InstallRestarts = 2
+					cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{
+						InstallConfigOverrides: []hivev1.InstallConfigOverride{
+							{Name: "region", Patch: runtime.RawExtension{Raw: []byte(`{"platform":{"aws":{"region":"us-west-2"}}}`)}},
+						},
+					}
+					cd.Status.Platform = &hivev1.PlatformStatus{Region: "us-west-2"}
+					cd.Labels[hivev1.HiveClusterRegionLabel] = "us-west-2"
+					return cd
+				}(),
+				testProvision(tcp.WithFailureReason("aReason"), tcp.Attempt(1), func(p *hivev1.ClusterProvision) {
+					p.Spec.Region = pointer.StringPtr("us-west-2")
+				}),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				var provision *hivev1.ClusterProvision
+				for _, p := range getProvisions(c) {
+					if p.Spec.Attempt == 2 {
+						provision = p
+					}
+				}
+				if assert.NotNil(t, provision, "expected a ClusterProvision for the new attempt") {
+					if assert.NotNil(t, provision.Spec.Region, "expected region on provision") {
+						assert.Equal(t, "us-west-2", *provision.Spec.Region, "unexpected provision region")
+					}
+					if assert.NotNil(t, provision.Spec.PrevRegion, "expected previous region on provision") {
+						assert.Equal(t, "us-west-2", *provision.Spec.PrevRegion, "unexpected previous provision region")
+					}
+				}
+				secret := &corev1.Secret{}
+				if assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName + "-install-config-region"}, secret), "could not get install config override secret") {
+					assert.Contains(t, string(secret.Data["install-config.yaml"]), "region: us-west-2", "expected overridden region in install config")
+				}
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					assert.Equal(t, "us-east-1", cd.Spec.Platform.AWS.Region, "unexpected platform region")
+					if assert.NotNil(t, cd.Status.Platform, "expected platform status") {
+						assert.Equal(t, "us-west-2", cd.Status.Platform.Region, "unexpected cluster region")
+					}
+				}
+			},
+		},
+		{
+			name: "install config override of retry policy changes region for the first time",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment()))
+					cd.Status.InstallRestarts = 1
+					cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{
+						InstallConfigOverrides: []hivev1.InstallConfigOverride{
+							{Name: "region", Patch: runtime.RawExtension{Raw: []byte(`{"platform":{"aws":{"region":"us-west-2"}}}`)}},
+						},
+					}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				require.Len(t, provisions, 1, "expected 1 ClusterProvision to exist")
+				if assert.NotNil(t, provisions[0].Spec.Region, "expected region on provision") {
+					assert.Equal(t, "us-west-2", *provisions[0].Spec.Region, "unexpected provision region")
+				}
+				assert.Nil(t, provisions[0].Spec.PrevRegion, "unexpected previous provision region")
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if assert.NotNil(t, cd.Status.Platform, "expected platform status") {
+						assert.Equal(t, "us-west-2", cd.Status.Platform.Region, "unexpected cluster region")
+					}
+				}
+			},
+		},
+		{
+			name: "install config override of retry policy changes platform",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment()))
+					cd.Status.InstallRestarts = 1
+					cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{
+						InstallConfigOverrides: []hivev1.InstallConfigOverride{
+							{Name: "platform", Patch: runtime.RawExtension{Raw: []byte(`{"platform":{"aws":null,"gcp":{"region":"us-east1"}}}`)}},
+						},
+					}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no ClusterProvision to exist")
+			},
+		},
+		{
+			name: "provisioning quota exceeded",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, "aws-credentials", "aws_access_key_id", "test-key"),
+				testSecretWithNamespace(corev1.SecretTypeOpaque, "quota-credentials", "quota-namespace", "aws_access_key_id", "test-key"),
+				testProvisioningQuota("", 1),
+				testSecretWithNamespace(corev1.SecretTypeOpaque, "aws-credentials", "other-namespace", "aws_access_key_id", "test-key"),
+				testProvisioningClusterDeployment("other-namespace"),
+				testProvisioningProvision("other-namespace"),
+			},
+			expectedRequeueAfter: provisioningQuotaRequeueInterval,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no ClusterProvision to exist")
+				testassert.AssertConditions(t, getCD(c), []hivev1.ClusterDeploymentCondition{{
+					Type:    hivev1.RequirementsMetCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  provisioningQuotaExceededReason,
+					Message: "Waiting for ProvisioningQuota test-quota: 1 of 1 concurrent provisions in progress",
+				}})
+			},
+		},
+		{
+			name: "provisioning quota not exceeded",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, "aws-credentials", "aws_access_key_id", "test-key"),
+				testSecretWithNamespace(corev1.SecretTypeOpaque, "quota-credentials", "quota-namespace", "aws_access_key_id", "test-key"),
+				testProvisioningQuota("", 2),
+				testSecretWithNamespace(corev1.SecretTypeOpaque, "aws-credentials", "other-namespace", "aws_access_key_id", "test-key"),
+				testProvisioningClusterDeployment("other-namespace"),
+				testProvisioningProvision("other-namespace"),
+				// The provision of a cluster with other credentials is not counted against the quota
+				testSecretWithNamespace(corev1.SecretTypeOpaque, "aws-credentials", "third-namespace", "aws_access_key_id", "other-key"),
+				testProvisioningClusterDeployment("third-namespace"),
+				testProvisioningProvision("third-namespace"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+				testassert.AssertConditions(t, getCD(c), []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.RequirementsMetCondition,
+					Status: corev1.ConditionTrue,
+					Reason: "AllRequirementsMet",
+				}})
+				quota := getProvisioningQuota(c)
+				if assert.Len(t, quota.Status.Reservations, 1, "expected a provisioning quota reservation") {
+					assert.Equal(t, testNamespace, quota.Status.Reservations[0].Namespace, "unexpected reservation namespace")
+					assert.Equal(t, testName, quota.Status.Reservations[0].Name, "unexpected reservation name")
+				}
+			},
+		},
+		{
+			name: "provisioning quota reserved by another cluster",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, "aws-credentials", "aws_access_key_id", "test-key"),
+				testSecretWithNamespace(corev1.SecretTypeOpaque, "quota-credentials", "quota-namespace", "aws_access_key_id", "test-key"),
+				testProvisioningQuotaWithReservation(1, "other-namespace", time.Now().Add(-time.Minute)),
+			},
+			expectedRequeueAfter: provisioningQuotaRequeueInterval,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no ClusterProvision to exist")
+				testassert.AssertConditions(t, getCD(c), []hivev1.ClusterDeploymentCondition{{
+					Type:    hivev1.RequirementsMetCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  provisioningQuotaExceededReason,
+					Message: "Waiting for ProvisioningQuota test-quota: 1 of 1 concurrent provisions in progress",
+				}})
+			},
+		},
+		{
+			name: "expired provisioning quota reservation",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, "aws-credentials", "aws_access_key_id", "test-key"),
+				testSecretWithNamespace(corev1.SecretTypeOpaque, "quota-credentials", "quota-namespace", "aws_access_key_id", "test-key"),
+				testProvisioningQuotaWithReservation(1, "other-namespace", time.Now().Add(-provisioningQuotaReservationTimeout)),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+				quota := getProvisioningQuota(c)
+				if assert.Len(t, quota.Status.Reservations, 1, "expected the expired reservation to be replaced") {
+					assert.Equal(t, testNamespace, quota.Status.Reservations[0].Namespace, "unexpected reservation namespace")
+				}
+			},
+		},
+		{
+			name: "provisioning quota for another region",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, "aws-credentials", "aws_access_key_id", "test-key"),
+				testSecretWithNamespace(corev1.SecretTypeOpaque, "quota-credentials", "quota-namespace", "aws_access_key_id", "test-key"),
+				testProvisioningQuota("us-west-2", 0),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "cloud quotas exceeded",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testCloudQuotaPreflightClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.checkCloudQuotas = func(client.Client, *hivev1.ClusterDeployment, []byte, log.FieldLogger) ([]cloudQuotaShortage, error) {
+					return []cloudQuotaShortage{
+						{resource: "VPCs", required: 1, usage: 5, limit: 5},
+						{resource: "Elastic IPs", required: 3, usage: 4, limit: 5},
+					}, nil
+				}
+			},
+			expectedRequeueAfter: cloudQuotaPreflightInterval,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no ClusterProvision to exist")
+				testassert.AssertConditions(t, getCD(c), []hivev1.ClusterDeploymentCondition{{
+					Type:    hivev1.RequirementsMetCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  cloudQuotaExceededReason,
+					Message: "Cloud quotas do not have room for the cluster: VPCs: 1 required, 5 of 5 in use; Elastic IPs: 3 required, 4 of 5 in use",
+				}})
+			},
+		},
+		{
+			name: "cloud quotas recently checked",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testCloudQuotaPreflightClusterDeployment()))
+					cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(cd.Status.Conditions,
+						hivev1.RequirementsMetCondition, corev1.ConditionFalse, cloudQuotaExceededReason, "quotas exceeded",
+						controllerutils.UpdateConditionAlways)
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.checkCloudQuotas = func(client.Client, *hivev1.ClusterDeployment, []byte, log.FieldLogger) ([]cloudQuotaShortage, error) {
+					return nil, errors.New("cloud quotas should not be checked")
+				}
+			},
+			expectedRequeueAfter: cloudQuotaPreflightInterval,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no ClusterProvision to exist")
+			},
+		},
+		{
+			name: "cloud quotas have room",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testCloudQuotaPreflightClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.checkCloudQuotas = func(client.Client, *hivev1.ClusterDeployment, []byte, log.FieldLogger) ([]cloudQuotaShortage, error) {
+					return nil, nil
+				}
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "cloud quotas cannot be checked",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testCloudQuotaPreflightClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.checkCloudQuotas = func(client.Client, *hivev1.ClusterDeployment, []byte, log.FieldLogger) ([]cloudQuotaShortage, error) {
+					return nil, errors.New("access denied")
+				}
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+			},
+		},
 		{
 			name: "install attempts is equal to the limit",
 			existing: []runtime.Object{
@@ -2679,6 +3080,66 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
 			},
 		},
+		{
+			name: "FailureClassification: retryable: retry",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureClassification("aReason", hivev1.InstallFailureCategoryTransient, true, "")),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, corev1.ConditionFalse, cond.Status, "expected ProvisionStopped to be False")
+					}
+				}
+				assert.Len(t, getProvisions(c), 2, "expected 2 ClusterProvisions to exist")
+			},
+		},
+		{
+			name: "FailureClassification: not retryable: no retry",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureClassification("aReason", hivev1.InstallFailureCategoryQuota, false, "Request a quota increase.")),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "expected ProvisionStopped to be True")
+						assert.Equal(t, "FailureReasonNotRetryable", cond.Reason, "expected ProvisionStopped Reason to be FailureReasonNotRetryable")
+						assert.Equal(t, "Provision failure reason not retryable (category: Quota, retryable: false). Remediation: Request a quota increase.", cond.Message, "unexpected ProvisionStopped Message")
+					}
+				}
+				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
+			},
+		},
+		{
+			name: "FailureClassification: not retryable, matching RetryReasons: retry",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureClassification("aReason", hivev1.InstallFailureCategoryQuota, false, "Request a quota increase.")),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			// RetryReasons takes precedence over the classification
+			retryReasons:          &[]string{"aReason"},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, corev1.ConditionFalse, cond.Status, "expected ProvisionStopped to be False")
+					}
+				}
+				assert.Len(t, getProvisions(c), 2, "expected 2 ClusterProvisions to exist")
+			},
+		},
 		{
 			name: "RetryReasons: no provision yet: list ignored, provision created",
 			existing: []runtime.Object{
@@ -2733,12 +3194,10 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			if test.retryReasons == nil {
 				readFile = fakeReadFile("")
 			} else {
-				b, _ := json.Marshal(*test.retryReasons)
-				readFile = fakeReadFile(fmt.Sprintf(`
-				{
-					"retryReasons": %s
-				}
-				`, string(b)))
+				b, _ := json.Marshal(hivev1.FailedProvisionConfig{
+					RetryReasons: test.retryReasons,
+				})
+				readFile = fakeReadFile(string(b))
 			}
 			fakeClient := fake.NewFakeClient(test.existing...)
 			controllerExpectations := controllerutils.NewExpectations(logger)
@@ -2861,6 +3320,7 @@ func TestCalculateNextProvisionTime(t *testing.T) {
 		name             string
 		failureTime      time.Time
 		attempt          int
+		policy           *hivev1.ProvisionRetryPolicy
 		expectedNextTime time.Time
 	}{
 		{
@@ -2905,15 +3365,154 @@ func TestCalculateNextProvisionTime(t *testing.T) {
 			attempt:          999999,
 			expectedNextTime: time.Date(2019, time.July, 17, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name:        "retry policy: first attempt",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     0,
+			policy: &hivev1.ProvisionRetryPolicy{
+				InitialBackoff: &metav1.Duration{Duration: 5 * time.Minute},
+				MaxBackoff:     &metav1.Duration{Duration: time.Hour},
+				BackoffFactor:  pointer.Int32Ptr(3),
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 0, 5, 0, 0, time.UTC),
+		},
+		{
+			name:        "retry policy: third attempt",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     2,
+			policy: &hivev1.ProvisionRetryPolicy{
+				InitialBackoff: &metav1.Duration{Duration: 5 * time.Minute},
+				MaxBackoff:     &metav1.Duration{Duration: time.Hour},
+				BackoffFactor:  pointer.Int32Ptr(3),
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 0, 45, 0, 0, time.UTC),
+		},
+		{
+			name:        "retry policy: max backoff",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     999999,
+			policy: &hivev1.ProvisionRetryPolicy{
+				InitialBackoff: &metav1.Duration{Duration: 5 * time.Minute},
+				MaxBackoff:     &metav1.Duration{Duration: time.Hour},
+				BackoffFactor:  pointer.Int32Ptr(2147483647),
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "retry policy: constant backoff",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     999999,
+			policy: &hivev1.ProvisionRetryPolicy{
+				InitialBackoff: &metav1.Duration{Duration: 10 * time.Minute},
+				BackoffFactor:  pointer.Int32Ptr(1),
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 0, 10, 0, 0, time.UTC),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actualNextTime := calculateNextProvisionTime(tc.failureTime, tc.attempt, log.WithField("controller", "clusterDeployment"))
+			actualNextTime := calculateNextProvisionTime(tc.failureTime, tc.attempt, tc.policy, log.WithField("controller", "clusterDeployment"))
 			assert.Equal(t, tc.expectedNextTime.String(), actualNextTime.String(), "unexpected next provision time")
 		})
 	}
 }
 
+// staleProvisioningQuotaClient lists the provisioning quotas as they were before another ClusterDeployment reserved
+// them, like a stale cache.
+type staleProvisioningQuotaClient struct {
+	client.Client
+	quotas *hivev1.ProvisioningQuotaList
+}
+
+func (c *staleProvisioningQuotaClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if quotaList, ok := list.(*hivev1.ProvisioningQuotaList); ok {
+		c.quotas.DeepCopyInto(quotaList)
+		return nil
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestReserveProvisioningQuotasConflict(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	logger := log.WithField("test", "TestReserveProvisioningQuotasConflict")
+	otherCD := testClusterDeployment()
+	otherCD.Namespace = "other-namespace"
+	fakeClient := fake.NewFakeClient(
+		testSecret(corev1.SecretTypeOpaque, "aws-credentials", "aws_access_key_id", "test-key"),
+		testSecretWithNamespace(corev1.SecretTypeOpaque, "aws-credentials", "other-namespace", "aws_access_key_id", "test-key"),
+		testSecretWithNamespace(corev1.SecretTypeOpaque, "quota-credentials", "quota-namespace", "aws_access_key_id", "test-key"),
+		testProvisioningQuota("", 1),
+	)
+	staleQuotas := &hivev1.ProvisioningQuotaList{}
+	require.NoError(t, fakeClient.List(context.TODO(), staleQuotas), "could not list provisioning quotas")
+
+	rcd := &ReconcileClusterDeployment{Client: fakeClient, scheme: scheme.Scheme}
+	quota, _, err := rcd.reserveProvisioningQuotas(otherCD, logger)
+	require.NoError(t, err, "unexpected error reserving the quota for the other cluster")
+	require.Nil(t, quota, "unexpected exceeded quota for the other cluster")
+
+	// Both clusters saw the quota unused, but only the first one to reserve it may provision
+	rcd.Client = &staleProvisioningQuotaClient{Client: fakeClient, quotas: staleQuotas}
+	_, _, err = rcd.reserveProvisioningQuotas(testClusterDeployment(), logger)
+	assert.True(t, apierrors.IsConflict(err), "expected conflict reserving the quota, got %v", err)
+
+	// Once the reservation of the other cluster is observed, the quota is exceeded
+	rcd.Client = fakeClient
+	quota, provisioning, err := rcd.reserveProvisioningQuotas(testClusterDeployment(), logger)
+	require.NoError(t, err, "unexpected error")
+	if assert.NotNil(t, quota, "expected quota to be exceeded") {
+		assert.Equal(t, int32(1), provisioning, "unexpected provisioning count")
+	}
+	reservations := getProvisioningQuota(fakeClient).Status.Reservations
+	if assert.Len(t, reservations, 1, "expected a single reservation") {
+		assert.Equal(t, "other-namespace", reservations[0].Namespace, "unexpected reservation namespace")
+	}
+}
+
+func TestReserveProvisioningQuotasConflictReleasesReservations(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	logger := log.WithField("test", "TestReserveProvisioningQuotasConflictReleasesReservations")
+	firstQuota := testProvisioningQuota("", 2)
+	firstQuota.Name = "a-quota"
+	secondQuota := testProvisioningQuota("", 2)
+	secondQuota.Name = "b-quota"
+	fakeClient := fake.NewFakeClient(
+		testSecret(corev1.SecretTypeOpaque, "aws-credentials", "aws_access_key_id", "test-key"),
+		testSecretWithNamespace(corev1.SecretTypeOpaque, "quota-credentials", "quota-namespace", "aws_access_key_id", "test-key"),
+		firstQuota,
+		secondQuota,
+	)
+	staleQuotas := &hivev1.ProvisioningQuotaList{}
+	require.NoError(t, fakeClient.List(context.TODO(), staleQuotas), "could not list provisioning quotas")
+	require.Len(t, staleQuotas.Items, 2, "unexpected number of provisioning quotas")
+	require.Equal(t, "a-quota", staleQuotas.Items[0].Name, "unexpected order of provisioning quotas")
+
+	// Another cluster reserves the second quota after the quotas were listed
+	quota := &hivev1.ProvisioningQuota{}
+	require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Name: "b-quota"}, quota), "could not get provisioning quota")
+	quota.Status.Reservations = []hivev1.ProvisioningQuotaReservation{{
+		Namespace:       "other-namespace",
+		Name:            testName,
+		ReservationTime: metav1.Now(),
+	}}
+	require.NoError(t, fakeClient.Status().Update(context.TODO(), quota), "could not reserve provisioning quota")
+
+	rcd := &ReconcileClusterDeployment{
+		Client: &staleProvisioningQuotaClient{Client: fakeClient, quotas: staleQuotas},
+		scheme: scheme.Scheme,
+	}
+	_, _, err := rcd.reserveProvisioningQuotas(testClusterDeployment(), logger)
+	assert.True(t, apierrors.IsConflict(err), "expected conflict reserving the second quota, got %v", err)
+
+	// The reservation of the first quota is released
+	require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Name: "a-quota"}, quota), "could not get provisioning quota")
+	assert.Empty(t, quota.Status.Reservations, "unexpected reservations of the first quota")
+	require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Name: "b-quota"}, quota), "could not get provisioning quota")
+	if assert.Len(t, quota.Status.Reservations, 1, "expected a single reservation of the second quota") {
+		assert.Equal(t, "other-namespace", quota.Status.Reservations[0].Namespace, "unexpected reservation namespace")
+	}
+}
+
 func TestDeleteStaleProvisions(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 	cases := []struct {
@@ -3267,6 +3866,59 @@ func testProvision(opts ...tcp.Option) *hivev1.ClusterProvision {
 	return provision
 }
 
+func testCloudQuotaPreflightClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[cloudQuotaPreflightAnnotation] = "true"
+	return cd
+}
+
+func testProvisioningQuota(region string, maxConcurrent int32) *hivev1.ProvisioningQuota {
+	return &hivev1.ProvisioningQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-quota",
+		},
+		Spec: hivev1.ProvisioningQuotaSpec{
+			CredentialsSecretRef: &corev1.SecretReference{Namespace: "quota-namespace", Name: "quota-credentials"},
+			Region:               region,
+			MaxConcurrent:        maxConcurrent,
+		},
+	}
+}
+
+func testProvisioningQuotaWithReservation(maxConcurrent int32, namespace string, reservationTime time.Time) *hivev1.ProvisioningQuota {
+	quota := testProvisioningQuota("", maxConcurrent)
+	quota.Status.Reservations = []hivev1.ProvisioningQuotaReservation{{
+		Namespace:       namespace,
+		Name:            testName,
+		ReservationTime: metav1.NewTime(reservationTime),
+	}}
+	return quota
+}
+
+func getProvisioningQuota(c client.Client) *hivev1.ProvisioningQuota {
+	quota := &hivev1.ProvisioningQuota{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: "test-quota"}, quota); err != nil {
+		return nil
+	}
+	return quota
+}
+
+func testProvisioningClusterDeployment(namespace string) *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Namespace = namespace
+	cd.Status.ProvisionRef = &corev1.LocalObjectReference{Name: provisionName}
+	return cd
+}
+
+func testProvisioningProvision(namespace string) *hivev1.ClusterProvision {
+	provision := testProvision(tcp.WithStage(hivev1.ClusterProvisionStageProvisioning))
+	provision.Namespace = namespace
+	return provision
+}
+
 func testSuccessfulProvision() *hivev1.ClusterProvision {
 	return testProvision(tcp.Successful(
 		testClusterID, testInfraID, adminKubeconfigSecret, adminPasswordSecret))
@@ -3782,6 +4434,86 @@ func TestCopyInstallLogSecret(t *testing.T) {
 	}
 }
 
+func TestGetInstallLogEnvVars(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          string
+		expectedErr     bool
+		expectedEnvVars []corev1.EnvVar
+	}{
+		{
+			name:            "no object store",
+			config:          `{}`,
+			expectedEnvVars: []corev1.EnvVar{},
+		},
+		{
+			name:   "aws",
+			config: `{"aws": {"credentialsSecretRef": {"name": "aws-creds"}, "region": "us-east-1", "bucket": "logs"}}`,
+			expectedEnvVars: []corev1.EnvVar{
+				{Name: constants.InstallLogsUploadProviderEnvVar, Value: constants.InstallLogsUploadProviderAWS},
+				{Name: constants.InstallLogsCredentialsSecretRefEnvVar, Value: "prefix-aws-creds"},
+				{Name: constants.InstallLogsAWSRegionEnvVar, Value: "us-east-1"},
+				{Name: constants.InstallLogsAWSServiceEndpointEnvVar},
+				{Name: constants.InstallLogsAWSS3BucketEnvVar, Value: "logs"},
+			},
+		},
+		{
+			name:   "gcp",
+			config: `{"gcp": {"credentialsSecretRef": {"name": "gcp-creds"}, "bucket": "logs"}}`,
+			expectedEnvVars: []corev1.EnvVar{
+				{Name: constants.InstallLogsUploadProviderEnvVar, Value: constants.InstallLogsUploadProviderGCP},
+				{Name: constants.InstallLogsCredentialsSecretRefEnvVar, Value: "prefix-gcp-creds"},
+				{Name: constants.InstallLogsGCPBucketEnvVar, Value: "logs"},
+			},
+		},
+		{
+			name:   "azure",
+			config: `{"azure": {"credentialsSecretRef": {"name": "azure-creds"}, "storageAccount": "account", "container": "logs", "cloudName": "AzureUSGovernmentCloud"}}`,
+			expectedEnvVars: []corev1.EnvVar{
+				{Name: constants.InstallLogsUploadProviderEnvVar, Value: constants.InstallLogsUploadProviderAzure},
+				{Name: constants.InstallLogsCredentialsSecretRefEnvVar, Value: "prefix-azure-creds"},
+				{Name: constants.InstallLogsAzureStorageAccountEnvVar, Value: "account"},
+				{Name: constants.InstallLogsAzureContainerEnvVar, Value: "logs"},
+				{Name: constants.InstallLogsAzureCloudNameEnvVar, Value: "AzureUSGovernmentCloud"},
+			},
+		},
+		{
+			name:   "s3 compatible",
+			config: `{"s3Compatible": {"credentialsSecretRef": {"name": "minio-creds"}, "serviceEndpoint": "https://minio.example.com:9000", "bucket": "logs", "forcePathStyle": true}}`,
+			expectedEnvVars: []corev1.EnvVar{
+				{Name: constants.InstallLogsUploadProviderEnvVar, Value: constants.InstallLogsUploadProviderS3Compatible},
+				{Name: constants.InstallLogsCredentialsSecretRefEnvVar, Value: "prefix-minio-creds"},
+				{Name: constants.InstallLogsAWSRegionEnvVar},
+				{Name: constants.InstallLogsAWSServiceEndpointEnvVar, Value: "https://minio.example.com:9000"},
+				{Name: constants.InstallLogsAWSS3BucketEnvVar, Value: "logs"},
+				{Name: constants.InstallLogsS3ForcePathStyleEnvVar, Value: "true"},
+			},
+		},
+		{
+			name:        "multiple object stores",
+			config:      `{"gcp": {"credentialsSecretRef": {"name": "gcp-creds"}, "bucket": "logs"}, "s3Compatible": {"credentialsSecretRef": {"name": "minio-creds"}, "bucket": "logs"}}`,
+			expectedErr: true,
+		},
+	}
+
+	t.Setenv(constants.FailedProvisionConfigFileEnvVar, "fake")
+	origReadFile := readFile
+	defer func() { readFile = origReadFile }()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			readFile = fakeReadFile(test.config)
+			envVars, err := getInstallLogEnvVars("prefix")
+			if test.expectedErr {
+				assert.Error(t, err, "expected error for invalid config")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedEnvVars, envVars, "unexpected env vars")
+		})
+	}
+}
+
 func TestEnsureManagedDNSZone(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 
@@ -3793,6 +4525,7 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 		expectedErr                  bool
 		expectedDNSZone              *hivev1.DNSZone
 		expectedDNSNotReadyCondition *hivev1.ClusterDeploymentCondition
+		validate                     func(client.Client, *testing.T)
 	}{
 		{
 			name: "unsupported platform",
@@ -3807,12 +4540,45 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 				Reason: dnsUnsupportedPlatformReason,
 			},
 		},
+		{
+			name: "unsupported platform outside of RFC2136 managed domains",
+			clusterDeployment: testclusterdeployment.Build(
+				testclusterdeployment.WithNamespace(testNamespace),
+				testclusterdeployment.WithName(testName),
+				func(cd *hivev1.ClusterDeployment) { cd.Spec.BaseDomain = "bar.example.com" },
+			),
+			expectedErr: true,
+			expectedDNSNotReadyCondition: &hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.DNSNotReadyCondition,
+				Status: corev1.ConditionTrue,
+				Reason: dnsUnsupportedPlatformReason,
+			},
+		},
 		{
 			name: "create zone",
 			clusterDeployment: testclusterdeployment.Build(
 				clusterDeploymentBase(),
 			),
 		},
+		{
+			name:         "create RFC2136 zone for platform without managed DNS",
+			existingObjs: []runtime.Object{testRFC2136TSIGSecret()},
+			clusterDeployment: testclusterdeployment.Build(
+				testclusterdeployment.WithNamespace(testNamespace),
+				testclusterdeployment.WithName(testName),
+				func(cd *hivev1.ClusterDeployment) { cd.Spec.BaseDomain = "bar.rfc2136.example.com" },
+			),
+			validate: func(c client.Client, t *testing.T) {
+				zone := &hivev1.DNSZone{}
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: controllerutils.DNSZoneName(testName)}, zone), "dns zone should exist")
+				assert.Equal(t, "bar.rfc2136.example.com", zone.Spec.Zone, "unexpected zone")
+				require.NotNil(t, zone.Spec.RFC2136, "dns zone should be hosted on the RFC2136 server")
+				assert.Equal(t, "ns1.rfc2136.example.com:53", zone.Spec.RFC2136.Nameserver, "unexpected nameserver")
+				assert.Equal(t, testName+"-rfc2136-tsig", zone.Spec.RFC2136.TSIGSecretRef.Name, "unexpected TSIG secret")
+				secret := &corev1.Secret{}
+				assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName + "-rfc2136-tsig"}, secret), "TSIG secret should be copied")
+			},
+		},
 		{
 			name: "zone already exists and is owned by clusterdeployment",
 			existingObjs: []runtime.Object{
@@ -3895,6 +4661,7 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 				scheme:                        scheme.Scheme,
 				logger:                        log.WithField("controller", "clusterDeployment"),
 				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				managedDomains:                testRFC2136ManagedDomains(),
 			}
 
 			// act
@@ -3916,13 +4683,39 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 				actualDNSNotReadyCondition.Message = ""                       // zero out so it won't be checked.
 			}
 			assert.Equal(t, test.expectedDNSNotReadyCondition, actualDNSNotReadyCondition, "Expected DNSZone DNSNotReady condition doesn't match returned condition")
+			if test.validate != nil {
+				test.validate(fakeClient, t)
+			}
 		})
 	}
 }
 
+func testRFC2136ManagedDomains() []hivev1.ManageDNSConfig {
+	return []hivev1.ManageDNSConfig{{
+		Domains: []string{"rfc2136.example.com"},
+		RFC2136: &hivev1.ManageDNSRFC2136Config{
+			Nameserver:    "ns1.rfc2136.example.com:53",
+			TSIGSecretRef: corev1.LocalObjectReference{Name: "rfc2136-tsig"},
+		},
+	}}
+}
+
+func testRFC2136TSIGSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controllerutils.GetHiveNamespace(),
+			Name:      "rfc2136-tsig",
+		},
+		Data: map[string][]byte{
+			constants.RFC2136TSIGKeyNameSecretKey: []byte("hive-key"),
+			constants.RFC2136TSIGSecretSecretKey:  []byte("c2VjcmV0"),
+		},
+	}
+}
+
 func getProvisions(c client.Client) []*hivev1.ClusterProvision {
 	provisionList := &hivev1.ClusterProvisionList{}
-	if err := c.List(context.TODO(), provisionList); err != nil {
+	if err := c.List(context.TODO(), provisionList, client.InNamespace(testNamespace)); err != nil {
 		return nil
 	}
 	provisions := make([]*hivev1.ClusterProvision, len(provisionList.Items))
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -104,7 +105,7 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		return reconcile.Result{}, err
 	}
 	if !shouldRetry {
-		return setProvisionStoppedTrue(failureReasonNotListed, "Provision failure reason not retryable")
+		return setProvisionStoppedTrue(failureReasonNotListed, withFailureClassification("Provision failure reason not retryable", lastFailedProvision))
 	}
 
 	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
@@ -144,8 +145,24 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	}
 	extraEnvVars = append(extraEnvVars, getAWSServiceProviderEnvVars(cd, cd.Name)...)
 
+	// Successive install attempts may use the install config with an override of the retry policy applied.
+	podCD := cd
+	var region string
+	override := installConfigOverrideForAttempt(cd.Spec.ProvisionRetryPolicy, cd.Status.InstallRestarts)
+	if override != nil {
+		logger = logger.WithField("installConfigOverride", override.Name)
+		var secretName string
+		secretName, region, err = r.ensureInstallConfigOverrideSecret(cd, override, logger)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not apply install config override")
+			return reconcile.Result{}, err
+		}
+		podCD = cd.DeepCopy()
+		podCD.Spec.Provisioning.InstallConfigSecretRef = &corev1.LocalObjectReference{Name: secretName}
+	}
+
 	podSpec, err := install.InstallerPodSpec(
-		cd,
+		podCD,
 		provisionName,
 		releaseImage,
 		controllerutils.InstallServiceAccountName,
@@ -174,12 +191,19 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 			Stage:   hivev1.ClusterProvisionStageInitializing,
 		},
 	}
+	if override != nil {
+		provision.Spec.InstallConfigOverride = &override.Name
+	}
+	if region != "" {
+		provision.Spec.Region = &region
+	}
 
-	// Copy over the name, cluster ID and infra ID from previous provision so that a failed install can be removed.
+	// Copy over the name, cluster ID, infra ID and region from previous provision so that a failed install can be removed.
 	if lastFailedProvision != nil {
 		provision.Spec.PrevProvisionName = &lastFailedProvision.Name
 		provision.Spec.PrevClusterID = lastFailedProvision.Spec.ClusterID
 		provision.Spec.PrevInfraID = lastFailedProvision.Spec.InfraID
+		provision.Spec.PrevRegion = lastFailedProvision.Spec.Region
 	}
 
 	logger.WithField("derivedObject", provision.Name).Debug("Setting label on derived object")
@@ -212,6 +236,20 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		}
 	}
 
+	if err := r.setClusterRegionStatus(cd, region, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not record the region of the cluster")
+		return reconcile.Result{}, err
+	}
+
+	// Reserve the provisioning quotas, which other ClusterDeployments may have taken since they were checked.
+	switch quota, _, err := r.reserveProvisioningQuotas(cd, logger); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case quota != nil:
+		logger.WithField("provisioningQuota", quota.Name).Info("provisioning quota exceeded, waiting")
+		return reconcile.Result{RequeueAfter: provisioningQuotaRequeueInterval}, nil
+	}
+
 	r.expectations.ExpectCreations(types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}.String(), 1)
 	if err := r.Create(context.TODO(), provision); err != nil {
 		logger.WithError(err).Error("could not create provision")
@@ -252,8 +290,18 @@ func (r *ReconcileClusterDeployment) shouldRetryBasedOnFailureReason(prov *hivev
 	if err != nil {
 		return false, err
 	}
-	// If no retry reasons are specified, "always" retry
+	// If no retry reasons are specified, retry based on the classification of the failure, or "always" retry if the
+	// provision has none
 	if fpConfig.RetryReasons == nil {
+		if fc := prov.Status.FailureClassification; fc != nil {
+			fcLog := logger.WithFields(log.Fields{"reason": fc.Reason, "category": fc.Category})
+			if fc.Retryable {
+				fcLog.Debug("retrying due to retryable failure classification")
+				return true, nil
+			}
+			fcLog.Debug("failure classification is not retryable -- not retrying")
+			return false, nil
+		}
 		logger.Debug("no RetryReasons found in FailedProvisionConfig -- allowing retry")
 		return true, nil
 	}
@@ -274,6 +322,19 @@ func (r *ReconcileClusterDeployment) shouldRetryBasedOnFailureReason(prov *hivev
 	return false, nil
 }
 
+// withFailureClassification appends the classification of the install failure of the provision, if any, to the message.
+func withFailureClassification(message string, prov *hivev1.ClusterProvision) string {
+	if prov == nil || prov.Status.FailureClassification == nil {
+		return message
+	}
+	fc := prov.Status.FailureClassification
+	message = fmt.Sprintf("%s (category: %s, retryable: %t)", message, fc.Category, fc.Retryable)
+	if fc.Remediation != "" {
+		message = fmt.Sprintf("%s. Remediation: %s", message, fc.Remediation)
+	}
+	return message
+}
+
 func (r *ReconcileClusterDeployment) reconcileExistingProvision(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (result reconcile.Result, returnedErr error) {
 	logger = logger.WithField("provision", cd.Status.ProvisionRef.Name)
 	logger.Debug("reconciling existing provision")
@@ -403,9 +464,9 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 
 	failedCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
 	if failedCond != nil && failedCond.Status == corev1.ConditionTrue {
-		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cdLog)
+		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cd.Spec.ProvisionRetryPolicy, cdLog)
 		reason = failedCond.Reason
-		message = failedCond.Message
+		message = withFailureClassification(failedCond.Message, provision)
 	} else {
 		cdLog.Warnf("failed provision does not have a %s condition", hivev1.ClusterProvisionFailedCondition)
 	}
@@ -591,8 +652,13 @@ func getInstallLogEnvVars(secretPrefix string) ([]corev1.EnvVar, error) {
 	if err != nil || fpConfig == nil {
 		return extraEnvVars, err
 	}
-	if awsSpec := fpConfig.AWS; awsSpec != nil {
-		// By default we will try to gather logs on failed installs:
+	if err := controllerutils.ValidateFailedProvisionConfig(fpConfig); err != nil {
+		return extraEnvVars, err
+	}
+	// By default we will try to gather logs on failed installs:
+	switch {
+	case fpConfig.AWS != nil:
+		awsSpec := fpConfig.AWS
 		extraEnvVars = []corev1.EnvVar{
 			{
 				Name:  constants.InstallLogsUploadProviderEnvVar,
@@ -615,6 +681,74 @@ func getInstallLogEnvVars(secretPrefix string) ([]corev1.EnvVar, error) {
 				Value: awsSpec.Bucket,
 			},
 		}
+	case fpConfig.GCP != nil:
+		gcpSpec := fpConfig.GCP
+		extraEnvVars = []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderGCP,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: secretPrefix + "-" + gcpSpec.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsGCPBucketEnvVar,
+				Value: gcpSpec.Bucket,
+			},
+		}
+	case fpConfig.Azure != nil:
+		azureSpec := fpConfig.Azure
+		extraEnvVars = []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderAzure,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: secretPrefix + "-" + azureSpec.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsAzureStorageAccountEnvVar,
+				Value: azureSpec.StorageAccount,
+			},
+			{
+				Name:  constants.InstallLogsAzureContainerEnvVar,
+				Value: azureSpec.Container,
+			},
+			{
+				Name:  constants.InstallLogsAzureCloudNameEnvVar,
+				Value: string(azureSpec.CloudName),
+			},
+		}
+	case fpConfig.S3Compatible != nil:
+		s3Spec := fpConfig.S3Compatible
+		extraEnvVars = []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderS3Compatible,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: secretPrefix + "-" + s3Spec.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsAWSRegionEnvVar,
+				Value: s3Spec.Region,
+			},
+			{
+				Name:  constants.InstallLogsAWSServiceEndpointEnvVar,
+				Value: s3Spec.ServiceEndpoint,
+			},
+			{
+				Name:  constants.InstallLogsAWSS3BucketEnvVar,
+				Value: s3Spec.Bucket,
+			},
+			{
+				Name:  constants.InstallLogsS3ForcePathStyleEnvVar,
+				Value: strconv.FormatBool(s3Spec.ForcePathStyle),
+			},
+		}
 	}
 
 	return extraEnvVars, nil
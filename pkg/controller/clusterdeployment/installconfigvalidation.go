@@ -7,6 +7,7 @@ import (
 	installertypes "github.com/openshift/installer/pkg/types"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
@@ -58,3 +59,27 @@ func ValidateInstallConfig(cd *hivev1.ClusterDeployment, installConfig []byte) e
 	}
 	return nil
 }
+
+// validateInstallConfigOverride validates the install config with an install config override applied. Unlike
+// ValidateInstallConfig, it allows the region of the install config to differ from the region of the
+// ClusterDeployment, and returns the region of the install config if it does.
+func validateInstallConfigOverride(cd *hivev1.ClusterDeployment, installConfig []byte) (string, error) {
+	ic := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(installConfig, &ic); err != nil {
+		return "", errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+
+	var region string
+	switch platform := cd.Spec.Platform; {
+	case platform.AWS != nil && ic.Platform.AWS != nil && ic.Platform.AWS.Region != platform.AWS.Region:
+		region = ic.Platform.AWS.Region
+	case platform.GCP != nil && ic.Platform.GCP != nil && ic.Platform.GCP.Region != platform.GCP.Region:
+		region = ic.Platform.GCP.Region
+	case platform.Azure != nil && ic.Platform.Azure != nil && ic.Platform.Azure.Region != platform.Azure.Region:
+		region = ic.Platform.Azure.Region
+	}
+	if region == "" {
+		return "", ValidateInstallConfig(cd, installConfig)
+	}
+	return region, ValidateInstallConfig(controllerutils.ClusterDeploymentWithRegion(cd, region), installConfig)
+}
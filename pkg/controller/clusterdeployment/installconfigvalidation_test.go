@@ -271,3 +271,70 @@ func TestInstallConfigValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestInstallConfigOverrideValidation(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cdBuilder := testcd.FullBuilder("testns", "testcluster", scheme.Scheme)
+
+	tests := []struct {
+		name           string
+		ic             string
+		cd             *hivev1.ClusterDeployment
+		expectedRegion string
+		expectedError  string
+	}{
+		{
+			name: "aws same region",
+			cd: cdBuilder.Build(
+				testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+			),
+			ic: testAWSIC,
+		},
+		{
+			name: "aws region changed",
+			cd: cdBuilder.Build(
+				testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-west-2"}),
+			),
+			ic:             testAWSIC,
+			expectedRegion: "us-east-1",
+		},
+		{
+			name: "gcp region changed",
+			cd: cdBuilder.Build(
+				testcd.WithGCPPlatform(&hivev1gcp.Platform{Region: "us-west2"}),
+			),
+			ic:             testGCPIC,
+			expectedRegion: "us-east1",
+		},
+		{
+			name: "azure region changed",
+			cd: cdBuilder.Build(
+				testcd.WithAzurePlatform(&hivev1azure.Platform{Region: "us-west2"}),
+			),
+			ic:             testAzureIC,
+			expectedRegion: "centralus",
+		},
+		{
+			name: "platform changed",
+			cd: cdBuilder.Build(
+				testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+			),
+			ic:            testGCPIC,
+			expectedError: noAWSPlatformErr,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			region, err := validateInstallConfigOverride(test.cd, []byte(test.ic))
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRegion, region, "unexpected region")
+			} else {
+				if assert.Error(t, err, test.expectedError) {
+					assert.Contains(t, err.Error(), test.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package clusterdeployment
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	provisioningQuotaExceededReason = "ProvisioningQuotaExceeded"
+
+	// provisioningQuotaRequeueInterval is how often a ClusterDeployment waiting for a provisioning quota checks
+	// whether it can start provisioning.
+	provisioningQuotaRequeueInterval = time.Minute
+
+	// provisioningQuotaReservationTimeout is how long the reservation of a provisioning quota counts against the quota.
+	// By then the provision of the ClusterDeployment that made the reservation is in the cache.
+	provisioningQuotaReservationTimeout = 2 * time.Minute
+
+	// clusterProvisionInProgressIndex indexes the ClusterProvisions that count against the provisioning quotas.
+	clusterProvisionInProgressIndex = "spec.stage.inProgress"
+)
+
+// provisioningQuotaMatcher tells whether a ClusterDeployment is subject to a provisioning quota. It caches the data
+// of the credentials secrets so that each secret is only read once.
+type provisioningQuotaMatcher struct {
+	client      *ReconcileClusterDeployment
+	credentials map[types.NamespacedName]map[string][]byte
+	logger      log.FieldLogger
+}
+
+func (m *provisioningQuotaMatcher) credentialsData(key types.NamespacedName) (map[string][]byte, error) {
+	if data, ok := m.credentials[key]; ok {
+		return data, nil
+	}
+	secret := &corev1.Secret{}
+	switch err := m.client.Get(context.TODO(), key, secret); {
+	case apierrors.IsNotFound(err):
+		m.logger.WithField("secret", key).Debug("credentials secret does not exist")
+	case err != nil:
+		m.logger.WithField("secret", key).WithError(err).Error("could not get credentials secret")
+		return nil, err
+	}
+	m.credentials[key] = secret.Data
+	return secret.Data, nil
+}
+
+func (m *provisioningQuotaMatcher) matches(quota *hivev1.ProvisioningQuota, cd *hivev1.ClusterDeployment) (bool, error) {
+	if quota.Spec.Region != "" && quota.Spec.Region != getClusterRegion(cd) {
+		return false, nil
+	}
+	ref := quota.Spec.CredentialsSecretRef
+	if ref == nil {
+		return true, nil
+	}
+	credentialsSecretName := controllerutils.CredentialsSecretName(cd)
+	if credentialsSecretName == "" {
+		return false, nil
+	}
+	quotaCredentials, err := m.credentialsData(types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name})
+	if err != nil || len(quotaCredentials) == 0 {
+		return false, err
+	}
+	cdCredentials, err := m.credentialsData(types.NamespacedName{Namespace: cd.Namespace, Name: credentialsSecretName})
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(quotaCredentials, cdCredentials), nil
+}
+
+// provisioningQuotaUsage is a provisioning quota applying to a ClusterDeployment and the number of other
+// ClusterDeployments counted against it.
+type provisioningQuotaUsage struct {
+	quota        *hivev1.ProvisioningQuota
+	provisioning int32
+}
+
+// provisioningQuotaUsages returns the provisioning quotas applying to the ClusterDeployment, along with the number of
+// other ClusterDeployments that are provisioning or hold a reservation of each quota.
+func (r *ReconcileClusterDeployment) provisioningQuotaUsages(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]provisioningQuotaUsage, error) {
+	quotaList := &hivev1.ProvisioningQuotaList{}
+	if err := r.List(context.TODO(), quotaList); err != nil {
+		cdLog.WithError(err).Error("could not list provisioning quotas")
+		return nil, err
+	}
+	if len(quotaList.Items) == 0 {
+		return nil, nil
+	}
+
+	matcher := &provisioningQuotaMatcher{
+		client:      r,
+		credentials: map[types.NamespacedName]map[string][]byte{},
+		logger:      cdLog,
+	}
+	var quotas []*hivev1.ProvisioningQuota
+	for i := range quotaList.Items {
+		quota := &quotaList.Items[i]
+		matches, err := matcher.matches(quota, cd)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			quotas = append(quotas, quota)
+		}
+	}
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+
+	provisionList := &hivev1.ClusterProvisionList{}
+	if err := r.List(context.TODO(), provisionList, client.MatchingFields{clusterProvisionInProgressIndex: "true"}); err != nil {
+		cdLog.WithError(err).Error("could not list cluster provisions")
+		return nil, err
+	}
+	cdKey := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
+	provisioningCDs := map[types.NamespacedName]*hivev1.ClusterDeployment{}
+	for _, provision := range provisionList.Items {
+		if !provisionInProgress(&provision) {
+			continue
+		}
+		provisioningCDKey := types.NamespacedName{Namespace: provision.Namespace, Name: provision.Spec.ClusterDeploymentRef.Name}
+		if provisioningCDKey == cdKey {
+			continue
+		}
+		provisioningCD := &hivev1.ClusterDeployment{}
+		switch err := r.Get(context.TODO(), provisioningCDKey, provisioningCD); {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			cdLog.WithField("provision", provision.Name).WithError(err).Error("could not get cluster deployment of provision")
+			return nil, err
+		}
+		provisioningCDs[provisioningCDKey] = provisioningCD
+	}
+
+	usages := make([]provisioningQuotaUsage, len(quotas))
+	for i, quota := range quotas {
+		usages[i].quota = quota
+		counted := map[types.NamespacedName]bool{}
+		for key, provisioningCD := range provisioningCDs {
+			matches, err := matcher.matches(quota, provisioningCD)
+			if err != nil {
+				return nil, err
+			}
+			if matches {
+				counted[key] = true
+			}
+		}
+		for _, reservation := range activeReservations(quota) {
+			key := types.NamespacedName{Namespace: reservation.Namespace, Name: reservation.Name}
+			if key != cdKey {
+				counted[key] = true
+			}
+		}
+		usages[i].provisioning = int32(len(counted))
+	}
+	return usages, nil
+}
+
+// exceededProvisioningQuota returns the first provisioning quota applying to the ClusterDeployment whose limit is
+// reached by the provisions in progress, along with the number of provisions in progress counted against it. It
+// returns nil when the ClusterDeployment can start provisioning.
+func (r *ReconcileClusterDeployment) exceededProvisioningQuota(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*hivev1.ProvisioningQuota, int32, error) {
+	usages, err := r.provisioningQuotaUsages(cd, cdLog)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, usage := range usages {
+		if usage.provisioning >= usage.quota.Spec.MaxConcurrent {
+			return usage.quota, usage.provisioning, nil
+		}
+	}
+	return nil, 0, nil
+}
+
+// reserveProvisioningQuotas reserves room for the ClusterDeployment in the provisioning quotas applying to it, before
+// its provision is created. The reservations are saved with the resource version of the quotas read to count their
+// usage, so that ClusterDeployments reserving the same quota at the same time fail with a conflict instead of
+// exceeding the quota. It returns the first quota whose limit is reached, if any, and reserves nothing then. When a
+// quota cannot be reserved, the reservations already made are released so that they do not hold back other
+// ClusterDeployments until they expire.
+func (r *ReconcileClusterDeployment) reserveProvisioningQuotas(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*hivev1.ProvisioningQuota, int32, error) {
+	usages, err := r.provisioningQuotaUsages(cd, cdLog)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, usage := range usages {
+		if usage.provisioning >= usage.quota.Spec.MaxConcurrent {
+			return usage.quota, usage.provisioning, nil
+		}
+	}
+	var reserved []*hivev1.ProvisioningQuota
+	for _, usage := range usages {
+		quota := usage.quota
+		reservations := []hivev1.ProvisioningQuotaReservation{{
+			Namespace:       cd.Namespace,
+			Name:            cd.Name,
+			ReservationTime: metav1.Now(),
+		}}
+		for _, reservation := range activeReservations(quota) {
+			if reservation.Namespace != cd.Namespace || reservation.Name != cd.Name {
+				reservations = append(reservations, reservation)
+			}
+		}
+		quota.Status.Reservations = reservations
+		if err := r.Status().Update(context.TODO(), quota); err != nil {
+			cdLog.WithField("provisioningQuota", quota.Name).WithError(err).Log(controllerutils.LogLevel(err), "could not reserve provisioning quota")
+			r.releaseProvisioningQuotas(cd, reserved, cdLog)
+			return nil, 0, err
+		}
+		cdLog.WithField("provisioningQuota", quota.Name).Debug("reserved provisioning quota")
+		reserved = append(reserved, quota)
+	}
+	return nil, 0, nil
+}
+
+// releaseProvisioningQuotas removes the reservations of the ClusterDeployment from the provisioning quotas. Failures
+// are only logged, as the reservations expire anyway.
+func (r *ReconcileClusterDeployment) releaseProvisioningQuotas(cd *hivev1.ClusterDeployment, quotas []*hivev1.ProvisioningQuota, cdLog log.FieldLogger) {
+	for _, quota := range quotas {
+		quotaLog := cdLog.WithField("provisioningQuota", quota.Name)
+		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			curr := &hivev1.ProvisioningQuota{}
+			if err := r.Get(context.TODO(), client.ObjectKeyFromObject(quota), curr); err != nil {
+				return err
+			}
+			var reservations []hivev1.ProvisioningQuotaReservation
+			for _, reservation := range curr.Status.Reservations {
+				if reservation.Namespace != cd.Namespace || reservation.Name != cd.Name {
+					reservations = append(reservations, reservation)
+				}
+			}
+			if len(reservations) == len(curr.Status.Reservations) {
+				return nil
+			}
+			curr.Status.Reservations = reservations
+			return r.Status().Update(context.TODO(), curr)
+		})
+		if err != nil {
+			quotaLog.WithError(err).Log(controllerutils.LogLevel(err), "could not release provisioning quota")
+			continue
+		}
+		quotaLog.Debug("released provisioning quota")
+	}
+}
+
+// activeReservations returns the reservations of the quota that have not expired.
+func activeReservations(quota *hivev1.ProvisioningQuota) []hivev1.ProvisioningQuotaReservation {
+	var active []hivev1.ProvisioningQuotaReservation
+	for _, reservation := range quota.Status.Reservations {
+		if time.Since(reservation.ReservationTime.Time) < provisioningQuotaReservationTimeout {
+			active = append(active, reservation)
+		}
+	}
+	return active
+}
+
+// provisionInProgress tells whether the provision counts against the provisioning quotas.
+func provisionInProgress(provision *hivev1.ClusterProvision) bool {
+	return provision.Spec.Stage == hivev1.ClusterProvisionStageInitializing ||
+		provision.Spec.Stage == hivev1.ClusterProvisionStageProvisioning
+}
+
+func indexClusterProvisionInProgress(o client.Object) []string {
+	provision, ok := o.(*hivev1.ClusterProvision)
+	if !ok || !provisionInProgress(provision) {
+		return nil
+	}
+	return []string{"true"}
+}
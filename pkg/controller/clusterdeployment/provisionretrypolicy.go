@@ -0,0 +1,162 @@
+package clusterdeployment
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	installConfigSecretKey = "install-config.yaml"
+
+	defaultInitialProvisionBackoff = time.Minute
+	defaultMaxProvisionBackoff     = 24 * time.Hour
+	defaultProvisionBackoffFactor  = 2
+)
+
+// provisionBackoff returns the delay between the failure of the install attempt following the given number of retries
+// and the next install attempt.
+func provisionBackoff(policy *hivev1.ProvisionRetryPolicy, retries int) time.Duration {
+	initial, max, factor := defaultInitialProvisionBackoff, defaultMaxProvisionBackoff, time.Duration(defaultProvisionBackoffFactor)
+	if policy != nil {
+		if policy.InitialBackoff != nil {
+			initial = policy.InitialBackoff.Duration
+		}
+		if policy.MaxBackoff != nil {
+			max = policy.MaxBackoff.Duration
+		}
+		if policy.BackoffFactor != nil {
+			factor = time.Duration(*policy.BackoffFactor)
+		}
+	}
+	backoff := initial
+	for i := 0; i < retries && factor > 1; i++ {
+		if backoff > max/factor {
+			return max
+		}
+		backoff *= factor
+	}
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
+
+// installConfigOverrideForAttempt returns the install config override of the retry policy to use for the install attempt
+// following the given number of retries, or nil if the install config should be used as is.
+func installConfigOverrideForAttempt(policy *hivev1.ProvisionRetryPolicy, retries int) *hivev1.InstallConfigOverride {
+	if policy == nil || len(policy.InstallConfigOverrides) == 0 || retries == 0 {
+		return nil
+	}
+	i := retries - 1
+	if i >= len(policy.InstallConfigOverrides) {
+		i = len(policy.InstallConfigOverrides) - 1
+	}
+	return &policy.InstallConfigOverrides[i]
+}
+
+// applyInstallConfigOverride applies the JSON merge patch of the override to the install config.
+func applyInstallConfigOverride(installConfig []byte, override *hivev1.InstallConfigOverride) ([]byte, error) {
+	original, err := yaml.YAMLToJSON(installConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert install config to JSON")
+	}
+	patched, err := jsonpatch.MergePatch(original, override.Patch.Raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not apply install config override %s", override.Name)
+	}
+	return yaml.JSONToYAML(patched)
+}
+
+// installConfigOverrideSecretName returns the name of the secret holding the install config with the override applied.
+func installConfigOverrideSecretName(cd *hivev1.ClusterDeployment, override *hivev1.InstallConfigOverride) string {
+	return apihelpers.GetResourceName(cd.Name, "install-config-"+override.Name)
+}
+
+// ensureInstallConfigOverrideSecret creates or updates the secret holding the install config of the ClusterDeployment
+// with the override applied, and returns its name, and the region of the install config if the override changes it.
+func (r *ReconcileClusterDeployment) ensureInstallConfigOverrideSecret(cd *hivev1.ClusterDeployment, override *hivev1.InstallConfigOverride, logger log.FieldLogger) (string, string, error) {
+	icSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, icSecret); err != nil {
+		return "", "", errors.Wrap(err, "could not get install config secret")
+	}
+	installConfig, err := applyInstallConfigOverride(icSecret.Data[installConfigSecretKey], override)
+	if err != nil {
+		return "", "", err
+	}
+	region, err := validateInstallConfigOverride(cd, installConfig)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "install config override %s is invalid", override.Name)
+	}
+
+	secretName := installConfigOverrideSecretName(cd, override)
+	secretLog := logger.WithField("secret", secretName)
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: secretName}, secret); {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: cd.Namespace,
+			},
+			Type: icSecret.Type,
+			Data: map[string][]byte{installConfigSecretKey: installConfig},
+		}
+		secret.Labels = k8slabels.AddLabel(secret.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+		if err := controllerutil.SetControllerReference(cd, secret, r.scheme); err != nil {
+			secretLog.WithError(err).Error("could not set the owner ref on install config override secret")
+			return "", "", err
+		}
+		if err := r.Create(context.TODO(), secret); err != nil {
+			return "", "", errors.Wrap(err, "could not create install config override secret")
+		}
+		secretLog.Info("created install config override secret")
+	case err != nil:
+		return "", "", errors.Wrap(err, "could not get install config override secret")
+	case !reflect.DeepEqual(secret.Data[installConfigSecretKey], installConfig):
+		secret.Data = map[string][]byte{installConfigSecretKey: installConfig}
+		if err := r.Update(context.TODO(), secret); err != nil {
+			return "", "", errors.Wrap(err, "could not update install config override secret")
+		}
+		secretLog.Info("updated install config override secret")
+	}
+	return secretName, region, nil
+}
+
+// setClusterRegionStatus records in the platform status of the ClusterDeployment the region of the cluster, if an
+// install config override changed it from the region of the platform, so that the cluster is deprovisioned in the
+// right region.
+func (r *ReconcileClusterDeployment) setClusterRegionStatus(cd *hivev1.ClusterDeployment, region string, logger log.FieldLogger) error {
+	var currentRegion string
+	if cd.Status.Platform != nil {
+		currentRegion = cd.Status.Platform.Region
+	}
+	if currentRegion == region {
+		return nil
+	}
+	if cd.Status.Platform == nil {
+		cd.Status.Platform = &hivev1.PlatformStatus{}
+	}
+	cd.Status.Platform.Region = region
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		return errors.Wrap(err, "could not update the region of the cluster")
+	}
+	logger.WithField("region", region).Info("updated the region of the cluster")
+	return nil
+}
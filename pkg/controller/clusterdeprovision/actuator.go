@@ -1,6 +1,8 @@
 package clusterdeprovision
 
 import (
+	"context"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,3 +16,11 @@ type Actuator interface {
 	// TestCredentials returns nil if the credential check succeeds. Otherwise returns the error.
 	TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error
 }
+
+// Deprovisioner is implemented by the actuators that can delete the cloud resources of a cluster from within the
+// controller. Clusters on platforms without one are deprovisioned by an uninstall job.
+type Deprovisioner interface {
+	// Deprovision deletes the cloud resources of the cluster from within the controller. It returns once all
+	// resources are deleted or ctx is done, along with the resources still remaining to be deleted grouped by type.
+	Deprovision(ctx context.Context, clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]hivev1.DeprovisionResourceStatus, error)
+}
@@ -1,14 +1,25 @@
 package clusterdeprovision
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sts"
 
+	awsdestroy "github.com/openshift/installer/pkg/destroy/aws"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
@@ -16,16 +27,27 @@ import (
 )
 
 func init() {
-	registerActuator(&awsActuator{awsClientFn: getAWSClient})
+	registerActuator(&awsActuator{
+		awsClientFn:      getAWSClient,
+		awsSessionFn:     getAWSSession,
+		runUninstallerFn: (*awsdestroy.ClusterUninstaller).RunWithContext,
+	})
 }
 
 // Ensure AWSActuator implements the Actuator interface. This will fail at compile time when false.
 var _ Actuator = &awsActuator{}
 
+// Ensure AWSActuator implements the Deprovisioner interface. This will fail at compile time when false.
+var _ Deprovisioner = &awsActuator{}
+
 // AWSActuator manages getting the desired state, getting the current state and reconciling the two.
 type awsActuator struct {
 	// awsClientFn is the function to build an AWS client, here for testing
 	awsClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (awsclient.Client, error)
+	// awsSessionFn is the function to build the AWS session used to deprovision, here for testing
+	awsSessionFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (*session.Session, error)
+	// runUninstallerFn is the function running the installer's AWS destroyer, here for testing
+	runUninstallerFn func(*awsdestroy.ClusterUninstaller, context.Context) ([]string, error)
 }
 
 // CanHandle returns true if the actuator can handle a particular ClusterDeprovision
@@ -51,8 +73,150 @@ func (a *awsActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovis
 	return nil
 }
 
+// Deprovision deletes the AWS resources tagged for the cluster using the installer's destroyer, the same way
+// the aws-tag-deprovision command run by the uninstall job does.
+func (a *awsActuator) Deprovision(ctx context.Context, clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]hivev1.DeprovisionResourceStatus, error) {
+	sess, err := a.awsSessionFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session")
+	}
+
+	// The destroyer logs through a logger of its own so that its entries, including the debug entries carrying
+	// deletion errors, reach the hook regardless of the controller log level.
+	hook := &arnErrorHook{logger: logger}
+	destroyerLogger := &log.Logger{
+		Out:       ioutil.Discard,
+		Formatter: &log.TextFormatter{},
+		Hooks:     log.LevelHooks{},
+		Level:     log.DebugLevel,
+	}
+	destroyerLogger.AddHook(hook)
+
+	uninstaller := &awsdestroy.ClusterUninstaller{
+		Filters: awsDeprovisionFilters(clusterDeprovision),
+		Region:  clusterDeprovision.Spec.Platform.AWS.Region,
+		Logger:  destroyerLogger,
+		Session: sess,
+	}
+	remaining, err := a.runUninstallerFn(uninstaller, ctx)
+	resources := hook.remainingResources(remaining)
+	switch {
+	case err == nil:
+		return resources, nil
+	case ctx.Err() != nil && len(remaining) > 0:
+		// The attempt ran out of time. The remaining resources are picked up by the next attempt.
+		return resources, nil
+	default:
+		return resources, err
+	}
+}
+
+// awsDeprovisionFilters returns the tag filters matching the resources of the cluster. These are the
+// filters passed to aws-tag-deprovision by the uninstall job.
+func awsDeprovisionFilters(clusterDeprovision *hivev1.ClusterDeprovision) []awsdestroy.Filter {
+	filters := []awsdestroy.Filter{
+		{fmt.Sprintf("kubernetes.io/cluster/%s", clusterDeprovision.Spec.InfraID): "owned"},
+	}
+	if len(clusterDeprovision.Spec.ClusterID) > 0 {
+		// Also cleanup anything with the tag for the legacy cluster ID (credentials still using this for example)
+		filters = append(filters, awsdestroy.Filter{"openshiftClusterID": clusterDeprovision.Spec.ClusterID})
+	}
+	return filters
+}
+
+// arnErrorHook forwards the log entries of the AWS destroyer to the controller logger, and records the last
+// error logged for each resource. The destroyer logs failures to delete a resource at warn or debug level
+// with the ARN of the resource in the "arn" field.
+type arnErrorHook struct {
+	logger log.FieldLogger
+
+	mutex  sync.Mutex
+	count  int
+	errors map[string]arnError
+}
+
+type arnError struct {
+	message string
+	// seq orders the errors by the time they were logged
+	seq int
+}
+
+func (h *arnErrorHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *arnErrorHook) Fire(entry *log.Entry) error {
+	if arnString, ok := entry.Data["arn"].(string); ok && (entry.Level == log.WarnLevel || entry.Level == log.DebugLevel) {
+		message := entry.Message
+		if err, ok := entry.Data[log.ErrorKey].(error); ok {
+			message = fmt.Sprintf("%s: %v", message, err)
+		}
+		h.mutex.Lock()
+		if h.errors == nil {
+			h.errors = map[string]arnError{}
+		}
+		h.count++
+		h.errors[arnString] = arnError{message: message, seq: h.count}
+		h.mutex.Unlock()
+	}
+	h.logger.WithFields(entry.Data).Log(entry.Level, entry.Message)
+	return nil
+}
+
+// remainingResources groups the remaining resources by type, along with the last error recorded for a
+// resource of each type.
+func (h *arnErrorHook) remainingResources(arns []string) []hivev1.DeprovisionResourceStatus {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	byType := map[string]*hivev1.DeprovisionResourceStatus{}
+	lastSeq := map[string]int{}
+	for _, arnString := range arns {
+		resourceType := awsResourceType(arnString)
+		status, ok := byType[resourceType]
+		if !ok {
+			status = &hivev1.DeprovisionResourceStatus{Type: resourceType}
+			byType[resourceType] = status
+		}
+		status.Remaining++
+		if e, ok := h.errors[arnString]; ok && e.seq > lastSeq[resourceType] {
+			status.LastError = e.message
+			lastSeq[resourceType] = e.seq
+		}
+	}
+
+	resources := make([]hivev1.DeprovisionResourceStatus, 0, len(byType))
+	for _, status := range byType {
+		resources = append(resources, *status)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Type < resources[j].Type })
+	return resources
+}
+
+// awsResourceType returns the type of the resource identified by an ARN, such as "ec2:instance" for
+// "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789abcdef0". Resources without a type in their
+// ARN, like S3 buckets, are identified by their service.
+func awsResourceType(arnString string) string {
+	parsed, err := arn.Parse(arnString)
+	if err != nil {
+		return "unknown"
+	}
+	if i := strings.IndexAny(parsed.Resource, "/:"); i >= 0 {
+		return parsed.Service + ":" + parsed.Resource[:i]
+	}
+	return parsed.Service
+}
+
 func getAWSClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
-	options := awsclient.Options{
+	return awsclient.New(c, awsClientOptions(cd))
+}
+
+func getAWSSession(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (*session.Session, error) {
+	return awsclient.NewSession(c, awsClientOptions(cd))
+}
+
+func awsClientOptions(cd *hivev1.ClusterDeprovision) awsclient.Options {
+	return awsclient.Options{
 		Region: cd.Spec.Platform.AWS.Region,
 		CredentialsSource: awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
@@ -68,6 +232,4 @@ func getAWSClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.Fie
 			},
 		},
 	}
-
-	return awsclient.New(c, options)
 }
@@ -0,0 +1,131 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	azuresession "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypesazure "github.com/openshift/installer/pkg/types/azure"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	"github.com/openshift/hive/contrib/pkg/deprovision"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// azureStagePrefix prefixes the entries logged by the installer's Azure destroyer when it starts a stage.
+	azureStagePrefix = "deleting "
+)
+
+func init() {
+	registerActuator(&azureActuator{
+		azureClientFn: getAzureClient,
+		destroyerFn:   newAzureDestroyer,
+	})
+}
+
+// Ensure azureActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &azureActuator{}
+
+// Ensure azureActuator implements the Deprovisioner interface. This will fail at compile time when false.
+var _ Deprovisioner = &azureActuator{}
+
+type azureActuator struct {
+	// azureClientFn is the function to build an Azure client, here for testing
+	azureClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (azureclient.Client, error)
+	// destroyerFn is the function to build the installer's Azure destroyer, here for testing
+	destroyerFn func(*hivev1.ClusterDeprovision, client.Client, *log.Logger) (providers.Destroyer, error)
+
+	runs destroyerRuns
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *azureActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.Azure != nil
+}
+
+// TestCredentials ensures that the the Azure credentials are usable.
+func (a *azureActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	azureClient, err := a.azureClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+	_, err = azureClient.ListAllVirtualMachines(context.TODO(), "false")
+	return err
+}
+
+// Deprovision deletes the Azure resources of the cluster using the installer's destroyer, the same way the
+// deprovision azure command run by the uninstall job does.
+func (a *azureActuator) Deprovision(ctx context.Context, clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]hivev1.DeprovisionResourceStatus, error) {
+	return a.runs.wait(ctx, clusterDeprovision, func(destroyerLogger *log.Logger) (providers.Destroyer, error) {
+		return a.destroyerFn(clusterDeprovision, c, destroyerLogger)
+	}, newAzureStageErrorParser(), logger)
+}
+
+// newAzureStageErrorParser returns a parser of the entries logged by the Azure destroyer. The destroyer logs the
+// start of each stage, such as "deleting resource group", and then the errors of the stage as plain entries.
+func newAzureStageErrorParser() stageErrorParser {
+	stage := ""
+	return func(entry *log.Entry) (string, int, bool) {
+		if entry.Level != log.DebugLevel || len(entry.Data) > 0 {
+			return "", 0, false
+		}
+		if strings.HasPrefix(entry.Message, azureStagePrefix) {
+			stage = strings.TrimPrefix(entry.Message, azureStagePrefix)
+			return "", 0, false
+		}
+		if stage == "" || strings.HasPrefix(entry.Message, "already deleted") || strings.HasPrefix(entry.Message, "removing ") {
+			return "", 0, false
+		}
+		return stage, 1, true
+	}
+}
+
+func getAzureCredentialsSecret(cd *hivev1.ClusterDeprovision, c client.Client) (*corev1.Secret, error) {
+	if cd.Spec.Platform.Azure.CredentialsSecretRef == nil {
+		return nil, errors.New("no Azure credentials secret set in ClusterDeprovision")
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Azure.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch Azure credentials secret")
+	}
+	return secret, nil
+}
+
+func getAzureClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (azureclient.Client, error) {
+	secret, err := getAzureCredentialsSecret(cd, c)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get Azure credentials")
+		return nil, err
+	}
+	return azureclient.NewClientFromSecret(secret, azureCloudName(cd).Name())
+}
+
+func newAzureDestroyer(cd *hivev1.ClusterDeprovision, c client.Client, logger *log.Logger) (providers.Destroyer, error) {
+	secret, err := getAzureCredentialsSecret(cd, c)
+	if err != nil {
+		return nil, err
+	}
+	credentials := &azuresession.Credentials{}
+	if err := json.Unmarshal(secret.Data[constants.AzureCredentialsName], credentials); err != nil {
+		return nil, errors.Wrap(err, "failed to parse Azure credentials")
+	}
+	return deprovision.NewAzureDestroyer(logger, cd.Spec.InfraID, installertypesazure.CloudEnvironment(azureCloudName(cd)), credentials)
+}
+
+func azureCloudName(cd *hivev1.ClusterDeprovision) hivev1azure.CloudEnvironment {
+	if cd.Spec.Platform.Azure.CloudName != nil {
+		return *cd.Spec.Platform.Azure.CloudName
+	}
+	return hivev1azure.PublicCloud
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -41,6 +43,13 @@ const (
 	jobHashAnnotation             = "hive.openshift.io/jobhash"
 	authenticationFailedReason    = "AuthenticationFailed"
 	authenticationSucceededReason = "AuthenticationSucceeded"
+
+	// deprovisionAttemptTimeout bounds the time an attempt spends deleting cloud resources before its progress
+	// is recorded.
+	deprovisionAttemptTimeout = 5 * time.Minute
+
+	// deprovisionRequeueInterval is how often a running deprovision attempt is checked for completion.
+	deprovisionRequeueInterval = 30 * time.Second
 )
 
 var (
@@ -51,6 +60,13 @@ var (
 			Buckets: []float64{60, 300, 600, 1200, 1800, 2400, 3000, 3600},
 		},
 	)
+	metricDeprovisionInControllerDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "hive_cluster_deprovision_in_controller_duration_seconds",
+			Help:    "Distribution of the time taken by the controller to delete the cloud resources of clusters, from the creation of the ClusterDeprovision.",
+			Buckets: []float64{60, 300, 600, 1200, 1800, 2400, 3000, 3600},
+		},
+	)
 
 	// actuators is a list of available actuators for this controller
 	// It is populated via the registerActuator function
@@ -66,6 +82,7 @@ func registerActuator(a Actuator) {
 
 func init() {
 	metrics.Registry.MustRegister(metricUninstallJobDuration)
+	metrics.Registry.MustRegister(metricDeprovisionInControllerDuration)
 }
 
 // Add creates a new ClusterDeprovision Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -96,10 +113,21 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (re
 			return nil, err
 		}
 	}
+	deprovisionInController := false
+	if val, ok := os.LookupEnv(constants.DeprovisionInControllerEnvVar); ok {
+		var err error
+		deprovisionInController, err = strconv.ParseBool(val)
+		if err != nil {
+			log.WithError(err).WithField(constants.DeprovisionInControllerEnvVar, os.Getenv(constants.DeprovisionInControllerEnvVar)).
+				Error("error parsing bool from env var")
+			return nil, err
+		}
+	}
 	return &ReconcileClusterDeprovision{
-		Client:               controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		scheme:               mgr.GetScheme(),
-		deprovisionsDisabled: deprovisionsDisabled,
+		Client:                  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:                  mgr.GetScheme(),
+		deprovisionsDisabled:    deprovisionsDisabled,
+		deprovisionInController: deprovisionInController,
 	}, nil
 }
 
@@ -143,6 +171,24 @@ type ReconcileClusterDeprovision struct {
 	client.Client
 	scheme               *runtime.Scheme
 	deprovisionsDisabled bool
+
+	// deprovisionInController is true when the cloud resources of clusters are deleted from within the controller
+	// for the platforms whose actuator is a Deprovisioner.
+	deprovisionInController bool
+
+	// attempts are the deprovision attempts running in the background, by ClusterDeprovision.
+	attempts      map[types.NamespacedName]*deprovisionAttempt
+	attemptsMutex sync.Mutex
+
+	// runAttempt runs a deprovision attempt in the background, here for testing
+	runAttempt func(func())
+}
+
+// deprovisionAttempt is a deprovision attempt running in the background. done is closed once it finishes.
+type deprovisionAttempt struct {
+	done      chan struct{}
+	resources []hivev1.DeprovisionResourceStatus
+	err       error
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeprovision object and makes changes based on the state read
@@ -266,6 +312,21 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		}
 	}
 
+	if deprovisioner, ok := actuator.(Deprovisioner); ok && r.deprovisionInController {
+		// Deprovision from within the controller, unless an uninstall job was already launched for this
+		// deprovision, in which case it is left to complete.
+		existingJob := &batchv1.Job{}
+		err := r.Get(context.TODO(), types.NamespacedName{Name: install.GetUninstallJobName(instance.Name), Namespace: instance.Namespace}, existingJob)
+		switch {
+		case errors.IsNotFound(err):
+			return r.deprovision(instance, deprovisioner, rLog)
+		case err != nil:
+			rLog.WithError(err).Error("error getting uninstall job")
+			return reconcile.Result{}, err
+		}
+		rLog.Debug("uninstall job exists, continuing with it")
+	}
+
 	extraEnvVars := getAWSServiceProviderEnvVars(instance, instance.Name)
 
 	if err := install.CopyAWSServiceProviderSecret(r.Client, instance.Namespace, extraEnvVars, instance, r.scheme); err != nil {
@@ -411,6 +472,103 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 	return reconcile.Result{}, nil
 }
 
+// deprovision deletes the cloud resources of the cluster from within the controller. The resources are deleted by
+// attempts running in the background, each bounded by deprovisionAttemptTimeout, so that reconciles do not block on
+// the cloud. The deprovision is requeued until the running attempt finishes, and the resources still remaining to
+// be deleted are then recorded in status. Attempts are started until no resources remain.
+func (r *ReconcileClusterDeprovision) deprovision(instance *hivev1.ClusterDeprovision, deprovisioner Deprovisioner, logger log.FieldLogger) (reconcile.Result, error) {
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	attempt := r.startAttempt(key, instance.DeepCopy(), deprovisioner, logger)
+	select {
+	case <-attempt.done:
+		r.attemptsMutex.Lock()
+		delete(r.attempts, key)
+		r.attemptsMutex.Unlock()
+	default:
+		logger.Debug("deprovision attempt running")
+		return reconcile.Result{RequeueAfter: deprovisionRequeueInterval}, nil
+	}
+
+	remaining := 0
+	for _, resource := range attempt.resources {
+		remaining += resource.Remaining
+	}
+	instance.Status.ResourcesRemaining = remaining
+	instance.Status.BlockingResources = nil
+	if len(attempt.resources) > 0 {
+		instance.Status.BlockingResources = attempt.resources
+	}
+
+	switch {
+	case attempt.err != nil:
+		logger.WithError(attempt.err).Warn("deprovision attempt failed")
+		instance.Status.Conditions, _ = controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+			instance.Status.Conditions,
+			hivev1.DeprovisionFailedClusterDeprovisionCondition,
+			corev1.ConditionTrue,
+			"DeprovisionError",
+			attempt.err.Error(),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	case remaining > 0:
+		logger.WithField("resourcesRemaining", remaining).Info("deprovision attempt finished with resources remaining")
+	default:
+		logger.Info("deprovision successful, setting completed status")
+		instance.Status.Conditions, _ = controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+			instance.Status.Conditions,
+			hivev1.DeprovisionFailedClusterDeprovisionCondition,
+			corev1.ConditionFalse,
+			"DeprovisionCompleted",
+			"Deprovision has succeeded",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		instance.Status.Completed = true
+	}
+
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
+		return reconcile.Result{}, err
+	}
+	if attempt.err != nil {
+		return reconcile.Result{}, attempt.err
+	}
+	if remaining > 0 {
+		// Start the next attempt right away.
+		return reconcile.Result{Requeue: true}, nil
+	}
+	duration := time.Since(instance.CreationTimestamp.Time)
+	logger.WithField("duration", duration.Seconds()).Debug("deprovision completed")
+	metricDeprovisionInControllerDuration.Observe(duration.Seconds())
+	return reconcile.Result{}, nil
+}
+
+// startAttempt returns the deprovision attempt running for the ClusterDeprovision, starting one if none is.
+func (r *ReconcileClusterDeprovision) startAttempt(key types.NamespacedName, instance *hivev1.ClusterDeprovision, deprovisioner Deprovisioner, logger log.FieldLogger) *deprovisionAttempt {
+	r.attemptsMutex.Lock()
+	defer r.attemptsMutex.Unlock()
+	if attempt, ok := r.attempts[key]; ok {
+		return attempt
+	}
+	if r.attempts == nil {
+		r.attempts = map[types.NamespacedName]*deprovisionAttempt{}
+	}
+	attempt := &deprovisionAttempt{done: make(chan struct{})}
+	r.attempts[key] = attempt
+
+	logger.Info("deprovisioning cluster resources")
+	runAttempt := r.runAttempt
+	if runAttempt == nil {
+		runAttempt = func(f func()) { go f() }
+	}
+	runAttempt(func() {
+		defer close(attempt.done)
+		ctx, cancel := context.WithTimeout(context.Background(), deprovisionAttemptTimeout)
+		defer cancel()
+		attempt.resources, attempt.err = deprovisioner.Deprovision(ctx, instance, r.Client, logger)
+	})
+	return attempt
+}
+
 func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
 	return []*controllerutils.OwnershipUniqueKey{
 		{
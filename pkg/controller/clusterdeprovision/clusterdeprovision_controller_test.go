@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -23,11 +24,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	awsdestroy "github.com/openshift/installer/pkg/destroy/aws"
+	"github.com/openshift/installer/pkg/destroy/providers"
+
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/azureclient"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/hive/pkg/install"
 )
 
@@ -58,6 +64,18 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 		validate                       func(t *testing.T, c client.Client)
 		expectErr                      bool
 		deprovisionsDisabled           bool
+		deprovisionInController        bool
+		// runUninstaller stands in for the installer's AWS destroyer when deprovisioning in the controller.
+		// When unset, the destroyer deletes all resources.
+		runUninstaller func(*awsdestroy.ClusterUninstaller, context.Context) ([]string, error)
+		// runDestroyer stands in for the installer's GCP and Azure destroyers when deprovisioning in the controller.
+		// When unset, the destroyers delete all resources.
+		runDestroyer         func(*log.Logger) error
+		mockGCPCredentials   bool
+		mockAzureCredentials bool
+		// attemptRunning leaves the deprovision attempt running in the background.
+		attemptRunning bool
+		expectRequeue  bool
 	}{
 		{
 			name: "no-op deleting",
@@ -107,11 +125,152 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateNoJobExists(t, c)
 			},
 		},
+		{
+			name:                    "deprovision in controller",
+			deprovision:             testClusterDeprovision(),
+			deployment:              testDeletedClusterDeployment(),
+			mockGetCallerIdentity:   true,
+			deprovisionInController: true,
+			runUninstaller: func(uninstaller *awsdestroy.ClusterUninstaller, ctx context.Context) ([]string, error) {
+				assert.Equal(t, []awsdestroy.Filter{
+					{"kubernetes.io/cluster/test-infra-id": "owned"},
+					{"openshiftClusterID": "test-cluster-id"},
+				}, uninstaller.Filters, "unexpected destroyer filters")
+				assert.Equal(t, "us-east-1", uninstaller.Region, "unexpected destroyer region")
+				return nil, nil
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateCompleted(t, c)
+				validateBlockingResources(t, c, 0, nil)
+			},
+		},
+		{
+			name:                    "deprovision in controller attempt running",
+			deprovision:             testClusterDeprovision(),
+			deployment:              testDeletedClusterDeployment(),
+			mockGetCallerIdentity:   true,
+			deprovisionInController: true,
+			attemptRunning:          true,
+			expectRequeue:           true,
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateNotCompleted(t, c)
+			},
+		},
+		{
+			name:                    "deprovision in controller with resources remaining",
+			deprovision:             testClusterDeprovision(),
+			deployment:              testDeletedClusterDeployment(),
+			mockGetCallerIdentity:   true,
+			deprovisionInController: true,
+			expectRequeue:           true,
+			runUninstaller: func(uninstaller *awsdestroy.ClusterUninstaller, ctx context.Context) ([]string, error) {
+				uninstaller.Logger.WithField("arn", "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1").Debug(fmt.Errorf("first vpc error"))
+				uninstaller.Logger.WithField("arn", "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-2").Warn(fmt.Errorf("second vpc error"))
+				uninstaller.Logger.WithField("arn", "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-1").Debug(fmt.Errorf("deleted subnet error"))
+				uninstaller.Logger.WithField("arn", "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1").Info("not an error")
+				return []string{
+					"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-2",
+					"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1",
+					"arn:aws:s3:::test-bucket",
+				}, nil
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateNotCompleted(t, c)
+				validateBlockingResources(t, c, 3, []hivev1.DeprovisionResourceStatus{
+					{Type: "ec2:vpc", Remaining: 2, LastError: "second vpc error"},
+					{Type: "s3", Remaining: 1},
+				})
+			},
+		},
+		{
+			name:                    "deprovision in controller fails",
+			deprovision:             testClusterDeprovision(),
+			deployment:              testDeletedClusterDeployment(),
+			mockGetCallerIdentity:   true,
+			deprovisionInController: true,
+			runUninstaller: func(uninstaller *awsdestroy.ClusterUninstaller, ctx context.Context) ([]string, error) {
+				return nil, fmt.Errorf("cannot destroy cluster")
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateNotCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.DeprovisionFailedClusterDeprovisionCondition,
+						Reason: "DeprovisionError",
+						Status: corev1.ConditionTrue,
+					},
+				})
+			},
+			expectErr: true,
+		},
 		{
 			name:                  "create uninstall job",
 			deprovision:           testClusterDeprovision(),
 			deployment:            testDeletedClusterDeployment(),
 			mockGetCallerIdentity: true,
+			runUninstaller: func(*awsdestroy.ClusterUninstaller, context.Context) ([]string, error) {
+				t.Error("unexpected deprovision in controller")
+				return nil, nil
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+			},
+		},
+		{
+			name:                    "deprovision GCP in controller",
+			deprovision:             testGCPClusterDeprovision(),
+			deployment:              testDeletedClusterDeployment(),
+			mockGCPCredentials:      true,
+			deprovisionInController: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateCompleted(t, c)
+				validateBlockingResources(t, c, 0, nil)
+			},
+		},
+		{
+			name:                    "deprovision Azure in controller fails",
+			deprovision:             testAzureClusterDeprovision(),
+			deployment:              testDeletedClusterDeployment(),
+			mockAzureCredentials:    true,
+			deprovisionInController: true,
+			runDestroyer: func(logger *log.Logger) error {
+				logger.Debug("deleting resource group")
+				logger.Debug("resource group is in use")
+				return fmt.Errorf("unable to delete resource group")
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateNotCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.DeprovisionFailedClusterDeprovisionCondition,
+						Reason: "DeprovisionError",
+						Status: corev1.ConditionTrue,
+					},
+				})
+			},
+			expectErr: true,
+		},
+		{
+			name:                    "create uninstall job for platform without deprovisioner",
+			deprovisionInController: true,
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Spec.Platform.AWS = nil
+				req.Spec.Platform.OpenStack = &hivev1.OpenStackClusterDeprovision{
+					Cloud: "openstack",
+					CredentialsSecretRef: &corev1.LocalObjectReference{
+						Name: "openstack-creds",
+					},
+				}
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
 			validate: func(t *testing.T, c client.Client) {
 				validateJobExists(t, c)
 			},
@@ -291,20 +450,67 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					GetCallerIdentity(gomock.Any()).
 					Return(nil, test.expectedGetCallerIdentityError)
 			}
+			if test.mockGCPCredentials {
+				mocks.mockGCPClient.EXPECT().GetComputeProject().Return(nil, nil)
+			}
+			if test.mockAzureCredentials {
+				mocks.mockAzureClient.EXPECT().ListAllVirtualMachines(gomock.Any(), "false")
+			}
 
 			r := &ReconcileClusterDeprovision{
-				Client:               mocks.fakeKubeClient,
-				scheme:               scheme.Scheme,
-				deprovisionsDisabled: test.deprovisionsDisabled,
+				Client:                  mocks.fakeKubeClient,
+				scheme:                  scheme.Scheme,
+				deprovisionsDisabled:    test.deprovisionsDisabled,
+				deprovisionInController: test.deprovisionInController,
+				runAttempt: func(f func()) {
+					if !test.attemptRunning {
+						f()
+					}
+				},
 			}
 
 			// Save the list of actuators so that it can be restored at the end of this test
 			actuatorsSaved := actuators
-			actuators = []Actuator{&awsActuator{awsClientFn: func(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
-				return mocks.mockAWSClient, nil
-			}}}
+			runUninstaller := test.runUninstaller
+			if runUninstaller == nil {
+				runUninstaller = func(*awsdestroy.ClusterUninstaller, context.Context) ([]string, error) {
+					return nil, nil
+				}
+			}
+			actuators = []Actuator{&awsActuator{
+				awsClientFn: func(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
+					return mocks.mockAWSClient, nil
+				},
+				awsSessionFn: func(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (*session.Session, error) {
+					return nil, nil
+				},
+				runUninstallerFn: runUninstaller,
+			}}
+			runDestroyer := test.runDestroyer
+			if runDestroyer == nil {
+				runDestroyer = func(*log.Logger) error {
+					return nil
+				}
+			}
+			destroyerFn := func(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger *log.Logger) (providers.Destroyer, error) {
+				return &fakeDestroyer{run: func() error { return runDestroyer(logger) }}, nil
+			}
+			actuators = append(actuators,
+				&gcpActuator{
+					gcpClientFn: func(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (gcpclient.Client, error) {
+						return mocks.mockGCPClient, nil
+					},
+					destroyerFn: destroyerFn,
+				},
+				&azureActuator{
+					azureClientFn: func(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (azureclient.Client, error) {
+						return mocks.mockAzureClient, nil
+					},
+					destroyerFn: destroyerFn,
+				},
+			)
 
-			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      testName,
 					Namespace: testNamespace,
@@ -323,6 +529,7 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			} else {
 				assert.Nil(t, err, "Unexpected error: %v", err)
 			}
+			assert.Equal(t, test.expectRequeue, result.Requeue || result.RequeueAfter > 0, "unexpected requeue")
 
 		})
 	}
@@ -349,6 +556,29 @@ func testClusterDeprovision() *hivev1.ClusterDeprovision {
 	}
 }
 
+func testGCPClusterDeprovision() *hivev1.ClusterDeprovision {
+	req := testClusterDeprovision()
+	req.Spec.Platform.AWS = nil
+	req.Spec.Platform.GCP = &hivev1.GCPClusterDeprovision{
+		Region: "us-east1",
+		CredentialsSecretRef: &corev1.LocalObjectReference{
+			Name: "gcp-creds",
+		},
+	}
+	return req
+}
+
+func testAzureClusterDeprovision() *hivev1.ClusterDeprovision {
+	req := testClusterDeprovision()
+	req.Spec.Platform.AWS = nil
+	req.Spec.Platform.Azure = &hivev1.AzureClusterDeprovision{
+		CredentialsSecretRef: &corev1.LocalObjectReference{
+			Name: "azure-creds",
+		},
+	}
+	return req
+}
+
 func testDeletedClusterDeployment() *hivev1.ClusterDeployment {
 	now := metav1.Now()
 	cd := testClusterDeployment()
@@ -457,3 +687,11 @@ func validateCompleted(t *testing.T, c client.Client) {
 		t.Errorf("request is expected to be in completed state")
 	}
 }
+
+func validateBlockingResources(t *testing.T, c client.Client, expectedRemaining int, expectedResources []hivev1.DeprovisionResourceStatus) {
+	req := &hivev1.ClusterDeprovision{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req)
+	require.NoError(t, err, "unexpected error getting ClusterDeprovision")
+	assert.Equal(t, expectedRemaining, req.Status.ResourcesRemaining, "unexpected number of resources remaining")
+	assert.Equal(t, expectedResources, req.Status.BlockingResources, "unexpected blocking resources")
+}
@@ -0,0 +1,158 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"io/ioutil"
+	"sort"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// destroyerRun is a run of an installer destroyer for a ClusterDeprovision. done is closed once it finishes.
+type destroyerRun struct {
+	done   chan struct{}
+	err    error
+	stages *stageErrorHook
+}
+
+// destroyerRuns are the runs of the installer destroyers that cannot be cancelled, by ClusterDeprovision. A run
+// outlives the deprovision attempt that started it, and the next attempts wait on it rather than starting another.
+type destroyerRuns struct {
+	mutex sync.Mutex
+	runs  map[types.NamespacedName]*destroyerRun
+}
+
+// wait waits for the destroyer run of the ClusterDeprovision to finish, starting one with the destroyer returned by
+// newDestroyer if none is running. If ctx is done first, the stages of the destroyer that logged errors since the
+// previous attempt are returned as the resources remaining to be deleted.
+func (r *destroyerRuns) wait(
+	ctx context.Context,
+	clusterDeprovision *hivev1.ClusterDeprovision,
+	newDestroyer func(*log.Logger) (providers.Destroyer, error),
+	parseStageError stageErrorParser,
+	logger log.FieldLogger,
+) ([]hivev1.DeprovisionResourceStatus, error) {
+	key := types.NamespacedName{Namespace: clusterDeprovision.Namespace, Name: clusterDeprovision.Name}
+
+	r.mutex.Lock()
+	run, ok := r.runs[key]
+	if !ok {
+		run = &destroyerRun{
+			done:   make(chan struct{}),
+			stages: &stageErrorHook{parse: parseStageError},
+		}
+		destroyer, err := newDestroyer(newDestroyerLogger(logger, run.stages))
+		if err != nil {
+			r.mutex.Unlock()
+			return nil, err
+		}
+		if r.runs == nil {
+			r.runs = map[types.NamespacedName]*destroyerRun{}
+		}
+		r.runs[key] = run
+		logger.Info("starting destroyer")
+		go func() {
+			defer close(run.done)
+			// ClusterQuota stomped in return
+			_, run.err = destroyer.Run()
+		}()
+	}
+	r.mutex.Unlock()
+
+	select {
+	case <-run.done:
+		r.mutex.Lock()
+		delete(r.runs, key)
+		r.mutex.Unlock()
+		return nil, run.err
+	case <-ctx.Done():
+		logger.Debug("destroyer still running")
+		resources := run.stages.remainingResources()
+		if len(resources) == 0 {
+			// The destroyer has not reported what it is blocked on yet. Resources remain until it finishes.
+			resources = []hivev1.DeprovisionResourceStatus{{Type: "unknown", Remaining: 1}}
+		}
+		return resources, nil
+	}
+}
+
+// newDestroyerLogger returns the logger of an installer destroyer. Its entries, including the debug entries carrying
+// deletion errors, reach the hooks regardless of the controller log level, and are then forwarded to logger.
+func newDestroyerLogger(logger log.FieldLogger, hooks ...log.Hook) *log.Logger {
+	destroyerLogger := &log.Logger{
+		Out:       ioutil.Discard,
+		Formatter: &log.TextFormatter{},
+		Hooks:     log.LevelHooks{},
+		Level:     log.DebugLevel,
+	}
+	for _, hook := range hooks {
+		destroyerLogger.AddHook(hook)
+	}
+	destroyerLogger.AddHook(&forwardHook{logger: logger})
+	return destroyerLogger
+}
+
+// forwardHook forwards log entries to another logger.
+type forwardHook struct {
+	logger log.FieldLogger
+}
+
+func (h *forwardHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *forwardHook) Fire(entry *log.Entry) error {
+	h.logger.WithFields(entry.Data).Log(entry.Level, entry.Message)
+	return nil
+}
+
+// stageErrorParser returns the stage of the destroyer a log entry reports an error for, along with the number of
+// resources still pending in the stage. ok is false for the entries not reporting an error.
+type stageErrorParser func(entry *log.Entry) (stage string, pending int, ok bool)
+
+// stageErrorHook records the last error logged by an installer destroyer for each of its stages.
+type stageErrorHook struct {
+	parse stageErrorParser
+
+	mutex  sync.Mutex
+	errors map[string]hivev1.DeprovisionResourceStatus
+}
+
+func (h *stageErrorHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *stageErrorHook) Fire(entry *log.Entry) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if stage, pending, ok := h.parse(entry); ok {
+		if h.errors == nil {
+			h.errors = map[string]hivev1.DeprovisionResourceStatus{}
+		}
+		h.errors[stage] = hivev1.DeprovisionResourceStatus{
+			Type:      stage,
+			Remaining: pending,
+			LastError: entry.Message,
+		}
+	}
+	return nil
+}
+
+// remainingResources returns the stages for which errors were logged since it was last called, sorted by stage.
+func (h *stageErrorHook) remainingResources() []hivev1.DeprovisionResourceStatus {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	resources := make([]hivev1.DeprovisionResourceStatus, 0, len(h.errors))
+	for _, status := range h.errors {
+		resources = append(resources, status)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Type < resources[j].Type })
+	h.errors = nil
+	return resources
+}
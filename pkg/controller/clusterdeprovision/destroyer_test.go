@@ -0,0 +1,96 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// fakeDestroyer stands in for an installer destroyer.
+type fakeDestroyer struct {
+	run func() error
+}
+
+func (d *fakeDestroyer) Run() (*installertypes.ClusterQuota, error) {
+	return nil, d.run()
+}
+
+func TestDestroyerRuns(t *testing.T) {
+	runs := &destroyerRuns{}
+	start := make(chan struct{})
+	logged := make(chan struct{})
+	finish := make(chan struct{})
+	started := 0
+	newDestroyer := func(logger *log.Logger) (providers.Destroyer, error) {
+		started++
+		return &fakeDestroyer{run: func() error {
+			<-start
+			logger.Debug("Instances: 2 items pending")
+			logger.Debug("Networks: network in use")
+			logger.Debug("Listing instances")
+			close(logged)
+			<-finish
+			return nil
+		}}, nil
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	logger := log.WithField("test", t.Name())
+
+	// The destroyer is started and outlives the attempt.
+	resources, err := runs.wait(cancelled, testGCPClusterDeprovision(), newDestroyer, parseGCPStageError, logger)
+	require.NoError(t, err, "unexpected error")
+	assert.Equal(t, []hivev1.DeprovisionResourceStatus{{Type: "unknown", Remaining: 1}}, resources, "unexpected resources remaining")
+
+	// The next attempt waits on the same destroyer.
+	close(start)
+	<-logged
+	resources, err = runs.wait(cancelled, testGCPClusterDeprovision(), newDestroyer, parseGCPStageError, logger)
+	require.NoError(t, err, "unexpected error")
+	assert.Equal(t, []hivev1.DeprovisionResourceStatus{
+		{Type: "Instances", Remaining: 2, LastError: "Instances: 2 items pending"},
+		{Type: "Networks", Remaining: 1, LastError: "Networks: network in use"},
+	}, resources, "unexpected resources remaining")
+
+	close(finish)
+	resources, err = runs.wait(context.Background(), testGCPClusterDeprovision(), newDestroyer, parseGCPStageError, logger)
+	assert.NoError(t, err, "unexpected error")
+	assert.Empty(t, resources, "unexpected resources remaining")
+	assert.Equal(t, 1, started, "unexpected number of destroyers started")
+	assert.Empty(t, runs.runs, "unexpected destroyer runs left")
+}
+
+func TestParseAzureStageError(t *testing.T) {
+	parse := newAzureStageErrorParser()
+	entries := []struct {
+		message       string
+		level         log.Level
+		fields        log.Fields
+		expectedStage string
+	}{
+		{message: "not in a stage", level: log.DebugLevel},
+		{message: "deleting public records", level: log.DebugLevel},
+		{message: "zone not found", level: log.DebugLevel, expectedStage: "public records"},
+		{message: "deleting resource group", level: log.DebugLevel},
+		{message: "already deleted", level: log.DebugLevel},
+		{message: "deleted", level: log.InfoLevel},
+		{message: "deleted", level: log.DebugLevel, fields: log.Fields{"resource group": "test-rg"}},
+		{message: "resource group is in use", level: log.DebugLevel, expectedStage: "resource group"},
+	}
+	for _, e := range entries {
+		stage, pending, ok := parse(&log.Entry{Message: e.message, Level: e.level, Data: e.fields})
+		assert.Equal(t, e.expectedStage, stage, "unexpected stage for %q", e.message)
+		assert.Equal(t, e.expectedStage != "", ok, "unexpected error parsed for %q", e.message)
+		if ok {
+			assert.Equal(t, 1, pending, "unexpected pending for %q", e.message)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/deprovision"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+const (
+	// gcpCredentialsEnvVar is the first environment variable the installer's GCP destroyer reads its credentials from.
+	gcpCredentialsEnvVar = "GOOGLE_CREDENTIALS"
+)
+
+var (
+	// gcpStages are the stages of the installer's GCP destroyer, which logs an error for each stage not completed.
+	gcpStages = sets.NewString(
+		"Stop instances",
+		"Cloud controller resources",
+		"Instances",
+		"Disks",
+		"Service accounts",
+		"Images",
+		"DNS",
+		"Buckets",
+		"Routes",
+		"Firewalls",
+		"Addresses",
+		"Target Pools",
+		"Instance groups",
+		"Forwarding rules",
+		"Backend services",
+		"Health checks",
+		"HTTP Health checks",
+		"Routers",
+		"Subnetworks",
+		"Networks",
+	)
+
+	gcpPendingRegexp = regexp.MustCompile(`^(\d+) items pending$`)
+
+	// gcpCredentialsMutex serializes the use of the environment to pass credentials to the GCP destroyers.
+	gcpCredentialsMutex sync.Mutex
+)
+
+func init() {
+	registerActuator(&gcpActuator{
+		gcpClientFn: getGCPClient,
+		destroyerFn: newGCPDestroyer,
+	})
+}
+
+// Ensure gcpActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &gcpActuator{}
+
+// Ensure gcpActuator implements the Deprovisioner interface. This will fail at compile time when false.
+var _ Deprovisioner = &gcpActuator{}
+
+type gcpActuator struct {
+	// gcpClientFn is the function to build a GCP client, here for testing
+	gcpClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (gcpclient.Client, error)
+	// destroyerFn is the function to build the installer's GCP destroyer, here for testing
+	destroyerFn func(*hivev1.ClusterDeprovision, client.Client, *log.Logger) (providers.Destroyer, error)
+
+	runs destroyerRuns
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *gcpActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.GCP != nil
+}
+
+// TestCredentials ensures that the the GCP credentials are usable.
+func (a *gcpActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	gcpClient, err := a.gcpClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+	_, err = gcpClient.GetComputeProject()
+	return err
+}
+
+// Deprovision deletes the GCP resources of the cluster using the installer's destroyer, the same way the
+// deprovision gcp command run by the uninstall job does.
+func (a *gcpActuator) Deprovision(ctx context.Context, clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]hivev1.DeprovisionResourceStatus, error) {
+	return a.runs.wait(ctx, clusterDeprovision, func(destroyerLogger *log.Logger) (providers.Destroyer, error) {
+		return a.destroyerFn(clusterDeprovision, c, destroyerLogger)
+	}, parseGCPStageError, logger)
+}
+
+// parseGCPStageError parses the "<stage>: <error>" entries logged by the GCP destroyer for the stages not completed.
+func parseGCPStageError(entry *log.Entry) (string, int, bool) {
+	if entry.Level != log.DebugLevel || len(entry.Data) > 0 {
+		return "", 0, false
+	}
+	parts := strings.SplitN(entry.Message, ": ", 2)
+	if len(parts) != 2 || !gcpStages.Has(parts[0]) {
+		return "", 0, false
+	}
+	pending := 1
+	if m := gcpPendingRegexp.FindStringSubmatch(parts[1]); m != nil {
+		pending, _ = strconv.Atoi(m[1])
+	}
+	return parts[0], pending, true
+}
+
+func getGCPCredentialsSecret(cd *hivev1.ClusterDeprovision, c client.Client) (*corev1.Secret, error) {
+	if cd.Spec.Platform.GCP.CredentialsSecretRef == nil {
+		return nil, errors.New("no GCP credentials secret set in ClusterDeprovision")
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.GCP.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch GCP credentials secret")
+	}
+	return secret, nil
+}
+
+func getGCPClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (gcpclient.Client, error) {
+	secret, err := getGCPCredentialsSecret(cd, c)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get GCP credentials")
+		return nil, err
+	}
+	return gcpclient.NewClientFromSecret(secret)
+}
+
+func newGCPDestroyer(cd *hivev1.ClusterDeprovision, c client.Client, logger *log.Logger) (providers.Destroyer, error) {
+	secret, err := getGCPCredentialsSecret(cd, c)
+	if err != nil {
+		return nil, err
+	}
+	projectID, err := gcpclient.ProjectIDFromSecret(secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get GCP project ID")
+	}
+	credentials := &gcpEnvCredentials{credentials: string(secret.Data[constants.GCPCredentialsName])}
+	logger.AddHook(credentials)
+	destroyer, err := deprovision.NewGCPDestroyer(logger, cd.Spec.InfraID, cd.Spec.Platform.GCP.Region, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return &gcpEnvCredentialsDestroyer{Destroyer: destroyer, credentials: credentials}, nil
+}
+
+// gcpEnvCredentialsDestroyer runs the installer's GCP destroyer with the credentials of the cluster. The destroyer
+// only reads its credentials from the environment, when it starts running and before logging anything. The
+// credentials are set in the environment until then, during which no other GCP destroyer starts.
+type gcpEnvCredentialsDestroyer struct {
+	providers.Destroyer
+	credentials *gcpEnvCredentials
+}
+
+func (d *gcpEnvCredentialsDestroyer) Run() (*installertypes.ClusterQuota, error) {
+	d.credentials.set()
+	defer d.credentials.unset()
+	return d.Destroyer.Run()
+}
+
+// gcpEnvCredentials sets GCP credentials in the environment, and unsets them on the first entry logged by the
+// destroyer it is a hook of.
+type gcpEnvCredentials struct {
+	credentials string
+
+	mutex sync.Mutex
+	isSet bool
+}
+
+func (e *gcpEnvCredentials) set() {
+	gcpCredentialsMutex.Lock()
+	os.Setenv(gcpCredentialsEnvVar, e.credentials)
+	e.mutex.Lock()
+	e.isSet = true
+	e.mutex.Unlock()
+}
+
+func (e *gcpEnvCredentials) unset() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if !e.isSet {
+		return
+	}
+	e.isSet = false
+	os.Unsetenv(gcpCredentialsEnvVar)
+	gcpCredentialsMutex.Unlock()
+}
+
+func (e *gcpEnvCredentials) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (e *gcpEnvCredentials) Fire(*log.Entry) error {
+	e.unset()
+	return nil
+}
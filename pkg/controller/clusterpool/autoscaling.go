@@ -0,0 +1,107 @@
+package clusterpool
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	defaultAutoscalingDemandWindow      = time.Hour
+	defaultAutoscalingScaleDownCooldown = 30 * time.Minute
+)
+
+// autoscale computes the size of the pool from the demand for clusters and records it in the autoscaling status of
+// the pool. The pool is scaled up as soon as the demand grows, but is only scaled down once the scale down cooldown
+// has elapsed since the last time the size changed. Returns the size of the pool, and how long until the size
+// should be recomputed because the demand or the cooldown will have changed by then. A zero duration means there is
+// no need to recompute the size until the pool or its claims change.
+func autoscale(clp *hivev1.ClusterPool, claims *claimCollection, now time.Time, logger log.FieldLogger) (int32, time.Duration) {
+	autoscaling := clp.Spec.Autoscaling
+	demandWindow := defaultAutoscalingDemandWindow
+	if autoscaling.DemandWindow != nil {
+		demandWindow = autoscaling.DemandWindow.Duration
+	}
+	scaleDownCooldown := defaultAutoscalingScaleDownCooldown
+	if autoscaling.ScaleDownCooldown != nil {
+		scaleDownCooldown = autoscaling.ScaleDownCooldown.Duration
+	}
+
+	demand, recheckAfter := claimDemand(claims, demandWindow, now)
+	desiredSize := demand + autoscaling.TargetHeadroom
+	if desiredSize > autoscaling.MaxSize {
+		desiredSize = autoscaling.MaxSize
+	}
+	if desiredSize < autoscaling.MinSize {
+		desiredSize = autoscaling.MinSize
+	}
+
+	logger = logger.WithFields(log.Fields{
+		"demand":      demand,
+		"desiredSize": desiredSize,
+	})
+	status := clp.Status.Autoscaling
+	if status == nil {
+		logger.Info("initializing autoscaled pool size")
+		clp.Status.Autoscaling = &hivev1.ClusterPoolAutoscalingStatus{
+			Size:          desiredSize,
+			Demand:        demand,
+			LastScaleTime: &metav1.Time{Time: now},
+		}
+		return desiredSize, recheckAfter
+	}
+	status.Demand = demand
+
+	switch {
+	case desiredSize > status.Size:
+		logger.WithField("size", status.Size).Info("scaling up pool")
+		status.Size = desiredSize
+		status.LastScaleTime = &metav1.Time{Time: now}
+	case desiredSize < status.Size:
+		if status.LastScaleTime != nil {
+			if cooldownRemaining := status.LastScaleTime.Add(scaleDownCooldown).Sub(now); cooldownRemaining > 0 {
+				logger.WithField("size", status.Size).WithField("cooldownRemaining", cooldownRemaining).
+					Debug("delaying scaling down pool until the scale down cooldown has elapsed")
+				return status.Size, minPositiveDuration(recheckAfter, cooldownRemaining)
+			}
+		}
+		logger.WithField("size", status.Size).Info("scaling down pool")
+		status.Size = desiredSize
+		status.LastScaleTime = &metav1.Time{Time: now}
+	}
+	return status.Size, recheckAfter
+}
+
+// claimDemand counts the claims created during the demand window that are no longer pending. Pending claims are not
+// counted as the pool creates clusters for them on top of its size. Returns the demand, and how long until the next
+// counted claim leaves the demand window, or zero if none will.
+func claimDemand(claims *claimCollection, demandWindow time.Duration, now time.Time) (int32, time.Duration) {
+	var demand int32
+	var nextExpiry time.Duration
+	windowStart := now.Add(-demandWindow)
+	for _, claim := range claims.byClaimName {
+		if claim.Spec.Namespace == "" {
+			continue
+		}
+		if claim.CreationTimestamp.Time.After(windowStart) {
+			demand++
+			nextExpiry = minPositiveDuration(nextExpiry, claim.CreationTimestamp.Sub(windowStart))
+		}
+	}
+	return demand, nextExpiry
+}
+
+// minPositiveDuration returns the smallest of the durations that are greater than zero, or zero if there are none.
+func minPositiveDuration(durations ...time.Duration) time.Duration {
+	var min time.Duration
+	for _, d := range durations {
+		if d > 0 && (min == 0 || d < min) {
+			min = d
+		}
+	}
+	return min
+}
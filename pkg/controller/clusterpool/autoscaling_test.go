@@ -0,0 +1,166 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestAutoscale(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	now := time.Now()
+	assignedClaim := func(name string, age time.Duration) *hivev1.ClusterClaim {
+		return testclaim.FullBuilder(testNamespace, name, scheme).Build(
+			testclaim.WithPool(testLeasePoolName),
+			testclaim.WithCluster("cluster-"+name),
+			testclaim.Generic(testgeneric.WithCreationTimestamp(now.Add(-age))),
+		)
+	}
+	pendingClaim := func(name string, age time.Duration) *hivev1.ClusterClaim {
+		return testclaim.FullBuilder(testNamespace, name, scheme).Build(
+			testclaim.WithPool(testLeasePoolName),
+			testclaim.Generic(testgeneric.WithCreationTimestamp(now.Add(-age))),
+		)
+	}
+
+	cases := []struct {
+		name                  string
+		pool                  *hivev1.ClusterPool
+		claims                []*hivev1.ClusterClaim
+		expectedSize          int32
+		expectedDemand        int32
+		expectedRecheckAfter  time.Duration
+		expectedLastScaleTime time.Time
+	}{
+		{
+			name:                  "initialize to min size",
+			pool:                  testcp.Build(testcp.WithAutoscaling(2, 10, 0)),
+			expectedSize:          2,
+			expectedLastScaleTime: now,
+		},
+		{
+			name: "initialize to demand",
+			pool: testcp.Build(testcp.WithAutoscaling(0, 10, 1)),
+			claims: []*hivev1.ClusterClaim{
+				assignedClaim("recent-1", 10*time.Minute),
+				assignedClaim("recent-2", 50*time.Minute),
+				assignedClaim("old", 2*time.Hour),
+				pendingClaim("pending", time.Minute),
+			},
+			expectedSize:          3,
+			expectedDemand:        2,
+			expectedRecheckAfter:  10 * time.Minute,
+			expectedLastScaleTime: now,
+		},
+		{
+			name: "demand capped at max size",
+			pool: testcp.Build(testcp.WithAutoscaling(0, 2, 1)),
+			claims: []*hivev1.ClusterClaim{
+				assignedClaim("recent-1", time.Minute),
+				assignedClaim("recent-2", time.Minute),
+			},
+			expectedSize:          2,
+			expectedDemand:        2,
+			expectedRecheckAfter:  59 * time.Minute,
+			expectedLastScaleTime: now,
+		},
+		{
+			name: "scale up immediately",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(1, 10, 0),
+				testcp.WithAutoscaledSize(1, now.Add(-time.Minute)),
+			),
+			claims: []*hivev1.ClusterClaim{
+				assignedClaim("recent-1", 30*time.Minute),
+				assignedClaim("recent-2", 30*time.Minute),
+			},
+			expectedSize:          2,
+			expectedDemand:        2,
+			expectedRecheckAfter:  30 * time.Minute,
+			expectedLastScaleTime: now,
+		},
+		{
+			name: "delay scale down during cooldown",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(1, 10, 0),
+				testcp.WithAutoscaledSize(3, now.Add(-20*time.Minute)),
+			),
+			expectedSize:          3,
+			expectedRecheckAfter:  10 * time.Minute,
+			expectedLastScaleTime: now.Add(-20 * time.Minute),
+		},
+		{
+			name: "scale down after cooldown",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(1, 10, 0),
+				testcp.WithAutoscaledSize(3, now.Add(-40*time.Minute)),
+			),
+			expectedSize:          1,
+			expectedLastScaleTime: now,
+		},
+		{
+			name: "custom demand window and cooldown",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(0, 10, 0),
+				testcp.WithAutoscaledSize(3, now.Add(-20*time.Minute)),
+				func(clp *hivev1.ClusterPool) {
+					clp.Spec.Autoscaling.DemandWindow = &metav1.Duration{Duration: 2 * time.Hour}
+					clp.Spec.Autoscaling.ScaleDownCooldown = &metav1.Duration{Duration: 10 * time.Minute}
+				},
+			),
+			claims: []*hivev1.ClusterClaim{
+				assignedClaim("recent", 90*time.Minute),
+				assignedClaim("old", 3*time.Hour),
+			},
+			expectedSize:          1,
+			expectedDemand:        1,
+			expectedRecheckAfter:  30 * time.Minute,
+			expectedLastScaleTime: now,
+		},
+		{
+			name: "size unchanged",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(0, 10, 1),
+				testcp.WithAutoscaledSize(2, now.Add(-time.Minute)),
+			),
+			claims: []*hivev1.ClusterClaim{
+				assignedClaim("recent", 10*time.Minute),
+				pendingClaim("pending", 2*time.Hour),
+			},
+			expectedSize:          2,
+			expectedDemand:        1,
+			expectedRecheckAfter:  50 * time.Minute,
+			expectedLastScaleTime: now.Add(-time.Minute),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := &claimCollection{byClaimName: map[string]*hivev1.ClusterClaim{}}
+			for _, claim := range tc.claims {
+				claims.byClaimName[claim.Name] = claim
+			}
+			size, recheckAfter := autoscale(tc.pool, claims, now, log.WithField("controller", "clusterpool"))
+			assert.Equal(t, tc.expectedSize, size, "unexpected size")
+			assert.Equal(t, tc.expectedRecheckAfter, recheckAfter, "unexpected recheck duration")
+			status := tc.pool.Status.Autoscaling
+			require.NotNil(t, status, "expected autoscaling status")
+			assert.Equal(t, tc.expectedSize, status.Size, "unexpected size in status")
+			assert.Equal(t, tc.expectedDemand, status.Demand, "unexpected demand in status")
+			if assert.NotNil(t, status.LastScaleTime, "expected last scale time") {
+				assert.Equal(t, tc.expectedLastScaleTime, status.LastScaleTime.Time, "unexpected last scale time")
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"reflect"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -302,6 +303,13 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	clp.Status.Size = int32(len(cds.Unassigned(true)))
 	clp.Status.Standby = int32(len(cds.Standby()))
 	clp.Status.Ready = int32(len(cds.Assignable()))
+	poolSize := clp.Spec.Size
+	var autoscaleRequeueAfter time.Duration
+	if clp.Spec.Autoscaling != nil {
+		poolSize, autoscaleRequeueAfter = autoscale(clp, claims, time.Now(), logger)
+	} else {
+		clp.Status.Autoscaling = nil
+	}
 	if !reflect.DeepEqual(origStatus, &clp.Status) {
 		if err := r.Status().Update(context.Background(), clp); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
@@ -353,9 +361,9 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	availableCurrent -= toDel
 
 	// drift will indicate how many clusters we need to add or delete to get back to steady state
-	// of the pool's Size. This needs to take into account the clusters we're creating to satisfy
-	// the immediate demand of pending claims.
-	switch drift := len(cds.Unassigned(true)) - int(clp.Spec.Size) - len(claims.Unassigned()); {
+	// of the pool's Size (or the autoscaled size). This needs to take into account the clusters
+	// we're creating to satisfy the immediate demand of pending claims.
+	switch drift := len(cds.Unassigned(true)) - int(poolSize) - len(claims.Unassigned()); {
 	// activity quota exceeded, so no action
 	case availableCurrent <= 0:
 		logger.WithFields(log.Fields{
@@ -412,7 +420,7 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: autoscaleRequeueAfter}, nil
 }
 
 // reconcileRunningClusters ensures the oldest unassigned clusters are set to running, and the
@@ -681,6 +689,7 @@ func (r *ReconcileClusterPool) createCluster(
 		Annotations:           annotations,
 		InstallConfigTemplate: installConfigTemplate,
 		InstallAttemptsLimit:  clp.Spec.InstallAttemptsLimit,
+		ProvisionRetryPolicy:  clp.Spec.ProvisionRetryPolicy,
 		SkipMachinePools:      clp.Spec.SkipMachinePools,
 	}
 
@@ -688,6 +697,10 @@ func (r *ReconcileClusterPool) createCluster(
 		builder.HibernateAfter = &clp.Spec.HibernateAfter.Duration
 	}
 
+	if clp.Spec.HibernationConfig != nil {
+		builder.HibernationSchedule = clp.Spec.HibernationConfig.Schedule
+	}
+
 	objs, err := builder.Build()
 	if err != nil {
 		return nil, errors.Wrap(err, "error building resources")
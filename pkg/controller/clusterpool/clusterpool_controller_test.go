@@ -111,6 +111,9 @@ func TestReconcileClusterPool(t *testing.T) {
 		// (The clusterpool controller always sets this condition's Status to True.)
 		// Not checked if nil.
 		expectedClaimPendingReasons map[string]string
+		// Map, keyed by claim name, of expected Status.QueuePosition. Zero means the position is expected to be
+		// unset. Not checked if nil.
+		expectedClaimQueuePositions map[string]int32
 		expectPoolVersionChanged    bool
 	}{
 		{
@@ -134,6 +137,50 @@ func TestReconcileClusterPool(t *testing.T) {
 			},
 			expectedTotalClusters: 2,
 		},
+		{
+			name: "autoscaled size overrides size",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithAutoscaling(3, 5, 0)),
+			},
+			expectedTotalClusters: 3,
+		},
+		{
+			name: "autoscaled size follows claims",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithAutoscaling(0, 5, 1)),
+				cdBuilder("c1").Build(
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim-1"),
+					testcd.Running(),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("c1"),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Minute))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			// One cluster assigned, one to satisfy the pending claim, and two for the autoscaled size
+			expectedTotalClusters:    4,
+			expectedAssignedClaims:   1,
+			expectedAssignedCDs:      1,
+			expectedRunning:          2,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "autoscaled pool counts pending claims once",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithAutoscaling(0, 5, 0)),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			// One cluster for each pending claim, which are not part of the demand
+			expectedTotalClusters:    2,
+			expectedRunning:          2,
+			expectedUnassignedClaims: 2,
+		},
 		{
 			name: "poolVersion changes with Platform",
 			existing: []runtime.Object{
@@ -904,6 +951,59 @@ func TestReconcileClusterPool(t *testing.T) {
 				"test-claim-2": "ClusterAssigned",
 				"test-claim-3": "NoClusters",
 			},
+			expectedClaimQueuePositions: map[string]int32{
+				"test-claim-1": 0,
+				"test-claim-2": 0,
+				"test-claim-3": 1,
+			},
+		},
+		{
+			name: "assign to claims by priority",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(4)),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				unclaimedCDBuilder("c2").Build(testcd.Running()),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+				unclaimedCDBuilder("c4").Build(),
+				// Claims with a higher priority jump the queue; FIFO within the same priority
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second*4))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithPriority(-1),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second*3))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithPriority(10),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second*2))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-4", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second))),
+				),
+			},
+			expectedTotalClusters:    8,
+			expectedObservedSize:     4,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedAssignedCDs:      2,
+			expectedRunning:          4,
+			expectedUnassignedClaims: 2,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-1": "ClusterAssigned",
+				"test-claim-2": "NoClusters",
+				"test-claim-3": "ClusterAssigned",
+				"test-claim-4": "NoClusters",
+			},
+			expectedClaimQueuePositions: map[string]int32{
+				"test-claim-1": 0,
+				"test-claim-2": 2,
+				"test-claim-3": 0,
+				"test-claim-4": 1,
+			},
 		},
 		{
 			name: "do not assign to claims for other pools",
@@ -1550,6 +1650,15 @@ func TestReconcileClusterPool(t *testing.T) {
 						}
 					}
 				}
+				if test.expectedClaimQueuePositions != nil {
+					if position, ok := test.expectedClaimQueuePositions[claim.Name]; ok {
+						if position == 0 {
+							assert.Nil(t, claim.Status.QueuePosition, "unexpected queue position for claim %s", claim.Name)
+						} else if assert.NotNil(t, claim.Status.QueuePosition, "missing queue position for claim %s", claim.Name) {
+							assert.Equal(t, position, *claim.Status.QueuePosition, "wrong queue position for claim %s", claim.Name)
+						}
+					}
+				}
 				if claim.Spec.Namespace == "" {
 					actualUnassignedClaims++
 				} else {
@@ -67,11 +67,15 @@ func getAllClaimsForPool(c client.Client, pool *hivev1.ClusterPool, logger log.F
 			claimCol.byCDName[cdName] = ref
 		}
 	}
-	// Sort assignable claims by creationTimestamp for FIFO behavior.
-	sort.Slice(
+	// Sort assignable claims by priority, highest first, and then by creationTimestamp for FIFO behavior.
+	sort.SliceStable(
 		claimCol.unassigned,
 		func(i, j int) bool {
-			return claimCol.unassigned[i].CreationTimestamp.Before(&claimCol.unassigned[j].CreationTimestamp)
+			claimi, claimj := claimCol.unassigned[i], claimCol.unassigned[j]
+			if claimi.Spec.Priority != claimj.Spec.Priority {
+				return claimi.Spec.Priority > claimj.Spec.Priority
+			}
+			return claimi.CreationTimestamp.Before(&claimj.CreationTimestamp)
 		},
 	)
 
@@ -89,7 +93,7 @@ func (c *claimCollection) ByName(claimName string) *hivev1.ClusterClaim {
 }
 
 // Unassigned returns a list of claims that are not assigned to clusters yet. The list is sorted by
-// age, oldest first.
+// priority, highest first, and then by age, oldest first.
 func (c *claimCollection) Unassigned() []*hivev1.ClusterClaim {
 	return c.unassigned
 }
@@ -118,6 +122,7 @@ func (claims *claimCollection) Assign(c client.Client, claim *hivev1.ClusterClai
 				"Cluster assigned to ClusterClaim, awaiting claim",
 				controllerutils.UpdateConditionIfReasonOrMessageChange,
 			)
+			claimi.Status.QueuePosition = nil
 			if err := c.Status().Update(context.Background(), claimi); err != nil {
 				return err
 			}
@@ -695,7 +700,7 @@ func ensureClaimAssignment(c client.Client, claim *hivev1.ClusterClaim, claims *
 
 // assignClustersToClaims iterates over unassigned claims and assignable ClusterDeployments, in order (see
 // claimCollection.Unassigned and cdCollection.Assignable), assigning them to each other, stopping when the
-// first of the two lists is exhausted.
+// first of the two lists is exhausted. Claims left unassigned are told their position in the queue.
 func assignClustersToClaims(c client.Client, claims *claimCollection, cds *cdCollection, logger log.FieldLogger) error {
 	// ensureClaimAssignment modifies claims.unassigned and cds.assignable, so make a copy of the lists.
 	// copy() limits itself to the size of the destination
@@ -711,18 +716,24 @@ func assignClustersToClaims(c client.Client, claims *claimCollection, cds *cdCol
 			errs = append(errs, err)
 		}
 	}
-	// If any unassigned claims remain, mark their status accordingly
-	for _, claim := range claims.Unassigned() {
-		logger := logger.WithField("claim", claim.Name)
+	// If any unassigned claims remain, mark their status, including their position in the queue, accordingly
+	for i, claim := range claims.Unassigned() {
+		queuePosition := int32(i + 1)
+		logger := logger.WithField("claim", claim.Name).WithField("queuePosition", queuePosition)
 		logger.Debug("no clusters ready to assign to claim")
-		if conds, statusChanged := controllerutils.SetClusterClaimConditionWithChangeCheck(
+		conds, statusChanged := controllerutils.SetClusterClaimConditionWithChangeCheck(
 			claim.Status.Conditions,
 			hivev1.ClusterClaimPendingCondition,
 			corev1.ConditionTrue,
 			"NoClusters",
 			"No clusters in pool are ready to be claimed",
 			controllerutils.UpdateConditionIfReasonOrMessageChange,
-		); statusChanged {
+		)
+		if claim.Status.QueuePosition == nil || *claim.Status.QueuePosition != queuePosition {
+			claim.Status.QueuePosition = &queuePosition
+			statusChanged = true
+		}
+		if statusChanged {
 			claim.Status.Conditions = conds
 			if err := c.Status().Update(context.Background(), claim); err != nil {
 				logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
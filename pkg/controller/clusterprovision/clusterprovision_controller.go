@@ -3,6 +3,7 @@ package clusterprovision
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -63,11 +64,18 @@ var (
 	},
 		[]string{"cluster_type", "reason"},
 	)
+	metricInstallFailuresByCategory = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_install_failures_by_category_total",
+		Help: "Counter incremented every time we observe a failed install, by the category of the failure and whether it is retryable.",
+	},
+		[]string{"cluster_type", "category", "retryable"},
+	)
 )
 
 func init() {
 	metrics.Registry.MustRegister(metricInstallErrors)
 	metrics.Registry.MustRegister(metricClusterProvisionsTotal)
+	metrics.Registry.MustRegister(metricInstallFailuresByCategory)
 }
 
 // Add creates a new ClusterProvision Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -382,14 +390,23 @@ func (r *ReconcileClusterProvision) reconcileSuccessfulJob(instance *hivev1.Clus
 
 func (r *ReconcileClusterProvision) reconcileFailedJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("install job failed")
-	reason, message := r.parseInstallLog(instance.Spec.InstallLog, pLog)
-	if controllerutils.IsDeadlineExceeded(job) && reason == unknownReason {
-		reason, message = "AttemptDeadlineExceeded", "Install job failed due to deadline being exceeded for the attempt"
+	classification, message := r.parseInstallLog(instance.Spec.InstallLog, pLog)
+	if controllerutils.IsDeadlineExceeded(job) && classification.Reason == unknownReason {
+		classification = &hivev1.InstallFailureClassification{
+			Reason:    "AttemptDeadlineExceeded",
+			Category:  hivev1.InstallFailureCategoryTransient,
+			Retryable: true,
+		}
+		message = "Install job failed due to deadline being exceeded for the attempt"
 	}
-	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, reason, message, pLog)
+	// The classification is saved in the status update of the ClusterProvisionFailed condition.
+	instance.Status.FailureClassification = classification
+	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, classification.Reason, message, pLog)
 	if err == nil {
-		// Increment a counter metric for this cluster type and error reason:
-		metricInstallErrors.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), reason).Inc()
+		// Increment counter metrics for this cluster type and error reason and category:
+		clusterType := hivemetrics.GetClusterDeploymentType(instance)
+		metricInstallErrors.WithLabelValues(clusterType, classification.Reason).Inc()
+		metricInstallFailuresByCategory.WithLabelValues(clusterType, string(classification.Category), strconv.FormatBool(classification.Retryable)).Inc()
 		metricClusterProvisionsTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), resultFailure).Inc()
 	}
 	return result, err
@@ -59,6 +59,7 @@ func TestClusterProvisionReconcile(t *testing.T) {
 		expectErr             bool
 		expectedStage         hivev1.ClusterProvisionStage
 		expectedFailReason    string
+		expectedFailCategory  hivev1.InstallFailureCategory
 		expectNoJob           bool
 		expectNoJobReference  bool
 		expectPendingCreation bool
@@ -137,8 +138,9 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				testJob(failedJob()),
 				testPod("foo"),
 			},
-			expectedStage:      hivev1.ClusterProvisionStageFailed,
-			expectedFailReason: unknownReason,
+			expectedStage:        hivev1.ClusterProvisionStageFailed,
+			expectedFailReason:   unknownReason,
+			expectedFailCategory: hivev1.InstallFailureCategoryUnknown,
 		},
 		{
 			name: "deadline exceeded job",
@@ -147,8 +149,9 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				testJob(deadlineExceededJob()),
 				testPod("foo"),
 			},
-			expectedStage:      hivev1.ClusterProvisionStageFailed,
-			expectedFailReason: "AttemptDeadlineExceeded",
+			expectedStage:        hivev1.ClusterProvisionStageFailed,
+			expectedFailReason:   "AttemptDeadlineExceeded",
+			expectedFailCategory: hivev1.InstallFailureCategoryTransient,
 		},
 		{
 			name: "keep job for 24 hours after success",
@@ -311,6 +314,13 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				} else {
 					assert.Nil(t, failedCond, "expected not to find a Failed condition")
 				}
+				if test.expectedFailCategory != "" {
+					if assert.NotNil(t, provision.Status.FailureClassification, "expected a failure classification") {
+						assert.Equal(t, test.expectedFailReason, provision.Status.FailureClassification.Reason, "unexpected failure classification reason")
+						assert.Equal(t, test.expectedFailCategory, provision.Status.FailureClassification.Category, "unexpected failure category")
+						assert.True(t, provision.Status.FailureClassification.Retryable, "expected retryable failure classification")
+					}
+				}
 				if test.expectNoJobReference {
 					assert.Nil(t, provision.Status.JobRef, "expected no job reference from provision")
 				} else {
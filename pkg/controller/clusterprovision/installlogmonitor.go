@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
@@ -24,10 +25,11 @@ const (
 	unknownMessage               = "Cluster install failed but no known errors found in logs"
 )
 
-// parseInstallLog parses install log to monitor for known issues.
-func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string) {
+// parseInstallLog parses install log to monitor for known issues. It returns the classification of the install failure
+// and the message to report for it.
+func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (*hivev1.InstallFailureClassification, string) {
 	if log == nil {
-		return unknownReason, logMissingMessage
+		return unknownClassification(), logMissingMessage
 	}
 
 	// Load the regex configmap, if we don't have one, there's not much point proceeding here.
@@ -37,19 +39,19 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 		// Even if the error was a transient error in fetching the configmap, we should not block
 		// the continuation of deploying the cluster just so that we can potentially get a
 		// better failure message.
-		return unknownReason, regexBadMessage
+		return unknownClassification(), regexBadMessage
 	}
 
 	regexesRaw, ok := regexCM.Data[regexDataEntryName]
 	if !ok {
 		pLog.Errorf("%s configmap does not have a %q data entry", regexConfigMapName, regexDataEntryName)
-		return unknownReason, regexBadMessage
+		return unknownClassification(), regexBadMessage
 	}
 
 	regexes := []installLogRegex{}
 	if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
 		pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-		return unknownReason, regexBadMessage
+		return unknownClassification(), regexBadMessage
 	}
 
 	// Load additional regex configmap, continue anyway if configmap isn't present
@@ -90,11 +92,15 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 			case err != nil:
 				ssLog.WithError(err).Error("unable to compile regex")
 			case match:
-				pLog.WithField("reason", ilr.InstallFailingReason).Info("found known install failure string")
-				return ilr.InstallFailingReason, ilr.InstallFailingMessage
+				classification := ilr.classification()
+				pLog.WithField("reason", classification.Reason).
+					WithField("category", classification.Category).
+					WithField("retryable", classification.Retryable).
+					Info("found known install failure string")
+				return classification, ilr.InstallFailingMessage
 			}
 		}
 	}
 
-	return unknownReason, *log
+	return unknownClassification(), *log
 }
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 )
 
@@ -70,6 +71,9 @@ func TestParseInstallLog(t *testing.T) {
 		existing        []runtime.Object
 		expectedReason  string
 		expectedMessage *string
+
+		expectedCategory  hivev1.InstallFailureCategory
+		expectedRetryable *bool
 	}{
 		{
 			name:           "load balancer service linked role prereq",
@@ -224,6 +228,9 @@ func TestParseInstallLog(t *testing.T) {
 			log:             pointer.StringPtr(noMatchLog),
 			expectedReason:  unknownReason,
 			expectedMessage: pointer.StringPtr(noMatchLog),
+
+			expectedCategory:  hivev1.InstallFailureCategoryUnknown,
+			expectedRetryable: pointer.BoolPtr(true),
 		},
 		{
 			name:           "missing regex configmap",
@@ -280,6 +287,35 @@ func TestParseInstallLog(t *testing.T) {
 				},
 			}},
 			expectedReason: "DNSAlreadyExists",
+
+			expectedCategory:  hivev1.InstallFailureCategoryUnknown,
+			expectedRetryable: pointer.BoolPtr(true),
+		},
+		{
+			name: "classified regex entry",
+			log:  pointer.StringPtr(dnsAlreadyExistsLog),
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      regexConfigMapName,
+					Namespace: constants.DefaultHiveNamespace,
+				},
+				Data: map[string]string{
+					"regexes": `
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "aws_route53_record.*Error building changeset:.*Tried to create resource record set.*but it already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+  installFailingCategory: Configuration
+  retryable: false
+  remediation: Delete the stale DNS records.
+`,
+				},
+			}},
+			expectedReason: "DNSAlreadyExists",
+
+			expectedCategory:  hivev1.InstallFailureCategoryConfiguration,
+			expectedRetryable: pointer.BoolPtr(false),
 		},
 		{
 			name: "skip bad search string",
@@ -331,6 +367,9 @@ func TestParseInstallLog(t *testing.T) {
 			name:           "AWSInsufficientPermissions",
 			log:            pointer.StringPtr(insufficientPermissions),
 			expectedReason: "AWSInsufficientPermissions",
+
+			expectedCategory:  hivev1.InstallFailureCategoryCredentials,
+			expectedRetryable: pointer.BoolPtr(false),
 		},
 		{
 			name:           "LoadBalancerLimitExceeded",
@@ -341,6 +380,9 @@ func TestParseInstallLog(t *testing.T) {
 			name:           "AWSEC2QuotaExceeded",
 			log:            pointer.StringPtr(awsEC2QuotaExceeded),
 			expectedReason: "AWSEC2QuotaExceeded",
+
+			expectedCategory:  hivev1.InstallFailureCategoryQuota,
+			expectedRetryable: pointer.BoolPtr(false),
 		},
 		{
 			name:           "AWSNoWorkerNodes",
@@ -356,11 +398,17 @@ func TestParseInstallLog(t *testing.T) {
 			name:           "BootstrapFailed",
 			log:            pointer.StringPtr(bootstrapFailed),
 			expectedReason: "BootstrapFailed",
+
+			expectedCategory:  hivev1.InstallFailureCategoryNetwork,
+			expectedRetryable: pointer.BoolPtr(true),
 		},
 		{
 			name:           "AWSRoute53Timeout",
 			log:            pointer.StringPtr(route53Timeout),
 			expectedReason: "AWSRoute53Timeout",
+
+			expectedCategory:  hivev1.InstallFailureCategoryTransient,
+			expectedRetryable: pointer.BoolPtr(true),
 		},
 		{
 			name:           "InconsistentTerraformResult",
@@ -395,8 +443,19 @@ func TestParseInstallLog(t *testing.T) {
 				Client: fakeClient,
 				scheme: scheme.Scheme,
 			}
-			reason, message := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
-			assert.Equal(t, test.expectedReason, reason, "unexpected reason")
+			classification, message := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
+			if assert.NotNil(t, classification, "expected a classification") {
+				assert.Equal(t, test.expectedReason, classification.Reason, "unexpected reason")
+				if test.expectedCategory != "" {
+					assert.Equal(t, test.expectedCategory, classification.Category, "unexpected category")
+				}
+				if test.expectedRetryable != nil {
+					assert.Equal(t, *test.expectedRetryable, classification.Retryable, "unexpected retryable")
+				}
+				if !classification.Retryable {
+					assert.NotEmpty(t, classification.Remediation, "expected remediation for failure that is not retryable")
+				}
+			}
 			if test.expectedMessage != nil {
 				assert.Equal(t, *test.expectedMessage, message)
 			} else {
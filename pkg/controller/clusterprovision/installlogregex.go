@@ -1,5 +1,9 @@
 package clusterprovision
 
+import (
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
 // installLogRegex is a struct that represents all the data we use to scan for certain
 // search strings in install logs. These structs are serialized as yaml and stored/read from
 // the install-log-regexes ConfigMap.
@@ -15,4 +19,36 @@ type installLogRegex struct {
 
 	// InstallFailingMessage is the user friendly sentence we report for this failure and conditions, metrics and logs.
 	InstallFailingMessage string `json:"installFailingMessage"`
+
+	// InstallFailingCategory is the category we report for this failure. Defaults to Unknown.
+	InstallFailingCategory hivev1.InstallFailureCategory `json:"installFailingCategory,omitempty"`
+
+	// Retryable is whether a new provision attempt may succeed after this failure. Defaults to true.
+	Retryable *bool `json:"retryable,omitempty"`
+
+	// Remediation is the hint we report to fix the cause of this failure.
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// classification returns the classification of the install failures matched by the regex.
+func (ilr *installLogRegex) classification() *hivev1.InstallFailureClassification {
+	c := &hivev1.InstallFailureClassification{
+		Reason:      ilr.InstallFailingReason,
+		Category:    ilr.InstallFailingCategory,
+		Retryable:   ilr.Retryable == nil || *ilr.Retryable,
+		Remediation: ilr.Remediation,
+	}
+	if c.Category == "" {
+		c.Category = hivev1.InstallFailureCategoryUnknown
+	}
+	return c
+}
+
+// unknownClassification returns the classification of install failures that do not match any regex.
+func unknownClassification() *hivev1.InstallFailureClassification {
+	return &hivev1.InstallFailureClassification{
+		Reason:    unknownReason,
+		Category:  hivev1.InstallFailureCategoryUnknown,
+		Retryable: true,
+	}
 }
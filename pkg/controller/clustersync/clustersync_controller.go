@@ -28,9 +28,11 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/yaml"
@@ -54,9 +56,15 @@ const (
 	labelApply             = "apply"
 	labelCreateOrUpdate    = "createOrUpdate"
 	labelCreateOnly        = "createOnly"
+	labelServerSideApply   = "serverSideApply"
+	labelDryRun            = "dryRun"
 	metricResultSuccess    = "success"
 	metricResultError      = "error"
 	stsName                = "hive-clustersync"
+
+	// maxDryRunDiffLength is the maximum length of a diff recorded in the dry-run results, to keep the size of the
+	// ClusterSync in check.
+	maxDryRunDiffLength = 1024
 )
 
 var (
@@ -219,7 +227,8 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconc
 	// Watch for changes to SelectorSyncSets
 	if err := c.Watch(
 		&source.Kind{Type: &hivev1.SelectorSyncSet{}},
-		handler.EnqueueRequestsFromMapFunc(requestsForSelectorSyncSet(r.Client, r.logger))); err != nil {
+		handler.EnqueueRequestsFromMapFunc(requestsForSelectorSyncSet(r.Client, r.logger)),
+		predicate.Funcs{UpdateFunc: selectorSyncSetUpdateNeedsSync}); err != nil {
 		return err
 	}
 
@@ -265,6 +274,30 @@ func requestsForSelectorSyncSet(c client.Client, logger log.FieldLogger) handler
 	}
 }
 
+// selectorSyncSetUpdateNeedsSync filters out updates to the status of a SelectorSyncSet that only record the progress
+// of its rollout, so that the clusters it applies to are not all reconciled each time that a cluster applies it. The
+// clusters still need to be reconciled when the rollout moves on to more clusters.
+func selectorSyncSetUpdateNeedsSync(e event.UpdateEvent) bool {
+	oldSSS, ok := e.ObjectOld.(*hivev1.SelectorSyncSet)
+	if !ok {
+		return true
+	}
+	newSSS, ok := e.ObjectNew.(*hivev1.SelectorSyncSet)
+	if !ok {
+		return true
+	}
+	if oldSSS.Generation != newSSS.Generation || reflect.DeepEqual(oldSSS.Status, newSSS.Status) {
+		return true
+	}
+	oldRollout, newRollout := oldSSS.Status.Rollout, newSSS.Status.Rollout
+	if oldRollout == nil || newRollout == nil {
+		return oldRollout != newRollout
+	}
+	return oldRollout.ObservedGeneration != newRollout.ObservedGeneration ||
+		oldRollout.Phase != newRollout.Phase ||
+		oldRollout.Percent != newRollout.Percent
+}
+
 var _ reconcile.Reconciler = &ReconcileClusterSync{}
 
 // ReconcileClusterSync reconciles a ClusterDeployment object to apply its SyncSets and SelectorSyncSets
@@ -584,6 +617,53 @@ func (r *ReconcileClusterSync) applySyncSets(
 		logger := logger.WithField(syncSetType, syncSet.AsMetaObject().GetName())
 		oldSyncStatus, indexOfOldStatus := getOldSyncStatus(syncSet, syncStatuses)
 
+		// Leave the selectorsyncset as it was last applied until the rollout of its generation reaches the cluster
+		if sss, ok := syncSet.(*SelectorSyncSetAsCommon); ok {
+			rolledOut, err := controllerutils.IsSelectorSyncSetRolledOutToCluster((*hivev1.SelectorSyncSet)(sss), cd)
+			if err != nil {
+				logger.WithError(err).Warn("cannot determine whether the rollout of the selectorsyncset has reached the cluster")
+			}
+			if !rolledOut {
+				logger.Debug("skipping apply of syncset since its rollout has not reached the cluster")
+				if indexOfOldStatus >= 0 {
+					newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+				}
+				continue
+			}
+		}
+
+		newSyncStatus := hiveintv1alpha1.SyncStatus{
+			Name:               syncSet.AsMetaObject().GetName(),
+			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+			Result:             hiveintv1alpha1.SuccessSyncSetResult,
+		}
+
+		// Evaluate the templates in the syncset for the cluster. When the templates cannot be evaluated, nothing is
+		// applied to or deleted from the cluster, since the resources in the syncset are not known. The templates are
+		// evaluated before deciding whether to apply the syncset, since the values they evaluate to can change without
+		// the syncset changing.
+		if syncSet.GetSpec().EnableTemplates {
+			renderedSyncSet, err := renderTemplates(syncSet, cd)
+			if err == nil {
+				newSyncStatus.RenderedHash, err = renderedHash(renderedSyncSet)
+			}
+			if err != nil {
+				logger.WithError(err).Warn("failed to render syncset templates")
+				newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
+				newSyncStatus.FailureMessage = err.Error()
+				newSyncStatus.ResourcesToDelete = oldSyncStatus.ResourcesToDelete
+				newSyncStatus.RenderedHash = oldSyncStatus.RenderedHash
+				newSyncStatus.LastTransitionTime = oldSyncStatus.LastTransitionTime
+				newSyncStatus.FirstSuccessTime = oldSyncStatus.FirstSuccessTime
+				if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
+					newSyncStatus.LastTransitionTime = metav1.Now()
+				}
+				newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+				continue
+			}
+			syncSet = renderedSyncSet
+		}
+
 		// Determine if the syncset needs to be applied
 		switch {
 		case needToDoFullReapply:
@@ -594,18 +674,23 @@ func (r *ReconcileClusterSync) applySyncSets(
 			logger.Debug("applying syncset because the last attempt to apply failed")
 		case oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration():
 			logger.Debug("applying syncset because the syncset generation has changed")
+		case oldSyncStatus.RenderedHash != newSyncStatus.RenderedHash:
+			logger.Debug("applying syncset because the rendered templates have changed")
 		default:
 			logger.Debug("skipping apply of syncset since it is up-to-date and it is not time to do a full re-apply")
 			newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
 			continue
 		}
 
-		// Apply the syncset
-		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, resourceHelper, logger)
-		newSyncStatus := hiveintv1alpha1.SyncStatus{
-			Name:               syncSet.AsMetaObject().GetName(),
-			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
-			Result:             hiveintv1alpha1.SuccessSyncSetResult,
+		// Apply the syncset, or only do a dry run of applying it
+		var resourcesApplied, resourcesInSyncSet []hiveintv1alpha1.SyncResourceReference
+		var syncSetNeedsRequeue bool
+		var err error
+		dryRun := syncSet.GetSpec().DryRun
+		if dryRun {
+			newSyncStatus.DryRunResults, resourcesInSyncSet, syncSetNeedsRequeue, err = r.dryRunSyncSet(syncSet, oldSyncStatus.ResourcesToDelete, resourceHelper, logger)
+		} else {
+			resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err = r.applySyncSet(syncSet, resourceHelper, logger)
 		}
 		applyMode := syncSet.GetSpec().ResourceApplyMode
 		if applyMode == hivev1.SyncResourceApplyMode {
@@ -624,7 +709,12 @@ func (r *ReconcileClusterSync) applySyncSets(
 			requeue = true
 		}
 
-		if indexOfOldStatus >= 0 {
+		if indexOfOldStatus >= 0 && dryRun {
+			// Nothing is deleted from the cluster during a dry run, so the resources to delete are carried over.
+			newSyncStatus.ResourcesToDelete = oldSyncStatus.ResourcesToDelete
+			newSyncStatus.LastTransitionTime = oldSyncStatus.LastTransitionTime
+			newSyncStatus.FirstSuccessTime = oldSyncStatus.FirstSuccessTime
+		} else if indexOfOldStatus >= 0 {
 			// Delete any resources that were included in the syncset previously but are no longer included now.
 			remainingResources, err := deleteFromTargetCluster(
 				oldSyncStatus.ResourcesToDelete,
@@ -653,8 +743,15 @@ func (r *ReconcileClusterSync) applySyncSets(
 			newSyncStatus.LastTransitionTime = metav1.Now()
 		}
 
+		// A successful dry run counts as a success so that the syncset does not hold up anything waiting for all of the
+		// syncsets to be applied, such as hibernation. There is no apply-duration to observe for a dry run.
+		if newSyncStatus.Result == hiveintv1alpha1.SuccessSyncSetResult && oldSyncStatus.FirstSuccessTime == nil && dryRun {
+			now := metav1.Now()
+			newSyncStatus.FirstSuccessTime = &now
+		}
+
 		// Set the FirstSuccessTime if this is the first success. Also, observe the apply-duration metric.
-		if newSyncStatus.Result == hiveintv1alpha1.SuccessSyncSetResult && oldSyncStatus.FirstSuccessTime == nil {
+		if newSyncStatus.Result == hiveintv1alpha1.SuccessSyncSetResult && oldSyncStatus.FirstSuccessTime == nil && !dryRun {
 			now := metav1.Now()
 			newSyncStatus.FirstSuccessTime = &now
 			startTime := syncSet.AsMetaObject().GetCreationTimestamp().Time
@@ -736,6 +833,12 @@ func (r *ReconcileClusterSync) applySyncSet(
 	case hivev1.CreateOnlySyncSetApplyBehavior:
 		applyFn = resourceHelper.Create
 		applyFnMetricsLabel = labelCreateOnly
+	case hivev1.ServerSideApplySyncSetApplyBehavior:
+		forceConflicts := syncSet.GetSpec().ForceConflicts
+		applyFn = func(obj []byte) (resource.ApplyResult, error) {
+			return resourceHelper.ServerSideApply(obj, forceConflicts)
+		}
+		applyFnMetricsLabel = labelServerSideApply
 	}
 
 	// Apply Resources
@@ -770,6 +873,93 @@ func (r *ReconcileClusterSync) applySyncSet(
 	return
 }
 
+// dryRunSyncSet performs a server-side dry run of applying the resources and secrets of the syncset to the cluster,
+// recording the change that would be made to each. When the resource apply mode is Sync, the resources to delete that
+// are no longer in the syncset are recorded as resources that would be deleted.
+func (r *ReconcileClusterSync) dryRunSyncSet(
+	syncSet CommonSyncSet,
+	resourcesToDelete []hiveintv1alpha1.SyncResourceReference,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (
+	dryRunResults []hiveintv1alpha1.DryRunResult,
+	resourcesInSyncSet []hiveintv1alpha1.SyncResourceReference,
+	requeue bool,
+	returnErr error,
+) {
+	resources, referencesToResources, decodeErr := decodeResources(syncSet, logger)
+	referencesToSecrets := referencesToSecrets(syncSet)
+	resourcesInSyncSet = append(referencesToResources, referencesToSecrets...)
+	if decodeErr != nil {
+		returnErr = decodeErr
+		return
+	}
+	applyBehavior := syncSet.GetSpec().ApplyBehavior
+	forceConflicts := syncSet.GetSpec().ForceConflicts
+
+	// Dry run Resources
+	for i, resource := range resources {
+		result := hiveintv1alpha1.DryRunResult{SyncResourceReference: referencesToResources[i]}
+		returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], dryRunFn(resourceHelper, applyBehavior, forceConflicts, &result), labelDryRun, logger)
+		if returnErr != nil {
+			return
+		}
+		dryRunResults = append(dryRunResults, result)
+	}
+
+	// Dry run Secrets
+	for i, secretMapping := range syncSet.GetSpec().Secrets {
+		result := hiveintv1alpha1.DryRunResult{SyncResourceReference: referencesToSecrets[i]}
+		returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], dryRunFn(resourceHelper, applyBehavior, forceConflicts, &result), labelDryRun, logger)
+		if returnErr != nil {
+			return
+		}
+		// The diff would expose the contents of the secret.
+		result.Diff = ""
+		dryRunResults = append(dryRunResults, result)
+	}
+
+	if syncSet.GetSpec().ResourceApplyMode == hivev1.SyncResourceApplyMode {
+		for _, r := range resourcesToDelete {
+			if !containsResource(resourcesInSyncSet, r) {
+				dryRunResults = append(dryRunResults, hiveintv1alpha1.DryRunResult{
+					SyncResourceReference: r,
+					Action:                hiveintv1alpha1.DeleteDryRunAction,
+				})
+			}
+		}
+	}
+
+	logger.Info("syncset dry run completed")
+	return
+}
+
+// dryRunFn returns an apply function that only does a dry run of the apply, and records the change that the apply
+// would make in the given result.
+func dryRunFn(
+	resourceHelper resource.Helper,
+	applyBehavior hivev1.SyncSetApplyBehavior,
+	forceConflicts bool,
+	result *hiveintv1alpha1.DryRunResult,
+) func(obj []byte) (resource.ApplyResult, error) {
+	return func(obj []byte) (resource.ApplyResult, error) {
+		applyResult, diff, err := resourceHelper.DryRun(obj, applyBehavior, forceConflicts)
+		switch applyResult {
+		case resource.CreatedApplyResult:
+			result.Action = hiveintv1alpha1.CreateDryRunAction
+		case resource.ConfiguredApplyResult:
+			result.Action = hiveintv1alpha1.UpdateDryRunAction
+			if len(diff) > maxDryRunDiffLength {
+				diff = diff[:maxDryRunDiffLength] + "..."
+			}
+			result.Diff = diff
+		default:
+			result.Action = hiveintv1alpha1.NoneDryRunAction
+		}
+		return applyResult, err
+	}
+}
+
 func decodeResources(syncSet CommonSyncSet, logger log.FieldLogger) (
 	resources []*unstructured.Unstructured, references []hiveintv1alpha1.SyncResourceReference, returnErr error,
 ) {
@@ -1835,20 +1835,34 @@ func TestReconcileClusterSync_DeleteErrorDoesNotBlockOtherDeletes(t *testing.T)
 
 func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 	cases := []struct {
-		applyBehavior hivev1.SyncSetApplyBehavior
+		name           string
+		applyBehavior  hivev1.SyncSetApplyBehavior
+		forceConflicts bool
 	}{
 		{
+			name:          "apply",
 			applyBehavior: hivev1.ApplySyncSetApplyBehavior,
 		},
 		{
+			name:          "create only",
 			applyBehavior: hivev1.CreateOnlySyncSetApplyBehavior,
 		},
 		{
+			name:          "create or update",
 			applyBehavior: hivev1.CreateOrUpdateSyncSetApplyBehavior,
 		},
+		{
+			name:          "server-side apply",
+			applyBehavior: hivev1.ServerSideApplySyncSetApplyBehavior,
+		},
+		{
+			name:           "server-side apply with force conflicts",
+			applyBehavior:  hivev1.ServerSideApplySyncSetApplyBehavior,
+			forceConflicts: true,
+		},
 	}
 	for _, tc := range cases {
-		t.Run(string(tc.applyBehavior), func(t *testing.T) {
+		t.Run(tc.name, func(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
 			scheme := newScheme()
@@ -1857,6 +1871,7 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 				testsyncset.ForClusterDeployments(testCDName),
 				testsyncset.WithGeneration(1),
 				testsyncset.WithApplyBehavior(tc.applyBehavior),
+				testsyncset.WithForceConflicts(tc.forceConflicts),
 				testsyncset.WithResources(resourceToApply),
 				testsyncset.WithSecrets(
 					testSecretMapping("test-secret", "secret-namespace", "secret-name"),
@@ -1899,6 +1914,9 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 			case hivev1.CreateOrUpdateSyncSetApplyBehavior:
 				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
 				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+			case hivev1.ServerSideApplySyncSetApplyBehavior:
+				rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(resourceToApply), tc.forceConflicts).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(secretToApply), tc.forceConflicts).Return(resource.CreatedApplyResult, nil)
 			}
 			rt.mockResourceHelper.EXPECT().Patch(
 				types.NamespacedName{Namespace: "patch-namespace", Name: "patch-name"},
@@ -1913,6 +1931,448 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_DryRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	resourceToCreate := testConfigMap("dest-namespace", "created-resource")
+	resourceToUpdate := testConfigMap("dest-namespace", "updated-resource")
+	unchangedResource := testConfigMap("dest-namespace", "unchanged-resource")
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(2),
+		testsyncset.WithDryRun(true),
+		testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+		testsyncset.WithResources(resourceToCreate, resourceToUpdate, unchangedResource),
+		testsyncset.WithSecrets(
+			testSecretMapping("test-secret", "secret-namespace", "secret-name"),
+		),
+		testsyncset.WithPatches(hivev1.SyncObjectPatch{
+			APIVersion: "patch-api/v1",
+			Kind:       "PatchKind",
+			Namespace:  "patch-namespace",
+			Name:       "patch-name",
+			Patch:      "test-patch",
+		}),
+	)
+	srcSecret := testsecret.FullBuilder(testNamespace, "test-secret", scheme).Build(
+		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+	)
+	resourcesToDelete := []hiveintv1alpha1.SyncResourceReference{
+		testConfigMapRef("dest-namespace", "deleted-resource"),
+		testConfigMapRef("dest-namespace", "updated-resource"),
+	}
+	existingSyncStatus := buildSyncStatus("test-syncset",
+		withResourcesToDelete(resourcesToDelete...),
+		withTransitionInThePast(),
+		withFirstSuccessTimeInThePast(),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(existingSyncStatus)),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+		srcSecret)
+	secretToApply := testsecret.BasicBuilder().GenericOptions(
+		testgeneric.WithNamespace("secret-namespace"),
+		testgeneric.WithName("secret-name"),
+		testgeneric.WithTypeMeta(scheme),
+	).Build(
+		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+	)
+	rt.mockResourceHelper.EXPECT().DryRun(newApplyMatcher(resourceToCreate), hivev1.SyncSetApplyBehavior(""), false).
+		Return(resource.CreatedApplyResult, "", nil)
+	rt.mockResourceHelper.EXPECT().DryRun(newApplyMatcher(resourceToUpdate), hivev1.SyncSetApplyBehavior(""), false).
+		Return(resource.ConfiguredApplyResult, `{"data":{"key":"new-value"}}`, nil)
+	rt.mockResourceHelper.EXPECT().DryRun(newApplyMatcher(unchangedResource), hivev1.SyncSetApplyBehavior(""), false).
+		Return(resource.UnchangedApplyResult, "", nil)
+	rt.mockResourceHelper.EXPECT().DryRun(newApplyMatcher(secretToApply), hivev1.SyncSetApplyBehavior(""), false).
+		Return(resource.ConfiguredApplyResult, `{"data":{"test-key":"dGVzdC1kYXRh"}}`, nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withObservedGeneration(2),
+		withResourcesToDelete(resourcesToDelete...),
+		withFirstSuccessTimeInThePast(),
+		withDryRunResults(
+			hiveintv1alpha1.DryRunResult{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "created-resource"),
+				Action:                hiveintv1alpha1.CreateDryRunAction,
+			},
+			hiveintv1alpha1.DryRunResult{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "updated-resource"),
+				Action:                hiveintv1alpha1.UpdateDryRunAction,
+				Diff:                  `{"data":{"key":"new-value"}}`,
+			},
+			hiveintv1alpha1.DryRunResult{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "unchanged-resource"),
+				Action:                hiveintv1alpha1.NoneDryRunAction,
+			},
+			hiveintv1alpha1.DryRunResult{
+				SyncResourceReference: testSecretRef("secret-namespace", "secret-name"),
+				Action:                hiveintv1alpha1.UpdateDryRunAction,
+			},
+			hiveintv1alpha1.DryRunResult{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "deleted-resource"),
+				Action:                hiveintv1alpha1.DeleteDryRunAction,
+			},
+		),
+	)}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ErrorDryRunningResource(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	resourceToApply := testConfigMap("dest-namespace", "dest-name")
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithDryRun(true),
+		testsyncset.WithApplyBehavior(hivev1.CreateOrUpdateSyncSetApplyBehavior),
+		testsyncset.WithResources(resourceToApply),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet)
+	rt.mockResourceHelper.EXPECT().DryRun(newApplyMatcher(resourceToApply), hivev1.CreateOrUpdateSyncSetApplyBehavior, false).
+		Return(resource.ApplyResult(""), "", errors.New("test dry run error"))
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withFailureResult("failed to apply resource 0: test dry run error"),
+		withNoFirstSuccessTime(),
+	)}
+	rt.expectRequeue = true
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_SelectorSyncSetRollout(t *testing.T) {
+	cases := []struct {
+		name            string
+		cdLabels        map[string]string
+		rolloutStatus   *hivev1.SelectorSyncSetRolloutStatus
+		existingStatus  *hiveintv1alpha1.SyncStatus
+		expectApply     bool
+		expectOldStatus bool
+	}{
+		{
+			name: "rollout not started for new selectorsyncset",
+		},
+		{
+			name: "rollout not started for updated selectorsyncset",
+			existingStatus: func() *hiveintv1alpha1.SyncStatus {
+				s := buildSyncStatus("test-selectorsyncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())
+				return &s
+			}(),
+			expectOldStatus: true,
+		},
+		{
+			name: "rollout of previous generation",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              hivev1.CompleteSelectorSyncSetRolloutPhase,
+				Percent:            100,
+			},
+			existingStatus: func() *hiveintv1alpha1.SyncStatus {
+				s := buildSyncStatus("test-selectorsyncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())
+				return &s
+			}(),
+			expectOldStatus: true,
+		},
+		{
+			name:     "canary cluster",
+			cdLabels: map[string]string{"canary": "true"},
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.CanarySelectorSyncSetRolloutPhase,
+			},
+			expectApply: true,
+		},
+		{
+			name: "not a canary cluster",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.CanarySelectorSyncSetRolloutPhase,
+			},
+		},
+		{
+			name: "batch reached cluster",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Percent:            100,
+			},
+			expectApply: true,
+		},
+		{
+			name: "halted before reaching cluster",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.HaltedSelectorSyncSetRolloutPhase,
+			},
+		},
+		{
+			name: "rollout complete",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              hivev1.CompleteSelectorSyncSetRolloutPhase,
+				Percent:            100,
+			},
+			expectApply: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			resourceToApply := testConfigMap("dest-namespace", "dest-name")
+			selectorSyncSet := testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+				testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+				testselectorsyncset.WithGeneration(2),
+				testselectorsyncset.WithResources(resourceToApply),
+				testselectorsyncset.WithRollout(&hivev1.SelectorSyncSetRollout{
+					CanarySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+					BatchPercent:   50,
+				}),
+				testselectorsyncset.WithRolloutStatus(tc.rolloutStatus),
+			)
+			cdOpts := []testcd.Option{testcd.WithLabel("test-label-key", "test-label-value")}
+			for k, v := range tc.cdLabels {
+				cdOpts = append(cdOpts, testcd.WithLabel(k, v))
+			}
+			var clusterSyncOpts []testcs.Option
+			if tc.existingStatus != nil {
+				clusterSyncOpts = append(clusterSyncOpts, testcs.WithSelectorSyncSetStatus(*tc.existingStatus))
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(cdOpts...),
+				clusterSyncBuilder(scheme).Build(clusterSyncOpts...),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				selectorSyncSet,
+			)
+			switch {
+			case tc.expectApply:
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+				rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+					buildSyncStatus("test-selectorsyncset", withObservedGeneration(2)),
+				}
+			case tc.expectOldStatus:
+				rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{*tc.existingStatus}
+			}
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_Templates(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	templatedResource := testConfigMap("dest-namespace", "{{ .ClusterName }}-config")
+	templatedResource.Data = map[string]string{
+		"infraID":                                "{{ .InfraID }}",
+		"region":                                 "{{ .Region }}",
+		"{{ index .Labels \"test-label-key\" }}": "label",
+	}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithEnableTemplates(true),
+		testsyncset.WithResources(templatedResource),
+		testsyncset.WithSecrets(
+			testSecretMapping("test-secret", "dest-namespace", "{{ .ClusterName }}-secret"),
+		),
+		testsyncset.WithPatches(hivev1.SyncObjectPatch{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "patch-namespace",
+			Name:       "{{ .Name }}",
+			PatchType:  "merge",
+			Patch:      `{"data":{"baseDomain":"{{ .BaseDomain }}"}}`,
+		}),
+	)
+	srcSecret := testsecret.FullBuilder(testNamespace, "test-secret", scheme).Build(
+		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+	)
+	cd := cdBuilder(scheme).Build(
+		testcd.WithLabel("test-label-key", "test-label-value"),
+		testcd.WithLabel(hivev1.HiveClusterRegionLabel, "us-east-1"),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterName = "test-cluster"
+			cd.Spec.BaseDomain = "example.com"
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{InfraID: "test-infra-id"}
+		},
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cd,
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+		srcSecret,
+	)
+	renderedResource := testConfigMap("dest-namespace", "test-cluster-config")
+	renderedResource.Data = map[string]string{
+		"infraID":          "test-infra-id",
+		"region":           "us-east-1",
+		"test-label-value": "label",
+	}
+	renderedSecret := testsecret.BasicBuilder().GenericOptions(
+		testgeneric.WithNamespace("dest-namespace"),
+		testgeneric.WithName("test-cluster-secret"),
+		testgeneric.WithTypeMeta(scheme),
+	).Build(
+		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+	)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(renderedResource)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(renderedSecret)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Patch(
+		types.NamespacedName{Namespace: "patch-namespace", Name: testCDName},
+		"ConfigMap",
+		"v1",
+		[]byte(`{"data":{"baseDomain":"example.com"}}`),
+		"merge",
+	).Return(nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withRenderedHash(testRenderedHash(t, syncSet, cd)),
+	)}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_TemplatesRenderedHash(t *testing.T) {
+	cases := []struct {
+		name          string
+		previousLabel string
+		expectApply   bool
+	}{
+		{
+			name:          "rendered syncset unchanged",
+			previousLabel: "test-label-value",
+			expectApply:   false,
+		},
+		{
+			name:          "rendered syncset changed",
+			previousLabel: "previous-label-value",
+			expectApply:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			templatedResource := testConfigMap("dest-namespace", "dest-name")
+			templatedResource.Data = map[string]string{"label": "{{ index .Labels \"test-label-key\" }}"}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithEnableTemplates(true),
+				testsyncset.WithResources(templatedResource),
+			)
+			cd := cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value"))
+			previousCD := cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", tc.previousLabel))
+			previousHash := testRenderedHash(t, syncSet, previousCD)
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cd,
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+						withRenderedHash(previousHash),
+						withTransitionInThePast(),
+						withFirstSuccessTimeInThePast(),
+					)),
+				),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				buildSyncLease(time.Now().Add(-time.Hour)),
+				syncSet,
+			)
+			rt.expectUnchangedLeaseRenewTime = true
+			if tc.expectApply {
+				renderedResource := testConfigMap("dest-namespace", "dest-name")
+				renderedResource.Data = map[string]string{"label": "test-label-value"}
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(renderedResource)).Return(resource.ConfiguredApplyResult, nil)
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withRenderedHash(testRenderedHash(t, syncSet, cd)),
+					withFirstSuccessTimeInThePast(),
+				)}
+			} else {
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withRenderedHash(previousHash),
+					withTransitionInThePast(),
+					withFirstSuccessTimeInThePast(),
+				)}
+			}
+			rt.run(t)
+		})
+	}
+}
+
+func testRenderedHash(t *testing.T, syncSet *hivev1.SyncSet, cd *hivev1.ClusterDeployment) string {
+	// The resources of the syncset built for the test are only encoded once the syncset is stored in the client.
+	syncSet = syncSet.DeepCopy()
+	for i, r := range syncSet.Spec.Resources {
+		raw, err := json.Marshal(r.Object)
+		require.NoError(t, err, "unexpected error encoding resource")
+		syncSet.Spec.Resources[i] = runtime.RawExtension{Raw: raw}
+	}
+	rendered, err := renderTemplates((*SyncSetAsCommon)(syncSet), cd)
+	require.NoError(t, err, "unexpected error rendering templates")
+	hash, err := renderedHash(rendered)
+	require.NoError(t, err, "unexpected error hashing rendered syncset")
+	return hash
+}
+
+func TestReconcileClusterSync_ErrorRenderingTemplates(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(2),
+		testsyncset.WithEnableTemplates(true),
+		testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "{{ .Labels.missing }}")),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(
+			testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "previous-config")),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			)),
+		),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+	)
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withObservedGeneration(2),
+		withFailureResult(`failed to render resource 0: value of "metadata": value of "name": template: syncset:1:10: executing "syncset" at <.Labels.missing>: map has no entry for key "missing"`),
+		withResourcesToDelete(testConfigMapRef("dest-namespace", "previous-config")),
+		withFirstSuccessTimeInThePast(),
+	)}
+	rt.run(t)
+}
+
 func TestReconcileClusterSync_IgnoreNotApplicableSyncSets(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -2641,6 +3101,18 @@ func withResourcesToDelete(resourcesToDelete ...hiveintv1alpha1.SyncResourceRefe
 	}
 }
 
+func withRenderedHash(renderedHash string) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.RenderedHash = renderedHash
+	}
+}
+
+func withDryRunResults(dryRunResults ...hiveintv1alpha1.DryRunResult) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.DryRunResults = dryRunResults
+	}
+}
+
 func withTransitionInThePast() syncStatusOption {
 	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
 		syncStatus.LastTransitionTime = timeInThePast
@@ -0,0 +1,80 @@
+package clustersync
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// renderTemplates returns a copy of the syncset in which the templates in the resources, the secret mappings, and the
+// patches have been evaluated for the ClusterDeployment.
+func renderTemplates(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment) (CommonSyncSet, error) {
+	var rendered CommonSyncSet
+	switch s := syncSet.AsRuntimeObject().DeepCopyObject().(type) {
+	case *hivev1.SyncSet:
+		rendered = (*SyncSetAsCommon)(s)
+	case *hivev1.SelectorSyncSet:
+		rendered = (*SelectorSyncSetAsCommon)(s)
+	default:
+		return nil, errors.Errorf("unexpected syncset type %T", s)
+	}
+	data := controllerutils.NewSyncSetTemplateData(cd)
+	spec := rendered.GetSpec()
+
+	for i, resource := range spec.Resources {
+		raw, err := controllerutils.RenderSyncSetTemplateResource(resource.Raw, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render resource %d", i)
+		}
+		spec.Resources[i] = runtime.RawExtension{Raw: raw}
+	}
+
+	for i := range spec.Secrets {
+		secretMapping := &spec.Secrets[i]
+		for _, value := range []*string{
+			&secretMapping.SourceRef.Namespace,
+			&secretMapping.SourceRef.Name,
+			&secretMapping.TargetRef.Namespace,
+			&secretMapping.TargetRef.Name,
+		} {
+			var err error
+			if *value, err = controllerutils.RenderSyncSetTemplate(*value, data); err != nil {
+				return nil, errors.Wrapf(err, "failed to render secret %d", i)
+			}
+		}
+	}
+
+	for i := range spec.Patches {
+		patch := &spec.Patches[i]
+		for _, value := range []*string{
+			&patch.Namespace,
+			&patch.Name,
+			&patch.Patch,
+		} {
+			var err error
+			if *value, err = controllerutils.RenderSyncSetTemplate(*value, data); err != nil {
+				return nil, errors.Wrapf(err, "failed to render patch %d", i)
+			}
+		}
+	}
+
+	return rendered, nil
+}
+
+// renderedHash returns a hash of the spec of a syncset whose templates have been evaluated, so that a change in the
+// values that the templates evaluate to can be detected.
+func renderedHash(syncSet CommonSyncSet) (string, error) {
+	specBytes, err := json.Marshal(syncSet.GetSpec())
+	if err != nil {
+		return "", err
+	}
+	sum := md5.Sum(specBytes)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -300,6 +300,11 @@ func createNameServerQuery(c client.Client, logger log.FieldLogger, managedDomai
 		logger.Infof("using azure creds for managed domain stored in %q secret", secretName)
 		return nameserver.NewAzureQuery(c, secretName, managedDomain.Azure.ResourceGroupName, managedDomain.Azure.CloudName.Name())
 	}
+	if managedDomain.RFC2136 != nil {
+		secretName := managedDomain.RFC2136.TSIGSecretRef.Name
+		logger.Infof("using rfc2136 tsig key for managed domain stored in %q secret", secretName)
+		return nameserver.NewRFC2136Query(c, secretName, managedDomain.RFC2136.Nameserver)
+	}
 	logger.Error("unsupported cloud for managing DNS")
 	return nil
 }
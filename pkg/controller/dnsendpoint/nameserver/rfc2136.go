@@ -0,0 +1,123 @@
+package nameserver
+
+import (
+	"context"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/rfc2136client"
+)
+
+// NewRFC2136Query creates a new name server query for a DNS server that accepts RFC2136 dynamic updates.
+func NewRFC2136Query(c client.Client, tsigSecretName, nameserver string) Query {
+	return &rfc2136Query{
+		getRFC2136Client: func() (rfc2136client.Client, error) {
+			tsigSecret := &corev1.Secret{}
+			if err := c.Get(
+				context.Background(),
+				client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: tsigSecretName},
+				tsigSecret,
+			); err != nil {
+				return nil, errors.Wrap(err, "could not get the TSIG secret")
+			}
+			rfc2136Client, err := rfc2136client.NewClientFromSecret(tsigSecret, nameserver)
+			return rfc2136Client, errors.Wrap(err, "error creating RFC2136 client")
+		},
+	}
+}
+
+type rfc2136Query struct {
+	getRFC2136Client func() (rfc2136client.Client, error)
+}
+
+var _ Query = (*rfc2136Query)(nil)
+
+// Get implements Query.Get.
+func (q *rfc2136Query) Get(rootDomain string) (map[string]sets.String, error) {
+	rfc2136Client, err := q.getRFC2136Client()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get RFC2136 client")
+	}
+	currentNameServers, err := q.queryNameServers(rfc2136Client, rootDomain)
+	return currentNameServers, errors.Wrap(err, "error querying name servers")
+}
+
+// Create implements Query.Create.
+func (q *rfc2136Query) Create(rootDomain string, domain string, values sets.String) error {
+	rfc2136Client, err := q.getRFC2136Client()
+	if err != nil {
+		return errors.Wrap(err, "failed to get RFC2136 client")
+	}
+	return errors.Wrap(q.createNameServers(rfc2136Client, rootDomain, domain, values), "error creating the name server")
+}
+
+// Delete implements Query.Delete.
+func (q *rfc2136Query) Delete(rootDomain string, domain string, values sets.String) error {
+	rfc2136Client, err := q.getRFC2136Client()
+	if err != nil {
+		return errors.Wrap(err, "failed to get RFC2136 client")
+	}
+	return errors.Wrap(q.deleteNameServers(rfc2136Client, rootDomain, domain), "error deleting the name servers")
+}
+
+// queryNameServers transfers the zone of the root domain and collects the name servers of each of its domains.
+func (q *rfc2136Query) queryNameServers(rfc2136Client rfc2136client.Client, rootDomain string) (map[string]sets.String, error) {
+	records, err := rfc2136Client.Transfer(rootDomain)
+	if err != nil {
+		return nil, err
+	}
+	nameServers := map[string]sets.String{}
+	for _, rr := range records {
+		ns, ok := rr.(*dns.NS)
+		if !ok {
+			continue
+		}
+		domain := controllerutils.Undotted(ns.Hdr.Name)
+		values, ok := nameServers[domain]
+		if !ok {
+			values = sets.NewString()
+			nameServers[domain] = values
+		}
+		values.Insert(controllerutils.Undotted(ns.Ns))
+	}
+	return nameServers, nil
+}
+
+// createNameServers replaces the name servers for the specified domain in the zone of the root domain.
+func (q *rfc2136Query) createNameServers(rfc2136Client rfc2136client.Client, rootDomain string, domain string, values sets.String) error {
+	insert := make([]dns.RR, len(values))
+	for i, v := range values.List() {
+		insert[i] = &dns.NS{
+			Hdr: dns.RR_Header{
+				Name:   dns.Fqdn(domain),
+				Rrtype: dns.TypeNS,
+				Class:  dns.ClassINET,
+				Ttl:    60,
+			},
+			Ns: dns.Fqdn(v),
+		}
+	}
+	return rfc2136Client.Update(rootDomain, nsRRset(domain), insert)
+}
+
+// deleteNameServers deletes the name servers for the specified domain in the zone of the root domain.
+func (q *rfc2136Query) deleteNameServers(rfc2136Client rfc2136client.Client, rootDomain string, domain string) error {
+	return rfc2136Client.Update(rootDomain, nsRRset(domain), nil)
+}
+
+// nsRRset returns a record identifying the NS RRset of the domain.
+func nsRRset(domain string) []dns.RR {
+	return []dns.RR{&dns.NS{
+		Hdr: dns.RR_Header{
+			Name:   dns.Fqdn(domain),
+			Rrtype: dns.TypeNS,
+			Class:  dns.ClassINET,
+		},
+	}}
+}
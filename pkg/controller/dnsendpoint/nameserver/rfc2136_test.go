@@ -0,0 +1,172 @@
+package nameserver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/rfc2136client"
+	rfc2136test "github.com/openshift/hive/pkg/test/rfc2136"
+)
+
+const testRFC2136RootDomain = "rfc2136.test.com"
+
+// Unlike the tests for the cloud providers, this test runs against an in-process DNS server.
+func TestRFC2136(t *testing.T) {
+	suite.Run(t, &RFC2136TestSuite{})
+}
+
+type RFC2136TestSuite struct {
+	suite.Suite
+	server *rfc2136test.Server
+}
+
+func (s *RFC2136TestSuite) SetupTest() {
+	server, err := rfc2136test.NewServer(testRFC2136RootDomain)
+	s.Require().NoError(err, "unexpected error starting DNS server")
+	s.server = server
+}
+
+func (s *RFC2136TestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *RFC2136TestSuite) TestGetForNonExistentZone() {
+	nameServers, err := s.getCUT().Get("non-existent.zone.rfc2136.test.com")
+	s.Error(err, "expected error")
+	s.Empty(nameServers, "expected no name servers")
+}
+
+func (s *RFC2136TestSuite) TestGetForExistentZone() {
+	s.server.AddRecords(
+		fmt.Sprintf("delegated.%s 60 IN NS ns.other.com.", testRFC2136RootDomain),
+		fmt.Sprintf("api.%s 60 IN A 10.0.0.1", testRFC2136RootDomain),
+	)
+	nameServers, err := s.getCUT().Get(testRFC2136RootDomain)
+	s.NoError(err, "expected no error")
+	s.Equal(map[string]sets.String{
+		testRFC2136RootDomain:                sets.NewString("ns1."+testRFC2136RootDomain, "ns2."+testRFC2136RootDomain),
+		"delegated." + testRFC2136RootDomain: sets.NewString("ns.other.com"),
+	}, nameServers, "unexpected name servers")
+}
+
+func (s *RFC2136TestSuite) TestCreateAndDelete_SingleValue() {
+	s.testCreateAndDelete(&testCreateAndDeleteCase{
+		createValues: []string{"test-value"},
+		deleteValues: []string{"test-value"},
+	})
+}
+
+func (s *RFC2136TestSuite) TestCreateAndDelete_SingleValueOutdatedDelete() {
+	s.testCreateAndDelete(&testCreateAndDeleteCase{
+		createValues: []string{"test-value"},
+		deleteValues: []string{"bad-value"},
+	})
+}
+
+func (s *RFC2136TestSuite) TestCreateAndDelete_MultipleValues() {
+	s.testCreateAndDelete(&testCreateAndDeleteCase{
+		createValues: []string{"test-value-1", "test-value-2", "test-value-3"},
+		deleteValues: []string{"test-value-1", "test-value-2", "test-value-3"},
+	})
+}
+
+func (s *RFC2136TestSuite) TestCreateAndDelete_MultipleValuesOutdatedDelete() {
+	s.testCreateAndDelete(&testCreateAndDeleteCase{
+		createValues: []string{"test-value-1", "test-value-2", "test-value-3"},
+		deleteValues: []string{"test-value-1", "test-value-2"},
+	})
+}
+
+func (s *RFC2136TestSuite) TestCreateAndDelete_UnknownDeleteValues() {
+	s.testCreateAndDelete(&testCreateAndDeleteCase{
+		createValues: []string{"test-value"},
+	})
+}
+
+func (s *RFC2136TestSuite) TestCreateThenUpdate_SameValuesOnUpdate() {
+	s.testCreateThenUpdate(&testCreateThenUpdateCase{
+		createValues: []string{"test-value"},
+		updateValues: []string{"test-value"},
+	})
+}
+
+func (s *RFC2136TestSuite) TestCreateThenUpdate_DifferentValuesOnUpdate() {
+	s.testCreateThenUpdate(&testCreateThenUpdateCase{
+		createValues: []string{"test-value"},
+		updateValues: []string{"test-value-2"},
+	})
+}
+
+func (s *RFC2136TestSuite) TestDeleteOfNonExistentNS() {
+	cases := []struct {
+		name         string
+		deleteValues []string
+	}{
+		{
+			name:         "known values",
+			deleteValues: []string{"test-value."},
+		},
+		{
+			name: "unknown values",
+		},
+	}
+	for _, tc := range cases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			err := s.getCUT().Delete(testRFC2136RootDomain, fmt.Sprintf("non-existent.subdomain.%s", testRFC2136RootDomain), sets.NewString(tc.deleteValues...))
+			s.NoError(err, "expected no error")
+		})
+	}
+}
+
+func (s *RFC2136TestSuite) testCreateAndDelete(tc *testCreateAndDeleteCase) {
+	cut := s.getCUT()
+	domain := fmt.Sprintf("rfc2136-test.%s", testRFC2136RootDomain)
+	err := cut.Create(testRFC2136RootDomain, domain, sets.NewString(tc.createValues...))
+	s.Require().NoError(err, "unexpected error creating NS")
+
+	nameServers, err := cut.Get(testRFC2136RootDomain)
+	s.NoError(err, "unexpected error querying domain")
+	s.Equal(sets.NewString(tc.createValues...), nameServers[domain], "unexpected values for domain")
+
+	err = cut.Delete(testRFC2136RootDomain, domain, sets.NewString(tc.deleteValues...))
+	s.NoError(err, "unexpected error deleting NS")
+
+	nameServers, err = cut.Get(testRFC2136RootDomain)
+	s.NoError(err, "unexpected error querying domain")
+	s.NotContains(nameServers, domain, "expected name servers for domain to be deleted")
+}
+
+func (s *RFC2136TestSuite) testCreateThenUpdate(tc *testCreateThenUpdateCase) {
+	cut := s.getCUT()
+	domain := fmt.Sprintf("rfc2136-test.%s", testRFC2136RootDomain)
+	err := cut.Create(testRFC2136RootDomain, domain, sets.NewString(tc.createValues...))
+	s.Require().NoError(err, "unexpected error creating NS")
+
+	// now test updating by re-issuing a Create()
+	err = cut.Create(testRFC2136RootDomain, domain, sets.NewString(tc.updateValues...))
+	s.NoError(err, "unexpected error updating NS")
+
+	nameServers, err := cut.Get(testRFC2136RootDomain)
+	s.NoError(err, "unexpected error querying domain")
+	s.Equal(sets.NewString(tc.updateValues...), nameServers[domain], "unexpected values for domain")
+}
+
+func (s *RFC2136TestSuite) getCUT() *rfc2136Query {
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			constants.RFC2136TSIGKeyNameSecretKey: []byte(rfc2136test.KeyName),
+			constants.RFC2136TSIGSecretSecretKey:  []byte(rfc2136test.Secret),
+		},
+	}
+	return &rfc2136Query{
+		getRFC2136Client: func() (rfc2136client.Client, error) {
+			return rfc2136client.NewClientFromSecret(secret, s.server.Addr)
+		},
+	}
+}
@@ -1,5 +1,9 @@
 package dnszone
 
+// txtRecordTTL is the TTL in seconds of the TXT records set by the TXTRecordActuators. It is short so that
+// changes of the records are quickly visible to the ACME servers.
+const txtRecordTTL = 60
+
 // Actuator interface is the interface that is used to add dns provider support to the dnszone controller.
 type Actuator interface {
 	// Create tells the actuator to make a zone in the dns provider.
@@ -26,3 +30,15 @@ type Actuator interface {
 	// SetConditionsForError sets conditions on the dnszone given a specific error
 	SetConditionsForError(err error) bool
 }
+
+// TXTRecordActuator is the interface of the actuators that can manage TXT records in the zone, as used by the
+// DNS-01 challenges of ACME certificate issuers. Unlike the Actuator functions, Refresh does not need to be called
+// before these functions, but the platform-specific DNSZone status fields must have been populated.
+type TXTRecordActuator interface {
+	// SetTXTRecord creates or replaces the TXT record with the given fully qualified name in the zone.
+	SetTXTRecord(name string, values []string) error
+
+	// DeleteTXTRecord removes the TXT record with the given fully qualified name and values from the zone.
+	// It does not fail when the record does not exist.
+	DeleteTXTRecord(name string, values []string) error
+}
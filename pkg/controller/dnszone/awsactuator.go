@@ -3,6 +3,7 @@ package dnszone
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -728,6 +729,50 @@ func (a *AWSActuator) SetConditionsForError(err error) bool {
 	return accessDeniedCondsChanged || authenticationFailureCondsChanged || apiOptInCondsChanged || cloudErrorsCondsChanged
 }
 
+// Ensure AWSActuator implements the TXTRecordActuator interface. This will fail at compile time when false.
+var _ TXTRecordActuator = &AWSActuator{}
+
+// SetTXTRecord implements the SetTXTRecord call of the TXTRecordActuator interface
+func (a *AWSActuator) SetTXTRecord(name string, values []string) error {
+	return a.changeTXTRecord(name, values, route53.ChangeActionUpsert)
+}
+
+// DeleteTXTRecord implements the DeleteTXTRecord call of the TXTRecordActuator interface
+func (a *AWSActuator) DeleteTXTRecord(name string, values []string) error {
+	err := a.changeTXTRecord(name, values, route53.ChangeActionDelete)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeInvalidChangeBatch &&
+		strings.HasSuffix(awsErr.Message(), "not found]") {
+		return nil
+	}
+	return err
+}
+
+func (a *AWSActuator) changeTXTRecord(name string, values []string, action string) error {
+	if a.dnsZone.Status.AWS == nil || a.dnsZone.Status.AWS.ZoneID == nil {
+		return errors.New("the ID of the hosted zone is not known yet")
+	}
+	records := make([]*route53.ResourceRecord, len(values))
+	for i, v := range values {
+		records[i] = &route53.ResourceRecord{Value: aws.String(strconv.Quote(v))}
+	}
+	a.logger.WithField("name", name).WithField("action", action).Debug("changing TXT record")
+	_, err := a.awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: a.dnsZone.Status.AWS.ZoneID,
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action: aws.String(action),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name:            aws.String(name),
+					Type:            aws.String(route53.RRTypeTxt),
+					TTL:             aws.Int64(txtRecordTTL),
+					ResourceRecords: records,
+				},
+			}},
+		},
+	})
+	return err
+}
+
 func tagEquals(a, b *route53.Tag) bool {
 	if a == nil && b == nil {
 		return true
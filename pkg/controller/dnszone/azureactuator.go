@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest/to"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
@@ -66,7 +67,7 @@ func (a *AzureActuator) Create() error {
 	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
 
 	zone := a.dnsZone.Spec.Zone
-	managedZone, err := a.azureClient.CreateOrUpdateZone(context.TODO(), resourceGroupName, zone)
+	managedZone, err := a.azureClient.CreateOrUpdateZone(context.TODO(), resourceGroupName, zone, a.dnsZone.Spec.Azure.AdditionalTags)
 	if err != nil {
 		logger.WithError(err).Error("Error creating managed zone")
 		return err
@@ -229,3 +230,43 @@ func (a *AzureActuator) SetConditionsForError(err error) bool {
 	}
 	return cloudErrorsCondsChanged
 }
+
+// Ensure AzureActuator implements the TXTRecordActuator interface. This will fail at compile time when false.
+var _ TXTRecordActuator = &AzureActuator{}
+
+// SetTXTRecord implements the SetTXTRecord call of the TXTRecordActuator interface
+func (a *AzureActuator) SetTXTRecord(name string, values []string) error {
+	txtRecords := make([]dns.TxtRecord, len(values))
+	for i := range values {
+		txtRecords[i] = dns.TxtRecord{Value: &[]string{values[i]}}
+	}
+	a.logger.WithField("name", name).Debug("setting TXT record")
+	_, err := a.azureClient.CreateOrUpdateRecordSet(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone,
+		a.relativeRecordName(name), dns.TXT, dns.RecordSet{
+			RecordSetProperties: &dns.RecordSetProperties{
+				TxtRecords: &txtRecords,
+				TTL:        to.Int64Ptr(txtRecordTTL),
+			},
+		})
+	return err
+}
+
+// DeleteTXTRecord implements the DeleteTXTRecord call of the TXTRecordActuator interface
+func (a *AzureActuator) DeleteTXTRecord(name string, values []string) error {
+	a.logger.WithField("name", name).Debug("deleting TXT record")
+	err := a.azureClient.DeleteRecordSet(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone,
+		a.relativeRecordName(name), dns.TXT)
+	if azureclient.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// relativeRecordName returns the name of the record set relative to the zone, as used by Azure DNS.
+func (a *AzureActuator) relativeRecordName(name string) string {
+	name = controllerutils.Undotted(name)
+	if name == a.dnsZone.Spec.Zone {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+a.dnsZone.Spec.Zone)
+}
@@ -79,7 +79,11 @@ func mockAzureZoneDoesntExist(expect *mock.MockClientMockRecorder) {
 }
 
 func mockCreateAzureZone(expect *mock.MockClientMockRecorder) {
-	expect.CreateOrUpdateZone(gomock.Any(), gomock.Any(), gomock.Any()).Return(dns.Zone{
+	mockCreateAzureZoneWithTags(expect, gomock.Any())
+}
+
+func mockCreateAzureZoneWithTags(expect *mock.MockClientMockRecorder, tags interface{}) {
+	expect.CreateOrUpdateZone(gomock.Any(), gomock.Any(), gomock.Any(), tags).Return(dns.Zone{
 		Name: to.StringPtr("blah.example.com"),
 		ZoneProperties: &dns.ZoneProperties{
 			NameServers: to.StringSlicePtr([]string{"ns1.example.com", "ns2.example.com"}),
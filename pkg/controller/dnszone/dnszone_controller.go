@@ -20,6 +20,7 @@ import (
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/rfc2136client"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -390,6 +391,12 @@ func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 }
 
 func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.FieldLogger) (Actuator, error) {
+	return NewActuator(r.Client, dnsZone, dnsLog)
+}
+
+// NewActuator creates the actuator for the DNS provider of the DNSZone, using the credentials referenced by
+// the DNSZone. All the actuators also implement TXTRecordActuator.
+func NewActuator(c client.Client, dnsZone *hivev1.DNSZone, dnsLog log.FieldLogger) (Actuator, error) {
 	if dnsZone.Spec.AWS != nil {
 		credentials := awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
@@ -405,12 +412,12 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 			},
 		}
 
-		return NewAWSActuator(dnsLog, r.Client, credentials, dnsZone, awsclient.New)
+		return NewAWSActuator(dnsLog, c, credentials, dnsZone, awsclient.New)
 	}
 
 	if dnsZone.Spec.GCP != nil {
 		secret := &corev1.Secret{}
-		err := r.Get(context.TODO(),
+		err := c.Get(context.TODO(),
 			types.NamespacedName{
 				Name:      dnsZone.Spec.GCP.CredentialsSecretRef.Name,
 				Namespace: dnsZone.Namespace,
@@ -425,7 +432,7 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 
 	if dnsZone.Spec.Azure != nil {
 		secret := &corev1.Secret{}
-		err := r.Get(context.TODO(),
+		err := c.Get(context.TODO(),
 			types.NamespacedName{
 				Name:      dnsZone.Spec.Azure.CredentialsSecretRef.Name,
 				Namespace: dnsZone.Namespace,
@@ -438,6 +445,21 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 		return NewAzureActuator(dnsLog, secret, dnsZone, azureclient.NewClientFromSecret)
 	}
 
+	if dnsZone.Spec.RFC2136 != nil {
+		secret := &corev1.Secret{}
+		err := c.Get(context.TODO(),
+			types.NamespacedName{
+				Name:      dnsZone.Spec.RFC2136.TSIGSecretRef.Name,
+				Namespace: dnsZone.Namespace,
+			},
+			secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewRFC2136Actuator(dnsLog, secret, dnsZone, rfc2136client.NewClientFromSecret)
+	}
+
 	return nil, errors.New("unable to determine which actuator to use")
 }
 
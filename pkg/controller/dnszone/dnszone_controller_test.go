@@ -419,6 +419,21 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
 			},
 		},
+		{
+			name: "Create Managed Zone with additional tags",
+			dnsZone: func() *hivev1.DNSZone {
+				zone := validAzureDNSZone()
+				zone.Spec.Azure.AdditionalTags = map[string]string{"foo": "bar"}
+				return zone
+			}(),
+			setupAzureMock: func(_ *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+				mockAzureZoneDoesntExist(expect)
+				mockCreateAzureZoneWithTags(expect, map[string]string{"foo": "bar"})
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
+			},
+		},
 		{
 			name:    "Adopt existing zone",
 			dnsZone: validAzureDNSZone(),
@@ -2,6 +2,7 @@ package dnszone
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -12,6 +13,7 @@ import (
 
 	dns "google.golang.org/api/dns/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
@@ -70,11 +72,16 @@ func (a *GCPActuator) Create() error {
 	logger.Info("Creating managed zone")
 
 	zone := a.dnsZone.Spec.Zone
+	var labels map[string]string
+	if a.dnsZone.Spec.GCP != nil {
+		labels = a.dnsZone.Spec.GCP.AdditionalLabels
+	}
 	managedZone, err := a.gcpClient.CreateManagedZone(
 		&dns.ManagedZone{
 			Name:        generateManagedZoneName(zone),
 			Description: managedByHiveDescription,
 			DnsName:     controllerutils.Dotted(zone),
+			Labels:      labels,
 		},
 	)
 
@@ -264,6 +271,60 @@ func (a *GCPActuator) SetConditionsForError(err error) bool {
 	return cloudErrorsCondsChanged
 }
 
+// Ensure GCPActuator implements the TXTRecordActuator interface. This will fail at compile time when false.
+var _ TXTRecordActuator = &GCPActuator{}
+
+// SetTXTRecord implements the SetTXTRecord call of the TXTRecordActuator interface
+func (a *GCPActuator) SetTXTRecord(name string, values []string) error {
+	zoneName, current, err := a.getTXTRecord(name)
+	if err != nil {
+		return err
+	}
+	desired := &dns.ResourceRecordSet{
+		Name: controllerutils.Dotted(name),
+		Type: "TXT",
+		Ttl:  txtRecordTTL,
+	}
+	for _, v := range values {
+		desired.Rrdatas = append(desired.Rrdatas, strconv.Quote(v))
+	}
+	if current != nil && current.Ttl == desired.Ttl && sets.NewString(current.Rrdatas...).Equal(sets.NewString(desired.Rrdatas...)) {
+		return nil
+	}
+	a.logger.WithField("name", name).Debug("setting TXT record")
+	return a.gcpClient.UpdateResourceRecordSet(zoneName, desired, current)
+}
+
+// DeleteTXTRecord implements the DeleteTXTRecord call of the TXTRecordActuator interface
+func (a *GCPActuator) DeleteTXTRecord(name string, values []string) error {
+	zoneName, current, err := a.getTXTRecord(name)
+	if err != nil || current == nil {
+		return err
+	}
+	a.logger.WithField("name", name).Debug("deleting TXT record")
+	return a.gcpClient.DeleteResourceRecordSet(zoneName, current)
+}
+
+// getTXTRecord returns the name of the managed zone and the TXT record set with the given name in it, which is
+// nil when the record set does not exist.
+func (a *GCPActuator) getTXTRecord(name string) (string, *dns.ResourceRecordSet, error) {
+	if a.dnsZone.Status.GCP == nil || a.dnsZone.Status.GCP.ZoneName == nil {
+		return "", nil, errors.New("the name of the managed zone is not known yet")
+	}
+	zoneName := *a.dnsZone.Status.GCP.ZoneName
+	resp, err := a.gcpClient.ListResourceRecordSets(zoneName, gcpclient.ListResourceRecordSetsOptions{
+		Name: controllerutils.Dotted(name),
+		Type: "TXT",
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if len(resp.Rrsets) == 0 {
+		return zoneName, nil, nil
+	}
+	return zoneName, resp.Rrsets[0], nil
+}
+
 func generateManagedZoneName(zone string) string {
 	tmp := strings.ToLower(zone)
 	tmp = strings.ReplaceAll(tmp, ".", "-")
@@ -0,0 +1,252 @@
+package dnszone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/rfc2136client"
+)
+
+const (
+	// rfc2136ZoneNotConfiguredReason is the reason of the DNSError condition of the DNSZones whose zone is not
+	// configured on the DNS server.
+	rfc2136ZoneNotConfiguredReason = "ZoneNotConfigured"
+)
+
+// errRFC2136ZoneNotConfigured is returned when creating a zone. The zone must be configured on the DNS server
+// before the DNSZone can become available.
+var errRFC2136ZoneNotConfigured = errors.New("RFC2136 dynamic updates cannot create zones, the zone must be created on the DNS server")
+
+// RFC2136Actuator attempts to make the current state reflect the given desired state.
+// RFC2136 dynamic updates cannot create or delete zones, so the zone must already be configured on the
+// DNS server. Deleting the DNSZone deletes the records in the zone instead.
+type RFC2136Actuator struct {
+	// logger is the logger used for this controller
+	logger log.FieldLogger
+
+	// rfc2136Client is a utility for making it easy for controllers to interface with the DNS server
+	rfc2136Client rfc2136client.Client
+
+	// dnsZone is the DNSZone that represents the desired state.
+	dnsZone *hivev1.DNSZone
+
+	// nameServers are the name servers of the zone. It is nil when the DNS server does not host the zone.
+	nameServers []string
+}
+
+type rfc2136ClientBuilderType func(secret *corev1.Secret, nameserver string) (rfc2136client.Client, error)
+
+// NewRFC2136Actuator creates a new RFC2136Actuator object. A new RFC2136Actuator is expected to be created for each controller sync.
+func NewRFC2136Actuator(
+	logger log.FieldLogger,
+	secret *corev1.Secret,
+	dnsZone *hivev1.DNSZone,
+	rfc2136ClientBuilder rfc2136ClientBuilderType,
+) (*RFC2136Actuator, error) {
+	rfc2136Client, err := rfc2136ClientBuilder(secret, dnsZone.Spec.RFC2136.Nameserver)
+	if err != nil {
+		logger.WithError(err).Error("Error creating RFC2136 client")
+		return nil, err
+	}
+
+	return &RFC2136Actuator{
+		logger:        logger,
+		rfc2136Client: rfc2136Client,
+		dnsZone:       dnsZone,
+	}, nil
+}
+
+// Ensure RFC2136Actuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &RFC2136Actuator{}
+
+// Create implements the Create call of the actuator interface
+func (a *RFC2136Actuator) Create() error {
+	return fmt.Errorf("zone %s is not configured on DNS server %s: %w", a.dnsZone.Spec.Zone, a.dnsZone.Spec.RFC2136.Nameserver, errRFC2136ZoneNotConfigured)
+}
+
+// Delete implements the Delete call of the actuator interface
+func (a *RFC2136Actuator) Delete() error {
+	if a.nameServers == nil {
+		return errors.New("zone is not hosted by the DNS server")
+	}
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Info("Deleting records in zone")
+	return DeleteRFC2136Records(a.rfc2136Client, a.dnsZone, logger)
+}
+
+// DeleteRFC2136Records will remove all non-essential records from the DNSZone provided.
+func DeleteRFC2136Records(rfc2136Client rfc2136client.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	zone := dns.Fqdn(dnsZone.Spec.Zone)
+	records, err := rfc2136Client.Transfer(zone)
+	if err != nil {
+		return err
+	}
+
+	type rrset struct {
+		name   string
+		rrType uint16
+	}
+	seen := map[rrset]bool{}
+	var remove []dns.RR
+	for _, rr := range records {
+		header := rr.Header()
+		key := rrset{name: strings.ToLower(header.Name), rrType: header.Rrtype}
+		// Ignore the SOA and NS records that define the zone and that cannot be deleted
+		if key.name == strings.ToLower(zone) && (key.rrType == dns.TypeSOA || key.rrType == dns.TypeNS) {
+			continue
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		logger.WithField("name", header.Name).WithField("type", dns.TypeToString[header.Rrtype]).Info("deleting recordset")
+		remove = append(remove, rr)
+	}
+	if len(remove) == 0 {
+		return nil
+	}
+	return rfc2136Client.Update(zone, remove, nil)
+}
+
+// Exists implements the Exists call of the actuator interface
+func (a *RFC2136Actuator) Exists() (bool, error) {
+	return a.nameServers != nil, nil
+}
+
+// GetNameServers implements the GetNameServers call of the actuator interface
+func (a *RFC2136Actuator) GetNameServers() ([]string, error) {
+	if a.nameServers == nil {
+		return nil, errors.New("zone is not hosted by the DNS server")
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.WithField("nameservers", a.nameServers).Debug("found zone name servers")
+	return a.nameServers, nil
+}
+
+// Refresh implements the Refresh call of the actuator interface
+func (a *RFC2136Actuator) Refresh() error {
+	zone := dns.Fqdn(a.dnsZone.Spec.Zone)
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+
+	// The DNS server hosts the zone if it answers authoritatively with the SOA record of the zone.
+	logger.Debug("Fetching zone SOA record")
+	resp, err := a.rfc2136Client.Query(zone, dns.TypeSOA)
+	if err != nil {
+		logger.WithError(err).Error("Cannot query zone SOA record")
+		return err
+	}
+	switch resp.Rcode {
+	case dns.RcodeSuccess, dns.RcodeNameError, dns.RcodeRefused:
+		// DNS servers refuse queries for zones they do not host
+	default:
+		err := fmt.Errorf("query for zone SOA record failed: %s", dns.RcodeToString[resp.Rcode])
+		logger.WithError(err).Error("Cannot query zone SOA record")
+		return err
+	}
+	if resp.Rcode != dns.RcodeSuccess || !resp.Authoritative || !hasRecord(resp.Answer, zone, dns.TypeSOA) {
+		logger.Debug("Zone not hosted by DNS server, clearing out the cached name servers")
+		a.nameServers = nil
+		return nil
+	}
+
+	logger.Debug("Fetching zone name servers")
+	resp, err = a.rfc2136Client.Query(zone, dns.TypeNS)
+	if err != nil {
+		logger.WithError(err).Error("Cannot query zone name servers")
+		return err
+	}
+	nameServers := []string{}
+	for _, rr := range resp.Answer {
+		if ns, ok := rr.(*dns.NS); ok && strings.EqualFold(ns.Hdr.Name, zone) {
+			nameServers = append(nameServers, controllerutils.Undotted(ns.Ns))
+		}
+	}
+	a.nameServers = nameServers
+	return nil
+}
+
+func hasRecord(records []dns.RR, name string, rrType uint16) bool {
+	for _, rr := range records {
+		if rr.Header().Rrtype == rrType && strings.EqualFold(rr.Header().Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateMetadata implements the UpdateMetadata call of the actuator interface
+func (a *RFC2136Actuator) UpdateMetadata() error {
+	return nil
+}
+
+// SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
+func (a *RFC2136Actuator) SetConditionsForError(err error) bool {
+	// other conditions not implemented for RFC2136 yet, so set generic condition
+	var cloudErrorsConds []hivev1.DNSZoneCondition
+	var cloudErrorsCondsChanged bool
+	switch {
+	case err == nil:
+		cloudErrorsConds, cloudErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			a.dnsZone.Status.Conditions,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionFalse,
+			dnsNoErrorReason,
+			"No cloud errors occurred",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	case errors.Is(err, errRFC2136ZoneNotConfigured):
+		cloudErrorsConds, cloudErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			a.dnsZone.Status.Conditions,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionTrue,
+			rfc2136ZoneNotConfiguredReason,
+			err.Error(),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	default:
+		cloudErrorsConds, cloudErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			a.dnsZone.Status.Conditions,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionTrue,
+			dnsCloudErrorReason,
+			controllerutils.ErrorScrub(err),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	if cloudErrorsCondsChanged {
+		a.dnsZone.Status.Conditions = cloudErrorsConds
+	}
+	return cloudErrorsCondsChanged
+}
+
+// Ensure RFC2136Actuator implements the TXTRecordActuator interface. This will fail at compile time when false.
+var _ TXTRecordActuator = &RFC2136Actuator{}
+
+// SetTXTRecord implements the SetTXTRecord call of the TXTRecordActuator interface
+func (a *RFC2136Actuator) SetTXTRecord(name string, values []string) error {
+	insert := make([]dns.RR, len(values))
+	for i, v := range values {
+		insert[i] = &dns.TXT{Hdr: txtRRHeader(name, txtRecordTTL), Txt: []string{v}}
+	}
+	a.logger.WithField("name", name).Debug("setting TXT record")
+	return a.rfc2136Client.Update(a.dnsZone.Spec.Zone, []dns.RR{&dns.TXT{Hdr: txtRRHeader(name, 0)}}, insert)
+}
+
+// DeleteTXTRecord implements the DeleteTXTRecord call of the TXTRecordActuator interface
+func (a *RFC2136Actuator) DeleteTXTRecord(name string, values []string) error {
+	a.logger.WithField("name", name).Debug("deleting TXT record")
+	return a.rfc2136Client.Update(a.dnsZone.Spec.Zone, []dns.RR{&dns.TXT{Hdr: txtRRHeader(name, 0)}}, nil)
+}
+
+func txtRRHeader(name string, ttl uint32) dns.RR_Header {
+	return dns.RR_Header{Name: dns.Fqdn(name), Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: ttl}
+}
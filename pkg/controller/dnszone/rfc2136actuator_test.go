@@ -0,0 +1,189 @@
+package dnszone
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/rfc2136client"
+	rfc2136test "github.com/openshift/hive/pkg/test/rfc2136"
+)
+
+func TestRFC2136Actuator(t *testing.T) {
+	cases := []struct {
+		name                string
+		hostedZones         []string
+		records             []string
+		secret              *corev1.Secret
+		expectRefreshErr    bool
+		expectExists        bool
+		expectedNameServers []string
+		expectCreateErr     bool
+	}{
+		{
+			name:                "zone hosted",
+			hostedZones:         []string{"blah.example.com"},
+			expectExists:        true,
+			expectedNameServers: []string{"ns1.blah.example.com", "ns2.blah.example.com"},
+		},
+		{
+			name:            "zone not hosted",
+			hostedZones:     []string{"other.example.com"},
+			expectCreateErr: true,
+		},
+		{
+			name:            "zone inside hosted zone",
+			hostedZones:     []string{"example.com"},
+			records:         []string{"api.blah.example.com 60 IN A 10.0.0.1"},
+			expectCreateErr: true,
+		},
+		{
+			name:        "invalid TSIG secret",
+			hostedZones: []string{"blah.example.com"},
+			secret: func() *corev1.Secret {
+				secret := validRFC2136Secret()
+				secret.Data[constants.RFC2136TSIGSecretSecretKey] = []byte("d3Jvbmctc2VjcmV0")
+				return secret
+			}(),
+			expectRefreshErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, err := rfc2136test.NewServer(tc.hostedZones...)
+			require.NoError(t, err, "unexpected error starting DNS server")
+			defer server.Close()
+			server.AddRecords(tc.records...)
+
+			dnsZone := validRFC2136DNSZone(server.Addr)
+			secret := tc.secret
+			if secret == nil {
+				secret = validRFC2136Secret()
+			}
+			actuator, err := NewRFC2136Actuator(log.WithField("controller", ControllerName), secret, dnsZone, rfc2136client.NewClientFromSecret)
+			require.NoError(t, err, "unexpected error creating actuator")
+
+			err = actuator.Refresh()
+			if tc.expectRefreshErr {
+				assert.Error(t, err, "expected error refreshing")
+				return
+			}
+			require.NoError(t, err, "unexpected error refreshing")
+
+			exists, err := actuator.Exists()
+			require.NoError(t, err, "unexpected error checking existence")
+			assert.Equal(t, tc.expectExists, exists, "unexpected zone existence")
+
+			if tc.expectExists {
+				nameServers, err := actuator.GetNameServers()
+				require.NoError(t, err, "unexpected error getting name servers")
+				sort.Strings(nameServers)
+				assert.Equal(t, tc.expectedNameServers, nameServers, "unexpected name servers")
+			}
+			if tc.expectCreateErr {
+				err := actuator.Create()
+				assert.Error(t, err, "expected error creating zone")
+				assert.True(t, actuator.SetConditionsForError(err), "expected conditions to change")
+				cond := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.GenericDNSErrorsCondition)
+				require.NotNil(t, cond, "expected DNSError condition")
+				assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected DNSError condition status")
+				assert.Equal(t, rfc2136ZoneNotConfiguredReason, cond.Reason, "unexpected DNSError condition reason")
+			}
+		})
+	}
+}
+
+func TestRFC2136ActuatorDelete(t *testing.T) {
+	server, err := rfc2136test.NewServer("blah.example.com", "example.com")
+	require.NoError(t, err, "unexpected error starting DNS server")
+	defer server.Close()
+	server.AddRecords(
+		"api.blah.example.com 60 IN A 10.0.0.1",
+		"api.blah.example.com 60 IN A 10.0.0.2",
+		"*.apps.blah.example.com 60 IN A 10.0.0.3",
+		"blah.example.com 60 IN TXT \"owner\"",
+		"other.example.com 60 IN A 10.0.0.4",
+	)
+
+	actuator, err := NewRFC2136Actuator(log.WithField("controller", ControllerName), validRFC2136Secret(), validRFC2136DNSZone(server.Addr), rfc2136client.NewClientFromSecret)
+	require.NoError(t, err, "unexpected error creating actuator")
+	require.NoError(t, actuator.Refresh(), "unexpected error refreshing")
+	require.NoError(t, actuator.Delete(), "unexpected error deleting")
+
+	var remaining []string
+	for _, rr := range server.Records("blah.example.com") {
+		remaining = append(remaining, dns.TypeToString[rr.Header().Rrtype])
+	}
+	assert.Equal(t, []string{"SOA", "NS", "NS"}, remaining, "expected only the zone SOA and NS records to remain")
+	assert.Len(t, server.Records("example.com"), 4, "expected records of other zones to remain")
+}
+
+func TestRFC2136ActuatorTXTRecord(t *testing.T) {
+	server, err := rfc2136test.NewServer("blah.example.com")
+	require.NoError(t, err, "unexpected error starting DNS server")
+	defer server.Close()
+	server.AddRecords("_acme-challenge.blah.example.com 60 IN TXT \"stale\"")
+
+	actuator, err := NewRFC2136Actuator(log.WithField("controller", ControllerName), validRFC2136Secret(), validRFC2136DNSZone(server.Addr), rfc2136client.NewClientFromSecret)
+	require.NoError(t, err, "unexpected error creating actuator")
+
+	txtValues := func() []string {
+		var values []string
+		for _, rr := range server.Records("blah.example.com") {
+			if txt, ok := rr.(*dns.TXT); ok && txt.Hdr.Name == "_acme-challenge.blah.example.com." {
+				values = append(values, txt.Txt...)
+			}
+		}
+		sort.Strings(values)
+		return values
+	}
+
+	require.NoError(t, actuator.SetTXTRecord("_acme-challenge.blah.example.com", []string{"value1", "value2"}), "unexpected error setting TXT record")
+	assert.Equal(t, []string{"value1", "value2"}, txtValues(), "unexpected TXT record values after set")
+
+	require.NoError(t, actuator.DeleteTXTRecord("_acme-challenge.blah.example.com", []string{"value1", "value2"}), "unexpected error deleting TXT record")
+	assert.Empty(t, txtValues(), "expected TXT record to be deleted")
+}
+
+func validRFC2136DNSZone(nameserver string) *hivev1.DNSZone {
+	return &hivev1.DNSZone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "dnszoneobject",
+			Namespace:  "ns",
+			Finalizers: []string{hivev1.FinalizerDNSZone},
+		},
+		Spec: hivev1.DNSZoneSpec{
+			Zone: "blah.example.com",
+			RFC2136: &hivev1.RFC2136DNSZoneSpec{
+				Nameserver: nameserver,
+				TSIGSecretRef: corev1.LocalObjectReference{
+					Name: "somesecret",
+				},
+			},
+		},
+	}
+}
+
+func validRFC2136Secret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "somesecret",
+			Namespace: "ns",
+		},
+		Data: map[string][]byte{
+			constants.RFC2136TSIGKeyNameSecretKey: []byte(rfc2136test.KeyName),
+			constants.RFC2136TSIGSecretSecretKey:  []byte(rfc2136test.Secret),
+		},
+	}
+}
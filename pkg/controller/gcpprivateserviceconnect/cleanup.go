@@ -0,0 +1,130 @@
+package gcpprivateserviceconnect
+
+import (
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+func cleanupRequired(cd *hivev1.ClusterDeployment) bool {
+	var pscStatus hivev1gcp.PrivateServiceConnectAccessStatus
+	if cd.Status.Platform != nil && cd.Status.Platform.GCP != nil && cd.Status.Platform.GCP.PrivateServiceConnect != nil {
+		pscStatus = *cd.Status.Platform.GCP.PrivateServiceConnect
+	}
+	return pscStatus.ServiceAttachmentSubnet != "" ||
+		pscStatus.ServiceAttachmentFirewall != "" ||
+		pscStatus.ServiceAttachment != "" ||
+		pscStatus.EndpointAddress != "" ||
+		pscStatus.Endpoint != "" ||
+		pscStatus.DNSZone != ""
+}
+
+// Cleanup deletes the GCP resources named after the infra ID of the metadata. The resources of a cluster deployment
+// without metadata cannot be found, so they are left alone.
+func (a *actuator) Cleanup(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error {
+	if metadata == nil {
+		logger.Debug("cluster deployment has no metadata to find the private service connect resources with, skipping cleanup")
+		return nil
+	}
+
+	if err := a.cleanupDNSZone(metadata, logger); err != nil {
+		logger.WithError(err).Error("error cleaning up DNS zone")
+		return err
+	}
+	if err := a.cleanupEndpoint(cd, metadata, logger); err != nil {
+		logger.WithError(err).Error("error cleaning up endpoint")
+		return err
+	}
+	if err := a.cleanupServiceAttachment(cd, metadata, logger); err != nil {
+		logger.WithError(err).Error("error cleaning up service attachment")
+		return err
+	}
+
+	initPrivateServiceConnectStatus(cd)
+	cd.Status.Platform.GCP.PrivateServiceConnect = nil
+	if err := a.r.updatePrivateServiceConnectStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment after cleanup of private service connect")
+		return err
+	}
+
+	return nil
+}
+
+func (a *actuator) cleanupDNSZone(metadata *hivev1.ClusterMetadata,
+	logger log.FieldLogger) error {
+	name := resourceName(metadata)
+	zoneLog := logger.WithField("dnsZone", name)
+
+	recordsResp, err := a.hub.ListResourceRecordSets(name, gcpclient.ListResourceRecordSetsOptions{})
+	if gcpErrCodeEquals(err, http.StatusNotFound) {
+		return nil // no more work
+	}
+	if err != nil {
+		zoneLog.WithError(err).Error("failed to list the DNS zone")
+		return err
+	}
+	for _, record := range recordsResp.Rrsets {
+		if record.Type == "SOA" || record.Type == "NS" {
+			// can't delete SOA and NS types
+			continue
+		}
+		if err := a.hub.DeleteResourceRecordSet(name, record); err != nil {
+			zoneLog.WithField("record", record.Name).WithError(err).Error("failed to delete the record from the DNS zone")
+			return err
+		}
+	}
+
+	if err := a.hub.DeleteManagedZone(name); err != nil && !gcpErrCodeEquals(err, http.StatusNotFound) {
+		zoneLog.WithError(err).Error("error deleting the DNS zone")
+		return err
+	}
+
+	return nil
+}
+
+func (a *actuator) cleanupEndpoint(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	logger log.FieldLogger) error {
+	region := controllerutils.GetClusterRegion(cd)
+	name := resourceName(metadata)
+	endpointLog := logger.WithField("endpoint", name)
+
+	if err := a.hub.DeleteForwardingRule(region, name); err != nil && !gcpErrCodeEquals(err, http.StatusNotFound) {
+		endpointLog.WithError(err).Error("error deleting the endpoint")
+		return err
+	}
+	if err := a.hub.DeleteAddress(region, name); err != nil && !gcpErrCodeEquals(err, http.StatusNotFound) {
+		endpointLog.WithError(err).Error("error deleting the endpoint address")
+		return err
+	}
+
+	return nil
+}
+
+func (a *actuator) cleanupServiceAttachment(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	logger log.FieldLogger) error {
+	region := controllerutils.GetClusterRegion(cd)
+	name := resourceName(metadata)
+	serviceLog := logger.WithField("serviceAttachment", name)
+
+	if err := a.user.DeleteServiceAttachment(region, name); err != nil && !gcpErrCodeEquals(err, http.StatusNotFound) {
+		serviceLog.WithError(err).Error("error deleting the service attachment")
+		return err
+	}
+	if err := a.user.DeleteFirewall(name); err != nil && !gcpErrCodeEquals(err, http.StatusNotFound) {
+		serviceLog.WithError(err).Error("error deleting the service attachment firewall")
+		return err
+	}
+
+	// an existing subnet given for the service attachment was not created by the controller, so it is left alone.
+	if err := a.user.DeleteSubnetwork(region, name); err != nil && !gcpErrCodeEquals(err, http.StatusNotFound) {
+		serviceLog.WithError(err).Error("error deleting the service attachment subnet")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,683 @@
+package gcpprivateserviceconnect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	compute "google.golang.org/api/compute/v1"
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/privatelink"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+const (
+	ControllerName = hivev1.GCPPrivateServiceConnectControllerName
+	finalizer      = "hive.openshift.io/gcp-private-service-connect"
+
+	lastCleanupAnnotationKey = "gcp-private-service-connect-controller.hive.openshift.io/last-cleanup-for"
+
+	// defaultServiceAttachmentSubnetCIDR is the IP range of the subnet created for the service attachment
+	// when the cluster deployment does not configure one.
+	defaultServiceAttachmentSubnetCIDR = "192.168.0.0/29"
+
+	// serviceAttachmentConnectionLimit is the number of endpoints the hub project can connect to the
+	// service attachment of a cluster.
+	serviceAttachmentConnectionLimit = 1
+)
+
+// Add creates a new GCPPrivateServiceConnect Controller and adds it to the Manager with default RBAC.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	reconciler, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("could not create reconciler")
+		return err
+	}
+	return AddToManager(mgr, reconciler, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileGCPPrivateServiceConnect
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileGCPPrivateServiceConnect, error) {
+	logger := log.WithField("controller", ControllerName)
+	reconciler := &ReconcileGCPPrivateServiceConnect{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+	}
+
+	config, err := ReadGCPPrivateServiceConnectControllerConfigFile()
+	if err != nil {
+		logger.WithError(err).Error("could not get load configuration")
+		return reconciler, err
+	}
+	reconciler.controllerconfig = config
+	reconciler.gcpClientFn = gcpclient.NewClientFromSecret
+	return reconciler, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileGCPPrivateServiceConnect, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controller.New("gcpprivateserviceconnect-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}},
+		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, controllerutils.IsClusterDeploymentErrorUpdateEvent))
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		return err
+	}
+
+	// Watch for changes to ClusterProvision
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterProvision{}},
+		&handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &hivev1.ClusterDeployment{},
+		}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster provision")
+		return err
+	}
+
+	// Watch for changes to ClusterDeprovision
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeprovision{}},
+		&handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &hivev1.ClusterDeployment{},
+		}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deprovision")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileGCPPrivateServiceConnect{}
+
+// ReconcileGCPPrivateServiceConnect reconciles a PrivateServiceConnect for clusterdeployment object
+type ReconcileGCPPrivateServiceConnect struct {
+	client.Client
+
+	controllerconfig *hivev1.GCPPrivateServiceConnectConfig
+
+	// testing purpose
+	gcpClientFn gcpClientFn
+}
+
+type gcpClientFn func(*corev1.Secret) (gcpclient.Client, error)
+
+// Reconcile reconciles PrivateServiceConnect for ClusterDeployment.
+func (r *ReconcileGCPPrivateServiceConnect) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	return r.privateLinkReconciler().Reconcile(ctx, request)
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) privateLinkReconciler() *privatelink.Reconciler {
+	return &privatelink.Reconciler{
+		Client:                   r.Client,
+		Platform:                 r,
+		ControllerName:           ControllerName,
+		Finalizer:                finalizer,
+		LastCleanupAnnotationKey: lastCleanupAnnotationKey,
+		FailedCondition:          hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+		ReadyCondition:           hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+		AccessName:               "PrivateServiceConnect",
+		AccessDescription:        "private service connect",
+	}
+}
+
+// Access returns whether the cluster deployment configures GCP Private Service Connect, and whether it is enabled.
+func (r *ReconcileGCPPrivateServiceConnect) Access(cd *hivev1.ClusterDeployment) (bool, bool) {
+	if cd.Spec.Platform.GCP == nil || cd.Spec.Platform.GCP.PrivateServiceConnect == nil {
+		return false, false
+	}
+	return true, cd.Spec.Platform.GCP.PrivateServiceConnect.Enabled
+}
+
+// SupportsRegion returns true when a network of the inventory has a subnet in the region.
+func (r *ReconcileGCPPrivateServiceConnect) SupportsRegion(region string) bool {
+	for _, item := range r.controllerconfig.EndpointVPCInventory {
+		for _, subnet := range item.Subnets {
+			if strings.EqualFold(subnet.Region, region) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AssociatedNetworks returns the networks that resolve the API domain of the clusters.
+func (r *ReconcileGCPPrivateServiceConnect) AssociatedNetworks() []string {
+	return r.controllerconfig.AssociatedNetworks
+}
+
+// CleanupRequired returns true when the status of the cluster deployment records GCP resources.
+func (r *ReconcileGCPPrivateServiceConnect) CleanupRequired(cd *hivev1.ClusterDeployment) bool {
+	return cleanupRequired(cd)
+}
+
+// NewActuator returns the actuator for the GCP resources of the cluster deployment.
+func (r *ReconcileGCPPrivateServiceConnect) NewActuator(cd *hivev1.ClusterDeployment) (privatelink.Actuator, error) {
+	return newActuator(r, cd)
+}
+
+// ReconcileService discovers the internal API forwarding rule of the cluster, and makes sure a service attachment
+// with its subnet and firewall exposes it to the HUB project.
+func (a *actuator) ReconcileService(cd *hivev1.ClusterDeployment, clusterMetadata *hivev1.ClusterMetadata, logger log.FieldLogger) (bool, *privatelink.Service, error) {
+	// discover the internal API forwarding rule for the cluster.
+	apiForwardingRule, err := a.user.GetForwardingRule(controllerutils.GetClusterRegion(cd), clusterMetadata.InfraID+"-api-internal")
+	if err != nil {
+		if gcpErrCodeEquals(err, http.StatusNotFound) {
+			return false, nil, privatelink.NewWaitingError("DiscoveringForwardingRuleNotYetFound",
+				"discovering internal API forwarding rule for the cluster, but it does not exist yet")
+		}
+
+		logger.WithField("infraID", clusterMetadata.InfraID).WithError(err).Error("error discovering internal API forwarding rule for the cluster")
+		return false, nil, privatelink.NewConditionError("DiscoveringForwardingRuleFailed", err)
+	}
+
+	// reconcile the subnet and firewall of the service attachment
+	subnetModified, subnet, err := a.reconcileServiceAttachmentSubnet(cd, clusterMetadata, apiForwardingRule, logger)
+	if err != nil {
+		return false, nil, privatelink.NewConditionError("ServiceAttachmentSubnetReconcileFailed",
+			errors.Wrap(err, "failed to reconcile the service attachment subnet"))
+	}
+
+	firewallModified, err := a.reconcileServiceAttachmentFirewall(cd, clusterMetadata, subnet, logger)
+	if err != nil {
+		return false, nil, privatelink.NewConditionError("ServiceAttachmentFirewallReconcileFailed",
+			errors.Wrap(err, "failed to reconcile the service attachment firewall"))
+	}
+
+	// reconcile the service attachment
+	serviceAttachmentModified, serviceAttachment, err := a.reconcileServiceAttachment(cd, clusterMetadata, apiForwardingRule, subnet, logger)
+	if err != nil {
+		return false, nil, privatelink.NewConditionError("ServiceAttachmentReconcileFailed",
+			errors.Wrap(err, "failed to reconcile the service attachment"))
+	}
+
+	modified := subnetModified || firewallModified || serviceAttachmentModified
+	return modified, &privatelink.Service{Name: serviceAttachment.Name, ID: serviceAttachment.SelfLink}, nil
+}
+
+// reconcileServiceAttachmentSubnet ensures that the subnet used by the service attachment to translate the
+// addresses of the connections exists in the network of the cluster's internal API forwarding rule.
+// When the cluster deployment names an existing subnet, that subnet is used instead of creating one.
+func (a *actuator) reconcileServiceAttachmentSubnet(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	apiForwardingRule *compute.ForwardingRule,
+	logger log.FieldLogger) (bool, *compute.Subnetwork, error) {
+	modified := false
+	region := controllerutils.GetClusterRegion(cd)
+	spec := cd.Spec.Platform.GCP.PrivateServiceConnect.ServiceAttachmentSubnet
+	if spec == nil {
+		spec = &hivev1gcp.ServiceAttachmentSubnet{}
+	}
+
+	name := spec.Existing
+	if name == "" {
+		name = resourceName(metadata)
+	}
+	subnetLog := logger.WithField("subnet", name)
+
+	subnet, err := a.user.GetSubnetwork(region, name)
+	switch {
+	case gcpErrCodeEquals(err, http.StatusNotFound) && spec.Existing == "":
+		cidr := spec.CIDR
+		if cidr == "" {
+			cidr = defaultServiceAttachmentSubnetCIDR
+		}
+		modified = true
+		subnet, err = a.user.CreateSubnetwork(region, &compute.Subnetwork{
+			Name:        name,
+			Description: fmt.Sprintf("Private Service Connect service attachment subnet for cluster %s", metadata.InfraID),
+			Network:     apiForwardingRule.Network,
+			IpCidrRange: cidr,
+			Purpose:     "PRIVATE_SERVICE_CONNECT",
+		})
+		if err != nil {
+			subnetLog.WithError(err).Error("failed to create the service attachment subnet")
+			return modified, nil, err
+		}
+	case err != nil:
+		subnetLog.WithError(err).Error("failed to get the service attachment subnet")
+		return modified, nil, err
+	}
+
+	initPrivateServiceConnectStatus(cd)
+	cd.Status.Platform.GCP.PrivateServiceConnect.ServiceAttachmentSubnet = subnet.SelfLink
+	if err := a.r.updatePrivateServiceConnectStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment status with serviceAttachmentSubnet")
+		return modified, nil, err
+	}
+
+	return modified, subnet, nil
+}
+
+// reconcileServiceAttachmentFirewall ensures that the control plane machines of the cluster allow the connections
+// to the API server from the subnet of the service attachment.
+func (a *actuator) reconcileServiceAttachmentFirewall(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	subnet *compute.Subnetwork,
+	logger log.FieldLogger) (bool, error) {
+	modified := false
+	name := resourceName(metadata)
+	firewallLog := logger.WithField("firewall", name)
+
+	firewall, err := a.user.GetFirewall(name)
+	switch {
+	case gcpErrCodeEquals(err, http.StatusNotFound):
+		modified = true
+		firewall, err = a.user.CreateFirewall(&compute.Firewall{
+			Name:         name,
+			Description:  fmt.Sprintf("Private Service Connect access to the API of cluster %s", metadata.InfraID),
+			Network:      subnet.Network,
+			Direction:    "INGRESS",
+			SourceRanges: []string{subnet.IpCidrRange},
+			TargetTags:   []string{metadata.InfraID + "-master"},
+			Allowed: []*compute.FirewallAllowed{{
+				IPProtocol: "tcp",
+				Ports:      []string{"6443"},
+			}},
+		})
+		if err != nil {
+			firewallLog.WithError(err).Error("failed to create the service attachment firewall")
+			return modified, err
+		}
+	case err != nil:
+		firewallLog.WithError(err).Error("failed to get the service attachment firewall")
+		return modified, err
+	}
+
+	initPrivateServiceConnectStatus(cd)
+	cd.Status.Platform.GCP.PrivateServiceConnect.ServiceAttachmentFirewall = firewall.SelfLink
+	if err := a.r.updatePrivateServiceConnectStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment status with serviceAttachmentFirewall")
+		return modified, err
+	}
+
+	return modified, nil
+}
+
+// reconcileServiceAttachment ensures that a service attachment is created for the cluster's internal API
+// forwarding rule. It continuously makes sure that only the HUB project is allowed to connect endpoints to
+// the service attachment, and that the service attachment uses the subnet computed by the controller.
+func (a *actuator) reconcileServiceAttachment(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	apiForwardingRule *compute.ForwardingRule, subnet *compute.Subnetwork,
+	logger log.FieldLogger) (bool, *gcpclient.ServiceAttachment, error) {
+	modified := false
+	region := controllerutils.GetClusterRegion(cd)
+	name := resourceName(metadata)
+	serviceLog := logger.WithField("serviceAttachment", name)
+
+	hubProject, err := a.hub.GetComputeProject()
+	if err != nil {
+		serviceLog.WithError(err).Error("error getting the project that will create the endpoint")
+		return modified, nil, err
+	}
+	desiredAcceptList := []*gcpclient.ServiceAttachmentConsumerProjectLimit{{
+		ProjectIdOrNum:  hubProject.Name,
+		ConnectionLimit: serviceAttachmentConnectionLimit,
+	}}
+
+	serviceAttachment, err := a.user.GetServiceAttachment(region, name)
+	switch {
+	case gcpErrCodeEquals(err, http.StatusNotFound):
+		modified = true
+		serviceAttachment, err = a.user.CreateServiceAttachment(region, &gcpclient.ServiceAttachment{
+			Name:                 name,
+			TargetService:        apiForwardingRule.SelfLink,
+			ConnectionPreference: "ACCEPT_MANUAL",
+			ConsumerAcceptLists:  desiredAcceptList,
+			NatSubnets:           []string{subnet.SelfLink},
+		})
+		if err != nil {
+			serviceLog.WithError(err).Error("failed to create the service attachment")
+			return modified, nil, err
+		}
+	case err != nil:
+		serviceLog.WithError(err).Error("failed to get the service attachment")
+		return modified, nil, err
+	}
+
+	oldProjects := sets.NewString()
+	for _, accepted := range serviceAttachment.ConsumerAcceptLists {
+		oldProjects.Insert(accepted.ProjectIdOrNum)
+	}
+	if !oldProjects.Equal(sets.NewString(hubProject.Name)) ||
+		!sets.NewString(serviceAttachment.NatSubnets...).Equal(sets.NewString(subnet.SelfLink)) {
+		modified = true
+		serviceAttachment, err = a.user.PatchServiceAttachment(region, &gcpclient.ServiceAttachment{
+			Name:                 name,
+			Fingerprint:          serviceAttachment.Fingerprint,
+			ConnectionPreference: "ACCEPT_MANUAL",
+			ConsumerAcceptLists:  desiredAcceptList,
+			NatSubnets:           []string{subnet.SelfLink},
+		})
+		if err != nil {
+			serviceLog.WithError(err).Error("error updating the service attachment to match the desired state")
+			return modified, nil, err
+		}
+	}
+
+	initPrivateServiceConnectStatus(cd)
+	cd.Status.Platform.GCP.PrivateServiceConnect.ServiceAttachment = serviceAttachment.SelfLink
+	if err := a.r.updatePrivateServiceConnectStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment status with serviceAttachment")
+		return modified, nil, err
+	}
+
+	return modified, serviceAttachment, nil
+}
+
+// ReconcileEndpoint ensures that an endpoint is created for the service attachment in the HUB project.
+// It reserves an internal address in a subnet chosen from the inventory given to the controller and
+// creates a forwarding rule targeting the service attachment with that address.
+// It currently doesn't manage any properties of the endpoint once it is created.
+func (a *actuator) ReconcileEndpoint(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	serviceAttachment *privatelink.Service,
+	logger log.FieldLogger) (bool, *privatelink.Endpoint, error) {
+	modified := false
+	region := controllerutils.GetClusterRegion(cd)
+	name := resourceName(metadata)
+	endpointLog := logger.WithField("endpoint", name)
+
+	address, err := a.hub.GetAddress(region, name)
+	switch {
+	case gcpErrCodeEquals(err, http.StatusNotFound):
+		modified = true
+		chosen, err := a.r.chooseSubnetForEndpoint(cd, logger)
+		if err != nil {
+			endpointLog.WithError(err).Error("failed to choose subnet for the endpoint from the inventory")
+			return modified, nil, err
+		}
+		address, err = a.hub.CreateAddress(region, &compute.Address{
+			Name:        name,
+			Description: fmt.Sprintf("Private Service Connect endpoint address for cluster %s", metadata.InfraID),
+			AddressType: "INTERNAL",
+			Subnetwork:  fmt.Sprintf("regions/%s/subnetworks/%s", region, chosen.Subnets[0].Subnet),
+		})
+		if err != nil {
+			endpointLog.WithError(err).Error("error creating the endpoint address")
+			return modified, nil, err
+		}
+	case err != nil:
+		endpointLog.WithError(err).Error("error getting the endpoint address")
+		return modified, nil, err
+	}
+
+	subnet, err := a.hub.GetSubnetwork(region, path.Base(address.Subnetwork))
+	if err != nil {
+		endpointLog.WithField("subnet", address.Subnetwork).WithError(err).Error("error getting the subnet of the endpoint address")
+		return modified, nil, err
+	}
+
+	forwardingRule, err := a.hub.GetForwardingRule(region, name)
+	switch {
+	case gcpErrCodeEquals(err, http.StatusNotFound):
+		modified = true
+		forwardingRule, err = a.hub.CreateForwardingRule(region, &compute.ForwardingRule{
+			Name:        name,
+			Description: fmt.Sprintf("Private Service Connect endpoint for cluster %s", metadata.InfraID),
+			Network:     subnet.Network,
+			IPAddress:   address.SelfLink,
+			Target:      serviceAttachment.ID,
+		})
+		if err != nil {
+			endpointLog.WithError(err).Error("error creating the endpoint")
+			return modified, nil, err
+		}
+	case err != nil:
+		endpointLog.WithError(err).Error("error getting the endpoint")
+		return modified, nil, err
+	}
+
+	initPrivateServiceConnectStatus(cd)
+	cd.Status.Platform.GCP.PrivateServiceConnect.EndpointAddress = address.SelfLink
+	cd.Status.Platform.GCP.PrivateServiceConnect.Endpoint = forwardingRule.SelfLink
+	if err := a.r.updatePrivateServiceConnectStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment status with endpoint")
+		return modified, nil, err
+	}
+
+	return modified, &privatelink.Endpoint{Network: subnet.Network, IP: address.Address}, nil
+}
+
+// ReconcileDNSZone ensures that a Cloud DNS private zone for apiDomain exists in the HUB project and is visible
+// from exactly the given networks. It also makes sure the DNS zone has an A record pointing to the address of the
+// endpoint.
+func (a *actuator) ReconcileDNSZone(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	endpoint *privatelink.Endpoint, apiDomain string, networks []string,
+	logger log.FieldLogger) (bool, error) {
+	modified := false
+	name := resourceName(metadata)
+	zoneLog := logger.WithField("dnsZone", name)
+
+	desiredNetworks := sets.NewString(networks...)
+	visibility := &dns.ManagedZonePrivateVisibilityConfig{}
+	for _, network := range desiredNetworks.List() {
+		visibility.Networks = append(visibility.Networks, &dns.ManagedZonePrivateVisibilityConfigNetwork{
+			NetworkUrl: network,
+		})
+	}
+
+	zone, err := a.hub.GetManagedZone(name)
+	switch {
+	case gcpErrCodeEquals(err, http.StatusNotFound):
+		modified = true
+		zone, err = a.hub.CreateManagedZone(&dns.ManagedZone{
+			Name:                    name,
+			Description:             fmt.Sprintf("Private Service Connect access to the API of cluster %s", metadata.InfraID),
+			DnsName:                 controllerutils.Dotted(apiDomain),
+			Visibility:              "private",
+			PrivateVisibilityConfig: visibility,
+		})
+		if err != nil {
+			zoneLog.WithField("apiDomain", apiDomain).WithError(err).Error("could not create the private DNS zone")
+			return modified, err
+		}
+	case err != nil:
+		zoneLog.WithError(err).Error("failed to get the private DNS zone")
+		return modified, err
+	}
+
+	oldNetworks := sets.NewString()
+	if zone.PrivateVisibilityConfig != nil {
+		for _, network := range zone.PrivateVisibilityConfig.Networks {
+			oldNetworks.Insert(network.NetworkUrl)
+		}
+	}
+	if !oldNetworks.Equal(desiredNetworks) {
+		modified = true
+		zoneLog.WithFields(log.Fields{
+			"associate":    desiredNetworks.Difference(oldNetworks).List(),
+			"disassociate": oldNetworks.Difference(desiredNetworks).List(),
+		}).Debug("updating the networks the DNS zone is visible from")
+		if err := a.hub.PatchManagedZone(name, &dns.ManagedZone{PrivateVisibilityConfig: visibility}); err != nil {
+			zoneLog.WithError(err).Error("failed to update the networks of the private DNS zone")
+			return modified, err
+		}
+	}
+
+	desiredRecord := &dns.ResourceRecordSet{
+		Name:    controllerutils.Dotted(apiDomain),
+		Type:    "A",
+		Ttl:     10,
+		Rrdatas: []string{endpoint.IP},
+	}
+	recordsResp, err := a.hub.ListResourceRecordSets(name, gcpclient.ListResourceRecordSetsOptions{
+		Name: desiredRecord.Name,
+		Type: desiredRecord.Type,
+	})
+	if err != nil {
+		zoneLog.WithError(err).Error("failed to list the records of the private DNS zone")
+		return modified, err
+	}
+	var oldRecord *dns.ResourceRecordSet
+	if len(recordsResp.Rrsets) > 0 {
+		oldRecord = recordsResp.Rrsets[0]
+	}
+	if oldRecord == nil || oldRecord.Ttl != desiredRecord.Ttl || !recordDataEqual(oldRecord.Rrdatas, desiredRecord.Rrdatas) {
+		if err := a.hub.UpdateResourceRecordSet(name, desiredRecord, oldRecord); err != nil {
+			zoneLog.WithError(err).Error("error adding record to the private DNS zone for the endpoint")
+			return modified, err
+		}
+	}
+
+	initPrivateServiceConnectStatus(cd)
+	cd.Status.Platform.GCP.PrivateServiceConnect.DNSZone = zone.Name
+	if err := a.r.updatePrivateServiceConnectStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("failed to update the DNS zone for cluster deployment")
+		return modified, err
+	}
+
+	return modified, nil
+}
+
+func recordDataEqual(a, b []string) bool {
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
+
+// resourceName is the name of the GCP resources created for the cluster.
+func resourceName(metadata *hivev1.ClusterMetadata) string {
+	return metadata.InfraID + "-psc"
+}
+
+// actuator creates and deletes the GCP resources of the private service connect access of a cluster. The service
+// attachment is created with the credentials of the cluster, and the endpoint and DNS zone with the credentials of
+// the HUB project.
+type actuator struct {
+	r    *ReconcileGCPPrivateServiceConnect
+	hub  gcpclient.Client
+	user gcpclient.Client
+}
+
+func newActuator(r *ReconcileGCPPrivateServiceConnect, cd *hivev1.ClusterDeployment) (*actuator, error) {
+	userSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{
+		Namespace: cd.Namespace,
+		Name:      cd.Spec.Platform.GCP.CredentialsSecretRef.Name,
+	}, userSecret); err != nil {
+		return nil, errors.Wrap(err, "failed to get GCP credentials secret of the cluster")
+	}
+	uClient, err := r.gcpClientFn(userSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	hubSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{
+		Namespace: controllerutils.GetHiveNamespace(),
+		Name:      r.controllerconfig.CredentialsSecretRef.Name,
+	}, hubSecret); err != nil {
+		return nil, errors.Wrap(err, "failed to get GCP credentials secret for private service connect")
+	}
+	hClient, err := r.gcpClientFn(hubSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &actuator{r: r, hub: hClient, user: uClient}, nil
+}
+
+// gcpErrCodeEquals returns true if the error matches all these conditions:
+//   - err is of type googleapi.Error
+//   - Error.Code equals code
+func gcpErrCodeEquals(err error, code int) bool {
+	if err == nil {
+		return false
+	}
+	var gcpErr *googleapi.Error
+	if errors.As(err, &gcpErr) {
+		return gcpErr.Code == code
+	}
+	return false
+}
+
+// ReadGCPPrivateServiceConnectControllerConfigFile reads the configuration from the env
+// and unmarshals. If the env is set to a file but that file doesn't exist it returns
+// a zero value configuration.
+func ReadGCPPrivateServiceConnectControllerConfigFile() (*hivev1.GCPPrivateServiceConnectConfig, error) {
+	fPath := os.Getenv(constants.GCPPrivateServiceConnectControllerConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+
+	config := &hivev1.GCPPrivateServiceConnectConfig{}
+
+	fileBytes, err := ioutil.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, errors.Wrap(err, "failed to read the gcp private service connect controller config file")
+	}
+	if err := json.Unmarshal(fileBytes, &config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) updatePrivateServiceConnectStatus(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	return retry.RetryOnConflict(privatelink.RetryBackoff, func() error {
+		curr := &hivev1.ClusterDeployment{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+		if err != nil {
+			return err
+		}
+
+		initPrivateServiceConnectStatus(curr)
+		curr.Status.Platform.GCP.PrivateServiceConnect = cd.Status.Platform.GCP.PrivateServiceConnect
+		return r.Client.Status().Update(context.TODO(), curr)
+	})
+}
+
+func initPrivateServiceConnectStatus(cd *hivev1.ClusterDeployment) {
+	if cd.Status.Platform == nil {
+		cd.Status.Platform = &hivev1.PlatformStatus{}
+	}
+	if cd.Status.Platform.GCP == nil {
+		cd.Status.Platform.GCP = &hivev1gcp.PlatformStatus{}
+	}
+	if cd.Status.Platform.GCP.PrivateServiceConnect == nil {
+		cd.Status.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccessStatus{}
+	}
+}
@@ -0,0 +1,770 @@
+package gcpprivateserviceconnect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/gcpclient/mock"
+	testassert "github.com/openshift/hive/pkg/test/assert"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	testNS = "test-namespace"
+
+	testRegion            = "us-central1"
+	userCredsSecretName   = "user-gcp-creds"
+	hubCredsSecretName    = "hub-gcp-creds"
+	testKubeconfigSecret  = "test-cd-provision-0-kubeconfig"
+	testServiceAttachment = "https://www.googleapis.com/compute/v1/projects/user-project/regions/us-central1/serviceAttachments/test-cd-1234-psc"
+)
+
+var notFoundErr = &googleapi.Error{Code: http.StatusNotFound, Message: "not found"}
+
+// clusterDeploymentGCPPrivateServiceConnectConditions are the cluster deployment conditions controlled by
+// GCP private service connect controller
+var clusterDeploymentGCPPrivateServiceConnectConditions = []hivev1.ClusterDeploymentConditionType{
+	hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+	hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	key := client.ObjectKey{Name: "test-cd", Namespace: testNS}
+	cdBuilder := testcd.FullBuilder(testNS, "test-cd", scheme)
+	gcpPlatform := func(psc *hivev1gcp.PrivateServiceConnectAccess) testcd.Option {
+		return testcd.WithGCPPlatform(&hivev1gcp.Platform{
+			Region:                testRegion,
+			CredentialsSecretRef:  corev1.LocalObjectReference{Name: userCredsSecretName},
+			PrivateServiceConnect: psc,
+		})
+	}
+	enabledPSCBuilder := cdBuilder.
+		Options(gcpPlatform(&hivev1gcp.PrivateServiceConnectAccess{Enabled: true}),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionUnknown,
+				Type:   hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+			}),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionUnknown,
+				Type:   hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			}),
+		)
+	validInventory := []hivev1.GCPPrivateServiceConnectInventory{{
+		Network: "hub-network",
+		Subnets: []hivev1.GCPPrivateServiceConnectSubnet{{
+			Subnet: "hub-subnet-east",
+			Region: "us-east1",
+		}, {
+			Subnet: "hub-subnet-central",
+			Region: testRegion,
+		}},
+	}}
+	kubeConfigSecret := map[string]string{
+		"kubeconfig": `apiVersion: v1
+clusters:
+- cluster:
+    server: https://api.test-cluster:6443
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: admin
+  name: admin
+current-context: admin
+kind: Config
+users:
+- name: admin
+`,
+	}
+	credsSecrets := []runtime.Object{
+		testSecret(testNS, userCredsSecretName, map[string]string{constants.GCPCredentialsName: "{}"}),
+		testSecret(constants.DefaultHiveNamespace, hubCredsSecretName, map[string]string{constants.GCPCredentialsName: "{}"}),
+	}
+
+	apiForwardingRule := &compute.ForwardingRule{
+		Name:     "test-cd-1234-api-internal",
+		Network:  "projects/user-project/global/networks/cluster-network",
+		SelfLink: "projects/user-project/regions/us-central1/forwardingRules/test-cd-1234-api-internal",
+	}
+	serviceAttachmentSubnet := &compute.Subnetwork{
+		Name:        "test-cd-1234-psc",
+		Network:     "projects/user-project/global/networks/cluster-network",
+		IpCidrRange: defaultServiceAttachmentSubnetCIDR,
+		SelfLink:    "projects/user-project/regions/us-central1/subnetworks/test-cd-1234-psc",
+	}
+	endpointAddress := &compute.Address{
+		Name:       "test-cd-1234-psc",
+		Address:    "10.0.0.5",
+		Subnetwork: "projects/hub-project/regions/us-central1/subnetworks/hub-subnet-central",
+		SelfLink:   "projects/hub-project/regions/us-central1/addresses/test-cd-1234-psc",
+	}
+	hubSubnet := &compute.Subnetwork{
+		Name:     "hub-subnet-central",
+		Network:  "projects/hub-project/global/networks/hub-network",
+		SelfLink: "projects/hub-project/regions/us-central1/subnetworks/hub-subnet-central",
+	}
+	readyStatus := &hivev1gcp.PrivateServiceConnectAccessStatus{
+		ServiceAttachmentSubnet:   serviceAttachmentSubnet.SelfLink,
+		ServiceAttachmentFirewall: "projects/user-project/global/firewalls/test-cd-1234-psc",
+		ServiceAttachment:         testServiceAttachment,
+		EndpointAddress:           endpointAddress.SelfLink,
+		Endpoint:                  "projects/hub-project/regions/us-central1/forwardingRules/test-cd-1234-psc",
+		DNSZone:                   "test-cd-1234-psc",
+	}
+	mockExistingHubResources := func(m *mock.MockClient) {
+		m.EXPECT().GetAddress(testRegion, "test-cd-1234-psc").Return(endpointAddress, nil)
+		m.EXPECT().GetSubnetwork(testRegion, "hub-subnet-central").Return(hubSubnet, nil)
+		m.EXPECT().GetForwardingRule(testRegion, "test-cd-1234-psc").
+			Return(&compute.ForwardingRule{SelfLink: readyStatus.Endpoint}, nil)
+		m.EXPECT().GetManagedZone("test-cd-1234-psc").Return(&dns.ManagedZone{
+			Name: "test-cd-1234-psc",
+			PrivateVisibilityConfig: &dns.ManagedZonePrivateVisibilityConfig{
+				Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{{
+					NetworkUrl: hubSubnet.Network,
+				}},
+			},
+		}, nil)
+		m.EXPECT().ListResourceRecordSets("test-cd-1234-psc", gcpclient.ListResourceRecordSetsOptions{
+			Name: "api.test-cluster.",
+			Type: "A",
+		}).Return(&dns.ResourceRecordSetsListResponse{
+			Rrsets: []*dns.ResourceRecordSet{{
+				Name:    "api.test-cluster.",
+				Type:    "A",
+				Ttl:     10,
+				Rrdatas: []string{"10.0.0.5"},
+			}},
+		}, nil)
+	}
+
+	cases := []struct {
+		name string
+
+		existing      []runtime.Object
+		inventory     []hivev1.GCPPrivateServiceConnectInventory
+		associate     []string
+		configureUser func(*mock.MockClient)
+		configureHub  func(*mock.MockClient)
+
+		hasFinalizer        bool
+		expectedAnnotations map[string]string
+		expectedStatus      *hivev1gcp.PrivateServiceConnectAccessStatus
+		expectedConditions  []hivev1.ClusterDeploymentCondition
+		err                 string
+	}{{
+		name: "cd without initialized conditions",
+
+		existing: []runtime.Object{
+			cdBuilder.Build(gcpPlatform(nil)),
+		},
+	}, {
+		name: "cd with aws platform",
+
+		existing: []runtime.Object{
+			cdBuilder.Build(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"})),
+		},
+	}, {
+		name: "cd without private service connect",
+
+		existing: []runtime.Object{
+			cdBuilder.Build(gcpPlatform(nil)),
+		},
+	}, {
+		name: "cd with private service connect disabled",
+
+		existing: []runtime.Object{
+			cdBuilder.Build(gcpPlatform(&hivev1gcp.PrivateServiceConnectAccess{Enabled: false})),
+		},
+	}, {
+		name: "cd with private service connect enabled, no inventory",
+
+		existing: []runtime.Object{
+			enabledPSCBuilder.Build(),
+		},
+
+		hasFinalizer: true,
+		expectedConditions: getExpectedConditions(true, "UnsupportedRegion",
+			"cluster deployment region \"us-central1\" is not supported as there is no inventory to create necessary resources"),
+	}, {
+		name: "cd with private service connect enabled, no inventory in given region",
+
+		existing: []runtime.Object{
+			enabledPSCBuilder.Build(),
+		},
+		inventory: []hivev1.GCPPrivateServiceConnectInventory{{
+			Network: "hub-network",
+			Subnets: []hivev1.GCPPrivateServiceConnectSubnet{{
+				Subnet: "hub-subnet-east",
+				Region: "us-east1",
+			}},
+		}},
+
+		hasFinalizer: true,
+		expectedConditions: getExpectedConditions(true, "UnsupportedRegion",
+			"cluster deployment region \"us-central1\" is not supported as there is no inventory to create necessary resources"),
+	}, {
+		name: "cd with private service connect enabled, no provision started",
+
+		existing: []runtime.Object{
+			enabledPSCBuilder.Build(),
+		},
+		inventory: validInventory,
+
+		hasFinalizer: true,
+	}, {
+		name: "cd with private service connect enabled, provision started, but no admin kubeconfig",
+
+		existing: []runtime.Object{
+			testProvision("test-cd-provision-0", provisionWithInfraID("test-cd-1234")),
+			enabledPSCBuilder.Build(withClusterProvision("test-cd-provision-0")),
+		},
+		inventory: validInventory,
+
+		hasFinalizer: true,
+	}, {
+		name: "cd with private service connect enabled, provision started, forwarding rule not found",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			enabledPSCBuilder.Build(withClusterProvision("test-cd-provision-0")),
+		}, credsSecrets...),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetForwardingRule(testRegion, "test-cd-1234-api-internal").Return(nil, notFoundErr)
+		},
+
+		hasFinalizer: true,
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+		}, {
+			Status:  corev1.ConditionFalse,
+			Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Reason:  "DiscoveringForwardingRuleNotYetFound",
+			Message: "discovering internal API forwarding rule for the cluster, but it does not exist yet",
+		}},
+	}, {
+		name: "cd with private service connect enabled, provision started, forwarding rule access denied",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			enabledPSCBuilder.Build(withClusterProvision("test-cd-provision-0")),
+		}, credsSecrets...),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetForwardingRule(testRegion, "test-cd-1234-api-internal").
+				Return(nil, &googleapi.Error{Code: http.StatusForbidden, Message: "access denied"})
+		},
+
+		hasFinalizer: true,
+		expectedConditions: getExpectedConditions(true, "DiscoveringForwardingRuleFailed",
+			"googleapi: Error 403: access denied"),
+		err: "googleapi: Error 403: access denied",
+	}, {
+		name: "cd with private service connect enabled, provision started, no previous resources",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			testSecret(testNS, testKubeconfigSecret, kubeConfigSecret),
+			enabledPSCBuilder.Build(withClusterProvision("test-cd-provision-0")),
+		}, credsSecrets...),
+		inventory: validInventory,
+		associate: []string{"projects/hub-project/global/networks/hive-network"},
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetForwardingRule(testRegion, "test-cd-1234-api-internal").Return(apiForwardingRule, nil)
+			m.EXPECT().GetSubnetwork(testRegion, "test-cd-1234-psc").Return(nil, notFoundErr)
+			m.EXPECT().CreateSubnetwork(testRegion, gomock.Any()).
+				DoAndReturn(func(_ string, subnet *compute.Subnetwork) (*compute.Subnetwork, error) {
+					assert.Equal(t, "PRIVATE_SERVICE_CONNECT", subnet.Purpose)
+					assert.Equal(t, defaultServiceAttachmentSubnetCIDR, subnet.IpCidrRange)
+					assert.Equal(t, apiForwardingRule.Network, subnet.Network)
+					return serviceAttachmentSubnet, nil
+				})
+			m.EXPECT().GetFirewall("test-cd-1234-psc").Return(nil, notFoundErr)
+			m.EXPECT().CreateFirewall(gomock.Any()).
+				DoAndReturn(func(firewall *compute.Firewall) (*compute.Firewall, error) {
+					assert.Equal(t, []string{defaultServiceAttachmentSubnetCIDR}, firewall.SourceRanges)
+					assert.Equal(t, []string{"test-cd-1234-master"}, firewall.TargetTags)
+					return &compute.Firewall{SelfLink: readyStatus.ServiceAttachmentFirewall}, nil
+				})
+			m.EXPECT().GetServiceAttachment(testRegion, "test-cd-1234-psc").Return(nil, notFoundErr)
+			m.EXPECT().CreateServiceAttachment(testRegion, gomock.Any()).
+				DoAndReturn(func(_ string, sa *gcpclient.ServiceAttachment) (*gcpclient.ServiceAttachment, error) {
+					assert.Equal(t, apiForwardingRule.SelfLink, sa.TargetService)
+					assert.Equal(t, "ACCEPT_MANUAL", sa.ConnectionPreference)
+					sa.SelfLink = testServiceAttachment
+					return sa, nil
+				})
+		},
+		configureHub: func(m *mock.MockClient) {
+			m.EXPECT().GetComputeProject().Return(&compute.Project{Name: "hub-project"}, nil)
+			m.EXPECT().GetAddress(testRegion, "test-cd-1234-psc").Return(nil, notFoundErr)
+			m.EXPECT().CreateAddress(testRegion, gomock.Any()).
+				DoAndReturn(func(_ string, address *compute.Address) (*compute.Address, error) {
+					assert.Equal(t, "regions/us-central1/subnetworks/hub-subnet-central", address.Subnetwork)
+					return endpointAddress, nil
+				})
+			m.EXPECT().GetSubnetwork(testRegion, "hub-subnet-central").Return(hubSubnet, nil)
+			m.EXPECT().GetForwardingRule(testRegion, "test-cd-1234-psc").Return(nil, notFoundErr)
+			m.EXPECT().CreateForwardingRule(testRegion, gomock.Any()).
+				DoAndReturn(func(_ string, rule *compute.ForwardingRule) (*compute.ForwardingRule, error) {
+					assert.Equal(t, testServiceAttachment, rule.Target)
+					assert.Equal(t, endpointAddress.SelfLink, rule.IPAddress)
+					return &compute.ForwardingRule{SelfLink: readyStatus.Endpoint}, nil
+				})
+			m.EXPECT().GetManagedZone("test-cd-1234-psc").Return(nil, notFoundErr)
+			m.EXPECT().CreateManagedZone(gomock.Any()).
+				DoAndReturn(func(zone *dns.ManagedZone) (*dns.ManagedZone, error) {
+					assert.Equal(t, "api.test-cluster.", zone.DnsName)
+					return zone, nil
+				})
+			m.EXPECT().ListResourceRecordSets("test-cd-1234-psc", gcpclient.ListResourceRecordSetsOptions{
+				Name: "api.test-cluster.",
+				Type: "A",
+			}).Return(&dns.ResourceRecordSetsListResponse{}, nil)
+			m.EXPECT().UpdateResourceRecordSet("test-cd-1234-psc", &dns.ResourceRecordSet{
+				Name:    "api.test-cluster.",
+				Type:    "A",
+				Ttl:     10,
+				Rrdatas: []string{"10.0.0.5"},
+			}, nil).Return(nil)
+		},
+
+		hasFinalizer:   true,
+		expectedStatus: readyStatus,
+		expectedConditions: getExpectedConditions(false, "PrivateServiceConnectAccessReady",
+			"private service connect access is ready for use"),
+	}, {
+		name: "cd with private service connect enabled, previous resources, service attachment accepts other project",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			testSecret(testNS, testKubeconfigSecret, kubeConfigSecret),
+			enabledPSCBuilder.Build(
+				withClusterProvision("test-cd-provision-0"),
+				withPrivateServiceConnect(readyStatus),
+			),
+		}, credsSecrets...),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetForwardingRule(testRegion, "test-cd-1234-api-internal").Return(apiForwardingRule, nil)
+			m.EXPECT().GetSubnetwork(testRegion, "test-cd-1234-psc").Return(serviceAttachmentSubnet, nil)
+			m.EXPECT().GetFirewall("test-cd-1234-psc").
+				Return(&compute.Firewall{SelfLink: readyStatus.ServiceAttachmentFirewall}, nil)
+			m.EXPECT().GetServiceAttachment(testRegion, "test-cd-1234-psc").Return(&gcpclient.ServiceAttachment{
+				SelfLink:    testServiceAttachment,
+				Fingerprint: "fingerprint-1",
+				ConsumerAcceptLists: []*gcpclient.ServiceAttachmentConsumerProjectLimit{{
+					ProjectIdOrNum:  "other-project",
+					ConnectionLimit: serviceAttachmentConnectionLimit,
+				}},
+				NatSubnets: []string{serviceAttachmentSubnet.SelfLink},
+			}, nil)
+			m.EXPECT().PatchServiceAttachment(testRegion, &gcpclient.ServiceAttachment{
+				Name:                 "test-cd-1234-psc",
+				Fingerprint:          "fingerprint-1",
+				ConnectionPreference: "ACCEPT_MANUAL",
+				ConsumerAcceptLists: []*gcpclient.ServiceAttachmentConsumerProjectLimit{{
+					ProjectIdOrNum:  "hub-project",
+					ConnectionLimit: serviceAttachmentConnectionLimit,
+				}},
+				NatSubnets: []string{serviceAttachmentSubnet.SelfLink},
+			}).Return(&gcpclient.ServiceAttachment{SelfLink: testServiceAttachment}, nil)
+		},
+		configureHub: func(m *mock.MockClient) {
+			m.EXPECT().GetComputeProject().Return(&compute.Project{Name: "hub-project"}, nil)
+			mockExistingHubResources(m)
+		},
+
+		hasFinalizer:   true,
+		expectedStatus: readyStatus,
+		expectedConditions: getExpectedConditions(false, "PrivateServiceConnectAccessReady",
+			"private service connect access is ready for use"),
+	}, {
+		name: "cd with private service connect enabled, previous resources, associate networks",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret)),
+			testSecret(testNS, testKubeconfigSecret, kubeConfigSecret),
+			enabledPSCBuilder.Build(
+				withClusterProvision("test-cd-provision-0"),
+				withPrivateServiceConnect(readyStatus),
+			),
+		}, credsSecrets...),
+		inventory: validInventory,
+		associate: []string{"projects/hub-project/global/networks/hive-network"},
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetForwardingRule(testRegion, "test-cd-1234-api-internal").Return(apiForwardingRule, nil)
+			m.EXPECT().GetSubnetwork(testRegion, "test-cd-1234-psc").Return(serviceAttachmentSubnet, nil)
+			m.EXPECT().GetFirewall("test-cd-1234-psc").
+				Return(&compute.Firewall{SelfLink: readyStatus.ServiceAttachmentFirewall}, nil)
+			m.EXPECT().GetServiceAttachment(testRegion, "test-cd-1234-psc").Return(&gcpclient.ServiceAttachment{
+				SelfLink: testServiceAttachment,
+				ConsumerAcceptLists: []*gcpclient.ServiceAttachmentConsumerProjectLimit{{
+					ProjectIdOrNum:  "hub-project",
+					ConnectionLimit: serviceAttachmentConnectionLimit,
+				}},
+				NatSubnets: []string{serviceAttachmentSubnet.SelfLink},
+			}, nil)
+		},
+		configureHub: func(m *mock.MockClient) {
+			m.EXPECT().GetComputeProject().Return(&compute.Project{Name: "hub-project"}, nil)
+			mockExistingHubResources(m)
+			m.EXPECT().PatchManagedZone("test-cd-1234-psc", &dns.ManagedZone{
+				PrivateVisibilityConfig: &dns.ManagedZonePrivateVisibilityConfig{
+					Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{{
+						NetworkUrl: "projects/hub-project/global/networks/hive-network",
+					}, {
+						NetworkUrl: hubSubnet.Network,
+					}},
+				},
+			}).Return(nil)
+		},
+
+		hasFinalizer:   true,
+		expectedStatus: readyStatus,
+		expectedConditions: getExpectedConditions(false, "PrivateServiceConnectAccessReady",
+			"private service connect access is ready for use"),
+	}, {
+		name: "cd with private service connect enabled, previous provision failed, new started",
+
+		existing: append([]runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig(testKubeconfigSecret),
+				provisionWithFailed()),
+			testProvision("test-cd-provision-1",
+				provisionWithPrevInfraID("test-cd-1234")),
+			enabledPSCBuilder.Build(
+				withClusterProvision("test-cd-provision-1"),
+				withPrivateServiceConnect(readyStatus),
+			),
+		}, credsSecrets...),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().DeleteServiceAttachment(testRegion, "test-cd-1234-psc").Return(nil)
+			m.EXPECT().DeleteFirewall("test-cd-1234-psc").Return(nil)
+			m.EXPECT().DeleteSubnetwork(testRegion, "test-cd-1234-psc").Return(nil)
+		},
+		configureHub: func(m *mock.MockClient) {
+			aRecord := &dns.ResourceRecordSet{
+				Name:    "api.test-cluster.",
+				Type:    "A",
+				Rrdatas: []string{"10.0.0.5"},
+			}
+			m.EXPECT().ListResourceRecordSets("test-cd-1234-psc", gcpclient.ListResourceRecordSetsOptions{}).
+				Return(&dns.ResourceRecordSetsListResponse{
+					Rrsets: []*dns.ResourceRecordSet{
+						{Name: "api.test-cluster.", Type: "SOA"},
+						{Name: "api.test-cluster.", Type: "NS"},
+						aRecord,
+					},
+				}, nil)
+			m.EXPECT().DeleteResourceRecordSet("test-cd-1234-psc", aRecord).Return(nil)
+			m.EXPECT().DeleteManagedZone("test-cd-1234-psc").Return(nil)
+			m.EXPECT().DeleteForwardingRule(testRegion, "test-cd-1234-psc").Return(nil)
+			m.EXPECT().DeleteAddress(testRegion, "test-cd-1234-psc").Return(notFoundErr)
+		},
+
+		hasFinalizer: true,
+		expectedAnnotations: map[string]string{
+			lastCleanupAnnotationKey: "test-cd-1234",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+		}, {
+			Status:  corev1.ConditionFalse,
+			Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Reason:  "PreviousAttemptCleanupComplete",
+			Message: "successfully cleaned up resources from previous provision attempt so that next attempt can start",
+		}},
+	}, {
+		name: "cd with private service connect enabled, previous provision failed, new started, cleanup already done",
+
+		existing: []runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithFailed()),
+			testProvision("test-cd-provision-1",
+				provisionWithPrevInfraID("test-cd-1234")),
+			enabledPSCBuilder.GenericOptions(
+				generic.WithAnnotation(lastCleanupAnnotationKey, "test-cd-1234"),
+			).Build(
+				withClusterProvision("test-cd-provision-1"),
+				withPrivateServiceConnect(readyStatus),
+			),
+		},
+		inventory: validInventory,
+
+		hasFinalizer: true,
+		expectedAnnotations: map[string]string{
+			lastCleanupAnnotationKey: "test-cd-1234",
+		},
+		expectedStatus: readyStatus,
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockedUserClient := mock.NewMockClient(mockCtrl)
+			mockedHubClient := mock.NewMockClient(mockCtrl)
+
+			if test.configureUser != nil {
+				test.configureUser(mockedUserClient)
+			}
+			if test.configureHub != nil {
+				test.configureHub(mockedHubClient)
+			}
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			log.SetLevel(log.DebugLevel)
+			reconciler := &ReconcileGCPPrivateServiceConnect{
+				Client: fakeClient,
+				controllerconfig: &hivev1.GCPPrivateServiceConnectConfig{
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: hubCredsSecretName},
+					EndpointVPCInventory: test.inventory,
+					AssociatedNetworks:   test.associate,
+				},
+
+				gcpClientFn: func(secret *corev1.Secret) (gcpclient.Client, error) {
+					if secret.Name == hubCredsSecretName {
+						return mockedHubClient, nil
+					}
+					return mockedUserClient, nil
+				},
+			}
+
+			reconcileRequest := reconcile.Request{
+				NamespacedName: key,
+			}
+
+			_, err := reconciler.Reconcile(context.TODO(), reconcileRequest)
+			if test.err == "" {
+				assert.NoError(t, err, "unexpected error from Reconcile")
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+			cd := &hivev1.ClusterDeployment{}
+			err = fakeClient.Get(context.TODO(), key, cd)
+			require.NoError(t, err)
+
+			if test.hasFinalizer {
+				assert.Contains(t, cd.ObjectMeta.Finalizers, finalizer)
+			}
+
+			if len(test.expectedAnnotations) > 0 {
+				assert.Equal(t, test.expectedAnnotations, cd.Annotations)
+			}
+
+			for _, cond := range clusterDeploymentGCPPrivateServiceConnectConditions {
+				if present := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions,
+					cond); present == nil {
+					test.expectedConditions = append(test.expectedConditions, hivev1.ClusterDeploymentCondition{
+						Status:  corev1.ConditionUnknown,
+						Type:    cond,
+						Reason:  "Initialized",
+						Message: "Condition Initialized",
+					})
+				}
+			}
+			testassert.AssertConditions(t, cd, test.expectedConditions)
+
+			if cd.Status.Platform == nil {
+				cd.Status.Platform = &hivev1.PlatformStatus{}
+			}
+			if cd.Status.Platform.GCP == nil {
+				cd.Status.Platform.GCP = &hivev1gcp.PlatformStatus{}
+			}
+			assert.Equal(t, test.expectedStatus, cd.Status.Platform.GCP.PrivateServiceConnect)
+		})
+	}
+}
+
+func Test_toSupportedSubnets(t *testing.T) {
+	inv := []hivev1.GCPPrivateServiceConnectInventory{{
+		Network: "network-1",
+		Subnets: []hivev1.GCPPrivateServiceConnectSubnet{{
+			Subnet: "subnet-1",
+			Region: "us-east1",
+		}, {
+			Subnet: "subnet-2",
+			Region: "us-central1",
+		}},
+	}, {
+		Network: "network-2",
+		Subnets: []hivev1.GCPPrivateServiceConnectSubnet{{
+			Subnet: "subnet-3",
+			Region: "us-east1",
+		}},
+	}}
+
+	inv = filterInventory(inv, toSupportedSubnets("us-central1"))
+	assert.Equal(t, []hivev1.GCPPrivateServiceConnectInventory{{
+		Network: "network-1",
+		Subnets: []hivev1.GCPPrivateServiceConnectSubnet{{
+			Subnet: "subnet-2",
+			Region: "us-central1",
+		}},
+	}}, inv)
+}
+
+func testSecret(namespace, name string, data map[string]string) *corev1.Secret {
+	s := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Data: map[string][]byte{},
+	}
+	for k, v := range data {
+		s.Data[k] = []byte(v)
+	}
+	return s
+}
+
+func withClusterProvision(provisionName string) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.ProvisionRef = &corev1.LocalObjectReference{Name: provisionName}
+	}
+}
+
+func withClusterMetadata(infraID, kubeconfigSecretName string) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+			InfraID: infraID,
+			AdminKubeconfigSecretRef: corev1.LocalObjectReference{
+				Name: kubeconfigSecretName,
+			},
+		}
+	}
+}
+
+func withPrivateServiceConnect(p *hivev1gcp.PrivateServiceConnectAccessStatus) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		if cd.Status.Platform == nil {
+			cd.Status.Platform = &hivev1.PlatformStatus{GCP: &hivev1gcp.PlatformStatus{}}
+		}
+		cd.Status.Platform.GCP.PrivateServiceConnect = p.DeepCopy()
+	}
+}
+
+type provisionOption func(*hivev1.ClusterProvision)
+
+func testProvision(name string, opts ...provisionOption) *hivev1.ClusterProvision {
+	provision := &hivev1.ClusterProvision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNS,
+			Labels: map[string]string{
+				constants.ClusterDeploymentNameLabel: "test-cd",
+			},
+		},
+		Spec: hivev1.ClusterProvisionSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{
+				Name: "test-cd",
+			},
+			Stage: hivev1.ClusterProvisionStageInitializing,
+		},
+	}
+
+	for _, o := range opts {
+		o(provision)
+	}
+
+	return provision
+}
+
+func provisionWithInfraID(id string) provisionOption {
+	return func(cp *hivev1.ClusterProvision) {
+		cp.Spec.InfraID = &id
+	}
+}
+
+func provisionWithFailed() provisionOption {
+	return func(cp *hivev1.ClusterProvision) {
+		cp.Spec.Stage = hivev1.ClusterProvisionStageFailed
+	}
+}
+
+func provisionWithPrevInfraID(id string) provisionOption {
+	return func(cp *hivev1.ClusterProvision) {
+		cp.Spec.PrevInfraID = &id
+	}
+}
+
+func provisionWithAdminKubeconfig(name string) provisionOption {
+	return func(cp *hivev1.ClusterProvision) {
+		cp.Spec.AdminKubeconfigSecretRef = &corev1.LocalObjectReference{Name: name}
+	}
+}
+
+// getExpectedConditions should be called when only one of Ready and Failed conditions is true,
+// and both have the same reason and message
+func getExpectedConditions(failed bool, reason string, message string) []hivev1.ClusterDeploymentCondition {
+	if failed {
+		return []hivev1.ClusterDeploymentCondition{{
+			Status:  corev1.ConditionTrue,
+			Type:    hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+			Reason:  reason,
+			Message: message,
+		}, {
+			Status:  corev1.ConditionFalse,
+			Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Reason:  reason,
+			Message: message,
+		}}
+	}
+	return []hivev1.ClusterDeploymentCondition{{
+		Status:  corev1.ConditionFalse,
+		Type:    hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+		Reason:  reason,
+		Message: message,
+	}, {
+		Status:  corev1.ConditionTrue,
+		Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+		Reason:  reason,
+		Message: message,
+	}}
+}
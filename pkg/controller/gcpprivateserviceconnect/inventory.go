@@ -0,0 +1,54 @@
+package gcpprivateserviceconnect
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+var errNoSupportedSubnetInInventory = errors.New("no supported subnet in inventory in the region of the cluster")
+
+// chooseSubnetForEndpoint chooses a network from the inventory given to the controller that has a subnet in
+// the region of the cluster. The returned inventory only contains the subnets in that region.
+func (r *ReconcileGCPPrivateServiceConnect) chooseSubnetForEndpoint(cd *hivev1.ClusterDeployment,
+	logger log.FieldLogger) (*hivev1.GCPPrivateServiceConnectInventory, error) {
+	candidates := filterInventory(r.controllerconfig.DeepCopy().EndpointVPCInventory, toSupportedSubnets(controllerutils.GetClusterRegion(cd)))
+	if len(candidates) == 0 {
+		logger.WithField("region", controllerutils.GetClusterRegion(cd)).Error(errNoSupportedSubnetInInventory.Error())
+		return nil, errNoSupportedSubnetInInventory
+	}
+
+	return &candidates[0], nil
+}
+
+type filterInventoryFn func(*hivev1.GCPPrivateServiceConnectInventory) bool
+
+func filterInventory(input []hivev1.GCPPrivateServiceConnectInventory, fn filterInventoryFn) []hivev1.GCPPrivateServiceConnectInventory {
+	n := 0
+	for _, cand := range input {
+		if fn(&cand) {
+			input[n] = cand
+			n++
+		}
+	}
+	input = input[:n]
+	return input
+}
+
+func toSupportedSubnets(region string) filterInventoryFn {
+	return func(inv *hivev1.GCPPrivateServiceConnectInventory) bool {
+		n := 0
+		for _, subnet := range inv.Subnets {
+			if strings.EqualFold(region, subnet.Region) {
+				inv.Subnets[n] = subnet
+				n++
+			}
+		}
+		inv.Subnets = inv.Subnets[:n]
+		return len(inv.Subnets) > 0
+	}
+}
@@ -1,18 +1,14 @@
 package hibernation
 
 import (
-	"context"
 	"fmt"
 	"os"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 
-	machineapi "github.com/openshift/api/machine/v1beta1"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -170,90 +166,12 @@ func (a *awsActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient c
 
 // ReplaceMachines implements HibernationPreemptibleMachines interface.
 func (a *awsActuator) ReplaceMachines(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) (bool, error) {
-	hibernatingCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions,
-		hivev1.ClusterHibernatingCondition)
-	if hibernatingCondition == nil {
-		return false, errors.New("cannot find hibernating condition")
-	}
-	hibernationStartedTime := hibernatingCondition.LastTransitionTime
-
-	machineList := &machineapi.MachineList{}
-	err := remoteClient.List(context.TODO(), machineList,
-		client.InNamespace(machineAPINamespace),
-		client.MatchingLabels{machineAPIInterruptibleLabel: ""},
-	)
-	if err != nil {
-		logger.WithError(err).Error("Failed to list machines")
-		return false, errors.Wrap(err, "failed to list machines")
-	}
-	if len(machineList.Items) == 0 {
-		return false, nil
-	}
-
-	var toBeReplaced []machineapi.Machine
-	for _, m := range machineList.Items {
-		if m.GetDeletionTimestamp() != nil {
-			// this object is already marked for deletion
-			continue
-		}
-		if m.Status.LastUpdated.After(hibernationStartedTime.Time) &&
-			m.Status.Phase != nil && *m.Status.Phase != "Failed" {
-			// this is a machine that is reporting not failed
-			// after hibernation was started, therefore do not
-			// remove
-			continue
-		}
-
-		toBeReplaced = append(toBeReplaced, m)
-	}
-
-	logger.WithField("machines", machineNames(toBeReplaced)).Debug("Preemptible Machine objects will be replaced")
-	var replaced bool
-	var errs []error
-	for _, m := range toBeReplaced {
-		// We want the machine-api to skip the draining
-		// since we already know these nodes were terminated
-		// during hibernation.
-		anno := m.GetAnnotations()
-		if anno == nil {
-			anno = map[string]string{}
-		}
-		anno[machineAPIExcludeDrainingAnnotation] = "true"
-		m.SetAnnotations(anno)
-		if err := remoteClient.Update(context.TODO(), &m); err != nil {
-			errs = append(errs, errors.Wrapf(err, "failed to update machine %s/%s to be excluded from draining",
-				machineAPINamespace, m.GetName()))
-			continue
-		}
-
-		// Delete the machine object so that it will be replaced
-		// by the machine set.
-		if err := remoteClient.Delete(context.TODO(), &m); err != nil {
-			errs = append(errs, errors.Wrapf(err, "failed to delete machine %s/%s", machineAPINamespace, m.GetName()))
-			continue
-		}
-		replaced = true
-	}
-	if len(errs) > 0 {
-		err := utilerrors.NewAggregate(errs)
-		logger.WithError(err).Error("Failed to delete machines")
-		return replaced, err
-	}
-
-	return replaced, nil
-}
-
-func machineNames(machines []machineapi.Machine) []string {
-	result := make([]string, len(machines))
-	for idx, m := range machines {
-		result[idx] = m.GetName()
-	}
-	return result
+	return replacePreemptibleMachines(cd, remoteClient, logger)
 }
 
 func getAWSClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
 	options := awsclient.Options{
-		Region: cd.Spec.Platform.AWS.Region,
+		Region: controllerutils.GetClusterRegion(cd),
 		CredentialsSource: awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
 				Namespace: cd.Namespace,
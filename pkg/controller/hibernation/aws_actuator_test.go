@@ -318,29 +318,39 @@ func TestReplacePreemptibleMachines(t *testing.T) {
 		expectedReplaced: true,
 		expectedMachines: []string{"spot-3"},
 	}}
+	actuators := map[string]HibernationPreemptibleMachines{
+		"aws":   testAWSActuator(nil),
+		"gcp":   testGCPActuator(nil),
+		"azure": testAzureActuator(nil),
+	}
 	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			actuator := testAWSActuator(nil)
-			c := fake.NewFakeClientWithScheme(scheme, test.existingMachines...)
+		for platform, actuator := range actuators {
+			t.Run(platform+"/"+test.name, func(t *testing.T) {
+				machines := make([]runtime.Object, len(test.existingMachines))
+				for i, m := range test.existingMachines {
+					machines[i] = m.DeepCopyObject()
+				}
+				c := fake.NewFakeClientWithScheme(scheme, machines...)
 
-			replaced, err := actuator.ReplaceMachines(testcd, c, logger)
-			if test.expectedErr != "" {
-				assert.EqualError(t, err, test.expectedErr)
-			} else {
-				assert.Equal(t, test.expectedReplaced, replaced)
-				machineList := &machineapi.MachineList{}
-				err = c.List(context.TODO(), machineList,
-					client.InNamespace(machineAPINamespace),
-				)
-				require.NoError(t, err)
+				replaced, err := actuator.ReplaceMachines(testcd, c, logger)
+				if test.expectedErr != "" {
+					assert.EqualError(t, err, test.expectedErr)
+				} else {
+					assert.Equal(t, test.expectedReplaced, replaced)
+					machineList := &machineapi.MachineList{}
+					err = c.List(context.TODO(), machineList,
+						client.InNamespace(machineAPINamespace),
+					)
+					require.NoError(t, err)
 
-				machines := sets.NewString()
-				for _, m := range machineList.Items {
-					machines.Insert(m.GetName())
+					machines := sets.NewString()
+					for _, m := range machineList.Items {
+						machines.Insert(m.GetName())
+					}
+					assert.Equal(t, test.expectedMachines, machines.List())
 				}
-				assert.Equal(t, test.expectedMachines, machines.List())
-			}
-		})
+			})
+		}
 	}
 }
 
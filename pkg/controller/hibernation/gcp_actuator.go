@@ -126,6 +126,11 @@ func (a *gcpActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient c
 	return len(instances) == 0, instanceNames(instances), nil
 }
 
+// ReplaceMachines implements HibernationPreemptibleMachines interface.
+func (a *gcpActuator) ReplaceMachines(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) (bool, error) {
+	return replacePreemptibleMachines(cd, remoteClient, logger)
+}
+
 func getGCPClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (gcpclient.Client, error) {
 	if cd.Spec.Platform.GCP == nil {
 		return nil, errors.New("GCP platform is not set in ClusterDeployment")
@@ -233,6 +233,38 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, r.updateClusterDeploymentStatus(cd, cdLog)
 	}
 
+	// Pool clusters wait until they're claimed for HibernateAfter and HibernationSchedule to have effect.
+	poolRef := cd.Spec.ClusterPoolRef
+	isUnclaimedPoolCluster := poolRef != nil && poolRef.PoolName != "" &&
+		// Upgrade note: If we hit this code path on a CD that was claimed before upgrading to
+		// where we introduced ClaimedTimestamp, then that CD was Hibernating when it was claimed
+		// (because that's the same time we introduced ClusterPool.RunningCount) so it's safe to
+		// just use installed/last-resumed as the baseline for hibernateAfter.
+		(poolRef.ClaimName == "" || poolRef.ClaimedTimestamp == nil)
+
+	// Apply the HibernationSchedule, which may change the desired power state.
+	if !isUnclaimedPoolCluster {
+		nextTransition, updated, err := r.reconcileHibernationSchedule(cd, cdLog)
+		if err != nil || updated {
+			return reconcile.Result{}, err
+		}
+		if nextTransition != nil {
+			// Make sure we reconcile again in time for the next scheduled transition.
+			defer func() {
+				requeueNow := result.Requeue && result.RequeueAfter <= 0
+				if returnErr != nil || requeueNow {
+					return
+				}
+				requeueAfter := time.Until(*nextTransition)
+				if requeueAfter < result.RequeueAfter || result.RequeueAfter <= 0 {
+					cdLog.Debugf("cluster will reconcile due to hibernation schedule in: %v", requeueAfter)
+					result.RequeueAfter = requeueAfter
+					result.Requeue = true
+				}
+			}()
+		}
+	}
+
 	shouldHibernate := cd.Spec.PowerState == hivev1.ClusterPowerStateHibernating
 	// set readyToHibernate if hibernate after is ready to kick in hibernation
 	var readyToHibernate bool
@@ -243,14 +275,6 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		// - When the cluster finished installing (status.installedTimestamp)
 		// - When the cluster was claimed (spec.clusterPoolRef.claimedTimestamp -- ClusterPool CDs only)
 		// - The last time the cluster resumed (status.conditions[Hibernating].lastTransitionTime if not hibernating (but see TODO))
-		// BUT pool clusters wait until they're claimed for HibernateAfter to have effect.
-		poolRef := cd.Spec.ClusterPoolRef
-		isUnclaimedPoolCluster := poolRef != nil && poolRef.PoolName != "" &&
-			// Upgrade note: If we hit this code path on a CD that was claimed before upgrading to
-			// where we introduced ClaimedTimestamp, then that CD was Hibernating when it was claimed
-			// (because that's the same time we introduced ClusterPool.RunningCount) so it's safe to
-			// just use installed/last-resumed as the baseline for hibernateAfter.
-			(poolRef.ClaimName == "" || poolRef.ClaimedTimestamp == nil)
 		if !isUnclaimedPoolCluster {
 			hibernateAfterDur := cd.Spec.HibernateAfter.Duration
 			hibLog := cdLog.WithField("hibernateAfter", hibernateAfterDur)
@@ -0,0 +1,151 @@
+package hibernation
+
+import (
+	"context"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// scheduleLookbacks are the windows searched, in order, for the most recent activation of a cron schedule.
+// Trying progressively larger windows keeps frequently firing schedules cheap to evaluate while still
+// finding the last activation of schedules that only fire monthly or yearly.
+var scheduleLookbacks = []time.Duration{
+	time.Hour,
+	24 * time.Hour,
+	8 * 24 * time.Hour,
+	32 * 24 * time.Hour,
+	367 * 24 * time.Hour,
+}
+
+// scheduledTransition is a power state change requested by a HibernationSchedule.
+type scheduledTransition struct {
+	time       time.Time
+	powerState hivev1.ClusterPowerState
+}
+
+// evaluateHibernationSchedule returns the most recent transition of the schedule at or before now, and the
+// next transition after now that changes the power state. Either may be nil if the schedule does not fire.
+func evaluateHibernationSchedule(schedule *hivev1.HibernationSchedule, now time.Time) (last, next *scheduledTransition, err error) {
+	resume, hibernate, err := controllerutils.ParseHibernationSchedule(schedule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lastResume, lastHibernate := lastActivation(resume, now), lastActivation(hibernate, now)
+	switch {
+	case lastResume.IsZero() && lastHibernate.IsZero():
+	case lastResume.After(lastHibernate):
+		last = &scheduledTransition{time: lastResume, powerState: hivev1.ClusterPowerStateRunning}
+	default:
+		last = &scheduledTransition{time: lastHibernate, powerState: hivev1.ClusterPowerStateHibernating}
+	}
+
+	// cron returns the zero time for schedules that never fire again.
+	nextResume := &scheduledTransition{time: resume.Next(now), powerState: hivev1.ClusterPowerStateRunning}
+	nextHibernate := &scheduledTransition{time: hibernate.Next(now), powerState: hivev1.ClusterPowerStateHibernating}
+	switch {
+	case last != nil && last.powerState == hivev1.ClusterPowerStateRunning:
+		next = nextHibernate
+	case last != nil:
+		next = nextResume
+	case nextHibernate.time.IsZero() || (!nextResume.time.IsZero() && nextResume.time.Before(nextHibernate.time)):
+		next = nextResume
+	default:
+		next = nextHibernate
+	}
+	if next.time.IsZero() {
+		next = nil
+	}
+	return last, next, nil
+}
+
+// lastActivation returns the latest time at or before now at which the schedule fires, or the zero time
+// if it did not fire within the largest lookback window.
+func lastActivation(schedule cron.Schedule, now time.Time) time.Time {
+	for _, lookback := range scheduleLookbacks {
+		var last time.Time
+		for t := schedule.Next(now.Add(-lookback)); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+			last = t
+		}
+		if !last.IsZero() {
+			return last
+		}
+	}
+	return time.Time{}
+}
+
+// reconcileHibernationSchedule moves the ClusterDeployment to the power state of the most recent scheduled
+// transition if that transition has not been handled yet, unless the schedule is overridden by annotation.
+// The handled and upcoming transitions are recorded in status. It returns the time of the next scheduled
+// transition, if any, and whether the ClusterDeployment spec was updated.
+func (r *hibernationReconciler) reconcileHibernationSchedule(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*time.Time, bool, error) {
+	if cd.Spec.HibernationSchedule == nil {
+		if cd.Status.HibernationSchedule != nil {
+			logger.Info("clearing status of removed hibernation schedule")
+			cd.Status.HibernationSchedule = nil
+			return nil, false, r.updateClusterDeploymentStatus(cd, logger)
+		}
+		return nil, false, nil
+	}
+
+	now := time.Now()
+	last, next, err := evaluateHibernationSchedule(cd.Spec.HibernationSchedule, now)
+	if err != nil {
+		// The schedule is validated by the webhook, so there is nothing to retry here.
+		logger.WithError(err).Error("invalid hibernation schedule")
+		return nil, false, nil
+	}
+
+	status := cd.Status.HibernationSchedule.DeepCopy()
+	if status == nil {
+		status = &hivev1.HibernationScheduleStatus{}
+	}
+	if last != nil && (status.LastTransitionTime == nil || last.time.After(status.LastTransitionTime.Time)) {
+		schedLog := logger.WithField("transitionTime", last.time).WithField("powerState", last.powerState)
+		overriddenUntil, err := controllerutils.HibernationScheduleOverriddenUntil(cd)
+		if err != nil {
+			schedLog.WithError(err).Warn("ignoring hibernation schedule override")
+		}
+		currentPowerState := cd.Spec.PowerState
+		if currentPowerState == "" {
+			currentPowerState = hivev1.ClusterPowerStateRunning
+		}
+		switch {
+		case now.Before(overriddenUntil):
+			schedLog.WithField("overriddenUntil", overriddenUntil).Info("hibernation schedule is overridden, skipping scheduled transition")
+		case currentPowerState != last.powerState:
+			schedLog.Info("applying scheduled power state")
+			cd.Spec.PowerState = last.powerState
+			if err := r.Update(context.TODO(), cd); err != nil {
+				schedLog.WithError(err).Log(controllerutils.LogLevel(err), "error applying scheduled power state")
+				return nil, false, err
+			}
+			// The transition is recorded in status once we reconcile the updated ClusterDeployment.
+			return nil, true, nil
+		}
+		status.LastTransitionTime = &metav1.Time{Time: last.time}
+	}
+
+	var nextTime *time.Time
+	status.NextTransitionTime, status.NextPowerState = nil, ""
+	if next != nil {
+		nextTime = &next.time
+		status.NextTransitionTime = &metav1.Time{Time: next.time}
+		status.NextPowerState = next.powerState
+	}
+	if !equality.Semantic.DeepEqual(status, cd.Status.HibernationSchedule) {
+		cd.Status.HibernationSchedule = status
+		if err := r.updateClusterDeploymentStatus(cd, logger); err != nil {
+			return nil, false, err
+		}
+	}
+	return nextTime, false, nil
+}
@@ -0,0 +1,318 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+)
+
+func TestEvaluateHibernationSchedule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	weekdays := &hivev1.HibernationSchedule{
+		Resume:    "0 8 * * 1-5",
+		Hibernate: "0 19 * * 1-5",
+		TimeZone:  "Europe/Berlin",
+	}
+
+	tests := []struct {
+		name      string
+		schedule  *hivev1.HibernationSchedule
+		now       time.Time
+		expectErr bool
+		expLast   *scheduledTransition
+		expNext   *scheduledTransition
+	}{
+		{
+			name:     "weekday working hours",
+			schedule: weekdays,
+			now:      time.Date(2021, time.June, 16, 10, 0, 0, 0, berlin),
+			expLast:  &scheduledTransition{time: time.Date(2021, time.June, 16, 8, 0, 0, 0, berlin), powerState: hivev1.ClusterPowerStateRunning},
+			expNext:  &scheduledTransition{time: time.Date(2021, time.June, 16, 19, 0, 0, 0, berlin), powerState: hivev1.ClusterPowerStateHibernating},
+		},
+		{
+			name:     "weekday evening",
+			schedule: weekdays,
+			now:      time.Date(2021, time.June, 16, 21, 0, 0, 0, berlin),
+			expLast:  &scheduledTransition{time: time.Date(2021, time.June, 16, 19, 0, 0, 0, berlin), powerState: hivev1.ClusterPowerStateHibernating},
+			expNext:  &scheduledTransition{time: time.Date(2021, time.June, 17, 8, 0, 0, 0, berlin), powerState: hivev1.ClusterPowerStateRunning},
+		},
+		{
+			name:     "weekend",
+			schedule: weekdays,
+			now:      time.Date(2021, time.June, 19, 12, 0, 0, 0, berlin),
+			expLast:  &scheduledTransition{time: time.Date(2021, time.June, 18, 19, 0, 0, 0, berlin), powerState: hivev1.ClusterPowerStateHibernating},
+			expNext:  &scheduledTransition{time: time.Date(2021, time.June, 21, 8, 0, 0, 0, berlin), powerState: hivev1.ClusterPowerStateRunning},
+		},
+		{
+			name:     "evaluated in time zone",
+			schedule: weekdays,
+			// 07:30 UTC is 09:30 in Berlin
+			now:     time.Date(2021, time.June, 16, 7, 30, 0, 0, time.UTC),
+			expLast: &scheduledTransition{time: time.Date(2021, time.June, 16, 8, 0, 0, 0, berlin), powerState: hivev1.ClusterPowerStateRunning},
+			expNext: &scheduledTransition{time: time.Date(2021, time.June, 16, 19, 0, 0, 0, berlin), powerState: hivev1.ClusterPowerStateHibernating},
+		},
+		{
+			name:     "defaults to UTC",
+			schedule: &hivev1.HibernationSchedule{Resume: "0 8 * * *", Hibernate: "0 19 * * *"},
+			now:      time.Date(2021, time.June, 16, 7, 30, 0, 0, time.UTC),
+			expLast:  &scheduledTransition{time: time.Date(2021, time.June, 15, 19, 0, 0, 0, time.UTC), powerState: hivev1.ClusterPowerStateHibernating},
+			expNext:  &scheduledTransition{time: time.Date(2021, time.June, 16, 8, 0, 0, 0, time.UTC), powerState: hivev1.ClusterPowerStateRunning},
+		},
+		{
+			name:     "monthly",
+			schedule: &hivev1.HibernationSchedule{Resume: "0 0 1 * *", Hibernate: "0 0 15 * *"},
+			now:      time.Date(2021, time.June, 30, 12, 0, 0, 0, time.UTC),
+			expLast:  &scheduledTransition{time: time.Date(2021, time.June, 15, 0, 0, 0, 0, time.UTC), powerState: hivev1.ClusterPowerStateHibernating},
+			expNext:  &scheduledTransition{time: time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC), powerState: hivev1.ClusterPowerStateRunning},
+		},
+		{
+			name:     "resume never fires",
+			schedule: &hivev1.HibernationSchedule{Resume: "0 0 30 2 *", Hibernate: "0 19 * * *"},
+			now:      time.Date(2021, time.June, 16, 21, 0, 0, 0, time.UTC),
+			expLast:  &scheduledTransition{time: time.Date(2021, time.June, 16, 19, 0, 0, 0, time.UTC), powerState: hivev1.ClusterPowerStateHibernating},
+		},
+		{
+			name:      "invalid time zone",
+			schedule:  &hivev1.HibernationSchedule{Resume: "0 8 * * *", Hibernate: "0 19 * * *", TimeZone: "Mars/Olympus_Mons"},
+			now:       time.Date(2021, time.June, 16, 21, 0, 0, 0, time.UTC),
+			expectErr: true,
+		},
+		{
+			name:      "invalid cron expression",
+			schedule:  &hivev1.HibernationSchedule{Resume: "0 8 * *", Hibernate: "0 19 * * *"},
+			now:       time.Date(2021, time.June, 16, 21, 0, 0, 0, time.UTC),
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			last, next, err := evaluateHibernationSchedule(test.schedule, test.now)
+			if test.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assertScheduledTransition(t, test.expLast, last, "last")
+			assertScheduledTransition(t, test.expNext, next, "next")
+		})
+	}
+}
+
+func assertScheduledTransition(t *testing.T, expected, actual *scheduledTransition, which string) {
+	if expected == nil {
+		assert.Nil(t, actual, "expected no %s transition", which)
+		return
+	}
+	if assert.NotNil(t, actual, "expected a %s transition", which) {
+		assert.True(t, expected.time.Equal(actual.time), "unexpected %s transition time: %v", which, actual.time)
+		assert.Equal(t, expected.powerState, actual.powerState, "unexpected %s transition power state", which)
+	}
+}
+
+func TestHibernationSchedule(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	// Hourly schedule which resumed the cluster 10 minutes ago and hibernated it 5 minutes ago, whatever
+	// the current time is.
+	now := time.Now().Truncate(time.Minute)
+	lastResume, lastHibernate := now.Add(-10*time.Minute), now.Add(-5*time.Minute)
+	resume, hibernate := fmt.Sprintf("%d * * * *", lastResume.Minute()), fmt.Sprintf("%d * * * *", lastHibernate.Minute())
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+		testcd.InstalledTimestamp(now.Add(-10*time.Hour)),
+		testcd.WithCondition(hibernatingCondition(corev1.ConditionFalse, hivev1.HibernatingReasonResumingOrRunning, 6*time.Hour)),
+		testcd.WithCondition(readyCondition(corev1.ConditionTrue, hivev1.ReadyReasonRunning, 6*time.Hour)),
+		// Fake clusters let us observe the power state without involving the actuator or the remote cluster.
+		testcd.WithAnnotation(constants.HiveFakeClusterAnnotation, "true"),
+	)
+	cs := testcs.FullBuilder(namespace, cdName, scheme).Build(
+		testcs.WithFirstSuccessTime(now.Add(-10 * time.Hour)),
+	)
+
+	tests := []struct {
+		name string
+		cd   *hivev1.ClusterDeployment
+
+		expectRequeueAfter     time.Duration
+		expectedPowerState     hivev1.ClusterPowerState
+		expectedStatus         bool
+		expectedLastTransition time.Time
+		expectedNextPowerState hivev1.ClusterPowerState
+	}{
+		{
+			name:               "no schedule",
+			cd:                 cdBuilder.Build(),
+			expectedPowerState: "",
+		},
+		{
+			name:               "apply scheduled hibernation",
+			cd:                 cdBuilder.Build(testcd.WithHibernationSchedule(resume, hibernate, "")),
+			expectedPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name: "scheduled hibernation already applied",
+			cd: cdBuilder.Build(
+				testcd.WithHibernationSchedule(resume, hibernate, ""),
+				testcd.WithPowerState(hivev1.ClusterPowerStateHibernating),
+			),
+			expectRequeueAfter:     50 * time.Minute,
+			expectedPowerState:     hivev1.ClusterPowerStateHibernating,
+			expectedStatus:         true,
+			expectedLastTransition: lastHibernate,
+			expectedNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name: "manually resumed since scheduled hibernation",
+			cd: cdBuilder.Build(
+				testcd.WithHibernationSchedule(resume, hibernate, ""),
+				testcd.WithPowerState(hivev1.ClusterPowerStateRunning),
+				testcd.WithHibernationScheduleStatus(&hivev1.HibernationScheduleStatus{
+					LastTransitionTime: &metav1.Time{Time: lastHibernate},
+				}),
+			),
+			expectRequeueAfter:     50 * time.Minute,
+			expectedPowerState:     hivev1.ClusterPowerStateRunning,
+			expectedStatus:         true,
+			expectedLastTransition: lastHibernate,
+			expectedNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name: "schedule overridden",
+			cd: cdBuilder.Build(
+				testcd.WithHibernationSchedule(resume, hibernate, ""),
+				testcd.WithAnnotation(constants.HibernationScheduleOverrideUntilAnnotation, now.Add(time.Hour).Format(time.RFC3339)),
+			),
+			expectRequeueAfter:     50 * time.Minute,
+			expectedPowerState:     "",
+			expectedStatus:         true,
+			expectedLastTransition: lastHibernate,
+			expectedNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name: "schedule override expired",
+			cd: cdBuilder.Build(
+				testcd.WithHibernationSchedule(resume, hibernate, ""),
+				testcd.WithAnnotation(constants.HibernationScheduleOverrideUntilAnnotation, now.Add(-time.Hour).Format(time.RFC3339)),
+			),
+			expectedPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name: "unclaimed pool cluster",
+			cd: cdBuilder.Build(
+				testcd.WithHibernationSchedule(resume, hibernate, ""),
+				testcd.WithUnclaimedClusterPoolReference(namespace, "pool"),
+			),
+			expectedPowerState: "",
+		},
+		{
+			name: "claimed pool cluster",
+			cd: cdBuilder.Build(
+				testcd.WithHibernationSchedule(resume, hibernate, ""),
+				testcd.WithClusterPoolReference(namespace, "pool", "claim"),
+			),
+			expectedPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name: "apply scheduled resume",
+			cd: cdBuilder.Build(
+				testcd.WithHibernationSchedule(hibernate, resume, ""),
+				testcd.WithPowerState(hivev1.ClusterPowerStateHibernating),
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionTrue, hivev1.HibernatingReasonHibernating, time.Hour)),
+				testcd.WithCondition(readyCondition(corev1.ConditionFalse, hivev1.ReadyReasonStoppingOrHibernating, time.Hour)),
+			),
+			expectedPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name: "clear status of removed schedule",
+			cd: cdBuilder.Build(
+				testcd.WithHibernationScheduleStatus(&hivev1.HibernationScheduleStatus{
+					LastTransitionTime: &metav1.Time{Time: lastHibernate},
+				}),
+			),
+			expectedPowerState: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			mockBuilder := remoteclientmock.NewMockBuilder(ctrl)
+			mockCSRHelper := mock.NewMockcsrHelper(ctrl)
+			actuators = []HibernationActuator{mockActuator}
+			c := fake.NewFakeClientWithScheme(scheme, test.cd, cs.DeepCopy())
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockBuilder
+				},
+				csrUtil: mockCSRHelper,
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "expected no error from reconcile")
+
+			// Need to do fuzzy requeue after matching
+			if test.expectRequeueAfter == 0 {
+				assert.Zero(t, result.RequeueAfter)
+			} else {
+				assert.GreaterOrEqual(t, result.RequeueAfter.Seconds(), (test.expectRequeueAfter - time.Minute).Seconds(), "requeue after too small")
+				assert.LessOrEqual(t, result.RequeueAfter.Seconds(), (test.expectRequeueAfter + time.Minute).Seconds(), "request after too large")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			require.NoError(t, err, "error looking up ClusterDeployment")
+			assert.Equal(t, test.expectedPowerState, cd.Spec.PowerState, "unexpected PowerState")
+			if !test.expectedStatus {
+				assert.Nil(t, cd.Status.HibernationSchedule, "expected no hibernation schedule status")
+				return
+			}
+			if assert.NotNil(t, cd.Status.HibernationSchedule, "expected hibernation schedule status") {
+				status := cd.Status.HibernationSchedule
+				if assert.NotNil(t, status.LastTransitionTime, "expected last transition time") {
+					assert.True(t, test.expectedLastTransition.Equal(status.LastTransitionTime.Time), "unexpected last transition time")
+				}
+				assert.NotNil(t, status.NextTransitionTime, "expected next transition time")
+				assert.Equal(t, test.expectedNextPowerState, status.NextPowerState, "unexpected next power state")
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/openstackclient"
+)
+
+var (
+	// Nova server statuses as described in
+	// https://docs.openstack.org/api-guide/compute/server_concepts.html
+	openstackRunningStatuses          = sets.NewString("ACTIVE")
+	openstackStoppedStatuses          = sets.NewString("SHUTOFF")
+	openstackPendingStatuses          = sets.NewString("BUILD", "REBOOT", "HARD_REBOOT")
+	openstackRunningOrPendingStatuses = openstackRunningStatuses.Union(openstackPendingStatuses)
+	openstackNotRunningStatuses       = openstackStoppedStatuses.Union(openstackPendingStatuses)
+	openstackNotStoppedStatuses       = openstackRunningOrPendingStatuses
+)
+
+func init() {
+	RegisterActuator(&openstackActuator{openstackClientFn: getOpenStackClient})
+}
+
+type openstackActuator struct {
+	// openstackClientFn is the function to build an OpenStack client, here for testing
+	openstackClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (openstackclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *openstackActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.OpenStack != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *openstackActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "openstack")
+	openstackClient, err := a.openstackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	// Servers that are still building or rebooting cannot be stopped, so only running servers are
+	// stopped here. Pending servers will be picked up once the controller requeues.
+	serverList, err := openstackListServers(openstackClient, cd, openstackRunningStatuses, logger)
+	if err != nil {
+		return err
+	}
+	if len(serverList) == 0 {
+		logger.Info("No servers were found to stop")
+		return nil
+	}
+	var errs []error
+	for _, server := range serverList {
+		logger.WithField("server", server.Name).Info("Stopping server")
+		if err := openstackClient.StopServer(server.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *openstackActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "openstack")
+	openstackClient, err := a.openstackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	serverList, err := openstackListServers(openstackClient, cd, openstackStoppedStatuses, logger)
+	if err != nil {
+		return err
+	}
+	if len(serverList) == 0 {
+		logger.Info("No servers were found to start")
+		return nil
+	}
+	var errs []error
+	for _, server := range serverList {
+		logger.WithField("server", server.Name).Info("Starting server")
+		if err := openstackClient.StartServer(server.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state. It also returns a list of machines that
+// are not running.
+func (a *openstackActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "openstack")
+	openstackClient, err := a.openstackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	serverList, err := openstackListServers(openstackClient, cd, openstackNotRunningStatuses, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(serverList) == 0, openstackServerNames(serverList), nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state. It also returns a list of machines
+// that have not stopped.
+func (a *openstackActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "openstack")
+	openstackClient, err := a.openstackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	serverList, err := openstackListServers(openstackClient, cd, openstackNotStoppedStatuses, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(serverList) == 0, openstackServerNames(serverList), nil
+}
+
+func getOpenStackClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (openstackclient.Client, error) {
+	if cd.Spec.Platform.OpenStack == nil {
+		return nil, errors.New("OpenStack platform is not set in ClusterDeployment")
+	}
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch OpenStack credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch OpenStack credentials secret")
+	}
+	var certificatesSecret *corev1.Secret
+	if ref := cd.Spec.Platform.OpenStack.CertificatesSecretRef; ref != nil && ref.Name != "" {
+		certificatesSecret = &corev1.Secret{}
+		err := c.Get(context.TODO(), client.ObjectKey{Name: ref.Name, Namespace: cd.Namespace}, certificatesSecret)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch OpenStack certificates secret")
+			return nil, errors.Wrap(err, "failed to fetch OpenStack certificates secret")
+		}
+	}
+	return openstackclient.NewClientFromSecret(secret, certificatesSecret, cd.Spec.Platform.OpenStack.Cloud)
+}
+
+func openstackListServers(openstackClient openstackclient.Client, cd *hivev1.ClusterDeployment, statuses sets.String, logger log.FieldLogger) ([]servers.Server, error) {
+	logger.Debug("listing cluster servers")
+	// The installer names every server it creates after the infra ID, and Nova treats
+	// the name filter as a regular expression.
+	allServers, err := openstackClient.ListServers(&servers.ListOpts{
+		Name: fmt.Sprintf("^%s-", cd.Spec.ClusterMetadata.InfraID),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to list servers")
+		return nil, err
+	}
+	var result []servers.Server
+	for _, server := range allServers {
+		if statuses.Has(server.Status) {
+			result = append(result, server)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("statuses", statuses.List()).Debug("found servers")
+	return result, nil
+}
+
+func openstackServerNames(serverList []servers.Server) []string {
+	ret := make([]string, len(serverList))
+	for i, server := range serverList {
+		ret[i] = server.Name
+	}
+	return ret
+}
@@ -0,0 +1,195 @@
+package hibernation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
+	"github.com/openshift/hive/pkg/openstackclient"
+	mockopenstackclient "github.com/openshift/hive/pkg/openstackclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestOpenStackCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.OpenStack = &hivev1openstack.Platform{}
+	}).Build()
+	actuator := openstackActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestOpenStackStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name        string
+		testFunc    string
+		servers     map[string]int
+		setupClient func(*testing.T, *mockopenstackclient.MockClient)
+	}{
+		{
+			name:     "stop no running servers",
+			testFunc: "StopMachines",
+			servers:  map[string]int{"SHUTOFF": 3},
+		},
+		{
+			name:     "stop running servers",
+			testFunc: "StopMachines",
+			servers:  map[string]int{"SHUTOFF": 2, "ACTIVE": 3},
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockClient) {
+				c.EXPECT().StopServer(gomock.Any()).Times(3).Do(
+					func(id string) {
+						assert.Regexp(t, "^ACTIVE-", id)
+					},
+				)
+			},
+		},
+		{
+			name:     "stop skips building servers",
+			testFunc: "StopMachines",
+			servers:  map[string]int{"BUILD": 2, "ACTIVE": 1},
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockClient) {
+				c.EXPECT().StopServer(gomock.Any()).Times(1)
+			},
+		},
+		{
+			name:     "start no stopped servers",
+			testFunc: "StartMachines",
+			servers:  map[string]int{"ACTIVE": 3, "BUILD": 1},
+		},
+		{
+			name:     "start stopped servers",
+			testFunc: "StartMachines",
+			servers:  map[string]int{"SHUTOFF": 4, "ACTIVE": 2},
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockClient) {
+				c.EXPECT().StartServer(gomock.Any()).Times(4).Do(
+					func(id string) {
+						assert.Regexp(t, "^SHUTOFF-", id)
+					},
+				)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			openstackClient := mockopenstackclient.NewMockClient(ctrl)
+			setupOpenStackClientServers(openstackClient, test.servers)
+			if test.setupClient != nil {
+				test.setupClient(t, openstackClient)
+			}
+			actuator := testOpenStackActuator(openstackClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			assert.Nil(t, err)
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestOpenStackMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name     string
+		testFunc string
+		expected bool
+		servers  map[string]int
+	}{
+		{
+			name:     "Stopped - All servers shut off",
+			testFunc: "MachinesStopped",
+			expected: true,
+			servers:  map[string]int{"SHUTOFF": 3},
+		},
+		{
+			name:     "Stopped - Some servers rebooting",
+			testFunc: "MachinesStopped",
+			expected: false,
+			servers:  map[string]int{"SHUTOFF": 3, "REBOOT": 1},
+		},
+		{
+			name:     "Stopped - Some servers active",
+			testFunc: "MachinesStopped",
+			expected: false,
+			servers:  map[string]int{"SHUTOFF": 3, "ACTIVE": 2},
+		},
+		{
+			name:     "Running - All servers active",
+			testFunc: "MachinesRunning",
+			expected: true,
+			servers:  map[string]int{"ACTIVE": 3},
+		},
+		{
+			name:     "Running - Some servers building",
+			testFunc: "MachinesRunning",
+			expected: false,
+			servers:  map[string]int{"ACTIVE": 3, "BUILD": 1},
+		},
+		{
+			name:     "Running - Some servers shut off",
+			testFunc: "MachinesRunning",
+			expected: false,
+			servers:  map[string]int{"ACTIVE": 3, "SHUTOFF": 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			openstackClient := mockopenstackclient.NewMockClient(ctrl)
+			setupOpenStackClientServers(openstackClient, test.servers)
+			actuator := testOpenStackActuator(openstackClient)
+			var err error
+			var result bool
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, _, err = actuator.MachinesStopped(testClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, _, err = actuator.MachinesRunning(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func testOpenStackActuator(openstackClient openstackclient.Client) *openstackActuator {
+	return &openstackActuator{
+		openstackClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (openstackclient.Client, error) {
+			return openstackClient, nil
+		},
+	}
+}
+
+func setupOpenStackClientServers(openstackClient *mockopenstackclient.MockClient, statuses map[string]int) {
+	serverList := []servers.Server{}
+	for status, count := range statuses {
+		for i := 0; i < count; i++ {
+			serverList = append(serverList, servers.Server{
+				ID:     fmt.Sprintf("%s-%d", status, i),
+				Name:   fmt.Sprintf("abcd1234-%s-%d", status, i),
+				Status: status,
+			})
+		}
+	}
+	openstackClient.EXPECT().ListServers(&servers.ListOpts{Name: "^abcd1234-"}).Times(1).Return(serverList, nil)
+}
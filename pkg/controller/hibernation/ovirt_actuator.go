@@ -0,0 +1,182 @@
+package hibernation
+
+import (
+	"context"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/ovirtclient"
+)
+
+var (
+	ovirtRunningStatuses = sets.NewString(string(ovirtsdk.VMSTATUS_UP))
+	ovirtStoppedStatuses = sets.NewString(string(ovirtsdk.VMSTATUS_DOWN))
+	ovirtPendingStatuses = sets.NewString(
+		string(ovirtsdk.VMSTATUS_POWERING_UP),
+		string(ovirtsdk.VMSTATUS_WAIT_FOR_LAUNCH),
+		string(ovirtsdk.VMSTATUS_REBOOT_IN_PROGRESS),
+		string(ovirtsdk.VMSTATUS_RESTORING_STATE),
+	)
+	ovirtStoppingStatuses          = sets.NewString(string(ovirtsdk.VMSTATUS_POWERING_DOWN), string(ovirtsdk.VMSTATUS_SAVING_STATE))
+	ovirtRunningOrPendingStatuses  = ovirtRunningStatuses.Union(ovirtPendingStatuses)
+	ovirtStoppedOrStoppingStatuses = ovirtStoppedStatuses.Union(ovirtStoppingStatuses)
+	ovirtNotRunningStatuses        = ovirtStoppedOrStoppingStatuses.Union(ovirtPendingStatuses)
+	ovirtNotStoppedStatuses        = ovirtRunningOrPendingStatuses.Union(ovirtStoppingStatuses)
+)
+
+func init() {
+	RegisterActuator(&ovirtActuator{ovirtClientFn: getOvirtClient})
+}
+
+type ovirtActuator struct {
+	// ovirtClientFn is the function to build an oVirt client, here for testing
+	ovirtClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (ovirtclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *ovirtActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.Ovirt != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *ovirtActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "ovirt")
+	ovirtClient, err := a.ovirtClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer ovirtClient.Close()
+	vms, err := ovirtListVMs(ovirtClient, cd, ovirtRunningOrPendingStatuses, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No VMs were found to stop")
+		return nil
+	}
+	var errs []error
+	for _, vm := range vms {
+		logger.WithField("vm", vm.MustName()).Info("Stopping VM")
+		if err := ovirtClient.ShutdownVM(vm.MustId()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *ovirtActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "ovirt")
+	ovirtClient, err := a.ovirtClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer ovirtClient.Close()
+	vms, err := ovirtListVMs(ovirtClient, cd, ovirtStoppedStatuses, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No VMs were found to start")
+		return nil
+	}
+	var errs []error
+	for _, vm := range vms {
+		logger.WithField("vm", vm.MustName()).Info("Starting VM")
+		if err := ovirtClient.StartVM(vm.MustId()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state. It also returns a list of machines that
+// are not running.
+func (a *ovirtActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "ovirt")
+	ovirtClient, err := a.ovirtClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer ovirtClient.Close()
+	vms, err := ovirtListVMs(ovirtClient, cd, ovirtNotRunningStatuses, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, ovirtVMNames(vms), nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state. It also returns a list of machines
+// that have not stopped.
+func (a *ovirtActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "ovirt")
+	ovirtClient, err := a.ovirtClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer ovirtClient.Close()
+	vms, err := ovirtListVMs(ovirtClient, cd, ovirtNotStoppedStatuses, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, ovirtVMNames(vms), nil
+}
+
+func getOvirtClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (ovirtclient.Client, error) {
+	if cd.Spec.Platform.Ovirt == nil {
+		return nil, errors.New("oVirt platform is not set in ClusterDeployment")
+	}
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Ovirt.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch oVirt credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch oVirt credentials secret")
+	}
+	var certificatesSecret *corev1.Secret
+	if name := cd.Spec.Platform.Ovirt.CertificatesSecretRef.Name; name != "" {
+		certificatesSecret = &corev1.Secret{}
+		err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: cd.Namespace}, certificatesSecret)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch oVirt certificates secret")
+			return nil, errors.Wrap(err, "failed to fetch oVirt certificates secret")
+		}
+	}
+	return ovirtclient.NewClientFromSecret(secret, certificatesSecret)
+}
+
+func ovirtListVMs(ovirtClient ovirtclient.Client, cd *hivev1.ClusterDeployment, statuses sets.String, logger log.FieldLogger) ([]*ovirtsdk.Vm, error) {
+	logger.Debug("listing cluster VMs")
+	// The installer tags every VM it creates with the infra ID.
+	allVMs, err := ovirtClient.ListVMs(cd.Spec.ClusterMetadata.InfraID)
+	if err != nil {
+		logger.WithError(err).Error("failed to list VMs")
+		return nil, err
+	}
+	var result []*ovirtsdk.Vm
+	for _, vm := range allVMs {
+		if statuses.Has(string(vm.MustStatus())) {
+			result = append(result, vm)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("statuses", statuses.List()).Debug("found VMs")
+	return result, nil
+}
+
+func ovirtVMNames(vms []*ovirtsdk.Vm) []string {
+	ret := make([]string, len(vms))
+	for i, vm := range vms {
+		ret[i] = vm.MustName()
+	}
+	return ret
+}
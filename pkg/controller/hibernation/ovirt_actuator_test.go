@@ -0,0 +1,180 @@
+package hibernation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ovirtsdk "github.com/ovirt/go-ovirt"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1ovirt "github.com/openshift/hive/apis/hive/v1/ovirt"
+	"github.com/openshift/hive/pkg/ovirtclient"
+	mockovirtclient "github.com/openshift/hive/pkg/ovirtclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestOvirtCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.Ovirt = &hivev1ovirt.Platform{}
+	}).Build()
+	actuator := ovirtActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestOvirtStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name        string
+		testFunc    string
+		vms         map[ovirtsdk.VmStatus]int
+		setupClient func(*testing.T, *mockovirtclient.MockClient)
+	}{
+		{
+			name:     "stop no running vms",
+			testFunc: "StopMachines",
+			vms:      map[ovirtsdk.VmStatus]int{ovirtsdk.VMSTATUS_DOWN: 2, ovirtsdk.VMSTATUS_POWERING_DOWN: 1},
+		},
+		{
+			name:     "stop running and pending vms",
+			testFunc: "StopMachines",
+			vms: map[ovirtsdk.VmStatus]int{
+				ovirtsdk.VMSTATUS_DOWN:        2,
+				ovirtsdk.VMSTATUS_UP:          3,
+				ovirtsdk.VMSTATUS_POWERING_UP: 1,
+			},
+			setupClient: func(t *testing.T, c *mockovirtclient.MockClient) {
+				c.EXPECT().ShutdownVM(gomock.Any()).Times(4).Do(
+					func(id string) {
+						assert.NotRegexp(t, "^down-", id)
+					},
+				)
+			},
+		},
+		{
+			name:     "start no stopped vms",
+			testFunc: "StartMachines",
+			vms:      map[ovirtsdk.VmStatus]int{ovirtsdk.VMSTATUS_UP: 3},
+		},
+		{
+			name:     "start stopped vms",
+			testFunc: "StartMachines",
+			vms:      map[ovirtsdk.VmStatus]int{ovirtsdk.VMSTATUS_DOWN: 3, ovirtsdk.VMSTATUS_UP: 2},
+			setupClient: func(t *testing.T, c *mockovirtclient.MockClient) {
+				c.EXPECT().StartVM(gomock.Any()).Times(3).Do(
+					func(id string) {
+						assert.Regexp(t, "^down-", id)
+					},
+				)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ovirtClient := mockovirtclient.NewMockClient(ctrl)
+			setupOvirtClientVMs(ovirtClient, test.vms)
+			if test.setupClient != nil {
+				test.setupClient(t, ovirtClient)
+			}
+			actuator := testOvirtActuator(ovirtClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			assert.Nil(t, err)
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestOvirtMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name     string
+		testFunc string
+		expected bool
+		vms      map[ovirtsdk.VmStatus]int
+	}{
+		{
+			name:     "Stopped - All vms down",
+			testFunc: "MachinesStopped",
+			expected: true,
+			vms:      map[ovirtsdk.VmStatus]int{ovirtsdk.VMSTATUS_DOWN: 3},
+		},
+		{
+			name:     "Stopped - Some vms powering down",
+			testFunc: "MachinesStopped",
+			expected: false,
+			vms:      map[ovirtsdk.VmStatus]int{ovirtsdk.VMSTATUS_DOWN: 3, ovirtsdk.VMSTATUS_POWERING_DOWN: 1},
+		},
+		{
+			name:     "Running - All vms up",
+			testFunc: "MachinesRunning",
+			expected: true,
+			vms:      map[ovirtsdk.VmStatus]int{ovirtsdk.VMSTATUS_UP: 3},
+		},
+		{
+			name:     "Running - Some vms powering up",
+			testFunc: "MachinesRunning",
+			expected: false,
+			vms:      map[ovirtsdk.VmStatus]int{ovirtsdk.VMSTATUS_UP: 3, ovirtsdk.VMSTATUS_POWERING_UP: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ovirtClient := mockovirtclient.NewMockClient(ctrl)
+			setupOvirtClientVMs(ovirtClient, test.vms)
+			actuator := testOvirtActuator(ovirtClient)
+			var err error
+			var result bool
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, _, err = actuator.MachinesStopped(testClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, _, err = actuator.MachinesRunning(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func testOvirtActuator(ovirtClient ovirtclient.Client) *ovirtActuator {
+	return &ovirtActuator{
+		ovirtClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (ovirtclient.Client, error) {
+			return ovirtClient, nil
+		},
+	}
+}
+
+func setupOvirtClientVMs(ovirtClient *mockovirtclient.MockClient, statuses map[ovirtsdk.VmStatus]int) {
+	vms := []*ovirtsdk.Vm{}
+	for status, count := range statuses {
+		for i := 0; i < count; i++ {
+			vms = append(vms, ovirtsdk.NewVmBuilder().
+				Id(fmt.Sprintf("%s-%d", status, i)).
+				Name(fmt.Sprintf("abcd1234-%s-%d", status, i)).
+				Status(status).
+				MustBuild())
+		}
+	}
+	ovirtClient.EXPECT().ListVMs("abcd1234").Times(1).Return(vms, nil)
+	ovirtClient.EXPECT().Close().AnyTimes()
+}
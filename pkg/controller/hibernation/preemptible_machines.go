@@ -0,0 +1,100 @@
+package hibernation
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// replacePreemptibleMachines deletes the interruptible Machines of the cluster that have not reported being healthy
+// since hibernation started, so that their MachineSets replace them. The machine-api labels the Machines of AWS Spot
+// instances, GCP preemptible VMs and Azure Spot VMs as interruptible alike.
+func replacePreemptibleMachines(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) (bool, error) {
+	hibernatingCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions,
+		hivev1.ClusterHibernatingCondition)
+	if hibernatingCondition == nil {
+		return false, errors.New("cannot find hibernating condition")
+	}
+	hibernationStartedTime := hibernatingCondition.LastTransitionTime
+
+	machineList := &machineapi.MachineList{}
+	err := remoteClient.List(context.TODO(), machineList,
+		client.InNamespace(machineAPINamespace),
+		client.MatchingLabels{machineAPIInterruptibleLabel: ""},
+	)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list machines")
+		return false, errors.Wrap(err, "failed to list machines")
+	}
+	if len(machineList.Items) == 0 {
+		return false, nil
+	}
+
+	var toBeReplaced []machineapi.Machine
+	for _, m := range machineList.Items {
+		if m.GetDeletionTimestamp() != nil {
+			// this object is already marked for deletion
+			continue
+		}
+		if m.Status.LastUpdated.After(hibernationStartedTime.Time) &&
+			m.Status.Phase != nil && *m.Status.Phase != "Failed" {
+			// this is a machine that is reporting not failed
+			// after hibernation was started, therefore do not
+			// remove
+			continue
+		}
+
+		toBeReplaced = append(toBeReplaced, m)
+	}
+
+	logger.WithField("machines", machineNames(toBeReplaced)).Debug("Preemptible Machine objects will be replaced")
+	var replaced bool
+	var errs []error
+	for _, m := range toBeReplaced {
+		// We want the machine-api to skip the draining
+		// since we already know these nodes were terminated
+		// during hibernation.
+		anno := m.GetAnnotations()
+		if anno == nil {
+			anno = map[string]string{}
+		}
+		anno[machineAPIExcludeDrainingAnnotation] = "true"
+		m.SetAnnotations(anno)
+		if err := remoteClient.Update(context.TODO(), &m); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to update machine %s/%s to be excluded from draining",
+				machineAPINamespace, m.GetName()))
+			continue
+		}
+
+		// Delete the machine object so that it will be replaced
+		// by the machine set.
+		if err := remoteClient.Delete(context.TODO(), &m); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to delete machine %s/%s", machineAPINamespace, m.GetName()))
+			continue
+		}
+		replaced = true
+	}
+	if len(errs) > 0 {
+		err := utilerrors.NewAggregate(errs)
+		logger.WithError(err).Error("Failed to delete machines")
+		return replaced, err
+	}
+
+	return replaced, nil
+}
+
+func machineNames(machines []machineapi.Machine) []string {
+	result := make([]string, len(machines))
+	for idx, m := range machines {
+		result[idx] = m.GetName()
+	}
+	return result
+}
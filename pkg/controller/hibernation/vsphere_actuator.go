@@ -0,0 +1,188 @@
+package hibernation
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/vsphereclient"
+)
+
+const (
+	// vsphereTimeout bounds the calls made to vCenter by a single actuator operation.
+	vsphereTimeout = 5 * time.Minute
+)
+
+var (
+	vsphereRunningStates    = sets.NewString(string(types.VirtualMachinePowerStatePoweredOn))
+	vsphereStoppedStates    = sets.NewString(string(types.VirtualMachinePowerStatePoweredOff), string(types.VirtualMachinePowerStateSuspended))
+	vsphereNotRunningStates = vsphereStoppedStates
+	vsphereNotStoppedStates = vsphereRunningStates
+)
+
+func init() {
+	RegisterActuator(&vsphereActuator{vsphereClientFn: getVSphereClient})
+}
+
+type vsphereActuator struct {
+	// vsphereClientFn is the function to build a vSphere client, here for testing
+	vsphereClientFn func(context.Context, *hivev1.ClusterDeployment, client.Client, log.FieldLogger) (vsphereclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *vsphereActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.VSphere != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *vsphereActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vsphere")
+	ctx, cancel := context.WithTimeout(context.Background(), vsphereTimeout)
+	defer cancel()
+	vsphereClient, err := a.vsphereClientFn(ctx, cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vsphereClient.Close(ctx)
+	vms, err := vsphereListVirtualMachines(ctx, vsphereClient, cd, vsphereRunningStates, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No virtual machines were found to stop")
+		return nil
+	}
+	var errs []error
+	for _, vm := range vms {
+		logger.WithField("vm", vm.Name).Info("Stopping virtual machine")
+		if err := vsphereClient.PowerOffVirtualMachine(ctx, vm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *vsphereActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vsphere")
+	ctx, cancel := context.WithTimeout(context.Background(), vsphereTimeout)
+	defer cancel()
+	vsphereClient, err := a.vsphereClientFn(ctx, cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vsphereClient.Close(ctx)
+	vms, err := vsphereListVirtualMachines(ctx, vsphereClient, cd, vsphereStoppedStates, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No virtual machines were found to start")
+		return nil
+	}
+	var errs []error
+	for _, vm := range vms {
+		logger.WithField("vm", vm.Name).Info("Starting virtual machine")
+		if err := vsphereClient.PowerOnVirtualMachine(ctx, vm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state. It also returns a list of machines that
+// are not running.
+func (a *vsphereActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "vsphere")
+	ctx, cancel := context.WithTimeout(context.Background(), vsphereTimeout)
+	defer cancel()
+	vsphereClient, err := a.vsphereClientFn(ctx, cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer vsphereClient.Close(ctx)
+	vms, err := vsphereListVirtualMachines(ctx, vsphereClient, cd, vsphereNotRunningStates, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, vsphereVirtualMachineNames(vms), nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state. It also returns a list of machines
+// that have not stopped.
+func (a *vsphereActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "vsphere")
+	ctx, cancel := context.WithTimeout(context.Background(), vsphereTimeout)
+	defer cancel()
+	vsphereClient, err := a.vsphereClientFn(ctx, cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer vsphereClient.Close(ctx)
+	vms, err := vsphereListVirtualMachines(ctx, vsphereClient, cd, vsphereNotStoppedStates, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, vsphereVirtualMachineNames(vms), nil
+}
+
+func getVSphereClient(ctx context.Context, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (vsphereclient.Client, error) {
+	if cd.Spec.Platform.VSphere == nil {
+		return nil, errors.New("vSphere platform is not set in ClusterDeployment")
+	}
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.VSphere.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch vSphere credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch vSphere credentials secret")
+	}
+	var certificatesSecret *corev1.Secret
+	if name := cd.Spec.Platform.VSphere.CertificatesSecretRef.Name; name != "" {
+		certificatesSecret = &corev1.Secret{}
+		err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: cd.Namespace}, certificatesSecret)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch vSphere certificates secret")
+			return nil, errors.Wrap(err, "failed to fetch vSphere certificates secret")
+		}
+	}
+	return vsphereclient.NewClientFromSecret(ctx, cd.Spec.Platform.VSphere.VCenter, secret, certificatesSecret)
+}
+
+func vsphereListVirtualMachines(ctx context.Context, vsphereClient vsphereclient.Client, cd *hivev1.ClusterDeployment, states sets.String, logger log.FieldLogger) ([]mo.VirtualMachine, error) {
+	logger.Debug("listing cluster virtual machines")
+	// The installer attaches a tag named after the infra ID to every virtual machine it creates.
+	allVMs, err := vsphereClient.ListVirtualMachines(ctx, cd.Spec.ClusterMetadata.InfraID)
+	if err != nil {
+		logger.WithError(err).Error("failed to list virtual machines")
+		return nil, err
+	}
+	var result []mo.VirtualMachine
+	for _, vm := range allVMs {
+		if states.Has(string(vm.Runtime.PowerState)) {
+			result = append(result, vm)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("states", states.List()).Debug("found virtual machines")
+	return result, nil
+}
+
+func vsphereVirtualMachineNames(vms []mo.VirtualMachine) []string {
+	ret := make([]string, len(vms))
+	for i, vm := range vms {
+		ret[i] = vm.Name
+	}
+	return ret
+}
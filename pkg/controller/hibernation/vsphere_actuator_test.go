@@ -0,0 +1,191 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/vsphereclient"
+	mockvsphereclient "github.com/openshift/hive/pkg/vsphereclient/mock"
+)
+
+func TestVSphereCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.VSphere = &hivev1vsphere.Platform{}
+	}).Build()
+	actuator := vsphereActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestVSphereStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name        string
+		testFunc    string
+		vms         map[types.VirtualMachinePowerState]int
+		setupClient func(*testing.T, *mockvsphereclient.MockClient)
+	}{
+		{
+			name:     "stop no running vms",
+			testFunc: "StopMachines",
+			vms:      map[types.VirtualMachinePowerState]int{types.VirtualMachinePowerStatePoweredOff: 3},
+		},
+		{
+			name:     "stop running vms",
+			testFunc: "StopMachines",
+			vms: map[types.VirtualMachinePowerState]int{
+				types.VirtualMachinePowerStatePoweredOff: 2,
+				types.VirtualMachinePowerStatePoweredOn:  3,
+			},
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockClient) {
+				c.EXPECT().PowerOffVirtualMachine(gomock.Any(), gomock.Any()).Times(3).Do(
+					func(_ context.Context, vm mo.VirtualMachine) {
+						assert.Equal(t, types.VirtualMachinePowerStatePoweredOn, vm.Runtime.PowerState)
+					},
+				)
+			},
+		},
+		{
+			name:     "start no stopped vms",
+			testFunc: "StartMachines",
+			vms:      map[types.VirtualMachinePowerState]int{types.VirtualMachinePowerStatePoweredOn: 3},
+		},
+		{
+			name:     "start stopped and suspended vms",
+			testFunc: "StartMachines",
+			vms: map[types.VirtualMachinePowerState]int{
+				types.VirtualMachinePowerStatePoweredOff: 2,
+				types.VirtualMachinePowerStateSuspended:  1,
+				types.VirtualMachinePowerStatePoweredOn:  4,
+			},
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockClient) {
+				c.EXPECT().PowerOnVirtualMachine(gomock.Any(), gomock.Any()).Times(3).Do(
+					func(_ context.Context, vm mo.VirtualMachine) {
+						assert.NotEqual(t, types.VirtualMachinePowerStatePoweredOn, vm.Runtime.PowerState)
+					},
+				)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			vsphereClient := mockvsphereclient.NewMockClient(ctrl)
+			setupVSphereClientVirtualMachines(vsphereClient, test.vms)
+			if test.setupClient != nil {
+				test.setupClient(t, vsphereClient)
+			}
+			actuator := testVSphereActuator(vsphereClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			assert.Nil(t, err)
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestVSphereMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name     string
+		testFunc string
+		expected bool
+		vms      map[types.VirtualMachinePowerState]int
+	}{
+		{
+			name:     "Stopped - All vms powered off",
+			testFunc: "MachinesStopped",
+			expected: true,
+			vms:      map[types.VirtualMachinePowerState]int{types.VirtualMachinePowerStatePoweredOff: 3},
+		},
+		{
+			name:     "Stopped - Some vms powered on",
+			testFunc: "MachinesStopped",
+			expected: false,
+			vms: map[types.VirtualMachinePowerState]int{
+				types.VirtualMachinePowerStatePoweredOff: 3,
+				types.VirtualMachinePowerStatePoweredOn:  1,
+			},
+		},
+		{
+			name:     "Running - All vms powered on",
+			testFunc: "MachinesRunning",
+			expected: true,
+			vms:      map[types.VirtualMachinePowerState]int{types.VirtualMachinePowerStatePoweredOn: 3},
+		},
+		{
+			name:     "Running - Some vms suspended",
+			testFunc: "MachinesRunning",
+			expected: false,
+			vms: map[types.VirtualMachinePowerState]int{
+				types.VirtualMachinePowerStatePoweredOn: 3,
+				types.VirtualMachinePowerStateSuspended: 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			vsphereClient := mockvsphereclient.NewMockClient(ctrl)
+			setupVSphereClientVirtualMachines(vsphereClient, test.vms)
+			actuator := testVSphereActuator(vsphereClient)
+			var err error
+			var result bool
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, _, err = actuator.MachinesStopped(testClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, _, err = actuator.MachinesRunning(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func testVSphereActuator(vsphereClient vsphereclient.Client) *vsphereActuator {
+	return &vsphereActuator{
+		vsphereClientFn: func(context.Context, *hivev1.ClusterDeployment, client.Client, log.FieldLogger) (vsphereclient.Client, error) {
+			return vsphereClient, nil
+		},
+	}
+}
+
+func setupVSphereClientVirtualMachines(vsphereClient *mockvsphereclient.MockClient, states map[types.VirtualMachinePowerState]int) {
+	vms := []mo.VirtualMachine{}
+	for state, count := range states {
+		for i := 0; i < count; i++ {
+			vm := mo.VirtualMachine{
+				Runtime: types.VirtualMachineRuntimeInfo{PowerState: state},
+			}
+			vm.Name = fmt.Sprintf("%s-%d", state, i)
+			vms = append(vms, vm)
+		}
+	}
+	vsphereClient.EXPECT().ListVirtualMachines(gomock.Any(), "abcd1234").Times(1).Return(vms, nil)
+	vsphereClient.EXPECT().Close(gomock.Any()).Times(1)
+}
@@ -151,7 +151,7 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
 		}
 		if len(zones) == 0 {
-			return nil, false, fmt.Errorf("zero zones returned for region %s", cd.Spec.Platform.AWS.Region)
+			return nil, false, fmt.Errorf("zero zones returned for region %s", controllerutils.GetClusterRegion(cd))
 		}
 		computePool.Platform.AWS.Zones = zones
 	}
@@ -172,7 +172,7 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 
 	installerMachineSets, err := installaws.MachineSets(
 		cd.Spec.ClusterMetadata.InfraID,
-		cd.Spec.Platform.AWS.Region,
+		controllerutils.GetClusterRegion(cd),
 		subnets,
 		computePool,
 		pool.Spec.Name,
@@ -302,6 +302,7 @@ func (a *AWSActuator) updateProviderConfig(machineSet *machineapi.MachineSet, in
 			Values: []string{fmt.Sprintf("%s-worker-sg", infraID)},
 		}},
 	}}
+	providerConfig.InstanceType = awsInstanceTypeForMachineSet(pool, machineSet.Name)
 	if pool.Spec.Platform.AWS.SpotMarketOptions != nil {
 		providerConfig.SpotMarketOptions = &awsproviderv1beta1.SpotMarketOptions{
 			MaxPrice: pool.Spec.Platform.AWS.SpotMarketOptions.MaxPrice,
@@ -531,3 +532,79 @@ func (a *AWSActuator) validateSubnets(subnets map[string]ec2.Subnet, pool *hivev
 	}
 	return subnetsByAvailabilityZone, nil
 }
+
+// awsInstanceTypes returns the instance types that can be used for the machines of the MachinePool, in order of
+// preference.
+func awsInstanceTypes(pool *hivev1.MachinePool) []string {
+	return append([]string{pool.Spec.Platform.AWS.InstanceType}, pool.Spec.Platform.AWS.FallbackInstanceTypes...)
+}
+
+// awsInstanceTypeForMachineSet returns the instance type to use for the MachineSet. This is the instance type chosen
+// for the MachineSet in the status of the MachinePool when it is still one of the instance types of the MachinePool,
+// and the preferred instance type otherwise.
+func awsInstanceTypeForMachineSet(pool *hivev1.MachinePool, machineSetName string) string {
+	for _, ms := range pool.Status.MachineSets {
+		if ms.Name != machineSetName {
+			continue
+		}
+		for _, instanceType := range awsInstanceTypes(pool) {
+			if ms.InstanceType == instanceType {
+				return instanceType
+			}
+		}
+	}
+	return pool.Spec.Platform.AWS.InstanceType
+}
+
+// nextAWSInstanceType returns the instance type to fall back to from the given instance type. It returns an empty
+// string when there are no more instance types to fall back to.
+func nextAWSInstanceType(pool *hivev1.MachinePool, instanceType string) string {
+	instanceTypes := awsInstanceTypes(pool)
+	for i, t := range instanceTypes[:len(instanceTypes)-1] {
+		if t == instanceType {
+			return instanceTypes[i+1]
+		}
+	}
+	return ""
+}
+
+// getAWSMachineProviderSpec returns the AWSMachineProviderConfig of the MachineSet, decoding it if needed.
+func getAWSMachineProviderSpec(machineSet *machineapi.MachineSet, scheme *runtime.Scheme) (*awsproviderv1beta1.AWSMachineProviderConfig, error) {
+	providerSpec := machineSet.Spec.Template.Spec.ProviderSpec.Value
+	if providerSpec != nil {
+		if providerConfig, ok := providerSpec.Object.(*awsproviderv1beta1.AWSMachineProviderConfig); ok {
+			return providerConfig, nil
+		}
+	}
+	return decodeAWSMachineProviderSpec(providerSpec, scheme)
+}
+
+// updateAWSInstanceType sets the instance type of the remote MachineSet to the instance type of the generated
+// MachineSet. It returns true if the instance type of the remote MachineSet was changed.
+func updateAWSInstanceType(remoteMachineSet, generatedMachineSet *machineapi.MachineSet, scheme *runtime.Scheme) (bool, error) {
+	generatedProviderConfig, err := getAWSMachineProviderSpec(generatedMachineSet, scheme)
+	if err != nil {
+		return false, err
+	}
+	remoteProviderConfig, err := getAWSMachineProviderSpec(remoteMachineSet, scheme)
+	if err != nil {
+		return false, err
+	}
+	if remoteProviderConfig.InstanceType == generatedProviderConfig.InstanceType {
+		return false, nil
+	}
+	remoteProviderConfig.InstanceType = generatedProviderConfig.InstanceType
+	remoteMachineSet.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Object: remoteProviderConfig}
+	return true, nil
+}
+
+// isInsufficientInstanceCapacityError returns true if the error reason or message is for AWS not having sufficient
+// capacity of an instance type.
+func isInsufficientInstanceCapacityError(reason, message *string) bool {
+	for _, s := range []*string{reason, message} {
+		if s != nil && strings.Contains(*s, "InsufficientInstanceCapacity") {
+			return true
+		}
+	}
+	return false
+}
@@ -47,6 +47,7 @@ func TestAWSActuator(t *testing.T) {
 		expectedErr                  bool
 		expectedCondition            *hivev1.MachinePoolCondition
 		expectedKMSKey               string
+		expectedInstanceTypes        map[string]string
 	}{
 		{
 			name:              "generate single machineset for single zone",
@@ -392,6 +393,32 @@ func TestAWSActuator(t *testing.T) {
 				Reason: "UnsupportedSpotMarketOptions",
 			},
 		},
+		{
+			name:              "generate machinesets with fallback instance types",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2", "zone3"}
+					pool.Spec.Platform.AWS.FallbackInstanceTypes = []string{"fallback-1", "fallback-2"}
+					pool.Status.MachineSets = []hivev1.MachineSetStatus{
+						{Name: generateAWSMachineSetName("zone1"), InstanceType: testInstanceType},
+						{Name: generateAWSMachineSetName("zone2"), InstanceType: "fallback-2"},
+						{Name: generateAWSMachineSetName("zone3"), InstanceType: "unknown"},
+					}
+					return pool
+				}(),
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 1,
+				generateAWSMachineSetName("zone2"): 1,
+				generateAWSMachineSetName("zone3"): 1,
+			},
+			expectedInstanceTypes: map[string]string{
+				generateAWSMachineSetName("zone2"): "fallback-2",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -425,7 +452,7 @@ func TestAWSActuator(t *testing.T) {
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
 			} else {
-				validateAWSMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas, test.expectedSubnetIDInMachineSet, test.expectedKMSKey)
+				validateAWSMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas, test.expectedSubnetIDInMachineSet, test.expectedKMSKey, test.expectedInstanceTypes)
 			}
 			if test.expectedCondition != nil {
 				cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, test.expectedCondition.Type)
@@ -475,7 +502,7 @@ func TestGetAWSAMIID(t *testing.T) {
 	}
 }
 
-func validateAWSMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64, expectedSubnetID bool, expectedKMSKey string) {
+func validateAWSMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64, expectedSubnetID bool, expectedKMSKey string, expectedInstanceTypes map[string]string) {
 	assert.Equal(t, len(expectedMSReplicas), len(mSets), "different number of machine sets generated than expected")
 
 	for _, ms := range mSets {
@@ -487,7 +514,11 @@ func validateAWSMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expect
 		awsProvider, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*awsprovider.AWSMachineProviderConfig)
 		assert.True(t, ok, "failed to convert to AWSMachineProviderConfig")
 
-		assert.Equal(t, testInstanceType, awsProvider.InstanceType, "unexpected instance type")
+		expectedInstanceType := testInstanceType
+		if instanceType, ok := expectedInstanceTypes[ms.Name]; ok {
+			expectedInstanceType = instanceType
+		}
+		assert.Equal(t, expectedInstanceType, awsProvider.InstanceType, "unexpected instance type")
 
 		if assert.NotNil(t, awsProvider.AMI.ID, "missing AMI ID") {
 			assert.Equal(t, testAMI, *awsProvider.AMI.ID, "unexpected AMI ID")
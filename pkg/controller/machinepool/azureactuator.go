@@ -18,6 +18,7 @@ import (
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 // AzureActuator encapsulates the pieces necessary to be able to generate
@@ -59,7 +60,7 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 	ic := &installertypes.InstallConfig{
 		Platform: installertypes.Platform{
 			Azure: &installertypesazure.Platform{
-				Region: cd.Spec.Platform.Azure.Region,
+				Region: controllerutils.GetClusterRegion(cd),
 			},
 		},
 	}
@@ -74,12 +75,12 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 	}
 
 	if len(computePool.Platform.Azure.Zones) == 0 {
-		zones, err := a.getZones(cd.Spec.Platform.Azure.Region, pool.Spec.Platform.Azure.InstanceType)
+		zones, err := a.getZones(controllerutils.GetClusterRegion(cd), pool.Spec.Platform.Azure.InstanceType)
 		if err != nil {
 			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
 		}
 		if len(zones) == 0 {
-			return nil, false, fmt.Errorf("zero zones returned for region %s", cd.Spec.Platform.Azure.Region)
+			return nil, false, fmt.Errorf("zero zones returned for region %s", controllerutils.GetClusterRegion(cd))
 		}
 		computePool.Platform.Azure.Zones = zones
 	}
@@ -95,7 +96,34 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	for _, ms := range installerMachineSets {
+		updateAzureProviderSpec(ms, pool, cd)
+	}
+
+	return installerMachineSets, true, nil
+}
+
+// updateAzureProviderSpec adds the settings of the MachinePool and the user tags of the cluster that the installer does
+// not support to the AzureMachineProviderSpec of the MachineSet.
+func updateAzureProviderSpec(machineSet *machineapi.MachineSet, pool *hivev1.MachinePool, cd *hivev1.ClusterDeployment) {
+	providerSpec := machineSet.Spec.Template.Spec.ProviderSpec.Value.Object.(*machineapi.AzureMachineProviderSpec)
+	if userTags := cd.Spec.Platform.Azure.UserTags; len(userTags) > 0 {
+		if providerSpec.Tags == nil {
+			providerSpec.Tags = make(map[string]string, len(userTags))
+		}
+		for k, v := range userTags {
+			providerSpec.Tags[k] = v
+		}
+	}
+	if spotVMOptions := pool.Spec.Platform.Azure.SpotVMOptions; spotVMOptions != nil {
+		providerSpec.SpotVMOptions = &machineapi.SpotVMOptions{
+			MaxPrice: spotVMOptions.MaxPrice,
+		}
+	}
 }
 
 func (a *AzureActuator) getZones(region string, instanceType string) ([]string, error) {
@@ -9,6 +9,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 
 	machineapi "github.com/openshift/api/machine/v1beta1"
@@ -98,6 +99,38 @@ func TestAzureActuator(t *testing.T) {
 				generateAzureMachineSetName("zone5"): 0,
 			},
 		},
+		{
+			name:              "generate spot machinesets",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.Zones = []string{"zone1"}
+				maxPrice := resource.MustParse("0.5")
+				p.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: &maxPrice}
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+		},
+		{
+			name: "generate machinesets with user tags",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testAzureClusterDeployment()
+				cd.Spec.Platform.Azure.UserTags = map[string]string{"team": "hive"}
+				return cd
+			}(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.Zones = []string{"zone1"}
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+		},
 		{
 			name:              "list zones returns zero",
 			clusterDeployment: testAzureClusterDeployment(),
@@ -130,13 +163,13 @@ func TestAzureActuator(t *testing.T) {
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
 			} else {
-				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
+				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas, test.pool, test.clusterDeployment)
 			}
 		})
 	}
 }
 
-func validateAzureMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64) {
+func validateAzureMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64, pool *hivev1.MachinePool, cd *hivev1.ClusterDeployment) {
 	assert.Equal(t, len(expectedMSReplicas), len(mSets), "different number of machine sets generated than expected")
 
 	for _, ms := range mSets {
@@ -148,6 +181,16 @@ func validateAzureMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expe
 		azureProvider, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*machineapi.AzureMachineProviderSpec)
 		if assert.True(t, ok, "failed to convert to azureProviderSpec") {
 			assert.Equal(t, testInstanceType, azureProvider.VMSize, "unexpected instance type")
+			if spotVMOptions := pool.Spec.Platform.Azure.SpotVMOptions; spotVMOptions != nil {
+				if assert.NotNil(t, azureProvider.SpotVMOptions, "expected spot VM options") {
+					assert.Equal(t, spotVMOptions.MaxPrice, azureProvider.SpotVMOptions.MaxPrice, "unexpected max price")
+				}
+			} else {
+				assert.Nil(t, azureProvider.SpotVMOptions, "unexpected spot VM options")
+			}
+			for k, v := range cd.Spec.Platform.Azure.UserTags {
+				assert.Equal(t, v, azureProvider.Tags[k], "unexpected tag %s", k)
+			}
 		}
 	}
 }
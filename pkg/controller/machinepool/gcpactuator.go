@@ -167,7 +167,7 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	ic := &installertypes.InstallConfig{
 		Platform: installertypes.Platform{
 			GCP: &installertypesgcp.Platform{
-				Region:        cd.Spec.Platform.GCP.Region,
+				Region:        controllerutils.GetClusterRegion(cd),
 				ProjectID:     a.projectID,
 				ComputeSubnet: a.subnet,
 				Network:       a.network,
@@ -211,12 +211,12 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	}
 
 	if len(computePool.Platform.GCP.Zones) == 0 {
-		zones, err := a.getZones(cd.Spec.Platform.GCP.Region)
+		zones, err := a.getZones(controllerutils.GetClusterRegion(cd))
 		if err != nil {
 			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
 		}
 		if len(zones) == 0 {
-			return nil, false, fmt.Errorf("zero zones returned for region %s", cd.Spec.Platform.GCP.Region)
+			return nil, false, fmt.Errorf("zero zones returned for region %s", controllerutils.GetClusterRegion(cd))
 		}
 		computePool.Platform.GCP.Zones = zones
 	}
@@ -230,7 +230,38 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	for _, ms := range installerMachineSets {
+		updateGCPProviderSpec(ms, pool, cd)
+	}
+
+	return installerMachineSets, true, nil
+}
+
+// updateGCPProviderSpec adds the settings of the MachinePool and the user labels of the cluster that the installer does
+// not support to the GCPMachineProviderSpec of the MachineSet.
+func updateGCPProviderSpec(machineSet *machineapi.MachineSet, pool *hivev1.MachinePool, cd *hivev1.ClusterDeployment) {
+	providerSpec := machineSet.Spec.Template.Spec.ProviderSpec.Value.Object.(*machineapi.GCPMachineProviderSpec)
+	providerSpec.Preemptible = pool.Spec.Platform.GCP.Preemptible
+	if userLabels := cd.Spec.Platform.GCP.UserLabels; len(userLabels) > 0 {
+		providerSpec.Labels = addGCPLabels(providerSpec.Labels, userLabels)
+		for _, disk := range providerSpec.Disks {
+			disk.Labels = addGCPLabels(disk.Labels, userLabels)
+		}
+	}
+}
+
+func addGCPLabels(labels, userLabels map[string]string) map[string]string {
+	if labels == nil {
+		labels = make(map[string]string, len(userLabels))
+	}
+	for k, v := range userLabels {
+		labels[k] = v
+	}
+	return labels
 }
 
 func (a *GCPActuator) getZones(region string) ([]string, error) {
@@ -45,6 +45,8 @@ func TestGCPActuator(t *testing.T) {
 		existing                        []runtime.Object
 		mockGCPClient                   func(*mockgcp.MockClient)
 		setupPendingCreationExpectation bool
+		userLabels                      map[string]string
+		clusterRegion                   string
 
 		expectedMachineSetReplicas map[string]int64
 		expectedErr                bool
@@ -170,6 +172,42 @@ func TestGCPActuator(t *testing.T) {
 				generateGCPMachineSetName("worker", "zone1"): 3,
 			},
 		},
+		{
+			name: "generate preemptible machinesets",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Preemptible = true
+				return pool
+			}(),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1"}, testRegion)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+		},
+		{
+			name:       "generate machinesets with user labels",
+			pool:       testGCPPool(testPoolName),
+			userLabels: map[string]string{"team": "hive"},
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1"}, testRegion)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+		},
+		{
+			name:          "generate machinesets in region changed by install config override",
+			pool:          testGCPPool(testPoolName),
+			clusterRegion: "other-region",
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1"}, "other-region")
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -181,6 +219,10 @@ func TestGCPActuator(t *testing.T) {
 
 			gClient := mockgcp.NewMockClient(mockCtrl)
 			clusterDeployment := testGCPClusterDeployment(testName, testInfraID)
+			clusterDeployment.Spec.Platform.GCP.UserLabels = test.userLabels
+			if test.clusterRegion != "" {
+				clusterDeployment.Status.Platform = &hivev1.PlatformStatus{Region: test.clusterRegion}
+			}
 
 			logger := log.WithField("actuator", "gcpactuator")
 			controllerExpectations := controllerutils.NewExpectations(logger)
@@ -228,6 +270,7 @@ func TestGCPActuator(t *testing.T) {
 					assert.True(t, ok, "failed to convert to gcpProviderSpec")
 
 					assert.Equal(t, testInstanceType, gcpProvider.MachineType, "unexpected instance type")
+					assert.Equal(t, controllerutils.GetClusterRegion(clusterDeployment), gcpProvider.Region, "unexpected region")
 
 					// Ensure network details are propagated correctly.
 					assert.Equal(t, ga.network, gcpProvider.NetworkInterfaces[0].Network)
@@ -255,6 +298,13 @@ func TestGCPActuator(t *testing.T) {
 						assert.Equal(t, encKey.KMSKey.Location, gcpProvider.Disks[0].EncryptionKey.KMSKey.Location)
 					}
 
+					assert.Equal(t, test.pool.Spec.Platform.GCP.Preemptible, gcpProvider.Preemptible, "unexpected preemptible")
+
+					for k, v := range test.userLabels {
+						assert.Equal(t, v, gcpProvider.Labels[k], "unexpected label %s", k)
+						assert.Equal(t, v, gcpProvider.Disks[0].Labels[k], "unexpected disk label %s", k)
+					}
+
 				}
 			}
 		})
@@ -338,6 +338,13 @@ func (r *ReconcileMachinePool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	if pool.Spec.RollingUpdate != nil && pool.DeletionTimestamp == nil {
+		if err := r.replaceOutdatedMachines(pool, generatedMachineSets, machineSets, remoteClusterAPIClient, logger); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not replaceOutdatedMachines")
+			return reconcile.Result{}, err
+		}
+	}
+
 	if err := r.syncMachineAutoscalers(pool, cd, machineSets, remoteClusterAPIClient, logger); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineAutoscalers")
 		return reconcile.Result{}, err
@@ -526,6 +533,7 @@ func (r *ReconcileMachinePool) syncMachineSets(
 	machineSetsToDelete := []*machineapi.MachineSet{}
 	machineSetsToCreate := []*machineapi.MachineSet{}
 	machineSetsToUpdate := []*machineapi.MachineSet{}
+	machineSetsWithNewInstanceType := []*machineapi.MachineSet{}
 
 	// Find MachineSets that need updating/creating
 	for i, ms := range generatedMachineSets {
@@ -538,13 +546,35 @@ func (r *ReconcileMachinePool) syncMachineSets(
 				resourcemerge.EnsureObjectMeta(&objectMetaModified, &rMS.ObjectMeta, ms.ObjectMeta)
 				msLog := logger.WithField("machineset", rMS.Name)
 
+				// Update the providerSpec when the outdated machines will be replaced by a rolling update.
+				if pool.Spec.RollingUpdate != nil {
+					providerSpecModified, err := updateMachineSetProviderSpec(&rMS, ms)
+					if err != nil {
+						msLog.WithError(err).Error("unable to update providerSpec")
+						return nil, err
+					}
+					if providerSpecModified {
+						msLog.Info("providerSpec out of sync")
+						objectModified = true
+					}
+				}
+
 				if pool.Spec.Autoscaling == nil {
-					if *rMS.Spec.Replicas != *ms.Spec.Replicas {
+					replicas := *ms.Spec.Replicas
+					if pool.Spec.RollingUpdate != nil {
+						surge, err := rollingUpdateSurge(pool, &rMS, replicas, remoteClusterAPIClient, msLog)
+						if err != nil {
+							msLog.WithError(err).Error("unable to determine rolling update surge")
+							return nil, err
+						}
+						replicas += surge
+					}
+					if *rMS.Spec.Replicas != replicas {
 						msLog.WithFields(log.Fields{
-							"desired":  *ms.Spec.Replicas,
+							"desired":  replicas,
 							"observed": *rMS.Spec.Replicas,
 						}).Info("replicas out of sync")
-						rMS.Spec.Replicas = ms.Spec.Replicas
+						rMS.Spec.Replicas = &replicas
 						objectModified = true
 					}
 				} else {
@@ -587,6 +617,20 @@ func (r *ReconcileMachinePool) syncMachineSets(
 					objectModified = true
 				}
 
+				// Update if the machineset has fallen back to another instance type.
+				if pool.Spec.Platform.AWS != nil && len(pool.Spec.Platform.AWS.FallbackInstanceTypes) > 0 {
+					instanceTypeModified, err := updateAWSInstanceType(&rMS, ms, r.scheme)
+					if err != nil {
+						msLog.WithError(err).Error("unable to update instance type")
+						return nil, err
+					}
+					if instanceTypeModified {
+						msLog.Info("instance type out of sync")
+						objectModified = true
+						machineSetsWithNewInstanceType = append(machineSetsWithNewInstanceType, &rMS)
+					}
+				}
+
 				if objectMetaModified || objectModified {
 					rMS.Generation++
 					machineSetsToUpdate = append(machineSetsToUpdate, &rMS)
@@ -598,6 +642,12 @@ func (r *ReconcileMachinePool) syncMachineSets(
 		}
 
 		if !found {
+			if pool.Spec.RollingUpdate != nil {
+				if err := setGeneratedMachineSpecHash(ms); err != nil {
+					logger.WithField("machineset", ms.Name).WithError(err).Error("unable to set providerSpec hash")
+					return nil, err
+				}
+			}
 			machineSetsToCreate = append(machineSetsToCreate, ms)
 			result[i] = ms
 		}
@@ -646,6 +696,14 @@ func (r *ReconcileMachinePool) syncMachineSets(
 		}
 	}
 
+	// Machines that failed for lack of capacity of the previous instance type are not replaced by the machineset,
+	// so delete them to have the machineset create machines of the new instance type.
+	for _, ms := range machineSetsWithNewInstanceType {
+		if err := deleteInsufficientInstanceCapacityMachines(remoteClusterAPIClient, ms, logger); err != nil {
+			return nil, err
+		}
+	}
+
 	logger.Info("done reconciling machine sets for machine pool")
 	return result, nil
 }
@@ -851,6 +909,9 @@ func (r *ReconcileMachinePool) updatePoolStatusForMachineSets(
 
 	pool.Status.MachineSets = make([]hivev1.MachineSetStatus, len(machineSets))
 	pool.Status.Replicas = 0
+	pool.Status.UpdatedReplicas = 0
+	fallback := false
+	rollingUpdate := false
 	for i, ms := range machineSets {
 		var min, max int32
 		if pool.Spec.Autoscaling == nil {
@@ -868,11 +929,39 @@ func (r *ReconcileMachinePool) updatePoolStatusForMachineSets(
 			ErrorReason:   (*string)(ms.Status.ErrorReason),
 			ErrorMessage:  ms.Status.ErrorMessage,
 		}
+		if pool.Spec.Platform.AWS != nil {
+			if providerConfig, err := getAWSMachineProviderSpec(ms, r.scheme); err != nil {
+				logger.WithField("machineset", ms.Name).WithError(err).Warn("could not get instance type of machineset")
+			} else {
+				s.InstanceType = providerConfig.InstanceType
+			}
+		}
+		if pool.Spec.RollingUpdate != nil {
+			if rollout, err := getMachineSetRollout(remoteClusterAPIClient, ms, logger.WithField("machineset", ms.Name)); err != nil {
+				logger.WithField("machineset", ms.Name).WithError(err).Warn("could not get rollout of machineset")
+			} else {
+				s.UpdatedReplicas = rollout.updated
+				pool.Status.UpdatedReplicas += rollout.updated
+				if len(rollout.outdated) > 0 {
+					rollingUpdate = true
+				}
+			}
+		}
 		if s.Replicas != s.ReadyReplicas && s.ErrorReason == nil {
 			r, m := summarizeMachinesError(remoteClusterAPIClient, ms, logger)
 			s.ErrorReason = &r
 			s.ErrorMessage = &m
 		}
+		if pool.Spec.Platform.AWS != nil && isInsufficientInstanceCapacityError(s.ErrorReason, s.ErrorMessage) {
+			if next := nextAWSInstanceType(pool, s.InstanceType); next != "" {
+				logger.WithField("machineset", ms.Name).
+					WithField("instanceType", s.InstanceType).
+					WithField("nextInstanceType", next).
+					Info("falling back to next instance type due to insufficient instance capacity")
+				s.InstanceType = next
+				fallback = true
+			}
+		}
 
 		pool.Status.MachineSets[i] = s
 		pool.Status.Replicas += *ms.Spec.Replicas
@@ -888,13 +977,46 @@ func (r *ReconcileMachinePool) updatePoolStatusForMachineSets(
 			break
 		}
 	}
+	// Machines are not watched, so requeue to continue replacing the outdated machines.
+	if rollingUpdate {
+		requeueAfter = rollingUpdateRequeueInterval
+	}
+	// Requeue right away to regenerate the machinesets that are falling back to another instance type.
+	if fallback {
+		requeueAfter = 0
+	}
+	result := reconcile.Result{Requeue: fallback, RequeueAfter: requeueAfter}
 
 	if (len(origPool.Status.MachineSets) == 0 && len(pool.Status.MachineSets) == 0) ||
 		reflect.DeepEqual(origPool.Status, pool.Status) {
-		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+		return result, nil
+	}
+
+	return result, errors.Wrap(r.Status().Update(context.Background(), pool), "failed to update pool status")
+}
+
+// deleteInsufficientInstanceCapacityMachines deletes the machines of the machineset that failed because AWS did not
+// have sufficient capacity of the instance type.
+func deleteInsufficientInstanceCapacityMachines(remoteClusterAPIClient client.Client, machineSet *machineapi.MachineSet, logger log.FieldLogger) error {
+	msLog := logger.WithField("machineset", machineSet.Name)
+
+	machines, err := listMachineSetMachines(remoteClusterAPIClient, machineSet, msLog)
+	if err != nil {
+		return err
 	}
 
-	return reconcile.Result{RequeueAfter: requeueAfter}, errors.Wrap(r.Status().Update(context.Background(), pool), "failed to update pool status")
+	for i, m := range machines {
+		if m.DeletionTimestamp != nil ||
+			!isInsufficientInstanceCapacityError((*string)(m.Status.ErrorReason), m.Status.ErrorMessage) {
+			continue
+		}
+		msLog.WithField("machine", m.Name).Info("deleting machine that failed due to insufficient instance capacity")
+		if err := remoteClusterAPIClient.Delete(context.TODO(), &machines[i]); err != nil && !apierrors.IsNotFound(err) {
+			msLog.WithField("machine", m.Name).WithError(err).Error("unable to delete machine")
+			return err
+		}
+	}
+	return nil
 }
 
 // summarizeMachinesError returns reason and message for error state of machineSets by
@@ -926,6 +1048,10 @@ func summarizeMachinesError(remoteClusterAPIClient client.Client, machineSet *ma
 
 	var errs []errSummary
 	for _, m := range list.Items {
+		if m.DeletionTimestamp != nil {
+			// Machines that are being deleted are being replaced.
+			continue
+		}
 		if m.Status.ErrorReason == nil &&
 			m.Status.ErrorMessage == nil {
 			continue
@@ -977,7 +1103,7 @@ func (r *ReconcileMachinePool) createActuator(
 				Role: cd.Spec.Platform.AWS.CredentialsAssumeRole,
 			},
 		}
-		return NewAWSActuator(r.Client, creds, cd.Spec.Platform.AWS.Region, pool, masterMachine, r.scheme, logger)
+		return NewAWSActuator(r.Client, creds, controllerutils.GetClusterRegion(cd), pool, masterMachine, r.scheme, logger)
 	case cd.Spec.Platform.GCP != nil:
 		creds := &corev1.Secret{}
 		if err := r.Get(
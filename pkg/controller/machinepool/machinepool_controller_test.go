@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 
@@ -19,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
 	awsproviderapis "sigs.k8s.io/cluster-api-provider-aws/pkg/apis"
@@ -119,6 +121,12 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 		expectedRemoteMachineSets        []*machineapi.MachineSet
 		expectedRemoteMachineAutoscalers []autoscalingv1beta1.MachineAutoscaler
 		expectedRemoteClusterAutoscalers []autoscalingv1.ClusterAutoscaler
+		// expectedRemoteMachines is ignored if nil
+		expectedRemoteMachines []string
+		// expectedInstanceTypes is ignored if nil
+		expectedInstanceTypes   map[string]string
+		expectedCordonedNodes   []string
+		expectedUpdatedReplicas int32
 	}{
 		{
 			name: "Cluster not installed yet",
@@ -648,6 +656,150 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				*testClusterAutoscaler("1"),
 			},
 		},
+		{
+			name:              "Fall back to next instance type",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       withFallbackInstanceTypes(testMachinePool(), "fallback-1", "fallback-2"),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0), testInstanceType),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0), testInstanceType),
+				withInsufficientInstanceCapacity(testMachineSetMachine("machine-1", "worker", "foo-12345-worker-us-east-1b")),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0), testInstanceType),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0), testInstanceType),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0), testInstanceType),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0), testInstanceType),
+			},
+			expectedRemoteMachines: []string{"master1", "machine-1"},
+			expectedInstanceTypes: map[string]string{
+				"foo-12345-worker-us-east-1a": testInstanceType,
+				"foo-12345-worker-us-east-1b": "fallback-1",
+			},
+		},
+		{
+			name:              "Switch machine set to fallback instance type",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				pool := withFallbackInstanceTypes(testMachinePool(), "fallback-1", "fallback-2")
+				pool.Status.MachineSets = []hivev1.MachineSetStatus{
+					{Name: "foo-12345-worker-us-east-1a", InstanceType: testInstanceType},
+					{Name: "foo-12345-worker-us-east-1b", InstanceType: "fallback-1"},
+				}
+				return pool
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0), testInstanceType),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0), testInstanceType),
+				testMachineSetMachine("machine-1", "worker", "foo-12345-worker-us-east-1b"),
+				withInsufficientInstanceCapacity(testMachineSetMachine("machine-2", "worker", "foo-12345-worker-us-east-1b")),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0), testInstanceType),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0), "fallback-1"),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0), testInstanceType),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 1), "fallback-1"),
+			},
+			expectedRemoteMachines: []string{"master1", "machine-1"},
+			expectedInstanceTypes: map[string]string{
+				"foo-12345-worker-us-east-1a": testInstanceType,
+				"foo-12345-worker-us-east-1b": "fallback-1",
+			},
+		},
+		{
+			name:              "No more instance types to fall back to",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				pool := withFallbackInstanceTypes(testMachinePool(), "fallback-1")
+				pool.Status.MachineSets = []hivev1.MachineSetStatus{
+					{Name: "foo-12345-worker-us-east-1a", InstanceType: "fallback-1"},
+				}
+				return pool
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0), "fallback-1"),
+				withInsufficientInstanceCapacity(testMachineSetMachine("machine-1", "worker", "foo-12345-worker-us-east-1a")),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0), "fallback-1"),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0), "fallback-1"),
+			},
+			expectedRemoteMachines: []string{"master1", "machine-1"},
+			expectedInstanceTypes: map[string]string{
+				"foo-12345-worker-us-east-1a": "fallback-1",
+			},
+		},
+		{
+			name:              "Rolling update of changed providerSpec",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       withRollingUpdate(testMachinePool(), nil, nil),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0), testInstanceType),
+				withNode(testMachineSetMachine("machine-1", "worker", "foo-12345-worker-us-east-1a"), "node-1"),
+				testNode("node-1"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0), "new-type"),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 2, 1), "new-type"),
+			},
+			expectedRemoteMachines: []string{"master1", "machine-1"},
+		},
+		{
+			name:              "Rolling update replaces outdated machine once replacement is available",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       withRollingUpdate(testMachinePool(), nil, nil),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withMachineSpecHash(withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 2, 0), "new-type")),
+				withNode(testMachineSetMachine("machine-1", "worker", "foo-12345-worker-us-east-1a"), "node-1"),
+				withMachineSpecHashOf(
+					withNode(testMachineSetMachine("machine-2", "worker", "foo-12345-worker-us-east-1a"), "node-2"),
+					withMachineSpecHash(withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0), "new-type")),
+				),
+				testNode("node-1"),
+				testNode("node-2"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0), "new-type"),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 2, 0), "new-type"),
+			},
+			expectedRemoteMachines:  []string{"master1", "machine-2"},
+			expectedCordonedNodes:   []string{"node-1"},
+			expectedUpdatedReplicas: 1,
+		},
+		{
+			name:              "Rolling update replaces unavailable outdated machines first",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       withRollingUpdate(testMachinePool(), intOrStringPtr(0), intOrStringPtr(1)),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withMachineSpecHash(withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 2, 0), "new-type")),
+				withNode(testMachineSetMachine("machine-1", "worker", "foo-12345-worker-us-east-1a"), "node-1"),
+				testMachineSetMachine("machine-2", "worker", "foo-12345-worker-us-east-1a"),
+				testNode("node-1"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 2, 0), "new-type"),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withInstanceType(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 2, 0), "new-type"),
+			},
+			expectedRemoteMachines: []string{"master1", "machine-1"},
+		},
 	}
 
 	for _, test := range tests {
@@ -755,6 +907,7 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 							log.Debugf("expected AWS: %v", printAWSMachineProviderConfig(eAWSProviderSpec))
 							assert.NotNil(t, eAWSProviderSpec)
 							assert.Equal(t, eAWSProviderSpec.AMI, rAWSProviderSpec.AMI, "%s AMI does not match", eMS.Name)
+							assert.Equal(t, eAWSProviderSpec.InstanceType, rAWSProviderSpec.InstanceType, "%s instance type does not match", eMS.Name)
 
 						}
 					}
@@ -782,6 +935,40 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 			if rCAL, err := getRCAL(remoteFakeClient); assert.NoError(t, err, "error getting cluster autoscalers") {
 				assert.ElementsMatch(t, test.expectedRemoteClusterAutoscalers, rCAL.Items, "unexpected remote cluster autoscalers")
 			}
+
+			if test.expectedRemoteMachines != nil {
+				rML := &machineapi.MachineList{}
+				if err := remoteFakeClient.List(context.TODO(), rML); assert.NoError(t, err, "error getting machines") {
+					names := make([]string, len(rML.Items))
+					for i, m := range rML.Items {
+						names[i] = m.Name
+					}
+					assert.ElementsMatch(t, test.expectedRemoteMachines, names, "unexpected remote machines")
+				}
+			}
+
+			if test.expectedInstanceTypes != nil {
+				instanceTypes := map[string]string{}
+				for _, ms := range pool.Status.MachineSets {
+					instanceTypes[ms.Name] = ms.InstanceType
+				}
+				assert.Equal(t, test.expectedInstanceTypes, instanceTypes, "unexpected instance types in machine set statuses")
+			}
+
+			nodeList := &corev1.NodeList{}
+			if err := remoteFakeClient.List(context.TODO(), nodeList); assert.NoError(t, err, "error getting nodes") {
+				cordoned := []string{}
+				for _, node := range nodeList.Items {
+					if node.Spec.Unschedulable {
+						cordoned = append(cordoned, node.Name)
+					}
+				}
+				assert.ElementsMatch(t, test.expectedCordonedNodes, cordoned, "unexpected cordoned nodes")
+			}
+
+			if pool != nil {
+				assert.Equal(t, test.expectedUpdatedReplicas, pool.Status.UpdatedReplicas, "unexpected updated replicas")
+			}
 		})
 	}
 }
@@ -824,6 +1011,20 @@ func Test_summarizeMachinesError(t *testing.T) {
 
 		reason:  "GoneNotComingBack",
 		message: "The machine is not found",
+	}, {
+		name: "failed machine being deleted",
+		existing: []runtime.Object{
+			testMachineSetMachine("machine-1", "worker", testName),
+			func() *machineapi.Machine {
+				m := testMachineSetMachine("machine-2", "worker", testName)
+				m.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				m.Finalizers = []string{"test-finalizer"}
+				m.Status.ErrorReason = (*machineapi.MachineStatusError)(pointer.StringPtr("GoneNotComingBack"))
+				m.Status.ErrorMessage = pointer.StringPtr("The machine is not found")
+				return m
+			}(),
+			testMachineSet(testName, "worker", false, 3, 0),
+		},
 	}, {
 		name: "more than one machine failed",
 		existing: []runtime.Object{
@@ -1043,6 +1244,70 @@ func testMachineSet(name string, machineType string, unstompedAnnotation bool, r
 	return &ms
 }
 
+func withInstanceType(ms *machineapi.MachineSet, instanceType string) *machineapi.MachineSet {
+	providerSpec := testAWSProviderSpec()
+	providerSpec.InstanceType = instanceType
+	rawProviderSpec, err := encodeAWSMachineProviderSpec(providerSpec, scheme.Scheme)
+	if err != nil {
+		log.WithError(err).Fatal("error encoding AWS machine provider spec")
+	}
+	ms.Spec.Template.Spec.ProviderSpec.Value = rawProviderSpec
+	return ms
+}
+
+func withInsufficientInstanceCapacity(m *machineapi.Machine) *machineapi.Machine {
+	m.Status.ErrorReason = (*machineapi.MachineStatusError)(pointer.StringPtr("InvalidConfiguration"))
+	m.Status.ErrorMessage = pointer.StringPtr("error launching instance: InsufficientInstanceCapacity: We currently do not have sufficient capacity in the Availability Zone you requested")
+	return m
+}
+
+func withFallbackInstanceTypes(pool *hivev1.MachinePool, instanceTypes ...string) *hivev1.MachinePool {
+	pool.Spec.Platform.AWS.FallbackInstanceTypes = instanceTypes
+	return pool
+}
+
+func withRollingUpdate(pool *hivev1.MachinePool, maxSurge, maxUnavailable *intstr.IntOrString) *hivev1.MachinePool {
+	pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{
+		MaxSurge:       maxSurge,
+		MaxUnavailable: maxUnavailable,
+	}
+	return pool
+}
+
+func intOrStringPtr(i int) *intstr.IntOrString {
+	v := intstr.FromInt(i)
+	return &v
+}
+
+func withMachineSpecHash(ms *machineapi.MachineSet) *machineapi.MachineSet {
+	if err := setGeneratedMachineSpecHash(ms); err != nil {
+		log.WithError(err).Fatal("error setting machine spec hash")
+	}
+	return ms
+}
+
+func withMachineSpecHashOf(m *machineapi.Machine, ms *machineapi.MachineSet) *machineapi.Machine {
+	if m.Annotations == nil {
+		m.Annotations = map[string]string{}
+	}
+	m.Annotations[machineSpecHashAnnotation] = ms.Spec.Template.Annotations[machineSpecHashAnnotation]
+	return m
+}
+
+func withNode(m *machineapi.Machine, nodeName string) *machineapi.Machine {
+	m.Status.Phase = pointer.StringPtr(machinePhaseRunning)
+	m.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: nodeName}
+	return m
+}
+
+func testNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
 func testMachineAutoscaler(name string, resourceVersion string, min, max int) *autoscalingv1beta1.MachineAutoscaler {
 	return &autoscalingv1beta1.MachineAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,310 @@
+package machinepool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// machineSpecHashAnnotation is set on the machine template of the machinesets of machine pools with a rolling
+	// update strategy to the hash of the providerSpec. The machines created by the machineset inherit the annotation,
+	// which tells apart the machines that were created with a previous providerSpec.
+	machineSpecHashAnnotation = "hive.openshift.io/machine-spec-hash"
+
+	// machinePhaseRunning is the phase of a machine whose node has joined the cluster.
+	machinePhaseRunning = "Running"
+
+	// rollingUpdateRequeueInterval is how often the progress of a rolling update is checked.
+	rollingUpdateRequeueInterval = time.Minute
+)
+
+// machineSetRollout is the state of the replacement of the outdated machines of a machineset.
+type machineSetRollout struct {
+	// outdated are the machines that were created with a previous providerSpec of the machineset.
+	outdated []*machineapi.Machine
+	// updated is the number of machines that were created with the current providerSpec of the machineset.
+	updated int32
+	// available is the number of running machines, outdated or not.
+	available int32
+}
+
+// updateMachineSetProviderSpec updates the providerSpec of the remote machineset to the providerSpec of the generated
+// machineset, and records the hash of the providerSpec on the machine templates of both. It returns whether the
+// remote machineset was modified.
+func updateMachineSetProviderSpec(rMS, ms *machineapi.MachineSet) (bool, error) {
+	providerSpec, err := providerSpecJSON(ms)
+	if err != nil {
+		return false, err
+	}
+	hash := machineSpecHash(providerSpec)
+	setMachineSpecHash(ms, hash)
+
+	switch remoteHash := rMS.Spec.Template.Annotations[machineSpecHashAnnotation]; remoteHash {
+	case hash:
+		return false, nil
+	case "":
+		// The machineset was created before the machine pool had a rolling update strategy. Its machines only become
+		// outdated once its providerSpec changes.
+		remoteProviderSpec, err := providerSpecJSON(rMS)
+		if err != nil {
+			return false, err
+		}
+		if equal, err := jsonEqual(providerSpec, remoteProviderSpec); err != nil || equal {
+			return false, err
+		}
+	}
+
+	rMS.Spec.Template.Spec.ProviderSpec = *ms.Spec.Template.Spec.ProviderSpec.DeepCopy()
+	setMachineSpecHash(rMS, hash)
+	return true, nil
+}
+
+// setGeneratedMachineSpecHash records the hash of the providerSpec on the machine template of the generated machineset.
+func setGeneratedMachineSpecHash(ms *machineapi.MachineSet) error {
+	providerSpec, err := providerSpecJSON(ms)
+	if err != nil {
+		return err
+	}
+	setMachineSpecHash(ms, machineSpecHash(providerSpec))
+	return nil
+}
+
+func machineSpecHash(providerSpec []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(providerSpec))
+}
+
+func setMachineSpecHash(ms *machineapi.MachineSet, hash string) {
+	if ms.Spec.Template.Annotations == nil {
+		ms.Spec.Template.Annotations = map[string]string{}
+	}
+	ms.Spec.Template.Annotations[machineSpecHashAnnotation] = hash
+}
+
+func providerSpecJSON(ms *machineapi.MachineSet) ([]byte, error) {
+	value := ms.Spec.Template.Spec.ProviderSpec.Value
+	switch {
+	case value == nil:
+		return []byte("null"), nil
+	case value.Object != nil:
+		return json.Marshal(value.Object)
+	default:
+		return value.Raw, nil
+	}
+}
+
+func jsonEqual(a, b []byte) (bool, error) {
+	var aValue, bValue interface{}
+	if err := json.Unmarshal(a, &aValue); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &bValue); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(aValue, bValue), nil
+}
+
+// getMachineSetRollout returns the state of the replacement of the outdated machines of the machineset. Machines that
+// are being deleted are ignored.
+func getMachineSetRollout(remoteClusterAPIClient client.Client, machineSet *machineapi.MachineSet, logger log.FieldLogger) (*machineSetRollout, error) {
+	machines, err := listMachineSetMachines(remoteClusterAPIClient, machineSet, logger)
+	if err != nil {
+		return nil, err
+	}
+	hash := machineSet.Spec.Template.Annotations[machineSpecHashAnnotation]
+	rollout := &machineSetRollout{}
+	for i, m := range machines {
+		if m.DeletionTimestamp != nil {
+			continue
+		}
+		if hash != "" && m.Annotations[machineSpecHashAnnotation] != hash {
+			rollout.outdated = append(rollout.outdated, &machines[i])
+		} else {
+			rollout.updated++
+		}
+		if isMachineAvailable(&m) {
+			rollout.available++
+		}
+	}
+	return rollout, nil
+}
+
+// isMachineAvailable returns whether the machine is running and has a node.
+func isMachineAvailable(m *machineapi.Machine) bool {
+	return m.Status.Phase != nil && *m.Status.Phase == machinePhaseRunning && m.Status.NodeRef != nil
+}
+
+// rollingUpdateLimits returns the number of machines that can be created above the desired replicas of a machineset
+// and the number of machines of the machineset that can be unavailable while its outdated machines are replaced.
+func rollingUpdateLimits(pool *hivev1.MachinePool, replicas int32) (maxSurge, maxUnavailable int32, err error) {
+	surge, unavailable := intstr.FromInt(1), intstr.FromInt(0)
+	if pool.Spec.RollingUpdate.MaxSurge != nil {
+		surge = *pool.Spec.RollingUpdate.MaxSurge
+	}
+	if pool.Spec.RollingUpdate.MaxUnavailable != nil {
+		unavailable = *pool.Spec.RollingUpdate.MaxUnavailable
+	}
+	s, err := intstr.GetScaledValueFromIntOrPercent(&surge, int(replicas), true)
+	if err != nil {
+		return 0, 0, err
+	}
+	u, err := intstr.GetScaledValueFromIntOrPercent(&unavailable, int(replicas), false)
+	if err != nil {
+		return 0, 0, err
+	}
+	// The replicas of auto-scaled machinesets are left to the autoscaler.
+	if pool.Spec.Autoscaling != nil {
+		s = 0
+	}
+	// Make sure that the rolling update makes progress.
+	if s == 0 && u == 0 {
+		u = 1
+	}
+	return int32(s), int32(u), nil
+}
+
+// rollingUpdateSurge returns the number of machines to create above the desired replicas of the machineset to replace
+// its outdated machines.
+func rollingUpdateSurge(pool *hivev1.MachinePool, machineSet *machineapi.MachineSet, replicas int32, remoteClusterAPIClient client.Client, logger log.FieldLogger) (int32, error) {
+	rollout, err := getMachineSetRollout(remoteClusterAPIClient, machineSet, logger)
+	if err != nil {
+		return 0, err
+	}
+	maxSurge, _, err := rollingUpdateLimits(pool, replicas)
+	if err != nil {
+		return 0, err
+	}
+	if outdated := int32(len(rollout.outdated)); outdated < maxSurge {
+		return outdated, nil
+	}
+	return maxSurge, nil
+}
+
+// replaceOutdatedMachines cordons and deletes the outdated machines of the machinesets, as many at a time as the
+// rolling update strategy of the machine pool allows. The machinesets create the replacements of the deleted machines.
+func (r *ReconcileMachinePool) replaceOutdatedMachines(
+	pool *hivev1.MachinePool,
+	generatedMachineSets []*machineapi.MachineSet,
+	machineSets []*machineapi.MachineSet,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) error {
+	for i, ms := range machineSets {
+		msLog := logger.WithField("machineset", ms.Name)
+		rollout, err := getMachineSetRollout(remoteClusterAPIClient, ms, msLog)
+		if err != nil {
+			return err
+		}
+		if len(rollout.outdated) == 0 {
+			continue
+		}
+
+		// The replicas of the remote machineset include the surge, so use the replicas of the generated machineset.
+		replicas := *generatedMachineSets[i].Spec.Replicas
+		if pool.Spec.Autoscaling != nil {
+			replicas = *ms.Spec.Replicas
+		}
+		_, maxUnavailable, err := rollingUpdateLimits(pool, replicas)
+		if err != nil {
+			msLog.WithError(err).Error("could not determine the rolling update limits")
+			return err
+		}
+
+		// Outdated machines that are not available can be replaced right away. Available outdated machines can be
+		// replaced as long as enough machines remain available.
+		toReplace := []*machineapi.Machine{}
+		budget := rollout.available - (replicas - maxUnavailable)
+		for _, m := range rollout.outdated {
+			if !isMachineAvailable(m) {
+				toReplace = append(toReplace, m)
+			}
+		}
+		for _, m := range rollout.outdated {
+			if budget <= 0 {
+				break
+			}
+			if isMachineAvailable(m) {
+				toReplace = append(toReplace, m)
+				budget--
+			}
+		}
+
+		rolloutLog := msLog.WithFields(log.Fields{
+			"outdated":  len(rollout.outdated),
+			"updated":   rollout.updated,
+			"available": rollout.available,
+		})
+		if len(toReplace) == 0 {
+			rolloutLog.Debug("waiting for machines to become available before replacing outdated machines")
+			continue
+		}
+		rolloutLog.WithField("replacing", len(toReplace)).Info("replacing outdated machines")
+		for _, m := range toReplace {
+			if err := replaceMachine(remoteClusterAPIClient, m, msLog); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replaceMachine cordons the node of the machine and deletes the machine. The machine controller drains the node
+// before the machine is deleted.
+func replaceMachine(remoteClusterAPIClient client.Client, machine *machineapi.Machine, logger log.FieldLogger) error {
+	mLog := logger.WithField("machine", machine.Name)
+	if nodeRef := machine.Status.NodeRef; nodeRef != nil {
+		node := &corev1.Node{}
+		switch err := remoteClusterAPIClient.Get(context.TODO(), client.ObjectKey{Name: nodeRef.Name}, node); {
+		case apierrors.IsNotFound(err):
+			mLog.WithField("node", nodeRef.Name).Debug("node of machine does not exist")
+		case err != nil:
+			mLog.WithField("node", nodeRef.Name).WithError(err).Error("could not get node of machine")
+			return err
+		case !node.Spec.Unschedulable:
+			mLog.WithField("node", nodeRef.Name).Info("cordoning node of outdated machine")
+			node.Spec.Unschedulable = true
+			if err := remoteClusterAPIClient.Update(context.TODO(), node); err != nil {
+				mLog.WithField("node", nodeRef.Name).WithError(err).Error("could not cordon node")
+				return err
+			}
+		}
+	}
+	mLog.Info("deleting outdated machine")
+	if err := remoteClusterAPIClient.Delete(context.TODO(), machine); err != nil && !apierrors.IsNotFound(err) {
+		mLog.WithError(err).Error("unable to delete machine")
+		return err
+	}
+	return nil
+}
+
+// listMachineSetMachines lists the machines of the machineset.
+func listMachineSetMachines(remoteClusterAPIClient client.Client, machineSet *machineapi.MachineSet, logger log.FieldLogger) ([]machineapi.Machine, error) {
+	sel, err := metav1.LabelSelectorAsSelector(&machineSet.Spec.Selector)
+	if err != nil {
+		logger.WithError(err).Error("failed to create label selector")
+		return nil, err
+	}
+	list := &machineapi.MachineList{}
+	if err := remoteClusterAPIClient.List(context.TODO(), list,
+		client.InNamespace(machineSet.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		logger.WithError(err).Error("failed to list machines for the machineset")
+		return nil, err
+	}
+	return list.Items, nil
+}
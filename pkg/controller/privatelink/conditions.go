@@ -0,0 +1,98 @@
+package privatelink
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+func (r *Reconciler) setErrCondition(cd *hivev1.ClusterDeployment,
+	reason string, err error,
+	logger log.FieldLogger) error {
+	curr := &hivev1.ClusterDeployment{}
+	errGet := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+	if errGet != nil {
+		return errGet
+	}
+	message := controllerutils.ErrorScrub(err)
+	conditions, failedChanged := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		curr.Status.Conditions,
+		r.FailedCondition,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	conditions, readyChanged := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		conditions,
+		r.ReadyCondition,
+		corev1.ConditionFalse,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !readyChanged && !failedChanged {
+		return nil
+	}
+	curr.Status.Conditions = conditions
+	logger.Debugf("setting %s to true", r.FailedCondition)
+	return r.Status().Update(context.TODO(), curr)
+}
+
+func (r *Reconciler) setReadyCondition(cd *hivev1.ClusterDeployment,
+	completed corev1.ConditionStatus,
+	reason string, message string,
+	logger log.FieldLogger) error {
+
+	curr := &hivev1.ClusterDeployment{}
+	errGet := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+	if errGet != nil {
+		return errGet
+	}
+
+	conditions := curr.Status.Conditions
+
+	var failedChanged bool
+	if completed == corev1.ConditionTrue {
+		conditions, failedChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			conditions,
+			r.FailedCondition,
+			corev1.ConditionFalse,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+	}
+
+	var readyChanged bool
+	ready := controllerutils.FindClusterDeploymentCondition(conditions, r.ReadyCondition)
+	if ready == nil || ready.Status != corev1.ConditionTrue {
+		// we want to allow Ready condition to reach Ready level
+		conditions, readyChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			conditions,
+			r.ReadyCondition,
+			completed,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+	} else if completed == corev1.ConditionTrue {
+		// allow reinforcing Ready level to track the last Ready probe.
+		// we have a higher level control of when to sync an already Ready cluster
+		conditions, readyChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			conditions,
+			r.ReadyCondition,
+			corev1.ConditionTrue,
+			reason,
+			message,
+			controllerutils.UpdateConditionAlways)
+	}
+	if !readyChanged && !failedChanged {
+		return nil
+	}
+	curr.Status.Conditions = conditions
+	logger.Debugf("setting %s to %s", r.ReadyCondition, completed)
+	return r.Status().Update(context.TODO(), curr)
+}
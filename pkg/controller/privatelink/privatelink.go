@@ -0,0 +1,557 @@
+package privatelink
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const defaultRequeueLater = 1 * time.Minute
+
+// Platform is the part of a private link controller specific to a cloud platform. The controller gives the hub
+// networks in the inventory of its configuration private access to the API of the clusters of the platform, through
+// a service exposing the internal API load balancer of each cluster, an endpoint for the service in a hub network and
+// a private DNS zone resolving the API domain of the cluster to the endpoint.
+type Platform interface {
+	// Access returns whether the cluster deployment is on the platform of the controller and configures private
+	// access, and whether the private access is enabled.
+	Access(cd *hivev1.ClusterDeployment) (configured bool, enabled bool)
+
+	// SupportsRegion returns true when the inventory of hub networks has a network in the region.
+	SupportsRegion(region string) bool
+
+	// AssociatedNetworks returns the hub networks that resolve the API domain of the clusters through the private DNS
+	// zones, in addition to the network of the endpoint of each cluster.
+	AssociatedNetworks() []string
+
+	// CleanupRequired returns true when the status of the cluster deployment records cloud resources created for the
+	// private access.
+	CleanupRequired(cd *hivev1.ClusterDeployment) bool
+
+	// NewActuator returns the actuator for the cloud resources of the private access of the cluster deployment.
+	NewActuator(cd *hivev1.ClusterDeployment) (Actuator, error)
+}
+
+// Actuator creates and deletes the cloud resources of the private access of a cluster, and records them in the status
+// of its cluster deployment.
+type Actuator interface {
+	// ReconcileService makes sure a service exposes the internal API load balancer of the cluster to the hub.
+	ReconcileService(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) (bool, *Service, error)
+
+	// ReconcileEndpoint makes sure an endpoint for the service exists in a hub network chosen from the inventory in
+	// the region of the cluster.
+	ReconcileEndpoint(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, service *Service, logger log.FieldLogger) (bool, *Endpoint, error)
+
+	// ReconcileDNSZone makes sure a private DNS zone for the API domain of the cluster resolves to the IP address of
+	// the endpoint, and is visible from exactly the given hub networks.
+	ReconcileDNSZone(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, endpoint *Endpoint, apiDomain string, networks []string, logger log.FieldLogger) (bool, error)
+
+	// Cleanup deletes the cloud resources created for the cluster with the infra ID of the metadata, and clears them
+	// from the status of the cluster deployment. The metadata is nil when the cluster deployment has none yet.
+	Cleanup(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error
+}
+
+// Service is the service exposing the internal API load balancer of a cluster to the hub.
+type Service struct {
+	Name string
+	ID   string
+}
+
+// Endpoint is the endpoint for the service of a cluster in a hub network.
+type Endpoint struct {
+	// Network is the ID of the hub network of the endpoint.
+	Network string
+	// IP is the address of the endpoint in the hub network.
+	IP string
+}
+
+// ConditionError is an error of an actuator reported with its own reason in the conditions of the cluster deployment.
+type ConditionError struct {
+	Reason string
+	Err    error
+	// Waiting is true when the error only means that the cloud resources of the cluster the actuator needs do not
+	// exist yet. It is reported in the Ready condition and the cluster deployment is reconciled again later.
+	Waiting bool
+}
+
+func (e *ConditionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConditionError) Unwrap() error {
+	return e.Err
+}
+
+// NewConditionError returns an error reported with the reason in the Failed condition.
+func NewConditionError(reason string, err error) error {
+	return &ConditionError{Reason: reason, Err: err}
+}
+
+// NewWaitingError returns an error reported with the reason and message in the Ready condition, for cloud resources
+// of the cluster that do not exist yet.
+func NewWaitingError(reason string, message string) error {
+	return &ConditionError{Reason: reason, Err: errors.New(message), Waiting: true}
+}
+
+// Reconciler reconciles the private access to the API of the clusters of a platform.
+type Reconciler struct {
+	client.Client
+
+	Platform Platform
+
+	ControllerName hivev1.ControllerName
+	// Finalizer is the finalizer holding the deletion of cluster deployments until the private access is cleaned up.
+	Finalizer string
+	// LastCleanupAnnotationKey is the annotation recording the infra ID of the last provision attempt whose private
+	// access was cleaned up.
+	LastCleanupAnnotationKey string
+
+	FailedCondition hivev1.ClusterDeploymentConditionType
+	ReadyCondition  hivev1.ClusterDeploymentConditionType
+
+	// AccessName is the name of the private access in the reasons of the conditions and the logs, e.g. PrivateLink.
+	AccessName string
+	// AccessDescription is the name of the private access in the messages of the conditions, e.g. private link.
+	AccessDescription string
+}
+
+// Reconcile reconciles the private access for the ClusterDeployment.
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(r.ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(r.ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		// Error reading the object - requeue the request.
+		logger.WithError(err).Error("error getting ClusterDeployment")
+		return reconcile.Result{}, err
+	}
+
+	// Initialize cluster deployment conditions if not present
+	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions,
+		[]hivev1.ClusterDeploymentConditionType{r.FailedCondition, r.ReadyCondition})
+	if len(newConditions) > len(cd.Status.Conditions) {
+		cd.Status.Conditions = newConditions
+		logger.Infof("initializing %s controller conditions", r.AccessDescription)
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	configured, enabled := r.Platform.Access(cd)
+	if !configured {
+		logger.Debug("controller cannot service the clusterdeployment, so skipping")
+		return reconcile.Result{}, nil
+	}
+	if !enabled {
+		if r.Platform.CleanupRequired(cd) {
+			// private access was disabled for this cluster so cleanup is required.
+			return r.cleanupClusterDeployment(cd, cd.Spec.ClusterMetadata, logger)
+		}
+
+		logger.Debugf("cluster deployment does not have %s enabled, so skipping", r.AccessDescription)
+		return reconcile.Result{}, nil
+	}
+
+	if cd.DeletionTimestamp != nil {
+		return r.cleanupClusterDeployment(cd, cd.Spec.ClusterMetadata, logger)
+	}
+
+	// Add finalizer if not already present
+	if !controllerutils.HasFinalizer(cd, r.Finalizer) {
+		logger.Debug("adding finalizer to ClusterDeployment")
+		controllerutils.AddFinalizer(cd, r.Finalizer)
+		if err := r.Update(context.Background(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error adding finalizer to ClusterDeployment")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if region := controllerutils.GetClusterRegion(cd); !r.Platform.SupportsRegion(region) {
+		err := errors.Errorf("cluster deployment region %q is not supported as there is no inventory to create necessary resources",
+			region)
+		logger.WithError(err).Error("cluster deployment region is not supported, so skipping")
+
+		if err := r.setErrCondition(cd, "UnsupportedRegion", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// See if we need to sync. This is what rate limits our cloud API usage, but allows for immediate syncing
+	// on changes and deletes.
+	shouldSync, syncAfter := r.shouldSync(cd)
+	if !shouldSync {
+		logger.WithFields(log.Fields{
+			"syncAfter": syncAfter,
+		}).Debug("Sync not needed")
+
+		return reconcile.Result{RequeueAfter: syncAfter}, nil
+	}
+
+	if cd.Spec.Installed {
+		logger.Debug("reconciling already installed cluster deployment")
+		return r.reconcilePrivateAccess(cd, cd.Spec.ClusterMetadata, logger)
+	}
+
+	if cd.Status.ProvisionRef == nil {
+		logger.Debug("waiting for cluster deployment provision to start, will retry soon.")
+		return reconcile.Result{}, nil
+	}
+
+	cpLog := logger.WithField("provision", cd.Status.ProvisionRef.Name)
+	cp := &hivev1.ClusterProvision{}
+	err = r.Get(context.TODO(), types.NamespacedName{Name: cd.Status.ProvisionRef.Name, Namespace: cd.Namespace}, cp)
+	if apierrors.IsNotFound(err) {
+		cpLog.Warn("linked cluster provision not found")
+		return reconcile.Result{}, err
+	}
+	if err != nil {
+		cpLog.WithError(err).Error("could not get provision")
+		return reconcile.Result{}, err
+	}
+
+	if cp.Spec.PrevInfraID != nil && *cp.Spec.PrevInfraID != "" && r.Platform.CleanupRequired(cd) {
+		lastCleanup := cd.Annotations[r.LastCleanupAnnotationKey]
+		if lastCleanup != *cp.Spec.PrevInfraID {
+			logger.WithField("prevInfraID", *cp.Spec.PrevInfraID).
+				Infof("cleaning up %s resources from previous attempt", r.AccessName)
+
+			if err := r.cleanupPreviousProvisionAttempt(cd, cp, logger); err != nil {
+				logger.WithError(err).Errorf("error cleaning up %s resources for ClusterDeployment", r.AccessName)
+
+				if err := r.setErrCondition(cd, "CleanupForProvisionReattemptFailed", err, logger); err != nil {
+					logger.WithError(err).Error("failed to update condition on cluster deployment")
+					return reconcile.Result{}, err
+				}
+				return reconcile.Result{}, err
+			}
+
+			if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+				"PreviousAttemptCleanupComplete",
+				"successfully cleaned up resources from previous provision attempt so that next attempt can start",
+				logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deployment")
+				return reconcile.Result{}, err
+			}
+
+			return reconcile.Result{Requeue: true}, nil
+		}
+	}
+
+	if cp.Spec.InfraID == nil || *cp.Spec.InfraID == "" ||
+		cp.Spec.AdminKubeconfigSecretRef == nil || cp.Spec.AdminKubeconfigSecretRef.Name == "" {
+		logger.Debug("waiting for cluster deployment provision to provide ClusterMetadata, will retry soon.")
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcilePrivateAccess(cd, &hivev1.ClusterMetadata{InfraID: *cp.Spec.InfraID, AdminKubeconfigSecretRef: *cp.Spec.AdminKubeconfigSecretRef}, logger)
+}
+
+// shouldSync returns if we should sync the desired ClusterDeployment. If it returns false, it also returns
+// the duration after which we should try to check if sync is required.
+func (r *Reconciler) shouldSync(desired *hivev1.ClusterDeployment) (bool, time.Duration) {
+	window := 2 * time.Hour
+	if desired.DeletionTimestamp != nil && !controllerutils.HasFinalizer(desired, r.Finalizer) {
+		return false, 0 // No finalizer means our cleanup has been completed. There's nothing left to do.
+	}
+
+	if desired.DeletionTimestamp != nil {
+		return true, 0 // We're in a deleting state, sync now.
+	}
+
+	failedCondition := controllerutils.FindClusterDeploymentCondition(desired.Status.Conditions, r.FailedCondition)
+	if failedCondition != nil && failedCondition.Status == corev1.ConditionTrue {
+		return true, 0 // we have failed to reconcile and therefore should continue to retry for quick recovery
+	}
+
+	readyCondition := controllerutils.FindClusterDeploymentCondition(desired.Status.Conditions, r.ReadyCondition)
+	if readyCondition == nil || readyCondition.Status != corev1.ConditionTrue {
+		return true, 0 // we have not reached Ready level
+	}
+	delta := time.Now().Sub(readyCondition.LastProbeTime.Time)
+
+	if !desired.Spec.Installed {
+		// as cluster is installing, but the private access has been setup once, we wait
+		// for a shorter duration before reconciling again.
+		window = 10 * time.Minute
+	}
+
+	if delta >= window {
+		// We haven't sync'd in over resync duration time, sync now.
+		return true, 0
+	}
+
+	syncAfter := (window - delta).Round(time.Minute)
+	if syncAfter == 0 {
+		// if it is less than a minute, sync after a minute
+		syncAfter = time.Minute
+	}
+	// We didn't meet any of the criteria above, so we should not sync.
+	return false, syncAfter
+}
+
+func (r *Reconciler) reconcilePrivateAccess(cd *hivev1.ClusterDeployment, clusterMetadata *hivev1.ClusterMetadata, logger log.FieldLogger) (reconcile.Result, error) {
+	logger.Debugf("reconciling %s resources", r.AccessName)
+	actuator, err := r.Platform.NewActuator(cd)
+	if err != nil {
+		logger.WithError(err).Error("error creating cloud clients for the cluster")
+		return reconcile.Result{}, err
+	}
+
+	// reconcile the service exposing the internal API load balancer of the cluster.
+	serviceModified, service, err := actuator.ReconcileService(cd, clusterMetadata, logger)
+	if err != nil {
+		var condErr *ConditionError
+		if errors.As(err, &condErr) && condErr.Waiting {
+			logger.WithField("infraID", clusterMetadata.InfraID).WithField("reason", condErr.Reason).
+				Debug("the internal API load balancer is not yet created for the cluster, will retry later")
+
+			if err := r.setReadyCondition(cd, corev1.ConditionFalse, condErr.Reason, condErr.Error(), logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deployment")
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: defaultRequeueLater}, nil
+		}
+
+		logger.WithError(err).Error("failed to reconcile the service")
+		if err := r.reportStepError(cd, "ServiceReconcileFailed", err, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, err
+	}
+	if serviceModified {
+		if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+			"ReconciledService",
+			"reconciled the service for the internal API load balancer of the cluster",
+			logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+	}
+
+	// reconcile the endpoint for the service in a hub network chosen from the inventory.
+	endpointModified, endpoint, err := actuator.ReconcileEndpoint(cd, clusterMetadata, service, logger)
+	if err != nil {
+		logger.WithError(err).Error("failed to reconcile the endpoint")
+		if err := r.reportStepError(cd, "EndpointReconcileFailed", err, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile the endpoint")
+	}
+	if endpointModified {
+		if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+			"ReconciledEndpoint",
+			"reconciled the endpoint for the cluster",
+			logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Figure out the API address for cluster.
+	apiDomain, err := initialURL(r.Client,
+		client.ObjectKey{Namespace: cd.Namespace, Name: clusterMetadata.AdminKubeconfigSecretRef.Name})
+	if err != nil {
+		logger.WithError(err).Error("could not get API URL from kubeconfig")
+		if err := r.reportStepError(cd, "CouldNotCalculateAPIDomain", err, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, err
+	}
+
+	// reconcile the private DNS zone for the endpoint, visible from the network of the endpoint and the associated
+	// networks.
+	networks := sets.NewString(endpoint.Network)
+	networks.Insert(r.Platform.AssociatedNetworks()...)
+	zoneModified, err := actuator.ReconcileDNSZone(cd, clusterMetadata, endpoint, apiDomain, networks.List(), logger)
+	if err != nil {
+		logger.WithError(err).Error("could not reconcile the private DNS zone")
+		if err := r.reportStepError(cd, "PrivateDNSZoneReconcileFailed", err, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, err
+	}
+	if zoneModified {
+		if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+			"ReconciledPrivateDNSZone",
+			"reconciled the private DNS zone for the endpoint of the cluster",
+			logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err := r.setReadyCondition(cd, corev1.ConditionTrue,
+		r.AccessName+"AccessReady",
+		r.AccessDescription+" access is ready for use",
+		logger); err != nil {
+		logger.WithError(err).Error("failed to update condition on cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reportStepError sets the Failed condition for the error of a step, with the reason of the error when it has one
+// and the default reason of the step otherwise. It returns the error updating the condition.
+func (r *Reconciler) reportStepError(cd *hivev1.ClusterDeployment, reason string, err error, logger log.FieldLogger) error {
+	var condErr *ConditionError
+	if errors.As(err, &condErr) {
+		reason = condErr.Reason
+	}
+	if err := r.setErrCondition(cd, reason, err, logger); err != nil {
+		logger.WithError(err).Error("failed to update condition on cluster deployment")
+		return err
+	}
+	return nil
+}
+
+func (r *Reconciler) cleanupClusterDeployment(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) (reconcile.Result, error) {
+	if !controllerutils.HasFinalizer(cd, r.Finalizer) {
+		return reconcile.Result{}, nil
+	}
+
+	if r.Platform.CleanupRequired(cd) {
+		if err := r.cleanup(cd, metadata, logger); err != nil {
+			logger.WithError(err).Errorf("error cleaning up %s resources for ClusterDeployment", r.AccessName)
+
+			if err := r.setErrCondition(cd, "CleanupForDeprovisionFailed", err, logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deployment")
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, err
+		}
+
+		if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+			"DeprovisionCleanupComplete",
+			"successfully cleaned up "+r.AccessDescription+" resources created to deprovision cluster",
+			logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+	}
+
+	logger.Info("removing finalizer from ClusterDeployment")
+	controllerutils.DeleteFinalizer(cd, r.Finalizer)
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove finalizer from ClusterDeployment")
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) cleanupPreviousProvisionAttempt(cd *hivev1.ClusterDeployment, cp *hivev1.ClusterProvision,
+	logger log.FieldLogger) error {
+	metadata := &hivev1.ClusterMetadata{
+		InfraID: *cp.Spec.PrevInfraID,
+	}
+
+	if err := r.cleanup(cd, metadata, logger); err != nil {
+		return err
+	}
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[r.LastCleanupAnnotationKey] = metadata.InfraID
+	return updateAnnotations(r.Client, cd)
+}
+
+func (r *Reconciler) cleanup(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error {
+	actuator, err := r.Platform.NewActuator(cd)
+	if err != nil {
+		logger.WithError(err).Error("error creating cloud clients for the cluster")
+		return err
+	}
+	return actuator.Cleanup(cd, metadata, logger)
+}
+
+// initialURL returns the initial API URL for the ClusterProvision.
+func initialURL(c client.Client, key client.ObjectKey) (string, error) {
+	kubeconfigSecret := &corev1.Secret{}
+	if err := c.Get(
+		context.Background(),
+		key,
+		kubeconfigSecret,
+	); err != nil {
+		return "", err
+	}
+	cfg, err := restConfigFromSecret(kubeconfigSecret)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load the kubeconfig")
+	}
+
+	u, err := url.Parse(cfg.Host)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(u.Hostname(), "."), nil
+}
+
+func restConfigFromSecret(kubeconfigSecret *corev1.Secret) (*rest.Config, error) {
+	kubeconfigData := kubeconfigSecret.Data[constants.RawKubeconfigSecretKey]
+	if len(kubeconfigData) == 0 {
+		kubeconfigData = kubeconfigSecret.Data[constants.KubeconfigSecretKey]
+	}
+	if len(kubeconfigData) == 0 {
+		return nil, errors.New("kubeconfig secret does not contain necessary data")
+	}
+	config, err := clientcmd.Load(kubeconfigData)
+	if err != nil {
+		return nil, err
+	}
+	kubeConfig := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
+	return kubeConfig.ClientConfig()
+}
+
+// RetryBackoff is the backoff of the retries of the updates of cluster deployments on conflicts.
+var RetryBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 1 * time.Second,
+	Factor:   1.0,
+	Jitter:   0.1,
+}
+
+func updateAnnotations(client client.Client, cd *hivev1.ClusterDeployment) error {
+	return retry.RetryOnConflict(RetryBackoff, func() error {
+		curr := &hivev1.ClusterDeployment{}
+		err := client.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+		if err != nil {
+			return err
+		}
+		curr.Annotations = cd.Annotations
+		return client.Update(context.TODO(), curr)
+	})
+}
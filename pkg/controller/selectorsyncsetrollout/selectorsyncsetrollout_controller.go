@@ -0,0 +1,389 @@
+package selectorsyncsetrollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.SelectorSyncSetRolloutControllerName
+
+	// clusterSyncSelectorSyncSetIndex indexes ClusterSyncs by the names of the SelectorSyncSets in their status.
+	clusterSyncSelectorSyncSetIndex = "status.selectorSyncSets.name"
+)
+
+// Add creates a new SelectorSyncSetRollout Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	r := &ReconcileSelectorSyncSetRollout{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	rolloutReconciler, ok := r.(*ReconcileSelectorSyncSetRollout)
+	if !ok {
+		return errors.New("reconciler supplied is not a ReconcileSelectorSyncSetRollout")
+	}
+
+	// Create a new controller
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              r,
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Index ClusterSyncs by the SelectorSyncSets applied to the clusters
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &hiveintv1alpha1.ClusterSync{}, clusterSyncSelectorSyncSetIndex,
+		func(o client.Object) []string {
+			clusterSync := o.(*hiveintv1alpha1.ClusterSync)
+			names := make([]string, len(clusterSync.Status.SelectorSyncSets))
+			for i, syncStatus := range clusterSync.Status.SelectorSyncSets {
+				names[i] = syncStatus.Name
+			}
+			return names
+		}); err != nil {
+		log.WithError(err).Error("Error indexing ClusterSyncs by SelectorSyncSet")
+		return err
+	}
+
+	// Watch for changes to SelectorSyncSets
+	if err := c.Watch(&source.Kind{Type: &hivev1.SelectorSyncSet{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterSyncs, which record the generations of the SelectorSyncSets applied to the clusters
+	if err := c.Watch(
+		&source.Kind{Type: &hiveintv1alpha1.ClusterSync{}},
+		handler.EnqueueRequestsFromMapFunc(requestsForClusterSync(rolloutReconciler.Client, rolloutReconciler.logger)),
+		predicate.Funcs{UpdateFunc: clusterSyncUpdateAffectsRollout}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestsForClusterSync enqueues the SelectorSyncSets that are being rolled out to the cluster of the ClusterSync.
+// SelectorSyncSets that no longer select the cluster or that have no rollout are not affected by the ClusterSync.
+func requestsForClusterSync(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		clusterSync, ok := o.(*hiveintv1alpha1.ClusterSync)
+		if !ok || len(clusterSync.Status.SelectorSyncSets) == 0 {
+			return nil
+		}
+		logger := logger.WithField("clusterSync", clusterSync.Namespace+"/"+clusterSync.Name)
+		cd := &hivev1.ClusterDeployment{}
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(clusterSync), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get ClusterDeployment for ClusterSync")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, syncStatus := range clusterSync.Status.SelectorSyncSets {
+			sss := &hivev1.SelectorSyncSet{}
+			if err := c.Get(context.Background(), client.ObjectKey{Name: syncStatus.Name}, sss); err != nil {
+				logger.WithError(err).WithField("selectorSyncSet", syncStatus.Name).Log(controllerutils.LogLevel(err), "could not get SelectorSyncSet")
+				continue
+			}
+			if sss.Spec.Rollout == nil {
+				continue
+			}
+			labelSelector, err := metav1.LabelSelectorAsSelector(&sss.Spec.ClusterDeploymentSelector)
+			if err != nil {
+				logger.WithError(err).WithField("selectorSyncSet", sss.Name).Warn("cannot parse ClusterDeployment selector")
+				continue
+			}
+			if !labelSelector.Matches(labels.Set(cd.Labels)) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: sss.Name}})
+		}
+		return requests
+	}
+}
+
+// clusterSyncUpdateAffectsRollout filters out updates to ClusterSyncs that do not change the generation or the result
+// of any of the SelectorSyncSets applied to the cluster, such as the periodic reapply of the syncsets, so that the
+// rollouts are only reconciled when a cluster makes progress.
+func clusterSyncUpdateAffectsRollout(e event.UpdateEvent) bool {
+	oldClusterSync, ok := e.ObjectOld.(*hiveintv1alpha1.ClusterSync)
+	if !ok {
+		return true
+	}
+	newClusterSync, ok := e.ObjectNew.(*hiveintv1alpha1.ClusterSync)
+	if !ok {
+		return true
+	}
+	if len(oldClusterSync.Status.SelectorSyncSets) != len(newClusterSync.Status.SelectorSyncSets) {
+		return true
+	}
+	oldStatuses := make(map[string]hiveintv1alpha1.SyncStatus, len(oldClusterSync.Status.SelectorSyncSets))
+	for _, syncStatus := range oldClusterSync.Status.SelectorSyncSets {
+		oldStatuses[syncStatus.Name] = syncStatus
+	}
+	for _, syncStatus := range newClusterSync.Status.SelectorSyncSets {
+		oldStatus, ok := oldStatuses[syncStatus.Name]
+		if !ok || oldStatus.ObservedGeneration != syncStatus.ObservedGeneration || oldStatus.Result != syncStatus.Result {
+			return true
+		}
+	}
+	return false
+}
+
+var _ reconcile.Reconciler = &ReconcileSelectorSyncSetRollout{}
+
+// ReconcileSelectorSyncSetRollout reconciles a SelectorSyncSet object to progress the rollout of its current generation
+// to the clusters that it applies to.
+type ReconcileSelectorSyncSetRollout struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile tracks which of the clusters have applied the current generation of the SelectorSyncSet, and rolls the
+// generation out to the next batch of clusters once the clusters in the rollout so far have applied it.
+func (r *ReconcileSelectorSyncSetRollout) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "selectorSyncSet", request.NamespacedName)
+	logger.Info("reconciling SelectorSyncSet")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the SelectorSyncSet instance
+	sss := &hivev1.SelectorSyncSet{}
+	switch err := r.Get(context.Background(), request.NamespacedName, sss); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("SelectorSyncSet not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get SelectorSyncSet")
+		return reconcile.Result{}, err
+	}
+
+	if sss.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	origStatus := sss.Status.DeepCopy()
+	var requeueAfter time.Duration
+	if sss.Spec.Rollout == nil {
+		sss.Status.Rollout = nil
+	} else {
+		var err error
+		requeueAfter, err = r.progressRollout(sss, time.Now(), logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if !reflect.DeepEqual(origStatus, &sss.Status) {
+		logger.Info("updating SelectorSyncSet rollout status")
+		if err := r.Status().Update(context.Background(), sss); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update SelectorSyncSet status")
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// progressRollout records the progress of the rollout of the current generation of the SelectorSyncSet in its status,
+// and rolls the generation out to more clusters when the rollout may proceed. Returns how long until the rollout should
+// be checked again because the pause between batches will have elapsed, or zero if there is no need to check again
+// until the SelectorSyncSet or the ClusterSyncs change.
+func (r *ReconcileSelectorSyncSetRollout) progressRollout(sss *hivev1.SelectorSyncSet, now time.Time, logger log.FieldLogger) (time.Duration, error) {
+	rollout := sss.Spec.Rollout
+	status := sss.Status.Rollout
+	if status == nil || status.ObservedGeneration != sss.Generation {
+		logger.WithField("generation", sss.Generation).Info("starting rollout of new generation")
+		status = &hivev1.SelectorSyncSetRolloutStatus{
+			ObservedGeneration: sss.Generation,
+			Phase:              hivev1.CanarySelectorSyncSetRolloutPhase,
+			LastBatchTime:      &metav1.Time{Time: now},
+		}
+		if rollout.CanarySelector == nil {
+			status.Phase = hivev1.ProgressingSelectorSyncSetRolloutPhase
+			status.Percent = nextPercent(0, rollout.BatchPercent)
+		}
+		sss.Status.Rollout = status
+	}
+
+	if err := r.countClusters(sss, logger); err != nil {
+		return 0, err
+	}
+	logger = logger.WithFields(log.Fields{
+		"phase":     status.Phase,
+		"percent":   status.Percent,
+		"rolledOut": status.RolledOutClusters,
+		"updated":   status.UpdatedClusters,
+		"failed":    status.FailedClusters,
+	})
+
+	switch status.Phase {
+	case hivev1.HaltedSelectorSyncSetRolloutPhase, hivev1.CompleteSelectorSyncSetRolloutPhase:
+		return 0, nil
+	}
+
+	if status.FailedClusters*100 > rollout.MaxFailurePercent*status.RolledOutClusters {
+		logger.Info("halting rollout since too many clusters failed to apply the generation")
+		status.Phase = hivev1.HaltedSelectorSyncSetRolloutPhase
+		status.Message = fmt.Sprintf("Halted since %d of %d clusters failed to apply generation %d",
+			status.FailedClusters, status.RolledOutClusters, status.ObservedGeneration)
+		return 0, nil
+	}
+
+	if pending := status.RolledOutClusters - status.UpdatedClusters - status.FailedClusters; pending > 0 {
+		logger.Debug("waiting for clusters to apply the generation")
+		status.Message = fmt.Sprintf("Waiting for %d clusters to apply generation %d", pending, status.ObservedGeneration)
+		return 0, nil
+	}
+
+	if status.Percent >= 100 {
+		logger.Info("rollout is complete")
+		status.Phase = hivev1.CompleteSelectorSyncSetRolloutPhase
+		status.Message = fmt.Sprintf("Generation %d has been rolled out to all clusters", status.ObservedGeneration)
+		return 0, nil
+	}
+
+	if rollout.PauseBetweenBatches != nil && status.LastBatchTime != nil {
+		if pauseRemaining := status.LastBatchTime.Add(rollout.PauseBetweenBatches.Duration).Sub(now); pauseRemaining > 0 {
+			logger.WithField("pauseRemaining", pauseRemaining).Debug("pausing before rolling out the next batch")
+			status.Message = fmt.Sprintf("Pausing before rolling out generation %d to the next batch of clusters", status.ObservedGeneration)
+			return pauseRemaining, nil
+		}
+	}
+
+	status.Phase = hivev1.ProgressingSelectorSyncSetRolloutPhase
+	status.Percent = nextPercent(status.Percent, rollout.BatchPercent)
+	status.LastBatchTime = &metav1.Time{Time: now}
+	status.Message = fmt.Sprintf("Rolling out generation %d to %d%% of clusters", status.ObservedGeneration, status.Percent)
+	logger.WithField("percent", status.Percent).Info("rolling out the next batch")
+	// The counts of clusters will be refreshed when the status update triggers the next reconcile.
+	return 0, nil
+}
+
+// countClusters counts the clusters that the SelectorSyncSet applies to, and how many of the clusters that the current
+// generation has been rolled out to have applied it successfully or have failed to apply it. Clusters that are not
+// being synced, such as clusters that are unreachable, are not counted as being rolled out so that they do not hold up
+// the rollout.
+func (r *ReconcileSelectorSyncSetRollout) countClusters(sss *hivev1.SelectorSyncSet, logger log.FieldLogger) error {
+	labelSelector, err := metav1.LabelSelectorAsSelector(&sss.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		logger.WithError(err).Error("cannot parse ClusterDeployment selector")
+		return err
+	}
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cds, client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments")
+		return err
+	}
+	clusterSyncs := &hiveintv1alpha1.ClusterSyncList{}
+	if err := r.List(context.Background(), clusterSyncs, client.MatchingFields{clusterSyncSelectorSyncSetIndex: sss.Name}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterSyncs")
+		return err
+	}
+	syncStatuses := map[types.NamespacedName]hiveintv1alpha1.SyncStatus{}
+	for _, clusterSync := range clusterSyncs.Items {
+		for _, syncStatus := range clusterSync.Status.SelectorSyncSets {
+			if syncStatus.Name == sss.Name {
+				syncStatuses[types.NamespacedName{Namespace: clusterSync.Namespace, Name: clusterSync.Name}] = syncStatus
+				break
+			}
+		}
+	}
+
+	status := sss.Status.Rollout
+	status.Clusters = int32(len(cds.Items))
+	status.RolledOutClusters, status.UpdatedClusters, status.FailedClusters = 0, 0, 0
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		if !isSyncing(cd, logger.WithField("clusterDeployment", cd.Namespace+"/"+cd.Name)) {
+			continue
+		}
+		rolledOut, err := controllerutils.IsSelectorSyncSetRolledOutToCluster(sss, cd)
+		if err != nil {
+			logger.WithError(err).Error("cannot determine whether the generation has been rolled out to the cluster")
+			return err
+		}
+		if !rolledOut {
+			continue
+		}
+		status.RolledOutClusters++
+		syncStatus, ok := syncStatuses[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}]
+		if !ok || syncStatus.ObservedGeneration != sss.Generation {
+			continue
+		}
+		switch syncStatus.Result {
+		case hiveintv1alpha1.SuccessSyncSetResult:
+			status.UpdatedClusters++
+		case hiveintv1alpha1.FailureSyncSetResult:
+			status.FailedClusters++
+		}
+	}
+	return nil
+}
+
+// isSyncing returns whether the clustersync controller is applying syncsets to the cluster.
+func isSyncing(cd *hivev1.ClusterDeployment, logger log.FieldLogger) bool {
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+		return false
+	}
+	if controllerutils.IsClusterPausedOrRelocating(cd, logger) {
+		return false
+	}
+	unreachable, _ := remoteclient.Unreachable(cd)
+	return !unreachable
+}
+
+// nextPercent returns the percentage of the clusters that the generation is rolled out to after the next batch.
+func nextPercent(percent, batchPercent int32) int32 {
+	percent += batchPercent
+	if percent > 100 {
+		percent = 100
+	}
+	return percent
+}
@@ -0,0 +1,361 @@
+package selectorsyncsetrollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testsss "github.com/openshift/hive/pkg/test/selectorsyncset"
+)
+
+const (
+	testNamespace = "test-namespace"
+	sssName       = "test-selectorsyncset"
+)
+
+func TestReconcileSelectorSyncSetRollout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	now := time.Now()
+	cdBuilder := testcd.FullBuilder(testNamespace, "", scheme).Options(
+		testcd.Installed(),
+		testcd.WithLabel("test-label-key", "test-label-value"),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+	)
+	canaryCD := cdBuilder.Build(testcd.WithName("canary-cd"), testcd.WithLabel("canary", "true"))
+	otherCD := cdBuilder.Build(testcd.WithName("other-cd"))
+	unreachableCD := cdBuilder.Build(
+		testcd.WithName("unreachable-cd"),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionTrue,
+		}),
+	)
+	clusterSync := func(name string, generation int64, result hiveintv1alpha1.SyncSetResult) *hiveintv1alpha1.ClusterSync {
+		return testcs.FullBuilder(testNamespace, name, scheme).Build(
+			testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{
+				Name:               sssName,
+				ObservedGeneration: generation,
+				Result:             result,
+			}),
+		)
+	}
+	rollout := func(pause time.Duration, maxFailurePercent int32) testsss.Option {
+		r := &hivev1.SelectorSyncSetRollout{
+			CanarySelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+			BatchPercent:      50,
+			MaxFailurePercent: maxFailurePercent,
+		}
+		if pause > 0 {
+			r.PauseBetweenBatches = &metav1.Duration{Duration: pause}
+		}
+		return testsss.WithRollout(r)
+	}
+	rolloutStatus := func(phase hivev1.SelectorSyncSetRolloutPhase, percent int32, lastBatchTime time.Time) testsss.Option {
+		return testsss.WithRolloutStatus(&hivev1.SelectorSyncSetRolloutStatus{
+			ObservedGeneration: 2,
+			Phase:              phase,
+			Percent:            percent,
+			LastBatchTime:      &metav1.Time{Time: lastBatchTime},
+		})
+	}
+
+	cases := []struct {
+		name                 string
+		sss                  *hivev1.SelectorSyncSet
+		existing             []runtime.Object
+		expectedStatus       *hivev1.SelectorSyncSetRolloutStatus
+		expectNewBatch       bool
+		expectedRequeueAfter time.Duration
+	}{
+		{
+			name: "start rollout with canary",
+			sss:  testsss.Build(rollout(0, 0)),
+			existing: []runtime.Object{
+				canaryCD,
+				otherCD,
+				clusterSync("canary-cd", 1, hiveintv1alpha1.SuccessSyncSetResult),
+				clusterSync("other-cd", 1, hiveintv1alpha1.SuccessSyncSetResult),
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				Phase:             hivev1.CanarySelectorSyncSetRolloutPhase,
+				Clusters:          2,
+				RolledOutClusters: 1,
+				Message:           "Waiting for 1 clusters to apply generation 2",
+			},
+			expectNewBatch: true,
+		},
+		{
+			name: "start rollout without canary",
+			sss: testsss.Build(testsss.WithRollout(&hivev1.SelectorSyncSetRollout{
+				BatchPercent: 100,
+			})),
+			existing: []runtime.Object{canaryCD, otherCD},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				Phase:             hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Percent:           100,
+				Clusters:          2,
+				RolledOutClusters: 2,
+				Message:           "Waiting for 2 clusters to apply generation 2",
+			},
+			expectNewBatch: true,
+		},
+		{
+			name: "canary updated",
+			sss: testsss.Build(
+				rollout(0, 0),
+				rolloutStatus(hivev1.CanarySelectorSyncSetRolloutPhase, 0, now.Add(-time.Hour)),
+			),
+			existing: []runtime.Object{
+				canaryCD,
+				otherCD,
+				clusterSync("canary-cd", 2, hiveintv1alpha1.SuccessSyncSetResult),
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				Phase:             hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				Percent:           50,
+				Clusters:          2,
+				RolledOutClusters: 1,
+				UpdatedClusters:   1,
+				Message:           "Rolling out generation 2 to 50% of clusters",
+			},
+			expectNewBatch: true,
+		},
+		{
+			name: "pause between batches",
+			sss: testsss.Build(
+				rollout(time.Hour, 0),
+				rolloutStatus(hivev1.CanarySelectorSyncSetRolloutPhase, 0, now.Add(-20*time.Minute)),
+			),
+			existing: []runtime.Object{
+				canaryCD,
+				clusterSync("canary-cd", 2, hiveintv1alpha1.SuccessSyncSetResult),
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				Phase:             hivev1.CanarySelectorSyncSetRolloutPhase,
+				Clusters:          1,
+				RolledOutClusters: 1,
+				UpdatedClusters:   1,
+				LastBatchTime:     &metav1.Time{Time: now.Add(-20 * time.Minute)},
+				Message:           "Pausing before rolling out generation 2 to the next batch of clusters",
+			},
+			expectedRequeueAfter: 40 * time.Minute,
+		},
+		{
+			name: "halt on failure",
+			sss: testsss.Build(
+				rollout(0, 0),
+				rolloutStatus(hivev1.CanarySelectorSyncSetRolloutPhase, 0, now.Add(-time.Hour)),
+			),
+			existing: []runtime.Object{
+				canaryCD,
+				otherCD,
+				clusterSync("canary-cd", 2, hiveintv1alpha1.FailureSyncSetResult),
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				Phase:             hivev1.HaltedSelectorSyncSetRolloutPhase,
+				Clusters:          2,
+				RolledOutClusters: 1,
+				FailedClusters:    1,
+				LastBatchTime:     &metav1.Time{Time: now.Add(-time.Hour)},
+				Message:           "Halted since 1 of 1 clusters failed to apply generation 2",
+			},
+		},
+		{
+			name: "failures within threshold",
+			sss: testsss.Build(
+				rollout(0, 50),
+				rolloutStatus(hivev1.ProgressingSelectorSyncSetRolloutPhase, 100, now.Add(-time.Hour)),
+			),
+			existing: []runtime.Object{
+				canaryCD,
+				otherCD,
+				clusterSync("canary-cd", 2, hiveintv1alpha1.SuccessSyncSetResult),
+				clusterSync("other-cd", 2, hiveintv1alpha1.FailureSyncSetResult),
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				Phase:             hivev1.CompleteSelectorSyncSetRolloutPhase,
+				Percent:           100,
+				Clusters:          2,
+				RolledOutClusters: 2,
+				UpdatedClusters:   1,
+				FailedClusters:    1,
+				LastBatchTime:     &metav1.Time{Time: now.Add(-time.Hour)},
+				Message:           "Generation 2 has been rolled out to all clusters",
+			},
+		},
+		{
+			name: "unreachable cluster does not hold up rollout",
+			sss: testsss.Build(
+				rollout(0, 0),
+				rolloutStatus(hivev1.ProgressingSelectorSyncSetRolloutPhase, 100, now.Add(-time.Hour)),
+			),
+			existing: []runtime.Object{
+				canaryCD,
+				unreachableCD,
+				clusterSync("canary-cd", 2, hiveintv1alpha1.SuccessSyncSetResult),
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				Phase:             hivev1.CompleteSelectorSyncSetRolloutPhase,
+				Percent:           100,
+				Clusters:          2,
+				RolledOutClusters: 1,
+				UpdatedClusters:   1,
+				LastBatchTime:     &metav1.Time{Time: now.Add(-time.Hour)},
+				Message:           "Generation 2 has been rolled out to all clusters",
+			},
+		},
+		{
+			name: "rollout removed",
+			sss: testsss.Build(
+				rolloutStatus(hivev1.ProgressingSelectorSyncSetRolloutPhase, 50, now.Add(-time.Hour)),
+			),
+			existing: []runtime.Object{canaryCD},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := log.New()
+			logger.SetLevel(log.DebugLevel)
+			sss := tc.sss
+			sss.Name = sssName
+			sss.Generation = 2
+			sss.Spec.ClusterDeploymentSelector = metav1.LabelSelector{MatchLabels: map[string]string{"test-label-key": "test-label-value"}}
+			c := fake.NewFakeClientWithScheme(scheme, append(tc.existing, sss)...)
+			r := &ReconcileSelectorSyncSetRollout{
+				Client: c,
+				logger: logger,
+			}
+
+			startTime := time.Now()
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: sssName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			if tc.expectedRequeueAfter > 0 {
+				assert.InDelta(t, tc.expectedRequeueAfter.Seconds(), result.RequeueAfter.Seconds(), time.Since(now).Seconds()+1, "unexpected requeue after")
+			} else {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
+			}
+
+			actual := &hivev1.SelectorSyncSet{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Name: sssName}, actual), "unexpected error getting SelectorSyncSet")
+			status := actual.Status.Rollout
+			if tc.expectedStatus == nil {
+				assert.Nil(t, status, "expected no rollout status")
+				return
+			}
+			require.NotNil(t, status, "expected rollout status")
+			if tc.expectNewBatch {
+				if assert.NotNil(t, status.LastBatchTime, "expected last batch time") {
+					assert.False(t, status.LastBatchTime.Time.Before(startTime.Truncate(time.Second)), "expected last batch time of now")
+				}
+				status.LastBatchTime = nil
+			} else if status.LastBatchTime != nil {
+				assert.Equal(t, tc.expectedStatus.LastBatchTime.Unix(), status.LastBatchTime.Unix(), "unexpected last batch time")
+				status.LastBatchTime = tc.expectedStatus.LastBatchTime
+			}
+			tc.expectedStatus.ObservedGeneration = 2
+			assert.Equal(t, tc.expectedStatus, status, "unexpected rollout status")
+		})
+	}
+}
+
+func TestRequestsForClusterSync(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	cd := testcd.FullBuilder(testNamespace, "test-cd", scheme).Build(testcd.WithLabel("test-label-key", "test-label-value"))
+	rollout := testsss.WithRollout(&hivev1.SelectorSyncSetRollout{BatchPercent: 50})
+	existing := []runtime.Object{
+		cd,
+		testsss.Build(testsss.WithName("matching-rollout"), testsss.WithLabelSelector("test-label-key", "test-label-value"), rollout),
+		testsss.Build(testsss.WithName("matching-no-rollout"), testsss.WithLabelSelector("test-label-key", "test-label-value")),
+		testsss.Build(testsss.WithName("not-matching-rollout"), testsss.WithLabelSelector("test-label-key", "other-value"), rollout),
+	}
+	clusterSync := testcs.FullBuilder(testNamespace, "test-cd", scheme).Build(
+		testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{Name: "matching-rollout"}),
+		testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{Name: "matching-no-rollout"}),
+		testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{Name: "not-matching-rollout"}),
+		testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{Name: "deleted"}),
+	)
+	c := fake.NewFakeClientWithScheme(scheme, existing...)
+
+	requests := requestsForClusterSync(c, log.New())(clusterSync)
+
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "matching-rollout"}}}, requests, "unexpected requests")
+}
+
+func TestClusterSyncUpdateAffectsRollout(t *testing.T) {
+	syncStatus := func(name string, generation int64, result hiveintv1alpha1.SyncSetResult) testcs.Option {
+		return testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{
+			Name:               name,
+			ObservedGeneration: generation,
+			Result:             result,
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+	cases := []struct {
+		name     string
+		old      *hiveintv1alpha1.ClusterSync
+		new      *hiveintv1alpha1.ClusterSync
+		expected bool
+	}{
+		{
+			name:     "reapplied",
+			old:      testcs.Build(syncStatus("foo", 1, hiveintv1alpha1.SuccessSyncSetResult)),
+			new:      testcs.Build(syncStatus("foo", 1, hiveintv1alpha1.SuccessSyncSetResult)),
+			expected: false,
+		},
+		{
+			name:     "new generation applied",
+			old:      testcs.Build(syncStatus("foo", 1, hiveintv1alpha1.SuccessSyncSetResult)),
+			new:      testcs.Build(syncStatus("foo", 2, hiveintv1alpha1.SuccessSyncSetResult)),
+			expected: true,
+		},
+		{
+			name:     "failed",
+			old:      testcs.Build(syncStatus("foo", 1, hiveintv1alpha1.SuccessSyncSetResult)),
+			new:      testcs.Build(syncStatus("foo", 1, hiveintv1alpha1.FailureSyncSetResult)),
+			expected: true,
+		},
+		{
+			name:     "selectorsyncset added",
+			old:      testcs.Build(syncStatus("foo", 1, hiveintv1alpha1.SuccessSyncSetResult)),
+			new:      testcs.Build(syncStatus("foo", 1, hiveintv1alpha1.SuccessSyncSetResult), syncStatus("bar", 1, hiveintv1alpha1.SuccessSyncSetResult)),
+			expected: true,
+		},
+		{
+			name:     "selectorsyncset replaced",
+			old:      testcs.Build(syncStatus("foo", 1, hiveintv1alpha1.SuccessSyncSetResult)),
+			new:      testcs.Build(syncStatus("bar", 1, hiveintv1alpha1.SuccessSyncSetResult)),
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := clusterSyncUpdateAffectsRollout(event.UpdateEvent{ObjectOld: tc.old, ObjectNew: tc.new})
+			assert.Equal(t, tc.expected, actual, "unexpected result")
+		})
+	}
+}
@@ -141,3 +141,42 @@ func IsClusterDeploymentErrorUpdateEvent(evt event.UpdateEvent) bool {
 
 	return false
 }
+
+// GetClusterRegion returns the region of the cluster of an AWS, Azure, GCP or IBM Cloud ClusterDeployment, and an
+// empty string for other platforms. An install config override of the provision retry policy may have installed the
+// cluster in another region than the one of the platform, which is then recorded in the platform status. Anything
+// acting on the cloud resources of an installed cluster must get its region from here.
+func GetClusterRegion(cd *hivev1.ClusterDeployment) string {
+	if cd.Status.Platform != nil && cd.Status.Platform.Region != "" {
+		return cd.Status.Platform.Region
+	}
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		return cd.Spec.Platform.AWS.Region
+	case cd.Spec.Platform.Azure != nil:
+		return cd.Spec.Platform.Azure.Region
+	case cd.Spec.Platform.GCP != nil:
+		return cd.Spec.Platform.GCP.Region
+	case cd.Spec.Platform.IBMCloud != nil:
+		return cd.Spec.Platform.IBMCloud.Region
+	}
+	return ""
+}
+
+// ClusterDeploymentWithRegion returns a copy of the ClusterDeployment with the region of its AWS, Azure or GCP platform,
+// and the region of the cluster recorded in its platform status, set to the given region.
+func ClusterDeploymentWithRegion(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeployment {
+	cd = cd.DeepCopy()
+	switch platform := cd.Spec.Platform; {
+	case platform.AWS != nil:
+		platform.AWS.Region = region
+	case platform.Azure != nil:
+		platform.Azure.Region = region
+	case platform.GCP != nil:
+		platform.GCP.Region = region
+	}
+	if cd.Status.Platform != nil && cd.Status.Platform.Region != "" {
+		cd.Status.Platform.Region = region
+	}
+	return cd
+}
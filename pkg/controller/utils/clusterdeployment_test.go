@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/test/clusterdeployment"
 	"github.com/openshift/hive/pkg/test/generic"
@@ -436,3 +437,44 @@ func TestIsClusterDeploymentErrorUpdateEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestGetClusterRegion(t *testing.T) {
+	awsCD := func(region, statusRegion string) *hivev1.ClusterDeployment {
+		cd := clusterdeployment.Build()
+		cd.Spec.Platform.AWS = &hivev1aws.Platform{Region: region}
+		if statusRegion != "" {
+			cd.Status.Platform = &hivev1.PlatformStatus{Region: statusRegion}
+		}
+		return cd
+	}
+	cases := []struct {
+		name           string
+		cd             *hivev1.ClusterDeployment
+		expectedRegion string
+	}{
+		{
+			name:           "region of the platform",
+			cd:             awsCD("us-east-1", ""),
+			expectedRegion: "us-east-1",
+		},
+		{
+			name:           "region changed by install config override",
+			cd:             awsCD("us-east-1", "us-west-2"),
+			expectedRegion: "us-west-2",
+		},
+		{
+			name:           "copy in another region",
+			cd:             ClusterDeploymentWithRegion(awsCD("us-east-1", "us-west-2"), "eu-west-1"),
+			expectedRegion: "eu-west-1",
+		},
+		{
+			name: "platform without region",
+			cd:   clusterdeployment.Build(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedRegion, GetClusterRegion(tc.cd), "unexpected region")
+		})
+	}
+}
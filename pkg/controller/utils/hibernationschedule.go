@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+	// Embed the time zone database so that schedules can be evaluated regardless of whether
+	// the image provides one.
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ParseHibernationSchedule parses the resume and hibernate cron expressions of the given schedule,
+// evaluated in the schedule's time zone.
+func ParseHibernationSchedule(schedule *hivev1.HibernationSchedule) (resume cron.Schedule, hibernate cron.Schedule, err error) {
+	if schedule.TimeZone != "" {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid time zone %q", schedule.TimeZone)
+		}
+	}
+	parse := func(spec string) (cron.Schedule, error) {
+		if schedule.TimeZone != "" {
+			spec = fmt.Sprintf("CRON_TZ=%s %s", schedule.TimeZone, spec)
+		}
+		return cron.ParseStandard(spec)
+	}
+	if resume, err = parse(schedule.Resume); err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid resume schedule %q", schedule.Resume)
+	}
+	if hibernate, err = parse(schedule.Hibernate); err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid hibernate schedule %q", schedule.Hibernate)
+	}
+	return resume, hibernate, nil
+}
+
+// HibernationScheduleOverriddenUntil returns the time until which the HibernationSchedule of the
+// ClusterDeployment is overridden by the hive.openshift.io/hibernation-schedule-override-until
+// annotation. A zero time is returned if the annotation is not set.
+func HibernationScheduleOverriddenUntil(cd *hivev1.ClusterDeployment) (time.Time, error) {
+	value, ok := cd.Annotations[constants.HibernationScheduleOverrideUntilAnnotation]
+	if !ok || value == "" {
+		return time.Time{}, nil
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid %s annotation", constants.HibernationScheduleOverrideUntilAnnotation)
+	}
+	return until, nil
+}
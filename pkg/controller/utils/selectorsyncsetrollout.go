@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"hash/fnv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// IsSelectorSyncSetRolledOutToCluster returns whether the current generation of the SelectorSyncSet may be applied to
+// the ClusterDeployment. This is always the case for a SelectorSyncSet without a rollout strategy. Otherwise, the
+// generation may be applied once the rollout of the generation has reached the ClusterDeployment.
+func IsSelectorSyncSetRolledOutToCluster(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment) (bool, error) {
+	if sss.Spec.Rollout == nil {
+		return true, nil
+	}
+	status := sss.Status.Rollout
+	if status == nil || status.ObservedGeneration != sss.Generation {
+		// The rollout of the generation has not been started yet.
+		return false, nil
+	}
+	if status.Phase == hivev1.CompleteSelectorSyncSetRolloutPhase {
+		return true, nil
+	}
+	isCanary, err := IsCanaryForSelectorSyncSetRollout(sss, cd)
+	if err != nil || isCanary {
+		return isCanary, err
+	}
+	return SelectorSyncSetRolloutBucket(sss, cd) < status.Percent, nil
+}
+
+// IsCanaryForSelectorSyncSetRollout returns whether the ClusterDeployment is a canary cluster for the rollout strategy
+// of the SelectorSyncSet.
+func IsCanaryForSelectorSyncSetRollout(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment) (bool, error) {
+	if sss.Spec.Rollout == nil || sss.Spec.Rollout.CanarySelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(sss.Spec.Rollout.CanarySelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(cd.Labels)), nil
+}
+
+// SelectorSyncSetRolloutBucket returns the bucket, from 0 to 99, that the ClusterDeployment falls in for the rollout of
+// the SelectorSyncSet. A generation is rolled out to the ClusterDeployment once the percentage of the rollout is greater
+// than the bucket. The bucket is stable for the SelectorSyncSet, but differs between SelectorSyncSets so that the same
+// clusters are not always the first to receive changes.
+func SelectorSyncSetRolloutBucket(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment) int32 {
+	h := fnv.New32a()
+	h.Write([]byte(sss.Name + "/" + cd.Namespace + "/" + cd.Name))
+	return int32(h.Sum32() % 100)
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestIsSelectorSyncSetRolledOutToCluster(t *testing.T) {
+	canaryCD := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "test-namespace",
+		Name:      "canary",
+		Labels:    map[string]string{"canary": "true"},
+	}}
+	otherCD := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "test-namespace",
+		Name:      "other",
+	}}
+	rollout := &hivev1.SelectorSyncSetRollout{
+		CanarySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+		BatchPercent:   10,
+	}
+	rolloutStatus := func(generation int64, phase hivev1.SelectorSyncSetRolloutPhase, percent int32) *hivev1.SelectorSyncSetRolloutStatus {
+		return &hivev1.SelectorSyncSetRolloutStatus{
+			ObservedGeneration: generation,
+			Phase:              phase,
+			Percent:            percent,
+		}
+	}
+
+	cases := []struct {
+		name           string
+		rollout        *hivev1.SelectorSyncSetRollout
+		status         *hivev1.SelectorSyncSetRolloutStatus
+		cd             *hivev1.ClusterDeployment
+		expectedResult bool
+	}{
+		{
+			name:           "no rollout",
+			cd:             otherCD,
+			expectedResult: true,
+		},
+		{
+			name:    "rollout not started",
+			rollout: rollout,
+			cd:      canaryCD,
+		},
+		{
+			name:    "rollout of previous generation",
+			rollout: rollout,
+			status:  rolloutStatus(1, hivev1.CompleteSelectorSyncSetRolloutPhase, 100),
+			cd:      otherCD,
+		},
+		{
+			name:           "canary",
+			rollout:        rollout,
+			status:         rolloutStatus(2, hivev1.CanarySelectorSyncSetRolloutPhase, 0),
+			cd:             canaryCD,
+			expectedResult: true,
+		},
+		{
+			name:    "not a canary",
+			rollout: rollout,
+			status:  rolloutStatus(2, hivev1.CanarySelectorSyncSetRolloutPhase, 0),
+			cd:      otherCD,
+		},
+		{
+			name:           "all batches",
+			rollout:        rollout,
+			status:         rolloutStatus(2, hivev1.ProgressingSelectorSyncSetRolloutPhase, 100),
+			cd:             otherCD,
+			expectedResult: true,
+		},
+		{
+			name:           "complete",
+			rollout:        rollout,
+			status:         rolloutStatus(2, hivev1.CompleteSelectorSyncSetRolloutPhase, 0),
+			cd:             otherCD,
+			expectedResult: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sss := &hivev1.SelectorSyncSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-selectorsyncset", Generation: 2},
+				Spec:       hivev1.SelectorSyncSetSpec{Rollout: tc.rollout},
+				Status:     hivev1.SelectorSyncSetStatus{Rollout: tc.status},
+			}
+			result, err := IsSelectorSyncSetRolledOutToCluster(sss, tc.cd)
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedResult, result, "unexpected result")
+		})
+	}
+}
+
+func TestSelectorSyncSetRolloutBucket(t *testing.T) {
+	sss := &hivev1.SelectorSyncSet{ObjectMeta: metav1.ObjectMeta{Name: "test-selectorsyncset"}}
+	buckets := map[int32]bool{}
+	for i := 0; i < 1000; i++ {
+		cd := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace: fmt.Sprintf("namespace-%d", i),
+			Name:      "cluster",
+		}}
+		bucket := SelectorSyncSetRolloutBucket(sss, cd)
+		assert.True(t, bucket >= 0 && bucket < 100, "bucket out of range: %d", bucket)
+		assert.Equal(t, bucket, SelectorSyncSetRolloutBucket(sss, cd), "expected stable bucket")
+		buckets[bucket] = true
+	}
+	assert.Greater(t, len(buckets), 90, "expected clusters to be spread across the buckets")
+}
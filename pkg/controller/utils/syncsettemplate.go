@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// SyncSetTemplateData is the data that templates in a SyncSet or SelectorSyncSet are evaluated with.
+type SyncSetTemplateData struct {
+	// Name is the name of the ClusterDeployment.
+	Name string
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string
+	// ClusterName is the name of the cluster.
+	ClusterName string
+	// BaseDomain is the base domain of the cluster.
+	BaseDomain string
+	// InfraID is the infra ID of the cluster. It is empty until the cluster has been provisioned.
+	InfraID string
+	// ClusterID is the cluster ID of the cluster. It is empty until the cluster has been provisioned.
+	ClusterID string
+	// Platform is the platform of the cluster, such as aws or gcp.
+	Platform string
+	// Region is the region of the cluster.
+	Region string
+	// Labels are the labels of the ClusterDeployment.
+	Labels map[string]string
+	// Annotations are the annotations of the ClusterDeployment.
+	Annotations map[string]string
+	// Spec is the spec of the ClusterDeployment.
+	Spec hivev1.ClusterDeploymentSpec
+}
+
+// NewSyncSetTemplateData builds the data that templates are evaluated with for the ClusterDeployment.
+func NewSyncSetTemplateData(cd *hivev1.ClusterDeployment) *SyncSetTemplateData {
+	data := &SyncSetTemplateData{
+		Name:        cd.Name,
+		Namespace:   cd.Namespace,
+		ClusterName: cd.Spec.ClusterName,
+		BaseDomain:  cd.Spec.BaseDomain,
+		Platform:    cd.Labels[hivev1.HiveClusterPlatformLabel],
+		Region:      cd.Labels[hivev1.HiveClusterRegionLabel],
+		Labels:      cd.Labels,
+		Annotations: cd.Annotations,
+		Spec:        cd.Spec,
+	}
+	if cd.Spec.ClusterMetadata != nil {
+		data.InfraID = cd.Spec.ClusterMetadata.InfraID
+		data.ClusterID = cd.Spec.ClusterMetadata.ClusterID
+	}
+	return data
+}
+
+// RenderSyncSetTemplate evaluates the text as a template with the data. Text that does not contain a template action
+// is returned as is.
+func RenderSyncSetTemplate(text string, data *SyncSetTemplateData) (string, error) {
+	tmpl, err := parseSyncSetTemplate(text)
+	if err != nil || tmpl == nil {
+		return text, err
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderSyncSetTemplateResource evaluates each of the string values in the resource as a template with the data. The
+// resource is returned as JSON.
+func RenderSyncSetTemplateResource(raw []byte, data *SyncSetTemplateData) ([]byte, error) {
+	obj, err := decodeSyncSetTemplateResource(raw)
+	if err != nil {
+		return nil, err
+	}
+	obj, err = renderSyncSetTemplateValue(obj, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+// ValidateSyncSetTemplate checks that the text is a valid template.
+func ValidateSyncSetTemplate(text string) error {
+	_, err := parseSyncSetTemplate(text)
+	return err
+}
+
+// ValidateSyncSetTemplateResource checks that each of the string values in the resource is a valid template.
+func ValidateSyncSetTemplateResource(raw []byte) error {
+	obj, err := decodeSyncSetTemplateResource(raw)
+	if err != nil {
+		return err
+	}
+	_, err = renderSyncSetTemplateValue(obj, nil)
+	return err
+}
+
+// renderSyncSetTemplateValue walks a decoded JSON value, evaluating the map keys and the strings in it as templates.
+// When the data is nil, the templates are only parsed.
+func renderSyncSetTemplateValue(value interface{}, data *SyncSetTemplateData) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if data == nil {
+			return v, ValidateSyncSetTemplate(v)
+		}
+		return RenderSyncSetTemplate(v, data)
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			renderedKey, err := renderSyncSetTemplateValue(key, data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "key %q", key)
+			}
+			renderedElem, err := renderSyncSetTemplateValue(elem, data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "value of %q", key)
+			}
+			rendered[renderedKey.(string)] = renderedElem
+		}
+		return rendered, nil
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, elem := range v {
+			renderedElem, err := renderSyncSetTemplateValue(elem, data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "item %d", i)
+			}
+			rendered[i] = renderedElem
+		}
+		return rendered, nil
+	}
+	return value, nil
+}
+
+// decodeSyncSetTemplateResource decodes a YAML or JSON resource, keeping numbers as they are written.
+func decodeSyncSetTemplateResource(raw []byte) (interface{}, error) {
+	jsonBytes, err := yaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	var obj interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func parseSyncSetTemplate(text string) (*template.Template, error) {
+	if !strings.Contains(text, "{{") {
+		return nil, nil
+	}
+	return template.New("syncset").Option("missingkey=error").Parse(text)
+}
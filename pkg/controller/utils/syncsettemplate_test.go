@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+)
+
+func TestRenderSyncSetTemplateResource(t *testing.T) {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-namespace",
+			Name:      "test-cd",
+			Labels: map[string]string{
+				hivev1.HiveClusterPlatformLabel: "aws",
+				hivev1.HiveClusterRegionLabel:   "us-east-1",
+				"team":                          "test-team",
+			},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: "test-cluster",
+			BaseDomain:  "example.com",
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				InfraID:   "test-infra-id",
+				ClusterID: "test-cluster-id",
+			},
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{Region: "us-east-1"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name           string
+		resource       string
+		expectedResult string
+		expectErr      bool
+	}{
+		{
+			name:           "no templates",
+			resource:       `{"kind":"ConfigMap","data":{"replicas":12345678901234567890}}`,
+			expectedResult: `{"data":{"replicas":12345678901234567890},"kind":"ConfigMap"}`,
+		},
+		{
+			name:           "cluster variables",
+			resource:       `{"metadata":{"name":"{{ .ClusterName }}-config"},"data":{"id":"{{ .InfraID }}/{{ .ClusterID }}","domain":"{{ .Name }}.{{ .BaseDomain }}"}}`,
+			expectedResult: `{"data":{"domain":"test-cd.example.com","id":"test-infra-id/test-cluster-id"},"metadata":{"name":"test-cluster-config"}}`,
+		},
+		{
+			name:           "platform variables",
+			resource:       `{"data":{"platform":"{{ .Platform }}","region":"{{ .Region }}","specRegion":"{{ .Spec.Platform.AWS.Region }}"}}`,
+			expectedResult: `{"data":{"platform":"aws","region":"us-east-1","specRegion":"us-east-1"}}`,
+		},
+		{
+			name:           "labels in keys and lists",
+			resource:       `{"metadata":{"labels":{"{{ index .Labels \"team\" }}":"true"}},"items":["{{ .Labels.team }}",1]}`,
+			expectedResult: `{"items":["test-team",1],"metadata":{"labels":{"test-team":"true"}}}`,
+		},
+		{
+			name:           "yaml resource",
+			resource:       "metadata:\n  name: '{{ .ClusterName }}'\n",
+			expectedResult: `{"metadata":{"name":"test-cluster"}}`,
+		},
+		{
+			name:      "missing label",
+			resource:  `{"data":{"value":"{{ .Labels.missing }}"}}`,
+			expectErr: true,
+		},
+		{
+			name:      "unknown variable",
+			resource:  `{"data":{"value":"{{ .Unknown }}"}}`,
+			expectErr: true,
+		},
+		{
+			name:      "invalid template",
+			resource:  `{"data":{"value":"{{ .ClusterName "}}`,
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := RenderSyncSetTemplateResource([]byte(tc.resource), NewSyncSetTemplateData(cd))
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			if assert.NoError(t, err, "unexpected error") {
+				assert.JSONEq(t, tc.expectedResult, string(result), "unexpected result")
+			}
+		})
+	}
+}
+
+func TestValidateSyncSetTemplateResource(t *testing.T) {
+	assert.NoError(t, ValidateSyncSetTemplateResource([]byte(`{"data":{"value":"{{ .Labels.missing }}"}}`)))
+	assert.Error(t, ValidateSyncSetTemplateResource([]byte(`{"data":{"value":"{{ .ClusterName "}}`)))
+	assert.Error(t, ValidateSyncSetTemplateResource([]byte(`{"data":{"{{ end }}":"value"}}`)))
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -392,3 +393,24 @@ func SafeDelete(cl client.Client, ctx context.Context, obj client.Object) error
 	rv := obj.GetResourceVersion()
 	return cl.Delete(ctx, obj, client.Preconditions{ResourceVersion: &rv})
 }
+
+// ValidateFailedProvisionConfig checks that the logs of failed provisions are uploaded to at most one object store.
+func ValidateFailedProvisionConfig(config *hivev1.FailedProvisionConfig) error {
+	var stores []string
+	if config.AWS != nil {
+		stores = append(stores, "aws")
+	}
+	if config.GCP != nil {
+		stores = append(stores, "gcp")
+	}
+	if config.Azure != nil {
+		stores = append(stores, "azure")
+	}
+	if config.S3Compatible != nil {
+		stores = append(stores, "s3Compatible")
+	}
+	if len(stores) > 1 {
+		return fmt.Errorf("only one of aws, gcp, azure and s3Compatible may be set in failedProvisionConfig, but %s are set", strings.Join(stores, ", "))
+	}
+	return nil
+}
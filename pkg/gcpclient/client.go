@@ -1,14 +1,19 @@
 package gcpclient
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 	compute "google.golang.org/api/compute/v1"
@@ -16,6 +21,7 @@ import (
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	serviceusage "google.golang.org/api/serviceusage/v1"
+	storage "google.golang.org/api/storage/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -41,6 +47,8 @@ type Client interface {
 
 	DeleteManagedZone(managedZone string) error
 
+	PatchManagedZone(managedZone string, patch *dns.ManagedZone) error
+
 	ListComputeZones(ListComputeZonesOptions) (*compute.ZoneList, error)
 
 	ListComputeImages(ListComputeImagesOptions) (*compute.ImageList, error)
@@ -50,6 +58,46 @@ type Client interface {
 	StopInstance(*compute.Instance) error
 
 	StartInstance(*compute.Instance) error
+
+	GetComputeRegion(region string) (*compute.Region, error)
+
+	GetComputeProject() (*compute.Project, error)
+
+	GetComputeMachineType(zone, machineType string) (*compute.MachineType, error)
+
+	GetForwardingRule(region, name string) (*compute.ForwardingRule, error)
+
+	CreateForwardingRule(region string, forwardingRule *compute.ForwardingRule) (*compute.ForwardingRule, error)
+
+	DeleteForwardingRule(region, name string) error
+
+	GetAddress(region, name string) (*compute.Address, error)
+
+	CreateAddress(region string, address *compute.Address) (*compute.Address, error)
+
+	DeleteAddress(region, name string) error
+
+	GetSubnetwork(region, name string) (*compute.Subnetwork, error)
+
+	CreateSubnetwork(region string, subnetwork *compute.Subnetwork) (*compute.Subnetwork, error)
+
+	DeleteSubnetwork(region, name string) error
+
+	GetFirewall(name string) (*compute.Firewall, error)
+
+	CreateFirewall(firewall *compute.Firewall) (*compute.Firewall, error)
+
+	DeleteFirewall(name string) error
+
+	GetServiceAttachment(region, name string) (*ServiceAttachment, error)
+
+	CreateServiceAttachment(region string, serviceAttachment *ServiceAttachment) (*ServiceAttachment, error)
+
+	PatchServiceAttachment(region string, serviceAttachment *ServiceAttachment) (*ServiceAttachment, error)
+
+	DeleteServiceAttachment(region, name string) error
+
+	UploadObject(bucket, name string, content io.Reader) error
 }
 
 // ListManagedZonesOptions are the options for listing managed zones.
@@ -67,6 +115,31 @@ type ListResourceRecordSetsOptions struct {
 	Type       string
 }
 
+// ServiceAttachment is a GCP Private Service Connect service attachment. The compute API version used
+// does not know about service attachments, so they are managed with plain REST calls.
+type ServiceAttachment struct {
+	Name                 string                                   `json:"name,omitempty"`
+	SelfLink             string                                   `json:"selfLink,omitempty"`
+	Fingerprint          string                                   `json:"fingerprint,omitempty"`
+	TargetService        string                                   `json:"targetService,omitempty"`
+	ConnectionPreference string                                   `json:"connectionPreference,omitempty"`
+	ConsumerAcceptLists  []*ServiceAttachmentConsumerProjectLimit `json:"consumerAcceptLists,omitempty"`
+	NatSubnets           []string                                 `json:"natSubnets,omitempty"`
+	ConnectedEndpoints   []*ServiceAttachmentConnectedEndpoint    `json:"connectedEndpoints,omitempty"`
+}
+
+// ServiceAttachmentConsumerProjectLimit is a project allowed to connect to a service attachment.
+type ServiceAttachmentConsumerProjectLimit struct {
+	ProjectIdOrNum  string `json:"projectIdOrNum,omitempty"`
+	ConnectionLimit int64  `json:"connectionLimit,omitempty"`
+}
+
+// ServiceAttachmentConnectedEndpoint is an endpoint connected to a service attachment.
+type ServiceAttachmentConnectedEndpoint struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
 type ListComputeInstancesOptions struct {
 	Filter string
 	Fields string
@@ -79,10 +152,13 @@ type gcpClient struct {
 	computeClient              *compute.Service
 	serviceUsageClient         *serviceusage.Service
 	dnsClient                  *dns.Service
+	storageClient              *storage.Service
 }
 
 const (
 	defaultCallTimeout = 2 * time.Minute
+
+	userAgent = "openshift.io hive/v1"
 )
 
 func contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
@@ -125,6 +201,13 @@ func (c *gcpClient) DeleteManagedZone(managedZone string) error {
 	return c.dnsClient.ManagedZones.Delete(c.projectName, managedZone).Context(ctx).Do()
 }
 
+func (c *gcpClient) PatchManagedZone(managedZone string, patch *dns.ManagedZone) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.dnsClient.ManagedZones.Patch(c.projectName, managedZone, patch).Context(ctx).Do()
+	return err
+}
+
 func (c *gcpClient) ListResourceRecordSets(managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error) {
 	ctx, cancel := contextWithTimeout(context.TODO())
 	defer cancel()
@@ -265,6 +348,255 @@ func (c *gcpClient) StartInstance(instance *compute.Instance) error {
 	return nil
 }
 
+func (c *gcpClient) GetComputeRegion(region string) (*compute.Region, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.Regions.Get(c.projectName, region).Context(ctx).Do()
+}
+
+func (c *gcpClient) GetComputeProject() (*compute.Project, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.Projects.Get(c.projectName).Context(ctx).Do()
+}
+
+func (c *gcpClient) GetComputeMachineType(zone, machineType string) (*compute.MachineType, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.MachineTypes.Get(c.projectName, zone, machineType).Context(ctx).Do()
+}
+
+func (c *gcpClient) GetForwardingRule(region, name string) (*compute.ForwardingRule, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.ForwardingRules.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateForwardingRule(region string, forwardingRule *compute.ForwardingRule) (*compute.ForwardingRule, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op, err := c.computeClient.ForwardingRules.Insert(c.projectName, region, forwardingRule).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.waitForOperation(ctx, op); err != nil {
+		return nil, err
+	}
+	return c.computeClient.ForwardingRules.Get(c.projectName, region, forwardingRule.Name).Context(ctx).Do()
+}
+
+func (c *gcpClient) DeleteForwardingRule(region, name string) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op, err := c.computeClient.ForwardingRules.Delete(c.projectName, region, name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return c.waitForOperation(ctx, op)
+}
+
+func (c *gcpClient) GetAddress(region, name string) (*compute.Address, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.Addresses.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateAddress(region string, address *compute.Address) (*compute.Address, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op, err := c.computeClient.Addresses.Insert(c.projectName, region, address).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.waitForOperation(ctx, op); err != nil {
+		return nil, err
+	}
+	return c.computeClient.Addresses.Get(c.projectName, region, address.Name).Context(ctx).Do()
+}
+
+func (c *gcpClient) DeleteAddress(region, name string) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op, err := c.computeClient.Addresses.Delete(c.projectName, region, name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return c.waitForOperation(ctx, op)
+}
+
+func (c *gcpClient) GetSubnetwork(region, name string) (*compute.Subnetwork, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.Subnetworks.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateSubnetwork(region string, subnetwork *compute.Subnetwork) (*compute.Subnetwork, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op, err := c.computeClient.Subnetworks.Insert(c.projectName, region, subnetwork).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.waitForOperation(ctx, op); err != nil {
+		return nil, err
+	}
+	return c.computeClient.Subnetworks.Get(c.projectName, region, subnetwork.Name).Context(ctx).Do()
+}
+
+func (c *gcpClient) DeleteSubnetwork(region, name string) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op, err := c.computeClient.Subnetworks.Delete(c.projectName, region, name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return c.waitForOperation(ctx, op)
+}
+
+func (c *gcpClient) GetFirewall(name string) (*compute.Firewall, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.Firewalls.Get(c.projectName, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateFirewall(firewall *compute.Firewall) (*compute.Firewall, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op, err := c.computeClient.Firewalls.Insert(c.projectName, firewall).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.waitForOperation(ctx, op); err != nil {
+		return nil, err
+	}
+	return c.computeClient.Firewalls.Get(c.projectName, firewall.Name).Context(ctx).Do()
+}
+
+func (c *gcpClient) DeleteFirewall(name string) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op, err := c.computeClient.Firewalls.Delete(c.projectName, name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return c.waitForOperation(ctx, op)
+}
+
+func (c *gcpClient) GetServiceAttachment(region, name string) (*ServiceAttachment, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	serviceAttachment := &ServiceAttachment{}
+	if err := c.serviceAttachmentsRequest(ctx, http.MethodGet, region, name, nil, serviceAttachment); err != nil {
+		return nil, err
+	}
+	return serviceAttachment, nil
+}
+
+func (c *gcpClient) CreateServiceAttachment(region string, serviceAttachment *ServiceAttachment) (*ServiceAttachment, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op := &compute.Operation{}
+	if err := c.serviceAttachmentsRequest(ctx, http.MethodPost, region, "", serviceAttachment, op); err != nil {
+		return nil, err
+	}
+	if err := c.waitForOperation(ctx, op); err != nil {
+		return nil, err
+	}
+	created := &ServiceAttachment{}
+	if err := c.serviceAttachmentsRequest(ctx, http.MethodGet, region, serviceAttachment.Name, nil, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (c *gcpClient) PatchServiceAttachment(region string, serviceAttachment *ServiceAttachment) (*ServiceAttachment, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op := &compute.Operation{}
+	if err := c.serviceAttachmentsRequest(ctx, http.MethodPatch, region, serviceAttachment.Name, serviceAttachment, op); err != nil {
+		return nil, err
+	}
+	if err := c.waitForOperation(ctx, op); err != nil {
+		return nil, err
+	}
+	patched := &ServiceAttachment{}
+	if err := c.serviceAttachmentsRequest(ctx, http.MethodGet, region, serviceAttachment.Name, nil, patched); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+func (c *gcpClient) DeleteServiceAttachment(region, name string) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	op := &compute.Operation{}
+	if err := c.serviceAttachmentsRequest(ctx, http.MethodDelete, region, name, nil, op); err != nil {
+		return err
+	}
+	return c.waitForOperation(ctx, op)
+}
+
+// serviceAttachmentsRequest sends a request to the service attachments of the region in the compute REST API
+// and decodes the response into out.
+func (c *gcpClient) serviceAttachmentsRequest(ctx context.Context, method, region, name string, in, out interface{}) error {
+	u := c.computeClient.BasePath + c.projectName + "/regions/" + region + "/serviceAttachments"
+	if name != "" {
+		u += "/" + name
+	}
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	res, err := oauth2.NewClient(ctx, c.creds.TokenSource).Do(req)
+	if err != nil {
+		return err
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return err
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// waitForOperation waits for a regional or global compute operation to complete and returns the error
+// the operation failed with, if any.
+func (c *gcpClient) waitForOperation(ctx context.Context, op *compute.Operation) error {
+	name := op.Name
+	var err error
+	for op.Status != "DONE" {
+		if op.Region != "" {
+			op, err = c.computeClient.RegionOperations.Wait(c.projectName, path.Base(op.Region), name).Context(ctx).Do()
+		} else {
+			op, err = c.computeClient.GlobalOperations.Wait(c.projectName, name).Context(ctx).Do()
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to wait for operation %s", name)
+		}
+	}
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return errors.Errorf("operation %s failed: %s", name, op.Error.Errors[0].Message)
+	}
+	return nil
+}
+
+func (c *gcpClient) UploadObject(bucket, name string, content io.Reader) error {
+	_, err := c.storageClient.Objects.Insert(bucket, &storage.Object{Name: name}).Media(content).Do()
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload object %s to bucket %s", name, bucket)
+	}
+	return nil
+}
+
 // NewClient creates our client wrapper object for interacting with GCP. The supplied byte slice contains the GCP creds.
 func NewClient(authJSON []byte) (Client, error) {
 	return newClient(authJSONPassthroughSource(authJSON))
@@ -326,7 +658,7 @@ func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
 
 	options := []option.ClientOption{
 		option.WithCredentials(creds),
-		option.WithUserAgent("openshift.io hive/v1"),
+		option.WithUserAgent(userAgent),
 	}
 	cloudResourceManagerClient, err := cloudresourcemanager.NewService(ctx, options...)
 	if err != nil {
@@ -348,6 +680,11 @@ func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
 		return nil, err
 	}
 
+	storageClient, err := storage.NewService(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
 	return &gcpClient{
 		projectName:                creds.ProjectID,
 		creds:                      creds,
@@ -355,6 +692,7 @@ func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
 		computeClient:              computeClient,
 		serviceUsageClient:         serviceUsageClient,
 		dnsClient:                  dnsClient,
+		storageClient:              storageClient,
 	}, nil
 }
 
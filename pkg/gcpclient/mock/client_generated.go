@@ -5,6 +5,7 @@
 package mock
 
 import (
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -50,6 +51,51 @@ func (mr *MockClientMockRecorder) AddResourceRecordSet(managedZone, recordSet in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResourceRecordSet", reflect.TypeOf((*MockClient)(nil).AddResourceRecordSet), managedZone, recordSet)
 }
 
+// CreateAddress mocks base method.
+func (m *MockClient) CreateAddress(region string, address *compute.Address) (*compute.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddress", region, address)
+	ret0, _ := ret[0].(*compute.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAddress indicates an expected call of CreateAddress.
+func (mr *MockClientMockRecorder) CreateAddress(region, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddress", reflect.TypeOf((*MockClient)(nil).CreateAddress), region, address)
+}
+
+// CreateFirewall mocks base method.
+func (m *MockClient) CreateFirewall(firewall *compute.Firewall) (*compute.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFirewall", firewall)
+	ret0, _ := ret[0].(*compute.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFirewall indicates an expected call of CreateFirewall.
+func (mr *MockClientMockRecorder) CreateFirewall(firewall interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFirewall", reflect.TypeOf((*MockClient)(nil).CreateFirewall), firewall)
+}
+
+// CreateForwardingRule mocks base method.
+func (m *MockClient) CreateForwardingRule(region string, forwardingRule *compute.ForwardingRule) (*compute.ForwardingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForwardingRule", region, forwardingRule)
+	ret0, _ := ret[0].(*compute.ForwardingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateForwardingRule indicates an expected call of CreateForwardingRule.
+func (mr *MockClientMockRecorder) CreateForwardingRule(region, forwardingRule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForwardingRule", reflect.TypeOf((*MockClient)(nil).CreateForwardingRule), region, forwardingRule)
+}
+
 // CreateManagedZone mocks base method.
 func (m *MockClient) CreateManagedZone(managedZone *dns.ManagedZone) (*dns.ManagedZone, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +111,78 @@ func (mr *MockClientMockRecorder) CreateManagedZone(managedZone interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManagedZone", reflect.TypeOf((*MockClient)(nil).CreateManagedZone), managedZone)
 }
 
+// CreateServiceAttachment mocks base method.
+func (m *MockClient) CreateServiceAttachment(region string, serviceAttachment *gcpclient.ServiceAttachment) (*gcpclient.ServiceAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServiceAttachment", region, serviceAttachment)
+	ret0, _ := ret[0].(*gcpclient.ServiceAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateServiceAttachment indicates an expected call of CreateServiceAttachment.
+func (mr *MockClientMockRecorder) CreateServiceAttachment(region, serviceAttachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAttachment", reflect.TypeOf((*MockClient)(nil).CreateServiceAttachment), region, serviceAttachment)
+}
+
+// CreateSubnetwork mocks base method.
+func (m *MockClient) CreateSubnetwork(region string, subnetwork *compute.Subnetwork) (*compute.Subnetwork, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubnetwork", region, subnetwork)
+	ret0, _ := ret[0].(*compute.Subnetwork)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubnetwork indicates an expected call of CreateSubnetwork.
+func (mr *MockClientMockRecorder) CreateSubnetwork(region, subnetwork interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnetwork", reflect.TypeOf((*MockClient)(nil).CreateSubnetwork), region, subnetwork)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockClient) DeleteAddress(region, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", region, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockClientMockRecorder) DeleteAddress(region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockClient)(nil).DeleteAddress), region, name)
+}
+
+// DeleteFirewall mocks base method.
+func (m *MockClient) DeleteFirewall(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFirewall", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFirewall indicates an expected call of DeleteFirewall.
+func (mr *MockClientMockRecorder) DeleteFirewall(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirewall", reflect.TypeOf((*MockClient)(nil).DeleteFirewall), name)
+}
+
+// DeleteForwardingRule mocks base method.
+func (m *MockClient) DeleteForwardingRule(region, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForwardingRule", region, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForwardingRule indicates an expected call of DeleteForwardingRule.
+func (mr *MockClientMockRecorder) DeleteForwardingRule(region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForwardingRule", reflect.TypeOf((*MockClient)(nil).DeleteForwardingRule), region, name)
+}
+
 // DeleteManagedZone mocks base method.
 func (m *MockClient) DeleteManagedZone(managedZone string) error {
 	m.ctrl.T.Helper()
@@ -107,6 +225,124 @@ func (mr *MockClientMockRecorder) DeleteResourceRecordSets(managedZone, recordSe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResourceRecordSets", reflect.TypeOf((*MockClient)(nil).DeleteResourceRecordSets), managedZone, recordSet)
 }
 
+// DeleteServiceAttachment mocks base method.
+func (m *MockClient) DeleteServiceAttachment(region, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceAttachment", region, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteServiceAttachment indicates an expected call of DeleteServiceAttachment.
+func (mr *MockClientMockRecorder) DeleteServiceAttachment(region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAttachment", reflect.TypeOf((*MockClient)(nil).DeleteServiceAttachment), region, name)
+}
+
+// DeleteSubnetwork mocks base method.
+func (m *MockClient) DeleteSubnetwork(region, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubnetwork", region, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubnetwork indicates an expected call of DeleteSubnetwork.
+func (mr *MockClientMockRecorder) DeleteSubnetwork(region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnetwork", reflect.TypeOf((*MockClient)(nil).DeleteSubnetwork), region, name)
+}
+
+// GetAddress mocks base method.
+func (m *MockClient) GetAddress(region, name string) (*compute.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress", region, name)
+	ret0, _ := ret[0].(*compute.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddress indicates an expected call of GetAddress.
+func (mr *MockClientMockRecorder) GetAddress(region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockClient)(nil).GetAddress), region, name)
+}
+
+// GetComputeMachineType mocks base method.
+func (m *MockClient) GetComputeMachineType(zone, machineType string) (*compute.MachineType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeMachineType", zone, machineType)
+	ret0, _ := ret[0].(*compute.MachineType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeMachineType indicates an expected call of GetComputeMachineType.
+func (mr *MockClientMockRecorder) GetComputeMachineType(zone, machineType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeMachineType", reflect.TypeOf((*MockClient)(nil).GetComputeMachineType), zone, machineType)
+}
+
+// GetComputeProject mocks base method.
+func (m *MockClient) GetComputeProject() (*compute.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeProject")
+	ret0, _ := ret[0].(*compute.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeProject indicates an expected call of GetComputeProject.
+func (mr *MockClientMockRecorder) GetComputeProject() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeProject", reflect.TypeOf((*MockClient)(nil).GetComputeProject))
+}
+
+// GetComputeRegion mocks base method.
+func (m *MockClient) GetComputeRegion(region string) (*compute.Region, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeRegion", region)
+	ret0, _ := ret[0].(*compute.Region)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeRegion indicates an expected call of GetComputeRegion.
+func (mr *MockClientMockRecorder) GetComputeRegion(region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeRegion", reflect.TypeOf((*MockClient)(nil).GetComputeRegion), region)
+}
+
+// GetFirewall mocks base method.
+func (m *MockClient) GetFirewall(name string) (*compute.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirewall", name)
+	ret0, _ := ret[0].(*compute.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirewall indicates an expected call of GetFirewall.
+func (mr *MockClientMockRecorder) GetFirewall(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirewall", reflect.TypeOf((*MockClient)(nil).GetFirewall), name)
+}
+
+// GetForwardingRule mocks base method.
+func (m *MockClient) GetForwardingRule(region, name string) (*compute.ForwardingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForwardingRule", region, name)
+	ret0, _ := ret[0].(*compute.ForwardingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForwardingRule indicates an expected call of GetForwardingRule.
+func (mr *MockClientMockRecorder) GetForwardingRule(region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForwardingRule", reflect.TypeOf((*MockClient)(nil).GetForwardingRule), region, name)
+}
+
 // GetManagedZone mocks base method.
 func (m *MockClient) GetManagedZone(managedZone string) (*dns.ManagedZone, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +358,36 @@ func (mr *MockClientMockRecorder) GetManagedZone(managedZone interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedZone", reflect.TypeOf((*MockClient)(nil).GetManagedZone), managedZone)
 }
 
+// GetServiceAttachment mocks base method.
+func (m *MockClient) GetServiceAttachment(region, name string) (*gcpclient.ServiceAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAttachment", region, name)
+	ret0, _ := ret[0].(*gcpclient.ServiceAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAttachment indicates an expected call of GetServiceAttachment.
+func (mr *MockClientMockRecorder) GetServiceAttachment(region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAttachment", reflect.TypeOf((*MockClient)(nil).GetServiceAttachment), region, name)
+}
+
+// GetSubnetwork mocks base method.
+func (m *MockClient) GetSubnetwork(region, name string) (*compute.Subnetwork, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetwork", region, name)
+	ret0, _ := ret[0].(*compute.Subnetwork)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetwork indicates an expected call of GetSubnetwork.
+func (mr *MockClientMockRecorder) GetSubnetwork(region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetwork", reflect.TypeOf((*MockClient)(nil).GetSubnetwork), region, name)
+}
+
 // ListComputeImages mocks base method.
 func (m *MockClient) ListComputeImages(arg0 gcpclient.ListComputeImagesOptions) (*compute.ImageList, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +462,35 @@ func (mr *MockClientMockRecorder) ListResourceRecordSets(managedZone, opts inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSets", reflect.TypeOf((*MockClient)(nil).ListResourceRecordSets), managedZone, opts)
 }
 
+// PatchManagedZone mocks base method.
+func (m *MockClient) PatchManagedZone(managedZone string, patch *dns.ManagedZone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchManagedZone", managedZone, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchManagedZone indicates an expected call of PatchManagedZone.
+func (mr *MockClientMockRecorder) PatchManagedZone(managedZone, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchManagedZone", reflect.TypeOf((*MockClient)(nil).PatchManagedZone), managedZone, patch)
+}
+
+// PatchServiceAttachment mocks base method.
+func (m *MockClient) PatchServiceAttachment(region string, serviceAttachment *gcpclient.ServiceAttachment) (*gcpclient.ServiceAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchServiceAttachment", region, serviceAttachment)
+	ret0, _ := ret[0].(*gcpclient.ServiceAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchServiceAttachment indicates an expected call of PatchServiceAttachment.
+func (mr *MockClientMockRecorder) PatchServiceAttachment(region, serviceAttachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchServiceAttachment", reflect.TypeOf((*MockClient)(nil).PatchServiceAttachment), region, serviceAttachment)
+}
+
 // StartInstance mocks base method.
 func (m *MockClient) StartInstance(arg0 *compute.Instance) error {
 	m.ctrl.T.Helper()
@@ -237,3 +532,17 @@ func (mr *MockClientMockRecorder) UpdateResourceRecordSet(managedZone, addRecord
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResourceRecordSet", reflect.TypeOf((*MockClient)(nil).UpdateResourceRecordSet), managedZone, addRecordSet, removeRecordSet)
 }
+
+// UploadObject mocks base method.
+func (m *MockClient) UploadObject(bucket, name string, content io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadObject", bucket, name, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadObject indicates an expected call of UploadObject.
+func (mr *MockClientMockRecorder) UploadObject(bucket, name, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadObject", reflect.TypeOf((*MockClient)(nil).UploadObject), bucket, name, content)
+}
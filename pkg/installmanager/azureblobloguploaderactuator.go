@@ -0,0 +1,77 @@
+package installmanager
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// Ensure azureBlobLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &azureBlobLogUploaderActuator{}
+
+// azureBlobLogUploaderActuator uploads logs to Azure Blob Storage.
+type azureBlobLogUploaderActuator struct {
+	// azureClientFn is the function to build an Azure client, here for lazy loading the client.
+	azureClientFn func(c client.Client, secretName, namespace, cloudName string, logger log.FieldLogger) (azureclient.Client, error)
+}
+
+// IsConfigured returns true if the actuator can handle a particular case
+func (a *azureBlobLogUploaderActuator) IsConfigured() bool {
+	return isInstallLogsUploadProvider(constants.InstallLogsUploadProviderAzure)
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *azureBlobLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if !foundSecretName {
+		return errors.New("couldn't find secret name in environment variable. Skipping upload")
+	}
+
+	storageAccount, foundStorageAccountEnvVar := os.LookupEnv(constants.InstallLogsAzureStorageAccountEnvVar)
+	if !foundStorageAccountEnvVar {
+		return errors.New("couldn't find storage account in environment variable. Skipping upload")
+	}
+
+	container, foundContainerEnvVar := os.LookupEnv(constants.InstallLogsAzureContainerEnvVar)
+	if !foundContainerEnvVar {
+		return errors.New("couldn't find container in environment variable. Skipping upload")
+	}
+
+	azurec, err := a.azureClientFn(c, secretName, clusterprovision.Namespace, os.Getenv(constants.InstallLogsAzureCloudNameEnvVar), log)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Uploading log(s) to Azure Blob Storage: %v/%v/%v/", storageAccount, container, installLogsFolder(clusterName, clusterprovision))
+
+	return uploadLogFiles(clusterName, clusterprovision, func(key string, file *os.File) error {
+		content, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		return azurec.UploadBlob(context.TODO(), storageAccount, container, key, content)
+	}, filenames...)
+}
+
+func getAzureClient(c client.Client, secretName, namespace, cloudName string, logger log.FieldLogger) (azureclient.Client, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+		logger.WithError(err).Error("failed to get Azure credentials secret")
+		return nil, err
+	}
+	azureClient, err := azureclient.NewClientFromSecret(secret, cloudName)
+	if err != nil {
+		logger.WithError(err).Error("failed to get Azure client")
+	}
+	return azureClient, err
+}
@@ -0,0 +1,86 @@
+package installmanager
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestAzureBlobUploadLogs(t *testing.T) {
+	issue, err := ioutil.ReadFile("/etc/issue")
+	if err != nil {
+		t.Fatalf("could not read test file: %v", err)
+	}
+
+	tests := []struct {
+		name                    string
+		envVars                 map[string]string
+		setupUploadMock         bool
+		expectedCloudName       string
+		expectedUploadLogsError bool
+	}{
+		{
+			name:                    "missing env vars",
+			expectedUploadLogsError: true,
+		},
+		{
+			name: "missing container",
+			envVars: map[string]string{
+				constants.InstallLogsUploadProviderEnvVar:       constants.InstallLogsUploadProviderAzure,
+				constants.InstallLogsCredentialsSecretRefEnvVar: "notarealsecret",
+				constants.InstallLogsAzureStorageAccountEnvVar:  "account1",
+			},
+			expectedUploadLogsError: true,
+		},
+		{
+			name: "successfully upload blobs",
+			envVars: map[string]string{
+				constants.InstallLogsUploadProviderEnvVar:       constants.InstallLogsUploadProviderAzure,
+				constants.InstallLogsCredentialsSecretRefEnvVar: "notarealsecret",
+				constants.InstallLogsAzureStorageAccountEnvVar:  "account1",
+				constants.InstallLogsAzureContainerEnvVar:       "container1",
+				constants.InstallLogsAzureCloudNameEnvVar:       "AzureUSGovernmentCloud",
+			},
+			setupUploadMock:   true,
+			expectedCloudName: "AzureUSGovernmentCloud",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t)
+			defer mocks.mockCtrl.Finish()
+
+			for k, v := range test.envVars {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+			if test.setupUploadMock {
+				mocks.mockAzureClient.EXPECT().
+					UploadBlob(gomock.Any(), "account1", "container1", "notarealcluster-"+testNamespace+"/"+testProvisionName+"-issue", issue).
+					Return(nil)
+			}
+
+			actuator := &azureBlobLogUploaderActuator{azureClientFn: func(_ client.Client, _, _, cloudName string, _ log.FieldLogger) (azureclient.Client, error) {
+				assert.Equal(t, test.expectedCloudName, cloudName, "unexpected cloud name")
+				return mocks.mockAzureClient, nil
+			}}
+			assert.Equal(t, test.envVars != nil, actuator.IsConfigured(), "unexpected IsConfigured")
+
+			err := actuator.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), "/etc/issue")
+
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+			} else {
+				assert.NoError(t, err, "Function errored unexpectedly")
+			}
+		})
+	}
+}
@@ -34,7 +34,7 @@ func cleanupDNSZone(dynClient client.Client, cd *hivev1.ClusterDeployment, logge
 
 	switch {
 	case cd.Spec.Platform.AWS != nil:
-		return cleanupAWSDNSZone(dnsZone, cd.Spec.Platform.AWS.Region, logger)
+		return cleanupAWSDNSZone(dnsZone, controllerutils.GetClusterRegion(cd), logger)
 	case cd.Spec.Platform.Azure != nil:
 		return cleanupAzureDNSZone(dnsZone, logger)
 	case cd.Spec.Platform.GCP != nil:
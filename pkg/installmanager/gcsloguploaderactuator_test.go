@@ -0,0 +1,74 @@
+package installmanager
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+func TestGCSUploadLogs(t *testing.T) {
+	tests := []struct {
+		name                    string
+		envVars                 map[string]string
+		setupUploadMock         bool
+		expectedUploadLogsError bool
+	}{
+		{
+			name:                    "missing env vars",
+			expectedUploadLogsError: true,
+		},
+		{
+			name: "missing bucket",
+			envVars: map[string]string{
+				constants.InstallLogsUploadProviderEnvVar:       constants.InstallLogsUploadProviderGCP,
+				constants.InstallLogsCredentialsSecretRefEnvVar: "notarealsecret",
+			},
+			expectedUploadLogsError: true,
+		},
+		{
+			name: "successfully upload objects",
+			envVars: map[string]string{
+				constants.InstallLogsUploadProviderEnvVar:       constants.InstallLogsUploadProviderGCP,
+				constants.InstallLogsCredentialsSecretRefEnvVar: "notarealsecret",
+				constants.InstallLogsGCPBucketEnvVar:            "bucket1",
+			},
+			setupUploadMock: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t)
+			defer mocks.mockCtrl.Finish()
+
+			for k, v := range test.envVars {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+			if test.setupUploadMock {
+				mocks.mockGCPClient.EXPECT().
+					UploadObject("bucket1", "notarealcluster-"+testNamespace+"/"+testProvisionName+"-issue", gomock.Any()).
+					Return(nil)
+			}
+
+			actuator := &gcsLogUploaderActuator{gcpClientFn: func(client.Client, string, string, log.FieldLogger) (gcpclient.Client, error) {
+				return mocks.mockGCPClient, nil
+			}}
+			assert.Equal(t, test.envVars != nil, actuator.IsConfigured(), "unexpected IsConfigured")
+
+			err := actuator.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), "/etc/issue")
+
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+			} else {
+				assert.NoError(t, err, "Function errored unexpectedly")
+			}
+		})
+	}
+}
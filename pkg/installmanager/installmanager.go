@@ -58,6 +58,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	contributils "github.com/openshift/hive/contrib/pkg/utils"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/hive/pkg/ibmclient"
 	"github.com/openshift/hive/pkg/resource"
@@ -351,7 +352,7 @@ func (m *InstallManager) Run() error {
 	// cluster provision attempt. Cleanup any resources that may have been provisioned.
 	if provision.Spec.PrevInfraID != nil {
 		m.log.Info("cleaning up resources from previous provision attempt")
-		if err := m.cleanupFailedInstall(cd, *provision.Spec.PrevInfraID, *provision.Spec.PrevProvisionName, m.Namespace); err != nil {
+		if err := m.cleanupFailedInstall(cdInRegion(cd, provision.Spec.PrevRegion), *provision.Spec.PrevInfraID, *provision.Spec.PrevProvisionName, m.Namespace); err != nil {
 			m.log.WithError(err).Error("error while trying to preemptively clean up")
 			return err
 		}
@@ -368,7 +369,7 @@ func (m *InstallManager) Run() error {
 		// because install log is not generated until we run installer binary. We do
 		// have the option of faking an install log that can then be regexed.
 		m.log.Error("infraID is already set on the ClusterProvision. Unexpected install pod restart detected. Cleaning up resources from previous install attempt")
-		if err := m.cleanupFailedInstall(cd, *provision.Spec.InfraID, m.ClusterProvisionName, m.Namespace); err != nil {
+		if err := m.cleanupFailedInstall(cdInRegion(cd, provision.Spec.Region), *provision.Spec.InfraID, m.ClusterProvisionName, m.Namespace); err != nil {
 			m.log.WithError(err).Error("error while trying to preemptively clean up")
 			return err
 		}
@@ -508,6 +509,9 @@ func getActuator() LogUploaderActuator {
 	// As we add more LogUploaderActuators, add them here
 	actuators := []LogUploaderActuator{
 		&s3LogUploaderActuator{awsClientFn: getAWSClient},
+		&s3CompatibleLogUploaderActuator{awsClientFn: getS3CompatibleClient},
+		&gcsLogUploaderActuator{gcpClientFn: getGCPClient},
+		&azureBlobLogUploaderActuator{azureClientFn: getAzureClient},
 	}
 
 	for _, a := range actuators {
@@ -565,6 +569,20 @@ func (m *InstallManager) copyFile(src, dst string) error {
 	return nil
 }
 
+// cdInRegion returns the ClusterDeployment in the region of a provision attempt, which differs from the region of the
+// ClusterDeployment if an install config override changed it. A nil region is the region of the platform.
+func cdInRegion(cd *hivev1.ClusterDeployment, region *string) *hivev1.ClusterDeployment {
+	if region != nil {
+		return controllerutils.ClusterDeploymentWithRegion(cd, *region)
+	}
+	if cd.Status.Platform == nil || cd.Status.Platform.Region == "" {
+		return cd
+	}
+	cd = cd.DeepCopy()
+	cd.Status.Platform.Region = ""
+	return cd
+}
+
 // cleanupFailedInstall allows recovering from an installation error and allows retries
 func (m *InstallManager) cleanupFailedInstall(cd *hivev1.ClusterDeployment, infraID, provisionName, provisionNamespace string) error {
 	if err := m.cleanupFailedProvision(m.DynamicClient, cd, infraID, m.log); err != nil {
@@ -592,7 +610,7 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 		}
 		uninstaller = &aws.ClusterUninstaller{
 			Filters: filters,
-			Region:  cd.Spec.Platform.AWS.Region,
+			Region:  controllerutils.GetClusterRegion(cd),
 			Logger:  logger,
 		}
 	case cd.Spec.Platform.Azure != nil:
@@ -623,7 +641,7 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 			InfraID: infraID,
 			ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
 				GCP: &installertypesgcp.Metadata{
-					Region:    cd.Spec.Platform.GCP.Region,
+					Region:    controllerutils.GetClusterRegion(cd),
 					ProjectID: projectID,
 				},
 			},
@@ -1,9 +1,16 @@
 package installmanager
 
 import (
-	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 )
 
 // LogUploaderActuator interface is the interface that is used to add provider support for uploading logs.
@@ -14,3 +21,55 @@ type LogUploaderActuator interface {
 	// UploadLogs uploads installer logs to the provider's storage mechanism.
 	UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error
 }
+
+// isInstallLogsUploadProvider returns true if the provider is the one that install logs are configured to be
+// uploaded to.
+func isInstallLogsUploadProvider(provider string) bool {
+	configuredProvider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
+	if !foundProviderEnvVar {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return false
+	}
+	return configuredProvider == provider
+}
+
+// installLogsFolder returns the folder that the install logs of the cluster are uploaded to.
+func installLogsFolder(clusterName string, clusterprovision *hivev1.ClusterProvision) string {
+	return fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+}
+
+// uploadLogFiles uploads each of the log files with the upload function. The files are stored under the folder of
+// the cluster, prefixed with the name of the provision.
+func uploadLogFiles(clusterName string, clusterprovision *hivev1.ClusterProvision, upload func(key string, file *os.File) error, filenames ...string) error {
+	retvalErrs := []error{}
+
+	folder := installLogsFolder(clusterName, clusterprovision)
+
+	for _, filename := range filenames {
+		if err := uploadLogFile(folder, clusterprovision, filename, upload); err != nil {
+			retvalErrs = append(retvalErrs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(retvalErrs)
+}
+
+func uploadLogFile(folder string, clusterprovision *hivev1.ClusterProvision, filename string, upload func(key string, file *os.File) error) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return errors.Wrapf(err, "Failed opening log file: %v", filename)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "Failed stat on log file: %v", filename)
+	}
+
+	logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
+
+	if err := upload(logkey, file); err != nil {
+		return errors.Wrapf(err, "Failed uploading log file: %v", filename)
+	}
+	return nil
+}
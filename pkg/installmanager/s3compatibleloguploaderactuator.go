@@ -0,0 +1,101 @@
+package installmanager
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	awsclient "github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// defaultS3CompatibleRegion is the region used with S3-compatible object stores when none is configured. Most
+	// S3-compatible object stores accept any region.
+	defaultS3CompatibleRegion = "us-east-1"
+)
+
+// Ensure s3CompatibleLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &s3CompatibleLogUploaderActuator{}
+
+// s3CompatibleLogUploaderActuator uploads logs to an S3-compatible object store, such as MinIO.
+type s3CompatibleLogUploaderActuator struct {
+	// awsClientFn is the function to build an AWS client for the endpoint of the object store, here for lazy loading
+	// the client.
+	awsClientFn func(c client.Client, secretName, namespace, region, endpoint string, forcePathStyle bool, logger log.FieldLogger) (awsclient.Client, error)
+}
+
+// IsConfigured returns true if the actuator can handle a particular case
+func (a *s3CompatibleLogUploaderActuator) IsConfigured() bool {
+	return isInstallLogsUploadProvider(constants.InstallLogsUploadProviderS3Compatible)
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *s3CompatibleLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if !foundSecretName {
+		return errors.New("couldn't find secret name in environment variable. Skipping upload")
+	}
+
+	endpoint := os.Getenv(constants.InstallLogsAWSServiceEndpointEnvVar)
+	if endpoint == "" {
+		return errors.New("couldn't find service endpoint in environment variable. Skipping upload")
+	}
+
+	bucket, foundBucketEnvVar := os.LookupEnv(constants.InstallLogsAWSS3BucketEnvVar)
+	if !foundBucketEnvVar {
+		return errors.New("couldn't find bucket in environment variable. Skipping upload")
+	}
+
+	region := os.Getenv(constants.InstallLogsAWSRegionEnvVar)
+	if region == "" {
+		region = defaultS3CompatibleRegion
+	}
+
+	forcePathStyle := false
+	if value := os.Getenv(constants.InstallLogsS3ForcePathStyleEnvVar); value != "" {
+		var err error
+		if forcePathStyle, err = strconv.ParseBool(value); err != nil {
+			return errors.Wrap(err, "couldn't parse force path style environment variable. Skipping upload")
+		}
+	}
+
+	awsc, err := a.awsClientFn(c, secretName, clusterprovision.Namespace, region, endpoint, forcePathStyle, log)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Uploading log(s) to S3-compatible object store %v: s3://%v/%v/", endpoint, bucket, installLogsFolder(clusterName, clusterprovision))
+
+	return uploadLogFiles(clusterName, clusterprovision, s3UploadFn(awsc, bucket), filenames...)
+}
+
+func getS3CompatibleClient(c client.Client, secretName, namespace, region, endpoint string, forcePathStyle bool, logger log.FieldLogger) (awsclient.Client, error) {
+	sess, err := awsclient.NewSession(c, awsclient.Options{
+		Region: region,
+		CredentialsSource: awsclient.CredentialsSource{
+			Secret: &awsclient.SecretCredentialsSource{
+				Namespace: namespace,
+				Ref:       &corev1.LocalObjectReference{Name: secretName},
+			},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to get AWS session")
+		return nil, err
+	}
+	awsClient, err := awsclient.NewClientFromSession(sess.Copy(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		S3ForcePathStyle: aws.Bool(forcePathStyle),
+	}))
+	if err != nil {
+		logger.WithError(err).Error("failed to get AWS client")
+	}
+	return awsClient, err
+}
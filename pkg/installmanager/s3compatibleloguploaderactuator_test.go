@@ -0,0 +1,126 @@
+package installmanager
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	awsclient "github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestS3CompatibleUploadLogs(t *testing.T) {
+	tests := []struct {
+		name                    string
+		envVars                 map[string]string
+		setupUploadMock         bool
+		expectedRegion          string
+		expectedForcePathStyle  bool
+		expectedUploadLogsError bool
+	}{
+		{
+			name:                    "missing env vars",
+			expectedUploadLogsError: true,
+		},
+		{
+			name: "missing endpoint",
+			envVars: map[string]string{
+				constants.InstallLogsUploadProviderEnvVar:       constants.InstallLogsUploadProviderS3Compatible,
+				constants.InstallLogsCredentialsSecretRefEnvVar: "notarealsecret",
+				constants.InstallLogsAWSS3BucketEnvVar:          "bucket1",
+			},
+			expectedUploadLogsError: true,
+		},
+		{
+			name: "invalid force path style",
+			envVars: map[string]string{
+				constants.InstallLogsUploadProviderEnvVar:       constants.InstallLogsUploadProviderS3Compatible,
+				constants.InstallLogsCredentialsSecretRefEnvVar: "notarealsecret",
+				constants.InstallLogsAWSServiceEndpointEnvVar:   "https://minio.example.com:9000",
+				constants.InstallLogsAWSS3BucketEnvVar:          "bucket1",
+				constants.InstallLogsS3ForcePathStyleEnvVar:     "sometimes",
+			},
+			expectedUploadLogsError: true,
+		},
+		{
+			name: "successfully upload objects with defaults",
+			envVars: map[string]string{
+				constants.InstallLogsUploadProviderEnvVar:       constants.InstallLogsUploadProviderS3Compatible,
+				constants.InstallLogsCredentialsSecretRefEnvVar: "notarealsecret",
+				constants.InstallLogsAWSServiceEndpointEnvVar:   "https://minio.example.com:9000",
+				constants.InstallLogsAWSS3BucketEnvVar:          "bucket1",
+			},
+			setupUploadMock: true,
+			expectedRegion:  defaultS3CompatibleRegion,
+		},
+		{
+			name: "successfully upload objects with path-style addressing",
+			envVars: map[string]string{
+				constants.InstallLogsUploadProviderEnvVar:       constants.InstallLogsUploadProviderS3Compatible,
+				constants.InstallLogsCredentialsSecretRefEnvVar: "notarealsecret",
+				constants.InstallLogsAWSServiceEndpointEnvVar:   "https://minio.example.com:9000",
+				constants.InstallLogsAWSRegionEnvVar:            "region1",
+				constants.InstallLogsAWSS3BucketEnvVar:          "bucket1",
+				constants.InstallLogsS3ForcePathStyleEnvVar:     "true",
+			},
+			setupUploadMock:        true,
+			expectedRegion:         "region1",
+			expectedForcePathStyle: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t)
+			defer mocks.mockCtrl.Finish()
+
+			for k, v := range test.envVars {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+			if test.setupUploadMock {
+				mocks.mockAWSClient.EXPECT().
+					Upload(gomockS3Key("bucket1", "notarealcluster-"+testNamespace+"/"+testProvisionName+"-issue")).
+					Return(nil, nil)
+			}
+
+			actuator := &s3CompatibleLogUploaderActuator{awsClientFn: func(_ client.Client, _, _, region, endpoint string, forcePathStyle bool, _ log.FieldLogger) (awsclient.Client, error) {
+				assert.Equal(t, test.expectedRegion, region, "unexpected region")
+				assert.Equal(t, "https://minio.example.com:9000", endpoint, "unexpected endpoint")
+				assert.Equal(t, test.expectedForcePathStyle, forcePathStyle, "unexpected force path style")
+				return mocks.mockAWSClient, nil
+			}}
+			assert.Equal(t, test.envVars != nil, actuator.IsConfigured(), "unexpected IsConfigured")
+
+			err := actuator.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), "/etc/issue")
+
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+			} else {
+				assert.NoError(t, err, "Function errored unexpectedly")
+			}
+		})
+	}
+}
+
+// s3KeyMatcher matches the S3 upload input for an object.
+type s3KeyMatcher struct {
+	bucket, key string
+}
+
+func gomockS3Key(bucket, key string) s3KeyMatcher {
+	return s3KeyMatcher{bucket: bucket, key: key}
+}
+
+func (m s3KeyMatcher) Matches(x interface{}) bool {
+	input, ok := x.(*s3manager.UploadInput)
+	return ok && aws.StringValue(input.Bucket) == m.bucket && aws.StringValue(input.Key) == m.key
+}
+
+func (m s3KeyMatcher) String() string {
+	return "uploads s3://" + m.bucket + "/" + m.key
+}
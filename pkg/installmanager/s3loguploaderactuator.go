@@ -1,7 +1,6 @@
 package installmanager
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -14,7 +13,6 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 
 	"github.com/pkg/errors"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 // Ensure s3LogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
@@ -28,13 +26,7 @@ type s3LogUploaderActuator struct {
 
 // IsConfigured returns true if the actuator can handle a particular ClusterDeprovision
 func (a *s3LogUploaderActuator) IsConfigured() bool {
-	provider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
-	if !foundProviderEnvVar {
-		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
-		return false
-	}
-
-	return provider == constants.InstallLogsUploadProviderAWS
+	return isInstallLogsUploadProvider(constants.InstallLogsUploadProviderAWS)
 }
 
 // UploadLogs uploads installer logs to the provider's storage mechanism.
@@ -59,40 +51,21 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 		return err
 	}
 
-	retvalErrs := []error{}
-
-	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
-
-	log.Infof("Uploading log(s) to S3: s3://%v/%v/", bucket, folder)
-
-	for _, filename := range filenames {
-		file, err := os.Open(filename)
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed opening log file: %v", filename))
-			continue
-		}
-		defer file.Close()
+	log.Infof("Uploading log(s) to S3: s3://%v/%v/", bucket, installLogsFolder(clusterName, clusterprovision))
 
-		stat, err := file.Stat()
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed stat on log file: %v", filename))
-			continue
-		}
-
-		logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
+	return uploadLogFiles(clusterName, clusterprovision, s3UploadFn(awsc, bucket), filenames...)
+}
 
-		_, err = awsc.Upload(&s3manager.UploadInput{
+// s3UploadFn returns a function that uploads a log file to the S3 bucket.
+func s3UploadFn(awsc awsclient.Client, bucket string) func(string, *os.File) error {
+	return func(key string, file *os.File) error {
+		_, err := awsc.Upload(&s3manager.UploadInput{
 			Bucket: aws.String(bucket),
-			Key:    aws.String(logkey),
+			Key:    aws.String(key),
 			Body:   file,
 		})
-
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed uploading log file: %v", filename))
-		}
+		return err
 	}
-
-	return utilerrors.NewAggregate(retvalErrs)
 }
 
 func getAWSClient(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
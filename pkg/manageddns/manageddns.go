@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
@@ -29,3 +30,23 @@ func ReadManagedDomainsFile() ([]hivev1.ManageDNSConfig, error) {
 
 	return domains, nil
 }
+
+// RFC2136ConfigForDomain returns the configuration of the managed domain hosted on an RFC2136 DNS server
+// that the base domain is a direct child of, or nil if there is none.
+func RFC2136ConfigForDomain(managedDomains []hivev1.ManageDNSConfig, baseDomain string) *hivev1.ManageDNSRFC2136Config {
+	parts := strings.SplitN(baseDomain, ".", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	for i, md := range managedDomains {
+		if md.RFC2136 == nil {
+			continue
+		}
+		for _, domain := range md.Domains {
+			if parts[1] == domain {
+				return managedDomains[i].RFC2136
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package openstackclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// Client is a wrapper object for actual OpenStack libraries to allow for easier mocking/testing.
+type Client interface {
+	// ListServers returns all Nova servers matching the given options.
+	ListServers(opts *servers.ListOpts) ([]servers.Server, error)
+
+	// StopServer powers off the Nova server with the given ID.
+	StopServer(id string) error
+
+	// StartServer powers on the Nova server with the given ID.
+	StartServer(id string) error
+}
+
+type openstackClient struct {
+	computeClient *gophercloud.ServiceClient
+}
+
+var _ Client = &openstackClient{}
+
+// ListServers returns all Nova servers matching the given options.
+func (c *openstackClient) ListServers(opts *servers.ListOpts) ([]servers.Server, error) {
+	pages, err := servers.List(c.computeClient, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return servers.ExtractServers(pages)
+}
+
+// StopServer powers off the Nova server with the given ID.
+func (c *openstackClient) StopServer(id string) error {
+	return c.serverAction(id, "os-stop")
+}
+
+// StartServer powers on the Nova server with the given ID.
+func (c *openstackClient) StartServer(id string) error {
+	return c.serverAction(id, "os-start")
+}
+
+func (c *openstackClient) serverAction(id, action string) error {
+	_, err := c.computeClient.Post(c.computeClient.ServiceURL("servers", id, "action"), map[string]interface{}{action: nil}, nil, nil)
+	return err
+}
+
+// NewClientFromSecret creates our client wrapper object for interacting with OpenStack. The clouds.yaml is read from
+// the specified credentials secret, and the named cloud entry is used to authenticate. If a certificates secret is
+// provided, every key in it is treated as a PEM bundle of CAs to trust when talking to the OpenStack endpoints.
+func NewClientFromSecret(secret *corev1.Secret, certificatesSecret *corev1.Secret, cloud string) (Client, error) {
+	cloudsYAML, ok := secret.Data[constants.OpenStackCredentialsName]
+	if !ok {
+		return nil, errors.Errorf("secret does not contain %q data", constants.OpenStackCredentialsName)
+	}
+	clouds := &clientconfig.Clouds{}
+	if err := yaml.Unmarshal(cloudsYAML, clouds); err != nil {
+		return nil, errors.Wrap(err, "failed to parse clouds.yaml")
+	}
+	cloudEntry, ok := clouds.Clouds[cloud]
+	if !ok {
+		return nil, errors.Errorf("cloud %q does not exist in clouds.yaml", cloud)
+	}
+
+	tlsConfig := &tls.Config{}
+	if cloudEntry.Verify != nil && !*cloudEntry.Verify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	if certificatesSecret != nil && len(certificatesSecret.Data) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		for _, data := range certificatesSecret.Data {
+			rootCAs.AppendCertsFromPEM(data)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	computeClient, err := clientconfig.NewServiceClient("compute", &clientconfig.ClientOpts{
+		Cloud:      cloud,
+		HTTPClient: &http.Client{Transport: transport},
+		YAMLOpts:   &secretYAMLOpts{clouds: clouds.Clouds},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OpenStack compute client")
+	}
+
+	return &openstackClient{
+		computeClient: computeClient,
+	}, nil
+}
+
+// secretYAMLOpts serves the clouds.yaml read from a secret rather than from the local filesystem.
+type secretYAMLOpts struct {
+	clouds map[string]clientconfig.Cloud
+}
+
+// LoadCloudsYAML returns the clouds from the secret. Any cacert file paths are cleared as they refer to
+// paths mounted into install pods, not the controller. The CAs are instead configured on the HTTP client.
+func (o *secretYAMLOpts) LoadCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	clouds := make(map[string]clientconfig.Cloud, len(o.clouds))
+	for name, cloud := range o.clouds {
+		cloud.CACertFile = ""
+		clouds[name] = cloud
+	}
+	return clouds, nil
+}
+
+// LoadSecureCloudsYAML returns no secure clouds as everything is read from the clouds.yaml in the secret.
+func (o *secretYAMLOpts) LoadSecureCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	return nil, nil
+}
+
+// LoadPublicCloudsYAML defers to the default lookup of clouds-public.yaml.
+func (o *secretYAMLOpts) LoadPublicCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	return clientconfig.LoadPublicCloudsYAML()
+}
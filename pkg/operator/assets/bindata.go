@@ -1135,6 +1135,7 @@ rules:
   resources:
   - clusterimagesets
   - hiveconfigs
+  - provisioningquotas
   - selectorsyncsets
   - selectorsyncidentityproviders
   verbs:
@@ -1516,6 +1517,7 @@ rules:
   resources:
   - clusterimagesets
   - hiveconfigs
+  - provisioningquotas
   verbs:
   - get
   - list
@@ -1599,82 +1601,126 @@ data:
       - "failed to generate asset.*Platform Quota Check.*MissingQuota.*ec2"
       installFailingReason: AWSEC2QuotaExceeded
       installFailingMessage: AWS EC2 Quota Exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Request an increase of the EC2 instance quota of the AWS account for the region.
     - name: AWSNATGatewayLimitExceeded
       searchRegexStrings:
       - "NatGatewayLimitExceeded"
       installFailingReason: AWSNATGatewayLimitExceeded
       installFailingMessage: AWS NAT gateway limit exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Delete unused NAT gateways or request an increase of the NAT gateway limit of the AWS account.
     - name: AWSVPCLimitExceeded
       searchRegexStrings:
       - "VpcLimitExceeded"
       installFailingReason: AWSVPCLimitExceeded
       installFailingMessage: AWS VPC limit exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Delete unused VPCs or request an increase of the VPC limit of the AWS account for the region.
     - name: S3BucketsLimitExceeded
       searchRegexStrings:
        - "TooManyBuckets"
       installFailingReason: S3BucketsLimitExceeded
       installFailingMessage: S3 Buckets Limit Exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Delete unused S3 buckets or request an increase of the S3 bucket limit of the AWS account.
     - name: LoadBalancerLimitExceeded
       searchRegexStrings:
       - "TooManyLoadBalancers: Exceeded quota of account"
       installFailingReason: LoadBalancerLimitExceeded
       installFailingMessage: AWS Load Balancer Limit Exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Delete unused load balancers or request an increase of the load balancer limit of the AWS account.
     - name: EIPAddressLimitExceeded
       searchRegexStrings:
       - "EIP: AddressLimitExceeded"
       installFailingReason: EIPAddressLimitExceeded
       installFailingMessage: EIP Address limit exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Release unused Elastic IP addresses or request an increase of the Elastic IP limit of the AWS account.
     - name: MissingPublicSubnetForZone
       searchRegexStrings:
       - "No public subnet provided for zone"
       installFailingReason: MissingPublicSubnetForZone
       installFailingMessage: No public subnet provided for at least one zone
+      installFailingCategory: Network
+      retryable: false
+      remediation: Provide a public subnet for every availability zone that has a private subnet in the install config.
     - name: PrivateSubnetInMultipleZones
       searchRegexStrings:
       - "private subnet .* is also in zone"
       installFailingReason: PrivateSubnetInMultipleZones
       installFailingMessage: Same private subnet used in multiple zones
+      installFailingCategory: Network
+      retryable: false
+      remediation: Provide a separate private subnet for each availability zone in the install config.
     - name: InvalidInstallConfigSubnet
       searchRegexStrings:
       - "CIDR range start.*is outside of the specified machine networks"
       installFailingReason: InvalidInstallConfigSubnet
       installFailingMessage: Invalid subnet in install config. Subnet's CIDR range start is outside of the specified machine networks
+      installFailingCategory: Network
+      retryable: false
+      remediation: Make sure that the CIDR ranges of the subnets are within the machine networks of the install config.
     # https://bugzilla.redhat.com/show_bug.cgi?id=1844320
     - name: AWSUnableToFindMatchingRouteTable
       searchRegexStrings:
       - "Error: Unable to find matching route for Route Table"
       installFailingReason: AWSUnableToFindMatchingRouteTable
       installFailingMessage: Unable to find matching route for route table
+      installFailingCategory: Network
+      retryable: false
+      remediation: Make sure that the subnets of the install config are associated with a route table.
     - name: DNSAlreadyExists
       searchRegexStrings:
       - "aws_route53_record.*Error building changeset:.*Tried to create resource record set.*but it already exists"
       installFailingReason: DNSAlreadyExists
       installFailingMessage: DNS record already exists
+      installFailingCategory: Configuration
+      retryable: false
+      remediation: Delete the stale DNS records of a previous cluster with the same name from the hosted zone.
     - name: PendingVerification
       searchRegexStrings:
       - "PendingVerification: Your request for accessing resources in this region is being validated"
       installFailingReason: PendingVerification
       installFailingMessage: Account pending verification for region
+      installFailingCategory: Credentials
+      retryable: false
+      remediation: Wait for AWS to complete the verification of the account for the region.
     - name: NoMatchingRoute53Zone
       searchRegexStrings:
       - "data.aws_route53_zone.public: no matching Route53Zone found"
       installFailingReason: NoMatchingRoute53Zone
       installFailingMessage: No matching Route53Zone found
+      installFailingCategory: Configuration
+      retryable: false
+      remediation: Create a Route53 hosted zone for the base domain of the cluster.
     - name: TooManyRoute53Zones
       searchRegexStrings:
       - "error creating Route53 Hosted Zone: TooManyHostedZones: Limits Exceeded"
       installFailingReason: TooManyRoute53Zones
       installFailingMessage: Route53 hosted zone limit exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Delete unused Route53 hosted zones or request an increase of the hosted zone limit of the AWS account.
     - name: SimulatorThrottling
       searchRegexStrings:
       - "validate AWS credentials: checking install permissions: error simulating policy: Throttling: Rate exceeded"
       installFailingReason: AWSAPIRateLimitExceeded
       installFailingMessage: AWS API rate limit exceeded while simulating policy
+      installFailingCategory: Transient
     - name: GeneralThrottling
       searchRegexStrings:
       - "Throttling: Rate exceeded"
       installFailingReason: AWSAPIRateLimitExceeded
       installFailingMessage: AWS API rate limit exceeded
+      installFailingCategory: Transient
     # This issue is caused by AWS throttling the CreateHostedZone request. The terraform provider is not properly
     # handling the throttling response and gets stuck in a state where it does not retry the request. Eventually,
     # the terraform provider times out claiming that it is waiting for the hosted zone to be INSYNC.
@@ -1683,32 +1729,47 @@ data:
       - "error waiting for Route53 Hosted Zone .* creation: timeout while waiting for state to become 'INSYNC'"
       installFailingReason: AWSRoute53Timeout
       installFailingMessage: AWS Route53 timeout while waiting for INSYNC. This is usually caused by Route53 rate limiting.
+      installFailingCategory: Transient
     - name: InvalidCredentials
       searchRegexStrings:
       - "InvalidClientTokenId: The security token included in the request is invalid."
       installFailingReason: InvalidCredentials
       installFailingMessage: Credentials are invalid
+      installFailingCategory: Credentials
+      retryable: false
+      remediation: Update the cloud credentials secret of the cluster deployment with valid credentials.
     # cf. GCPNoWorkerNodes
     - name: AWSNoWorkerNodes
       searchRegexStrings:
       - "(?s)terraform-provider-aws.*Got 0 worker nodes, \\d+ master nodes"
       installFailingReason: AWSNoWorkerNodes
       installFailingMessage: No worker nodes could be created. Check the machine-api logs.
+      installFailingCategory: Transient
+      remediation: Check the machine-api logs of the cluster if the failure happens again.
     - name: InvalidAWSTags
       searchRegexStrings:
       - "platform\\.aws\\.userTags.*: Invalid value:.*value contains invalid characters"
       installFailingReason: InvalidAWSTags
       installFailingMessage: You have specified an invalid AWS tag value. Verify that your tags meet AWS requirements and try again.
+      installFailingCategory: Configuration
+      retryable: false
+      remediation: Fix the AWS tags of the install config so that they meet the AWS requirements.
     - name: ErrorDeletingIAMRole
       searchRegexStrings:
         - "Error deleting IAM Role .* DeleteConflict: Cannot delete entity, must detach all policies first."
       installFailingReason: ErrorDeletingIAMRole
       installFailingMessage: The cluster installer was not able to delete the roles it used during the installation. Ensure that no policies are added to new roles by default and try again.
+      installFailingCategory: Credentials
+      retryable: false
+      remediation: Make sure that no policies are attached to new IAM roles by default.
     - name: AWSSubnetDoesNotExist
       searchRegexStrings:
       - "The subnet ID .* does not exist"
       installFailingReason: AWSSubnetDoesNotExist
       installFailingMessage: AWS Subnet Does Not Exist
+      installFailingCategory: Network
+      retryable: false
+      remediation: Make sure that the subnets of the install config exist in the region of the cluster.
     # iam:CreateServiceLinkedRole is a super powerful permission that we don't give to STS clusters. We require it's done as a one-time prereq.
     # This is the error we see when the prereq step was missed.
     - name: NATGatewayFailed 
@@ -1716,48 +1777,65 @@ data:
       - "Error waiting for NAT Gateway (.*) to become available"
       installFailingReason: NATGatewayFailed
       installFailingMessage: Error waiting for NAT Gateway to become available.
+      installFailingCategory: Transient
     - name: AWSAccessDeniedSLR
       searchRegexStrings:
       - "Error creating network Load Balancer: AccessDenied.*iam:CreateServiceLinkedRole"
       installFailingReason: AWSAccessDeniedSLR
       installFailingMessage: Missing prerequisite service role for load balancer
+      installFailingCategory: Credentials
+      retryable: false
+      remediation: Create the Elastic Load Balancing service-linked role in the AWS account.
     - name: AWSInsufficientPermissions
       searchRegexStrings:
       - "current credentials insufficient for performing cluster installation"
       installFailingReason: AWSInsufficientPermissions
       installFailingMessage: AWS credentials are insufficient for performing cluster installation
+      installFailingCategory: Credentials
+      retryable: false
+      remediation: Grant the permissions required to install a cluster to the AWS credentials of the cluster deployment.
     - name: VcpuLimitExceeded
       searchRegexStrings:
       - "VcpuLimitExceeded"
       installFailingReason: VcpuLimitExceeded
       installFailingMessage: The install requires more vCPU capacity than your current vCPU limit
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Request an increase of the vCPU limit of the AWS account for the region.
     - name: UserInitiatedShutdown
       searchRegexStrings:
       - "Error waiting for instance .* to become ready .* User initiated shutdown"
       installFailingReason: UserInitiatedShutdown
       installFailingMessage: User initiated shutdown of instances as the install was running
+      installFailingCategory: Transient
     # openshift-installer intermittent failure on AWS with Error: Provider produced inconsistent result after apply
     - name: InconsistentTerraformResult
       searchRegexStrings:
       - "Error: Provider produced inconsistent result after apply"
       installFailingReason: InconsistentTerraformResult
       installFailingMessage: Inconsistent result after Terraform apply
+      installFailingCategory: Transient
     - name: AWSVPCDoesNotExist
       searchRegexStrings:
       - "The vpc ID .* does not exist"
       installFailingReason: AWSVPCDoesNotExist
       installFailingMessage: The AWS VPC does not exist
+      installFailingCategory: Network
+      retryable: false
+      remediation: Make sure that the VPC of the subnets of the install config exists in the region of the cluster.
     - name: TargetGroupNotFound
     # https://bugzilla.redhat.com/show_bug.cgi?id=1898265
       searchRegexStrings:
       - "TargetGroupNotFound"
       installFailingReason: TargetGroupNotFound
       installFailingMessage: Target Group cannot be found
+      installFailingCategory: Transient
     - name: ErrorCreatingNetworkLoadBalancer
       searchRegexStrings:
       - "Error creating network Load Balancer: InternalFailure: "
       installFailingReason: ErrorCreatingNetworkLoadBalancer
       installFailingMessage: AWS network load balancer creation encountered an error during cluster installation
+      installFailingCategory: Transient
 
 
     # GCP Specific
@@ -1766,37 +1844,57 @@ data:
       - "platform.gcp.project.* invalid project ID"
       installFailingReason: GCPInvalidProjectID
       installFailingMessage: Invalid GCP project ID
+      installFailingCategory: Configuration
+      retryable: false
+      remediation: Set the project ID of the install config to an existing GCP project.
     - name: GCPInstanceTypeNotFound
       searchRegexStrings:
       - "platform.gcp.type: Invalid value:.* instance type.* not found]"
       installFailingReason: GCPInstanceTypeNotFound
       installFailingMessage: GCP instance type not found
+      installFailingCategory: Configuration
+      retryable: false
+      remediation: Use an instance type that is available in the region and zones of the cluster.
     - name: GCPPreconditionFailed
       searchRegexStrings:
       - "googleapi: Error 412"
       installFailingReason: GCPPreconditionFailed
       installFailingMessage: GCP Precondition Failed
+      installFailingCategory: Configuration
+      retryable: false
+      remediation: Check that the APIs required to install a cluster are enabled in the GCP project.
     - name: GCPQuotaSSDTotalGBExceeded
       searchRegexStrings:
       - "Quota \'SSD_TOTAL_GB\' exceeded"
       installFailingReason: GCPQuotaSSDTotalGBExceeded
       installFailingMessage: GCP quota SSD_TOTAL_GB exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Request an increase of the SSD_TOTAL_GB quota of the GCP project for the region.
     - name: GCPComputeQuota
       searchRegexStrings:
       - "compute\\.googleapis\\.com/cpus is not available in [a-z0-9-]* because the required number of resources \\([0-9]*\\) is more than"
       installFailingReason: GCPComputeQuotaExceeded
       installFailingMessage: GCP CPUs quota exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Request an increase of the CPUS quota of the GCP project for the region.
     - name: GCPServiceAccountQuota
       searchRegexStrings:
       - "iam\\.googleapis\\.com/quota/service-account-count is not available in global because the required number of resources \\([0-9]*\\) is more than remaining quota"
       installFailingReason: GCPServiceAccountQuotaExceeded
       installFailingMessage: GCP Service Account quota exceeded
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Delete unused service accounts or request an increase of the service account quota of the GCP project.
     # cf. AWSNoWorkerNodes
     - name: GCPNoWorkerNodes
       searchRegexStrings:
       - "(?s)terraform-provider-gcp.*Got 0 worker nodes, \\d+ master nodes"
       installFailingReason: GCPNoWorkerNodes
       installFailingMessage: No worker nodes could be created. Check the machine-api logs.
+      installFailingCategory: Transient
+      remediation: Check the machine-api logs of the cluster if the failure happens again.
 
     
     # Bare Metal
@@ -1805,11 +1903,16 @@ data:
       - "platform.baremetal.libvirtURI: Internal error: could not connect to libvirt: virError.Code=38, Domain=7, Message=.Cannot recv data: Permission denied"
       installFailingReason: LibvirtSSHKeyPermissionDenied
       installFailingMessage: "Permission denied connecting to libvirt host, check SSH key configuration and pass phrase"
+      installFailingCategory: Credentials
+      retryable: false
+      remediation: Check the SSH key used to connect to the libvirt host and its pass phrase.
     - name: LibvirtConnectionFailed
       searchRegexStrings:
       - "could not connect to libvirt"
       installFailingReason: LibvirtConnectionFailed
       installFailingMessage: "Could not connect to libvirt host"
+      installFailingCategory: Network
+      remediation: Check that the libvirt host is reachable.
 
 
     # Proxy-enabled clusters
@@ -1819,11 +1922,17 @@ data:
       - "error pinging docker registry .+ proxyconnect tcp: dial tcp [^ ]+: connect: connection refused"      
       installFailingReason: ProxyTimeout
       installFailingMessage: The cluster is installing via a proxy, however the proxy server is refusing or timing out connections. Verify that the proxy is running and would be accessible from the cluster's private subnet(s).
+      installFailingCategory: Network
+      retryable: false
+      remediation: "Verify that the proxy is running and accessible from the cluster's private network."
     - name: ProxyInvalidCABundle
       searchRegexStrings:
       - "error pinging docker registry .+ proxyconnect tcp: x509: certificate signed by unknown authority"
       installFailingReason: ProxyInvalidCABundle
       installFailingMessage: The cluster is installing via a proxy, but does not trust the signing certificate the proxy is presenting. Verify that the Certificate Authority certificate(s) to verify proxy communications have been supplied at installation time.
+      installFailingCategory: Network
+      retryable: false
+      remediation: Add the Certificate Authority certificates of the proxy to the additional trust bundle of the install config.
 
 
     # Generic OpenShift Install
@@ -1833,36 +1942,44 @@ data:
       - "Bootstrap failed to complete"
       installFailingReason: BootstrapFailed
       installFailingMessage: Installation Bootstrap failed to complete. Verify the networking configuration and account permissions and try again.
+      installFailingCategory: Network
+      remediation: Verify the networking configuration and account permissions if the failure happens again.
     - name: KubeAPIWaitTimeout
       searchRegexStrings:
       - "waiting for Kubernetes API: context deadline exceeded"
       installFailingReason: KubeAPIWaitTimeout
       installFailingMessage: Timeout waiting for the Kubernetes API to begin responding
+      installFailingCategory: Transient
     - name: MonitoringOperatorStillUpdating
       searchRegexStrings:
       - "failed to initialize the cluster: Cluster operator monitoring is still updating"
       installFailingReason: MonitoringOperatorStillUpdating
       installFailingMessage: Timeout waiting for the monitoring operator to become ready
+      installFailingCategory: Transient
     - name: AuthenticationOperatorDegraded
       searchRegexStrings:
       - "Cluster operator authentication Degraded is True"
       installFailingReason: AuthenticationOperatorDegraded
       installFailingMessage: Timeout waiting for the authentication operator to become ready
+      installFailingCategory: Transient
     - name: GeneralOperatorDegraded
       searchRegexStrings:
       - "Cluster operator.*Degraded is True"
       installFailingReason: GeneralOperatorDegraded
       installFailingMessage: Timeout waiting for an operator to become ready
+      installFailingCategory: Transient
     - name: GeneralClusterOperatorsStillUpdating
       searchRegexStrings:
       - "failed to initialize the cluster: Some cluster operators are still updating:"
       installFailingReason: GeneralClusterOperatorsStillUpdating
       installFailingMessage: Timeout waiting for all cluster operators to become ready
+      installFailingCategory: Transient
     - name: KubeAPIWaitFailed
       searchRegexStrings:
       - "Failed waiting for Kubernetes API. This error usually happens when there is a problem on the bootstrap host that prevents creating a temporary control plane"
       installFailingReason: KubeAPIWaitFailed
       installFailingMessage: Failed waiting for Kubernetes API. This error usually happens when there is a problem on the bootstrap host that prevents creating a temporary control plane
+      installFailingCategory: Transient
 
     # Keep these at the bottom so that they're only hit if nothing above matches.
     # We don't want to show these to users unless it's a last resort. It's barely better than "unknown error".
@@ -1872,21 +1989,31 @@ data:
       - "Quota '[A-Z_]*' exceeded"
       installFailingReason: FallbackQuotaExceeded
       installFailingMessage: Unknown quota exceeded - couldn't parse a specific resource type
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Check the quotas of the cloud account and request an increase of the exceeded quota.
     - name: FallbackResourceLimitExceeded
       searchRegexStrings:
       - "LimitExceeded"
       installFailingReason: FallbackResourceLimitExceeded
       installFailingMessage: Unknown resource limit exceeded - couldn't parse a specific resource type
+      installFailingCategory: Quota
+      retryable: false
+      remediation: Check the resource limits of the cloud account and request an increase of the exceeded limit.
     - name: FallbackInvalidInstallConfig
       searchRegexStrings:
       - "failed to load asset \\\"Install Config\\\""
       installFailingReason: FallbackInvalidInstallConfig
       installFailingMessage: Unknown error - installer failed to load install config
+      installFailingCategory: Configuration
+      retryable: false
+      remediation: Fix the install config of the cluster deployment.
     - name: FallbackInstancesFailedToBecomeReady
       searchRegexStrings:
       - "Error waiting for instance .* to become ready"
       installFailingReason: FallbackInstancesFailedToBecomeReady
       installFailingMessage: Unknown error - instances failed to become ready
+      installFailingCategory: Transient
 `)
 
 func configConfigmapsInstallLogRegexesConfigmapYamlBytes() ([]byte, error) {
@@ -93,6 +93,50 @@ var awsPrivateLinkConfigMapInfo = configMapInfo{
 	},
 }
 
+var gcpPrivateServiceConnectConfigMapInfo = configMapInfo{
+	name:                 "gcp-private-service-connect",
+	nameKey:              "gcp-private-service-connect",
+	mountPath:            "/data/gcp-private-service-connect-config",
+	envVar:               constants.GCPPrivateServiceConnectControllerConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.GCPPrivateServiceConnect, nil
+	},
+}
+
+var azurePrivateLinkConfigMapInfo = configMapInfo{
+	name:                 "azure-private-link",
+	nameKey:              "azure-private-link",
+	mountPath:            "/data/azure-private-link-config",
+	envVar:               constants.AzurePrivateLinkControllerConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.AzurePrivateLink, nil
+	},
+}
+
+var acmeIssuerConfigMapInfo = configMapInfo{
+	name:                 "acme-issuer",
+	nameKey:              "acme-issuer",
+	mountPath:            "/data/acme-issuer-config",
+	envVar:               constants.ACMEIssuerControllerConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.ACMEIssuer, nil
+	},
+}
+
+var adminKubeconfigRotationConfigMapInfo = configMapInfo{
+	name:                 "admin-kubeconfig-rotation",
+	nameKey:              "admin-kubeconfig-rotation",
+	mountPath:            "/data/admin-kubeconfig-rotation-config",
+	envVar:               constants.AdminKubeconfigRotationControllerConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.AdminKubeconfigRotation, nil
+	},
+}
+
 var failedProvisionConfigMapInfo = configMapInfo{
 	name:                 "hive-failed-provision-config",
 	nameKey:              "hive-failed-provision-config",
@@ -100,6 +144,9 @@ var failedProvisionConfigMapInfo = configMapInfo{
 	envVar:               constants.FailedProvisionConfigFileEnvVar,
 	volumeSourceOptional: true,
 	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		if err := utils.ValidateFailedProvisionConfig(&instance.Spec.FailedProvisionConfig); err != nil {
+			return nil, err
+		}
 		return &instance.Spec.FailedProvisionConfig, nil
 	},
 }
@@ -145,16 +145,20 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, managedDomainsConfigMapInfo, hiveContainer)
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, awsPrivateLinkConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, gcpPrivateServiceConnectConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, azurePrivateLinkConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, acmeIssuerConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, adminKubeconfigRotationConfigMapInfo, hiveContainer)
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, failedProvisionConfigMapInfo, hiveContainer)
 
 	// This triggers the clusterdeployment controller to copy the secret into the CD's namespace.
 	// It would be neat if it did that purely based on the FailedProvisionConfig ConfigMap, to
 	// which it does have access, but that code path is shared by other things that need the
 	// same copied secret.
-	if awsSpec := instance.Spec.FailedProvisionConfig.AWS; awsSpec != nil {
+	if secretRef := failedProvisionCredentialsSecretRef(&instance.Spec.FailedProvisionConfig); secretRef != nil {
 		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
 			Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
-			Value: awsSpec.CredentialsSecretRef.Name,
+			Value: secretRef.Name,
 		})
 	}
 
@@ -220,6 +224,15 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, tmpEnvVar)
 	}
 
+	if instance.Spec.DeprovisionInController != nil && *instance.Spec.DeprovisionInController {
+		hLog.Info("deprovision in controller enabled in hiveconfig")
+		tmpEnvVar := corev1.EnvVar{
+			Name:  constants.DeprovisionInControllerEnvVar,
+			Value: "true",
+		}
+		hiveContainer.Env = append(hiveContainer.Env, tmpEnvVar)
+	}
+
 	if instance.Spec.Backup.MinBackupPeriodSeconds != nil {
 		hLog.Infof("MinBackupPeriodSeconds specified.")
 		tmpEnvVar := corev1.EnvVar{
@@ -229,6 +242,14 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, tmpEnvVar)
 	}
 
+	if window := instance.Spec.CertificateExpiryWarningWindow; window != nil {
+		hLog.Infof("CertificateExpiryWarningWindow specified.")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.CertificateExpiryWarningWindowEnvVar,
+			Value: window.Duration.String(),
+		})
+	}
+
 	if instance.Spec.DeleteProtection == hivev1.DeleteProtectionEnabled {
 		hLog.Info("Delete Protection enabled")
 		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
@@ -443,6 +464,22 @@ func (r *ReconcileHiveConfig) includeGlobalPullSecret(hLog log.FieldLogger, h re
 	hiveContainer.Env = append(hiveContainer.Env, globalPullSecretEnvVar)
 }
 
+// failedProvisionCredentialsSecretRef returns the reference to the secret with the credentials used to upload the logs
+// of failed provisions, in the same order of precedence as the clusterdeployment controller picks the log uploader.
+func failedProvisionCredentialsSecretRef(config *hivev1.FailedProvisionConfig) *corev1.LocalObjectReference {
+	switch {
+	case config.AWS != nil:
+		return &config.AWS.CredentialsSecretRef
+	case config.GCP != nil:
+		return &config.GCP.CredentialsSecretRef
+	case config.Azure != nil:
+		return &config.Azure.CredentialsSecretRef
+	case config.S3Compatible != nil:
+		return &config.S3Compatible.CredentialsSecretRef
+	}
+	return nil
+}
+
 func (r *ReconcileHiveConfig) runningOnOpenShift(hLog log.FieldLogger) (bool, error) {
 	deploymentConfigGroupVersion := oappsv1.GroupVersion.String()
 	list, err := r.discoveryClient.ServerResourcesForGroupVersion(deploymentConfigGroupVersion)
@@ -0,0 +1,112 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"strings"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// Client is a wrapper object for actual oVirt libraries to allow for easier mocking/testing.
+type Client interface {
+	// ListVMs returns the VMs that have the given tag.
+	ListVMs(tag string) ([]*ovirtsdk.Vm, error)
+
+	// StartVM starts the VM with the given ID.
+	StartVM(id string) error
+
+	// ShutdownVM gracefully shuts down the VM with the given ID.
+	ShutdownVM(id string) error
+
+	// Close releases the connection to the oVirt engine.
+	Close() error
+}
+
+// config holds the oVirt engine access details as stored in the credentials secret.
+type config struct {
+	URL      string `yaml:"ovirt_url"`
+	Username string `yaml:"ovirt_username"`
+	Password string `yaml:"ovirt_password"`
+	Insecure bool   `yaml:"ovirt_insecure,omitempty"`
+	CABundle string `yaml:"ovirt_ca_bundle,omitempty"`
+}
+
+type ovirtClient struct {
+	connection *ovirtsdk.Connection
+}
+
+var _ Client = &ovirtClient{}
+
+// ListVMs returns the VMs that have the given tag.
+func (c *ovirtClient) ListVMs(tag string) ([]*ovirtsdk.Vm, error) {
+	resp, err := c.connection.SystemService().VmsService().List().Search(fmt.Sprintf("tag=%s", tag)).Send()
+	if err != nil {
+		return nil, err
+	}
+	vms, ok := resp.Vms()
+	if !ok {
+		return nil, nil
+	}
+	return vms.Slice(), nil
+}
+
+// StartVM starts the VM with the given ID.
+func (c *ovirtClient) StartVM(id string) error {
+	_, err := c.connection.SystemService().VmsService().VmService(id).Start().Send()
+	return err
+}
+
+// ShutdownVM gracefully shuts down the VM with the given ID.
+func (c *ovirtClient) ShutdownVM(id string) error {
+	_, err := c.connection.SystemService().VmsService().VmService(id).Shutdown().Send()
+	return err
+}
+
+// Close releases the connection to the oVirt engine.
+func (c *ovirtClient) Close() error {
+	return c.connection.Close()
+}
+
+// NewClientFromSecret creates our client wrapper object for interacting with oVirt. The engine URL and credentials
+// are read from the ovirt-config.yaml in the specified credentials secret. If a certificates secret is provided,
+// its contents are trusted in addition to any CA bundle in the config. The caller is responsible for closing
+// the client.
+func NewClientFromSecret(secret *corev1.Secret, certificatesSecret *corev1.Secret) (Client, error) {
+	configYAML, ok := secret.Data[constants.OvirtCredentialsName]
+	if !ok {
+		return nil, errors.Errorf("secret does not contain %q data", constants.OvirtCredentialsName)
+	}
+	cfg := &config{}
+	if err := yaml.Unmarshal(configYAML, cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to parse oVirt config")
+	}
+
+	caBundle := []string{cfg.CABundle}
+	if certificatesSecret != nil {
+		for _, data := range certificatesSecret.Data {
+			caBundle = append(caBundle, string(data))
+		}
+	}
+
+	connection, err := ovirtsdk.NewConnectionBuilder().
+		URL(cfg.URL).
+		Username(cfg.Username).
+		Password(cfg.Password).
+		CACert([]byte(strings.TrimSpace(strings.Join(caBundle, "\n")))).
+		Insecure(cfg.Insecure).
+		Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to the oVirt engine")
+	}
+	return &ovirtClient{
+		connection: connection,
+	}, nil
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./client.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockClient) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockClientMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockClient)(nil).Close))
+}
+
+// ListVMs mocks base method.
+func (m *MockClient) ListVMs(tag string) ([]*ovirtsdk.Vm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVMs", tag)
+	ret0, _ := ret[0].([]*ovirtsdk.Vm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVMs indicates an expected call of ListVMs.
+func (mr *MockClientMockRecorder) ListVMs(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVMs", reflect.TypeOf((*MockClient)(nil).ListVMs), tag)
+}
+
+// ShutdownVM mocks base method.
+func (m *MockClient) ShutdownVM(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShutdownVM", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShutdownVM indicates an expected call of ShutdownVM.
+func (mr *MockClientMockRecorder) ShutdownVM(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShutdownVM", reflect.TypeOf((*MockClient)(nil).ShutdownVM), id)
+}
+
+// StartVM mocks base method.
+func (m *MockClient) StartVM(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartVM", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartVM indicates an expected call of StartVM.
+func (mr *MockClientMockRecorder) StartVM(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVM", reflect.TypeOf((*MockClient)(nil).StartVM), id)
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/client-go/dynamic"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -198,6 +199,10 @@ const (
 func buildScheme() (*runtime.Scheme, error) {
 	scheme := runtime.NewScheme()
 
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
 	if err := machineapi.AddToScheme(scheme); err != nil {
 		return nil, err
 	}
@@ -209,6 +214,10 @@ func buildScheme() (*runtime.Scheme, error) {
 		return nil, err
 	}
 
+	if err := rbacv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
 	if err := openshiftapiv1.Install(scheme); err != nil {
 		return nil, err
 	}
@@ -296,7 +305,7 @@ func unadulteratedRESTConfig(c client.Client, cd *hivev1.ClusterDeployment) (*re
 	kubeconfigSecret := &corev1.Secret{}
 	if err := c.Get(
 		context.Background(),
-		client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name},
+		client.ObjectKey{Namespace: cd.Namespace, Name: AdminKubeconfigSecretName(cd)},
 		kubeconfigSecret,
 	); err != nil {
 		return nil, errors.Wrap(err, "could not get admin kubeconfig secret")
@@ -304,6 +313,16 @@ func unadulteratedRESTConfig(c client.Client, cd *hivev1.ClusterDeployment) (*re
 	return restConfigFromSecret(kubeconfigSecret)
 }
 
+// AdminKubeconfigSecretName returns the name of the secret with the kubeconfig used to connect to the remote cluster.
+// This is the service account kubeconfig created by the admin kubeconfig rotation controller if there is one, or the
+// admin kubeconfig generated by the installer otherwise.
+func AdminKubeconfigSecretName(cd *hivev1.ClusterDeployment) string {
+	if rotation := cd.Status.AdminKubeconfigRotation; rotation != nil && rotation.KubeconfigSecretRef.Name != "" {
+		return rotation.KubeconfigSecretRef.Name
+	}
+	return cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name
+}
+
 func restConfigFromSecret(kubeconfigSecret *corev1.Secret) (*rest.Config, error) {
 	kubeconfigData, ok := kubeconfigSecret.Data[constants.KubeconfigSecretKey]
 	if !ok {
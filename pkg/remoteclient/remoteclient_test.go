@@ -51,6 +51,28 @@ func Test_InitialURL(t *testing.T) {
 	assert.Equal(t, expected, actual, "unexpected API URL")
 }
 
+func Test_InitialURL_RotatedKubeconfig(t *testing.T) {
+	cd := testClusterDeployment()
+	cd.Status.AdminKubeconfigRotation = &hivev1.AdminKubeconfigRotationStatus{
+		KubeconfigSecretRef: corev1.LocalObjectReference{Name: "rotated-kubeconfig"},
+	}
+	kubeconfigSecret := testKubeconfigSecret(t)
+	kubeconfigSecret.Name = "rotated-kubeconfig"
+	c := fakeClient(cd, kubeconfigSecret)
+	actual, err := InitialURL(c, cd)
+	assert.NoError(t, err, "unexpected error getting API URL")
+	assert.Equal(t, apiURL, actual, "unexpected API URL")
+}
+
+func TestAdminKubeconfigSecretName(t *testing.T) {
+	cd := testClusterDeployment()
+	assert.Equal(t, testKubeconfigSecretName, AdminKubeconfigSecretName(cd), "unexpected secret name without rotation")
+	cd.Status.AdminKubeconfigRotation = &hivev1.AdminKubeconfigRotationStatus{
+		KubeconfigSecretRef: corev1.LocalObjectReference{Name: "rotated-kubeconfig"},
+	}
+	assert.Equal(t, "rotated-kubeconfig", AdminKubeconfigSecretName(cd), "unexpected secret name with rotation")
+}
+
 func Test_builder_RESTConfig(t *testing.T) {
 	cases := []struct {
 		name           string
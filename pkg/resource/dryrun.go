@@ -0,0 +1,176 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/jonboulle/clockwork"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	kresource "k8s.io/cli-runtime/pkg/resource"
+	kcmdapply "k8s.io/kubectl/pkg/cmd/apply"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	kutil "k8s.io/kubectl/pkg/util"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// redactedValue replaces the values of Secret data in dry-run diffs.
+const redactedValue = "REDACTED"
+
+// DryRun performs a server-side dry run of applying the given resource bytes to the target cluster using the given
+// apply behavior. It returns the result that the apply would have had. When the resource would be configured, it
+// also returns a JSON merge patch from the current state of the resource to the state it would have after the apply.
+// The forceConflicts flag only applies to the ServerSideApply behavior.
+func (r *helper) DryRun(obj []byte, applyBehavior hivev1.SyncSetApplyBehavior, forceConflicts bool) (ApplyResult, string, error) {
+	factory, err := r.getFactory("")
+	if err != nil {
+		r.logger.WithError(err).Error("failed to obtain factory for dry run")
+		return "", "", err
+	}
+	if applyBehavior == hivev1.ServerSideApplySyncSetApplyBehavior {
+		return r.dryRunServerSideApply(factory, obj, forceConflicts)
+	}
+	info, err := r.getResourceInternalInfo(factory, obj)
+	if err != nil {
+		return "", "", err
+	}
+	c, err := factory.DynamicClient()
+	if err != nil {
+		return "", "", err
+	}
+	sourceObj := info.Object.DeepCopyObject()
+	// Name may be empty if the object wants to use GenerateName, in which case a new object is always created.
+	if info.Name != "" {
+		err = info.Get()
+	}
+	if info.Name == "" || errors.IsNotFound(err) {
+		gvr := info.ResourceMapping().Resource
+		if _, err := c.Resource(gvr).Namespace(info.Namespace).Create(
+			context.TODO(),
+			sourceObj.(*unstructured.Unstructured),
+			metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}},
+		); err != nil {
+			r.logger.WithError(err).Warn("running the dry-run create failed")
+			return "", "", err
+		}
+		return CreatedApplyResult, "", nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if applyBehavior == hivev1.CreateOnlySyncSetApplyBehavior {
+		return UnchangedApplyResult, "", nil
+	}
+
+	// The Apply behavior records the last applied configuration on the resource, which is used to compute a three-way
+	// patch. The CreateOrUpdate behavior does not.
+	var modified []byte
+	if applyBehavior == hivev1.CreateOrUpdateSyncSetApplyBehavior {
+		modified, err = runtime.Encode(unstructured.UnstructuredJSONScheme, sourceObj)
+	} else {
+		modified, err = kutil.GetModifiedConfiguration(sourceObj, true, unstructured.UnstructuredJSONScheme)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	patcher := kcmdapply.Patcher{
+		Mapping:       info.Mapping,
+		Helper:        kresource.NewHelper(info.Client, info.Mapping).DryRun(true),
+		Overwrite:     true,
+		BackOff:       clockwork.NewRealClock(),
+		OpenapiSchema: r.openAPISchema,
+	}
+	errOut := &bytes.Buffer{}
+	patch, patchedObj, err := patcher.Patch(info.Object, modified, info.Source, info.Namespace, info.Name, errOut)
+	if err != nil {
+		r.logger.WithError(err).WithField("stderr", errOut.String()).Warn("running the dry-run patch failed")
+		return "", "", err
+	}
+	if string(patch) == "{}" {
+		return UnchangedApplyResult, "", nil
+	}
+	diff, err := dryRunDiff(info.Object, patchedObj)
+	if err != nil {
+		return "", "", err
+	}
+	return ConfiguredApplyResult, diff, nil
+}
+
+func (r *helper) dryRunServerSideApply(f cmdutil.Factory, obj []byte, force bool) (ApplyResult, string, error) {
+	result, current, applied, err := r.serverSideApply(f, obj, force, true)
+	if err != nil {
+		r.logger.WithError(err).Warn("running the dry-run server-side apply failed")
+		return "", "", err
+	}
+	if result != ConfiguredApplyResult {
+		return result, "", nil
+	}
+	diff, err := dryRunDiff(current, applied)
+	if err != nil {
+		return "", "", err
+	}
+	return result, diff, nil
+}
+
+// dryRunDiff creates a JSON merge patch from the current object to the patched object, ignoring the metadata that
+// the server or the apply itself maintain. The values in the data of Secrets are redacted, so that the diff only
+// shows which keys change.
+func dryRunDiff(current, patched runtime.Object) (string, error) {
+	currentJSON, err := dryRunDiffJSON(current)
+	if err != nil {
+		return "", err
+	}
+	patchedJSON, err := dryRunDiffJSON(patched)
+	if err != nil {
+		return "", err
+	}
+	diff, err := jsonpatch.CreateMergePatch(currentJSON, patchedJSON)
+	if err != nil {
+		return "", err
+	}
+	if gvk := patched.GetObjectKind().GroupVersionKind(); gvk.Group == "" && gvk.Kind == "Secret" {
+		return redactSecretDiff(diff)
+	}
+	return string(diff), nil
+}
+
+// redactSecretDiff replaces the values of the keys added or changed in the data and stringData of a Secret in the
+// given merge patch. Keys removed from the Secret keep their null value.
+func redactSecretDiff(diff []byte) (string, error) {
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(diff, &patch); err != nil {
+		return "", err
+	}
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := patch[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key, value := range data {
+			if value != nil {
+				data[key] = redactedValue
+			}
+		}
+	}
+	redacted, err := json.Marshal(patch)
+	return string(redacted), err
+}
+
+func dryRunDiffJSON(obj runtime.Object) ([]byte, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.DeepCopyObject())
+	if err != nil {
+		return nil, err
+	}
+	unstructured.RemoveNestedField(u, "metadata", "managedFields")
+	unstructured.RemoveNestedField(u, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(u, "metadata", "generation")
+	unstructured.RemoveNestedField(u, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	return json.Marshal(u)
+}
@@ -0,0 +1,74 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDryRunDiff(t *testing.T) {
+	cases := []struct {
+		name         string
+		current      map[string]interface{}
+		patched      map[string]interface{}
+		expectedDiff string
+	}{
+		{
+			name: "configmap",
+			current: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "foo", "resourceVersion": "1"},
+				"data":       map[string]interface{}{"foo": "bar", "baz": "qux"},
+			},
+			patched: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "foo", "resourceVersion": "2"},
+				"data":       map[string]interface{}{"foo": "new-bar", "baz": "qux"},
+			},
+			expectedDiff: `{"data":{"foo":"new-bar"}}`,
+		},
+		{
+			name: "secret",
+			current: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   map[string]interface{}{"name": "foo"},
+				"data":       map[string]interface{}{"changed": "b2xk", "removed": "b2xk", "unchanged": "c2FtZQ=="},
+			},
+			patched: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   map[string]interface{}{"name": "foo", "labels": map[string]interface{}{"foo": "bar"}},
+				"data":       map[string]interface{}{"changed": "bmV3", "added": "bmV3", "unchanged": "c2FtZQ=="},
+			},
+			expectedDiff: `{"data":{"added":"REDACTED","changed":"REDACTED","removed":null},"metadata":{"labels":{"foo":"bar"}}}`,
+		},
+		{
+			name: "secret string data",
+			current: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   map[string]interface{}{"name": "foo"},
+			},
+			patched: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   map[string]interface{}{"name": "foo"},
+				"stringData": map[string]interface{}{"password": "hunter2"},
+			},
+			expectedDiff: `{"stringData":{"password":"REDACTED"}}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := dryRunDiff(&unstructured.Unstructured{Object: tc.current}, &unstructured.Unstructured{Object: tc.patched})
+			require.NoError(t, err, "unexpected error")
+			assert.JSONEq(t, tc.expectedDiff, diff, "unexpected diff")
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 )
 
 // fakeHelper is a dummy implementation of the resource Helper that will never attempt to communicate with the server.
@@ -78,3 +80,12 @@ func (fakeHelper) Patch(name types.NamespacedName, kind, apiVersion string, patc
 func (fakeHelper) Delete(apiVersion, kind, namespace, name string) error {
 	return nil
 }
+
+func (r *fakeHelper) ServerSideApply(obj []byte, force bool) (ApplyResult, error) {
+	r.fakeApplySleep()
+	return ConfiguredApplyResult, nil
+}
+
+func (fakeHelper) DryRun(obj []byte, applyBehavior hivev1.SyncSetApplyBehavior, forceConflicts bool) (ApplyResult, string, error) {
+	return UnchangedApplyResult, "", nil
+}
@@ -37,6 +37,10 @@ type Helper interface {
 	// Patch invokes the kubectl patch command with the given resource, patch and patch type
 	Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error
 	Delete(apiVersion, kind, namespace, name string) error
+	// ServerSideApply applies the given resource bytes to the target cluster using server-side apply
+	ServerSideApply(obj []byte, force bool) (ApplyResult, error)
+	// DryRun performs a server-side dry run of applying the given resource bytes with the given apply behavior
+	DryRun(obj []byte, applyBehavior hivev1.SyncSetApplyBehavior, forceConflicts bool) (ApplyResult, string, error)
 }
 
 // helper contains configuration for apply and patch operations
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	v1 "github.com/openshift/hive/apis/hive/v1"
 	resource "github.com/openshift/hive/pkg/resource"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
@@ -140,6 +141,22 @@ func (mr *MockHelperMockRecorder) Delete(apiVersion, kind, namespace, name inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHelper)(nil).Delete), apiVersion, kind, namespace, name)
 }
 
+// DryRun mocks base method.
+func (m *MockHelper) DryRun(obj []byte, applyBehavior v1.SyncSetApplyBehavior, forceConflicts bool) (resource.ApplyResult, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRun", obj, applyBehavior, forceConflicts)
+	ret0, _ := ret[0].(resource.ApplyResult)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DryRun indicates an expected call of DryRun.
+func (mr *MockHelperMockRecorder) DryRun(obj, applyBehavior, forceConflicts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRun", reflect.TypeOf((*MockHelper)(nil).DryRun), obj, applyBehavior, forceConflicts)
+}
+
 // Info mocks base method.
 func (m *MockHelper) Info(obj []byte) (*resource.Info, error) {
 	m.ctrl.T.Helper()
@@ -168,3 +185,18 @@ func (mr *MockHelperMockRecorder) Patch(name, kind, apiVersion, patch, patchType
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockHelper)(nil).Patch), name, kind, apiVersion, patch, patchType)
 }
+
+// ServerSideApply mocks base method.
+func (m *MockHelper) ServerSideApply(obj []byte, force bool) (resource.ApplyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerSideApply", obj, force)
+	ret0, _ := ret[0].(resource.ApplyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServerSideApply indicates an expected call of ServerSideApply.
+func (mr *MockHelperMockRecorder) ServerSideApply(obj, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerSideApply", reflect.TypeOf((*MockHelper)(nil).ServerSideApply), obj, force)
+}
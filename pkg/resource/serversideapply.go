@@ -0,0 +1,83 @@
+package resource
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kresource "k8s.io/cli-runtime/pkg/resource"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ServerSideApply applies the given resource bytes to the target cluster using server-side apply with hive as the
+// field manager. When force is true, hive takes ownership of any fields that conflict with other field managers.
+func (r *helper) ServerSideApply(obj []byte, force bool) (ApplyResult, error) {
+	factory, err := r.getFactory("")
+	if err != nil {
+		r.logger.WithError(err).Error("failed to obtain factory for server-side apply")
+		return "", err
+	}
+	result, _, _, err := r.serverSideApply(factory, obj, force, false)
+	if err != nil {
+		r.logger.WithError(err).Warn("running the server-side apply failed")
+		return "", err
+	}
+	return result, nil
+}
+
+// serverSideApply sends the resource to the server as an apply patch. It returns the result of the apply along with
+// the current object, which is nil if the object does not exist yet, and the applied object.
+func (r *helper) serverSideApply(f cmdutil.Factory, obj []byte, force, dryRun bool) (ApplyResult, runtime.Object, runtime.Object, error) {
+	info, err := r.getResourceInternalInfo(f, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, info.Object)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	var current runtime.Object
+	switch err := info.Get(); {
+	case errors.IsNotFound(err):
+	case err != nil:
+		return "", nil, nil, err
+	default:
+		current = info.Object
+	}
+	applied, err := kresource.NewHelper(info.Client, info.Mapping).
+		DryRun(dryRun).
+		WithFieldManager(constants.HiveFieldManager).
+		Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &metav1.PatchOptions{Force: &force})
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if current == nil {
+		return CreatedApplyResult, nil, applied, nil
+	}
+	currentAccessor, err := meta.Accessor(current)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	appliedAccessor, err := meta.Accessor(applied)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	// The server does not persist an apply that makes no changes, so the resource version is left unchanged. During a
+	// dry run the resource version is never changed, so the objects have to be compared instead.
+	if dryRun {
+		diff, err := dryRunDiff(current, applied)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if diff == "{}" {
+			return UnchangedApplyResult, current, applied, nil
+		}
+	} else if currentAccessor.GetResourceVersion() == appliedAccessor.GetResourceVersion() {
+		return UnchangedApplyResult, current, applied, nil
+	}
+	return ConfiguredApplyResult, current, applied, nil
+}
@@ -0,0 +1,205 @@
+package resource
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testConfigMapPath = "/api/v1/namespaces/default/configmaps/foo"
+
+// fakeApplyServer is a minimal API server serving the discovery of config maps, and the get and apply patch of a
+// single config map.
+type fakeApplyServer struct {
+	// current is the config map on the server, or nil if it does not exist.
+	current *corev1.ConfigMap
+	// conflict makes an apply without force fail with a conflict.
+	conflict bool
+	// patches are the apply patch requests the server received.
+	patches []*http.Request
+	// patchBody is the body of the last apply patch request.
+	patchBody []byte
+}
+
+func (s *fakeApplyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/api":
+		writeJSON(w, http.StatusOK, &metav1.APIVersions{Versions: []string{"v1"}})
+	case r.URL.Path == "/apis":
+		writeJSON(w, http.StatusOK, &metav1.APIGroupList{})
+	case r.URL.Path == "/api/v1":
+		writeJSON(w, http.StatusOK, &metav1.APIResourceList{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{
+				Name:       "configmaps",
+				Namespaced: true,
+				Kind:       "ConfigMap",
+				Verbs:      metav1.Verbs{"get", "patch"},
+			}},
+		})
+	case r.URL.Path == testConfigMapPath && r.Method == http.MethodGet:
+		if s.current == nil {
+			writeJSON(w, http.StatusNotFound, &apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "foo").ErrStatus)
+			return
+		}
+		writeJSON(w, http.StatusOK, s.current)
+	case r.URL.Path == testConfigMapPath && r.Method == http.MethodPatch:
+		s.patches = append(s.patches, r)
+		s.patchBody, _ = ioutil.ReadAll(r.Body)
+		if s.conflict && r.URL.Query().Get("force") != "true" {
+			err := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "foo", nil)
+			writeJSON(w, http.StatusConflict, &err.ErrStatus)
+			return
+		}
+		applied := &corev1.ConfigMap{}
+		if err := json.Unmarshal(s.patchBody, applied); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		applied.ResourceVersion = "1"
+		if s.current != nil {
+			applied.ResourceVersion = s.current.ResourceVersion
+			if applied.Data["foo"] != s.current.Data["foo"] {
+				applied.ResourceVersion = "2"
+			}
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(obj)
+}
+
+func testApplyConfigMap(value string) []byte {
+	return []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo","namespace":"default"},"data":{"foo":"` + value + `"}}`)
+}
+
+func testServerConfigMap(value string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", ResourceVersion: "1"},
+		Data:       map[string]string{"foo": value},
+	}
+}
+
+func testServerSideApplyHelper(t *testing.T, server *httptest.Server) *helper {
+	r := &helper{
+		logger:     log.WithField("test", t.Name()),
+		cacheDir:   t.TempDir(),
+		restConfig: &rest.Config{Host: server.URL},
+	}
+	r.getFactory = r.getRESTConfigFactory
+	return r
+}
+
+func TestServerSideApply(t *testing.T) {
+	cases := []struct {
+		name           string
+		current        *corev1.ConfigMap
+		conflict       bool
+		force          bool
+		obj            []byte
+		expectedResult ApplyResult
+		expectConflict bool
+	}{
+		{
+			name:           "create",
+			obj:            testApplyConfigMap("bar"),
+			expectedResult: CreatedApplyResult,
+		},
+		{
+			name:           "configure",
+			current:        testServerConfigMap("bar"),
+			obj:            testApplyConfigMap("baz"),
+			expectedResult: ConfiguredApplyResult,
+		},
+		{
+			name:           "unchanged",
+			current:        testServerConfigMap("bar"),
+			obj:            testApplyConfigMap("bar"),
+			expectedResult: UnchangedApplyResult,
+		},
+		{
+			name:           "conflict",
+			current:        testServerConfigMap("bar"),
+			conflict:       true,
+			obj:            testApplyConfigMap("baz"),
+			expectConflict: true,
+		},
+		{
+			name:           "force conflicts",
+			current:        testServerConfigMap("bar"),
+			conflict:       true,
+			force:          true,
+			obj:            testApplyConfigMap("baz"),
+			expectedResult: ConfiguredApplyResult,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeServer := &fakeApplyServer{current: tc.current, conflict: tc.conflict}
+			server := httptest.NewServer(fakeServer)
+			defer server.Close()
+			r := testServerSideApplyHelper(t, server)
+
+			result, err := r.ServerSideApply(tc.obj, tc.force)
+
+			if tc.expectConflict {
+				assert.True(t, apierrors.IsConflict(err), "expected conflict error, got %v", err)
+			} else {
+				require.NoError(t, err, "unexpected error")
+				assert.Equal(t, tc.expectedResult, result, "unexpected apply result")
+			}
+			require.Len(t, fakeServer.patches, 1, "expected a single apply patch")
+			patch := fakeServer.patches[0]
+			assert.Equal(t, string(types.ApplyPatchType), patch.Header.Get("Content-Type"), "unexpected patch type")
+			assert.Equal(t, constants.HiveFieldManager, patch.URL.Query().Get("fieldManager"), "unexpected field manager")
+			expectedForce := "false"
+			if tc.force {
+				expectedForce = "true"
+			}
+			assert.Equal(t, expectedForce, patch.URL.Query().Get("force"), "unexpected force")
+			assert.Empty(t, patch.URL.Query().Get("dryRun"), "apply should not be a dry run")
+			assert.JSONEq(t, string(tc.obj), string(fakeServer.patchBody), "unexpected apply patch body")
+		})
+	}
+}
+
+func TestDryRunServerSideApply(t *testing.T) {
+	fakeServer := &fakeApplyServer{current: testServerConfigMap("bar")}
+	server := httptest.NewServer(fakeServer)
+	defer server.Close()
+	r := testServerSideApplyHelper(t, server)
+
+	result, diff, err := r.DryRun(testApplyConfigMap("baz"), hivev1.ServerSideApplySyncSetApplyBehavior, true)
+
+	require.NoError(t, err, "unexpected error")
+	assert.Equal(t, ConfiguredApplyResult, result, "unexpected apply result")
+	assert.JSONEq(t, `{"data":{"foo":"baz"}}`, diff, "unexpected diff")
+	require.Len(t, fakeServer.patches, 1, "expected a single apply patch")
+	patch := fakeServer.patches[0]
+	assert.Equal(t, constants.HiveFieldManager, patch.URL.Query().Get("fieldManager"), "unexpected field manager")
+	assert.Equal(t, "true", patch.URL.Query().Get("force"), "unexpected force")
+	assert.Equal(t, metav1.DryRunAll, patch.URL.Query().Get("dryRun"), "apply should be a dry run")
+}
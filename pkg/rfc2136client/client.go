@@ -0,0 +1,151 @@
+package rfc2136client
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	defaultPort    = "53"
+	defaultTimeout = 30 * time.Second
+	// tsigFudge is the permitted clock skew, in seconds, between hive and the DNS server for TSIG signatures.
+	tsigFudge = 300
+)
+
+// Client is a wrapper object for talking to a DNS server that accepts TSIG-authenticated RFC2136
+// dynamic updates and zone transfers.
+type Client interface {
+	// Query sends a query for the records of the given name and type to the DNS server.
+	Query(name string, rrType uint16) (*dns.Msg, error)
+
+	// Transfer returns all records of the zone using a zone transfer (AXFR).
+	Transfer(zone string) ([]dns.RR, error)
+
+	// Update sends a dynamic update of the zone that removes the RRsets with the name and type of
+	// each of the records in remove, and then adds the records in insert.
+	Update(zone string, remove []dns.RR, insert []dns.RR) error
+}
+
+type rfc2136Client struct {
+	nameserver string
+	keyName    string
+	secret     string
+	algorithm  string
+	timeout    time.Duration
+}
+
+// NewClientFromSecret creates our client wrapper object for the DNS server at the nameserver address,
+// authenticating with the TSIG key in the secret.
+func NewClientFromSecret(secret *corev1.Secret, nameserver string) (Client, error) {
+	keyName := strings.TrimSpace(string(secret.Data[constants.RFC2136TSIGKeyNameSecretKey]))
+	if keyName == "" {
+		return nil, fmt.Errorf("secret does not contain %q data", constants.RFC2136TSIGKeyNameSecretKey)
+	}
+	tsigSecret := strings.TrimSpace(string(secret.Data[constants.RFC2136TSIGSecretSecretKey]))
+	if tsigSecret == "" {
+		return nil, fmt.Errorf("secret does not contain %q data", constants.RFC2136TSIGSecretSecretKey)
+	}
+	algorithm, err := tsigAlgorithm(strings.TrimSpace(string(secret.Data[constants.RFC2136TSIGAlgorithmSecretKey])))
+	if err != nil {
+		return nil, err
+	}
+	if nameserver == "" {
+		return nil, errors.New("no nameserver specified")
+	}
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		nameserver = net.JoinHostPort(nameserver, defaultPort)
+	}
+	return &rfc2136Client{
+		nameserver: nameserver,
+		keyName:    dns.Fqdn(strings.ToLower(keyName)),
+		secret:     tsigSecret,
+		algorithm:  algorithm,
+		timeout:    defaultTimeout,
+	}, nil
+}
+
+// tsigAlgorithm returns the TSIG algorithm name for the given algorithm, which defaults to hmac-sha256.
+func tsigAlgorithm(algorithm string) (string, error) {
+	if algorithm == "" {
+		return dns.HmacSHA256, nil
+	}
+	algorithm = dns.Fqdn(strings.ToLower(algorithm))
+	switch algorithm {
+	case dns.HmacSHA1, dns.HmacSHA224, dns.HmacSHA256, dns.HmacSHA384, dns.HmacSHA512:
+		return algorithm, nil
+	}
+	return "", fmt.Errorf("unsupported TSIG algorithm %q", algorithm)
+}
+
+// Query implements Client.Query.
+func (c *rfc2136Client) Query(name string, rrType uint16) (*dns.Msg, error) {
+	m := &dns.Msg{}
+	m.SetQuestion(dns.Fqdn(name), rrType)
+	return c.exchange(m)
+}
+
+// Transfer implements Client.Transfer.
+func (c *rfc2136Client) Transfer(zone string) ([]dns.RR, error) {
+	m := &dns.Msg{}
+	m.SetAxfr(dns.Fqdn(zone))
+	m.SetTsig(c.keyName, c.algorithm, tsigFudge, time.Now().Unix())
+	t := &dns.Transfer{
+		DialTimeout:  c.timeout,
+		ReadTimeout:  c.timeout,
+		WriteTimeout: c.timeout,
+		TsigSecret:   map[string]string{c.keyName: c.secret},
+	}
+	envelopes, err := t.In(m, c.nameserver)
+	if err != nil {
+		return nil, errors.Wrapf(err, "zone transfer of %s failed", zone)
+	}
+	var records []dns.RR
+	for envelope := range envelopes {
+		if envelope.Error != nil {
+			return nil, errors.Wrapf(envelope.Error, "zone transfer of %s failed", zone)
+		}
+		records = append(records, envelope.RR...)
+	}
+	return records, nil
+}
+
+// Update implements Client.Update.
+func (c *rfc2136Client) Update(zone string, remove []dns.RR, insert []dns.RR) error {
+	m := &dns.Msg{}
+	m.SetUpdate(dns.Fqdn(zone))
+	if len(remove) > 0 {
+		m.RemoveRRset(remove)
+	}
+	if len(insert) > 0 {
+		m.Insert(insert)
+	}
+	resp, err := c.exchange(m)
+	if err != nil {
+		return errors.Wrapf(err, "update of zone %s failed", zone)
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("update of zone %s failed: %s", zone, dns.RcodeToString[resp.Rcode])
+	}
+	return nil
+}
+
+// exchange signs the message with the TSIG key and sends it to the DNS server over TCP, so that large
+// responses are not truncated.
+func (c *rfc2136Client) exchange(m *dns.Msg) (*dns.Msg, error) {
+	m.SetTsig(c.keyName, c.algorithm, tsigFudge, time.Now().Unix())
+	client := &dns.Client{
+		Net:        "tcp",
+		Timeout:    c.timeout,
+		TsigSecret: map[string]string{c.keyName: c.secret},
+	}
+	resp, _, err := client.Exchange(m, c.nameserver)
+	return resp, err
+}
@@ -200,6 +200,22 @@ func WithHibernateAfter(dur time.Duration) Option {
 	}
 }
 
+func WithHibernationSchedule(resume, hibernate, timeZone string) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+			Resume:    resume,
+			Hibernate: hibernate,
+			TimeZone:  timeZone,
+		}
+	}
+}
+
+func WithHibernationScheduleStatus(status *hivev1.HibernationScheduleStatus) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Status.HibernationSchedule = status
+	}
+}
+
 // WithAWSPlatform sets the specified aws platform on the supplied object.
 func WithAWSPlatform(platform *hivev1aws.Platform) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
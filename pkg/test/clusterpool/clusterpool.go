@@ -184,3 +184,22 @@ func WithRunningCount(size int) Option {
 		clusterPool.Spec.RunningCount = int32(size)
 	}
 }
+
+func WithAutoscaling(minSize, maxSize, targetHeadroom int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+			MinSize:        int32(minSize),
+			MaxSize:        int32(maxSize),
+			TargetHeadroom: int32(targetHeadroom),
+		}
+	}
+}
+
+func WithAutoscaledSize(size int, lastScaleTime time.Time) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Status.Autoscaling = &hivev1.ClusterPoolAutoscalingStatus{
+			Size:          int32(size),
+			LastScaleTime: &metav1.Time{Time: lastScaleTime},
+		}
+	}
+}
@@ -147,6 +147,19 @@ func WithFailureReason(reason string) Option {
 	}
 }
 
+// WithFailureClassification fails the provision with the reason of the classification, and sets the classification.
+func WithFailureClassification(reason string, category hivev1.InstallFailureCategory, retryable bool, remediation string) Option {
+	return func(clusterProvision *hivev1.ClusterProvision) {
+		WithFailureReason(reason)(clusterProvision)
+		clusterProvision.Status.FailureClassification = &hivev1.InstallFailureClassification{
+			Reason:      reason,
+			Category:    category,
+			Retryable:   retryable,
+			Remediation: remediation,
+		}
+	}
+}
+
 func WithCreationTimestamp(time time.Time) Option {
 	return Generic(generic.WithCreationTimestamp(time))
 }
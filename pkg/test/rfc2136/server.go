@@ -0,0 +1,231 @@
+package rfc2136
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// KeyName is the name of the TSIG key accepted by the Server.
+	KeyName = "hive-test-key."
+	// Secret is the base64 encoded secret of the TSIG key accepted by the Server.
+	Secret = "aGl2ZS10ZXN0LXNlY3JldA=="
+)
+
+// Server is an in-process DNS server hosting zones in memory. It answers queries, and accepts zone
+// transfers and RFC2136 dynamic updates authenticated with the KeyName TSIG key.
+type Server struct {
+	// Addr is the TCP address the server listens on.
+	Addr string
+
+	server *dns.Server
+	mutex  sync.Mutex
+	zones  map[string][]dns.RR
+}
+
+// NewServer starts a Server hosting the given zones. Each zone gets an SOA record and NS records for
+// ns1 and ns2 in the zone.
+func NewServer(zones ...string) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		Addr:  listener.Addr().String(),
+		zones: map[string][]dns.RR{},
+	}
+	for _, zone := range zones {
+		zone = dns.Fqdn(zone)
+		s.zones[zone] = nil
+		s.AddRecords(
+			fmt.Sprintf("%s 3600 IN SOA ns1.%s hostmaster.%s 1 3600 600 86400 60", zone, zone, zone),
+			fmt.Sprintf("%s 3600 IN NS ns1.%s", zone, zone),
+			fmt.Sprintf("%s 3600 IN NS ns2.%s", zone, zone),
+		)
+	}
+
+	started := make(chan struct{})
+	s.server = &dns.Server{
+		Listener:          listener,
+		Net:               "tcp",
+		Handler:           s,
+		TsigSecret:        map[string]string{KeyName: Secret},
+		NotifyStartedFunc: func() { close(started) },
+		// The default accept func rejects dynamic updates.
+		MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go s.server.ActivateAndServe()
+	select {
+	case <-started:
+	case <-time.After(10 * time.Second):
+		listener.Close()
+		return nil, fmt.Errorf("timed out waiting for the DNS server to start")
+	}
+	return s, nil
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.server.Shutdown()
+}
+
+// AddRecords adds records, in zone file format, to the zones hosting them.
+func (s *Server) AddRecords(records ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			panic(fmt.Sprintf("invalid record %q: %v", record, err))
+		}
+		zone := s.zoneFor(rr.Header().Name)
+		if zone == "" {
+			panic(fmt.Sprintf("no zone hosts record %q", record))
+		}
+		s.addRecord(zone, rr)
+	}
+}
+
+// Records returns the records of the zone.
+func (s *Server) Records(zone string) []dns.RR {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]dns.RR(nil), s.zones[dns.Fqdn(zone)]...)
+}
+
+// ServeDNS implements dns.Handler.
+func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	m := &dns.Msg{}
+	m.SetReply(r)
+	signed := r.IsTsig() != nil
+	switch {
+	case signed && w.TsigStatus() != nil:
+		m.Rcode = dns.RcodeNotAuth
+	case len(r.Question) != 1:
+		m.Rcode = dns.RcodeFormatError
+	case r.Opcode == dns.OpcodeUpdate:
+		if !signed {
+			m.Rcode = dns.RcodeRefused
+			break
+		}
+		m.Rcode = s.update(r.Question[0].Name, r.Ns)
+	case r.Question[0].Qtype == dns.TypeAXFR:
+		records, ok := s.zones[strings.ToLower(r.Question[0].Name)]
+		if !signed || !ok {
+			m.Rcode = dns.RcodeRefused
+			break
+		}
+		// A transfer starts and ends with the SOA record.
+		m.Answer = append(append([]dns.RR{}, records...), records[0])
+	default:
+		s.query(m, r.Question[0])
+	}
+
+	if signed && w.TsigStatus() == nil {
+		tsig := r.IsTsig()
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsig.Fudge, time.Now().Unix())
+	}
+	w.WriteMsg(m)
+}
+
+func (s *Server) query(m *dns.Msg, question dns.Question) {
+	name := strings.ToLower(question.Name)
+	zone := s.zoneFor(name)
+	if zone == "" {
+		m.Rcode = dns.RcodeRefused
+		return
+	}
+	m.Authoritative = true
+	nameExists := false
+	for _, rr := range s.zones[zone] {
+		if strings.ToLower(rr.Header().Name) != name {
+			continue
+		}
+		nameExists = true
+		if rr.Header().Rrtype == question.Qtype || question.Qtype == dns.TypeANY {
+			m.Answer = append(m.Answer, rr)
+		}
+	}
+	if len(m.Answer) == 0 {
+		m.Ns = append(m.Ns, s.zones[zone][0])
+		if !nameExists {
+			m.Rcode = dns.RcodeNameError
+		}
+	}
+}
+
+func (s *Server) update(zone string, updates []dns.RR) int {
+	zone = strings.ToLower(zone)
+	if _, ok := s.zones[zone]; !ok {
+		return dns.RcodeNotAuth
+	}
+	for _, rr := range updates {
+		if s.zoneFor(rr.Header().Name) != zone {
+			return dns.RcodeNotZone
+		}
+	}
+	for _, rr := range updates {
+		header := rr.Header()
+		name := strings.ToLower(header.Name)
+		switch header.Class {
+		case dns.ClassANY:
+			s.removeRecords(zone, func(existing dns.RR) bool {
+				return strings.ToLower(existing.Header().Name) == name &&
+					(header.Rrtype == dns.TypeANY || existing.Header().Rrtype == header.Rrtype)
+			})
+		case dns.ClassNONE:
+			s.removeRecords(zone, func(existing dns.RR) bool {
+				candidate := dns.Copy(rr)
+				candidate.Header().Class = existing.Header().Class
+				candidate.Header().Ttl = existing.Header().Ttl
+				return dns.IsDuplicate(existing, candidate)
+			})
+		default:
+			s.addRecord(zone, rr)
+		}
+	}
+	soa := s.zones[zone][0].(*dns.SOA)
+	soa.Serial++
+	return dns.RcodeSuccess
+}
+
+// addRecord adds a record to the zone, unless the zone already has it.
+func (s *Server) addRecord(zone string, rr dns.RR) {
+	for _, existing := range s.zones[zone] {
+		if dns.IsDuplicate(existing, rr) {
+			return
+		}
+	}
+	s.zones[zone] = append(s.zones[zone], rr)
+}
+
+// removeRecords removes the records of the zone matching the filter, except for the SOA record.
+func (s *Server) removeRecords(zone string, filter func(dns.RR) bool) {
+	records := s.zones[zone][:1]
+	for _, rr := range s.zones[zone][1:] {
+		if !filter(rr) {
+			records = append(records, rr)
+		}
+	}
+	s.zones[zone] = records
+}
+
+// zoneFor returns the most specific zone hosting the name, or the empty string if no zone hosts it.
+func (s *Server) zoneFor(name string) string {
+	name = dns.Fqdn(strings.ToLower(name))
+	zone := ""
+	for z := range s.zones {
+		if dns.IsSubDomain(z, name) && len(z) > len(zone) {
+			zone = z
+		}
+	}
+	return zone
+}
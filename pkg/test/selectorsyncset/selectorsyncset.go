@@ -105,6 +105,36 @@ func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	}
 }
 
+func WithForceConflicts(forceConflicts bool) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.ForceConflicts = forceConflicts
+	}
+}
+
+func WithDryRun(dryRun bool) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.DryRun = dryRun
+	}
+}
+
+func WithEnableTemplates(enableTemplates bool) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.EnableTemplates = enableTemplates
+	}
+}
+
+func WithRollout(rollout *hivev1.SelectorSyncSetRollout) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.Rollout = rollout
+	}
+}
+
+func WithRolloutStatus(status *hivev1.SelectorSyncSetRolloutStatus) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Status.Rollout = status
+	}
+}
+
 func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
 		selectorSyncSet.Spec.Resources = make([]runtime.RawExtension, len(objs))
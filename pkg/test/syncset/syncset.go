@@ -107,6 +107,24 @@ func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	}
 }
 
+func WithForceConflicts(forceConflicts bool) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ForceConflicts = forceConflicts
+	}
+}
+
+func WithDryRun(dryRun bool) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.DryRun = dryRun
+	}
+}
+
+func WithEnableTemplates(enableTemplates bool) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.EnableTemplates = enableTemplates
+	}
+}
+
 func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	return func(syncSet *hivev1.SyncSet) {
 		syncSet.Spec.Resources = make([]runtime.RawExtension, len(objs))
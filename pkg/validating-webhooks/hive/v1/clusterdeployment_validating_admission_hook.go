@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -26,10 +28,15 @@ import (
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	hivecontractsv1alpha1 "github.com/openshift/hive/apis/hivecontracts/v1alpha1"
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/azureprivatelink"
+	"github.com/openshift/hive/pkg/controller/gcpprivateserviceconnect"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/util/contracts"
 )
@@ -44,17 +51,24 @@ const (
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "Platform.AgentBareMetal.AgentSelector"}
+	// gcpLabelKeyRegexp and gcpLabelValueRegexp match the characters that GCP allows in the keys and values of labels.
+	gcpLabelKeyRegexp   = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	gcpLabelValueRegexp = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "HibernationSchedule", "InstallAttemptsLimit", "ProvisionRetryPolicy", "Platform.AgentBareMetal.AgentSelector"}
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterDeploymentValidatingAdmissionHook struct {
 	decoder *admission.Decoder
 
-	validManagedDomains  []string
-	fs                   *featureSet
-	awsPrivateLinkConfig *hivev1.AWSPrivateLinkConfig
-	supportedContracts   contracts.SupportedContractImplementationsList
+	validManagedDomains            []string
+	managedDomains                 []hivev1.ManageDNSConfig
+	fs                             *featureSet
+	awsPrivateLinkConfig           *hivev1.AWSPrivateLinkConfig
+	gcpPrivateServiceConnectConfig *hivev1.GCPPrivateServiceConnectConfig
+	azurePrivateLinkConfig         *hivev1.AzurePrivateLinkConfig
+	supportedContracts             contracts.SupportedContractImplementationsList
 }
 
 // NewClusterDeploymentValidatingAdmissionHook constructs a new ClusterDeploymentValidatingAdmissionHook
@@ -74,6 +88,16 @@ func NewClusterDeploymentValidatingAdmissionHook(decoder *admission.Decoder) *Cl
 		logger.WithError(err).Fatal("Unable to read AWS Private Link Config file")
 	}
 
+	pscConfig, err := gcpprivateserviceconnect.ReadGCPPrivateServiceConnectControllerConfigFile()
+	if err != nil {
+		logger.WithError(err).Fatal("Unable to read GCP Private Service Connect Config file")
+	}
+
+	azplConfig, err := azureprivatelink.ReadAzurePrivateLinkControllerConfigFile()
+	if err != nil {
+		logger.WithError(err).Fatal("Unable to read Azure Private Link Config file")
+	}
+
 	supportContractsConfig, err := contracts.ReadSupportContractsFile()
 	if err != nil {
 		logger.WithError(err).Fatal("Unable to read Supported Contract Implementations file")
@@ -82,11 +106,14 @@ func NewClusterDeploymentValidatingAdmissionHook(decoder *admission.Decoder) *Cl
 
 	logger.WithField("managedDomains", domains).Info("Read managed domains")
 	return &ClusterDeploymentValidatingAdmissionHook{
-		decoder:              decoder,
-		validManagedDomains:  domains,
-		fs:                   newFeatureSet(),
-		awsPrivateLinkConfig: aplConfig,
-		supportedContracts:   supportContractsConfig,
+		decoder:                        decoder,
+		validManagedDomains:            domains,
+		managedDomains:                 managedDomains,
+		fs:                             newFeatureSet(),
+		awsPrivateLinkConfig:           aplConfig,
+		gcpPrivateServiceConnectConfig: pscConfig,
+		azurePrivateLinkConfig:         azplConfig,
+		supportedContracts:             supportContractsConfig,
 	}
 }
 
@@ -290,12 +317,23 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 	}
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath.Child("platform"), cd.Spec.Platform)...)
-	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec)...)
+	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec, a.managedDomains)...)
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), cd.Spec.HibernationSchedule)...)
+	allErrs = append(allErrs, validateHibernationScheduleOverride(cd)...)
+	allErrs = append(allErrs, validateProvisionRetryPolicy(specPath.Child("provisionRetryPolicy"), cd.Spec.ProvisionRetryPolicy)...)
 
 	if cd.Spec.Platform.AWS != nil {
 		allErrs = append(allErrs, validateAWSPrivateLink(specPath.Child("platform", "aws"), cd.Spec.Platform.AWS, a.awsPrivateLinkConfig)...)
 	}
 
+	if cd.Spec.Platform.GCP != nil {
+		allErrs = append(allErrs, validateGCPPrivateServiceConnect(specPath.Child("platform", "gcp"), cd.Spec.Platform.GCP, a.gcpPrivateServiceConnectConfig)...)
+	}
+
+	if cd.Spec.Platform.Azure != nil {
+		allErrs = append(allErrs, validateAzurePrivateLink(specPath.Child("platform", "azure"), cd.Spec.Platform.Azure, a.azurePrivateLinkConfig)...)
+	}
+
 	if cd.Spec.Provisioning != nil {
 		if cd.Spec.Provisioning.SSHPrivateKeySecretRef != nil && cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name == "" {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning", "sshPrivateKeySecretRef", "name"), "must specify a name for the ssh private key secret if the ssh private key secret is specified"))
@@ -367,6 +405,70 @@ func validateAWSPrivateLink(path *field.Path, platform *hivev1aws.Platform, conf
 	return allErrs
 }
 
+func validateGCPPrivateServiceConnect(path *field.Path, platform *hivev1gcp.Platform, config *hivev1.GCPPrivateServiceConnectConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	psc := platform.PrivateServiceConnect
+
+	if psc == nil || !psc.Enabled {
+		return allErrs
+	}
+
+	if config == nil || len(config.EndpointVPCInventory) == 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("privateServiceConnect", "enabled"), "GCP Private Service Connect is not supported in the environment"))
+		return allErrs
+	}
+
+	supportedRegions := sets.NewString()
+	for _, inv := range config.EndpointVPCInventory {
+		for _, subnet := range inv.Subnets {
+			supportedRegions.Insert(subnet.Region)
+		}
+	}
+	if !supportedRegions.Has(platform.Region) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("privateServiceConnect", "enabled"),
+			fmt.Sprintf("GCP Private Service Connect is not supported in %s region", platform.Region)))
+	}
+
+	if subnet := psc.ServiceAttachmentSubnet; subnet != nil {
+		subnetPath := path.Child("privateServiceConnect", "serviceAttachmentSubnet")
+		if subnet.CIDR != "" && subnet.Existing != "" {
+			allErrs = append(allErrs, field.Invalid(subnetPath.Child("cidr"), subnet.CIDR, "cidr cannot be set along with existing"))
+		}
+		if subnet.CIDR != "" {
+			if _, _, err := net.ParseCIDR(subnet.CIDR); err != nil {
+				allErrs = append(allErrs, field.Invalid(subnetPath.Child("cidr"), subnet.CIDR, err.Error()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateAzurePrivateLink(path *field.Path, platform *hivev1azure.Platform, config *hivev1.AzurePrivateLinkConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	pl := platform.PrivateLink
+
+	if pl == nil || !pl.Enabled {
+		return allErrs
+	}
+
+	if config == nil || len(config.EndpointVNetInventory) == 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("privateLink", "enabled"), "Azure Private Link is not supported in the environment"))
+		return allErrs
+	}
+
+	supportedRegions := sets.NewString()
+	for _, inv := range config.EndpointVNetInventory {
+		supportedRegions.Insert(strings.ToLower(inv.Region))
+	}
+	if !supportedRegions.Has(strings.ToLower(platform.Region)) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("privateLink", "enabled"),
+			fmt.Sprintf("Azure Private Link is not supported in %s region", platform.Region)))
+	}
+
+	return allErrs
+}
+
 /* TODO: move to explicit validation for AgentClusterInstall */
 /*
 func validateAgentInstallStrategy(specPath *field.Path, cd *hivev1.ClusterDeployment) field.ErrorList {
@@ -466,6 +568,7 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if azure.BaseDomainResourceGroupName == "" {
 			allErrs = append(allErrs, field.Required(azurePath.Child("baseDomainResourceGroupName"), "must specify the Azure resource group for the base domain"))
 		}
+		allErrs = append(allErrs, validateAzureUserTags(azurePath.Child("userTags"), azure.UserTags)...)
 	}
 	if gcp := platform.GCP; gcp != nil {
 		numberOfPlatforms++
@@ -476,6 +579,7 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if gcp.Region == "" {
 			allErrs = append(allErrs, field.Required(gcpPath.Child("region"), "must specify GCP region"))
 		}
+		allErrs = append(allErrs, validateGCPUserLabels(gcpPath.Child("userLabels"), gcp.UserLabels)...)
 	}
 	if openstack := platform.OpenStack; openstack != nil {
 		numberOfPlatforms++
@@ -553,7 +657,102 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 	return allErrs
 }
 
-func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+func validateGCPUserLabels(path *field.Path, labels map[string]string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for k, v := range labels {
+		if !gcpLabelKeyRegexp.MatchString(k) {
+			allErrs = append(allErrs, field.Invalid(path, k, "label keys must start with a lowercase letter and contain only lowercase letters, digits, underscores and dashes, up to 63 characters"))
+		}
+		if strings.HasPrefix(k, "kubernetes-io") {
+			allErrs = append(allErrs, field.Invalid(path, k, "label keys must not start with kubernetes-io"))
+		}
+		if !gcpLabelValueRegexp.MatchString(v) {
+			allErrs = append(allErrs, field.Invalid(path.Key(k), v, "label values must contain only lowercase letters, digits, underscores and dashes, up to 63 characters"))
+		}
+	}
+	return allErrs
+}
+
+func validateAzureUserTags(path *field.Path, tags map[string]string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for k, v := range tags {
+		switch {
+		case k == "" || len(k) > 512:
+			allErrs = append(allErrs, field.Invalid(path, k, "tag keys must be between 1 and 512 characters"))
+		case strings.ContainsAny(k, `<>%&\?/`):
+			allErrs = append(allErrs, field.Invalid(path, k, `tag keys must not contain any of <>%&\?/`))
+		case strings.HasPrefix(strings.ToLower(k), "kubernetes.io"):
+			allErrs = append(allErrs, field.Invalid(path, k, "tag keys must not start with kubernetes.io"))
+		}
+		if len(v) > 256 {
+			allErrs = append(allErrs, field.Invalid(path.Key(k), v, "tag values must be at most 256 characters"))
+		}
+	}
+	return allErrs
+}
+
+func validateHibernationSchedule(path *field.Path, schedule *hivev1.HibernationSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if schedule == nil {
+		return allErrs
+	}
+	if schedule.Resume == "" {
+		allErrs = append(allErrs, field.Required(path.Child("resume"), "must specify when to resume the cluster"))
+	}
+	if schedule.Hibernate == "" {
+		allErrs = append(allErrs, field.Required(path.Child("hibernate"), "must specify when to hibernate the cluster"))
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+	if _, _, err := controllerutils.ParseHibernationSchedule(schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(path, schedule, err.Error()))
+	}
+	return allErrs
+}
+
+func validateProvisionRetryPolicy(path *field.Path, policy *hivev1.ProvisionRetryPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy == nil {
+		return allErrs
+	}
+	if policy.InitialBackoff != nil && policy.InitialBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("initialBackoff"), policy.InitialBackoff.Duration.String(), "must be positive"))
+	}
+	if policy.MaxBackoff != nil && policy.MaxBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxBackoff"), policy.MaxBackoff.Duration.String(), "must be positive"))
+	}
+	if policy.BackoffFactor != nil && *policy.BackoffFactor < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("backoffFactor"), *policy.BackoffFactor, "must be at least 1"))
+	}
+	names := sets.NewString()
+	for i, override := range policy.InstallConfigOverrides {
+		overridePath := path.Child("installConfigOverrides").Index(i)
+		switch {
+		case override.Name == "":
+			allErrs = append(allErrs, field.Required(overridePath.Child("name"), "must specify a name for the override"))
+		case names.Has(override.Name):
+			allErrs = append(allErrs, field.Duplicate(overridePath.Child("name"), override.Name))
+		}
+		names.Insert(override.Name)
+		patch := map[string]interface{}{}
+		if err := json.Unmarshal(override.Patch.Raw, &patch); err != nil {
+			allErrs = append(allErrs, field.Invalid(overridePath.Child("patch"), string(override.Patch.Raw), "must be a JSON merge patch object"))
+		}
+	}
+	return allErrs
+}
+
+func validateHibernationScheduleOverride(cd *hivev1.ClusterDeployment) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, err := controllerutils.HibernationScheduleOverriddenUntil(cd); err != nil {
+		path := field.NewPath("metadata", "annotations").Key(constants.HibernationScheduleOverrideUntilAnnotation)
+		allErrs = append(allErrs, field.Invalid(path, cd.Annotations[constants.HibernationScheduleOverrideUntilAnnotation], "must be an RFC3339 timestamp"))
+	}
+	return allErrs
+}
+
+func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.ClusterDeploymentSpec, managedDomains []hivev1.ManageDNSConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 	canManageDNS := false
 	if spec.Platform.AWS != nil {
@@ -565,6 +764,10 @@ func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.Cl
 	if spec.Platform.GCP != nil {
 		canManageDNS = true
 	}
+	// Clusters on other platforms can use a zone on the RFC2136 DNS server hosting their managed domain.
+	if manageddns.RFC2136ConfigForDomain(managedDomains, spec.BaseDomain) != nil {
+		canManageDNS = true
+	}
 	if !canManageDNS && spec.ManageDNS {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("manageDNS"), spec.ManageDNS, "cannot manage DNS for the selected platform"))
 	}
@@ -667,6 +870,10 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		}
 	}
 
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), cd.Spec.HibernationSchedule)...)
+	allErrs = append(allErrs, validateHibernationScheduleOverride(cd)...)
+	allErrs = append(allErrs, validateProvisionRetryPolicy(specPath.Child("provisionRetryPolicy"), cd.Spec.ProvisionRetryPolicy)...)
+
 	// Validate the ClusterPoolRef:
 	switch oldPoolRef, newPoolRef := oldObject.Spec.ClusterPoolRef, cd.Spec.ClusterPoolRef; {
 	case oldPoolRef != nil && newPoolRef != nil:
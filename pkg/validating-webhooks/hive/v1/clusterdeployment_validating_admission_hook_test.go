@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -13,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1agent "github.com/openshift/hive/apis/hive/v1/agent"
@@ -34,6 +36,21 @@ var validTestManagedDomains = []string{
 	"foo.aaa.com",
 	"bbb.com",
 	"ccc.com",
+	"rfc2136.com",
+}
+
+var testManagedDomains = []hivev1.ManageDNSConfig{
+	{
+		Domains: []string{"aaa.com", "foo.aaa.com", "bbb.com", "ccc.com"},
+		AWS:     &hivev1.ManageDNSAWSConfig{},
+	},
+	{
+		Domains: []string{"rfc2136.com"},
+		RFC2136: &hivev1.ManageDNSRFC2136Config{
+			Nameserver:    "ns1.rfc2136.com",
+			TSIGSecretRef: corev1.LocalObjectReference{Name: "rfc2136-tsig"},
+		},
+	},
 }
 
 func clusterDeploymentTemplate() *hivev1.ClusterDeployment {
@@ -226,6 +243,8 @@ func TestClusterDeploymentValidate(t *testing.T) {
 		gvr                 *metav1.GroupVersionResource
 		enabledFeatureGates []string
 		awsPrivateLink      *hivev1.AWSPrivateLinkConfig
+		gcpPSC              *hivev1.GCPPrivateServiceConnectConfig
+		azurePrivateLink    *hivev1.AzurePrivateLinkConfig
 		supportedContracts  contracts.SupportedContractImplementationsList
 	}{
 		{
@@ -472,6 +491,153 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
+		{
+			name: "Test create with hibernation schedule",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					Resume:    "0 8 * * 1-5",
+					Hibernate: "0 19 * * 1-5",
+					TimeZone:  "Europe/Berlin",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with invalid hibernation schedule",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					Resume:    "0 8 * * 1-5",
+					Hibernate: "0 25 * * 1-5",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with hibernation schedule missing resume",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					Hibernate: "0 19 * * 1-5",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with provision retry policy",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{
+					InitialBackoff: &metav1.Duration{Duration: 5 * time.Minute},
+					InstallConfigOverrides: []hivev1.InstallConfigOverride{
+						{Name: "zones", Patch: runtime.RawExtension{Raw: []byte(`{"compute":[{"name":"worker","platform":{"aws":{"zones":["us-east-1b"]}}}]}`)}},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with duplicate install config override names",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{
+					InstallConfigOverrides: []hivev1.InstallConfigOverride{
+						{Name: "zones", Patch: runtime.RawExtension{Raw: []byte(`{}`)}},
+						{Name: "zones", Patch: runtime.RawExtension{Raw: []byte(`{}`)}},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with install config override patch that is not an object",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{
+					InstallConfigOverrides: []hivev1.InstallConfigOverride{
+						{Name: "zones", Patch: runtime.RawExtension{Raw: []byte(`["us-east-1b"]`)}},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update adding provision retry policy",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ProvisionRetryPolicy = &hivev1.ProvisionRetryPolicy{
+					BackoffFactor: pointer.Int32Ptr(3),
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test update adding hibernation schedule",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					Resume:    "0 8 * * 1-5",
+					Hibernate: "0 19 * * 1-5",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test update with invalid hibernation schedule time zone",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					Resume:    "0 8 * * 1-5",
+					Hibernate: "0 19 * * 1-5",
+					TimeZone:  "Europe/Nowhere",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update with hibernation schedule override",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.HibernationScheduleOverrideUntilAnnotation: "2021-06-16T19:00:00Z"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test update with invalid hibernation schedule override",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.HibernationScheduleOverrideUntilAnnotation: "tomorrow"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name: "Test new clusterdeployment with missing SSH private key name",
 			newObject: func() *hivev1.ClusterDeployment {
@@ -546,6 +712,28 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test managed DNS is valid on OpenStack with RFC2136 managed domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validOpenStackClusterDeployment()
+				cd.Spec.ManageDNS = true
+				cd.Spec.BaseDomain = "bar.rfc2136.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test managed DNS is not valid on OpenStack without RFC2136 managed domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validOpenStackClusterDeployment()
+				cd.Spec.ManageDNS = true
+				cd.Spec.BaseDomain = "bar.aaa.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:      "Test allow modifying controlPlaneConfig",
 			oldObject: validAWSClusterDeployment(),
@@ -643,6 +831,36 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "Azure create with user tags",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.UserTags = map[string]string{"Cost Center": "1234"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Azure create with invalid user tag key",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.UserTags = map[string]string{"cost/center": "1234"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure create with reserved user tag key",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.UserTags = map[string]string{"kubernetes.io_cluster.foo": "owned"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name: "Azure create missing region",
 			newObject: func() *hivev1.ClusterDeployment {
@@ -906,6 +1124,36 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "GCP create with user labels",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.UserLabels = map[string]string{"cost-center": "1234"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "GCP create with invalid user label key",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.UserLabels = map[string]string{"CostCenter": "1234"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "GCP create with invalid user label value",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.UserLabels = map[string]string{"cost-center": "Finance"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name: "Provisioning is missing",
 			newObject: func() *hivev1.ClusterDeployment {
@@ -1070,6 +1318,137 @@ func TestClusterDeploymentValidate(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "private service connect enabled, no config",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "private service connect enabled, no inventory in the given region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+			gcpPSC: &hivev1.GCPPrivateServiceConnectConfig{
+				EndpointVPCInventory: []hivev1.GCPPrivateServiceConnectInventory{{
+					Network: "network",
+					Subnets: []hivev1.GCPPrivateServiceConnectSubnet{{Subnet: "subnet", Region: "some-region"}},
+				}},
+			},
+		},
+		{
+			name: "private service connect enabled, some inventory in given region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+			gcpPSC: &hivev1.GCPPrivateServiceConnectConfig{
+				EndpointVPCInventory: []hivev1.GCPPrivateServiceConnectInventory{{
+					Network: "network",
+					Subnets: []hivev1.GCPPrivateServiceConnectSubnet{
+						{Subnet: "subnet", Region: "some-region"},
+						{Subnet: "subnet-2", Region: "us-central1"},
+					},
+				}},
+			},
+		},
+		{
+			name: "private service connect enabled, invalid service attachment subnet cidr",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{
+					Enabled:                 true,
+					ServiceAttachmentSubnet: &hivev1gcp.ServiceAttachmentSubnet{CIDR: "192.168.0.0"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+			gcpPSC: &hivev1.GCPPrivateServiceConnectConfig{
+				EndpointVPCInventory: []hivev1.GCPPrivateServiceConnectInventory{{
+					Network: "network",
+					Subnets: []hivev1.GCPPrivateServiceConnectSubnet{{Subnet: "subnet", Region: "us-central1"}},
+				}},
+			},
+		},
+		{
+			name: "private service connect enabled, service attachment subnet cidr and existing",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{
+					Enabled: true,
+					ServiceAttachmentSubnet: &hivev1gcp.ServiceAttachmentSubnet{
+						CIDR:     "192.168.0.0/29",
+						Existing: "psc-subnet",
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+			gcpPSC: &hivev1.GCPPrivateServiceConnectConfig{
+				EndpointVPCInventory: []hivev1.GCPPrivateServiceConnectInventory{{
+					Network: "network",
+					Subnets: []hivev1.GCPPrivateServiceConnectSubnet{{Subnet: "subnet", Region: "us-central1"}},
+				}},
+			},
+		},
+		{
+			name: "azure private link enabled, no inventory",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.PrivateLink = &hivev1azure.PrivateLinkAccess{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "azure private link enabled, no inventory in the given region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.PrivateLink = &hivev1azure.PrivateLinkAccess{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+			azurePrivateLink: &hivev1.AzurePrivateLinkConfig{
+				EndpointVNetInventory: []hivev1.AzurePrivateLinkInventory{{
+					AzurePrivateLinkVNet: hivev1.AzurePrivateLinkVNet{VNetID: "vnet", Region: "some-region"},
+					Subnets:              []hivev1.AzurePrivateLinkSubnet{{Name: "subnet"}},
+				}},
+			},
+		},
+		{
+			name: "azure private link enabled, some inventory in given region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.PrivateLink = &hivev1azure.PrivateLinkAccess{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+			azurePrivateLink: &hivev1.AzurePrivateLinkConfig{
+				EndpointVNetInventory: []hivev1.AzurePrivateLinkInventory{{
+					AzurePrivateLinkVNet: hivev1.AzurePrivateLinkVNet{VNetID: "vnet", Region: "some-region"},
+					Subnets:              []hivev1.AzurePrivateLinkSubnet{{Name: "subnet"}},
+				}, {
+					AzurePrivateLinkVNet: hivev1.AzurePrivateLinkVNet{VNetID: "vnet-2", Region: "test-region"},
+					Subnets:              []hivev1.AzurePrivateLinkSubnet{{Name: "subnet"}},
+				}},
+			},
+		},
 		{
 			name:      "cd.spec.platform.agentBareMetal.agentSelector is a mutable field",
 			oldObject: validAgentBareMetalClusterDeployment(),
@@ -1094,13 +1473,16 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			data := ClusterDeploymentValidatingAdmissionHook{
 				decoder:             createDecoder(t),
 				validManagedDomains: validTestManagedDomains,
+				managedDomains:      testManagedDomains,
 				fs: &featureSet{
 					FeatureGatesEnabled: &hivev1.FeatureGatesEnabled{
 						Enabled: tc.enabledFeatureGates,
 					},
 				},
-				awsPrivateLinkConfig: tc.awsPrivateLink,
-				supportedContracts:   tc.supportedContracts,
+				awsPrivateLinkConfig:           tc.awsPrivateLink,
+				gcpPrivateServiceConnectConfig: tc.gcpPSC,
+				azurePrivateLinkConfig:         tc.azurePrivateLink,
+				supportedContracts:             tc.supportedContracts,
 			}
 
 			if tc.gvr == nil {
@@ -179,6 +179,13 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
 
+	if hc := newObject.Spec.HibernationConfig; hc != nil {
+		allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationConfig", "schedule"), hc.Schedule)...)
+	}
+
+	allErrs = append(allErrs, validateClusterPoolAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
+	allErrs = append(allErrs, validateProvisionRetryPolicy(specPath.Child("provisionRetryPolicy"), newObject.Spec.ProvisionRetryPolicy)...)
+
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
 		return &admissionv1beta1.AdmissionResponse{
@@ -239,6 +246,13 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
 
+	if hc := newObject.Spec.HibernationConfig; hc != nil {
+		allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationConfig", "schedule"), hc.Schedule)...)
+	}
+
+	allErrs = append(allErrs, validateClusterPoolAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
+	allErrs = append(allErrs, validateProvisionRetryPolicy(specPath.Child("provisionRetryPolicy"), newObject.Spec.ProvisionRetryPolicy)...)
+
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -254,3 +268,23 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 		Allowed: true,
 	}
 }
+
+func validateClusterPoolAutoscaling(path *field.Path, autoscaling *hivev1.ClusterPoolAutoscaling) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if autoscaling == nil {
+		return allErrs
+	}
+	if autoscaling.MaxSize < autoscaling.MinSize {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxSize"), autoscaling.MaxSize, "must not be less than minSize"))
+	}
+	if autoscaling.DemandWindow != nil && autoscaling.DemandWindow.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("demandWindow"), autoscaling.DemandWindow.Duration.String(), "must be positive"))
+	}
+	if autoscaling.TargetHeadroom < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("targetHeadroom"), autoscaling.TargetHeadroom, "must not be negative"))
+	}
+	if autoscaling.ScaleDownCooldown != nil && autoscaling.ScaleDownCooldown.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("scaleDownCooldown"), autoscaling.ScaleDownCooldown.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -216,6 +217,119 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "create with hibernation schedule",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationConfig = &hivev1.HibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{
+						Resume:    "0 8 * * 1-5",
+						Hibernate: "0 19 * * 1-5",
+						TimeZone:  "Europe/Berlin",
+					},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with invalid hibernation schedule",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationConfig = &hivev1.HibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{
+						Resume:    "at eight",
+						Hibernate: "0 19 * * 1-5",
+					},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with autoscaling",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MinSize:        1,
+					MaxSize:        5,
+					TargetHeadroom: 1,
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with autoscaling max size less than min size",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MinSize: 3,
+					MaxSize: 2,
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with zero autoscaling demand window",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MaxSize:      2,
+					DemandWindow: &metav1.Duration{},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with negative autoscaling target headroom",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MaxSize:        2,
+					TargetHeadroom: -1,
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with negative autoscaling scale down cooldown",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MaxSize:           2,
+					ScaleDownCooldown: &metav1.Duration{Duration: -time.Minute},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with zero autoscaling scale down cooldown",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MaxSize:           2,
+					ScaleDownCooldown: &metav1.Duration{},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
 		{
 			name:            "Test valid delete",
 			oldObject:       validAWSClusterPool(),
@@ -3,16 +3,21 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -225,7 +230,10 @@ func validateMachinePoolUpdate(old, new *hivev1.MachinePool) field.ErrorList {
 	specPath := field.NewPath("spec")
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.ClusterDeploymentRef, old.Spec.ClusterDeploymentRef, specPath.Child("clusterDeploymentRef"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Name, old.Spec.Name, specPath.Child("name"))...)
-	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Platform, old.Spec.Platform, specPath.Child("platform"))...)
+	// The platform can be changed when the outdated machines will be replaced by a rolling update.
+	if new.Spec.RollingUpdate == nil {
+		allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Platform, old.Spec.Platform, specPath.Child("platform"))...)
+	}
 	return allErrs
 }
 
@@ -332,9 +340,60 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 		}
 	}
 	allErrs = append(allErrs, metavalidation.ValidateLabels(spec.Labels, fldPath.Child("labels"))...)
+	if spec.RollingUpdate != nil {
+		allErrs = append(allErrs, validateMachinePoolRollingUpdate(spec, fldPath.Child("rollingUpdate"))...)
+	}
+	return allErrs
+}
+
+func validateMachinePoolRollingUpdate(spec *hivev1.MachinePoolSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	rollingUpdate := spec.RollingUpdate
+	maxSurgeIsZero := false
+	if rollingUpdate.MaxSurge != nil {
+		allErrs = append(allErrs, validateIntOrPercent(rollingUpdate.MaxSurge, fldPath.Child("maxSurge"))...)
+		maxSurgeIsZero = isZeroIntOrPercent(rollingUpdate.MaxSurge)
+	}
+	maxUnavailableIsZero := true
+	if rollingUpdate.MaxUnavailable != nil {
+		allErrs = append(allErrs, validateIntOrPercent(rollingUpdate.MaxUnavailable, fldPath.Child("maxUnavailable"))...)
+		maxUnavailableIsZero = isZeroIntOrPercent(rollingUpdate.MaxUnavailable)
+	}
+	if maxUnavailableIsZero {
+		switch {
+		case spec.Autoscaling != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxUnavailable"), rollingUpdate.MaxUnavailable, "must not be 0 when autoscaling is specified"))
+		case maxSurgeIsZero:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxUnavailable"), rollingUpdate.MaxUnavailable, "must not be 0 when maxSurge is 0"))
+		}
+	}
+	return allErrs
+}
+
+// validateIntOrPercent checks that the value is a non-negative integer or a percentage between 0% and 100%.
+func validateIntOrPercent(value *intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch value.Type {
+	case intstr.Int:
+		if value.IntVal < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath, value.IntVal, "must not be negative"))
+		}
+	case intstr.String:
+		percent, err := strconv.Atoi(strings.TrimSuffix(value.StrVal, "%"))
+		if !strings.HasSuffix(value.StrVal, "%") || err != nil || percent < 0 || percent > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath, value.StrVal, "must be an integer or a percentage between 0% and 100%"))
+		}
+	}
 	return allErrs
 }
 
+func isZeroIntOrPercent(value *intstr.IntOrString) bool {
+	if value.Type == intstr.Int {
+		return value.IntVal == 0
+	}
+	return value.StrVal == "0%"
+}
+
 func validateAWSMachinePoolPlatformInvariants(platform *hivev1aws.MachinePoolPlatform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, zone := range platform.Zones {
@@ -345,6 +404,16 @@ func validateAWSMachinePoolPlatformInvariants(platform *hivev1aws.MachinePoolPla
 	if platform.InstanceType == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("instanceType"), "instance type is required"))
 	}
+	instanceTypes := sets.NewString(platform.InstanceType)
+	for i, instanceType := range platform.FallbackInstanceTypes {
+		switch {
+		case instanceType == "":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("fallbackInstanceTypes").Index(i), instanceType, "instance type cannot be an empty string"))
+		case instanceTypes.Has(instanceType):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("fallbackInstanceTypes").Index(i), instanceType))
+		}
+		instanceTypes.Insert(instanceType)
+	}
 	rootVolume := &platform.EC2RootVolume
 	rootVolumePath := fldPath.Child("ec2RootVolume")
 	if rootVolume.IOPS < 0 {
@@ -387,6 +456,12 @@ func validateAzureMachinePoolPlatformInvariants(platform *hivev1azure.MachinePoo
 	if osDisk.DiskSizeGB <= 0 {
 		allErrs = append(allErrs, field.Invalid(osDiskPath.Child("iops"), osDisk.DiskSizeGB, "disk size must be positive"))
 	}
+	if spotVMOptions := platform.SpotVMOptions; spotVMOptions != nil && spotVMOptions.MaxPrice != nil {
+		maxPrice := spotVMOptions.MaxPrice
+		if maxPrice.Sign() <= 0 && maxPrice.Cmp(resource.MustParse("-1")) != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("spotVMOptions", "maxPrice"), maxPrice.String(), "max price must be positive or -1"))
+		}
+	}
 	return allErrs
 }
 
@@ -9,8 +9,10 @@ import (
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -380,6 +382,31 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "fallback AWS instance types",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.FallbackInstanceTypes = []string{"fallback-1", "fallback-2"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "empty fallback AWS instance type",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.FallbackInstanceTypes = []string{"fallback-1", ""}
+				return pool
+			}(),
+		},
+		{
+			name: "duplicate fallback AWS instance type",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.FallbackInstanceTypes = []string{"fallback-1", pool.Spec.Platform.AWS.InstanceType}
+				return pool
+			}(),
+		},
 		{
 			name: "non-default GCP pool",
 			provision: func() *hivev1.MachinePool {
@@ -447,6 +474,53 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "Azure spot VMs",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure spot VMs with max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				maxPrice := resource.MustParse("0.25")
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: &maxPrice}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure spot VMs capped at pay-as-you-go price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				maxPrice := resource.MustParse("-1")
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: &maxPrice}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "invalid Azure spot VM max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				maxPrice := resource.MustParse("0")
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: &maxPrice}
+				return pool
+			}(),
+		},
+		{
+			name: "GCP preemptible VMs",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.Preemptible = true
+				return pool
+			}(),
+			expectAllowed: true,
+		},
 		{
 			name: "valid labels",
 			provision: func() *hivev1.MachinePool {
@@ -489,6 +563,82 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 			}(),
 			expectAllowed: true,
 		},
+		{
+			name: "rolling update",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxSurge := intstr.FromString("25%")
+				maxUnavailable := intstr.FromInt(1)
+				pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "rolling update with defaults",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "rolling update with negative max surge",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxSurge := intstr.FromInt(-1)
+				pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{MaxSurge: &maxSurge}
+				return pool
+			}(),
+		},
+		{
+			name: "rolling update with invalid max unavailable percentage",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxUnavailable := intstr.FromString("150%")
+				pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{MaxUnavailable: &maxUnavailable}
+				return pool
+			}(),
+		},
+		{
+			name: "rolling update with zero max surge and max unavailable",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxSurge := intstr.FromString("0%")
+				pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{MaxSurge: &maxSurge}
+				return pool
+			}(),
+		},
+		{
+			name: "rolling update with autoscaling and zero max unavailable",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{
+					MinReplicas: 1,
+					MaxReplicas: 3,
+				}
+				pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{}
+				return pool
+			}(),
+		},
+		{
+			name: "rolling update with autoscaling",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{
+					MinReplicas: 1,
+					MaxReplicas: 3,
+				}
+				maxUnavailable := intstr.FromInt(1)
+				pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{MaxUnavailable: &maxUnavailable}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -592,6 +742,21 @@ func Test_MachinePoolAdmission_Validate_Update(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "instance type changed with rolling update",
+			old: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{}
+				return pool
+			}(),
+			new: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.RollingUpdate = &hivev1.MachinePoolRollingUpdate{}
+				pool.Spec.Platform.AWS.InstanceType = "other-instance-type"
+				return pool
+			}(),
+			expectAllowed: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
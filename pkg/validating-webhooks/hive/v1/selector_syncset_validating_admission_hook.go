@@ -8,6 +8,7 @@ import (
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
@@ -159,6 +160,9 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateCreate(admissionSpec *a
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateForceConflicts(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec", "forceConflicts"))...)
+	allErrs = append(allErrs, validateTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateRollout(newObject.Spec.Rollout, field.NewPath("spec", "rollout"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -206,6 +210,9 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateForceConflicts(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec", "forceConflicts"))...)
+	allErrs = append(allErrs, validateTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateRollout(newObject.Spec.Rollout, field.NewPath("spec", "rollout"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -222,3 +229,23 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 		Allowed: true,
 	}
 }
+
+func validateRollout(rollout *hivev1.SelectorSyncSetRollout, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if rollout == nil {
+		return allErrs
+	}
+	if rollout.CanarySelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(rollout.CanarySelector, fldPath.Child("canarySelector"))...)
+	}
+	if rollout.BatchPercent < 1 || rollout.BatchPercent > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("batchPercent"), rollout.BatchPercent, "must be between 1 and 100"))
+	}
+	if rollout.MaxFailurePercent < 0 || rollout.MaxFailurePercent > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxFailurePercent"), rollout.MaxFailurePercent, "must be between 0 and 100"))
+	}
+	if rollout.PauseBetweenBatches != nil && rollout.PauseBetweenBatches.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("pauseBetweenBatches"), rollout.PauseBetweenBatches.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
@@ -228,6 +228,63 @@ func TestSelectorSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid forceConflicts with ServerSideApply create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ApplyBehavior = hivev1.ServerSideApplySyncSetApplyBehavior
+				ss.Spec.ForceConflicts = true
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid forceConflicts without ServerSideApply update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ForceConflicts = true
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid rollout create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.Rollout = &hivev1.SelectorSyncSetRollout{
+					CanarySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+					BatchPercent:   25,
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid rollout batchPercent update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.Rollout = &hivev1.SelectorSyncSetRollout{}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid rollout canarySelector create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.Rollout = &hivev1.SelectorSyncSetRollout{
+					CanarySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "not valid!"}},
+					BatchPercent:   25,
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test invalid unmarshalable TypeMeta Resource create",
 			operation:       admissionv1beta1.Create,
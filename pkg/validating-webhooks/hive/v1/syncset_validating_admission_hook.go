@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
@@ -196,6 +197,8 @@ func (a *SyncSetValidatingAdmissionHook) validateCreate(admissionSpec *admission
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateForceConflicts(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec", "forceConflicts"))...)
+	allErrs = append(allErrs, validateTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -244,6 +247,8 @@ func (a *SyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateForceConflicts(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec", "forceConflicts"))...)
+	allErrs = append(allErrs, validateTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -280,6 +285,48 @@ func validateResourceApplyMode(resourceApplyMode hivev1.SyncSetResourceApplyMode
 	return allErrs
 }
 
+func validateForceConflicts(spec *hivev1.SyncSetCommonSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.ForceConflicts && spec.ApplyBehavior != hivev1.ServerSideApplySyncSetApplyBehavior {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.ForceConflicts, "forceConflicts may only be set when the applyBehavior is ServerSideApply"))
+	}
+	return allErrs
+}
+
+func validateTemplates(spec *hivev1.SyncSetCommonSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !spec.EnableTemplates {
+		return allErrs
+	}
+	for i, resource := range spec.Resources {
+		if err := controllerutils.ValidateSyncSetTemplateResource(resource.Raw); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("resources").Index(i), string(resource.Raw), err.Error()))
+		}
+	}
+	for i, secret := range spec.Secrets {
+		path := fldPath.Child("secretMappings").Index(i)
+		allErrs = append(allErrs, validateTemplate(secret.SourceRef.Namespace, path.Child("sourceRef", "namespace"))...)
+		allErrs = append(allErrs, validateTemplate(secret.SourceRef.Name, path.Child("sourceRef", "name"))...)
+		allErrs = append(allErrs, validateTemplate(secret.TargetRef.Namespace, path.Child("targetRef", "namespace"))...)
+		allErrs = append(allErrs, validateTemplate(secret.TargetRef.Name, path.Child("targetRef", "name"))...)
+	}
+	for i, patch := range spec.Patches {
+		path := fldPath.Child("patches").Index(i)
+		allErrs = append(allErrs, validateTemplate(patch.Namespace, path.Child("namespace"))...)
+		allErrs = append(allErrs, validateTemplate(patch.Name, path.Child("name"))...)
+		allErrs = append(allErrs, validateTemplate(patch.Patch, path.Child("patch"))...)
+	}
+	return allErrs
+}
+
+func validateTemplate(text string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if err := controllerutils.ValidateSyncSetTemplate(text); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, text, err.Error()))
+	}
+	return allErrs
+}
+
 func validateResources(resources []runtime.RawExtension, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, resource := range resources {
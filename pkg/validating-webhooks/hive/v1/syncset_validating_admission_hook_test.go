@@ -242,6 +242,80 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid forceConflicts with ServerSideApply create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyBehavior = hivev1.ServerSideApplySyncSetApplyBehavior
+				ss.Spec.ForceConflicts = true
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid forceConflicts without ServerSideApply create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyBehavior = hivev1.ApplySyncSetApplyBehavior
+				ss.Spec.ForceConflicts = true
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid forceConflicts without ServerSideApply update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ForceConflicts = true
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid templates create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{ .ClusterName }}-config"}}`)
+				ss.Spec.EnableTemplates = true
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{Name: "{{ .Name }}", PatchType: "merge", Patch: `{"data":{"region":"{{ .Region }}"}}`}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resource template update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{ .ClusterName"}}`)
+				ss.Spec.EnableTemplates = true
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid patch template create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.EnableTemplates = true
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{Name: "{{ end }}", PatchType: "merge"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid template ignored when templates are not enabled create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{Name: "{{ end }}", PatchType: "merge"}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
 		{
 			name:            "Test invalid unmarshalable Resource create",
 			operation:       admissionv1beta1.Create,
@@ -0,0 +1,157 @@
+package vsphereclient
+
+import (
+	"context"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// Client is a wrapper object for actual vSphere libraries to allow for easier mocking/testing.
+type Client interface {
+	// ListVirtualMachines returns the virtual machines attached to the given tag.
+	ListVirtualMachines(ctx context.Context, tag string) ([]mo.VirtualMachine, error)
+
+	// PowerOnVirtualMachine powers on the given virtual machine.
+	PowerOnVirtualMachine(ctx context.Context, vm mo.VirtualMachine) error
+
+	// PowerOffVirtualMachine shuts down the given virtual machine. A guest shutdown is used when VMware
+	// tools are running on the guest, otherwise the virtual machine is powered off.
+	PowerOffVirtualMachine(ctx context.Context, vm mo.VirtualMachine) error
+
+	// Close logs out of the vCenter sessions of the client.
+	Close(ctx context.Context) error
+}
+
+// virtualMachineProperties are the properties fetched for each virtual machine.
+var virtualMachineProperties = []string{"name", "runtime.powerState", "guest.toolsRunningStatus"}
+
+type vsphereClient struct {
+	vimClient  *vim25.Client
+	restClient *rest.Client
+}
+
+var _ Client = &vsphereClient{}
+
+// ListVirtualMachines returns the virtual machines attached to the given tag.
+func (c *vsphereClient) ListVirtualMachines(ctx context.Context, tag string) ([]mo.VirtualMachine, error) {
+	attached, err := tags.NewManager(c.restClient).ListAttachedObjects(ctx, tag)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list objects attached to tag %s", tag)
+	}
+	var refs []types.ManagedObjectReference
+	for _, obj := range attached {
+		if obj.Reference().Type == "VirtualMachine" {
+			refs = append(refs, obj.Reference())
+		}
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	var vms []mo.VirtualMachine
+	if err := property.DefaultCollector(c.vimClient).Retrieve(ctx, refs, virtualMachineProperties, &vms); err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve virtual machines")
+	}
+	return vms, nil
+}
+
+// PowerOnVirtualMachine powers on the given virtual machine.
+func (c *vsphereClient) PowerOnVirtualMachine(ctx context.Context, vm mo.VirtualMachine) error {
+	task, err := object.NewVirtualMachine(c.vimClient, vm.Reference()).PowerOn(ctx)
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+// PowerOffVirtualMachine shuts down the given virtual machine. A guest shutdown is used when VMware
+// tools are running on the guest, otherwise the virtual machine is powered off.
+func (c *vsphereClient) PowerOffVirtualMachine(ctx context.Context, vm mo.VirtualMachine) error {
+	obj := object.NewVirtualMachine(c.vimClient, vm.Reference())
+	if vm.Guest != nil && vm.Guest.ToolsRunningStatus == string(types.VirtualMachineToolsRunningStatusGuestToolsRunning) {
+		return obj.ShutdownGuest(ctx)
+	}
+	task, err := obj.PowerOff(ctx)
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+// Close logs out of the vCenter sessions of the client.
+func (c *vsphereClient) Close(ctx context.Context) error {
+	restErr := c.restClient.Logout(ctx)
+	if err := session.NewManager(c.vimClient).Logout(ctx); err != nil {
+		return errors.Wrap(err, "failed to log out of vSphere")
+	}
+	return errors.Wrap(restErr, "failed to log out of vSphere REST API")
+}
+
+// NewClientFromSecret creates our client wrapper object for interacting with vSphere. The username and password
+// are read from the credentials secret. If a certificates secret is provided, every key in it is treated as
+// a PEM bundle of CAs to trust when talking to the vCenter. The caller is responsible for closing the client.
+func NewClientFromSecret(ctx context.Context, vCenter string, secret *corev1.Secret, certificatesSecret *corev1.Secret) (Client, error) {
+	username, ok := secret.Data[constants.UsernameSecretKey]
+	if !ok {
+		return nil, errors.Errorf("secret does not contain %q data", constants.UsernameSecretKey)
+	}
+	password, ok := secret.Data[constants.PasswordSecretKey]
+	if !ok {
+		return nil, errors.Errorf("secret does not contain %q data", constants.PasswordSecretKey)
+	}
+
+	u, err := soap.ParseURL(vCenter)
+	if err != nil {
+		return nil, err
+	}
+	u.User = url.UserPassword(string(username), string(password))
+
+	// The high-level govmomi client doesn't allow us to set custom CAs early enough
+	// so the SOAP client is configured directly.
+	soapClient := soap.NewClient(u, false)
+	if certificatesSecret != nil && len(certificatesSecret.Data) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		for _, data := range certificatesSecret.Data {
+			rootCAs.AppendCertsFromPEM(data)
+		}
+		soapClient.DefaultTransport().TLSClientConfig.RootCAs = rootCAs
+	}
+
+	vimClient, err := vim25.NewClient(ctx, soapClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create vSphere client")
+	}
+	sessionManager := session.NewManager(vimClient)
+	if err := sessionManager.Login(ctx, u.User); err != nil {
+		return nil, errors.Wrap(err, "failed to log in to vSphere")
+	}
+	restClient := rest.NewClient(vimClient)
+	if err := restClient.Login(ctx, u.User); err != nil {
+		sessionManager.Logout(ctx)
+		return nil, errors.Wrap(err, "failed to log in to vSphere REST API")
+	}
+
+	return &vsphereClient{
+		vimClient:  vimClient,
+		restClient: restClient,
+	}, nil
+}